@@ -0,0 +1,194 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ClusterHealthCheckConditionTypePrefix is the prefix of the condition types with which the
+	// results of the health checks are reported on the InternalMemberCluster and MemberCluster objects;
+	// the name of the ClusterHealthCheck object follows the prefix.
+	ClusterHealthCheckConditionTypePrefix = "healthchecks.kubernetes-fleet.io/"
+
+	// ClusterHealthCheckPassedReason is the reason of a health check condition when the check passes.
+	ClusterHealthCheckPassedReason = "HealthCheckPassed"
+	// ClusterHealthCheckFailedReason is the reason of a health check condition when the check fails.
+	ClusterHealthCheckFailedReason = "HealthCheckFailed"
+	// ClusterHealthCheckFailedTaintReason is the reason of a health check condition when the check,
+	// which has TaintOnFailure set, fails; the hub cluster taints the MemberCluster when it sees
+	// a condition with this reason.
+	ClusterHealthCheckFailedTaintReason = "HealthCheckFailedTaintRequested"
+
+	// HealthCheckFailedTaintKey is the key of the taints that the hub cluster adds to a MemberCluster
+	// when its health checks with TaintOnFailure set fail; the value of a taint is the name of the
+	// failed ClusterHealthCheck object.
+	HealthCheckFailedTaintKey = "kubernetes-fleet.io/health-check-failed"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,categories={fleet,fleet-cluster},shortName=chc
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="Passed")].status`,name="Passed",type=string
+// +kubebuilder:printcolumn:JSONPath=`.spec.taintOnFailure`,name="Taint-On-Failure",type=boolean
+// +kubebuilder:printcolumn:JSONPath=`.metadata.creationTimestamp`,name="Age",type=date
+// +kubebuilder:validation:XValidation:rule="size(self.metadata.name) < 64",message="metadata.name max length is 63"
+
+// ClusterHealthCheck declares a health check of a member cluster, which the Fleet member agent runs
+// in addition to the readiness probe against the API server of the member cluster.
+//
+// ClusterHealthCheck objects are created in the member clusters (not the hub cluster); the member
+// agent runs the checks at each heartbeat, and reports the results as conditions in the status of
+// the MemberCluster object, with the type `healthchecks.kubernetes-fleet.io/<name of the check>`.
+// If TaintOnFailure is set, the hub cluster also taints the MemberCluster while the check fails, so
+// that the scheduler stops picking the member cluster for placements which do not tolerate the taint.
+// The name of a ClusterHealthCheck is at most 63 characters long, so that it fits in the taint value.
+type ClusterHealthCheck struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// The desired state of the ClusterHealthCheck.
+	// +required
+	Spec ClusterHealthCheckSpec `json:"spec"`
+
+	// The observed state of the ClusterHealthCheck.
+	// +optional
+	Status ClusterHealthCheckStatus `json:"status,omitempty"`
+}
+
+// ClusterHealthCheckSpec defines the desired state of the ClusterHealthCheck.
+// Exactly one of the probes must be set.
+// +kubebuilder:validation:XValidation:rule="(has(self.nodeReadiness) ? 1 : 0) + (has(self.deploymentAvailability) ? 1 : 0) + (has(self.dns) ? 1 : 0) + (has(self.http) ? 1 : 0) == 1",message="exactly one of nodeReadiness, deploymentAvailability, dns and http must be set"
+type ClusterHealthCheckSpec struct {
+	// NodeReadiness checks the ratio of the ready nodes.
+	// +optional
+	NodeReadiness *NodeReadinessProbe `json:"nodeReadiness,omitempty"`
+
+	// DeploymentAvailability checks if a deployment is available.
+	// +optional
+	DeploymentAvailability *DeploymentAvailabilityProbe `json:"deploymentAvailability,omitempty"`
+
+	// DNS checks if a host name can be resolved.
+	// +optional
+	DNS *DNSProbe `json:"dns,omitempty"`
+
+	// HTTP checks if an HTTP endpoint responds successfully.
+	// +optional
+	HTTP *HTTPProbe `json:"http,omitempty"`
+
+	// TimeoutSeconds is the number of seconds after which the check times out and fails.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=30
+	// +kubebuilder:default=5
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+
+	// TaintOnFailure, if set, asks the hub cluster to taint the MemberCluster with the
+	// `kubernetes-fleet.io/health-check-failed` taint (with the NoSchedule effect) while the check
+	// fails; the taint is removed once the check passes again.
+	// +optional
+	TaintOnFailure bool `json:"taintOnFailure,omitempty"`
+}
+
+// NodeReadinessProbe checks the ratio of the ready nodes.
+type NodeReadinessProbe struct {
+	// MinReadyPercentage is the minimum percentage of the selected nodes that must be ready for the
+	// check to pass.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:validation:Required
+	MinReadyPercentage int32 `json:"minReadyPercentage"`
+
+	// LabelSelector selects the nodes by their labels; if not set, all nodes are selected.
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+}
+
+// DeploymentAvailabilityProbe checks if a deployment is available, i.e., if its Available condition is true.
+type DeploymentAvailabilityProbe struct {
+	// Namespace is the namespace of the deployment.
+	// +kubebuilder:validation:Required
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the deployment.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+}
+
+// DNSProbe checks if a host name can be resolved with the DNS settings of the Fleet member agent,
+// which, by default, uses the cluster DNS.
+type DNSProbe struct {
+	// Hostname is the host name to resolve, e.g., `kubernetes.default.svc.cluster.local`.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=253
+	Hostname string `json:"hostname"`
+}
+
+// HTTPProbe checks if an HTTP endpoint responds with a status code in the range of [200, 400).
+type HTTPProbe struct {
+	// URL is the URL of the HTTP endpoint, e.g., `http://ingress-nginx-controller.ingress-nginx.svc/healthz`.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+}
+
+// ClusterHealthCheckStatus defines the observed state of the ClusterHealthCheck.
+type ClusterHealthCheckStatus struct {
+	// Conditions is an array of current observed conditions for the ClusterHealthCheck.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ClusterHealthCheckConditionType identifies a specific condition of the ClusterHealthCheck.
+type ClusterHealthCheckConditionType string
+
+const (
+	// ClusterHealthCheckConditionTypePassed indicates whether the health check has passed.
+	// Its condition status can be one of the following:
+	// - "True" means the health check has passed.
+	// - "False" means the health check has failed.
+	ClusterHealthCheckConditionTypePassed ClusterHealthCheckConditionType = "Passed"
+)
+
+// ClusterHealthCheckList contains a list of ClusterHealthCheck objects.
+// +kubebuilder:object:root=true
+type ClusterHealthCheckList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterHealthCheck `json:"items"`
+}
+
+// SetConditions sets the given conditions on the ClusterHealthCheck.
+func (c *ClusterHealthCheck) SetConditions(conditions ...metav1.Condition) {
+	for _, cond := range conditions {
+		meta.SetStatusCondition(&c.Status.Conditions, cond)
+	}
+}
+
+// GetCondition returns the condition of the given type on the ClusterHealthCheck.
+func (c *ClusterHealthCheck) GetCondition(conditionType string) *metav1.Condition {
+	return meta.FindStatusCondition(c.Status.Conditions, conditionType)
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterHealthCheck{}, &ClusterHealthCheckList{})
+}
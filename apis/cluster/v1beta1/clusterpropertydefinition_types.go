@@ -0,0 +1,217 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,categories={fleet,fleet-cluster},shortName=cpd
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:JSONPath=`.spec.propertyName`,name="Property",type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.value`,name="Value",type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="Evaluated")].status`,name="Evaluated",type=string
+// +kubebuilder:printcolumn:JSONPath=`.metadata.creationTimestamp`,name="Age",type=date
+
+// ClusterPropertyDefinition declares a custom property of a member cluster, which the Fleet member
+// agent computes from the objects in the member cluster.
+//
+// ClusterPropertyDefinition objects are created in the member clusters (not the hub cluster); the
+// member agent evaluates them each time it collects the cluster properties, and reports the values
+// along with the properties from the property provider in the status of the MemberCluster object,
+// so that they can be used in property selectors and property sorters. The properties from the
+// property provider (or the built-in ones when no property provider is set up) take precedence
+// over the custom properties of the same name.
+type ClusterPropertyDefinition struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// The desired state of the ClusterPropertyDefinition.
+	// +required
+	Spec ClusterPropertyDefinitionSpec `json:"spec"`
+
+	// The observed state of the ClusterPropertyDefinition.
+	// +optional
+	Status ClusterPropertyDefinitionStatus `json:"status,omitempty"`
+}
+
+// ClusterPropertyDefinitionSpec defines the desired state of the ClusterPropertyDefinition.
+// Exactly one of the sources must be set.
+// +kubebuilder:validation:XValidation:rule="(has(self.objectCount) ? 1 : 0) + (has(self.configMapValue) ? 1 : 0) + (has(self.nodeResourceSum) ? 1 : 0) + (has(self.cel) ? 1 : 0) == 1",message="exactly one of objectCount, configMapValue, nodeResourceSum and cel must be set"
+type ClusterPropertyDefinitionSpec struct {
+	// PropertyName is the name of the property, e.g., `example.com/gpu-node-count`.
+	//
+	// It follows the same format as the names in property selectors; names with the prefix
+	// `resources.kubernetes-fleet.io/`, which is reserved for the resource properties, are not allowed.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=317
+	// +kubebuilder:validation:XValidation:rule="!self.startsWith('resources.kubernetes-fleet.io/')",message="the prefix resources.kubernetes-fleet.io/ is reserved for the resource properties"
+	PropertyName string `json:"propertyName"`
+
+	// ObjectCount computes the property as the number of the selected objects.
+	// +optional
+	ObjectCount *ObjectSelector `json:"objectCount,omitempty"`
+
+	// ConfigMapValue computes the property as the value of a key in a ConfigMap.
+	// +optional
+	ConfigMapValue *ConfigMapValueSource `json:"configMapValue,omitempty"`
+
+	// NodeResourceSum computes the property as the sum of a resource of the selected nodes.
+	// +optional
+	NodeResourceSum *NodeResourceSumSource `json:"nodeResourceSum,omitempty"`
+
+	// CEL computes the property with a CEL expression over the selected objects.
+	// +optional
+	CEL *CELPropertySource `json:"cel,omitempty"`
+}
+
+// ObjectSelector selects the objects of a specific kind in the member cluster.
+type ObjectSelector struct {
+	// Group is the API group of the objects; leave it empty for the core API group.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Version is the API version of the objects.
+	// +kubebuilder:validation:Required
+	Version string `json:"version"`
+
+	// Kind is the kind of the objects.
+	// +kubebuilder:validation:Required
+	Kind string `json:"kind"`
+
+	// Namespace is the namespace of the objects; if not set, the objects in all namespaces are
+	// selected. It is ignored for cluster-scoped objects.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// LabelSelector selects the objects by their labels; if not set, all objects are selected.
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+}
+
+// ConfigMapValueSource specifies a key in a ConfigMap.
+type ConfigMapValueSource struct {
+	// Namespace is the namespace of the ConfigMap.
+	// +kubebuilder:validation:Required
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the ConfigMap.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Key is the key in the data of the ConfigMap whose value is reported as the property value.
+	// +kubebuilder:validation:Required
+	Key string `json:"key"`
+}
+
+// NodeResourceCapacityType is the type of the node resource capacity to sum up.
+// +enum
+type NodeResourceCapacityType string
+
+const (
+	// NodeResourceCapacityTypeCapacity sums up the total capacity of the resource.
+	NodeResourceCapacityTypeCapacity NodeResourceCapacityType = "Capacity"
+
+	// NodeResourceCapacityTypeAllocatable sums up the allocatable capacity of the resource.
+	NodeResourceCapacityTypeAllocatable NodeResourceCapacityType = "Allocatable"
+)
+
+// NodeResourceSumSource specifies a resource of nodes to sum up.
+type NodeResourceSumSource struct {
+	// ResourceName is the name of the resource, e.g., `nvidia.com/gpu`.
+	// +kubebuilder:validation:Required
+	ResourceName corev1.ResourceName `json:"resourceName"`
+
+	// CapacityType is the type of the resource capacity to sum up.
+	// +kubebuilder:validation:Enum=Capacity;Allocatable
+	// +kubebuilder:default=Allocatable
+	// +optional
+	CapacityType NodeResourceCapacityType `json:"capacityType,omitempty"`
+
+	// LabelSelector selects the nodes by their labels; if not set, all nodes are selected.
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+}
+
+// CELPropertySource specifies a CEL expression over the selected objects.
+type CELPropertySource struct {
+	// Objects selects the objects over which the expression is evaluated.
+	// +kubebuilder:validation:Required
+	Objects ObjectSelector `json:"objects"`
+
+	// Expression is the CEL expression which computes the property value; the selected objects are
+	// available as a list in the `objects` variable, e.g.,
+	// `objects.filter(o, o.status.phase == 'Running').size()`.
+	//
+	// The expression must evaluate to a number, a string or a boolean.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=4096
+	Expression string `json:"expression"`
+}
+
+// ClusterPropertyDefinitionStatus defines the observed state of the ClusterPropertyDefinition.
+type ClusterPropertyDefinitionStatus struct {
+	// Value is the last evaluated value of the property.
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// Conditions is an array of current observed conditions for the ClusterPropertyDefinition.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ClusterPropertyDefinitionConditionType identifies a specific condition of the ClusterPropertyDefinition.
+type ClusterPropertyDefinitionConditionType string
+
+const (
+	// ClusterPropertyDefinitionConditionTypeEvaluated indicates whether the property has been evaluated.
+	// Its condition status can be one of the following:
+	// - "True" means the property has been evaluated and reported.
+	// - "False" means the evaluation has failed, or the property is shadowed by a property of the same
+	// name from the property provider, or by another definition; the property is not reported.
+	ClusterPropertyDefinitionConditionTypeEvaluated ClusterPropertyDefinitionConditionType = "Evaluated"
+)
+
+// ClusterPropertyDefinitionList contains a list of ClusterPropertyDefinition objects.
+// +kubebuilder:object:root=true
+type ClusterPropertyDefinitionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterPropertyDefinition `json:"items"`
+}
+
+// SetConditions sets the given conditions on the ClusterPropertyDefinition.
+func (d *ClusterPropertyDefinition) SetConditions(conditions ...metav1.Condition) {
+	for _, c := range conditions {
+		meta.SetStatusCondition(&d.Status.Conditions, c)
+	}
+}
+
+// GetCondition returns the condition of the given type on the ClusterPropertyDefinition.
+func (d *ClusterPropertyDefinition) GetCondition(conditionType string) *metav1.Condition {
+	return meta.FindStatusCondition(d.Status.Conditions, conditionType)
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterPropertyDefinition{}, &ClusterPropertyDefinitionList{})
+}
@@ -78,6 +78,13 @@ type MemberClusterSpec struct {
 	// DeleteOptions for deleting the MemberCluster.
 	// +optional
 	DeleteOptions *DeleteOptions `json:"deleteOptions,omitempty"`
+
+	// MaintenanceWindows are the recurring time windows during which placements are allowed to roll out
+	// new resources to the MemberCluster. Rollouts to the MemberCluster are held outside all the windows.
+	// If not specified, rollouts to the MemberCluster are allowed at any time.
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
 }
 
 // DeleteValidationMode identifies the type of validation when deleting a MemberCluster.
@@ -158,10 +165,51 @@ type Taint struct {
 	Value string `json:"value,omitempty"`
 
 	// The effect of the taint on ClusterResourcePlacements that do not tolerate the taint.
-	// Only NoSchedule is supported.
-	// +kubebuilder:validation:Enum=NoSchedule
+	// NoSchedule and NoExecute are supported:
+	//
+	// - NoSchedule prevents the scheduler from placing resources on the MemberCluster; the resources
+	// that have already been placed on the MemberCluster are not affected.
+	//
+	// - NoExecute, in addition, evicts the resources that have already been placed on the MemberCluster,
+	// unless the placement tolerates the taint; a toleration with TolerationSeconds set delays the
+	// eviction for the given number of seconds after the taint is added. Evictions are subject to the
+	// disruption budgets of the placements.
+	// +kubebuilder:validation:Enum=NoSchedule;NoExecute
 	// +required
 	Effect corev1.TaintEffect `json:"effect"`
+
+	// TimeAdded is the time at which a taint with the NoExecute effect was added; it is set by
+	// the Fleet hub agent, and is used to compute when the tolerations with TolerationSeconds set expire.
+	// +optional
+	TimeAdded *metav1.Time `json:"timeAdded,omitempty"`
+}
+
+const (
+	// UnhealthyTaintKey is the key of the NoExecute taint that the Fleet hub agent adds to a MemberCluster
+	// when the member agent has stopped sending heartbeats, has reported that the cluster is unhealthy,
+	// or has left the fleet for a prolonged period of time, if taint-based eviction is enabled.
+	UnhealthyTaintKey = "kubernetes-fleet.io/unhealthy"
+)
+
+// MaintenanceWindow is a recurring time window described by a cron schedule and a duration.
+// The window opens at every time matched by the schedule and stays open for the given duration.
+type MaintenanceWindow struct {
+	// Schedule is a standard 5-field cron expression (minute, hour, day of month, month, day of week)
+	// that specifies when the window opens, e.g. "0 22 * * 1-5".
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Schedule string `json:"schedule"`
+
+	// Duration is how long the window stays open after each time matched by the schedule.
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(s|m|h))+$"
+	// +kubebuilder:validation:Type=string
+	// +required
+	Duration metav1.Duration `json:"duration"`
+
+	// TimeZone is the IANA name of the time zone in which the schedule is evaluated, e.g. "Europe/Berlin".
+	// Defaults to UTC.
+	// +optional
+	TimeZone *string `json:"timeZone,omitempty"`
 }
 
 // MemberClusterConditionType defines a specific condition of a member cluster.
@@ -0,0 +1,162 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,categories={fleet,fleet-placement},shortName=avr
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:JSONPath=`.spec.resourceType.group`,name="Group",type=string
+// +kubebuilder:printcolumn:JSONPath=`.spec.resourceType.kind`,name="Kind",type=string
+// +kubebuilder:printcolumn:JSONPath=`.metadata.creationTimestamp`,name="Age",type=date
+
+// AvailabilityRule specifies how Fleet determines the availability of the placed objects of
+// a specific resource type in member clusters.
+//
+// By default, Fleet can only track the availability of a limited set of Kubernetes built-in
+// resource types (e.g., Deployments, StatefulSets, DaemonSets, Services); objects of any other
+// resource type are considered untrackable, and Fleet will assume that they are available
+// after a period of time has elapsed since they were applied (see the UnavailablePeriodSeconds
+// field in the rolling update config). An AvailabilityRule allows Fleet to inspect the status of
+// such objects instead.
+//
+// An AvailabilityRule, if present, takes precedence over the built-in availability check for
+// the same resource type. The rules are evaluated by the member agents; they only take effect
+// in member clusters whose agents have the availability rules feature enabled.
+type AvailabilityRule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the desired state of the AvailabilityRule.
+	// +required
+	Spec AvailabilityRuleSpec `json:"spec"`
+}
+
+// AvailabilityRuleSpec is the desired state of the AvailabilityRule.
+type AvailabilityRuleSpec struct {
+	// ResourceType is the resource type that the rule applies to.
+	// +required
+	ResourceType AvailabilityRuleResourceType `json:"resourceType"`
+
+	// ObservedGenerationJSONPath is a JSONPath expression (e.g., `{.status.observedGeneration}`)
+	// that points to the generation of the object that its status reflects. If set, Fleet
+	// considers an object to be not yet available until the value matches the generation of the
+	// object, so that a stale status is not mistaken as the status of the latest spec.
+	// +optional
+	ObservedGenerationJSONPath string `json:"observedGenerationJSONPath,omitempty"`
+
+	// AvailableWhen is a list of status expressions; an object is considered to be available if
+	// all the expressions are satisfied and the object is neither failed nor progressing.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=8
+	// +required
+	AvailableWhen []StatusExpression `json:"availableWhen"`
+
+	// ProgressingWhen is a list of status expressions; an object is considered to be still
+	// progressing (i.e., not yet available) if any of the expressions is satisfied, even if all
+	// the AvailableWhen expressions are satisfied.
+	// +kubebuilder:validation:MaxItems=8
+	// +optional
+	ProgressingWhen []StatusExpression `json:"progressingWhen,omitempty"`
+
+	// FailedWhen is a list of status expressions; an object is considered to have failed if any
+	// of the expressions is satisfied. Fleet reports a failed object as unavailable with the
+	// details of the expression that is satisfied.
+	// +kubebuilder:validation:MaxItems=8
+	// +optional
+	FailedWhen []StatusExpression `json:"failedWhen,omitempty"`
+}
+
+// AvailabilityRuleResourceType identifies the resource type that an AvailabilityRule applies to.
+type AvailabilityRuleResourceType struct {
+	// Group is the API group of the resource type. Leave it empty for the core API group.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Version is the API version of the resource type. If not set, the rule applies to all
+	// the versions of the resource type.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Kind is the kind of the resource type.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Kind string `json:"kind"`
+}
+
+// StatusExpressionOperator is the operator of a status expression.
+// +enum
+type StatusExpressionOperator string
+
+const (
+	// StatusExpressionOperatorIn specifies that the expression is satisfied if any of the values
+	// found at the JSONPath is in the list of values of the expression.
+	StatusExpressionOperatorIn StatusExpressionOperator = "In"
+
+	// StatusExpressionOperatorNotIn specifies that the expression is satisfied if some values are
+	// found at the JSONPath and none of them is in the list of values of the expression.
+	StatusExpressionOperatorNotIn StatusExpressionOperator = "NotIn"
+
+	// StatusExpressionOperatorExists specifies that the expression is satisfied if any value is
+	// found at the JSONPath.
+	StatusExpressionOperatorExists StatusExpressionOperator = "Exists"
+
+	// StatusExpressionOperatorDoesNotExist specifies that the expression is satisfied if no value
+	// is found at the JSONPath.
+	StatusExpressionOperatorDoesNotExist StatusExpressionOperator = "DoesNotExist"
+)
+
+// StatusExpression is an expression over the object (typically its status) evaluated by Fleet
+// to determine the availability of the object.
+// +kubebuilder:validation:XValidation:rule="self.operator in ['In', 'NotIn'] ? has(self.values) && size(self.values) > 0 : !has(self.values) || size(self.values) == 0",message="values must be specified for the In and NotIn operators, and must be empty for the Exists and DoesNotExist operators"
+type StatusExpression struct {
+	// JSONPath is a JSONPath expression, in the same syntax as kubectl (e.g.,
+	// `{.status.conditions[?(@.type=="Ready")].status}`), that is evaluated against the object.
+	// The surrounding braces can be omitted.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	JSONPath string `json:"jsonPath"`
+
+	// Operator is the operator that is used to compare the values found at the JSONPath with
+	// the values of the expression.
+	// +kubebuilder:validation:Enum=In;NotIn;Exists;DoesNotExist
+	// +required
+	Operator StatusExpressionOperator `json:"operator"`
+
+	// Values is the list of values to compare with. All values are compared as strings.
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	Values []string `json:"values,omitempty"`
+}
+
+// AvailabilityRuleList contains a list of AvailabilityRule objects.
+// +kubebuilder:resource:scope=Cluster
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type AvailabilityRuleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// Items is the list of AvailabilityRule objects.
+	Items []AvailabilityRule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AvailabilityRule{}, &AvailabilityRuleList{})
+}
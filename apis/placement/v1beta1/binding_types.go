@@ -167,6 +167,15 @@ type ResourceBindingStatus struct {
 	// +kubebuilder:validation:MaxItems=100
 	DriftedPlacements []DriftedResourcePlacement `json:"driftedPlacements,omitempty"`
 
+	// DriftRemediatedPlacements is a list of resources whose configuration drifts have been
+	// reverted by Fleet automatically, as dictated by the drift remediation policy in the
+	// apply strategy.
+	//
+	// To control the object size, only the first 100 such resources will be included.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=100
+	DriftRemediatedPlacements []DriftRemediatedResourcePlacement `json:"driftRemediatedPlacements,omitempty"`
+
 	// DiffedPlacements is a list of resources that have configuration differences from their
 	// corresponding hub cluster manifests. Fleet will report such differences when:
 	//
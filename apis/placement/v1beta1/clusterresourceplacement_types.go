@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -132,6 +133,18 @@ type PlacementSpec struct {
 	// +kubebuilder:validation:MaxItems=100
 	ResourceSelectors []ResourceSelectorTerm `json:"resourceSelectors"`
 
+	// ResourceSources is an array of sources from which Fleet renders resources to place, in addition to the
+	// resources selected by the ResourceSelectors. The rendered resources become part of the resource snapshots
+	// and are handled the same way as the selected resources, e.g., overrides apply to them as well; the
+	// resources rendered from a Helm chart are kept in the resource snapshots as one HelmRelease object instead
+	// (see HelmSource).
+	// For a ResourcePlacement, the rendered resources must be namespace-scoped and in the namespace of the
+	// placement; the namespace of the placement is used for the rendered resources without a namespace.
+	// You can have 0-10 sources.
+	// +kubebuilder:validation:MaxItems=10
+	// +kubebuilder:validation:Optional
+	ResourceSources []ResourceSource `json:"resourceSources,omitempty"`
+
 	// Policy defines how to select member clusters to place the selected resources.
 	// If unspecified, all the joined member clusters are selected.
 	// +kubebuilder:validation:Optional
@@ -208,6 +221,133 @@ type ResourceSelectorTerm struct {
 	SelectionScope SelectionScope `json:"selectionScope,omitempty"`
 }
 
+// ResourceSource is a source from which Fleet renders the resources to place.
+// Exactly one of Kustomize and Helm must be set.
+// +kubebuilder:validation:XValidation:rule="has(self.kustomize) != has(self.helm)",message="exactly one of kustomize and helm must be set"
+type ResourceSource struct {
+	// Kustomize is a Kustomize directory which Fleet builds to render the resources.
+	// +kubebuilder:validation:Optional
+	Kustomize *KustomizeSource `json:"kustomize,omitempty"`
+
+	// Helm is a Helm chart which Fleet renders the resources from.
+	// +kubebuilder:validation:Optional
+	Helm *HelmSource `json:"helm,omitempty"`
+}
+
+// KustomizeSource is a Kustomize directory stored in a ConfigMap in the hub cluster.
+//
+// Each key of the ConfigMap is the name of a file in the directory, and the value is the file content; the
+// directory must have a kustomization file (`kustomization.yaml`, `kustomization.yml` or `Kustomization`) at
+// its root. As ConfigMap keys cannot contain slashes, the directory must be flat; all the resources,
+// components and patches referenced by the kustomization must be files in the ConfigMap, i.e., remote
+// resources are not supported. Neither are Helm charts (use a Helm source instead) and Kustomize plugins.
+//
+// Fleet re-renders the resources whenever the ConfigMap changes. Note that the ConfigMap itself is not placed
+// unless it is selected by the ResourceSelectors.
+type KustomizeSource struct {
+	// ConfigMapName is the name of the ConfigMap which stores the Kustomize directory.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=253
+	ConfigMapName string `json:"configMapName"`
+
+	// ConfigMapNamespace is the namespace of the ConfigMap which stores the Kustomize directory.
+	// It is required by a ClusterResourcePlacement. For a ResourcePlacement, the ConfigMap must be in the same
+	// namespace as the placement; the namespace of the placement is used if it is not set.
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Optional
+	ConfigMapNamespace string `json:"configMapNamespace,omitempty"`
+}
+
+// HelmSource is a Helm chart in a chart repository or an OCI registry, and the values to render it with.
+//
+// Fleet renders the chart in the hub cluster the same way as `helm template --include-crds` does, and places
+// the rendered resources instead of installing a Helm release on the member clusters, i.e., Helm hooks run
+// as ordinary resources and there is no release history. The subcharts must be vendored in the charts
+// directory of the chart, and library charts cannot be rendered. Charts which render different resources
+// every time (e.g., with the randAlphaNum or now template functions) are rejected.
+//
+// The resource snapshots keep the reference to the chart, the digest of the chart archive and the values
+// in a HelmRelease object (apiVersion placement.kubernetes-fleet.io/v1beta1), which is named after the
+// release and is in the release namespace:
+//
+//	apiVersion: placement.kubernetes-fleet.io/v1beta1
+//	kind: HelmRelease
+//	metadata:
+//	  name: <releaseName>
+//	  namespace: <releaseNamespace>
+//	spec:
+//	  chart: <chart>
+//	  chartDigest: sha256:<the digest of the chart archive>
+//	  values: <values>
+//
+// Fleet fetches the chart again and renders it from the HelmRelease object for each member cluster after
+// applying the overrides; if the chart archive no longer matches the digest, e.g., the chart version has been
+// overwritten in the repository, the resources are not rendered until a new resource snapshot is created.
+// A ResourceOverride in the release namespace (or a ClusterResourceOverride which selects the release
+// namespace) which patches the HelmRelease object, e.g., with a JSON patch on `/spec/values/replicaCount`,
+// sets the values for the member clusters selected by its override rules. The HelmRelease object itself is
+// never placed on the member clusters.
+type HelmSource struct {
+	// Chart is the chart to render.
+	// +kubebuilder:validation:Required
+	Chart HelmChart `json:"chart"`
+
+	// ReleaseName is the name of the release, i.e., `.Release.Name` in the templates.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=53
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	ReleaseName string `json:"releaseName"`
+
+	// ReleaseNamespace is the namespace of the release, i.e., `.Release.Namespace` in the templates; the
+	// rendered namespaced resources without a namespace are placed in it.
+	// It is required by a ClusterResourcePlacement. For a ResourcePlacement, the release namespace must be the
+	// namespace of the placement, which is used if it is not set.
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Optional
+	ReleaseNamespace string `json:"releaseNamespace,omitempty"`
+
+	// Values are the values to render the chart with, which are merged with the default values of the chart.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Values *apiextensionsv1.JSON `json:"values,omitempty"`
+}
+
+// HelmChart is a chart in a chart repository or an OCI registry.
+// +kubebuilder:validation:XValidation:rule="!has(self.plainHTTP) || !self.plainHTTP || self.repository.startsWith('oci://')",message="plainHTTP is only allowed with an OCI registry"
+type HelmChart struct {
+	// Repository is the URL of the chart repository or the OCI registry which stores the chart:
+	//   - `oci://<registry>/<path>` for an OCI registry, where the chart is `<registry>/<path>/<name>:<version>`,
+	//     the same as with `helm pull oci://<registry>/<path>/<name> --version <version>`;
+	//   - `https://` or `http://` for a chart repository served over HTTP, which has an `index.yaml` file;
+	//   - `file://` for a chart repository in a local directory of the hub agent, which has an `index.yaml` file.
+	//     The directory must be under the root set by the `--helm-file-repository-root` flag of the hub agent.
+	// The charts are fetched anonymously, and only from the hosts allowed by the `--helm-allowed-hosts` flag of
+	// the hub agent.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:Pattern=`^(oci|https?|file)://.+$`
+	Repository string `json:"repository"`
+
+	// Name is the name of the chart.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name"`
+
+	// Version is the exact version of the chart; version ranges are not supported.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=128
+	Version string `json:"version"`
+
+	// PlainHTTP specifies whether to access the OCI registry with HTTP instead of HTTPS, e.g., for a local
+	// registry. It is only allowed with an OCI registry.
+	// +kubebuilder:validation:Optional
+	PlainHTTP bool `json:"plainHTTP,omitempty"`
+}
+
 // SelectionScope defines the scope of resource selections.
 type SelectionScope string
 
@@ -263,13 +403,31 @@ type PlacementPolicy struct {
 	// +kubebuilder:validation:MaxItems=100
 	// +kubebuilder:validation:Optional
 	Tolerations []Toleration `json:"tolerations,omitempty"`
+
+	// SchedulerName is the name of the scheduling profile which schedules the placement.
+	// The scheduling profiles are configured on the hub agent; the default profile is used
+	// if it is not specified.
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Optional
+	SchedulerName string `json:"schedulerName,omitempty"`
 }
 
-// Affinity is a group of cluster affinity scheduling rules. More to be added.
+// Affinity is a group of cluster and placement affinity scheduling rules.
 type Affinity struct {
 	// ClusterAffinity contains cluster affinity scheduling rules for the selected resources.
 	// +kubebuilder:validation:Optional
 	ClusterAffinity *ClusterAffinity `json:"clusterAffinity,omitempty"`
+
+	// PlacementAffinity contains scheduling rules which co-locate the selected resources with
+	// the resources of other placements, e.g., keep a database and its API on the same clusters.
+	// +kubebuilder:validation:Optional
+	PlacementAffinity *PlacementAffinity `json:"placementAffinity,omitempty"`
+
+	// PlacementAntiAffinity contains scheduling rules which keep the selected resources apart
+	// from the resources of other placements, e.g., keep redundant replicas of a control plane
+	// on different clusters.
+	// +kubebuilder:validation:Optional
+	PlacementAntiAffinity *PlacementAntiAffinity `json:"placementAntiAffinity,omitempty"`
 }
 
 // ClusterAffinity contains cluster affinity scheduling rules for the selected resources.
@@ -313,6 +471,81 @@ type PreferredClusterSelector struct {
 	Preference ClusterSelectorTerm `json:"preference"`
 }
 
+// PlacementAffinity contains scheduling rules which co-locate the selected resources with the
+// resources of other placements.
+//
+// Note that the rules are only enforced when the placement is scheduled; the scheduler does not
+// consider the rules of a placement when it schedules other placements.
+type PlacementAffinity struct {
+	// If the affinity requirements specified by this field are not met at scheduling time,
+	// the resource will not be scheduled onto the cluster, i.e., a cluster is eligible only if
+	// it hosts a placement selected by each of the terms. The terms are `ANDed`.
+	// If the affinity requirements specified by this field cease to be met at some point after
+	// the placement (e.g. due to an update), the system may or may not try to eventually
+	// remove the resource from the cluster.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=10
+	RequiredDuringSchedulingIgnoredDuringExecution []PlacementAffinityTerm `json:"requiredDuringSchedulingIgnoredDuringExecution,omitempty"`
+
+	// The scheduler computes a score for each cluster at schedule time by iterating through
+	// the elements of this field and adding "weight" to the sum if the cluster hosts a placement
+	// selected by the corresponding term.
+	// This field is ignored if the placement type is "PickAll".
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=10
+	PreferredDuringSchedulingIgnoredDuringExecution []WeightedPlacementAffinityTerm `json:"preferredDuringSchedulingIgnoredDuringExecution,omitempty"`
+}
+
+// PlacementAntiAffinity contains scheduling rules which keep the selected resources apart from
+// the resources of other placements.
+//
+// Note that the rules are only enforced when the placement is scheduled; the scheduler does not
+// consider the rules of a placement when it schedules other placements.
+type PlacementAntiAffinity struct {
+	// If the anti-affinity requirements specified by this field are not met at scheduling time,
+	// the resource will not be scheduled onto the cluster, i.e., a cluster is eligible only if
+	// it hosts no placement selected by any of the terms.
+	// If the anti-affinity requirements specified by this field cease to be met at some point
+	// after the placement (e.g. due to an update), the system may or may not try to eventually
+	// remove the resource from the cluster.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=10
+	RequiredDuringSchedulingIgnoredDuringExecution []PlacementAffinityTerm `json:"requiredDuringSchedulingIgnoredDuringExecution,omitempty"`
+
+	// The scheduler computes a score for each cluster at schedule time by iterating through
+	// the elements of this field and subtracting "weight" from the sum if the cluster hosts a
+	// placement selected by the corresponding term.
+	// This field is ignored if the placement type is "PickAll".
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=10
+	PreferredDuringSchedulingIgnoredDuringExecution []WeightedPlacementAffinityTerm `json:"preferredDuringSchedulingIgnoredDuringExecution,omitempty"`
+}
+
+// PlacementAffinityTerm selects a group of placements, the clusters of which a placement should
+// (or should not) be co-located with.
+type PlacementAffinityTerm struct {
+	// PlacementSelector selects the placements by their labels. A ClusterResourcePlacement
+	// selects other ClusterResourcePlacements; a ResourcePlacement selects other
+	// ResourcePlacements in the same namespace. A placement never selects itself.
+	//
+	// A cluster hosts a placement if the placement has been scheduled or bound to it.
+	// +kubebuilder:validation:Required
+	PlacementSelector metav1.LabelSelector `json:"placementSelector"`
+}
+
+// WeightedPlacementAffinityTerm is a placement affinity term with a weight.
+type WeightedPlacementAffinityTerm struct {
+	// Weight associated with matching the corresponding placement affinity term, in the range [1, 100].
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	Weight int32 `json:"weight"`
+
+	// A placement affinity term, associated with the corresponding weight.
+	// +kubebuilder:validation:Required
+	PlacementAffinityTerm PlacementAffinityTerm `json:"placementAffinityTerm"`
+}
+
 // +enum
 type PropertySortOrder string
 
@@ -754,6 +987,99 @@ type ApplyStrategy struct {
 	// +kubebuilder:validation:Enum=Always;IfNoDiff;Never
 	// +kubebuilder:validation:Optional
 	WhenToTakeOver WhenToTakeOverType `json:"whenToTakeOver,omitempty"`
+
+	// IgnoreDifferences is a list of rules that specify the differences Fleet should ignore
+	// when it detects drifts or reports configuration differences, i.e., the differences that
+	// match any of the rules will not be reported, and will not block apply ops under the
+	// IfNotDrifted apply option or takeovers under the IfNoDiff takeover option.
+	//
+	// This is most useful with the FullComparison option, where fields that are set by other
+	// agents on the member cluster side, such as sidecars injected by service meshes, or replica
+	// counts set by HPAs, would otherwise be reported as drifts.
+	//
+	// Note that the rules do not affect the apply ops themselves; if an ignored field is also
+	// specified in the hub cluster manifest, Fleet will still overwrite it when applying the
+	// manifest.
+	//
+	// +kubebuilder:validation:MaxItems=20
+	// +kubebuilder:validation:Optional
+	IgnoreDifferences []IgnoreDifferenceRule `json:"ignoreDifferences,omitempty"`
+
+	// DriftRemediation is the policy for reverting configuration drifts automatically. It can
+	// only be set when WhenToApply is IfNotDrifted.
+	//
+	// With the IfNotDrifted option alone, Fleet stops applying the hub cluster manifest on a
+	// drifted resource until the drift is resolved manually. With a drift remediation policy,
+	// Fleet instead reverts the drift (i.e., applies the hub cluster manifest again) once the
+	// drift has been observed for longer than the specified grace period. This allows ad-hoc
+	// changes on the member cluster side, such as emergency hot-fixes, to stay in effect for
+	// a while, yet still have all the resources converge to their desired states eventually.
+	//
+	// Each remediation is recorded as an event on the Work object and in the status of the
+	// Work object and the placement. To exempt a resource from automatic remediation, add the
+	// annotation `kubernetes-fleet.io/skip-drift-remediation: "true"` to the resource,
+	// either in the hub cluster manifest or on the member cluster side.
+	//
+	// +kubebuilder:validation:Optional
+	DriftRemediation *DriftRemediationPolicy `json:"driftRemediation,omitempty"`
+}
+
+// DriftRemediationPolicy is the policy for reverting configuration drifts automatically.
+type DriftRemediationPolicy struct {
+	// GracePeriodSeconds is the period of time (in seconds) Fleet waits after a drift is first
+	// observed before it reverts the drift.
+	//
+	// Note that Fleet reverts the drift at the first processing attempt after the grace period
+	// elapses; depending on the processing frequency of the work applier, this might happen a
+	// while after the grace period.
+	//
+	// Defaults to 3600 (one hour). Set it to 0 to revert drifts as soon as they are found, while
+	// still keeping the record of each remediation.
+	//
+	// +kubebuilder:default=3600
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	GracePeriodSeconds *int32 `json:"gracePeriodSeconds,omitempty"`
+}
+
+// IgnoreDifferenceRule specifies the differences to ignore on a group of resources.
+type IgnoreDifferenceRule struct {
+	// Group is the API group of the resources the rule applies to. Leave it empty for the
+	// core API group.
+	// +kubebuilder:validation:Optional
+	Group string `json:"group,omitempty"`
+
+	// Kind is the kind of the resources the rule applies to.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Kind string `json:"kind"`
+
+	// Name is the name of the resource the rule applies to. If not set, the rule applies to all
+	// the resources of the kind.
+	// +kubebuilder:validation:Optional
+	Name string `json:"name,omitempty"`
+
+	// Namespace is the namespace of the resources the rule applies to. If not set, the rule
+	// applies to the resources of the kind in all namespaces.
+	// +kubebuilder:validation:Optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// JSONPointers is a list of JSON pointers (e.g., `/spec/replicas`) to the fields whose
+	// differences should be ignored. A pointer also covers all the fields under it.
+	// +kubebuilder:validation:MaxItems=20
+	// +kubebuilder:validation:Optional
+	JSONPointers []string `json:"jsonPointers,omitempty"`
+
+	// ManagedFieldsManagers is a list of field managers (as seen in the managed fields of the
+	// resources on the member cluster side); differences in the fields that are managed by
+	// any of these managers should be ignored.
+	//
+	// Note that changes made by mutating admission webhooks are attributed to the field
+	// manager of the request that triggers them, rather than the webhooks themselves; use
+	// JSON pointers to ignore such changes.
+	// +kubebuilder:validation:MaxItems=20
+	// +kubebuilder:validation:Optional
+	ManagedFieldsManagers []string `json:"managedFieldsManagers,omitempty"`
 }
 
 // ComparisonOptionType describes the compare option that Fleet uses to detect drifts and/or
@@ -918,6 +1244,36 @@ type RollingUpdateConfig struct {
 	// +kubebuilder:default=60
 	// +kubebuilder:validation:Optional
 	UnavailablePeriodSeconds *int `json:"unavailablePeriodSeconds,omitempty"`
+
+	// MaintenanceWindows are the recurring time windows during which the rollout controller is allowed to
+	// move bindings to new resource or override snapshots. Outside all the windows, the rollout is held
+	// and the bindings report that they are waiting for a maintenance window.
+	// Maintenance windows set on the target MemberCluster are honored as well.
+	// If not specified, the rollout can happen at any time.
+	// +kubebuilder:validation:MaxItems=10
+	// +kubebuilder:validation:Optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+}
+
+// MaintenanceWindow is a recurring time window described by a cron schedule and a duration.
+// The window opens at every time matched by the schedule and stays open for the given duration.
+type MaintenanceWindow struct {
+	// Schedule is a standard 5-field cron expression (minute, hour, day of month, month, day of week)
+	// that specifies when the window opens, e.g. "0 22 * * 1-5".
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Required
+	Schedule string `json:"schedule"`
+
+	// Duration is how long the window stays open after each time matched by the schedule.
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(s|m|h))+$"
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Required
+	Duration metav1.Duration `json:"duration"`
+
+	// TimeZone is the IANA name of the time zone in which the schedule is evaluated, e.g. "Europe/Berlin".
+	// Defaults to UTC.
+	// +kubebuilder:validation:Optional
+	TimeZone *string `json:"timeZone,omitempty"`
 }
 
 // PlacementStatus defines the observed status of the ClusterResourcePlacement and ResourcePlacement object.
@@ -1069,6 +1425,16 @@ type PerClusterPlacementStatus struct {
 	// +kubebuilder:validation:MaxItems=100
 	DriftedPlacements []DriftedResourcePlacement `json:"driftedPlacements,omitempty"`
 
+	// DriftRemediatedPlacements is a list of resources whose configuration drifts have been
+	// reverted by Fleet automatically, as dictated by the drift remediation policy in the
+	// apply strategy.
+	//
+	// To control the object size, only the first 100 such resources will be included.
+	// This field is only meaningful if the `ClusterName` is not empty.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=100
+	DriftRemediatedPlacements []DriftRemediatedResourcePlacement `json:"driftRemediatedPlacements,omitempty"`
+
 	// DiffedPlacements is a list of resources that have configuration differences from their
 	// corresponding hub cluster manifests. Fleet will report such differences when:
 	//
@@ -1125,6 +1491,18 @@ type PatchDetail struct {
 	ValueInHub string `json:"valueInHub,omitempty"`
 }
 
+// DriftRemediatedResourcePlacement contains the drift remediation history of a resource.
+type DriftRemediatedResourcePlacement struct {
+	// The resource whose drifts have been reverted.
+	ResourceIdentifier `json:",inline"`
+
+	// DriftRemediationHistory lists the most recent drift remediations that Fleet has performed
+	// on the resource. The latest remediation comes last.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxItems=5
+	DriftRemediationHistory []DriftRemediation `json:"driftRemediationHistory"`
+}
+
 // DriftedResourcePlacement contains the details of a resource with configuration drifts.
 type DriftedResourcePlacement struct {
 	// The resource that has drifted.
@@ -1219,10 +1597,21 @@ type Toleration struct {
 	Value string `json:"value,omitempty"`
 
 	// Effect indicates the taint effect to match. Empty means match all taint effects.
-	// When specified, only allowed value is NoSchedule.
-	// +kubebuilder:validation:Enum=NoSchedule
+	// When specified, allowed values are NoSchedule and NoExecute.
+	// +kubebuilder:validation:Enum=NoSchedule;NoExecute
 	// +kubebuilder:validation:Optional
 	Effect corev1.TaintEffect `json:"effect,omitempty"`
+
+	// TolerationSeconds represents the period of time the toleration (which must be
+	// of effect NoExecute) tolerates the taint. By default, it is not set, which means
+	// tolerate the taint forever; zero and negative values are treated as 0, i.e., the
+	// resources are evicted immediately.
+	//
+	// A toleration with TolerationSeconds set only delays the eviction of the resources that
+	// have already been placed on a MemberCluster with the taint; the scheduler does not place
+	// new resources on such a MemberCluster.
+	// +kubebuilder:validation:Optional
+	TolerationSeconds *int64 `json:"tolerationSeconds,omitempty"`
 }
 
 // ClusterResourcePlacementConditionType defines a specific condition of a cluster resource placement object.
@@ -63,6 +63,8 @@ const (
 	ClusterResourceEnvelopeKind = "ClusterResourceEnvelope"
 	// ClusterResourcePlacementStatusKind is the kind of the ClusterResourcePlacementStatus.
 	ClusterResourcePlacementStatusKind = "ClusterResourcePlacementStatus"
+	// ClusterResourcePlacementPreviewKind is the kind of the ClusterResourcePlacementPreview.
+	ClusterResourcePlacementPreviewKind = "ClusterResourcePlacementPreview"
 )
 
 const (
@@ -130,6 +132,10 @@ const (
 	// ParentNamespaceLabel is the label applied to work that contains the namespace of the binding that generates the work.
 	ParentNamespaceLabel = FleetPrefix + "parent-placement-namespace"
 
+	// PlacementPreviewLabel is the label applied to work that is generated for a ClusterResourcePlacementPreview;
+	// its value is the name of the preview.
+	PlacementPreviewLabel = FleetPrefix + "placement-preview"
+
 	// CRPGenerationAnnotation indicates the generation of the placement from which an object is derived or last updated.
 	// TODO: rename this variable
 	CRPGenerationAnnotation = FleetPrefix + "CRP-generation"
@@ -151,6 +157,24 @@ const (
 	// This is used to remember if an "unscheduled" binding was moved from a "bound" state or a "scheduled" state.
 	PreviousBindingStateAnnotation = FleetPrefix + "previous-binding-state"
 
+	// RebalanceSourceBindingAnnotation is added by the rebalancer to a binding it creates for migrating a
+	// placement away from another cluster; its value is the name of the binding to be evicted once the new
+	// binding becomes available.
+	RebalanceSourceBindingAnnotation = FleetPrefix + "rebalance-source-binding"
+
+	// TaintEvictionClusterLabel is added by the hub agent to an eviction it creates for evicting a placement
+	// from a cluster with a NoExecute taint that the placement does not tolerate; its value is the name of
+	// the cluster.
+	TaintEvictionClusterLabel = FleetPrefix + "taint-eviction-cluster"
+
+	// BlobDigestAnnotation marks a selected resource or a manifest as a reference to content kept in the external
+	// blob store instead of the object itself; its value is the digest of the content, in the form of
+	// "sha256:<hex>". Only the type and the identity of the object are kept in the reference.
+	BlobDigestAnnotation = FleetPrefix + "blob-digest"
+
+	// BlobSizeAnnotation records the size in bytes of the content that a blob reference points to.
+	BlobSizeAnnotation = FleetPrefix + "blob-size"
+
 	// UpdateRunFinalizer is used by the UpdateRun controller to make sure that the UpdateRun
 	// object is not deleted until all its dependent resources are deleted.
 	UpdateRunFinalizer = FleetPrefix + "stagedupdaterun-finalizer"
@@ -158,7 +182,7 @@ const (
 	// TargetUpdateRunLabel indicates the target update run on a staged run related object.
 	TargetUpdateRunLabel = FleetPrefix + "targetUpdateRun"
 
-	// TaskTypeLabel indicates the task type (before-stage or after-stage) on a staged run related object.
+	// TaskTypeLabel indicates the task type (before-stage, after-stage or after-canary) on a staged run related object.
 	TaskTypeLabel = FleetPrefix + "taskType"
 
 	// UpdateRunDeleteStageName is the name of delete stage in the staged update run.
@@ -167,6 +191,10 @@ const (
 	// IsLatestUpdateRunApprovalLabel indicates if the approval is the latest approval on a staged run.
 	IsLatestUpdateRunApprovalLabel = FleetPrefix + "isLatestUpdateRunApproval"
 
+	// IsHealthCheckApprovalLabel indicates that the approval request is created for a health check task of a staged run,
+	// which only the update run controller can approve or reject.
+	IsHealthCheckApprovalLabel = FleetPrefix + "isHealthCheckApproval"
+
 	// TargetUpdatingStageNameLabel indicates the updating stage name on a staged run related object.
 	TargetUpdatingStageNameLabel = FleetPrefix + "targetUpdatingStage"
 
@@ -176,11 +204,26 @@ const (
 	// AfterStageTaskLabelValue is the after stage task label value.
 	AfterStageTaskLabelValue = "afterStage"
 
+	// AfterCanaryTaskLabelValue is the after canary task label value.
+	AfterCanaryTaskLabelValue = "afterCanary"
+
 	// BeforeStageApprovalTaskNameFmt is the format of the before stage approval task name.
 	BeforeStageApprovalTaskNameFmt = "%s-before-%s"
 
 	// AfterStageApprovalTaskNameFmt is the format of the after stage approval task name.
 	AfterStageApprovalTaskNameFmt = "%s-after-%s"
+
+	// BeforeStageHealthCheckTaskNameFmt is the format of the before stage health check task name.
+	BeforeStageHealthCheckTaskNameFmt = "%s-before-%s-healthcheck"
+
+	// AfterStageHealthCheckTaskNameFmt is the format of the after stage health check task name.
+	AfterStageHealthCheckTaskNameFmt = "%s-after-%s-healthcheck"
+
+	// AfterCanaryApprovalTaskNameFmt is the format of the after canary approval task name.
+	AfterCanaryApprovalTaskNameFmt = "%s-canary-%s"
+
+	// AfterCanaryHealthCheckTaskNameFmt is the format of the after canary health check task name.
+	AfterCanaryHealthCheckTaskNameFmt = "%s-canary-%s-healthcheck"
 )
 
 var (
@@ -122,7 +122,7 @@ type OverrideRule struct {
 	ClusterSelector *ClusterSelector `json:"clusterSelector,omitempty"`
 
 	// OverrideType defines the type of the override rules.
-	// +kubebuilder:validation:Enum=JSONPatch;Delete
+	// +kubebuilder:validation:Enum=JSONPatch;Delete;MergePatch;CEL
 	// +kubebuilder:default=JSONPatch
 	// +optional
 	OverrideType OverrideType `json:"overrideType,omitempty"`
@@ -133,6 +133,18 @@ type OverrideRule struct {
 	// +kubebuilder:validation:MaxItems=20
 	// +optional
 	JSONPatchOverrides []JSONPatchOverride `json:"jsonPatchOverrides,omitempty"`
+
+	// MergePatchOverride defines a merge patch override rule.
+	// This field is only allowed when OverrideType is MergePatch.
+	// +optional
+	MergePatchOverride *MergePatchOverride `json:"mergePatchOverride,omitempty"`
+
+	// CELOverrides defines a list of CEL override rules.
+	// This field is only allowed when OverrideType is CEL.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=20
+	// +optional
+	CELOverrides []CELOverride `json:"celOverrides,omitempty"`
 }
 
 // OverrideType defines the type of Override
@@ -144,8 +156,99 @@ const (
 
 	// DeleteOverrideType deletes the selected resources on the target clusters.
 	DeleteOverrideType OverrideType = "Delete"
+
+	// MergePatchOverrideType applies a strategic merge patch or a JSON merge patch
+	// ([RFC 7386](https://datatracker.ietf.org/doc/html/rfc7386)) on the selected resources.
+	MergePatchOverrideType OverrideType = "MergePatch"
+
+	// CELOverrideType mutates the items of a list in the selected resources that are selected by
+	// CEL expressions.
+	CELOverrideType OverrideType = "CEL"
+)
+
+// MergePatchType defines the type of merge patch.
+type MergePatchType string
+
+const (
+	// StrategicMergePatchType applies the patch as a Kubernetes strategic merge patch, which
+	// merges list items by their merge keys (e.g., the names of the containers in a pod template)
+	// instead of replacing the whole list.
+	StrategicMergePatchType MergePatchType = "StrategicMerge"
+
+	// JSONMergePatchType applies the patch as a JSON merge patch following
+	// [RFC 7386](https://datatracker.ietf.org/doc/html/rfc7386).
+	JSONMergePatchType MergePatchType = "JSONMerge"
 )
 
+// MergePatchOverride applies a merge patch on the selected resources.
+type MergePatchOverride struct {
+	// Type is the type of the merge patch.
+	// Strategic merge patches can only be applied on Kubernetes built-in resource types; for
+	// any other resource types (e.g., custom resources), the patch is applied as a JSON merge patch.
+	// +kubebuilder:validation:Enum=StrategicMerge;JSONMerge
+	// +kubebuilder:default=StrategicMerge
+	// +optional
+	Type MergePatchType `json:"type,omitempty"`
+
+	// Patch is the patch (a partial object) to be merged into the selected resources.
+	// The patch cannot override the apiVersion, kind, and status fields, or any metadata fields except
+	// annotations and labels.
+	// The same variables as in the value of a JSON patch override are supported.
+	// +required
+	Patch apiextensionsv1.JSON `json:"patch"`
+
+	// EnableTemplate specifies whether the string values in the patch are rendered as Go templates
+	// against the member cluster before the patch is applied. See the EnableTemplate field of
+	// JSONPatchOverride for the details.
+	// +optional
+	EnableTemplate bool `json:"enableTemplate,omitempty"`
+}
+
+// CELOverride applies a JSON patch operation on each item of a list in the selected resources that
+// satisfies a CEL expression, so that list items can be overridden by their keys (e.g., the container
+// named `app`) rather than their positions in the list.
+type CELOverride struct {
+	// ListPath is the location of the list in the selected resources, in JSON pointer format
+	// (e.g., `/spec/template/spec/containers`).
+	// Note: override will fail if the list does not exist.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	ListPath string `json:"listPath"`
+
+	// Selector is a CEL expression that evaluates to a boolean, which selects the list items to
+	// override (e.g., `item.name == "app"`). The following variables are available:
+	// `item`: the list item;
+	// `object`: the selected resource;
+	// `cluster`: the member cluster, which has the `name`, `labels`, `annotations`, and `properties` fields.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Selector string `json:"selector"`
+
+	// Operator defines the operation on the target field of each selected list item.
+	// +kubebuilder:validation:Enum=add;remove;replace
+	// +required
+	Operator JSONPatchOverrideOperator `json:"op"`
+
+	// Path defines the target location relative to each selected list item, in JSON pointer format
+	// (e.g., `/image`). If not set, the operation applies to the list item itself; in this case the
+	// operator cannot be `add`.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Value defines the content to be applied on the target location.
+	// Value should be empty when operator is `remove`, or when ValueExpression is set.
+	// The same variables as in the value of a JSON patch override are supported.
+	// +optional
+	Value apiextensionsv1.JSON `json:"value,omitempty"`
+
+	// ValueExpression is a CEL expression that computes the content to be applied on the target
+	// location (e.g., `item.image.replace("docker.io", "myregistry.io")`), with the same variables
+	// as the Selector.
+	// ValueExpression should be empty when operator is `remove`, or when Value is set.
+	// +optional
+	ValueExpression string `json:"valueExpression,omitempty"`
+}
+
 // +genclient
 // +genclient:Namespaced
 // +kubebuilder:object:root=true
@@ -230,6 +333,26 @@ type JSONPatchOverride struct {
 	// `${MEMBER-CLUSTER-NAME}`:  this will be replaced by the name of the memberCluster CR that represents this cluster.
 	// +optional
 	Value apiextensionsv1.JSON `json:"value,omitempty"`
+	// EnableTemplate specifies whether the string values in the Value field are rendered as
+	// Go templates (https://pkg.go.dev/text/template) against the member cluster before the patch is applied.
+	//
+	// The template data has a single field, `.Cluster`, which has the following fields:
+	// `.Cluster.Name`: the name of the memberCluster CR that represents this cluster;
+	// `.Cluster.Labels` and `.Cluster.Annotations`: the labels and annotations of the memberCluster CR;
+	// `.Cluster.Properties`: the values of the properties reported in the memberCluster status, keyed by property name;
+	// `.Cluster.ResourceUsage.Capacity`, `.Cluster.ResourceUsage.Allocatable`, and `.Cluster.ResourceUsage.Available`:
+	// the resource usage reported in the memberCluster status, keyed by resource name.
+	// Keys that are not valid template identifiers (e.g., `kubernetes-fleet.io/node-count`) can be looked up
+	// with the `index` function. Referencing a missing key with the `.` syntax fails the override.
+	//
+	// Besides the built-in template functions (e.g., `printf`), the following functions are available:
+	// `lower`, `upper`, `trimPrefix`, `trimSuffix`, `replace`, `default`, `int`, `float`, `add`, `sub`, `mul`, and `div`.
+	//
+	// A string that consists of a single template action is replaced by the value the action evaluates to
+	// as is; for example, `{{ index .Cluster.Properties "kubernetes-fleet.io/node-count" | int | mul 2 }}`
+	// is rendered as a number rather than a string.
+	// +optional
+	EnableTemplate bool `json:"enableTemplate,omitempty"`
 }
 
 // JSONPatchOverrideOperator defines the supported JSON patch operator.
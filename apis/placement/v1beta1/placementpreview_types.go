@@ -0,0 +1,197 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,categories={fleet,fleet-placement},shortName=crpp
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:JSONPath=`.spec.placementName`,name="Placement",type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="Simulated")].status`,name="Simulated",type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="DiffReported")].status`,name="Diff-Reported",type=string
+// +kubebuilder:printcolumn:JSONPath=`.metadata.creationTimestamp`,name="Age",type=date
+
+// ClusterResourcePlacementPreview is a what-if preview of a ClusterResourcePlacement; one may use this
+// API to find out what a new ClusterResourcePlacement, or a change to an existing one, would do before
+// actually making the change.
+//
+// For a preview, Fleet:
+//   - selects the resources per the resource selectors in the previewed placement spec;
+//   - runs the scheduler in simulation to find out which clusters would be picked;
+//   - finds out which overrides would apply on each picked cluster, and renders the selected resources
+//     with the overrides; and
+//   - asks the member agent of each picked cluster to compare the rendered resources against the live
+//     objects in the member cluster (with server-side dry-run applies), as if the previewed placement
+//     were using the ReportDiff apply strategy.
+//
+// A preview never changes the resources in the member clusters, nor the scheduling decisions of any
+// placement. Note that the scheduling result is simulated as if the previewed placement spec had a
+// new scheduling policy; the scheduler might keep some of the current decisions of an existing placement
+// instead when only the number of clusters changes.
+//
+// Fleet evaluates a preview once for each generation of its spec; to refresh a preview without changing
+// the spec, re-create it.
+type ClusterResourcePlacementPreview struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the desired state of the ClusterResourcePlacementPreview.
+	// +required
+	Spec PlacementPreviewSpec `json:"spec"`
+
+	// Status is the observed state of the ClusterResourcePlacementPreview.
+	// +optional
+	Status PlacementPreviewStatus `json:"status,omitempty"`
+}
+
+// PlacementPreviewSpec is the desired state of the ClusterResourcePlacementPreview.
+type PlacementPreviewSpec struct {
+	// PlacementName is the name of the ClusterResourcePlacement to preview; it can be the name of an
+	// existing ClusterResourcePlacement or of one yet to be created. The name decides which overrides
+	// apply, as overrides might be attached to a specific placement.
+	//
+	// If not set, the name of the preview is used.
+	// +kubebuilder:validation:MaxLength=255
+	// +optional
+	PlacementName string `json:"placementName,omitempty"`
+
+	// PlacementSpec is the spec of the ClusterResourcePlacement to preview.
+	// +required
+	PlacementSpec PlacementSpec `json:"placementSpec"`
+
+	// SkipMemberClusterDiff, when set to true, skips comparing the rendered resources against the live
+	// objects in the member clusters; only the scheduling result and the applicable overrides are
+	// previewed.
+	// +kubebuilder:default=false
+	// +optional
+	SkipMemberClusterDiff bool `json:"skipMemberClusterDiff,omitempty"`
+}
+
+// PlacementPreviewStatus is the observed state of the ClusterResourcePlacementPreview.
+type PlacementPreviewStatus struct {
+	// SelectedResources is the list of resources which the previewed placement spec selects.
+	// +optional
+	SelectedResources []ResourceIdentifier `json:"selectedResources,omitempty"`
+
+	// Clusters is the list of the simulated scheduling decisions, one for each cluster that the
+	// scheduler has considered, along with the previewed placement on each picked cluster.
+	// +kubebuilder:validation:MaxItems=1000
+	// +optional
+	Clusters []ClusterPlacementPreview `json:"clusters,omitempty"`
+
+	// Conditions is the list of currently observed conditions for the ClusterResourcePlacementPreview.
+	//
+	// Available condition types include:
+	// * Simulated: whether the resource selection, scheduling, and override rendering are completed.
+	// * DiffReported: whether all the member clusters picked have reported the diffs.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ClusterPlacementPreview is the previewed placement on a specific cluster.
+type ClusterPlacementPreview struct {
+	// ClusterDecision is the simulated scheduling decision on the cluster.
+	ClusterDecision `json:",inline"`
+
+	// ApplicableClusterResourceOverrides is the list of the names of the ClusterResourceOverrideSnapshots
+	// which would apply on the cluster.
+	// +optional
+	ApplicableClusterResourceOverrides []string `json:"applicableClusterResourceOverrides,omitempty"`
+
+	// ApplicableResourceOverrides is the list of the ResourceOverrideSnapshots which would apply on the
+	// cluster.
+	// +optional
+	ApplicableResourceOverrides []NamespacedName `json:"applicableResourceOverrides,omitempty"`
+
+	// DiffedPlacements is the list of resources whose rendered manifests differ from the live objects in the
+	// member cluster; a resource which does not exist in the member cluster yet is reported with a diff
+	// at the root path.
+	//
+	// To control the object size, only the first 100 diffed resources will be included.
+	// +kubebuilder:validation:MaxItems=100
+	// +optional
+	DiffedPlacements []DiffedResourcePlacement `json:"diffedPlacements,omitempty"`
+
+	// Conditions is the list of currently observed conditions for the previewed placement on the cluster.
+	//
+	// Available condition types include:
+	// * Simulated: whether the selected resources have been rendered with the overrides for the cluster.
+	// * DiffReported: whether the member agent has reported the diffs.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// PlacementPreviewConditionType identifies a specific condition of the ClusterResourcePlacementPreview.
+type PlacementPreviewConditionType string
+
+const (
+	// PlacementPreviewConditionTypeSimulated indicates whether the preview has been simulated, i.e., the
+	// resources have been selected, the scheduler has been run in simulation, and the selected resources
+	// have been rendered with the overrides for each picked cluster.
+	//
+	// The following values are possible:
+	// * True: the preview has been simulated.
+	// * False: the simulation has failed; the message explains why.
+	PlacementPreviewConditionTypeSimulated PlacementPreviewConditionType = "Simulated"
+
+	// PlacementPreviewConditionTypeDiffReported indicates whether the member agents have reported the
+	// diffs between the rendered resources and the live objects in the member clusters.
+	//
+	// The following values are possible:
+	// * True: the member agents of all the picked clusters have reported the diffs.
+	// * False: the member agent of some picked cluster has failed to report the diffs.
+	// * Unknown: some member agent has not reported the diffs yet.
+	PlacementPreviewConditionTypeDiffReported PlacementPreviewConditionType = "DiffReported"
+)
+
+// ClusterResourcePlacementPreviewList contains a list of ClusterResourcePlacementPreview objects.
+// +kubebuilder:resource:scope=Cluster
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ClusterResourcePlacementPreviewList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// Items is the list of ClusterResourcePlacementPreview objects.
+	Items []ClusterResourcePlacementPreview `json:"items"`
+}
+
+// SetConditions set the given conditions on the ClusterResourcePlacementPreview.
+func (p *ClusterResourcePlacementPreview) SetConditions(conditions ...metav1.Condition) {
+	for _, c := range conditions {
+		meta.SetStatusCondition(&p.Status.Conditions, c)
+	}
+}
+
+// GetCondition returns the condition of the given ClusterResourcePlacementPreview.
+func (p *ClusterResourcePlacementPreview) GetCondition(conditionType string) *metav1.Condition {
+	return meta.FindStatusCondition(p.Status.Conditions, conditionType)
+}
+
+func init() {
+	SchemeBuilder.Register(
+		&ClusterResourcePlacementPreview{},
+		&ClusterResourcePlacementPreviewList{})
+}
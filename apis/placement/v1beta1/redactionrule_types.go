@@ -0,0 +1,129 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,categories={fleet,fleet-placement},shortName=rdr
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:JSONPath=`.spec.resourceType.group`,name="Group",type=string
+// +kubebuilder:printcolumn:JSONPath=`.spec.resourceType.kind`,name="Kind",type=string
+// +kubebuilder:printcolumn:JSONPath=`.spec.mode`,name="Mode",type=string
+// +kubebuilder:printcolumn:JSONPath=`.metadata.creationTimestamp`,name="Age",type=date
+
+// RedactionRule specifies the fields of the placed objects of a specific resource type whose
+// values Fleet must not reveal when it reports configuration drifts and differences.
+//
+// Fleet reports the observed drifts and differences of the placed objects in the status of
+// Work objects, and subsequently in the status of placements. By default, Fleet only redacts the
+// data of Secrets (the `/data` and `/stringData` fields); a RedactionRule allows Fleet to redact
+// the values of other fields, such as credentials in custom resources or the CA bundles of
+// webhook configurations.
+//
+// The rules are evaluated by the member agents; they only take effect in member clusters whose
+// agents have the redaction rules feature enabled.
+type RedactionRule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the desired state of the RedactionRule.
+	// +required
+	Spec RedactionRuleSpec `json:"spec"`
+}
+
+// RedactionRuleSpec is the desired state of the RedactionRule.
+// +kubebuilder:validation:XValidation:rule="(has(self.paths) && size(self.paths) > 0) || (has(self.pathRegexes) && size(self.pathRegexes) > 0)",message="at least one of paths and pathRegexes must be specified"
+type RedactionRuleSpec struct {
+	// ResourceType is the resource type that the rule applies to.
+	// +required
+	ResourceType RedactionRuleResourceType `json:"resourceType"`
+
+	// Paths is a list of JSON pointers (e.g., `/spec/credentials/password`) to the fields to redact.
+	// A path also covers all the fields under it. The segment `*` matches any single segment,
+	// e.g., `/webhooks/*/clientConfig/caBundle` covers the CA bundles of all the webhooks in
+	// a webhook configuration.
+	// +kubebuilder:validation:MaxItems=32
+	// +optional
+	Paths []string `json:"paths,omitempty"`
+
+	// PathRegexes is a list of regular expressions (in RE2 syntax) that are matched against the
+	// JSON pointers to the fields with drifts or differences; the values of the fields whose
+	// pointers match any of the expressions are redacted. For example, `(?i)token|password`
+	// covers all the fields whose paths contain `token` or `password`, regardless of case.
+	// If any of the expressions is invalid, Fleet redacts all the fields of the resource type.
+	// +kubebuilder:validation:MaxItems=32
+	// +optional
+	PathRegexes []string `json:"pathRegexes,omitempty"`
+
+	// Mode is the redaction mode.
+	// +kubebuilder:validation:Enum=Redact;Hash
+	// +kubebuilder:default=Redact
+	// +optional
+	Mode RedactionMode `json:"mode,omitempty"`
+}
+
+// RedactionRuleResourceType identifies the resource type that a RedactionRule applies to.
+type RedactionRuleResourceType struct {
+	// Group is the API group of the resource type. Leave it empty for the core API group.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Version is the API version of the resource type. If not set, the rule applies to all
+	// the versions of the resource type.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Kind is the kind of the resource type.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Kind string `json:"kind"`
+}
+
+// RedactionMode is the mode in which Fleet redacts the values of fields.
+// +enum
+type RedactionMode string
+
+const (
+	// RedactionModeRedact replaces the values with a fixed placeholder.
+	RedactionModeRedact RedactionMode = "Redact"
+
+	// RedactionModeHash replaces the values with (a prefix of) their HMAC-SHA256 digests, keyed
+	// with a secret that each member agent keeps in its member cluster, so that users can still tell
+	// whether a value has changed without seeing the value itself.
+	//
+	// Note that the digests of the same value differ across member clusters, but stay the same
+	// across agent restarts.
+	RedactionModeHash RedactionMode = "Hash"
+)
+
+// RedactionRuleList contains a list of RedactionRule objects.
+// +kubebuilder:resource:scope=Cluster
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type RedactionRuleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// Items is the list of RedactionRule objects.
+	Items []RedactionRule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RedactionRule{}, &RedactionRuleList{})
+}
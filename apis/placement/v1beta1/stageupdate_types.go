@@ -202,8 +202,52 @@ type UpdateRunSpec struct {
 	// +kubebuilder:default=Initialize
 	// +kubebuilder:validation:Enum=Initialize;Run;Stop
 	State State `json:"state,omitempty"`
+
+	// RollbackPolicy specifies what the update run does with the clusters it has already updated when
+	// the update run fails. If not specified, the updated clusters are left on the new resource snapshot.
+	// +kubebuilder:validation:Optional
+	RollbackPolicy *RollbackPolicy `json:"rollbackPolicy,omitempty"`
+}
+
+// RollbackPolicy describes how an update run rolls back the clusters it has updated.
+// +kubebuilder:validation:XValidation:rule="!has(self.stuckTimeout) || self.type == 'OnFailure'",message="stuckTimeout is only allowed with the OnFailure rollback type"
+type RollbackPolicy struct {
+	// Type is the type of the rollback policy.
+	// Never: The updated clusters are left on the new resource snapshot when the update run fails (default).
+	// OnFailure: When the update run fails, every cluster the update run has started updating is rolled back to
+	// the previous resource snapshot, walking the stages in reverse order. The previous resource snapshot is the
+	// latest one older than the snapshot used by the update run that is still retained under the RevisionHistoryLimit
+	// of the placement. Each cluster gets back the override snapshots recorded by the latest other update run that rolled
+	// out the previous resource snapshot to it, or keeps the ones of the failed update run if there is no such update run.
+	// The clusters that were newly scheduled when the update run started are not rolled back, as they had no resources
+	// placed before; they keep the resources of the failed update run.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Never
+	// +kubebuilder:validation:Enum=Never;OnFailure
+	Type RollbackType `json:"type,omitempty"`
+
+	// StuckTimeout is how long the update run may be stuck on a cluster, i.e., wait for a cluster which has been
+	// updating for more than 5 minutes to finish updating, before the update run fails and is rolled back.
+	// If not specified, a stuck update run waits indefinitely and is never rolled back; stopping it does not roll
+	// it back either. Only allowed with the OnFailure rollback type.
+	// +kubebuilder:validation:Pattern="^0|([0-9]+(\\.[0-9]+)?(s|m|h))+$"
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Optional
+	StuckTimeout *metav1.Duration `json:"stuckTimeout,omitempty"`
 }
 
+// RollbackType identifies the type of the rollback policy of an update run.
+// +enum
+type RollbackType string
+
+const (
+	// RollbackTypeNever disables rolling back the updated clusters when the update run fails.
+	RollbackTypeNever RollbackType = "Never"
+
+	// RollbackTypeOnFailure rolls back the updated clusters to the previous resource snapshot when the update run fails.
+	RollbackTypeOnFailure RollbackType = "OnFailure"
+)
+
 // UpdateStrategySpecGetterSetter offers the functionality to work with UpdateStrategySpec.
 // +kubebuilder:object:generate=false
 type UpdateStrategySpecGetterSetter interface {
@@ -266,6 +310,13 @@ type UpdateStrategySpec struct {
 	// +kubebuilder:validation:MaxItems=31
 	// +kubebuilder:validation:Required
 	Stages []StageConfig `json:"stages"`
+
+	// MaintenanceWindows are the recurring time windows during which clusters in any stage can start updating.
+	// A cluster only starts updating when the windows of the strategy, of its stage and of its MemberCluster
+	// are all open. If not specified, clusters can start updating at any time.
+	// +kubebuilder:validation:MaxItems=10
+	// +kubebuilder:validation:Optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
 }
 
 // ClusterStagedUpdateStrategyList contains a list of StagedUpdateStrategy.
@@ -324,10 +375,12 @@ type StageConfig struct {
 
 	// The collection of tasks that each stage needs to complete successfully before moving to the next stage.
 	// Each task is executed in parallel and there cannot be more than one task of the same type.
-	// +kubebuilder:validation:MaxItems=2
+	// +kubebuilder:validation:MaxItems=3
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:XValidation:rule="!self.exists(e, e.type == 'Approval' && has(e.waitTime))",message="AfterStageTaskType is Approval, waitTime is not allowed"
 	// +kubebuilder:validation:XValidation:rule="!self.exists(e, e.type == 'TimedWait' && !has(e.waitTime))",message="AfterStageTaskType is TimedWait, waitTime is required"
+	// +kubebuilder:validation:XValidation:rule="!self.exists(e, e.type == 'HealthCheck' && (!has(e.healthCheck) || has(e.waitTime)))",message="AfterStageTaskType is HealthCheck, healthCheck is required and waitTime is not allowed"
+	// +kubebuilder:validation:XValidation:rule="!self.exists(e, e.type != 'HealthCheck' && has(e.healthCheck))",message="healthCheck is only allowed when the task type is HealthCheck"
 	AfterStageTasks []StageTask `json:"afterStageTasks,omitempty"`
 
 	// The collection of tasks that needs to completed successfully by each stage before starting the stage.
@@ -336,13 +389,59 @@ type StageConfig struct {
 	// +kubebuilder:validation:MaxItems=1
 	// +kubebuilder:validation:XValidation:rule="!self.exists(e, e.type == 'Approval' && has(e.waitTime))",message="AfterStageTaskType is Approval, waitTime is not allowed"
 	// +kubebuilder:validation:XValidation:rule="!self.exists(e, e.type == 'TimedWait')",message="BeforeStageTaskType cannot be TimedWait"
+	// +kubebuilder:validation:XValidation:rule="!self.exists(e, e.type == 'HealthCheck' && (!has(e.healthCheck) || has(e.waitTime)))",message="BeforeStageTaskType is HealthCheck, healthCheck is required and waitTime is not allowed"
+	// +kubebuilder:validation:XValidation:rule="!self.exists(e, e.type != 'HealthCheck' && has(e.healthCheck))",message="healthCheck is only allowed when the task type is HealthCheck"
 	BeforeStageTasks []StageTask `json:"beforeStageTasks,omitempty"`
+
+	// Canary specifies the canary phase of the stage. If specified, the stage first updates only the canary clusters,
+	// waits for the bake time and the after-canary tasks to complete, and only then updates the rest of the clusters.
+	// The canary phase starts after the before-stage tasks are completed.
+	// +kubebuilder:validation:Optional
+	Canary *CanaryConfig `json:"canary,omitempty"`
+
+	// MaintenanceWindows are the recurring time windows during which clusters in the stage can start updating.
+	// They apply on top of the maintenance windows of the strategy and of the MemberClusters.
+	// If not specified, clusters in the stage can start updating at any time.
+	// +kubebuilder:validation:MaxItems=10
+	// +kubebuilder:validation:Optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+}
+
+// CanaryConfig describes the canary phase of a stage.
+type CanaryConfig struct {
+	// Clusters specifies the number of clusters in the stage that are updated first as canaries.
+	// Value can be an absolute number (ex: 1) or a percentage of the total clusters in the stage (ex: 10%).
+	// The canary clusters are the first clusters of the stage following the order defined by SortingLabelKey.
+	// Fractional results are rounded down. A minimum of 1 canary cluster is enforced.
+	// Defaults to 1.
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:XIntOrString
+	// +kubebuilder:validation:Pattern="^(100|[1-9][0-9]?)%$"
+	// +kubebuilder:validation:XValidation:rule="self == null || type(self) != int || self >= 1",message="canary clusters must be at least 1"
+	// +kubebuilder:validation:Optional
+	Clusters *intstr.IntOrString `json:"clusters,omitempty"`
+
+	// BakeTime is the time to wait after all the canary clusters are updated before starting the after-canary tasks.
+	// +kubebuilder:validation:Pattern="^0|([0-9]+(\\.[0-9]+)?(s|m|h))+$"
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Optional
+	BakeTime *metav1.Duration `json:"bakeTime,omitempty"`
+
+	// The collection of tasks that need to be completed successfully after the bake time before updating the rest of
+	// the clusters in the stage.
+	// +kubebuilder:validation:MaxItems=1
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="!self.exists(e, e.type == 'TimedWait')",message="AfterCanaryTaskType cannot be TimedWait, use bakeTime instead"
+	// +kubebuilder:validation:XValidation:rule="!self.exists(e, e.type == 'Approval' && has(e.waitTime))",message="AfterCanaryTaskType is Approval, waitTime is not allowed"
+	// +kubebuilder:validation:XValidation:rule="!self.exists(e, e.type == 'HealthCheck' && (!has(e.healthCheck) || has(e.waitTime)))",message="AfterCanaryTaskType is HealthCheck, healthCheck is required and waitTime is not allowed"
+	// +kubebuilder:validation:XValidation:rule="!self.exists(e, e.type != 'HealthCheck' && has(e.healthCheck))",message="healthCheck is only allowed when the task type is HealthCheck"
+	AfterCanaryTasks []StageTask `json:"afterCanaryTasks,omitempty"`
 }
 
 // StageTask is the pre or post stage task that needs to be completed before starting or moving to the next stage.
 type StageTask struct {
 	// The type of the before or after stage task.
-	// +kubebuilder:validation:Enum=TimedWait;Approval
+	// +kubebuilder:validation:Enum=TimedWait;Approval;HealthCheck
 	// +kubebuilder:validation:Required
 	Type StageTaskType `json:"type"`
 
@@ -351,8 +450,108 @@ type StageTask struct {
 	// +kubebuilder:validation:Type=string
 	// +kubebuilder:validation:Optional
 	WaitTime *metav1.Duration `json:"waitTime,omitempty"`
+
+	// HealthCheck specifies the health query that the update run controller evaluates to approve or reject
+	// the stage on behalf of the user. Only valid if the task type is HealthCheck.
+	// +kubebuilder:validation:Optional
+	HealthCheck *HealthCheckConfig `json:"healthCheck,omitempty"`
 }
 
+// HealthCheckConfig describes a health gate of a stage.
+// A health check task is handled like an approval task, except that the approval request it creates is approved
+// or rejected by the update run controller according to the result of the health query; the approval request
+// cannot be approved or rejected manually.
+// Exactly one of Prometheus and Availability must be set.
+// +kubebuilder:validation:XValidation:rule="has(self.prometheus) != has(self.availability)",message="exactly one of prometheus and availability must be set"
+type HealthCheckConfig struct {
+	// Prometheus specifies the query to evaluate against a Prometheus-compatible HTTP API.
+	// +kubebuilder:validation:Optional
+	Prometheus *PrometheusHealthCheck `json:"prometheus,omitempty"`
+
+	// Availability specifies a check of the availability conditions aggregated on the bindings of the clusters
+	// which the update run has updated so far.
+	// +kubebuilder:validation:Optional
+	Availability *AvailabilityHealthCheck `json:"availability,omitempty"`
+
+	// Interval is the time to wait between two evaluations of the health query.
+	// Defaults to 30s.
+	// +kubebuilder:validation:Pattern="^0|([0-9]+(\\.[0-9]+)?(s|m|h))+$"
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// Timeout is the maximum duration, counted from the creation of the approval request, during which the health
+	// query is allowed to report unhealthy or fail to be evaluated. The approval request is rejected and the update
+	// run fails once the timeout is reached.
+	// Defaults to 10m.
+	// +kubebuilder:validation:Pattern="^0|([0-9]+(\\.[0-9]+)?(s|m|h))+$"
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// AvailabilityHealthCheck checks the bindings of the clusters which the update run has updated so far, i.e., the
+// clusters in the finished stages and the clusters which have finished updating in the current stage.
+// The check passes when the binding of each of these clusters points to the resource snapshot of the update run,
+// and its Available condition has been true for the current generation of the binding for at least
+// MinAvailableDuration. It passes right away if no cluster has been updated yet.
+type AvailabilityHealthCheck struct {
+	// MinAvailableDuration is the minimum time the resources on each updated cluster must have been available.
+	// Defaults to 0, i.e., the resources only need to be available.
+	// +kubebuilder:validation:Pattern="^0|([0-9]+(\\.[0-9]+)?(s|m|h))+$"
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Optional
+	MinAvailableDuration *metav1.Duration `json:"minAvailableDuration,omitempty"`
+}
+
+// PrometheusHealthCheck describes an instant query against a Prometheus-compatible HTTP API.
+// The query must return a scalar or an instant vector. The check passes when the query returns at least one sample
+// and every returned sample satisfies the comparison against the threshold.
+type PrometheusHealthCheck struct {
+	// Address is the base URL of the Prometheus-compatible HTTP API, e.g. http://prometheus.monitoring:9090.
+	// It must be one of the addresses allowed by the `--health-check-prometheus-addresses` flag of the hub agent;
+	// the health check fails right away otherwise.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern="^https?://.+$"
+	Address string `json:"address"`
+
+	// Query is the PromQL expression to evaluate.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Query string `json:"query"`
+
+	// Operator is the comparison applied between each returned sample and the threshold.
+	// +kubebuilder:validation:Enum=LessThan;LessThanOrEqual;GreaterThan;GreaterThanOrEqual;Equal
+	// +kubebuilder:validation:Required
+	Operator HealthCheckOperator `json:"operator"`
+
+	// Threshold is the decimal value that each returned sample is compared against.
+	// +kubebuilder:validation:Pattern="^-?[0-9]+(\\.[0-9]+)?$"
+	// +kubebuilder:validation:Required
+	Threshold string `json:"threshold"`
+}
+
+// HealthCheckOperator is the comparison operator used by a health check.
+// +enum
+type HealthCheckOperator string
+
+const (
+	// HealthCheckOperatorLessThan requires every sample to be less than the threshold.
+	HealthCheckOperatorLessThan HealthCheckOperator = "LessThan"
+
+	// HealthCheckOperatorLessThanOrEqual requires every sample to be less than or equal to the threshold.
+	HealthCheckOperatorLessThanOrEqual HealthCheckOperator = "LessThanOrEqual"
+
+	// HealthCheckOperatorGreaterThan requires every sample to be greater than the threshold.
+	HealthCheckOperatorGreaterThan HealthCheckOperator = "GreaterThan"
+
+	// HealthCheckOperatorGreaterThanOrEqual requires every sample to be greater than or equal to the threshold.
+	HealthCheckOperatorGreaterThanOrEqual HealthCheckOperator = "GreaterThanOrEqual"
+
+	// HealthCheckOperatorEqual requires every sample to be equal to the threshold.
+	HealthCheckOperatorEqual HealthCheckOperator = "Equal"
+)
+
 // UpdateRunStatus defines the observed state of the ClusterStagedUpdateRun.
 type UpdateRunStatus struct {
 	// PolicySnapShotIndexUsed records the policy snapshot index of the ClusterResourcePlacement (CRP) that
@@ -400,13 +599,18 @@ type UpdateRunStatus struct {
 	// +kubebuilder:validation:Optional
 	DeletionStageStatus *StageUpdatingStatus `json:"deletionStageStatus,omitempty"`
 
+	// RollbackStatus records the progress of rolling back the updated clusters after the update run failed.
+	// It is empty if the update run has not failed or the rollback policy does not require a rollback.
+	// +kubebuilder:validation:Optional
+	RollbackStatus *RollbackStatus `json:"rollbackStatus,omitempty"`
+
 	// +patchMergeKey=type
 	// +patchStrategy=merge
 	// +listType=map
 	// +listMapKey=type
 	//
 	// Conditions is an array of current observed conditions for StagedUpdateRun.
-	// Known conditions are "Initialized", "Progressing", "Succeeded".
+	// Known conditions are "Initialized", "Progressing", "Succeeded", "RolledBack".
 	// +kubebuilder:validation:Optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
@@ -435,8 +639,30 @@ const (
 	// - "True": The staged update run is completed successfully.
 	// - "False": The staged update run encountered an error and stopped.
 	StagedUpdateRunConditionSucceeded StagedUpdateRunConditionType = "Succeeded"
+
+	// StagedUpdateRunConditionRolledBack indicates whether the clusters updated by a failed staged update run
+	// are rolled back to the previous resource snapshot.
+	// Its condition status can be one of the following:
+	// - "True": All the updated clusters are rolled back successfully.
+	// - "False": The rollback encountered an error and stopped.
+	// - "Unknown": The rollback is in progress.
+	StagedUpdateRunConditionRolledBack StagedUpdateRunConditionType = "RolledBack"
 )
 
+// RollbackStatus defines the status of rolling back a failed update run.
+type RollbackStatus struct {
+	// ResourceSnapshotIndex records the index of the resource snapshot that the updated clusters are rolled back to.
+	// It is empty if the rollback has not found the previous resource snapshot yet.
+	// +kubebuilder:validation:Optional
+	ResourceSnapshotIndex string `json:"resourceSnapshotIndex,omitempty"`
+
+	// StagesStatus lists the rollback status of each stage that had started updating clusters when the update run failed.
+	// The stages are listed in the reverse order of the update, and so are the clusters in each stage.
+	// Only the clusters that had started updating are included, except for the newly scheduled ones.
+	// +kubebuilder:validation:Optional
+	StagesStatus []StageUpdatingStatus `json:"stagesStatus,omitempty"`
+}
+
 // StageUpdatingStatus defines the status of the update run in a stage.
 type StageUpdatingStatus struct {
 	// The name of the stage.
@@ -449,7 +675,7 @@ type StageUpdatingStatus struct {
 
 	// The status of the post-update tasks associated with the current stage.
 	// Empty if the stage has not finished updating all the clusters.
-	// +kubebuilder:validation:MaxItems=2
+	// +kubebuilder:validation:MaxItems=3
 	// +kubebuilder:validation:Optional
 	AfterStageTaskStatus []StageTaskStatus `json:"afterStageTaskStatus,omitempty"`
 
@@ -470,6 +696,11 @@ type StageUpdatingStatus struct {
 	// +kubebuilder:validation:Format=date-time
 	EndTime *metav1.Time `json:"endTime,omitempty"`
 
+	// CanaryStatus records the status of the canary phase of the stage.
+	// Empty if the stage has no canary phase or no cluster.
+	// +kubebuilder:validation:Optional
+	CanaryStatus *CanaryStatus `json:"canaryStatus,omitempty"`
+
 	// +patchMergeKey=type
 	// +patchStrategy=merge
 	// +listType=map
@@ -500,6 +731,51 @@ const (
 	StageUpdatingConditionSucceeded StageUpdatingConditionType = "Succeeded"
 )
 
+// CanaryStatus defines the status of the canary phase of a stage.
+type CanaryStatus struct {
+	// Clusters lists the names of the canary clusters in the stage.
+	// +kubebuilder:validation:Optional
+	Clusters []string `json:"clusters,omitempty"`
+
+	// The status of the tasks that need to be completed after the bake time before updating the rest of the clusters.
+	// +kubebuilder:validation:MaxItems=1
+	// +kubebuilder:validation:Optional
+	AfterCanaryTaskStatus []StageTaskStatus `json:"afterCanaryTaskStatus,omitempty"`
+
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	//
+	// Conditions is an array of current observed conditions for the canary phase.
+	// Known conditions are "ClustersUpdated", "BakeTimeElapsed", "Succeeded".
+	// +kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// CanaryConditionType identifies a specific condition of the canary phase of a stage.
+// +enum
+type CanaryConditionType string
+
+const (
+	// CanaryConditionClustersUpdated indicates whether all the canary clusters are updated.
+	// The bake time starts when the condition becomes "True".
+	// Its condition status can be one of the following:
+	// - "True": All the canary clusters are updated successfully.
+	CanaryConditionClustersUpdated CanaryConditionType = "ClustersUpdated"
+
+	// CanaryConditionBakeTimeElapsed indicates whether the bake time has elapsed after the canary clusters are updated.
+	// Its condition status can be one of the following:
+	// - "True": The bake time has elapsed.
+	CanaryConditionBakeTimeElapsed CanaryConditionType = "BakeTimeElapsed"
+
+	// CanaryConditionSucceeded indicates whether the canary phase is completed successfully and the rest of
+	// the clusters in the stage can be updated.
+	// Its condition status can be one of the following:
+	// - "True": The canary phase is completed successfully.
+	CanaryConditionSucceeded CanaryConditionType = "Succeeded"
+)
+
 // ClusterUpdatingStatus defines the status of the update run on a cluster.
 type ClusterUpdatingStatus struct {
 	// The name of the cluster.
@@ -519,6 +795,11 @@ type ClusterUpdatingStatus struct {
 	// +kubebuilder:validation:Optional
 	ClusterResourceOverrideSnapshots []string `json:"clusterResourceOverrideSnapshots,omitempty"`
 
+	// NewlyScheduled indicates that the binding of the cluster was only scheduled, i.e., no resources had been placed
+	// on the cluster, when the update run was initialized. Such clusters are not rolled back when the update run fails.
+	// +kubebuilder:validation:Optional
+	NewlyScheduled bool `json:"newlyScheduled,omitempty"`
+
 	// +patchMergeKey=type
 	// +patchStrategy=merge
 	// +listType=map
@@ -549,12 +830,12 @@ const (
 
 type StageTaskStatus struct {
 	// The type of the pre or post update task.
-	// +kubebuilder:validation:Enum=TimedWait;Approval
+	// +kubebuilder:validation:Enum=TimedWait;Approval;HealthCheck
 	// +kubebuilder:validation:Required
 	Type StageTaskType `json:"type"`
 
 	// The name of the approval request object that is created for this stage.
-	// Only valid if the AfterStageTaskType is Approval.
+	// Only valid if the task type is Approval or HealthCheck.
 	// +kubebuilder:validation:Optional
 	ApprovalRequestName string `json:"approvalRequestName,omitempty"`
 
@@ -565,6 +846,7 @@ type StageTaskStatus struct {
 	//
 	// Conditions is an array of current observed conditions for the specific type of pre or post update task.
 	// Known conditions are "ApprovalRequestCreated", "WaitTimeElapsed", and "ApprovalRequestApproved".
+	// HealthCheck tasks report the same conditions as Approval tasks since they are approved by the controller.
 	// +kubebuilder:validation:Optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
@@ -579,6 +861,10 @@ const (
 
 	// StageTaskTypeApproval indicates the stage task is an approval.
 	StageTaskTypeApproval StageTaskType = "Approval"
+
+	// StageTaskTypeHealthCheck indicates the stage task is a health check whose approval request is approved or
+	// rejected by the update run controller based on the result of a health query.
+	StageTaskTypeHealthCheck StageTaskType = "HealthCheck"
 )
 
 // StageTaskConditionType identifies a specific condition of the AfterStageTask or BeforeStageTask.
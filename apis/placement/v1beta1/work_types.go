@@ -45,6 +45,19 @@ const (
 	// LastAppliedConfigAnnotation is to record the last applied configuration on the object.
 	LastAppliedConfigAnnotation = FleetPrefix + "last-applied-configuration"
 
+	// ApplyWaveAnnotation is the annotation that users can add to a placed resource to specify the apply wave
+	// it belongs to. The value must be an integer between 0 and 999 (inclusive); resources in a lower wave are
+	// applied first, and a wave with resources that carry this annotation is applied only after all the
+	// resources in the previous waves have become available.
+	//
+	// Resources without this annotation are assigned to a default wave based on their resource types.
+	ApplyWaveAnnotation = FleetPrefix + "apply-wave"
+
+	// SkipDriftRemediationAnnotation is the annotation that users can add to a placed resource, either
+	// in the hub cluster manifest or on the resource in the member cluster, to opt the resource out of
+	// automatic drift remediation. The only accepted value is "true".
+	SkipDriftRemediationAnnotation = FleetPrefix + "skip-drift-remediation"
+
 	// WorkConditionTypeApplied represents workload in Work is applied successfully on the spoke cluster.
 	WorkConditionTypeApplied = "Applied"
 
@@ -79,10 +92,48 @@ type WorkSpec struct {
 }
 
 // WorkloadTemplate represents the manifest workload to be deployed on spoke cluster
+// +kubebuilder:validation:XValidation:rule="!(has(self.manifests) && has(self.compressedManifests))",message="manifests and compressedManifests are mutually exclusive"
 type WorkloadTemplate struct {
 	// Manifests represents a list of kubernetes resources to be deployed on the spoke cluster.
 	// +optional
 	Manifests []Manifest `json:"manifests,omitempty"`
+
+	// CompressedManifests is the compressed form of the list of kubernetes resources to be deployed
+	// on the spoke cluster. It is set in place of the Manifests field when the hub agent has manifest
+	// compression enabled.
+	// +optional
+	CompressedManifests *CompressedManifests `json:"compressedManifests,omitempty"`
+}
+
+// ManifestEncoding is the encoding of compressed manifests.
+// +enum
+type ManifestEncoding string
+
+const (
+	// ManifestEncodingGzip compresses the JSON array of the manifests with gzip.
+	ManifestEncodingGzip ManifestEncoding = "gzip"
+)
+
+// CompressedManifests is the compressed form of a list of manifests.
+type CompressedManifests struct {
+	// Encoding is the encoding of the compressed data.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=gzip
+	Encoding ManifestEncoding `json:"encoding"`
+
+	// Digest is the digest of the compressed data, in the form of "sha256:<hex>".
+	//
+	// Works with the same set of manifests have the same digest.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^sha256:[a-f0-9]{64}$`
+	Digest string `json:"digest"`
+
+	// Data is the compressed data.
+	//
+	// It is left empty if the data is kept in the external blob store instead, so that Works with the same
+	// set of manifests share one copy of the data; the member agent fetches the data by its digest.
+	// +optional
+	Data []byte `json:"data,omitempty"`
 }
 
 // Manifest represents a resource to be deployed on spoke cluster.
@@ -254,6 +305,44 @@ type ManifestCondition struct {
 	//
 	// +kubebuilder:validation:Optional
 	BackReportedStatus *BackReportedStatus `json:"backReportedStatus,omitempty"`
+
+	// DriftRemediationHistory lists the most recent drift remediations that Fleet has performed
+	// on the resource, as dictated by the drift remediation policy in the apply strategy. The
+	// latest remediation comes last.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=5
+	DriftRemediationHistory []DriftRemediation `json:"driftRemediationHistory,omitempty"`
+}
+
+// DriftRemediation describes a drift remediation, i.e., an apply op that Fleet has performed
+// automatically to revert the configuration drifts on a resource.
+type DriftRemediation struct {
+	// RemediationTime is the timestamp when Fleet reverted the drifts.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=date-time
+	RemediationTime metav1.Time `json:"remediationTime"`
+
+	// FirstDriftedObservedTime is the timestamp when the reverted drifts were first detected.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=date-time
+	FirstDriftedObservedTime metav1.Time `json:"firstDriftedObservedTime"`
+
+	// ObservedInMemberClusterGeneration is the generation of the resource on the member cluster
+	// side that had the drifts.
+	// +kubebuilder:validation:Required
+	ObservedInMemberClusterGeneration int64 `json:"observedInMemberClusterGeneration"`
+
+	// RemediatedDriftPaths lists the JSON paths of the drifted fields that Fleet has reverted.
+	// Fleet might truncate the list as appropriate to control object size.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=20
+	RemediatedDriftPaths []string `json:"remediatedDriftPaths,omitempty"`
 }
 
 // +genclient
@@ -21,6 +21,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -34,6 +35,16 @@ func (in *Affinity) DeepCopyInto(out *Affinity) {
 		*out = new(ClusterAffinity)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PlacementAffinity != nil {
+		in, out := &in.PlacementAffinity, &out.PlacementAffinity
+		*out = new(PlacementAffinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PlacementAntiAffinity != nil {
+		in, out := &in.PlacementAntiAffinity, &out.PlacementAntiAffinity
+		*out = new(PlacementAntiAffinity)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Affinity.
@@ -164,6 +175,18 @@ func (in *ApplyStrategy) DeepCopyInto(out *ApplyStrategy) {
 		*out = new(ServerSideApplyConfig)
 		**out = **in
 	}
+	if in.IgnoreDifferences != nil {
+		in, out := &in.IgnoreDifferences, &out.IgnoreDifferences
+		*out = make([]IgnoreDifferenceRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DriftRemediation != nil {
+		in, out := &in.DriftRemediation, &out.DriftRemediation
+		*out = new(DriftRemediationPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplyStrategy.
@@ -272,6 +295,136 @@ func (in *ApprovalRequestStatus) DeepCopy() *ApprovalRequestStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AvailabilityHealthCheck) DeepCopyInto(out *AvailabilityHealthCheck) {
+	*out = *in
+	if in.MinAvailableDuration != nil {
+		in, out := &in.MinAvailableDuration, &out.MinAvailableDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AvailabilityHealthCheck.
+func (in *AvailabilityHealthCheck) DeepCopy() *AvailabilityHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(AvailabilityHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AvailabilityRule) DeepCopyInto(out *AvailabilityRule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AvailabilityRule.
+func (in *AvailabilityRule) DeepCopy() *AvailabilityRule {
+	if in == nil {
+		return nil
+	}
+	out := new(AvailabilityRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AvailabilityRule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AvailabilityRuleList) DeepCopyInto(out *AvailabilityRuleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AvailabilityRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AvailabilityRuleList.
+func (in *AvailabilityRuleList) DeepCopy() *AvailabilityRuleList {
+	if in == nil {
+		return nil
+	}
+	out := new(AvailabilityRuleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AvailabilityRuleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AvailabilityRuleResourceType) DeepCopyInto(out *AvailabilityRuleResourceType) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AvailabilityRuleResourceType.
+func (in *AvailabilityRuleResourceType) DeepCopy() *AvailabilityRuleResourceType {
+	if in == nil {
+		return nil
+	}
+	out := new(AvailabilityRuleResourceType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AvailabilityRuleSpec) DeepCopyInto(out *AvailabilityRuleSpec) {
+	*out = *in
+	out.ResourceType = in.ResourceType
+	if in.AvailableWhen != nil {
+		in, out := &in.AvailableWhen, &out.AvailableWhen
+		*out = make([]StatusExpression, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ProgressingWhen != nil {
+		in, out := &in.ProgressingWhen, &out.ProgressingWhen
+		*out = make([]StatusExpression, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FailedWhen != nil {
+		in, out := &in.FailedWhen, &out.FailedWhen
+		*out = make([]StatusExpression, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AvailabilityRuleSpec.
+func (in *AvailabilityRuleSpec) DeepCopy() *AvailabilityRuleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AvailabilityRuleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackReportedStatus) DeepCopyInto(out *BackReportedStatus) {
 	*out = *in
@@ -289,6 +442,88 @@ func (in *BackReportedStatus) DeepCopy() *BackReportedStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CELOverride) DeepCopyInto(out *CELOverride) {
+	*out = *in
+	in.Value.DeepCopyInto(&out.Value)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CELOverride.
+func (in *CELOverride) DeepCopy() *CELOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(CELOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryConfig) DeepCopyInto(out *CanaryConfig) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.BakeTime != nil {
+		in, out := &in.BakeTime, &out.BakeTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.AfterCanaryTasks != nil {
+		in, out := &in.AfterCanaryTasks, &out.AfterCanaryTasks
+		*out = make([]StageTask, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryConfig.
+func (in *CanaryConfig) DeepCopy() *CanaryConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryStatus) DeepCopyInto(out *CanaryStatus) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AfterCanaryTaskStatus != nil {
+		in, out := &in.AfterCanaryTaskStatus, &out.AfterCanaryTaskStatus
+		*out = make([]StageTaskStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryStatus.
+func (in *CanaryStatus) DeepCopy() *CanaryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterAffinity) DeepCopyInto(out *ClusterAffinity) {
 	*out = *in
@@ -395,6 +630,46 @@ func (in *ClusterDecision) DeepCopy() *ClusterDecision {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPlacementPreview) DeepCopyInto(out *ClusterPlacementPreview) {
+	*out = *in
+	in.ClusterDecision.DeepCopyInto(&out.ClusterDecision)
+	if in.ApplicableClusterResourceOverrides != nil {
+		in, out := &in.ApplicableClusterResourceOverrides, &out.ApplicableClusterResourceOverrides
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ApplicableResourceOverrides != nil {
+		in, out := &in.ApplicableResourceOverrides, &out.ApplicableResourceOverrides
+		*out = make([]NamespacedName, len(*in))
+		copy(*out, *in)
+	}
+	if in.DiffedPlacements != nil {
+		in, out := &in.DiffedPlacements, &out.DiffedPlacements
+		*out = make([]DiffedResourcePlacement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPlacementPreview.
+func (in *ClusterPlacementPreview) DeepCopy() *ClusterPlacementPreview {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPlacementPreview)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterResourceBinding) DeepCopyInto(out *ClusterResourceBinding) {
 	*out = *in
@@ -863,6 +1138,65 @@ func (in *ClusterResourcePlacementList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterResourcePlacementPreview) DeepCopyInto(out *ClusterResourcePlacementPreview) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterResourcePlacementPreview.
+func (in *ClusterResourcePlacementPreview) DeepCopy() *ClusterResourcePlacementPreview {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterResourcePlacementPreview)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterResourcePlacementPreview) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterResourcePlacementPreviewList) DeepCopyInto(out *ClusterResourcePlacementPreviewList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterResourcePlacementPreview, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterResourcePlacementPreviewList.
+func (in *ClusterResourcePlacementPreviewList) DeepCopy() *ClusterResourcePlacementPreviewList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterResourcePlacementPreviewList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterResourcePlacementPreviewList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterResourcePlacementStatus) DeepCopyInto(out *ClusterResourcePlacementStatus) {
 	*out = *in
@@ -1122,7 +1456,7 @@ func (in *ClusterStagedUpdateRun) DeepCopyInto(out *ClusterStagedUpdateRun) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -1266,6 +1600,26 @@ func (in *ClusterUpdatingStatus) DeepCopy() *ClusterUpdatingStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CompressedManifests) DeepCopyInto(out *CompressedManifests) {
+	*out = *in
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompressedManifests.
+func (in *CompressedManifests) DeepCopy() *CompressedManifests {
+	if in == nil {
+		return nil
+	}
+	out := new(CompressedManifests)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeleteStrategy) DeepCopyInto(out *DeleteStrategy) {
 	*out = *in
@@ -1358,6 +1712,71 @@ func (in *DriftDetails) DeepCopy() *DriftDetails {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftRemediatedResourcePlacement) DeepCopyInto(out *DriftRemediatedResourcePlacement) {
+	*out = *in
+	in.ResourceIdentifier.DeepCopyInto(&out.ResourceIdentifier)
+	if in.DriftRemediationHistory != nil {
+		in, out := &in.DriftRemediationHistory, &out.DriftRemediationHistory
+		*out = make([]DriftRemediation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftRemediatedResourcePlacement.
+func (in *DriftRemediatedResourcePlacement) DeepCopy() *DriftRemediatedResourcePlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftRemediatedResourcePlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftRemediation) DeepCopyInto(out *DriftRemediation) {
+	*out = *in
+	in.RemediationTime.DeepCopyInto(&out.RemediationTime)
+	in.FirstDriftedObservedTime.DeepCopyInto(&out.FirstDriftedObservedTime)
+	if in.RemediatedDriftPaths != nil {
+		in, out := &in.RemediatedDriftPaths, &out.RemediatedDriftPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftRemediation.
+func (in *DriftRemediation) DeepCopy() *DriftRemediation {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftRemediation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftRemediationPolicy) DeepCopyInto(out *DriftRemediationPolicy) {
+	*out = *in
+	if in.GracePeriodSeconds != nil {
+		in, out := &in.GracePeriodSeconds, &out.GracePeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftRemediationPolicy.
+func (in *DriftRemediationPolicy) DeepCopy() *DriftRemediationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftRemediationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DriftedResourcePlacement) DeepCopyInto(out *DriftedResourcePlacement) {
 	*out = *in
@@ -1413,6 +1832,102 @@ func (in *FailedResourcePlacement) DeepCopy() *FailedResourcePlacement {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthCheckConfig) DeepCopyInto(out *HealthCheckConfig) {
+	*out = *in
+	if in.Prometheus != nil {
+		in, out := &in.Prometheus, &out.Prometheus
+		*out = new(PrometheusHealthCheck)
+		**out = **in
+	}
+	if in.Availability != nil {
+		in, out := &in.Availability, &out.Availability
+		*out = new(AvailabilityHealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheckConfig.
+func (in *HealthCheckConfig) DeepCopy() *HealthCheckConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheckConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HelmChart) DeepCopyInto(out *HelmChart) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmChart.
+func (in *HelmChart) DeepCopy() *HelmChart {
+	if in == nil {
+		return nil
+	}
+	out := new(HelmChart)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HelmSource) DeepCopyInto(out *HelmSource) {
+	*out = *in
+	out.Chart = in.Chart
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmSource.
+func (in *HelmSource) DeepCopy() *HelmSource {
+	if in == nil {
+		return nil
+	}
+	out := new(HelmSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IgnoreDifferenceRule) DeepCopyInto(out *IgnoreDifferenceRule) {
+	*out = *in
+	if in.JSONPointers != nil {
+		in, out := &in.JSONPointers, &out.JSONPointers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ManagedFieldsManagers != nil {
+		in, out := &in.ManagedFieldsManagers, &out.ManagedFieldsManagers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IgnoreDifferenceRule.
+func (in *IgnoreDifferenceRule) DeepCopy() *IgnoreDifferenceRule {
+	if in == nil {
+		return nil
+	}
+	out := new(IgnoreDifferenceRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JSONPatchOverride) DeepCopyInto(out *JSONPatchOverride) {
 	*out = *in
@@ -1429,6 +1944,42 @@ func (in *JSONPatchOverride) DeepCopy() *JSONPatchOverride {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KustomizeSource) DeepCopyInto(out *KustomizeSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KustomizeSource.
+func (in *KustomizeSource) DeepCopy() *KustomizeSource {
+	if in == nil {
+		return nil
+	}
+	out := new(KustomizeSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+	if in.TimeZone != nil {
+		in, out := &in.TimeZone, &out.TimeZone
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Manifest) DeepCopyInto(out *Manifest) {
 	*out = *in
@@ -1471,6 +2022,13 @@ func (in *ManifestCondition) DeepCopyInto(out *ManifestCondition) {
 		*out = new(BackReportedStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DriftRemediationHistory != nil {
+		in, out := &in.DriftRemediationHistory, &out.DriftRemediationHistory
+		*out = make([]DriftRemediation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestCondition.
@@ -1483,6 +2041,22 @@ func (in *ManifestCondition) DeepCopy() *ManifestCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MergePatchOverride) DeepCopyInto(out *MergePatchOverride) {
+	*out = *in
+	in.Patch.DeepCopyInto(&out.Patch)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MergePatchOverride.
+func (in *MergePatchOverride) DeepCopy() *MergePatchOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(MergePatchOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NamespacedName) DeepCopyInto(out *NamespacedName) {
 	*out = *in
@@ -1535,6 +2109,18 @@ func (in *OverrideRule) DeepCopyInto(out *OverrideRule) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.MergePatchOverride != nil {
+		in, out := &in.MergePatchOverride, &out.MergePatchOverride
+		*out = new(MergePatchOverride)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CELOverrides != nil {
+		in, out := &in.CELOverrides, &out.CELOverrides
+		*out = make([]CELOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OverrideRule.
@@ -1589,6 +2175,13 @@ func (in *PerClusterPlacementStatus) DeepCopyInto(out *PerClusterPlacementStatus
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DriftRemediatedPlacements != nil {
+		in, out := &in.DriftRemediatedPlacements, &out.DriftRemediatedPlacements
+		*out = make([]DriftRemediatedResourcePlacement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.DiffedPlacements != nil {
 		in, out := &in.DiffedPlacements, &out.DiffedPlacements
 		*out = make([]DiffedResourcePlacement, len(*in))
@@ -1596,21 +2189,95 @@ func (in *PerClusterPlacementStatus) DeepCopyInto(out *PerClusterPlacementStatus
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerClusterPlacementStatus.
+func (in *PerClusterPlacementStatus) DeepCopy() *PerClusterPlacementStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PerClusterPlacementStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlacementAffinity) DeepCopyInto(out *PlacementAffinity) {
+	*out = *in
+	if in.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+		in, out := &in.RequiredDuringSchedulingIgnoredDuringExecution, &out.RequiredDuringSchedulingIgnoredDuringExecution
+		*out = make([]PlacementAffinityTerm, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PreferredDuringSchedulingIgnoredDuringExecution != nil {
+		in, out := &in.PreferredDuringSchedulingIgnoredDuringExecution, &out.PreferredDuringSchedulingIgnoredDuringExecution
+		*out = make([]WeightedPlacementAffinityTerm, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlacementAffinity.
+func (in *PlacementAffinity) DeepCopy() *PlacementAffinity {
+	if in == nil {
+		return nil
+	}
+	out := new(PlacementAffinity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlacementAffinityTerm) DeepCopyInto(out *PlacementAffinityTerm) {
+	*out = *in
+	in.PlacementSelector.DeepCopyInto(&out.PlacementSelector)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlacementAffinityTerm.
+func (in *PlacementAffinityTerm) DeepCopy() *PlacementAffinityTerm {
+	if in == nil {
+		return nil
+	}
+	out := new(PlacementAffinityTerm)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlacementAntiAffinity) DeepCopyInto(out *PlacementAntiAffinity) {
+	*out = *in
+	if in.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+		in, out := &in.RequiredDuringSchedulingIgnoredDuringExecution, &out.RequiredDuringSchedulingIgnoredDuringExecution
+		*out = make([]PlacementAffinityTerm, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PreferredDuringSchedulingIgnoredDuringExecution != nil {
+		in, out := &in.PreferredDuringSchedulingIgnoredDuringExecution, &out.PreferredDuringSchedulingIgnoredDuringExecution
+		*out = make([]WeightedPlacementAffinityTerm, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerClusterPlacementStatus.
-func (in *PerClusterPlacementStatus) DeepCopy() *PerClusterPlacementStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlacementAntiAffinity.
+func (in *PlacementAntiAffinity) DeepCopy() *PlacementAntiAffinity {
 	if in == nil {
 		return nil
 	}
-	out := new(PerClusterPlacementStatus)
+	out := new(PlacementAntiAffinity)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1705,7 +2372,9 @@ func (in *PlacementPolicy) DeepCopyInto(out *PlacementPolicy) {
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
 		*out = make([]Toleration, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -1719,6 +2388,58 @@ func (in *PlacementPolicy) DeepCopy() *PlacementPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlacementPreviewSpec) DeepCopyInto(out *PlacementPreviewSpec) {
+	*out = *in
+	in.PlacementSpec.DeepCopyInto(&out.PlacementSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlacementPreviewSpec.
+func (in *PlacementPreviewSpec) DeepCopy() *PlacementPreviewSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PlacementPreviewSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlacementPreviewStatus) DeepCopyInto(out *PlacementPreviewStatus) {
+	*out = *in
+	if in.SelectedResources != nil {
+		in, out := &in.SelectedResources, &out.SelectedResources
+		*out = make([]ResourceIdentifier, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]ClusterPlacementPreview, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlacementPreviewStatus.
+func (in *PlacementPreviewStatus) DeepCopy() *PlacementPreviewStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PlacementPreviewStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PlacementRef) DeepCopyInto(out *PlacementRef) {
 	*out = *in
@@ -1744,6 +2465,13 @@ func (in *PlacementSpec) DeepCopyInto(out *PlacementSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ResourceSources != nil {
+		in, out := &in.ResourceSources, &out.ResourceSources
+		*out = make([]ResourceSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Policy != nil {
 		in, out := &in.Policy, &out.Policy
 		*out = new(PlacementPolicy)
@@ -1819,6 +2547,21 @@ func (in *PreferredClusterSelector) DeepCopy() *PreferredClusterSelector {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusHealthCheck) DeepCopyInto(out *PrometheusHealthCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusHealthCheck.
+func (in *PrometheusHealthCheck) DeepCopy() *PrometheusHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PropertySelector) DeepCopyInto(out *PropertySelector) {
 	*out = *in
@@ -1876,6 +2619,105 @@ func (in *PropertySorter) DeepCopy() *PropertySorter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedactionRule) DeepCopyInto(out *RedactionRule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedactionRule.
+func (in *RedactionRule) DeepCopy() *RedactionRule {
+	if in == nil {
+		return nil
+	}
+	out := new(RedactionRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RedactionRule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedactionRuleList) DeepCopyInto(out *RedactionRuleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RedactionRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedactionRuleList.
+func (in *RedactionRuleList) DeepCopy() *RedactionRuleList {
+	if in == nil {
+		return nil
+	}
+	out := new(RedactionRuleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RedactionRuleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedactionRuleResourceType) DeepCopyInto(out *RedactionRuleResourceType) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedactionRuleResourceType.
+func (in *RedactionRuleResourceType) DeepCopy() *RedactionRuleResourceType {
+	if in == nil {
+		return nil
+	}
+	out := new(RedactionRuleResourceType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedactionRuleSpec) DeepCopyInto(out *RedactionRuleSpec) {
+	*out = *in
+	out.ResourceType = in.ResourceType
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PathRegexes != nil {
+		in, out := &in.PathRegexes, &out.PathRegexes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedactionRuleSpec.
+func (in *RedactionRuleSpec) DeepCopy() *RedactionRuleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RedactionRuleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReportBackStrategy) DeepCopyInto(out *ReportBackStrategy) {
 	*out = *in
@@ -2003,6 +2845,13 @@ func (in *ResourceBindingStatus) DeepCopyInto(out *ResourceBindingStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DriftRemediatedPlacements != nil {
+		in, out := &in.DriftRemediatedPlacements, &out.DriftRemediatedPlacements
+		*out = make([]DriftRemediatedResourcePlacement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.DiffedPlacements != nil {
 		in, out := &in.DiffedPlacements, &out.DiffedPlacements
 		*out = make([]DiffedResourcePlacement, len(*in))
@@ -2493,6 +3342,73 @@ func (in *ResourceSnapshotStatus) DeepCopy() *ResourceSnapshotStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceSource) DeepCopyInto(out *ResourceSource) {
+	*out = *in
+	if in.Kustomize != nil {
+		in, out := &in.Kustomize, &out.Kustomize
+		*out = new(KustomizeSource)
+		**out = **in
+	}
+	if in.Helm != nil {
+		in, out := &in.Helm, &out.Helm
+		*out = new(HelmSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSource.
+func (in *ResourceSource) DeepCopy() *ResourceSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollbackPolicy) DeepCopyInto(out *RollbackPolicy) {
+	*out = *in
+	if in.StuckTimeout != nil {
+		in, out := &in.StuckTimeout, &out.StuckTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollbackPolicy.
+func (in *RollbackPolicy) DeepCopy() *RollbackPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RollbackPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollbackStatus) DeepCopyInto(out *RollbackStatus) {
+	*out = *in
+	if in.StagesStatus != nil {
+		in, out := &in.StagesStatus, &out.StagesStatus
+		*out = make([]StageUpdatingStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollbackStatus.
+func (in *RollbackStatus) DeepCopy() *RollbackStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RollbackStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RollingUpdateConfig) DeepCopyInto(out *RollingUpdateConfig) {
 	*out = *in
@@ -2511,6 +3427,13 @@ func (in *RollingUpdateConfig) DeepCopyInto(out *RollingUpdateConfig) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdateConfig.
@@ -2718,6 +3641,18 @@ func (in *StageConfig) DeepCopyInto(out *StageConfig) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(CanaryConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StageConfig.
@@ -2738,6 +3673,11 @@ func (in *StageTask) DeepCopyInto(out *StageTask) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(HealthCheckConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StageTask.
@@ -2804,6 +3744,11 @@ func (in *StageUpdatingStatus) DeepCopyInto(out *StageUpdatingStatus) {
 		in, out := &in.EndTime, &out.EndTime
 		*out = (*in).DeepCopy()
 	}
+	if in.CanaryStatus != nil {
+		in, out := &in.CanaryStatus, &out.CanaryStatus
+		*out = new(CanaryStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -2828,7 +3773,7 @@ func (in *StagedUpdateRun) DeepCopyInto(out *StagedUpdateRun) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -2940,9 +3885,34 @@ func (in *StagedUpdateStrategyList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatusExpression) DeepCopyInto(out *StatusExpression) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatusExpression.
+func (in *StatusExpression) DeepCopy() *StatusExpression {
+	if in == nil {
+		return nil
+	}
+	out := new(StatusExpression)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Toleration) DeepCopyInto(out *Toleration) {
 	*out = *in
+	if in.TolerationSeconds != nil {
+		in, out := &in.TolerationSeconds, &out.TolerationSeconds
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Toleration.
@@ -2978,6 +3948,11 @@ func (in *TopologySpreadConstraint) DeepCopy() *TopologySpreadConstraint {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UpdateRunSpec) DeepCopyInto(out *UpdateRunSpec) {
 	*out = *in
+	if in.RollbackPolicy != nil {
+		in, out := &in.RollbackPolicy, &out.RollbackPolicy
+		*out = new(RollbackPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpdateRunSpec.
@@ -3015,6 +3990,11 @@ func (in *UpdateRunStatus) DeepCopyInto(out *UpdateRunStatus) {
 		*out = new(StageUpdatingStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RollbackStatus != nil {
+		in, out := &in.RollbackStatus, &out.RollbackStatus
+		*out = new(RollbackStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -3044,6 +4024,13 @@ func (in *UpdateStrategySpec) DeepCopyInto(out *UpdateStrategySpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpdateStrategySpec.
@@ -3056,6 +4043,22 @@ func (in *UpdateStrategySpec) DeepCopy() *UpdateStrategySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WeightedPlacementAffinityTerm) DeepCopyInto(out *WeightedPlacementAffinityTerm) {
+	*out = *in
+	in.PlacementAffinityTerm.DeepCopyInto(&out.PlacementAffinityTerm)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WeightedPlacementAffinityTerm.
+func (in *WeightedPlacementAffinityTerm) DeepCopy() *WeightedPlacementAffinityTerm {
+	if in == nil {
+		return nil
+	}
+	out := new(WeightedPlacementAffinityTerm)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Work) DeepCopyInto(out *Work) {
 	*out = *in
@@ -3195,6 +4198,11 @@ func (in *WorkloadTemplate) DeepCopyInto(out *WorkloadTemplate) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.CompressedManifests != nil {
+		in, out := &in.CompressedManifests, &out.CompressedManifests
+		*out = new(CompressedManifests)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadTemplate.
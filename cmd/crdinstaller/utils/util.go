@@ -37,7 +37,9 @@ var (
 		"multicluster.x-k8s.io_clusterprofiles.yaml": true,
 	}
 	memberCRD = map[string]bool{
-		"placement.kubernetes-fleet.io_appliedworks.yaml": true,
+		"placement.kubernetes-fleet.io_appliedworks.yaml":             true,
+		"cluster.kubernetes-fleet.io_clusterpropertydefinitions.yaml": true,
+		"cluster.kubernetes-fleet.io_clusterhealthchecks.yaml":        true,
 	}
 )
 
@@ -61,6 +61,7 @@ func runTest(t *testing.T, crdPath string) {
 				"memberclusters.cluster.kubernetes-fleet.io",
 				"internalmemberclusters.cluster.kubernetes-fleet.io",
 				"approvalrequests.placement.kubernetes-fleet.io",
+				"availabilityrules.placement.kubernetes-fleet.io",
 				"clusterapprovalrequests.placement.kubernetes-fleet.io",
 				"clusterresourcebindings.placement.kubernetes-fleet.io",
 				"clusterresourceenvelopes.placement.kubernetes-fleet.io",
@@ -70,10 +71,12 @@ func runTest(t *testing.T, crdPath string) {
 				"clusterresourceoverridesnapshots.placement.kubernetes-fleet.io",
 				"clusterresourceplacementdisruptionbudgets.placement.kubernetes-fleet.io",
 				"clusterresourceplacementevictions.placement.kubernetes-fleet.io",
+				"clusterresourceplacementpreviews.placement.kubernetes-fleet.io",
 				"clusterresourcesnapshots.placement.kubernetes-fleet.io",
 				"clusterschedulingpolicysnapshots.placement.kubernetes-fleet.io",
 				"clusterstagedupdateruns.placement.kubernetes-fleet.io",
 				"clusterstagedupdatestrategies.placement.kubernetes-fleet.io",
+				"redactionrules.placement.kubernetes-fleet.io",
 				"resourcebindings.placement.kubernetes-fleet.io",
 				"resourceenvelopes.placement.kubernetes-fleet.io",
 				"resourceoverrides.placement.kubernetes-fleet.io",
@@ -93,6 +96,8 @@ func runTest(t *testing.T, crdPath string) {
 			mode: "member",
 			wantedCRDNames: []string{
 				"appliedworks.placement.kubernetes-fleet.io",
+				"clusterpropertydefinitions.cluster.kubernetes-fleet.io",
+				"clusterhealthchecks.cluster.kubernetes-fleet.io",
 			},
 			wantError: false,
 		},
@@ -18,6 +18,7 @@ package options
 
 import (
 	"flag"
+	"strings"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -97,6 +98,9 @@ type Options struct {
 	ForceDeleteWaitTime metav1.Duration
 	// EnableStagedUpdateRunAPIs enables the agents to watch the clusterStagedUpdateRun CRs.
 	EnableStagedUpdateRunAPIs bool
+	// HealthCheckPrometheusAddresses are the base URLs of the Prometheus-compatible HTTP APIs which the health check
+	// stage tasks of the staged update runs can query. If not set, no health check can query Prometheus.
+	HealthCheckPrometheusAddresses []string
 	// EnableEvictionAPIs enables to agents to watch the eviction and placement disruption budget CRs.
 	EnableEvictionAPIs bool
 	// EnableResourcePlacement enables the agents to watch the ResourcePlacement APIs.
@@ -120,6 +124,46 @@ type Options struct {
 	ResourceChangesCollectionDuration time.Duration
 	// AzurePropertyCheckerOpts contains options for Azure property checker
 	AzurePropertyCheckerOpts AzurePropertyCheckerOptions
+	// SchedulerExtenderConfigFile is the path to the file which configures the scheduler extenders of the
+	// default scheduling profile.
+	SchedulerExtenderConfigFile string
+	// SchedulerConfigFile is the path to the versioned scheduler configuration file, which defines
+	// the scheduling profiles, including their plugins and extenders.
+	SchedulerConfigFile string
+	// EnableRebalancer enables the rebalancer, which periodically re-scores the clusters that placements of the
+	// PickN placement type have been scheduled to, and migrates a placement to a better cluster when the
+	// improvement in score crosses the RebalanceScoreThreshold.
+	EnableRebalancer bool
+	// RebalanceInterval is the interval at which the rebalancer re-scores each placement.
+	RebalanceInterval time.Duration
+	// RebalanceScoreThreshold is the minimum improvement in cluster score which warrants a migration.
+	RebalanceScoreThreshold int
+	// EnableTaintBasedEviction enables the taint manager, which adds a NoExecute taint to member clusters that have
+	// been unhealthy or have left for UnhealthyClusterTaintDelay, and evicts placements which do not tolerate the
+	// NoExecute taints of a cluster, subject to their disruption budgets.
+	EnableTaintBasedEviction bool
+	// UnhealthyClusterTaintDelay is the duration for a member cluster to be unhealthy or to have left before the
+	// unhealthy NoExecute taint is added to it.
+	UnhealthyClusterTaintDelay time.Duration
+	// BlobStoreURL is the URL of the external blob store for the selected resources that are too large to be
+	// kept in the resource snapshots and the works. If not set, no external blob store is in use.
+	BlobStoreURL string
+	// BlobOffloadThresholdBytes is the size above which a selected resource is saved in the blob store, with only
+	// a reference to it kept in the resource snapshots and the works.
+	BlobOffloadThresholdBytes int
+	// HelmFileRepositoryRoot is the local directory under which the file:// chart repositories of the Helm sources
+	// of the placements must be. If not set, file:// chart repositories are not allowed.
+	HelmFileRepositoryRoot string
+	// HelmAllowedHosts are the hosts of the chart repositories and the OCI registries which the charts of the Helm
+	// sources of the placements can be fetched from. If not set, only file:// chart repositories can be used.
+	HelmAllowedHosts []string
+	// EnableWorkManifestCompression enables the work generator to keep the manifests in the works in the compressed
+	// form, which reduces the sizes of the works in the hub cluster.
+	EnableWorkManifestCompression bool
+	// EnablePlacementPreview enables the ClusterResourcePlacementPreview API, which previews what a placement would
+	// do, i.e., the selected resources, the scheduling decisions, the applicable overrides, and the diffs against
+	// the live objects in the member clusters, without making the placement.
+	EnablePlacementPreview bool
 }
 
 // NewOptions builds an empty options.
@@ -184,6 +228,16 @@ func (o *Options) AddFlags(flags *flag.FlagSet) {
 	flags.BoolVar(&o.EnableClusterInventoryAPIs, "enable-cluster-inventory-apis", true, "If set, the agents will watch for the ClusterInventory APIs.")
 	flags.DurationVar(&o.ForceDeleteWaitTime.Duration, "force-delete-wait-time", 15*time.Minute, "The duration the hub agent waits before force deleting a member cluster.")
 	flags.BoolVar(&o.EnableStagedUpdateRunAPIs, "enable-staged-update-run-apis", true, "If set, the agents will watch for the ClusterStagedUpdateRun APIs.")
+	flags.Func("health-check-prometheus-addresses",
+		"A comma-separated list of the base URLs of the Prometheus-compatible HTTP APIs, e.g. http://prometheus.monitoring:9090, which the health check stage tasks of the staged update runs can query. The health checks against any other address fail without being queried.",
+		func(value string) error {
+			for _, address := range strings.Split(value, ",") {
+				if address = strings.TrimSpace(address); address != "" {
+					o.HealthCheckPrometheusAddresses = append(o.HealthCheckPrometheusAddresses, address)
+				}
+			}
+			return nil
+		})
 	flags.BoolVar(&o.EnableEvictionAPIs, "enable-eviction-apis", true, "If set, the agents will watch for the Eviction and PlacementDisruptionBudget APIs.")
 	flags.BoolVar(&o.EnableResourcePlacement, "enable-resource-placement", true, "If set, the agents will watch for the ResourcePlacement APIs.")
 	flags.BoolVar(&o.EnablePprof, "enable-pprof", false, "If set, the pprof profiling is enabled.")
@@ -194,6 +248,37 @@ func (o *Options) AddFlags(flags *flag.FlagSet) {
 	flags.DurationVar(&o.ResourceSnapshotCreationMinimumInterval, "resource-snapshot-creation-minimum-interval", 30*time.Second, "The minimum interval at which resource snapshots could be created.")
 	flags.DurationVar(&o.ResourceChangesCollectionDuration, "resource-changes-collection-duration", 15*time.Second,
 		"The duration for collecting resource changes into one snapshot. The default is 15 seconds, which means that the controller will collect resource changes for 15 seconds before creating a resource snapshot.")
+	flags.StringVar(&o.SchedulerExtenderConfigFile, "scheduler-extender-config-file", "",
+		"The path to the YAML or JSON file which configures the scheduler extenders of the default scheduling profile, i.e., remote endpoints which filter and score clusters for placements. It cannot be used if the scheduler configuration file overrides the default profile, which should specify its extenders instead.")
+	flags.StringVar(&o.SchedulerConfigFile, "scheduler-config-file", "",
+		"The path to the YAML or JSON scheduler configuration file, which defines the scheduling profiles that placements can pick via their schedulerName. If not set, only the default profile is available.")
+	flags.BoolVar(&o.EnableRebalancer, "enable-rebalancer", false,
+		"If set, placements of the PickN placement type are periodically re-scored and migrated to better clusters, subject to their disruption budgets. Requires the eviction APIs to be enabled.")
+	flags.DurationVar(&o.RebalanceInterval, "rebalance-interval", 10*time.Minute, "The interval at which the rebalancer re-scores each placement.")
+	flags.IntVar(&o.RebalanceScoreThreshold, "rebalance-score-threshold", 20, "The minimum improvement in cluster score which warrants the rebalancer to migrate a placement.")
+	flags.BoolVar(&o.EnableTaintBasedEviction, "enable-taint-based-eviction", false,
+		"If set, member clusters which have been unhealthy or have left for the unhealthy cluster taint delay are tainted with a NoExecute taint, and placements which do not tolerate it are evicted from them, subject to their disruption budgets. Requires the eviction APIs to be enabled.")
+	flags.DurationVar(&o.UnhealthyClusterTaintDelay, "cluster-unhealthy-taint-delay", 5*time.Minute,
+		"The duration for a member cluster to be unhealthy or to have left before the unhealthy NoExecute taint is added to it, if taint based eviction is enabled.")
+	flags.StringVar(&o.BlobStoreURL, "blob-store-url", "",
+		"If set, the selected resources larger than the offload threshold are saved in the blob store at this URL, and the resource snapshots and the works only keep references to them. Supported URLs are file:///<directory> and s3://<bucket>[/<prefix>][?endpoint=<endpoint URL>&region=<region>]; the credentials for S3-compatible stores are read from the AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN environment variables. The member agents must be configured with the same store.")
+	flags.IntVar(&o.BlobOffloadThresholdBytes, "blob-offload-threshold-bytes", 64*(1<<10), "The size in bytes above which a selected resource is saved in the blob store, if one is in use.")
+	flags.StringVar(&o.HelmFileRepositoryRoot, "helm-file-repository-root", "",
+		"If set, the Helm sources of the placements can use the file:// chart repositories under this local directory.")
+	flags.Func("helm-allowed-hosts",
+		"A comma-separated list of the hosts of the chart repositories and the OCI registries, including their token services and the hosts they redirect to, which the charts of the Helm sources of the placements can be fetched from. An entry is a host name, which matches the host on any port, a host name with a port, or a wildcard such as *.example.com for the subdomains of example.com. If not set, no chart can be fetched over HTTP(S).",
+		func(value string) error {
+			for _, host := range strings.Split(value, ",") {
+				if host = strings.TrimSpace(host); host != "" {
+					o.HelmAllowedHosts = append(o.HelmAllowedHosts, host)
+				}
+			}
+			return nil
+		})
+	flags.BoolVar(&o.EnableWorkManifestCompression, "enable-work-manifest-compression", false,
+		"If set, the manifests in the works are kept in the compressed form. If a blob store is also in use, compressed manifests larger than the offload threshold are saved in the blob store and shared by the works with the same manifests. All the member agents must support compressed manifests before this is enabled.")
+	flags.BoolVar(&o.EnablePlacementPreview, "enable-placement-preview", false,
+		"If set, the ClusterResourcePlacementPreview API is served, which previews the selected resources, the scheduling decisions, the applicable overrides, and the diffs against the live objects in the member clusters of a placement without making the placement.")
 	o.RateLimiterOpts.AddFlags(flags)
 	o.AzurePropertyCheckerOpts.AddFlags(flags)
 }
@@ -61,6 +61,31 @@ func (o *Options) Validate() field.ErrorList {
 		errs = append(errs, field.Invalid(newPath.Child("WebhookClientConnectionType"), o.WebhookClientConnectionType, err.Error()))
 	}
 
+	if o.EnableRebalancer {
+		if !o.EnableEvictionAPIs {
+			errs = append(errs, field.Invalid(newPath.Child("EnableRebalancer"), o.EnableRebalancer, "EnableRebalancer requires EnableEvictionAPIs to be true"))
+		}
+		if o.RebalanceInterval <= 0 {
+			errs = append(errs, field.Invalid(newPath.Child("RebalanceInterval"), o.RebalanceInterval, "Must be greater than 0"))
+		}
+		if o.RebalanceScoreThreshold <= 0 {
+			errs = append(errs, field.Invalid(newPath.Child("RebalanceScoreThreshold"), o.RebalanceScoreThreshold, "Must be greater than 0"))
+		}
+	}
+
+	if o.EnableTaintBasedEviction {
+		if !o.EnableEvictionAPIs {
+			errs = append(errs, field.Invalid(newPath.Child("EnableTaintBasedEviction"), o.EnableTaintBasedEviction, "EnableTaintBasedEviction requires EnableEvictionAPIs to be true"))
+		}
+		if o.UnhealthyClusterTaintDelay < 0 {
+			errs = append(errs, field.Invalid(newPath.Child("UnhealthyClusterTaintDelay"), o.UnhealthyClusterTaintDelay, "Must be greater than or equal to 0"))
+		}
+	}
+
+	if o.BlobStoreURL != "" && o.BlobOffloadThresholdBytes <= 0 {
+		errs = append(errs, field.Invalid(newPath.Child("BlobOffloadThresholdBytes"), o.BlobOffloadThresholdBytes, "Must be greater than 0 when a blob store is in use"))
+	}
+
 	if !o.EnableV1Alpha1APIs && !o.EnableV1Beta1APIs {
 		errs = append(errs, field.Required(newPath.Child("EnableV1Alpha1APIs"), "Either EnableV1Alpha1APIs or EnableV1Beta1APIs is required"))
 	}
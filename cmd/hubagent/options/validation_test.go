@@ -114,6 +114,43 @@ func TestValidateControllerManagerConfiguration(t *testing.T) {
 			}),
 			want: field.ErrorList{field.Invalid(newPath.Child("UseCertManager"), true, "UseCertManager requires EnableWorkload to be true (when EnableWorkload is false, a validating webhook blocks pod creation except for certain system pods; cert-manager controller pods must be allowed to run in the hub cluster)")},
 		},
+		"EnableRebalancer without EnableEvictionAPIs": {
+			opt: newTestOptions(func(option *Options) {
+				option.EnableRebalancer = true
+				option.RebalanceInterval = time.Minute
+				option.RebalanceScoreThreshold = 20
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("EnableRebalancer"), true, "EnableRebalancer requires EnableEvictionAPIs to be true")},
+		},
+		"invalid RebalanceScoreThreshold": {
+			opt: newTestOptions(func(option *Options) {
+				option.EnableRebalancer = true
+				option.EnableEvictionAPIs = true
+				option.RebalanceInterval = time.Minute
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("RebalanceScoreThreshold"), 0, "Must be greater than 0")},
+		},
+		"EnableTaintBasedEviction without EnableEvictionAPIs": {
+			opt: newTestOptions(func(option *Options) {
+				option.EnableTaintBasedEviction = true
+				option.UnhealthyClusterTaintDelay = 5 * time.Minute
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("EnableTaintBasedEviction"), true, "EnableTaintBasedEviction requires EnableEvictionAPIs to be true")},
+		},
+		"invalid UnhealthyClusterTaintDelay": {
+			opt: newTestOptions(func(option *Options) {
+				option.EnableTaintBasedEviction = true
+				option.EnableEvictionAPIs = true
+				option.UnhealthyClusterTaintDelay = -time.Minute
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("UnhealthyClusterTaintDelay"), -time.Minute, "Must be greater than or equal to 0")},
+		},
+		"invalid BlobOffloadThresholdBytes": {
+			opt: newTestOptions(func(option *Options) {
+				option.BlobStoreURL = "file:///var/lib/fleet/blobs"
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("BlobOffloadThresholdBytes"), 0, "Must be greater than 0 when a blob store is in use")},
+		},
 		"UseCertManager with EnableWebhook and EnableWorkload": {
 			opt: newTestOptions(func(option *Options) {
 				option.EnableWebhook = true
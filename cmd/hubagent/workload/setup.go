@@ -36,23 +36,29 @@ import (
 	"go.goms.io/fleet/pkg/clients/azure/compute"
 	"go.goms.io/fleet/pkg/clients/httputil"
 	"go.goms.io/fleet/pkg/controllers/bindingwatcher"
+	"go.goms.io/fleet/pkg/controllers/blobcollector"
 	"go.goms.io/fleet/pkg/controllers/clusterinventory/clusterprofile"
 	"go.goms.io/fleet/pkg/controllers/clusterresourceplacementeviction"
 	"go.goms.io/fleet/pkg/controllers/clusterresourceplacementstatuswatcher"
 	"go.goms.io/fleet/pkg/controllers/overrider"
 	"go.goms.io/fleet/pkg/controllers/placement"
+	"go.goms.io/fleet/pkg/controllers/placementpreview"
 	"go.goms.io/fleet/pkg/controllers/placementwatcher"
+	"go.goms.io/fleet/pkg/controllers/rebalancer"
 	"go.goms.io/fleet/pkg/controllers/resourcechange"
 	"go.goms.io/fleet/pkg/controllers/rollout"
 	"go.goms.io/fleet/pkg/controllers/schedulingpolicysnapshot"
+	"go.goms.io/fleet/pkg/controllers/taintmanager"
 	"go.goms.io/fleet/pkg/controllers/updaterun"
 	"go.goms.io/fleet/pkg/controllers/workgenerator"
 	"go.goms.io/fleet/pkg/propertychecker/azure"
 	"go.goms.io/fleet/pkg/resourcewatcher"
 	"go.goms.io/fleet/pkg/scheduler"
 	"go.goms.io/fleet/pkg/scheduler/clustereligibilitychecker"
+	schedulerconfig "go.goms.io/fleet/pkg/scheduler/config"
 	"go.goms.io/fleet/pkg/scheduler/framework"
 	"go.goms.io/fleet/pkg/scheduler/framework/plugins/clusteraffinity"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/extender"
 	"go.goms.io/fleet/pkg/scheduler/profile"
 	"go.goms.io/fleet/pkg/scheduler/queue"
 	schedulerbindingwatcher "go.goms.io/fleet/pkg/scheduler/watchers/binding"
@@ -60,7 +66,9 @@ import (
 	schedulerplacementwatcher "go.goms.io/fleet/pkg/scheduler/watchers/placement"
 	schedulerspswatcher "go.goms.io/fleet/pkg/scheduler/watchers/schedulingpolicysnapshot"
 	"go.goms.io/fleet/pkg/utils"
+	"go.goms.io/fleet/pkg/utils/blobstore"
 	"go.goms.io/fleet/pkg/utils/controller"
+	"go.goms.io/fleet/pkg/utils/helm"
 	"go.goms.io/fleet/pkg/utils/informer"
 	"go.goms.io/fleet/pkg/utils/validator"
 )
@@ -120,6 +128,10 @@ var (
 		placementv1beta1.GroupVersion.WithKind(placementv1beta1.ClusterResourcePlacementEvictionKind),
 		placementv1beta1.GroupVersion.WithKind(placementv1beta1.ClusterResourcePlacementDisruptionBudgetKind),
 	}
+
+	placementPreviewGVKs = []schema.GroupVersionKind{
+		placementv1beta1.GroupVersion.WithKind(placementv1beta1.ClusterResourcePlacementPreviewKind),
+	}
 )
 
 // SetupControllers set up the customized controllers we developed
@@ -160,6 +172,13 @@ func SetupControllers(ctx context.Context, wg *sync.WaitGroup, mgr ctrl.Manager,
 	validator.ResourceInformer = dynamicInformerManager // webhook needs this to check resource scope
 	validator.RestMapper = mgr.GetRESTMapper()          // webhook needs this to validate GVK of resource selector
 
+	// The charts of the Helm sources are fetched when the resource snapshots are created, and again when the
+	// works are generated; the fetcher is shared so that the fetched charts are cached once.
+	helmChartFetcher := helm.NewChartFetcher(helm.FetcherOptions{
+		FileRepositoryRoot: opts.HelmFileRepositoryRoot,
+		AllowedHosts:       opts.HelmAllowedHosts,
+	})
+
 	// Set up  a custom controller to reconcile placement objects
 	resourceSelectorResolver := controller.ResourceSelectorResolver{
 		RestMapper:        mgr.GetRESTMapper(),
@@ -167,7 +186,25 @@ func SetupControllers(ctx context.Context, wg *sync.WaitGroup, mgr ctrl.Manager,
 		ResourceConfig:    resourceConfig,
 		SkippedNamespaces: skippedNamespaces,
 		EnableWorkload:    opts.EnableWorkload,
+		HelmChartFetcher:  helmChartFetcher,
+	}
+
+	// Set up the external blob store for large selected resources, if any.
+	blobStore, err := blobstore.New(opts.BlobStoreURL)
+	if err != nil {
+		klog.ErrorS(err, "Unable to set up the blob store")
+		return err
 	}
+	var blobCollector *blobcollector.Collector
+	if blobStore != nil {
+		klog.Info("Setting up the blob collector")
+		blobCollector = blobcollector.NewCollector(mgr.GetClient(), blobStore, blobcollector.DefaultGracePeriod, blobcollector.DefaultInterval)
+		if err := mgr.Add(blobCollector); err != nil {
+			klog.ErrorS(err, "Unable to set up the blob collector")
+			return err
+		}
+	}
+
 	pc := &placement.Reconciler{
 		Client:                                  mgr.GetClient(),
 		Recorder:                                mgr.GetEventRecorderFor(placementControllerName),
@@ -176,6 +213,9 @@ func SetupControllers(ctx context.Context, wg *sync.WaitGroup, mgr ctrl.Manager,
 		ResourceSelectorResolver:                resourceSelectorResolver,
 		ResourceSnapshotCreationMinimumInterval: opts.ResourceSnapshotCreationMinimumInterval,
 		ResourceChangesCollectionDuration:       opts.ResourceChangesCollectionDuration,
+		BlobStore:                               blobStore,
+		BlobOffloadThresholdBytes:               opts.BlobOffloadThresholdBytes,
+		BlobCollector:                           blobCollector,
 	}
 
 	rateLimiter := options.DefaultControllerRateLimiter(opts.RateLimiterOpts)
@@ -313,8 +353,9 @@ func SetupControllers(ctx context.Context, wg *sync.WaitGroup, mgr ctrl.Manager,
 			}
 			klog.Info("Setting up clusterStagedUpdateRun controller")
 			if err = (&updaterun.Reconciler{
-				Client:          mgr.GetClient(),
-				InformerManager: dynamicInformerManager,
+				Client:                     mgr.GetClient(),
+				InformerManager:            dynamicInformerManager,
+				AllowedPrometheusAddresses: opts.HealthCheckPrometheusAddresses,
 			}).SetupWithManagerForClusterStagedUpdateRun(mgr); err != nil {
 				klog.ErrorS(err, "Unable to set up clusterStagedUpdateRun controller")
 				return err
@@ -329,8 +370,9 @@ func SetupControllers(ctx context.Context, wg *sync.WaitGroup, mgr ctrl.Manager,
 				}
 				klog.Info("Setting up stagedUpdateRun controller")
 				if err = (&updaterun.Reconciler{
-					Client:          mgr.GetClient(),
-					InformerManager: dynamicInformerManager,
+					Client:                     mgr.GetClient(),
+					InformerManager:            dynamicInformerManager,
+					AllowedPrometheusAddresses: opts.HealthCheckPrometheusAddresses,
 				}).SetupWithManagerForStagedUpdateRun(mgr); err != nil {
 					klog.ErrorS(err, "Unable to set up stagedUpdateRun controller")
 					return err
@@ -341,9 +383,13 @@ func SetupControllers(ctx context.Context, wg *sync.WaitGroup, mgr ctrl.Manager,
 		// Set up the work generator
 		klog.Info("Setting up work generator")
 		if err := (&workgenerator.Reconciler{
-			Client:                  mgr.GetClient(),
-			MaxConcurrentReconciles: int(math.Ceil(float64(opts.MaxFleetSizeSupported)/10) * math.Ceil(float64(opts.MaxConcurrentClusterPlacement)/10)),
-			InformerManager:         dynamicInformerManager,
+			Client:                    mgr.GetClient(),
+			MaxConcurrentReconciles:   int(math.Ceil(float64(opts.MaxFleetSizeSupported)/10) * math.Ceil(float64(opts.MaxConcurrentClusterPlacement)/10)),
+			InformerManager:           dynamicInformerManager,
+			BlobStore:                 blobStore,
+			BlobOffloadThresholdBytes: opts.BlobOffloadThresholdBytes,
+			CompressManifests:         opts.EnableWorkManifestCompression,
+			HelmChartFetcher:          helmChartFetcher,
 		}).SetupWithManagerForClusterResourceBinding(mgr); err != nil {
 			klog.ErrorS(err, "Unable to set up work generator for clusterResourceBinding")
 			return err
@@ -351,9 +397,13 @@ func SetupControllers(ctx context.Context, wg *sync.WaitGroup, mgr ctrl.Manager,
 
 		if opts.EnableResourcePlacement {
 			if err := (&workgenerator.Reconciler{
-				Client:                  mgr.GetClient(),
-				MaxConcurrentReconciles: int(math.Ceil(float64(opts.MaxFleetSizeSupported)/10) * math.Ceil(float64(opts.MaxConcurrentClusterPlacement)/10)),
-				InformerManager:         dynamicInformerManager,
+				Client:                    mgr.GetClient(),
+				MaxConcurrentReconciles:   int(math.Ceil(float64(opts.MaxFleetSizeSupported)/10) * math.Ceil(float64(opts.MaxConcurrentClusterPlacement)/10)),
+				InformerManager:           dynamicInformerManager,
+				BlobStore:                 blobStore,
+				BlobOffloadThresholdBytes: opts.BlobOffloadThresholdBytes,
+				CompressManifests:         opts.EnableWorkManifestCompression,
+				HelmChartFetcher:          helmChartFetcher,
 			}).SetupWithManagerForResourceBinding(mgr); err != nil {
 				klog.ErrorS(err, "Unable to set up work generator for resourceBinding")
 				return err
@@ -362,7 +412,18 @@ func SetupControllers(ctx context.Context, wg *sync.WaitGroup, mgr ctrl.Manager,
 
 		// Set up the scheduler
 		klog.Info("Setting up scheduler")
-		schedulerProfile := profile.NewDefaultProfile()
+		profileOpts := profile.Options{
+			ResourcePlacementEnabled: opts.EnableResourcePlacement,
+		}
+		if opts.SchedulerExtenderConfigFile != "" {
+			extenderConfig, err := extender.LoadConfiguration(opts.SchedulerExtenderConfigFile)
+			if err != nil {
+				klog.ErrorS(err, "Unable to load the scheduler extender configuration", "path", opts.SchedulerExtenderConfigFile)
+				return err
+			}
+			klog.InfoS("Setting up scheduler extenders", "count", len(extenderConfig.Extenders))
+			profileOpts.Extenders = extenderConfig.Extenders
+		}
 		if opts.AzurePropertyCheckerOpts.IsEnabled {
 			klog.Info("Azure property checker is enabled for cluster property validation")
 			client, err := compute.NewAttributeBasedVMSizeRecommenderClient(opts.AzurePropertyCheckerOpts.ComputeServiceAddressWithBasePath, httputil.DefaultClientForAzure)
@@ -372,18 +433,33 @@ func SetupControllers(ctx context.Context, wg *sync.WaitGroup, mgr ctrl.Manager,
 			}
 			klog.Info("Setting up cluster affinity plugin with Azure property checker")
 			clusterAffinityPlugin := clusteraffinity.New(clusteraffinity.WithPropertyChecker(azure.NewPropertyChecker(*client)))
-			profileOpts := profile.Options{
-				ClusterAffinityPlugin: &clusterAffinityPlugin,
+			profileOpts.ClusterAffinityPlugin = &clusterAffinityPlugin
+		}
+		schedulerProfiles := []*framework.Profile{profile.NewProfile(profileOpts)}
+		if opts.SchedulerConfigFile != "" {
+			schedulerConfig, err := schedulerconfig.Load(opts.SchedulerConfigFile)
+			if err != nil {
+				klog.ErrorS(err, "Unable to load the scheduler configuration", "path", opts.SchedulerConfigFile)
+				return err
+			}
+			if schedulerProfiles, err = profile.NewProfiles(schedulerConfig, profileOpts); err != nil {
+				klog.ErrorS(err, "Unable to set up the scheduling profiles", "path", opts.SchedulerConfigFile)
+				return err
 			}
-			schedulerProfile = profile.NewProfile(profileOpts)
 		}
-		defaultFramework := framework.NewFramework(schedulerProfile, mgr)
+		profileFrameworks := make(map[string]framework.Framework, len(schedulerProfiles))
+		for _, p := range schedulerProfiles {
+			klog.InfoS("Setting up scheduling profile", "profile", p.Name())
+			profileFrameworks[p.Name()] = framework.NewFramework(p, mgr)
+		}
+		defaultFramework := profileFrameworks[profile.DefaultProfileName]
 		defaultSchedulingQueue := queue.NewSimplePlacementSchedulingQueue(
 			schedulerQueueName, nil,
 		)
 		// we use one scheduler for every 10 concurrent placement
 		defaultScheduler := scheduler.NewScheduler("DefaultScheduler", defaultFramework, defaultSchedulingQueue, mgr,
-			int(math.Ceil(float64(opts.MaxFleetSizeSupported)/50)*math.Ceil(float64(opts.MaxConcurrentClusterPlacement)/10)))
+			int(math.Ceil(float64(opts.MaxFleetSizeSupported)/50)*math.Ceil(float64(opts.MaxConcurrentClusterPlacement)/10)),
+			scheduler.WithProfileFrameworks(profileFrameworks))
 		klog.Info("Starting the scheduler")
 		// Scheduler must run in a separate goroutine as Run() is a blocking call.
 		wg.Add(1)
@@ -396,6 +472,53 @@ func SetupControllers(ctx context.Context, wg *sync.WaitGroup, mgr ctrl.Manager,
 			klog.InfoS("The scheduler has exited")
 		}()
 
+		if opts.EnableRebalancer {
+			klog.Info("Setting up the rebalancer")
+			if err := (&rebalancer.Reconciler{
+				Client:         mgr.GetClient(),
+				UncachedReader: mgr.GetAPIReader(),
+				Recorder:       mgr.GetEventRecorderFor("rebalancer"),
+				FrameworkFor:   defaultScheduler.FrameworkFor,
+				Interval:       opts.RebalanceInterval,
+				ScoreThreshold: int32(opts.RebalanceScoreThreshold),
+			}).SetupWithManager(mgr); err != nil {
+				klog.ErrorS(err, "Unable to set up the rebalancer")
+				return err
+			}
+		}
+
+		if opts.EnableTaintBasedEviction {
+			klog.Info("Setting up the taint manager")
+			if err := (&taintmanager.Reconciler{
+				Client:                    mgr.GetClient(),
+				Recorder:                  mgr.GetEventRecorderFor("taint-manager"),
+				ClusterUnhealthyThreshold: opts.ClusterUnhealthyThreshold.Duration,
+				UnhealthyTaintDelay:       opts.UnhealthyClusterTaintDelay,
+			}).SetupWithManager(mgr); err != nil {
+				klog.ErrorS(err, "Unable to set up the taint manager")
+				return err
+			}
+		}
+
+		if opts.EnablePlacementPreview {
+			for _, gvk := range placementPreviewGVKs {
+				if err = utils.CheckCRDInstalled(discoverClient, gvk); err != nil {
+					klog.ErrorS(err, "Unable to find the required CRD", "GVK", gvk)
+					return err
+				}
+			}
+			klog.Info("Setting up the placement preview controller")
+			if err := (&placementpreview.Reconciler{
+				Client:                   mgr.GetClient(),
+				InformerManager:          dynamicInformerManager,
+				ResourceSelectorResolver: resourceSelectorResolver,
+				FrameworkFor:             defaultScheduler.FrameworkFor,
+			}).SetupWithManager(mgr); err != nil {
+				klog.ErrorS(err, "Unable to set up the placement preview controller")
+				return err
+			}
+		}
+
 		// Set up the watchers for the controller
 		klog.Info("Setting up the clusterResourcePlacement watcher for scheduler")
 		if err := (&schedulerplacementwatcher.Reconciler{
@@ -31,7 +31,9 @@ import (
 	"strings"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilrand "k8s.io/apimachinery/pkg/util/rand"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/discovery"
@@ -42,6 +44,7 @@ import (
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -54,7 +57,10 @@ import (
 	"go.goms.io/fleet/pkg/controllers/workapplier"
 	"go.goms.io/fleet/pkg/propertyprovider"
 	"go.goms.io/fleet/pkg/propertyprovider/azure"
+	"go.goms.io/fleet/pkg/propertyprovider/external"
+	"go.goms.io/fleet/pkg/propertyprovider/generic"
 	"go.goms.io/fleet/pkg/utils"
+	"go.goms.io/fleet/pkg/utils/blobstore"
 	"go.goms.io/fleet/pkg/utils/httpclient"
 	"go.goms.io/fleet/pkg/utils/parallelizer"
 	//+kubebuilder:scaffold:imports
@@ -62,7 +68,9 @@ import (
 
 const (
 	// The list of available property provider names.
-	azurePropertyProvider = "azure"
+	azurePropertyProvider    = "azure"
+	genericPropertyProvider  = "generic"
+	externalPropertyProvider = "external"
 )
 
 var (
@@ -106,9 +114,28 @@ var (
 	workApplierPriorityLinearEquationCoeffA = flag.Int("work-applier-priority-linear-equation-coeff-a", -3, "The work applier sets the priority for a Work object processing attempt using the linear equation: priority = A * (work object age in minutes) + B. This flag sets the coefficient A in the equation.")
 	workApplierPriorityLinearEquationCoeffB = flag.Int("work-applier-priority-linear-equation-coeff-b", 100, "The work applier sets the priority for a Work object processing attempt using the linear equation: priority = A * (work object age in minutes) + B. This flag sets the coefficient B in the equation.")
 
+	// Work applier availability rule settings.
+	enableAvailabilityRules = flag.Bool("enable-availability-rules", false, "If set, the work applier will track the availability of applied objects with the AvailabilityRule objects in the hub cluster.")
+
+	// Work applier redaction rule settings.
+	enableRedactionRules   = flag.Bool("enable-redaction-rules", false, "If set, the work applier will redact the drift and diff details of applied objects with the RedactionRule objects in the hub cluster.")
+	redactionHashKeySecret = flag.String("redaction-hash-key-secret", "fleet-system/fleet-redaction-hash-key", "The namespace/name of the Secret in the member cluster which keeps the key with which the work applier hashes values in the Hash mode of redaction rules; the Secret is created with a random key if it does not exist yet.")
+
+	// Work applier blob store settings.
+	blobStoreURL = flag.String("blob-store-url", "", "If set, the work applier will fetch the manifests that the hub agent has offloaded to the blob store at this URL. It should point to the same store as the one the hub agent uses. Supported URLs are file:///<directory> and s3://<bucket>[/<prefix>][?endpoint=<endpoint URL>&region=<region>]; the credentials for S3-compatible stores are read from the AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN environment variables.")
+
 	// Azure property provider feature gates.
 	isAzProviderCostPropertiesEnabled         = flag.Bool("use-cost-properties-in-azure-provider", true, "If set, the Azure property provider will expose cost properties in the member cluster.")
 	isAzProviderAvailableResPropertiesEnabled = flag.Bool("use-available-res-properties-in-azure-provider", true, "If set, the Azure property provider will expose available resources properties in the member cluster.")
+
+	// Generic property provider settings.
+	genericProviderPricingConfigMap                = flag.String("generic-provider-pricing-configmap", "", "The namespace/name of the ConfigMap which maps each node instance type to its hourly cost; if set, the generic property provider will expose cost properties in the member cluster.")
+	genericProviderGPUResourceNames                = flag.String("generic-provider-gpu-resource-names", "nvidia.com/gpu,amd.com/gpu,gpu.intel.com/i915", "A comma-separated list of the GPU extended resources that the generic property provider tracks.")
+	isGenericProviderAvailableResPropertiesEnabled = flag.Bool("use-available-res-properties-in-generic-provider", true, "If set, the generic property provider will expose available resources properties in the member cluster.")
+
+	// External property provider settings.
+	externalProviderSocket  = flag.String("external-property-provider-socket", "/var/run/fleet/property-provider.sock", "The Unix domain socket at which the out-of-process property provider, e.g., a sidecar container, serves the property collection requests.")
+	externalProviderTimeout = flag.Duration("external-property-provider-timeout", time.Second*5, "The timeout of the property collection requests to the out-of-process property provider; it should be shorter than 10 seconds, the deadline of the member agent for property collection.")
 )
 
 func init() {
@@ -424,6 +451,30 @@ func Start(ctx context.Context, hubCfg, memberConfig *rest.Config, hubOpts, memb
 			*workApplierRequeueRateLimiterSkipToFastBackoffForAvailableOrDiffReportedWorkObjs,
 		)
 
+		blobStore, err := blobstore.New(*blobStoreURL)
+		if err != nil {
+			klog.ErrorS(err, "Failed to set up the blob store")
+			return err
+		}
+
+		var redactionHashKey []byte
+		if *enableRedactionRules {
+			// Use an uncached client, as the member manager has not started yet.
+			memberClient, err := client.New(memberConfig, client.Options{Scheme: scheme})
+			if err != nil {
+				klog.ErrorS(err, "Failed to create the member cluster client")
+				return err
+			}
+			namespace, name, found := strings.Cut(*redactionHashKeySecret, "/")
+			if !found || len(namespace) == 0 || len(name) == 0 {
+				return fmt.Errorf("invalid redaction hash key Secret %q, must be in the format of namespace/name", *redactionHashKeySecret)
+			}
+			if redactionHashKey, err = workapplier.LoadRedactionHashKey(ctx, memberClient, types.NamespacedName{Namespace: namespace, Name: name}); err != nil {
+				klog.ErrorS(err, "Failed to load the redaction hash key")
+				return err
+			}
+		}
+
 		workApplier := workapplier.NewReconciler(
 			"work-applier",
 			hubMgr.GetClient(),
@@ -442,6 +493,10 @@ func Start(ctx context.Context, hubCfg, memberConfig *rest.Config, hubOpts, memb
 			*enableWorkApplierPriorityQueue,
 			workApplierPriorityLinearEquationCoeffA,
 			workApplierPriorityLinearEquationCoeffB,
+			*enableAvailabilityRules,
+			*enableRedactionRules,
+			redactionHashKey,
+			blobStore,
 		)
 
 		if err = workApplier.SetupWithManager(hubMgr); err != nil {
@@ -460,6 +515,21 @@ func Start(ctx context.Context, hubCfg, memberConfig *rest.Config, hubOpts, memb
 			klog.V(1).InfoS("Property Provider is azure, loading cloud config", "cloudConfigFile", *cloudConfigFile)
 			// TODO (britaniar): load cloud config for Azure property provider.
 			pp = azure.New(region, *isAzProviderCostPropertiesEnabled, *isAzProviderAvailableResPropertiesEnabled)
+		case propertyProvider != nil && *propertyProvider == genericPropertyProvider:
+			klog.V(2).Info("setting up the generic property provider")
+			pp, err = newGenericPropertyProvider()
+			if err != nil {
+				klog.ErrorS(err, "Failed to set up the generic property provider")
+				return err
+			}
+		case propertyProvider != nil && *propertyProvider == externalPropertyProvider:
+			klog.V(2).InfoS("setting up the external property provider", "socket", *externalProviderSocket, "timeout", *externalProviderTimeout)
+			if *externalProviderTimeout <= 0 {
+				err := fmt.Errorf("invalid external property provider timeout %v, must be positive", *externalProviderTimeout)
+				klog.ErrorS(err, "Failed to set up the external property provider")
+				return err
+			}
+			pp = external.New(*externalProviderSocket, *externalProviderTimeout)
 		default:
 			// Fall back to not using any property provider if the provided type is none or
 			// not recognizable.
@@ -502,3 +572,22 @@ func Start(ctx context.Context, hubCfg, memberConfig *rest.Config, hubOpts, memb
 
 	return nil
 }
+
+// newGenericPropertyProvider sets up a generic property provider per the command-line flags.
+func newGenericPropertyProvider() (propertyprovider.PropertyProvider, error) {
+	var pricingConfigMap *types.NamespacedName
+	if len(*genericProviderPricingConfigMap) > 0 {
+		namespace, name, found := strings.Cut(*genericProviderPricingConfigMap, "/")
+		if !found || len(namespace) == 0 || len(name) == 0 {
+			return nil, fmt.Errorf("invalid pricing ConfigMap %q, must be in the format of namespace/name", *genericProviderPricingConfigMap)
+		}
+		pricingConfigMap = &types.NamespacedName{Namespace: namespace, Name: name}
+	}
+	var gpuResourceNames []corev1.ResourceName
+	for _, rn := range strings.Split(*genericProviderGPUResourceNames, ",") {
+		if rn = strings.TrimSpace(rn); len(rn) > 0 {
+			gpuResourceNames = append(gpuResourceNames, corev1.ResourceName(rn))
+		}
+	}
+	return generic.New(pricingConfigMap, gpuResourceNames, *isGenericProviderAvailableResPropertiesEnabled), nil
+}
@@ -175,6 +175,10 @@ func isBindingStatusUpdated(oldBinding, newBinding fleetv1beta1.BindingObj) bool
 		klog.V(2).InfoS("Drifted placements reported on the binding status has changed, need to refresh the placement status", "binding", klog.KObj(oldBinding))
 		return true
 	}
+	if !utils.IsDriftRemediatedResourcePlacementsEqual(oldStatus.DriftRemediatedPlacements, newStatus.DriftRemediatedPlacements) {
+		klog.V(2).InfoS("Drift remediated placements reported on the binding status has changed, need to refresh the placement status", "binding", klog.KObj(oldBinding))
+		return true
+	}
 	if !utils.IsDiffedResourcePlacementsEqual(oldStatus.DiffedPlacements, newStatus.DiffedPlacements) {
 		klog.V(2).InfoS("Diffed placements reported on the binding status has changed, need to refresh the placement status", "binding", klog.KObj(oldBinding))
 		return true
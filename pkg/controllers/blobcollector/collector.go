@@ -0,0 +1,183 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package blobcollector features a garbage collector which deletes the blobs that are no longer referenced
+// by any resource snapshot or Work from the blob store.
+package blobcollector
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/blobstore"
+	"go.goms.io/fleet/pkg/utils/resource"
+)
+
+const (
+	// DefaultGracePeriod is the default time for which an unreferenced blob is kept after it is saved.
+	// It covers the time between the saving of a blob and the creation of the object which refers to it,
+	// including the time for the object to reach the informer cache.
+	DefaultGracePeriod = time.Hour
+
+	// DefaultInterval is the default interval between two collections which are not triggered.
+	DefaultInterval = time.Hour
+)
+
+// Collector deletes the blobs that are no longer referenced from the blob store with a mark-and-sweep:
+// the digests referenced by the resource snapshots and the Works in the hub cluster are marked first,
+// then the blobs which are neither marked nor saved within the grace period are deleted.
+//
+// A collection runs periodically, and whenever it is triggered, e.g., after resource snapshots are deleted.
+type Collector struct {
+	client      client.Reader
+	store       blobstore.Store
+	gracePeriod time.Duration
+	interval    time.Duration
+	trigger     chan struct{}
+}
+
+// NewCollector returns a collector for the given store, which reads the objects that refer to the blobs
+// with the given client.
+func NewCollector(c client.Reader, store blobstore.Store, gracePeriod, interval time.Duration) *Collector {
+	return &Collector{
+		client:      c,
+		store:       store,
+		gracePeriod: gracePeriod,
+		interval:    interval,
+		trigger:     make(chan struct{}, 1),
+	}
+}
+
+// Trigger requests a collection without waiting for it; the requests made before the collection starts are
+// merged into one. It is a no-op on a nil collector, i.e., when no blob store is in use.
+func (c *Collector) Trigger() {
+	if c == nil {
+		return
+	}
+	select {
+	case c.trigger <- struct{}{}:
+	default:
+		// A collection has been requested already.
+	}
+}
+
+// Start runs the collections until the context is done. It implements the manager.Runnable interface.
+func (c *Collector) Start(ctx context.Context) error {
+	klog.InfoS("Starting the blob collector", "gracePeriod", c.gracePeriod, "interval", c.interval)
+	defer klog.InfoS("The blob collector is stopped")
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		case <-c.trigger:
+		}
+		if err := c.Collect(ctx); err != nil {
+			klog.ErrorS(err, "Failed to collect the unreferenced blobs")
+		}
+	}
+}
+
+// NeedLeaderElection implements the LeaderElectionRunnable interface, so that only the leader collects the blobs.
+func (c *Collector) NeedLeaderElection() bool {
+	return true
+}
+
+// Collect deletes the blobs which are no longer referenced and have not been saved within the grace period.
+func (c *Collector) Collect(ctx context.Context) error {
+	// List the blobs before marking: a blob which is referenced by an object created after the marking has been
+	// saved within the grace period, as saving existing content refreshes its last modified time.
+	blobs, err := c.store.List(ctx)
+	if err != nil {
+		return err
+	}
+	referenced, err := c.referencedDigests(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	deleted := 0
+	for _, blob := range blobs {
+		if referenced[blob.Digest] || now.Sub(blob.LastModified) < c.gracePeriod {
+			continue
+		}
+		if err := c.store.Delete(ctx, blob.Digest); err != nil {
+			return err
+		}
+		klog.V(2).InfoS("Deleted an unreferenced blob", "digest", blob.Digest, "lastModified", blob.LastModified)
+		deleted++
+	}
+	klog.V(2).InfoS("Collected the unreferenced blobs", "blobs", len(blobs), "referenced", len(referenced), "deleted", deleted)
+	return nil
+}
+
+// referencedDigests returns the digests of the blobs referenced by the resource snapshots and the Works.
+func (c *Collector) referencedDigests(ctx context.Context) (map[string]bool, error) {
+	referenced := make(map[string]bool)
+	markResources := func(resources []placementv1beta1.ResourceContent) {
+		for i := range resources {
+			if digest, ok := blobstore.ReferenceDigest(resources[i].Raw); ok {
+				referenced[digest] = true
+			}
+		}
+	}
+
+	clusterSnapshots := &placementv1beta1.ClusterResourceSnapshotList{}
+	if err := c.client.List(ctx, clusterSnapshots); err != nil {
+		return nil, fmt.Errorf("failed to list the cluster resource snapshots: %w", err)
+	}
+	for i := range clusterSnapshots.Items {
+		markResources(clusterSnapshots.Items[i].Spec.SelectedResources)
+	}
+	snapshots := &placementv1beta1.ResourceSnapshotList{}
+	if err := c.client.List(ctx, snapshots); err != nil {
+		return nil, fmt.Errorf("failed to list the resource snapshots: %w", err)
+	}
+	for i := range snapshots.Items {
+		markResources(snapshots.Items[i].Spec.SelectedResources)
+	}
+
+	works := &placementv1beta1.WorkList{}
+	if err := c.client.List(ctx, works); err != nil {
+		return nil, fmt.Errorf("failed to list the works: %w", err)
+	}
+	for i := range works.Items {
+		workload := &works.Items[i].Spec.Workload
+		if workload.CompressedManifests != nil {
+			referenced[workload.CompressedManifests.Digest] = true
+		}
+		// The manifests of a Work may refer to blobs even if they are compressed and offloaded themselves.
+		manifests, err := resource.ManifestsOf(ctx, workload, c.store)
+		if err != nil {
+			// Do not sweep anything if the references of a Work are unknown.
+			return nil, fmt.Errorf("failed to read the manifests of work %s: %w", klog.KObj(&works.Items[i]), err)
+		}
+		for j := range manifests {
+			if digest, ok := blobstore.ReferenceDigest(manifests[j].Raw); ok {
+				referenced[digest] = true
+			}
+		}
+	}
+	return referenced, nil
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blobcollector
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/blobstore"
+	"go.goms.io/fleet/pkg/utils/resource"
+)
+
+func configMap(name string) []byte {
+	return []byte(fmt.Sprintf(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":%q,"namespace":"app"},"data":{"key":"value"}}`, name))
+}
+
+func offload(t *testing.T, store blobstore.Store, raw []byte) ([]byte, string) {
+	t.Helper()
+	ref, err := blobstore.Offload(context.Background(), store, raw, 0)
+	if err != nil {
+		t.Fatalf("Offload() = %v, want no error", err)
+	}
+	digest, ok := blobstore.ReferenceDigest(ref)
+	if !ok {
+		t.Fatalf("ReferenceDigest() = false, want true")
+	}
+	return ref, digest
+}
+
+func TestCollect(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() = %v, want no error", err)
+	}
+
+	testCases := []struct {
+		name        string
+		gracePeriod time.Duration
+		wantKept    []string
+	}{
+		{
+			name:        "unreferenced blobs are deleted after the grace period",
+			gracePeriod: 0,
+			wantKept:    []string{"clusterSnapshot", "snapshot", "workManifest", "workManifests", "workManifestInOffloadedManifests"},
+		},
+		{
+			name:        "unreferenced blobs are kept within the grace period",
+			gracePeriod: time.Hour,
+			wantKept:    []string{"clusterSnapshot", "snapshot", "workManifest", "workManifests", "workManifestInOffloadedManifests", "unreferenced"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := blobstore.NewMemoryStore()
+			digests := make(map[string]string)
+
+			clusterSnapshotRef, digest := offload(t, store, configMap("cluster-snapshot"))
+			digests[digest] = "clusterSnapshot"
+			snapshotRef, digest := offload(t, store, configMap("snapshot"))
+			digests[digest] = "snapshot"
+			workManifestRef, digest := offload(t, store, configMap("work-manifest"))
+			digests[digest] = "workManifest"
+			_, digest = offload(t, store, configMap("unreferenced"))
+			digests[digest] = "unreferenced"
+
+			// A Work whose manifests are offloaded, with a manifest which is offloaded by itself.
+			innerRef, digest := offload(t, store, configMap("work-manifest-in-offloaded-manifests"))
+			digests[digest] = "workManifestInOffloadedManifests"
+			offloadedWorkload := placementv1beta1.WorkloadTemplate{
+				Manifests: []placementv1beta1.Manifest{{RawExtension: runtime.RawExtension{Raw: innerRef}}},
+			}
+			if err := resource.CompressManifests(ctx, &offloadedWorkload, store, 0); err != nil {
+				t.Fatalf("CompressManifests() = %v, want no error", err)
+			}
+			digests[offloadedWorkload.CompressedManifests.Digest] = "workManifests"
+
+			objs := []client.Object{
+				&placementv1beta1.ClusterResourceSnapshot{
+					ObjectMeta: metav1.ObjectMeta{Name: "crp-0-snapshot"},
+					Spec: placementv1beta1.ResourceSnapshotSpec{
+						SelectedResources: []placementv1beta1.ResourceContent{
+							{RawExtension: runtime.RawExtension{Raw: configMap("inline")}},
+							{RawExtension: runtime.RawExtension{Raw: clusterSnapshotRef}},
+						},
+					},
+				},
+				&placementv1beta1.ResourceSnapshot{
+					ObjectMeta: metav1.ObjectMeta{Name: "rp-0-snapshot", Namespace: "app"},
+					Spec: placementv1beta1.ResourceSnapshotSpec{
+						SelectedResources: []placementv1beta1.ResourceContent{{RawExtension: runtime.RawExtension{Raw: snapshotRef}}},
+					},
+				},
+				&placementv1beta1.Work{
+					ObjectMeta: metav1.ObjectMeta{Name: "work", Namespace: "fleet-member-cluster-1"},
+					Spec: placementv1beta1.WorkSpec{
+						Workload: placementv1beta1.WorkloadTemplate{
+							Manifests: []placementv1beta1.Manifest{{RawExtension: runtime.RawExtension{Raw: workManifestRef}}},
+						},
+					},
+				},
+				&placementv1beta1.Work{
+					ObjectMeta: metav1.ObjectMeta{Name: "work", Namespace: "fleet-member-cluster-2"},
+					Spec:       placementv1beta1.WorkSpec{Workload: offloadedWorkload},
+				},
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+
+			collector := NewCollector(fakeClient, store, tc.gracePeriod, DefaultInterval)
+			if err := collector.Collect(ctx); err != nil {
+				t.Fatalf("Collect() = %v, want no error", err)
+			}
+
+			blobs, err := store.List(ctx)
+			if err != nil {
+				t.Fatalf("List() = %v, want no error", err)
+			}
+			var gotKept []string
+			for _, blob := range blobs {
+				gotKept = append(gotKept, digests[blob.Digest])
+			}
+			sort.Strings(gotKept)
+			wantKept := append([]string(nil), tc.wantKept...)
+			sort.Strings(wantKept)
+			if diff := cmp.Diff(wantKept, gotKept); diff != "" {
+				t.Errorf("Collect() kept blobs mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCollectUnreadableWork(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() = %v, want no error", err)
+	}
+	store := blobstore.NewMemoryStore()
+	if _, err := store.Put(ctx, configMap("unreferenced")); err != nil {
+		t.Fatalf("Put() = %v, want no error", err)
+	}
+	work := &placementv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{Name: "work", Namespace: "fleet-member-cluster-1"},
+		Spec: placementv1beta1.WorkSpec{
+			Workload: placementv1beta1.WorkloadTemplate{
+				CompressedManifests: &placementv1beta1.CompressedManifests{
+					Encoding: placementv1beta1.ManifestEncodingGzip,
+					Digest:   blobstore.Digest([]byte("missing")),
+				},
+			},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(work).Build()
+
+	collector := NewCollector(fakeClient, store, 0, DefaultInterval)
+	if err := collector.Collect(ctx); err == nil {
+		t.Fatalf("Collect() = nil, want error")
+	}
+	if got := store.Len(); got != 1 {
+		t.Errorf("store.Len() = %d, want 1: no blob should be deleted when the references of a Work are unknown", got)
+	}
+}
+
+func TestTrigger(t *testing.T) {
+	var nilCollector *Collector
+	// A nil collector ignores the triggers.
+	nilCollector.Trigger()
+
+	collector := NewCollector(nil, blobstore.NewMemoryStore(), DefaultGracePeriod, DefaultInterval)
+	// The triggers are merged without blocking.
+	collector.Trigger()
+	collector.Trigger()
+	if got := len(collector.trigger); got != 1 {
+		t.Errorf("len(trigger) = %d, want 1", got)
+	}
+}
@@ -0,0 +1,402 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	"github.com/google/cel-go/interpreter"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	"go.goms.io/fleet/pkg/utils/validator"
+)
+
+const (
+	// The condition information for reporting if a custom property has been evaluated.
+	ClusterPropertyDefinitionEvaluatedReason        = "PropertyEvaluated"
+	ClusterPropertyDefinitionEvaluatedMessage       = "The property has been evaluated and reported"
+	ClusterPropertyDefinitionEvaluationFailedReason = "EvaluationFailed"
+	ClusterPropertyDefinitionShadowedReason         = "PropertyShadowed"
+	ClusterPropertyDefinitionShadowedMessage        = "The property is not reported as a property of the same name has been reported by %s"
+
+	// celObjectsVarName is the name of the variable in which the selected objects are passed to
+	// the CEL expressions of custom properties.
+	celObjectsVarName = "objects"
+
+	// celPropertyCostLimit is the max. runtime cost of evaluating the CEL expression of a custom
+	// property once; it is the same as the per-call limit that Kubernetes sets for CEL admission.
+	celPropertyCostLimit uint64 = 1000000
+	// celPropertyInterruptCheckFrequency is how many comprehension iterations run between the
+	// checks of whether the evaluation has been cancelled.
+	celPropertyInterruptCheckFrequency uint = 100
+	// celPropertyEvaluationTimeout is the max. duration of evaluating the CEL expression of a
+	// custom property once.
+	celPropertyEvaluationTimeout = time.Second
+)
+
+var (
+	celEnvOnce sync.Once
+	celEnv     *cel.Env
+	celEnvErr  error
+)
+
+// celEnvironment returns the CEL environment in which the expressions of custom properties are compiled.
+func celEnvironment() (*cel.Env, error) {
+	celEnvOnce.Do(func() {
+		celEnv, celEnvErr = cel.NewEnv(
+			cel.Variable(celObjectsVarName, cel.ListType(cel.DynType)),
+			ext.Strings(),
+		)
+	})
+	return celEnv, celEnvErr
+}
+
+// celPropertyProgramCache caches the compiled CEL programs of the custom properties by the names
+// of their ClusterPropertyDefinitions, so that an expression is compiled only once per generation
+// of its definition rather than at every property collection. Its zero value is ready for use.
+type celPropertyProgramCache struct {
+	mu       sync.Mutex
+	programs map[string]celPropertyProgram
+}
+
+// celPropertyProgram is a compiled CEL program of a custom property.
+type celPropertyProgram struct {
+	generation int64
+	expression string
+	program    cel.Program
+}
+
+// get returns the compiled CEL program of a ClusterPropertyDefinition, compiling it if the
+// cached one is stale or missing.
+func (c *celPropertyProgramCache) get(def *clusterv1beta1.ClusterPropertyDefinition) (cel.Program, error) {
+	expr := def.Spec.CEL.Expression
+	c.mu.Lock()
+	cached, ok := c.programs[def.Name]
+	c.mu.Unlock()
+	// The expression is compared as well, as a recreated definition restarts its generation.
+	if ok && cached.generation == def.Generation && cached.expression == expr {
+		return cached.program, nil
+	}
+
+	prg, err := compileCELProperty(expr)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.programs == nil {
+		c.programs = make(map[string]celPropertyProgram)
+	}
+	c.programs[def.Name] = celPropertyProgram{
+		generation: def.Generation,
+		expression: expr,
+		program:    prg,
+	}
+	return prg, nil
+}
+
+// retain drops the cached CEL programs of the ClusterPropertyDefinitions which no longer exist.
+func (c *celPropertyProgramCache) retain(defs []clusterv1beta1.ClusterPropertyDefinition) {
+	names := make(map[string]bool, len(defs))
+	for idx := range defs {
+		names[defs[idx].Name] = true
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for name := range c.programs {
+		if !names[name] {
+			delete(c.programs, name)
+		}
+	}
+}
+
+// reportCustomProperties evaluates the ClusterPropertyDefinition objects in the member cluster, and
+// adds the custom properties to the collected cluster properties.
+//
+// The collected properties, i.e., the ones from the property provider or the built-in ones, take
+// precedence over the custom properties; if multiple definitions declare the same property, the
+// one with the alphabetically first name wins.
+func (r *Reconciler) reportCustomProperties(ctx context.Context, imc *clusterv1beta1.InternalMemberCluster) error {
+	var defList clusterv1beta1.ClusterPropertyDefinitionList
+	if err := r.memberClient.List(ctx, &defList); err != nil {
+		if meta.IsNoMatchError(err) {
+			// The ClusterPropertyDefinition API is not installed in the member cluster; there is
+			// no custom property to report.
+			klog.V(2).InfoS("ClusterPropertyDefinition API is not installed; skip reporting custom properties", "internalMemberCluster", klog.KObj(imc))
+			return nil
+		}
+		klog.ErrorS(err, "Failed to list cluster property definitions", "internalMemberCluster", klog.KObj(imc))
+		return fmt.Errorf("failed to list cluster property definitions: %w", err)
+	}
+	r.celPropertyPrograms.retain(defList.Items)
+	if len(defList.Items) == 0 {
+		return nil
+	}
+	defs := defList.Items
+	sort.Slice(defs, func(i, j int) bool {
+		return defs[i].Name < defs[j].Name
+	})
+
+	// Copy the collected properties, as the map might be owned by the property provider.
+	properties := make(map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue, len(imc.Status.Properties)+len(defs))
+	for name, value := range imc.Status.Properties {
+		properties[name] = value
+	}
+	reportedBy := make(map[clusterv1beta1.PropertyName]string, len(defs))
+	now := metav1.Now()
+	for idx := range defs {
+		def := &defs[idx]
+		name := clusterv1beta1.PropertyName(def.Spec.PropertyName)
+
+		var value string
+		cond := metav1.Condition{
+			Type:               string(clusterv1beta1.ClusterPropertyDefinitionConditionTypeEvaluated),
+			ObservedGeneration: def.Generation,
+		}
+		_, isCollected := imc.Status.Properties[name]
+		otherDefName, isReportedByOtherDef := reportedBy[name]
+		switch {
+		case isCollected:
+			cond.Status = metav1.ConditionFalse
+			cond.Reason = ClusterPropertyDefinitionShadowedReason
+			cond.Message = fmt.Sprintf(ClusterPropertyDefinitionShadowedMessage, "the property provider")
+		case isReportedByOtherDef:
+			cond.Status = metav1.ConditionFalse
+			cond.Reason = ClusterPropertyDefinitionShadowedReason
+			cond.Message = fmt.Sprintf(ClusterPropertyDefinitionShadowedMessage, fmt.Sprintf("ClusterPropertyDefinition %s", otherDefName))
+		default:
+			var err error
+			if value, err = r.evaluateCustomProperty(ctx, def); err != nil {
+				klog.ErrorS(err, "Failed to evaluate custom property", "clusterPropertyDefinition", klog.KObj(def), "property", name)
+				cond.Status = metav1.ConditionFalse
+				cond.Reason = ClusterPropertyDefinitionEvaluationFailedReason
+				cond.Message = err.Error()
+				break
+			}
+			properties[name] = clusterv1beta1.PropertyValue{
+				Value:           value,
+				ObservationTime: now,
+			}
+			reportedBy[name] = def.Name
+			cond.Status = metav1.ConditionTrue
+			cond.Reason = ClusterPropertyDefinitionEvaluatedReason
+			cond.Message = ClusterPropertyDefinitionEvaluatedMessage
+		}
+		r.updateClusterPropertyDefinitionStatus(ctx, def, value, cond)
+	}
+	imc.Status.Properties = properties
+	klog.V(2).InfoS("Reported custom properties", "internalMemberCluster", klog.KObj(imc), "definitionCount", len(defs), "reportedCount", len(reportedBy))
+	return nil
+}
+
+// updateClusterPropertyDefinitionStatus updates the status of a ClusterPropertyDefinition if it has changed.
+//
+// Failures are logged only; the status will be refreshed at the next property collection.
+func (r *Reconciler) updateClusterPropertyDefinitionStatus(ctx context.Context, def *clusterv1beta1.ClusterPropertyDefinition, value string, cond metav1.Condition) {
+	oldStatus := def.Status.DeepCopy()
+	def.Status.Value = value
+	def.SetConditions(cond)
+	if equality.Semantic.DeepEqual(oldStatus, &def.Status) {
+		return
+	}
+	if err := r.memberClient.Status().Update(ctx, def); err != nil {
+		klog.ErrorS(err, "Failed to update the status of the cluster property definition", "clusterPropertyDefinition", klog.KObj(def))
+	}
+}
+
+// evaluateCustomProperty evaluates the value of a custom property.
+func (r *Reconciler) evaluateCustomProperty(ctx context.Context, def *clusterv1beta1.ClusterPropertyDefinition) (string, error) {
+	spec := &def.Spec
+	if err := validator.ValidatePropertyName(spec.PropertyName); err != nil {
+		return "", err
+	}
+
+	switch {
+	case spec.ObjectCount != nil:
+		objs, err := r.listObjects(ctx, spec.ObjectCount)
+		if err != nil {
+			return "", err
+		}
+		return strconv.Itoa(len(objs)), nil
+	case spec.ConfigMapValue != nil:
+		return r.getConfigMapValue(ctx, spec.ConfigMapValue)
+	case spec.NodeResourceSum != nil:
+		return r.sumNodeResource(ctx, spec.NodeResourceSum)
+	case spec.CEL != nil:
+		return r.evaluateCELProperty(ctx, def)
+	default:
+		return "", fmt.Errorf("no source is specified for the property")
+	}
+}
+
+// listObjects lists the objects selected by an object selector.
+//
+// Note that the objects are read directly from the API server, as the cache of the member agent does
+// not cover unstructured objects.
+func (r *Reconciler) listObjects(ctx context.Context, sel *clusterv1beta1.ObjectSelector) ([]unstructured.Unstructured, error) {
+	objList := &unstructured.UnstructuredList{}
+	objList.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   sel.Group,
+		Version: sel.Version,
+		Kind:    sel.Kind + "List",
+	})
+	var listOpts []client.ListOption
+	if len(sel.Namespace) > 0 {
+		listOpts = append(listOpts, client.InNamespace(sel.Namespace))
+	}
+	if sel.LabelSelector != nil {
+		ls, err := metav1.LabelSelectorAsSelector(sel.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label selector: %w", err)
+		}
+		listOpts = append(listOpts, client.MatchingLabelsSelector{Selector: ls})
+	}
+	if err := r.memberClient.List(ctx, objList, listOpts...); err != nil {
+		return nil, fmt.Errorf("failed to list %s objects: %w", objList.GroupVersionKind(), err)
+	}
+	return objList.Items, nil
+}
+
+// getConfigMapValue returns the value of a key in a ConfigMap.
+func (r *Reconciler) getConfigMapValue(ctx context.Context, src *clusterv1beta1.ConfigMapValueSource) (string, error) {
+	// Read the ConfigMap as an unstructured object so that the member agent does not set up
+	// an informer for all the ConfigMaps in the member cluster.
+	cm := &unstructured.Unstructured{}
+	cm.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ConfigMap"))
+	if err := r.memberClient.Get(ctx, client.ObjectKey{Namespace: src.Namespace, Name: src.Name}, cm); err != nil {
+		return "", fmt.Errorf("failed to get ConfigMap %s/%s: %w", src.Namespace, src.Name, err)
+	}
+	value, found, err := unstructured.NestedString(cm.Object, "data", src.Key)
+	if err != nil {
+		return "", fmt.Errorf("failed to read key %s of ConfigMap %s/%s: %w", src.Key, src.Namespace, src.Name, err)
+	}
+	if !found {
+		return "", fmt.Errorf("key %s is not found in ConfigMap %s/%s", src.Key, src.Namespace, src.Name)
+	}
+	return value, nil
+}
+
+// sumNodeResource sums up a resource of the selected nodes.
+func (r *Reconciler) sumNodeResource(ctx context.Context, src *clusterv1beta1.NodeResourceSumSource) (string, error) {
+	ls := labels.Everything()
+	if src.LabelSelector != nil {
+		var err error
+		if ls, err = metav1.LabelSelectorAsSelector(src.LabelSelector); err != nil {
+			return "", fmt.Errorf("invalid label selector: %w", err)
+		}
+	}
+	var nodes corev1.NodeList
+	if err := r.memberClient.List(ctx, &nodes, client.MatchingLabelsSelector{Selector: ls}); err != nil {
+		return "", fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	total := resource.Quantity{}
+	for idx := range nodes.Items {
+		node := &nodes.Items[idx]
+		rl := node.Status.Allocatable
+		if src.CapacityType == clusterv1beta1.NodeResourceCapacityTypeCapacity {
+			rl = node.Status.Capacity
+		}
+		if q, ok := rl[src.ResourceName]; ok {
+			total.Add(q)
+		}
+	}
+	return total.String(), nil
+}
+
+// compileCELProperty compiles the CEL expression of a custom property with the evaluation limits.
+func compileCELProperty(expr string) (cel.Program, error) {
+	env, err := celEnvironment()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the CEL environment: %w", err)
+	}
+	ast, iss := env.Compile(expr)
+	if iss.Err() != nil {
+		return nil, fmt.Errorf("failed to compile CEL expression %q: %w", expr, iss.Err())
+	}
+	prg, err := env.Program(ast,
+		cel.CostLimit(celPropertyCostLimit),
+		cel.InterruptCheckFrequency(celPropertyInterruptCheckFrequency),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build CEL program for expression %q: %w", expr, err)
+	}
+	return prg, nil
+}
+
+// evaluateCELProperty evaluates the CEL expression of a custom property over the selected objects.
+func (r *Reconciler) evaluateCELProperty(ctx context.Context, def *clusterv1beta1.ClusterPropertyDefinition) (string, error) {
+	src := def.Spec.CEL
+	prg, err := r.celPropertyPrograms.get(def)
+	if err != nil {
+		return "", err
+	}
+
+	objs, err := r.listObjects(ctx, &src.Objects)
+	if err != nil {
+		return "", err
+	}
+	objVars := make([]interface{}, 0, len(objs))
+	for idx := range objs {
+		objVars = append(objVars, objs[idx].Object)
+	}
+	evalCtx, cancel := context.WithTimeout(ctx, celPropertyEvaluationTimeout)
+	defer cancel()
+	out, _, err := prg.ContextEval(evalCtx, map[string]interface{}{
+		celObjectsVarName: objVars,
+	})
+	if err != nil {
+		var cancelledErr interpreter.EvalCancelledError
+		if errors.As(err, &cancelledErr) {
+			return "", fmt.Errorf("CEL expression %q exceeds the evaluation limit: %s", src.Expression, cancelledErr.Message)
+		}
+		return "", fmt.Errorf("failed to evaluate CEL expression %q: %w", src.Expression, err)
+	}
+
+	switch v := out.Value().(type) {
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		return "", fmt.Errorf("CEL expression %q evaluated to %v of type %s, want a number, a string or a boolean", src.Expression, out.Value(), out.Type().TypeName())
+	}
+}
@@ -0,0 +1,495 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	"go.goms.io/fleet/pkg/propertyprovider"
+)
+
+const (
+	cpdName1 = "cpd-1"
+	cpdName2 = "cpd-2"
+
+	customPropertyName = "example.com/custom"
+	cmNamespace        = "work"
+	cmName             = "cluster-info"
+)
+
+// TestReportCustomProperties tests the reportCustomProperties method.
+func TestReportCustomProperties(t *testing.T) {
+	nodes := []client.Object{
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   nodeName1,
+				Labels: map[string]string{"gpu": "true"},
+			},
+			Status: corev1.NodeStatus{
+				Capacity: corev1.ResourceList{
+					"nvidia.com/gpu": resource.MustParse("8"),
+				},
+				Allocatable: corev1.ResourceList{
+					"nvidia.com/gpu": resource.MustParse("7"),
+				},
+			},
+		},
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   nodeName2,
+				Labels: map[string]string{"gpu": "true"},
+			},
+			Status: corev1.NodeStatus{
+				Capacity: corev1.ResourceList{
+					"nvidia.com/gpu": resource.MustParse("4"),
+				},
+				Allocatable: corev1.ResourceList{
+					"nvidia.com/gpu": resource.MustParse("4"),
+				},
+			},
+		},
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: nodeName3,
+			},
+		},
+	}
+	objs := []client.Object{
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cmNamespace,
+				Name:      cmName,
+			},
+			Data: map[string]string{
+				"tier": "gold",
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cmNamespace,
+				Name:      "pod-1",
+				Labels:    map[string]string{"app": "web"},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cmNamespace,
+				Name:      "pod-2",
+				Labels:    map[string]string{"app": "web"},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodPending,
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "pod-3",
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+			},
+		},
+	}
+	objs = append(objs, nodes...)
+
+	podSelector := clusterv1beta1.ObjectSelector{
+		Version: "v1",
+		Kind:    "Pod",
+	}
+
+	testCases := []struct {
+		name           string
+		properties     map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue
+		defs           []*clusterv1beta1.ClusterPropertyDefinition
+		wantProperties map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue
+		wantStatuses   map[string]clusterv1beta1.ClusterPropertyDefinitionStatus
+	}{
+		{
+			name: "no definitions",
+			properties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+				propertyprovider.NodeCountProperty: {Value: "3"},
+			},
+			wantProperties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+				propertyprovider.NodeCountProperty: {Value: "3"},
+			},
+			wantStatuses: map[string]clusterv1beta1.ClusterPropertyDefinitionStatus{},
+		},
+		{
+			name: "object count",
+			defs: []*clusterv1beta1.ClusterPropertyDefinition{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: cpdName1},
+					Spec: clusterv1beta1.ClusterPropertyDefinitionSpec{
+						PropertyName: customPropertyName,
+						ObjectCount: &clusterv1beta1.ObjectSelector{
+							Version:   "v1",
+							Kind:      "Pod",
+							Namespace: cmNamespace,
+							LabelSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"app": "web"},
+							},
+						},
+					},
+				},
+			},
+			wantProperties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+				customPropertyName: {Value: "2"},
+			},
+			wantStatuses: map[string]clusterv1beta1.ClusterPropertyDefinitionStatus{
+				cpdName1: evaluatedStatus("2"),
+			},
+		},
+		{
+			name: "config map value",
+			defs: []*clusterv1beta1.ClusterPropertyDefinition{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: cpdName1},
+					Spec: clusterv1beta1.ClusterPropertyDefinitionSpec{
+						PropertyName: customPropertyName,
+						ConfigMapValue: &clusterv1beta1.ConfigMapValueSource{
+							Namespace: cmNamespace,
+							Name:      cmName,
+							Key:       "tier",
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: cpdName2},
+					Spec: clusterv1beta1.ClusterPropertyDefinitionSpec{
+						PropertyName: "example.com/region",
+						ConfigMapValue: &clusterv1beta1.ConfigMapValueSource{
+							Namespace: cmNamespace,
+							Name:      cmName,
+							Key:       "region",
+						},
+					},
+				},
+			},
+			wantProperties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+				customPropertyName: {Value: "gold"},
+			},
+			wantStatuses: map[string]clusterv1beta1.ClusterPropertyDefinitionStatus{
+				cpdName1: evaluatedStatus("gold"),
+				cpdName2: failedStatus(ClusterPropertyDefinitionEvaluationFailedReason),
+			},
+		},
+		{
+			name: "node resource sum",
+			defs: []*clusterv1beta1.ClusterPropertyDefinition{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: cpdName1},
+					Spec: clusterv1beta1.ClusterPropertyDefinitionSpec{
+						PropertyName: customPropertyName,
+						NodeResourceSum: &clusterv1beta1.NodeResourceSumSource{
+							ResourceName: "nvidia.com/gpu",
+							CapacityType: clusterv1beta1.NodeResourceCapacityTypeAllocatable,
+							LabelSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"gpu": "true"},
+							},
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: cpdName2},
+					Spec: clusterv1beta1.ClusterPropertyDefinitionSpec{
+						PropertyName: "example.com/gpu-capacity",
+						NodeResourceSum: &clusterv1beta1.NodeResourceSumSource{
+							ResourceName: "nvidia.com/gpu",
+							CapacityType: clusterv1beta1.NodeResourceCapacityTypeCapacity,
+						},
+					},
+				},
+			},
+			wantProperties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+				customPropertyName:         {Value: "11"},
+				"example.com/gpu-capacity": {Value: "12"},
+			},
+			wantStatuses: map[string]clusterv1beta1.ClusterPropertyDefinitionStatus{
+				cpdName1: evaluatedStatus("11"),
+				cpdName2: evaluatedStatus("12"),
+			},
+		},
+		{
+			name: "cel expressions",
+			defs: []*clusterv1beta1.ClusterPropertyDefinition{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: cpdName1},
+					Spec: clusterv1beta1.ClusterPropertyDefinitionSpec{
+						PropertyName: customPropertyName,
+						CEL: &clusterv1beta1.CELPropertySource{
+							Objects:    podSelector,
+							Expression: "objects.filter(o, o.status.phase == 'Running').size()",
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: cpdName2},
+					Spec: clusterv1beta1.ClusterPropertyDefinitionSpec{
+						PropertyName: "example.com/running-ratio",
+						CEL: &clusterv1beta1.CELPropertySource{
+							Objects:    podSelector,
+							Expression: "double(objects.filter(o, o.status.phase == 'Running').size()) / double(objects.size())",
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "cpd-3"},
+					Spec: clusterv1beta1.ClusterPropertyDefinitionSpec{
+						PropertyName: "example.com/invalid",
+						CEL: &clusterv1beta1.CELPropertySource{
+							Objects:    podSelector,
+							Expression: "objects.filter(o, ",
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "cpd-4"},
+					Spec: clusterv1beta1.ClusterPropertyDefinitionSpec{
+						PropertyName: "example.com/list",
+						CEL: &clusterv1beta1.CELPropertySource{
+							Objects:    podSelector,
+							Expression: "objects.map(o, o.metadata.name)",
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "cpd-5"},
+					Spec: clusterv1beta1.ClusterPropertyDefinitionSpec{
+						PropertyName: "example.com/expensive",
+						CEL: &clusterv1beta1.CELPropertySource{
+							Objects: podSelector,
+							Expression: "objects.map(a, objects.map(b, objects.map(c, objects.map(d, objects.map(e, objects.map(f, " +
+								"objects.map(g, objects.map(h, objects.map(i, objects.map(j, objects.map(k, objects.map(l, " +
+								"objects.map(m, 0))))))))))))).size()",
+						},
+					},
+				},
+			},
+			wantProperties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+				customPropertyName:          {Value: "2"},
+				"example.com/running-ratio": {Value: "0.6666666666666666"},
+			},
+			wantStatuses: map[string]clusterv1beta1.ClusterPropertyDefinitionStatus{
+				cpdName1: evaluatedStatus("2"),
+				cpdName2: evaluatedStatus("0.6666666666666666"),
+				"cpd-3":  failedStatus(ClusterPropertyDefinitionEvaluationFailedReason),
+				"cpd-4":  failedStatus(ClusterPropertyDefinitionEvaluationFailedReason),
+				"cpd-5":  failedStatus(ClusterPropertyDefinitionEvaluationFailedReason),
+			},
+		},
+		{
+			name: "shadowed properties",
+			properties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+				propertyprovider.NodeCountProperty: {Value: "3"},
+			},
+			defs: []*clusterv1beta1.ClusterPropertyDefinition{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: cpdName1},
+					Spec: clusterv1beta1.ClusterPropertyDefinitionSpec{
+						PropertyName: customPropertyName,
+						ConfigMapValue: &clusterv1beta1.ConfigMapValueSource{
+							Namespace: cmNamespace,
+							Name:      cmName,
+							Key:       "tier",
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: cpdName2},
+					Spec: clusterv1beta1.ClusterPropertyDefinitionSpec{
+						PropertyName: customPropertyName,
+						ObjectCount:  &podSelector,
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "cpd-3"},
+					Spec: clusterv1beta1.ClusterPropertyDefinitionSpec{
+						PropertyName: propertyprovider.NodeCountProperty,
+						ObjectCount:  &podSelector,
+					},
+				},
+			},
+			wantProperties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+				propertyprovider.NodeCountProperty: {Value: "3"},
+				customPropertyName:                 {Value: "gold"},
+			},
+			wantStatuses: map[string]clusterv1beta1.ClusterPropertyDefinitionStatus{
+				cpdName1: evaluatedStatus("gold"),
+				cpdName2: failedStatus(ClusterPropertyDefinitionShadowedReason),
+				"cpd-3":  failedStatus(ClusterPropertyDefinitionShadowedReason),
+			},
+		},
+		{
+			name: "invalid property name",
+			defs: []*clusterv1beta1.ClusterPropertyDefinition{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: cpdName1},
+					Spec: clusterv1beta1.ClusterPropertyDefinitionSpec{
+						PropertyName: "example.com/-invalid",
+						ObjectCount:  &podSelector,
+					},
+				},
+			},
+			wantProperties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{},
+			wantStatuses: map[string]clusterv1beta1.ClusterPropertyDefinitionStatus{
+				cpdName1: failedStatus(ClusterPropertyDefinitionEvaluationFailedReason),
+			},
+		},
+	}
+
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add scheme (corev1): %v", err)
+	}
+	if err := clusterv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add scheme (clusterv1beta1): %v", err)
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClientBuilder := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...)
+			for _, def := range tc.defs {
+				fakeClientBuilder.WithObjects(def)
+				fakeClientBuilder.WithStatusSubresource(def)
+			}
+			fakeClient := fakeClientBuilder.Build()
+
+			r := &Reconciler{
+				memberClient: fakeClient,
+			}
+
+			imc := &clusterv1beta1.InternalMemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: imcName,
+				},
+			}
+			imc.Status.Properties = tc.properties
+			if err := r.reportCustomProperties(ctx, imc); err != nil {
+				t.Fatalf("reportCustomProperties() = %v, want no error", err)
+			}
+
+			wantProperties := tc.wantProperties
+			if wantProperties == nil {
+				wantProperties = map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{}
+			}
+			gotProperties := imc.Status.Properties
+			if gotProperties == nil {
+				gotProperties = map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{}
+			}
+			if diff := cmp.Diff(gotProperties, wantProperties, cmpopts.IgnoreTypes(metav1.Time{})); diff != "" {
+				t.Errorf("properties mismatch (-got, +want):\n%s", diff)
+			}
+
+			var defList clusterv1beta1.ClusterPropertyDefinitionList
+			if err := fakeClient.List(ctx, &defList); err != nil {
+				t.Fatalf("failed to list cluster property definitions: %v", err)
+			}
+			gotStatuses := make(map[string]clusterv1beta1.ClusterPropertyDefinitionStatus, len(defList.Items))
+			for _, def := range defList.Items {
+				gotStatuses[def.Name] = def.Status
+			}
+			if diff := cmp.Diff(gotStatuses, tc.wantStatuses,
+				cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime", "Message")); diff != "" {
+				t.Errorf("cluster property definition statuses mismatch (-got, +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestCELPropertyProgramCache tests the celPropertyProgramCache type.
+func TestCELPropertyProgramCache(t *testing.T) {
+	def := &clusterv1beta1.ClusterPropertyDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: cpdName1, Generation: 1},
+		Spec: clusterv1beta1.ClusterPropertyDefinitionSpec{
+			PropertyName: customPropertyName,
+			CEL: &clusterv1beta1.CELPropertySource{
+				Expression: "objects.size()",
+			},
+		},
+	}
+	cache := celPropertyProgramCache{}
+
+	prg, err := cache.get(def)
+	if err != nil {
+		t.Fatalf("get() = %v, want no error", err)
+	}
+	if cached, err := cache.get(def); err != nil || cached != prg {
+		t.Errorf("get() of the same generation = %v, %v, want the cached program", cached, err)
+	}
+
+	def.Generation = 2
+	def.Spec.CEL.Expression = "objects.size() + 1"
+	recompiled, err := cache.get(def)
+	if err != nil {
+		t.Fatalf("get() = %v, want no error", err)
+	}
+	if recompiled == prg {
+		t.Errorf("get() of a new generation returned the stale program")
+	}
+
+	def.Spec.CEL.Expression = "objects.filter(o, "
+	if _, err := cache.get(def); err == nil {
+		t.Errorf("get() of an invalid expression = nil, want error")
+	}
+
+	cache.retain(nil)
+	if len(cache.programs) != 0 {
+		t.Errorf("retain() kept %d programs, want 0", len(cache.programs))
+	}
+}
+
+func evaluatedStatus(value string) clusterv1beta1.ClusterPropertyDefinitionStatus {
+	return clusterv1beta1.ClusterPropertyDefinitionStatus{
+		Value: value,
+		Conditions: []metav1.Condition{
+			{
+				Type:   string(clusterv1beta1.ClusterPropertyDefinitionConditionTypeEvaluated),
+				Status: metav1.ConditionTrue,
+				Reason: ClusterPropertyDefinitionEvaluatedReason,
+			},
+		},
+	}
+}
+
+func failedStatus(reason string) clusterv1beta1.ClusterPropertyDefinitionStatus {
+	return clusterv1beta1.ClusterPropertyDefinitionStatus{
+		Conditions: []metav1.Condition{
+			{
+				Type:   string(clusterv1beta1.ClusterPropertyDefinitionConditionTypeEvaluated),
+				Status: metav1.ConditionFalse,
+				Reason: reason,
+			},
+		},
+	}
+}
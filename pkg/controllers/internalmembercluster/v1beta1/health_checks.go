@@ -0,0 +1,258 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+)
+
+const (
+	// defaultHealthCheckTimeout is the timeout of a health check if none is specified.
+	defaultHealthCheckTimeout = time.Second * 5
+)
+
+// healthProbe probes one aspect of the health of the member cluster; it returns an error that
+// explains the failure if the member cluster is not healthy in the aspect.
+type healthProbe func(ctx context.Context, r *Reconciler, spec *clusterv1beta1.ClusterHealthCheckSpec) error
+
+// healthProbeFor returns the health probe that runs a health check.
+func healthProbeFor(spec *clusterv1beta1.ClusterHealthCheckSpec) (healthProbe, error) {
+	switch {
+	case spec.NodeReadiness != nil:
+		return probeNodeReadiness, nil
+	case spec.DeploymentAvailability != nil:
+		return probeDeploymentAvailability, nil
+	case spec.DNS != nil:
+		return probeDNS, nil
+	case spec.HTTP != nil:
+		return probeHTTP, nil
+	default:
+		return nil, fmt.Errorf("no probe is specified for the health check")
+	}
+}
+
+// runHealthChecks runs the ClusterHealthCheck objects in the member cluster, and reports the
+// results as conditions on the InternalMemberCluster object.
+func (r *Reconciler) runHealthChecks(ctx context.Context, imc *clusterv1beta1.InternalMemberCluster) error {
+	var checkList clusterv1beta1.ClusterHealthCheckList
+	if err := r.memberClient.List(ctx, &checkList); err != nil {
+		if meta.IsNoMatchError(err) {
+			// The ClusterHealthCheck API is not installed in the member cluster; there is no
+			// health check to run.
+			klog.V(2).InfoS("ClusterHealthCheck API is not installed; skip running health checks", "internalMemberCluster", klog.KObj(imc))
+			return nil
+		}
+		klog.ErrorS(err, "Failed to list cluster health checks", "internalMemberCluster", klog.KObj(imc))
+		return fmt.Errorf("failed to list cluster health checks: %w", err)
+	}
+	checks := checkList.Items
+
+	// Run the health checks in parallel, so that slow checks do not hold up the heartbeat.
+	results := make([]error, len(checks))
+	var wg sync.WaitGroup
+	for idx := range checks {
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+			results[idx] = r.runHealthCheck(ctx, &checks[idx].Spec)
+		}(idx)
+	}
+	wg.Wait()
+
+	wantCondTypes := make(map[string]bool, len(checks))
+	failedCount := 0
+	for idx := range checks {
+		check := &checks[idx]
+		condType := clusterv1beta1.ClusterHealthCheckConditionTypePrefix + check.Name
+		wantCondTypes[condType] = true
+
+		cond := metav1.Condition{
+			Type:               condType,
+			Status:             metav1.ConditionTrue,
+			Reason:             clusterv1beta1.ClusterHealthCheckPassedReason,
+			Message:            "The health check has passed",
+			ObservedGeneration: imc.GetGeneration(),
+		}
+		if err := results[idx]; err != nil {
+			failedCount++
+			klog.V(2).InfoS("Health check failed", "clusterHealthCheck", klog.KObj(check), "internalMemberCluster", klog.KObj(imc), "error", err)
+			cond.Status = metav1.ConditionFalse
+			cond.Reason = clusterv1beta1.ClusterHealthCheckFailedReason
+			if check.Spec.TaintOnFailure {
+				cond.Reason = clusterv1beta1.ClusterHealthCheckFailedTaintReason
+			}
+			cond.Message = err.Error()
+		}
+		meta.SetStatusCondition(&imc.Status.Conditions, cond)
+		r.updateClusterHealthCheckStatus(ctx, check, cond)
+	}
+
+	// Remove the conditions of the health checks that no longer exist.
+	for idx := len(imc.Status.Conditions) - 1; idx >= 0; idx-- {
+		condType := imc.Status.Conditions[idx].Type
+		if strings.HasPrefix(condType, clusterv1beta1.ClusterHealthCheckConditionTypePrefix) && !wantCondTypes[condType] {
+			meta.RemoveStatusCondition(&imc.Status.Conditions, condType)
+		}
+	}
+	klog.V(2).InfoS("Ran health checks", "internalMemberCluster", klog.KObj(imc), "checkCount", len(checks), "failedCount", failedCount)
+	return nil
+}
+
+// runHealthCheck runs a health check with its timeout.
+func (r *Reconciler) runHealthCheck(ctx context.Context, spec *clusterv1beta1.ClusterHealthCheckSpec) error {
+	probe, err := healthProbeFor(spec)
+	if err != nil {
+		return err
+	}
+	timeout := defaultHealthCheckTimeout
+	if spec.TimeoutSeconds > 0 {
+		timeout = time.Duration(spec.TimeoutSeconds) * time.Second
+	}
+	childCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return probe(childCtx, r, spec)
+}
+
+// updateClusterHealthCheckStatus updates the status of a ClusterHealthCheck if it has changed.
+//
+// Failures are logged only; the status will be refreshed at the next heartbeat.
+func (r *Reconciler) updateClusterHealthCheckStatus(ctx context.Context, check *clusterv1beta1.ClusterHealthCheck, imcCond metav1.Condition) {
+	oldStatus := check.Status.DeepCopy()
+	check.SetConditions(metav1.Condition{
+		Type:               string(clusterv1beta1.ClusterHealthCheckConditionTypePassed),
+		Status:             imcCond.Status,
+		Reason:             imcCond.Reason,
+		Message:            imcCond.Message,
+		ObservedGeneration: check.Generation,
+	})
+	if equality.Semantic.DeepEqual(oldStatus, &check.Status) {
+		return
+	}
+	if err := r.memberClient.Status().Update(ctx, check); err != nil {
+		klog.ErrorS(err, "Failed to update the status of the cluster health check", "clusterHealthCheck", klog.KObj(check))
+	}
+}
+
+// probeNodeReadiness checks if enough of the selected nodes are ready.
+func probeNodeReadiness(ctx context.Context, r *Reconciler, spec *clusterv1beta1.ClusterHealthCheckSpec) error {
+	probe := spec.NodeReadiness
+	ls := labels.Everything()
+	if probe.LabelSelector != nil {
+		var err error
+		if ls, err = metav1.LabelSelectorAsSelector(probe.LabelSelector); err != nil {
+			return fmt.Errorf("invalid label selector: %w", err)
+		}
+	}
+	var nodes corev1.NodeList
+	if err := r.memberClient.List(ctx, &nodes, client.MatchingLabelsSelector{Selector: ls}); err != nil {
+		return fmt.Errorf("failed to list nodes: %w", err)
+	}
+	if len(nodes.Items) == 0 {
+		return fmt.Errorf("no node is selected")
+	}
+
+	readyCount := 0
+	for idx := range nodes.Items {
+		for _, cond := range nodes.Items[idx].Status.Conditions {
+			if cond.Type == corev1.NodeReady && cond.Status == corev1.ConditionTrue {
+				readyCount++
+				break
+			}
+		}
+	}
+	if readyCount*100 < int(probe.MinReadyPercentage)*len(nodes.Items) {
+		return fmt.Errorf("%d out of %d nodes are ready, want at least %d%%", readyCount, len(nodes.Items), probe.MinReadyPercentage)
+	}
+	return nil
+}
+
+// probeDeploymentAvailability checks if a deployment is available.
+func probeDeploymentAvailability(ctx context.Context, r *Reconciler, spec *clusterv1beta1.ClusterHealthCheckSpec) error {
+	probe := spec.DeploymentAvailability
+	// Read the deployment as an unstructured object so that the member agent does not set up
+	// an informer for all the deployments in the member cluster.
+	deploy := &unstructured.Unstructured{}
+	deploy.SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("Deployment"))
+	if err := r.memberClient.Get(ctx, client.ObjectKey{Namespace: probe.Namespace, Name: probe.Name}, deploy); err != nil {
+		return fmt.Errorf("failed to get deployment %s/%s: %w", probe.Namespace, probe.Name, err)
+	}
+	conds, _, err := unstructured.NestedSlice(deploy.Object, "status", "conditions")
+	if err != nil {
+		return fmt.Errorf("failed to read the conditions of deployment %s/%s: %w", probe.Namespace, probe.Name, err)
+	}
+	for _, c := range conds {
+		cond, ok := c.(map[string]interface{})
+		if !ok || cond["type"] != string(appsv1.DeploymentAvailable) {
+			continue
+		}
+		if cond["status"] == string(corev1.ConditionTrue) {
+			return nil
+		}
+		return fmt.Errorf("deployment %s/%s is not available: %v", probe.Namespace, probe.Name, cond["message"])
+	}
+	return fmt.Errorf("deployment %s/%s has not reported its availability", probe.Namespace, probe.Name)
+}
+
+// probeDNS checks if a host name can be resolved.
+func probeDNS(ctx context.Context, _ *Reconciler, spec *clusterv1beta1.ClusterHealthCheckSpec) error {
+	addrs, err := net.DefaultResolver.LookupHost(ctx, spec.DNS.Hostname)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", spec.DNS.Hostname, err)
+	}
+	if len(addrs) == 0 {
+		return fmt.Errorf("%s is resolved to no address", spec.DNS.Hostname)
+	}
+	return nil
+}
+
+// probeHTTP checks if an HTTP endpoint responds successfully.
+func probeHTTP(ctx context.Context, _ *Reconciler, spec *clusterv1beta1.ClusterHealthCheckSpec) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, spec.HTTP.URL, nil)
+	if err != nil {
+		return fmt.Errorf("invalid URL %s: %w", spec.HTTP.URL, err)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call %s: %w", spec.HTTP.URL, err)
+	}
+	defer res.Body.Close()
+	// Drain the body (to a limit) so that the connection can be reused.
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 1<<16))
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("%s responded with status code %d", spec.HTTP.URL, res.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,330 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+)
+
+const (
+	chcName1 = "chc-1"
+	chcName2 = "chc-2"
+
+	deployNamespace = "ingress"
+	deployName      = "ingress-controller"
+)
+
+// TestRunHealthChecks tests the runHealthChecks method.
+func TestRunHealthChecks(t *testing.T) {
+	okServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer okServer.Close()
+	failingServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failingServer.Close()
+
+	readyNode := func(name string, ready bool) *corev1.Node {
+		status := corev1.ConditionTrue
+		if !ready {
+			status = corev1.ConditionFalse
+		}
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{"pool": "system"},
+			},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{
+					{
+						Type:   corev1.NodeReady,
+						Status: status,
+					},
+				},
+			},
+		}
+	}
+	objs := []client.Object{
+		readyNode(nodeName1, true),
+		readyNode(nodeName2, true),
+		readyNode(nodeName3, false),
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: deployNamespace,
+				Name:      deployName,
+			},
+			Status: appsv1.DeploymentStatus{
+				Conditions: []appsv1.DeploymentCondition{
+					{
+						Type:   appsv1.DeploymentAvailable,
+						Status: corev1.ConditionTrue,
+					},
+				},
+			},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: deployNamespace,
+				Name:      "unavailable",
+			},
+			Status: appsv1.DeploymentStatus{
+				Conditions: []appsv1.DeploymentCondition{
+					{
+						Type:    appsv1.DeploymentAvailable,
+						Status:  corev1.ConditionFalse,
+						Message: "Deployment does not have minimum availability.",
+					},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name       string
+		conditions []metav1.Condition
+		checks     []*clusterv1beta1.ClusterHealthCheck
+		// wantConditions maps the names of the health checks to the wanted condition reasons.
+		wantConditions map[string]string
+	}{
+		{
+			name:           "no health checks",
+			wantConditions: map[string]string{},
+		},
+		{
+			name: "node readiness",
+			checks: []*clusterv1beta1.ClusterHealthCheck{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: chcName1},
+					Spec: clusterv1beta1.ClusterHealthCheckSpec{
+						NodeReadiness: &clusterv1beta1.NodeReadinessProbe{
+							MinReadyPercentage: 60,
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: chcName2},
+					Spec: clusterv1beta1.ClusterHealthCheckSpec{
+						NodeReadiness: &clusterv1beta1.NodeReadinessProbe{
+							MinReadyPercentage: 100,
+							LabelSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"pool": "system"},
+							},
+						},
+						TaintOnFailure: true,
+					},
+				},
+			},
+			wantConditions: map[string]string{
+				chcName1: clusterv1beta1.ClusterHealthCheckPassedReason,
+				chcName2: clusterv1beta1.ClusterHealthCheckFailedTaintReason,
+			},
+		},
+		{
+			name: "deployment availability",
+			checks: []*clusterv1beta1.ClusterHealthCheck{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: chcName1},
+					Spec: clusterv1beta1.ClusterHealthCheckSpec{
+						DeploymentAvailability: &clusterv1beta1.DeploymentAvailabilityProbe{
+							Namespace: deployNamespace,
+							Name:      deployName,
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: chcName2},
+					Spec: clusterv1beta1.ClusterHealthCheckSpec{
+						DeploymentAvailability: &clusterv1beta1.DeploymentAvailabilityProbe{
+							Namespace: deployNamespace,
+							Name:      "unavailable",
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "chc-3"},
+					Spec: clusterv1beta1.ClusterHealthCheckSpec{
+						DeploymentAvailability: &clusterv1beta1.DeploymentAvailabilityProbe{
+							Namespace: deployNamespace,
+							Name:      "not-found",
+						},
+					},
+				},
+			},
+			wantConditions: map[string]string{
+				chcName1: clusterv1beta1.ClusterHealthCheckPassedReason,
+				chcName2: clusterv1beta1.ClusterHealthCheckFailedReason,
+				"chc-3":  clusterv1beta1.ClusterHealthCheckFailedReason,
+			},
+		},
+		{
+			name: "dns and http",
+			checks: []*clusterv1beta1.ClusterHealthCheck{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: chcName1},
+					Spec: clusterv1beta1.ClusterHealthCheckSpec{
+						DNS: &clusterv1beta1.DNSProbe{
+							Hostname: "localhost",
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: chcName2},
+					Spec: clusterv1beta1.ClusterHealthCheckSpec{
+						HTTP: &clusterv1beta1.HTTPProbe{
+							URL: okServer.URL,
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "chc-3"},
+					Spec: clusterv1beta1.ClusterHealthCheckSpec{
+						HTTP: &clusterv1beta1.HTTPProbe{
+							URL: failingServer.URL,
+						},
+						TaintOnFailure: true,
+					},
+				},
+			},
+			wantConditions: map[string]string{
+				chcName1: clusterv1beta1.ClusterHealthCheckPassedReason,
+				chcName2: clusterv1beta1.ClusterHealthCheckPassedReason,
+				"chc-3":  clusterv1beta1.ClusterHealthCheckFailedTaintReason,
+			},
+		},
+		{
+			name: "stale conditions removed",
+			conditions: []metav1.Condition{
+				{
+					Type:   string(clusterv1beta1.ConditionTypeClusterPropertyCollectionSucceeded),
+					Status: metav1.ConditionTrue,
+					Reason: ClusterPropertyCollectionSucceededReason,
+				},
+				{
+					Type:   clusterv1beta1.ClusterHealthCheckConditionTypePrefix + "deleted",
+					Status: metav1.ConditionFalse,
+					Reason: clusterv1beta1.ClusterHealthCheckFailedReason,
+				},
+			},
+			checks: []*clusterv1beta1.ClusterHealthCheck{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: chcName1},
+					Spec: clusterv1beta1.ClusterHealthCheckSpec{
+						NodeReadiness: &clusterv1beta1.NodeReadinessProbe{
+							MinReadyPercentage: 50,
+						},
+					},
+				},
+			},
+			wantConditions: map[string]string{
+				chcName1: clusterv1beta1.ClusterHealthCheckPassedReason,
+			},
+		},
+	}
+
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add scheme (corev1): %v", err)
+	}
+	if err := appsv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add scheme (appsv1): %v", err)
+	}
+	if err := clusterv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add scheme (clusterv1beta1): %v", err)
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClientBuilder := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...)
+			for _, check := range tc.checks {
+				fakeClientBuilder.WithObjects(check)
+				fakeClientBuilder.WithStatusSubresource(check)
+			}
+			fakeClient := fakeClientBuilder.Build()
+
+			r := &Reconciler{
+				memberClient: fakeClient,
+			}
+
+			imc := &clusterv1beta1.InternalMemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: imcName,
+				},
+				Status: clusterv1beta1.InternalMemberClusterStatus{
+					Conditions: tc.conditions,
+				},
+			}
+			if err := r.runHealthChecks(ctx, imc); err != nil {
+				t.Fatalf("runHealthChecks() = %v, want no error", err)
+			}
+
+			gotConditions := make(map[string]string)
+			for _, cond := range imc.Status.Conditions {
+				if name, found := cutHealthCheckConditionType(cond.Type); found {
+					gotConditions[name] = cond.Reason
+				}
+			}
+			if diff := cmp.Diff(gotConditions, tc.wantConditions); diff != "" {
+				t.Errorf("health check conditions mismatch (-got, +want):\n%s", diff)
+			}
+			// Verify that the other conditions are kept.
+			for _, cond := range tc.conditions {
+				if _, found := cutHealthCheckConditionType(cond.Type); found {
+					continue
+				}
+				if diff := cmp.Diff(meta.FindStatusCondition(imc.Status.Conditions, cond.Type), &cond, cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime")); diff != "" {
+					t.Errorf("condition %s mismatch (-got, +want):\n%s", cond.Type, diff)
+				}
+			}
+
+			// Verify the status of the health checks.
+			var checkList clusterv1beta1.ClusterHealthCheckList
+			if err := fakeClient.List(ctx, &checkList); err != nil {
+				t.Fatalf("failed to list cluster health checks: %v", err)
+			}
+			for _, check := range checkList.Items {
+				cond := check.GetCondition(string(clusterv1beta1.ClusterHealthCheckConditionTypePassed))
+				if cond == nil || cond.Reason != tc.wantConditions[check.Name] {
+					t.Errorf("health check %s has condition %+v, want reason %s", check.Name, cond, tc.wantConditions[check.Name])
+				}
+			}
+		})
+	}
+}
+
+// cutHealthCheckConditionType returns the name of the health check of a condition type.
+func cutHealthCheckConditionType(condType string) (string, bool) {
+	return strings.CutPrefix(condType, clusterv1beta1.ClusterHealthCheckConditionTypePrefix)
+}
@@ -110,6 +110,9 @@ type Reconciler struct {
 	propertyProviderCfg *propertyProviderConfig
 
 	recorder record.EventRecorder
+
+	// celPropertyPrograms caches the compiled CEL programs of the custom properties.
+	celPropertyPrograms celPropertyProgramCache
 }
 
 const (
@@ -126,6 +129,8 @@ const (
 	ClusterPropertyCollectionFailedTooManyCallsMessage = "There are too many on-going calls to the property provider; will retry if some calls return"
 	ClusterPropertyCollectionTimedOutReason            = "TimedOut"
 	ClusterPropertyCollectionTimedOutMessage           = "The property provider does not respond in time"
+	ClusterPropertyCollectionFailedReason              = "CollectionFailed"
+	ClusterPropertyCollectionFailedMessage             = "The property provider has failed to collect the cluster properties: %v"
 	ClusterPropertyCollectionSucceededReason           = "PropertiesCollected"
 	ClusterPropertyCollectionSucceededMessage          = "The property provider has returned the latest cluster properties"
 
@@ -295,7 +300,8 @@ func (r *Reconciler) stopAgents(ctx context.Context, imc *clusterv1beta1.Interna
 	return nil
 }
 
-// updateHealth collects and updates member cluster resource stats and set ConditionTypeInternalMemberClusterHealth.
+// updateHealth probes the member cluster API server and sets the AgentHealthy condition; it also runs
+// the health checks defined by the ClusterHealthCheck objects in the member cluster.
 func (r *Reconciler) updateHealth(ctx context.Context, imc *clusterv1beta1.InternalMemberCluster) error {
 	klog.V(2).InfoS("Updating health status", "internalMemberCluster", klog.KObj(imc))
 
@@ -310,7 +316,9 @@ func (r *Reconciler) updateHealth(ctx context.Context, imc *clusterv1beta1.Inter
 
 	klog.V(2).InfoS("Health probe succeeded", "internalMemberCluster", klog.KObj(imc))
 	r.markInternalMemberClusterHealthy(imc)
-	return nil
+
+	// Run the user-defined health checks, whose results are reported as separate conditions.
+	return r.runHealthChecks(ctx, imc)
 }
 
 // connectToPropertyProvider connects to the property provider to collect the latest cluster properties.
@@ -360,7 +368,11 @@ func (r *Reconciler) connectToPropertyProvider(ctx context.Context, imc *cluster
 	if r.propertyProviderCfg.propertyProvider != nil && r.propertyProviderCfg.isPropertyProviderStarted {
 		// Attempt to collect latest cluster properties via the property provider.
 		klog.V(2).InfoS("Calling property provider for latest cluster properties", "internalMemberCluster", klog.KObj(imc))
-		return r.reportClusterPropertiesWithPropertyProvider(ctx, imc)
+		if err := r.reportClusterPropertiesWithPropertyProvider(ctx, imc); err != nil {
+			return err
+		}
+		// Add the user-defined properties on top of the ones from the property provider.
+		return r.reportCustomProperties(ctx, imc)
 	}
 
 	// Fall back to the built-in default behavior.
@@ -373,7 +385,8 @@ func (r *Reconciler) connectToPropertyProvider(ctx context.Context, imc *cluster
 		klog.ErrorS(err, "Failed to report cluster properties using built-in mechanism", "internalMemberCluster", klog.KObj(imc))
 		return err
 	}
-	return nil
+	// Add the user-defined properties on top of the built-in ones.
+	return r.reportCustomProperties(ctx, imc)
 }
 
 // reportPropertyProviderCollectionCondition reports the condition of whether a property
@@ -451,6 +464,20 @@ func (r *Reconciler) reportClusterPropertiesWithPropertyProvider(ctx context.Con
 		r.recorder.Event(imc, corev1.EventTypeWarning, ClusterPropertyCollectionTimedOutReason, ClusterPropertyCollectionTimedOutMessage)
 		return err
 	case <-collectedCh:
+		if res.Err != nil {
+			// The property provider has failed to collect the cluster properties; report the
+			// failure and keep the properties from the last successful collection.
+			message := fmt.Sprintf(ClusterPropertyCollectionFailedMessage, res.Err)
+			reportPropertyProviderCollectionCondition(imc,
+				metav1.ConditionFalse,
+				ClusterPropertyCollectionFailedReason,
+				message,
+			)
+			klog.ErrorS(res.Err, "Property provider failed to collect cluster properties", "internalMemberCluster", klog.KObj(imc))
+			r.recorder.Event(imc, corev1.EventTypeWarning, ClusterPropertyCollectionFailedReason, message)
+			return fmt.Errorf("property provider failed to collect cluster properties: %w", res.Err)
+		}
+
 		// The property provider has returned the latest cluster properties; update the
 		// internal member cluster object with the collected properties.
 		klog.V(2).InfoS("Property provider cluster property collection completed", "internalMemberCluster", klog.KObj(imc))
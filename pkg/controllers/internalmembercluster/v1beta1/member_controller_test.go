@@ -546,6 +546,87 @@ func TestReportClusterPropertiesWithPropertyProviderTimedOut(t *testing.T) {
 	}
 }
 
+// failedToCollectProvider is a property provider that always fails to collect properties.
+type failedToCollectProvider struct{}
+
+var _ propertyprovider.PropertyProvider = &failedToCollectProvider{}
+
+func (p *failedToCollectProvider) Start(_ context.Context, _ *rest.Config) error {
+	return nil
+}
+
+func (p *failedToCollectProvider) Collect(_ context.Context) propertyprovider.PropertyCollectionResponse {
+	return propertyprovider.PropertyCollectionResponse{
+		Err: errors.New("failed to collect properties"),
+	}
+}
+
+// TestReportClusterPropertiesWithPropertyProviderFailed tests the reportClusterPropertiesWithPropertyProvider method,
+// specifically the case where the property provider fails to collect properties.
+func TestReportClusterPropertiesWithPropertyProviderFailed(t *testing.T) {
+	testCases := []struct {
+		name    string
+		imc     *clusterv1beta1.InternalMemberCluster
+		wantIMC *clusterv1beta1.InternalMemberCluster
+	}{
+		{
+			name: "collection failed, previous properties kept",
+			imc: &clusterv1beta1.InternalMemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: imcName,
+				},
+				Status: clusterv1beta1.InternalMemberClusterStatus{
+					Properties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+						exampleClusterPropertyName: {
+							Value: exampleClusterPropertyValue,
+						},
+					},
+				},
+			},
+			wantIMC: &clusterv1beta1.InternalMemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: imcName,
+				},
+				Status: clusterv1beta1.InternalMemberClusterStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:    string(clusterv1beta1.ConditionTypeClusterPropertyCollectionSucceeded),
+							Status:  metav1.ConditionFalse,
+							Reason:  ClusterPropertyCollectionFailedReason,
+							Message: fmt.Sprintf(ClusterPropertyCollectionFailedMessage, "failed to collect properties"),
+						},
+					},
+					Properties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+						exampleClusterPropertyName: {
+							Value: exampleClusterPropertyValue,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			r := &Reconciler{
+				propertyProviderCfg: &propertyProviderConfig{
+					propertyProvider: &failedToCollectProvider{},
+				},
+				recorder: utils.NewFakeRecorder(1),
+			}
+
+			if err := r.reportClusterPropertiesWithPropertyProvider(ctx, tc.imc); err == nil {
+				t.Fatalf("reportClusterPropertiesWithPropertyProvider(), got no error, want error")
+			}
+
+			if diff := cmp.Diff(tc.imc, tc.wantIMC, ignoreLTTConditionField); diff != "" {
+				t.Fatalf("internalMemberCluster, (-got, +want):\n%s", diff)
+			}
+		})
+	}
+}
+
 // dummyProvider is a property provider that returns some static properties for testing
 // purposes.
 type dummyProvider struct{}
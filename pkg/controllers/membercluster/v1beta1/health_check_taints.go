@@ -0,0 +1,116 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+)
+
+const (
+	eventReasonHealthCheckTaintsUpdated = "HealthCheckTaintsUpdated"
+)
+
+// syncHealthCheckTaints taints the MemberCluster for each failed health check that asks for a taint,
+// and removes the taints of the health checks that have passed or no longer exist.
+//
+// Note that the health check results are read from the InternalMemberCluster object, as the MemberCluster
+// object (and its status) is refreshed with the response of the update call.
+func (r *Reconciler) syncHealthCheckTaints(ctx context.Context, mc *clusterv1beta1.MemberCluster, imc *clusterv1beta1.InternalMemberCluster) error {
+	if imc == nil {
+		return nil
+	}
+
+	wantTaintValues := make(map[string]bool)
+	for _, cond := range imc.Status.Conditions {
+		name, found := strings.CutPrefix(cond.Type, clusterv1beta1.ClusterHealthCheckConditionTypePrefix)
+		if !found || cond.Status != metav1.ConditionFalse || cond.Reason != clusterv1beta1.ClusterHealthCheckFailedTaintReason {
+			continue
+		}
+		// The names of the ClusterHealthCheck objects are limited to valid taint values, but the member
+		// cluster might run with an older CRD which does not enforce the limit; such a check cannot be
+		// turned into a taint, which the MemberCluster validating webhook would reject.
+		if errs := validation.IsValidLabelValue(name); len(errs) != 0 {
+			klog.V(2).InfoS("Skipping the taint of a failed health check with an invalid name", "memberCluster", klog.KObj(mc), "healthCheck", name, "errors", errs)
+			continue
+		}
+		wantTaintValues[name] = true
+	}
+
+	taints := make([]clusterv1beta1.Taint, 0, len(mc.Spec.Taints)+len(wantTaintValues))
+	removedCount := 0
+	for _, taint := range mc.Spec.Taints {
+		if taint.Key != clusterv1beta1.HealthCheckFailedTaintKey {
+			taints = append(taints, taint)
+			continue
+		}
+		if !wantTaintValues[taint.Value] {
+			removedCount++
+			continue
+		}
+		taints = append(taints, taint)
+		delete(wantTaintValues, taint.Value)
+	}
+	addedValues := make([]string, 0, len(wantTaintValues))
+	for value := range wantTaintValues {
+		addedValues = append(addedValues, value)
+	}
+	sort.Strings(addedValues)
+	for _, value := range addedValues {
+		taints = append(taints, clusterv1beta1.Taint{
+			Key:    clusterv1beta1.HealthCheckFailedTaintKey,
+			Value:  value,
+			Effect: corev1.TaintEffectNoSchedule,
+		})
+	}
+	if removedCount == 0 && len(addedValues) == 0 {
+		return nil
+	}
+
+	mc.Spec.Taints = taints
+	klog.V(2).InfoS("Updating the health check taints of the member cluster", "memberCluster", klog.KObj(mc), "addedTaints", addedValues, "removedTaintCount", removedCount)
+	if err := r.Client.Update(ctx, mc, client.FieldOwner(utils.MCControllerFieldManagerName)); err != nil {
+		return fmt.Errorf("failed to update the health check taints of member cluster %s: %w", mc.Name, err)
+	}
+	r.recorder.Event(mc, corev1.EventTypeNormal, eventReasonHealthCheckTaintsUpdated,
+		fmt.Sprintf("health check taints were updated, added for %v, removed %d", addedValues, removedCount))
+	return nil
+}
+
+// removeStaleHealthCheckConditions removes the health check conditions from the MemberCluster that
+// are no longer reported on the InternalMemberCluster, i.e., the ones of the deleted health checks.
+func removeStaleHealthCheckConditions(imc *clusterv1beta1.InternalMemberCluster, mc *clusterv1beta1.MemberCluster) {
+	for idx := len(mc.Status.Conditions) - 1; idx >= 0; idx-- {
+		condType := mc.Status.Conditions[idx].Type
+		if strings.HasPrefix(condType, clusterv1beta1.ClusterHealthCheckConditionTypePrefix) &&
+			meta.FindStatusCondition(imc.Status.Conditions, condType) == nil {
+			meta.RemoveStatusCondition(&mc.Status.Conditions, condType)
+		}
+	}
+}
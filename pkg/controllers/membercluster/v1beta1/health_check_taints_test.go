@@ -0,0 +1,303 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+)
+
+const (
+	healthCheckName1 = "dns"
+	healthCheckName2 = "ingress"
+)
+
+func healthCheckCondition(name string, status metav1.ConditionStatus, reason string) metav1.Condition {
+	return metav1.Condition{
+		Type:   clusterv1beta1.ClusterHealthCheckConditionTypePrefix + name,
+		Status: status,
+		Reason: reason,
+	}
+}
+
+func healthCheckTaint(name string) clusterv1beta1.Taint {
+	return clusterv1beta1.Taint{
+		Key:    clusterv1beta1.HealthCheckFailedTaintKey,
+		Value:  name,
+		Effect: corev1.TaintEffectNoSchedule,
+	}
+}
+
+// TestSyncHealthCheckTaints tests the syncHealthCheckTaints method.
+func TestSyncHealthCheckTaints(t *testing.T) {
+	userTaint := clusterv1beta1.Taint{
+		Key:    "example.com/maintenance",
+		Effect: corev1.TaintEffectNoSchedule,
+	}
+
+	testCases := []struct {
+		name       string
+		taints     []clusterv1beta1.Taint
+		imc        *clusterv1beta1.InternalMemberCluster
+		wantTaints []clusterv1beta1.Taint
+	}{
+		{
+			name:       "no internal member cluster",
+			taints:     []clusterv1beta1.Taint{userTaint},
+			wantTaints: []clusterv1beta1.Taint{userTaint},
+		},
+		{
+			name:   "failed health checks",
+			taints: []clusterv1beta1.Taint{userTaint},
+			imc: &clusterv1beta1.InternalMemberCluster{
+				Status: clusterv1beta1.InternalMemberClusterStatus{
+					Conditions: []metav1.Condition{
+						healthCheckCondition(healthCheckName2, metav1.ConditionFalse, clusterv1beta1.ClusterHealthCheckFailedTaintReason),
+						healthCheckCondition(healthCheckName1, metav1.ConditionFalse, clusterv1beta1.ClusterHealthCheckFailedTaintReason),
+						healthCheckCondition("nodes", metav1.ConditionFalse, clusterv1beta1.ClusterHealthCheckFailedReason),
+					},
+				},
+			},
+			wantTaints: []clusterv1beta1.Taint{
+				userTaint,
+				healthCheckTaint(healthCheckName1),
+				healthCheckTaint(healthCheckName2),
+			},
+		},
+		{
+			name: "health checks passed or deleted",
+			taints: []clusterv1beta1.Taint{
+				healthCheckTaint(healthCheckName1),
+				userTaint,
+				healthCheckTaint(healthCheckName2),
+				healthCheckTaint("deleted"),
+			},
+			imc: &clusterv1beta1.InternalMemberCluster{
+				Status: clusterv1beta1.InternalMemberClusterStatus{
+					Conditions: []metav1.Condition{
+						healthCheckCondition(healthCheckName1, metav1.ConditionTrue, clusterv1beta1.ClusterHealthCheckPassedReason),
+						healthCheckCondition(healthCheckName2, metav1.ConditionFalse, clusterv1beta1.ClusterHealthCheckFailedTaintReason),
+					},
+				},
+			},
+			wantTaints: []clusterv1beta1.Taint{
+				userTaint,
+				healthCheckTaint(healthCheckName2),
+			},
+		},
+		{
+			name:   "failed health check with a name too long for a taint value",
+			taints: []clusterv1beta1.Taint{userTaint},
+			imc: &clusterv1beta1.InternalMemberCluster{
+				Status: clusterv1beta1.InternalMemberClusterStatus{
+					Conditions: []metav1.Condition{
+						healthCheckCondition(healthCheckName1, metav1.ConditionFalse, clusterv1beta1.ClusterHealthCheckFailedTaintReason),
+						healthCheckCondition(strings.Repeat("a", 64), metav1.ConditionFalse, clusterv1beta1.ClusterHealthCheckFailedTaintReason),
+					},
+				},
+			},
+			wantTaints: []clusterv1beta1.Taint{
+				userTaint,
+				healthCheckTaint(healthCheckName1),
+			},
+		},
+		{
+			name:   "no change",
+			taints: []clusterv1beta1.Taint{healthCheckTaint(healthCheckName1)},
+			imc: &clusterv1beta1.InternalMemberCluster{
+				Status: clusterv1beta1.InternalMemberClusterStatus{
+					Conditions: []metav1.Condition{
+						healthCheckCondition(healthCheckName1, metav1.ConditionFalse, clusterv1beta1.ClusterHealthCheckFailedTaintReason),
+					},
+				},
+			},
+			wantTaints: []clusterv1beta1.Taint{healthCheckTaint(healthCheckName1)},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	if err := clusterv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add scheme (clusterv1beta1): %v", err)
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			mc := &clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "mc",
+				},
+				Spec: clusterv1beta1.MemberClusterSpec{
+					Taints: tc.taints,
+				},
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(mc).Build()
+			r := &Reconciler{
+				Client:   fakeClient,
+				recorder: record.NewFakeRecorder(10),
+			}
+
+			var currentMC clusterv1beta1.MemberCluster
+			if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(mc), &currentMC); err != nil {
+				t.Fatalf("failed to get member cluster: %v", err)
+			}
+			if err := r.syncHealthCheckTaints(ctx, &currentMC, tc.imc); err != nil {
+				t.Fatalf("syncHealthCheckTaints() = %v, want no error", err)
+			}
+
+			var gotMC clusterv1beta1.MemberCluster
+			if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(mc), &gotMC); err != nil {
+				t.Fatalf("failed to get member cluster: %v", err)
+			}
+			if diff := cmp.Diff(gotMC.Spec.Taints, tc.wantTaints, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("member cluster taints mismatch (-got, +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestReconcile_HealthCheckTaintsUpdateFailure verifies that a failure to update the health check taints
+// does not block the status sync of the MemberCluster.
+func TestReconcile_HealthCheckTaintsUpdateFailure(t *testing.T) {
+	ctx := context.Background()
+	mcName := "mc"
+	namespaceName := fmt.Sprintf(utils.NamespaceNameFormat, mcName)
+	mc := &clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       mcName,
+			Finalizers: []string{placementv1beta1.MemberClusterFinalizer},
+		},
+		Spec: clusterv1beta1.MemberClusterSpec{
+			Identity: rbacv1.Subject{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      "hub-access",
+				Namespace: "fleet-system",
+			},
+		},
+	}
+	failedCond := healthCheckCondition(healthCheckName1, metav1.ConditionFalse, clusterv1beta1.ClusterHealthCheckFailedTaintReason)
+	failedCond.LastTransitionTime = metav1.Now()
+	imc := &clusterv1beta1.InternalMemberCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      mcName,
+			Namespace: namespaceName,
+		},
+		Spec: clusterv1beta1.InternalMemberClusterSpec{
+			State: clusterv1beta1.ClusterStateJoin,
+		},
+		Status: clusterv1beta1.InternalMemberClusterStatus{
+			Conditions: []metav1.Condition{failedCond},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	if err := clusterv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add scheme (clusterv1beta1): %v", err)
+	}
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add scheme (corev1): %v", err)
+	}
+	if err := rbacv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add scheme (rbacv1): %v", err)
+	}
+	updateErr := errors.New("update error")
+	fakeClient := interceptor.NewClient(
+		fake.NewClientBuilder().WithScheme(scheme).WithObjects(mc, imc).WithStatusSubresource(mc, imc).Build(),
+		interceptor.Funcs{
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				if _, ok := obj.(*clusterv1beta1.MemberCluster); ok {
+					return updateErr
+				}
+				return c.Update(ctx, obj, opts...)
+			},
+		},
+	)
+	r := &Reconciler{
+		Client:   fakeClient,
+		recorder: record.NewFakeRecorder(10),
+	}
+
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: mcName}}); !errors.Is(err, updateErr) {
+		t.Fatalf("Reconcile() = %v, want %v", err, updateErr)
+	}
+
+	var gotMC clusterv1beta1.MemberCluster
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(mc), &gotMC); err != nil {
+		t.Fatalf("failed to get member cluster: %v", err)
+	}
+	if len(gotMC.Spec.Taints) != 0 {
+		t.Errorf("member cluster taints = %v, want none as the update fails", gotMC.Spec.Taints)
+	}
+	if meta.FindStatusCondition(gotMC.Status.Conditions, failedCond.Type) == nil {
+		t.Errorf("member cluster conditions = %v, want the health check condition %s to be synced", gotMC.Status.Conditions, failedCond.Type)
+	}
+}
+
+// TestRemoveStaleHealthCheckConditions tests the removeStaleHealthCheckConditions function.
+func TestRemoveStaleHealthCheckConditions(t *testing.T) {
+	otherCond := metav1.Condition{
+		Type:   string(clusterv1beta1.ConditionTypeMemberClusterJoined),
+		Status: metav1.ConditionTrue,
+		Reason: reasonMemberClusterJoined,
+	}
+	imc := &clusterv1beta1.InternalMemberCluster{
+		Status: clusterv1beta1.InternalMemberClusterStatus{
+			Conditions: []metav1.Condition{
+				healthCheckCondition(healthCheckName1, metav1.ConditionTrue, clusterv1beta1.ClusterHealthCheckPassedReason),
+			},
+		},
+	}
+	mc := &clusterv1beta1.MemberCluster{
+		Status: clusterv1beta1.MemberClusterStatus{
+			Conditions: []metav1.Condition{
+				otherCond,
+				healthCheckCondition(healthCheckName1, metav1.ConditionTrue, clusterv1beta1.ClusterHealthCheckPassedReason),
+				healthCheckCondition(healthCheckName2, metav1.ConditionFalse, clusterv1beta1.ClusterHealthCheckFailedReason),
+			},
+		},
+	}
+
+	removeStaleHealthCheckConditions(imc, mc)
+	want := []metav1.Condition{
+		otherCond,
+		healthCheckCondition(healthCheckName1, metav1.ConditionTrue, clusterv1beta1.ClusterHealthCheckPassedReason),
+	}
+	if diff := cmp.Diff(mc.Status.Conditions, want); diff != "" {
+		t.Errorf("member cluster conditions mismatch (-got, +want):\n%s", diff)
+	}
+}
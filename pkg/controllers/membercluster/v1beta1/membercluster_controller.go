@@ -50,19 +50,23 @@ import (
 )
 
 const (
-	eventReasonNamespaceCreated       = "NamespaceCreated"
-	eventReasonNamespacePatched       = "NamespacePatched"
-	eventReasonRoleCreated            = "RoleCreated"
-	eventReasonRoleUpdated            = "RoleUpdated"
-	eventReasonRoleBindingCreated     = "RoleBindingCreated"
-	eventReasonRoleBindingUpdated     = "RoleBindingUpdated"
-	eventReasonIMCCreated             = "InternalMemberClusterCreated"
-	eventReasonIMCSpecUpdated         = "InternalMemberClusterSpecUpdated"
-	reasonMemberClusterReadyToJoin    = "MemberClusterReadyToJoin"
-	reasonMemberClusterNotReadyToJoin = "MemberClusterNotReadyToJoin"
-	reasonMemberClusterJoined         = "MemberClusterJoined"
-	reasonMemberClusterLeft           = "MemberClusterLeft"
-	reasonMemberClusterUnknown        = "MemberClusterJoinStateUnknown"
+	eventReasonNamespaceCreated          = "NamespaceCreated"
+	eventReasonNamespacePatched          = "NamespacePatched"
+	eventReasonRoleCreated               = "RoleCreated"
+	eventReasonRoleUpdated               = "RoleUpdated"
+	eventReasonRoleBindingCreated        = "RoleBindingCreated"
+	eventReasonRoleBindingUpdated        = "RoleBindingUpdated"
+	eventReasonClusterRoleCreated        = "ClusterRoleCreated"
+	eventReasonClusterRoleUpdated        = "ClusterRoleUpdated"
+	eventReasonClusterRoleBindingCreated = "ClusterRoleBindingCreated"
+	eventReasonClusterRoleBindingUpdated = "ClusterRoleBindingUpdated"
+	eventReasonIMCCreated                = "InternalMemberClusterCreated"
+	eventReasonIMCSpecUpdated            = "InternalMemberClusterSpecUpdated"
+	reasonMemberClusterReadyToJoin       = "MemberClusterReadyToJoin"
+	reasonMemberClusterNotReadyToJoin    = "MemberClusterNotReadyToJoin"
+	reasonMemberClusterJoined            = "MemberClusterJoined"
+	reasonMemberClusterLeft              = "MemberClusterLeft"
+	reasonMemberClusterUnknown           = "MemberClusterJoinStateUnknown"
 )
 
 // Reconciler reconciles a MemberCluster object
@@ -114,6 +118,15 @@ func (r *Reconciler) Reconcile(ctx context.Context, req runtime.Request) (runtim
 		return runtime.Result{}, err
 	}
 
+	// Taint the MemberCluster per the health check results; this must run before the status is
+	// copied, as the update call refreshes the MemberCluster object.
+	// A failure to update the taints does not block the status sync below (e.g., the heartbeat and the
+	// health check results); the error is returned afterwards so that the taints are synced again.
+	taintErr := r.syncHealthCheckTaints(ctx, &mc, currentIMC)
+	if taintErr != nil {
+		klog.ErrorS(taintErr, "Failed to sync health check taints", "memberCluster", mcObjRef)
+	}
+
 	// Copy status from InternalMemberCluster to MemberCluster.
 	r.syncInternalMemberClusterStatus(currentIMC, &mc)
 	if err := r.updateMemberClusterStatus(ctx, &mc); err != nil {
@@ -125,7 +138,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req runtime.Request) (runtim
 		return runtime.Result{}, client.IgnoreNotFound(err)
 	}
 
-	return runtime.Result{}, nil
+	return runtime.Result{}, taintErr
 }
 
 // handleDelete handles the delete event of the member cluster, makes sure the agent has finished leaving the fleet first and
@@ -299,6 +312,15 @@ func (r *Reconciler) join(ctx context.Context, mc *clusterv1beta1.MemberCluster,
 		return fmt.Errorf("failed to sync role binding: %w", err)
 	}
 
+	clusterRoleName, err := r.syncClusterRole(ctx, mc)
+	if err != nil {
+		return fmt.Errorf("failed to sync cluster role: %w", err)
+	}
+
+	if err := r.syncClusterRoleBinding(ctx, mc, clusterRoleName); err != nil {
+		return fmt.Errorf("failed to sync cluster role binding: %w", err)
+	}
+
 	if _, err := r.syncInternalMemberCluster(ctx, mc, namespaceName, imc); err != nil {
 		return fmt.Errorf("failed to sync internal member cluster spec: %w", err)
 	}
@@ -426,14 +448,7 @@ func (r *Reconciler) syncRoleBinding(ctx context.Context, mc *clusterv1beta1.Mem
 			Name:     roleName,
 		},
 	}
-	// For User and Group kind, the APIGroup is defaulted to rbac.authorization.k8s.io if not set.
-	// Reference: https://pkg.go.dev/k8s.io/api/rbac/v1#Subject
-	for i := range expectedRoleBinding.Subjects {
-		subj := &expectedRoleBinding.Subjects[i]
-		if subj.APIGroup == "" && (subj.Kind == rbacv1.GroupKind || subj.Kind == rbacv1.UserKind) {
-			subj.APIGroup = rbacv1.GroupName
-		}
-	}
+	defaultSubjectAPIGroups(expectedRoleBinding.Subjects)
 
 	// Creates role binding if not found.
 	var currentRoleBinding rbacv1.RoleBinding
@@ -465,6 +480,113 @@ func (r *Reconciler) syncRoleBinding(ctx context.Context, mc *clusterv1beta1.Mem
 	return nil
 }
 
+// syncClusterRole creates or updates the cluster role for member cluster to read the cluster-scoped
+// objects that its agent needs in hub cluster (e.g., availability rules).
+func (r *Reconciler) syncClusterRole(ctx context.Context, mc *clusterv1beta1.MemberCluster) (string, error) {
+	klog.V(4).InfoS("Sync the cluster role for the member cluster", "memberCluster", klog.KObj(mc))
+	// Cluster role name is created using member cluster name.
+	clusterRoleName := fmt.Sprintf(utils.ClusterRoleNameFormat, mc.Name)
+	expectedClusterRole := rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            clusterRoleName,
+			OwnerReferences: []metav1.OwnerReference{*toOwnerReference(mc)},
+		},
+		Rules: []rbacv1.PolicyRule{utils.AvailabilityRuleReadRule, utils.RedactionRuleReadRule},
+	}
+
+	// Creates cluster role if not found.
+	var currentClusterRole rbacv1.ClusterRole
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: clusterRoleName}, &currentClusterRole); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("failed to get cluster role %s: %w", clusterRoleName, err)
+		}
+		klog.V(2).InfoS("creating cluster role", "memberCluster", klog.KObj(mc), "clusterRole", clusterRoleName)
+		if err = r.Client.Create(ctx, &expectedClusterRole, client.FieldOwner(utils.MCControllerFieldManagerName)); err != nil {
+			return "", fmt.Errorf("failed to create cluster role %s with rules %+v: %w", clusterRoleName, expectedClusterRole.Rules, err)
+		}
+		r.recorder.Event(mc, corev1.EventTypeNormal, eventReasonClusterRoleCreated, "cluster role was created")
+		klog.V(2).InfoS("created cluster role", "memberCluster", klog.KObj(mc), "clusterRole", clusterRoleName)
+		return clusterRoleName, nil
+	}
+
+	// Updates cluster role if currentClusterRole != expectedClusterRole.
+	if reflect.DeepEqual(currentClusterRole.Rules, expectedClusterRole.Rules) {
+		return clusterRoleName, nil
+	}
+	currentClusterRole.Rules = expectedClusterRole.Rules
+	klog.V(2).InfoS("updating cluster role", "memberCluster", klog.KObj(mc), "clusterRole", clusterRoleName)
+	if err := r.Client.Update(ctx, &currentClusterRole, client.FieldOwner(utils.MCControllerFieldManagerName)); err != nil {
+		return "", fmt.Errorf("failed to update cluster role %s with rules %+v: %w", clusterRoleName, currentClusterRole.Rules, err)
+	}
+	r.recorder.Event(mc, corev1.EventTypeNormal, eventReasonClusterRoleUpdated, "cluster role was updated")
+	klog.V(2).InfoS("updated cluster role", "memberCluster", klog.KObj(mc), "clusterRole", clusterRoleName)
+	return clusterRoleName, nil
+}
+
+// syncClusterRoleBinding creates or updates the cluster role binding for member cluster to read the
+// cluster-scoped objects that its agent needs in hub cluster.
+func (r *Reconciler) syncClusterRoleBinding(ctx context.Context, mc *clusterv1beta1.MemberCluster, clusterRoleName string) error {
+	klog.V(4).InfoS("Sync the cluster role binding for the member cluster", "memberCluster", klog.KObj(mc))
+	// Cluster role binding name is created using member cluster name.
+	clusterRoleBindingName := fmt.Sprintf(utils.ClusterRoleBindingNameFormat, mc.Name)
+	expectedClusterRoleBinding := rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            clusterRoleBindingName,
+			OwnerReferences: []metav1.OwnerReference{*toOwnerReference(mc)},
+		},
+		Subjects: []rbacv1.Subject{mc.Spec.Identity},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     clusterRoleName,
+		},
+	}
+	defaultSubjectAPIGroups(expectedClusterRoleBinding.Subjects)
+
+	// Creates cluster role binding if not found.
+	var currentClusterRoleBinding rbacv1.ClusterRoleBinding
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: clusterRoleBindingName}, &currentClusterRoleBinding); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get cluster role binding %s: %w", clusterRoleBindingName, err)
+		}
+		klog.V(2).InfoS("creating cluster role binding", "memberCluster", klog.KObj(mc), "subject", mc.Spec.Identity)
+		if err = r.Client.Create(ctx, &expectedClusterRoleBinding, client.FieldOwner(utils.MCControllerFieldManagerName)); err != nil {
+			return fmt.Errorf("failed to create cluster role binding %s: %w", clusterRoleBindingName, err)
+		}
+		r.recorder.Event(mc, corev1.EventTypeNormal, eventReasonClusterRoleBindingCreated, "cluster role binding was created")
+		klog.V(2).InfoS("created cluster role binding", "memberCluster", klog.KObj(mc), "subject", mc.Spec.Identity)
+		return nil
+	}
+
+	// Updates cluster role binding if currentClusterRoleBinding != expectedClusterRoleBinding.
+	//
+	// Note that the role reference of a cluster role binding is immutable; as the cluster role
+	// name is derived from the member cluster name, only the subjects might change.
+	if reflect.DeepEqual(currentClusterRoleBinding.Subjects, expectedClusterRoleBinding.Subjects) {
+		return nil
+	}
+	currentClusterRoleBinding.Subjects = expectedClusterRoleBinding.Subjects
+	klog.V(2).InfoS("updating cluster role binding", "memberCluster", klog.KObj(mc), "subject", mc.Spec.Identity)
+	if err := r.Client.Update(ctx, &currentClusterRoleBinding, client.FieldOwner(utils.MCControllerFieldManagerName)); err != nil {
+		return fmt.Errorf("failed to update cluster role binding %s: %w", clusterRoleBindingName, err)
+	}
+	r.recorder.Event(mc, corev1.EventTypeNormal, eventReasonClusterRoleBindingUpdated, "cluster role binding was updated")
+	klog.V(2).InfoS("updated cluster role binding", "memberCluster", klog.KObj(mc), "subject", mc.Spec.Identity)
+	return nil
+}
+
+// defaultSubjectAPIGroups sets the API group of User and Group kind subjects to
+// rbac.authorization.k8s.io if not set, which is the default value the API server uses.
+// Reference: https://pkg.go.dev/k8s.io/api/rbac/v1#Subject
+func defaultSubjectAPIGroups(subjects []rbacv1.Subject) {
+	for i := range subjects {
+		subj := &subjects[i]
+		if subj.APIGroup == "" && (subj.Kind == rbacv1.GroupKind || subj.Kind == rbacv1.UserKind) {
+			subj.APIGroup = rbacv1.GroupName
+		}
+	}
+}
+
 // syncInternalMemberCluster is used to sync spec from MemberCluster to InternalMemberCluster.
 func (r *Reconciler) syncInternalMemberCluster(ctx context.Context, mc *clusterv1beta1.MemberCluster,
 	namespaceName string, currentImc *clusterv1beta1.InternalMemberCluster) (*clusterv1beta1.InternalMemberCluster, error) {
@@ -544,6 +666,7 @@ func (r *Reconciler) syncInternalMemberClusterStatus(imc *clusterv1beta1.Interna
 		cond.ObservedGeneration = mc.GetGeneration()
 		meta.SetStatusCondition(&mc.Status.Conditions, cond)
 	}
+	removeStaleHealthCheckConditions(imc, mc)
 	// Copy the cluster properties.
 	mc.Status.Properties = imc.Status.Properties
 }
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"testing"
 	"time"
 
@@ -582,6 +583,222 @@ func TestSyncRoleBinding(t *testing.T) {
 	}
 }
 
+func TestSyncClusterRole(t *testing.T) {
+	expectedMemberCluster1 := clusterv1beta1.MemberCluster{ObjectMeta: metav1.ObjectMeta{Name: "mc2"}}
+	expectedMemberCluster2 := clusterv1beta1.MemberCluster{ObjectMeta: metav1.ObjectMeta{Name: "mc3"}}
+	expectedEvent1 := utils.GetEventString(&expectedMemberCluster1, corev1.EventTypeNormal, eventReasonClusterRoleUpdated, "cluster role was updated")
+	expectedEvent2 := utils.GetEventString(&expectedMemberCluster2, corev1.EventTypeNormal, eventReasonClusterRoleCreated, "cluster role was created")
+
+	tests := map[string]struct {
+		r                     *Reconciler
+		memberCluster         *clusterv1beta1.MemberCluster
+		wantedClusterRoleName string
+		wantedEvent           string
+		wantedError           string
+	}{
+		"cluster role exists but no diff": {
+			r: &Reconciler{
+				Client: &test.MockClient{
+					MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+						o := obj.(*rbacv1.ClusterRole)
+						*o = rbacv1.ClusterRole{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "fleet-clusterrole-mc1",
+							},
+							Rules: []rbacv1.PolicyRule{utils.AvailabilityRuleReadRule, utils.RedactionRuleReadRule},
+						}
+						return nil
+					},
+				},
+			},
+			memberCluster:         &clusterv1beta1.MemberCluster{ObjectMeta: metav1.ObjectMeta{Name: "mc1"}},
+			wantedClusterRoleName: "fleet-clusterrole-mc1",
+		},
+		"cluster role exists but with diff": {
+			r: &Reconciler{
+				Client: &test.MockClient{
+					MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+						o := obj.(*rbacv1.ClusterRole)
+						*o = rbacv1.ClusterRole{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "fleet-clusterrole-mc2",
+							},
+						}
+						return nil
+					},
+					MockUpdate: func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+						return nil
+					},
+				},
+				recorder: utils.NewFakeRecorder(1),
+			},
+			memberCluster:         &expectedMemberCluster1,
+			wantedClusterRoleName: "fleet-clusterrole-mc2",
+			wantedEvent:           expectedEvent1,
+		},
+		"cluster role doesn't exist": {
+			r: &Reconciler{
+				Client: &test.MockClient{
+					MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+						return apierrors.NewNotFound(schema.GroupResource{Group: rbacv1.GroupName, Resource: "clusterroles"}, "fleet-clusterrole-mc3")
+					},
+					MockCreate: func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+						return nil
+					},
+				},
+				recorder: utils.NewFakeRecorder(1),
+			},
+			memberCluster:         &expectedMemberCluster2,
+			wantedClusterRoleName: "fleet-clusterrole-mc3",
+			wantedEvent:           expectedEvent2,
+		},
+		"cluster role get error": {
+			r: &Reconciler{
+				Client: &test.MockClient{
+					MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+						return errors.New("cluster role cannot be retrieved")
+					},
+				},
+			},
+			memberCluster: &clusterv1beta1.MemberCluster{ObjectMeta: metav1.ObjectMeta{Name: "mc4"}},
+			wantedError:   "cluster role cannot be retrieved",
+		},
+	}
+
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			got, err := tt.r.syncClusterRole(context.Background(), tt.memberCluster)
+			if tt.r.recorder != nil {
+				fakeRecorder := tt.r.recorder.(*record.FakeRecorder)
+				event := <-fakeRecorder.Events
+				assert.Equal(t, tt.wantedEvent, event)
+			}
+			if tt.wantedError == "" {
+				assert.Equal(t, err, nil, utils.TestCaseMsg, testName)
+			} else {
+				assert.Contains(t, err.Error(), tt.wantedError, utils.TestCaseMsg, testName)
+			}
+			assert.Equalf(t, tt.wantedClusterRoleName, got, utils.TestCaseMsg, testName)
+		})
+	}
+}
+
+func TestSyncClusterRoleBinding(t *testing.T) {
+	identity := rbacv1.Subject{
+		APIGroup: "rbac.authorization.k8s.io",
+		Kind:     "User",
+		Name:     "MemberClusterIdentity",
+	}
+	identityWithoutGroup := rbacv1.Subject{
+		Kind: "User",
+		Name: "MemberClusterIdentity",
+	}
+	roleRef := rbacv1.RoleRef{
+		APIGroup: rbacv1.GroupName,
+		Kind:     "ClusterRole",
+		Name:     "fleet-clusterrole-mc1",
+	}
+
+	expectedMemberCluster1 := clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "mc1"},
+		Spec:       clusterv1beta1.MemberClusterSpec{Identity: identityWithoutGroup},
+	}
+	expectedEvent1 := utils.GetEventString(&expectedMemberCluster1, corev1.EventTypeNormal, eventReasonClusterRoleBindingUpdated, "cluster role binding was updated")
+	expectedEvent2 := utils.GetEventString(&expectedMemberCluster1, corev1.EventTypeNormal, eventReasonClusterRoleBindingCreated, "cluster role binding was created")
+
+	tests := map[string]struct {
+		r           *Reconciler
+		wantedEvent string
+		wantedError string
+	}{
+		"cluster role binding exists but no diff (API group defaulted)": {
+			r: &Reconciler{
+				Client: &test.MockClient{
+					MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+						o := obj.(*rbacv1.ClusterRoleBinding)
+						*o = rbacv1.ClusterRoleBinding{
+							ObjectMeta: metav1.ObjectMeta{Name: "fleet-clusterrolebinding-mc1"},
+							Subjects:   []rbacv1.Subject{identity},
+							RoleRef:    roleRef,
+						}
+						return nil
+					},
+				},
+			},
+		},
+		"cluster role binding exists but with diff": {
+			r: &Reconciler{
+				Client: &test.MockClient{
+					MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+						o := obj.(*rbacv1.ClusterRoleBinding)
+						*o = rbacv1.ClusterRoleBinding{
+							ObjectMeta: metav1.ObjectMeta{Name: "fleet-clusterrolebinding-mc1"},
+							RoleRef:    roleRef,
+						}
+						return nil
+					},
+					MockUpdate: func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+						o := obj.(*rbacv1.ClusterRoleBinding)
+						if !reflect.DeepEqual(o.Subjects, []rbacv1.Subject{identity}) {
+							return errors.New("unexpected subjects")
+						}
+						return nil
+					},
+				},
+				recorder: utils.NewFakeRecorder(1),
+			},
+			wantedEvent: expectedEvent1,
+		},
+		"cluster role binding doesn't exist": {
+			r: &Reconciler{
+				Client: &test.MockClient{
+					MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+						return apierrors.NewNotFound(schema.GroupResource{Group: rbacv1.GroupName, Resource: "clusterrolebindings"}, "fleet-clusterrolebinding-mc1")
+					},
+					MockCreate: func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+						o := obj.(*rbacv1.ClusterRoleBinding)
+						if o.RoleRef != roleRef {
+							return errors.New("unexpected role reference")
+						}
+						return nil
+					},
+				},
+				recorder: utils.NewFakeRecorder(1),
+			},
+			wantedEvent: expectedEvent2,
+		},
+		"cluster role binding create error": {
+			r: &Reconciler{
+				Client: &test.MockClient{
+					MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+						return apierrors.NewNotFound(schema.GroupResource{Group: rbacv1.GroupName, Resource: "clusterrolebindings"}, "fleet-clusterrolebinding-mc1")
+					},
+					MockCreate: func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+						return errors.New("cluster role binding cannot be created")
+					},
+				},
+			},
+			wantedError: "cluster role binding cannot be created",
+		},
+	}
+
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := tt.r.syncClusterRoleBinding(context.Background(), &expectedMemberCluster1, "fleet-clusterrole-mc1")
+			if tt.r.recorder != nil {
+				fakeRecorder := tt.r.recorder.(*record.FakeRecorder)
+				event := <-fakeRecorder.Events
+				assert.Equal(t, tt.wantedEvent, event)
+			}
+			if tt.wantedError == "" {
+				assert.Equal(t, err, nil, utils.TestCaseMsg, testName)
+			} else {
+				assert.Contains(t, err.Error(), tt.wantedError, utils.TestCaseMsg, testName)
+			}
+		})
+	}
+}
+
 func TestSyncInternalMemberCluster(t *testing.T) {
 	deleteTime := metav1.Now()
 	updateMock := func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
@@ -40,9 +40,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/controllers/blobcollector"
 	hubmetrics "go.goms.io/fleet/pkg/metrics/hub"
 	"go.goms.io/fleet/pkg/scheduler/queue"
 	"go.goms.io/fleet/pkg/utils/annotations"
+	"go.goms.io/fleet/pkg/utils/blobstore"
 	"go.goms.io/fleet/pkg/utils/condition"
 	"go.goms.io/fleet/pkg/utils/controller"
 	"go.goms.io/fleet/pkg/utils/defaulter"
@@ -54,6 +56,8 @@ import (
 // The max size of an object in k8s is 1.5MB because of ETCD limit https://etcd.io/docs/v3.3/dev-guide/limit/.
 // We choose 800KB as the soft limit for all the selected resources within one resourceSnapshot object because of this test in k8s which checks
 // if object size is greater than 1MB https://github.com/kubernetes/kubernetes/blob/db1990f48b92d603f469c1c89e2ad36da1b74846/test/integration/master/synthetic_master_test.go#L337
+// When a blob store is in use, large selected resources are kept in the store instead, and the snapshots only keep the
+// references to them, which significantly reduces the number of sub-indexed snapshots needed for large placements.
 var resourceSnapshotResourceSizeLimit = 800 * (1 << 10) // 800KB
 
 // We use a safety resync period to requeue all the finished request just in case there is a bug in the system.
@@ -83,6 +87,18 @@ type Reconciler struct {
 
 	// ResourceChangesCollectionDuration is the duration for collecting resource changes into one snapshot.
 	ResourceChangesCollectionDuration time.Duration
+
+	// BlobStore is the external store for the selected resources that are too large to be kept in the
+	// resource snapshots; it is nil if no external store is in use.
+	BlobStore blobstore.Store
+
+	// BlobOffloadThresholdBytes is the size above which a selected resource is saved in the blob store,
+	// with only a reference to it kept in the resource snapshot.
+	BlobOffloadThresholdBytes int
+
+	// BlobCollector deletes the blobs that are no longer referenced from the blob store; it is triggered
+	// whenever resource snapshots are deleted. It is nil if no external store is in use.
+	BlobCollector *blobcollector.Collector
 }
 
 func (r *Reconciler) Reconcile(ctx context.Context, key controller.QueueKey) (ctrl.Result, error) {
@@ -139,6 +155,7 @@ func (r *Reconciler) handleDelete(ctx context.Context, placementObj fleetv1beta1
 	if err := controller.DeleteResourceSnapshots(ctx, r.Client, placementObj); err != nil {
 		return ctrl.Result{}, err
 	}
+	r.BlobCollector.Trigger()
 	// change the metrics to add nameplace of namespace
 	hubmetrics.FleetPlacementStatusLastTimeStampSeconds.DeletePartialMatch(prometheus.Labels{"namespace": placementObj.GetNamespace(), "name": placementObj.GetName()})
 	controllerutil.RemoveFinalizer(placementObj, fleetv1beta1.PlacementCleanupFinalizer)
@@ -440,6 +457,7 @@ func (r *Reconciler) deleteRedundantResourceSnapshots(ctx context.Context, place
 	placementKObj := klog.KObj(placementObj)
 	lastGroupIndex := -1
 	groupCounter := 0
+	deleted := false
 
 	// delete the snapshots from the end as there are could be multiple snapshots in a group in order to keep the latest
 	// snapshots from the end.
@@ -462,6 +480,11 @@ func (r *Reconciler) deleteRedundantResourceSnapshots(ctx context.Context, place
 			klog.ErrorS(err, "Failed to delete resourceSnapshot", "placement", placementKObj, "resourceSnapshot", snapshotKObj)
 			return controller.NewAPIServerError(false, err)
 		}
+		deleted = true
+	}
+	if deleted {
+		// The blobs referenced by the deleted snapshots only may be deleted now.
+		r.BlobCollector.Trigger()
 	}
 	if groupCounter-revisionHistoryLimit > 0 {
 		// We always delete before creating a new snapshot, the snapshot group size should never exceed the limit
@@ -566,8 +589,14 @@ func (r *Reconciler) getOrCreateResourceSnapshot(ctx context.Context, placement
 		}
 		latestResourceSnapshotIndex++
 	}
+	// offload the large selected resources to the blob store, if any.
+	selectedResources, err := r.offloadSelectedResources(ctx, resourceSnapshotSpec.SelectedResources)
+	if err != nil {
+		klog.ErrorS(err, "Failed to offload the selected resources to the blob store", "placement", placementKObj)
+		return ctrl.Result{}, nil, err
+	}
 	// split selected resources as list of lists.
-	selectedResourcesList := controller.SplitSelectedResources(resourceSnapshotSpec.SelectedResources, resourceSnapshotResourceSizeLimit)
+	selectedResourcesList := controller.SplitSelectedResources(selectedResources, resourceSnapshotResourceSizeLimit)
 	var resourceSnapshot fleetv1beta1.ResourceSnapshotObj
 	for i := resourceSnapshotStartIndex; i < len(selectedResourcesList); i++ {
 		if i == 0 {
@@ -591,6 +620,25 @@ func (r *Reconciler) getOrCreateResourceSnapshot(ctx context.Context, placement
 	return ctrl.Result{}, latestResourceSnapshot, nil
 }
 
+// offloadSelectedResources saves the selected resources whose sizes exceed the offload threshold in the
+// blob store, and returns the selected resources with the offloaded ones replaced by references.
+// The selected resources are returned as they are if no blob store is in use.
+func (r *Reconciler) offloadSelectedResources(ctx context.Context, selectedResources []fleetv1beta1.ResourceContent) ([]fleetv1beta1.ResourceContent, error) {
+	if r.BlobStore == nil {
+		return selectedResources, nil
+	}
+	offloaded := make([]fleetv1beta1.ResourceContent, len(selectedResources))
+	for i := range selectedResources {
+		raw, err := blobstore.Offload(ctx, r.BlobStore, selectedResources[i].Raw, r.BlobOffloadThresholdBytes)
+		if err != nil {
+			// The blob store is external to the hub cluster; retry the request as the error may be transient.
+			return nil, err
+		}
+		offloaded[i].Raw = raw
+	}
+	return offloaded, nil
+}
+
 // shouldCreateNewResourceSnapshotNow checks whether it is ready to create the new resource snapshot to avoid too frequent creation
 // based on the configured resourceSnapshotCreationMinimumInterval and resourceChangesCollectionDuration.
 func (r *Reconciler) shouldCreateNewResourceSnapshotNow(ctx context.Context, latestResourceSnapshot fleetv1beta1.ResourceSnapshotObj) (ctrl.Result, error) {
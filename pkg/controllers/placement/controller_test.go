@@ -40,6 +40,7 @@ import (
 
 	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
 	"go.goms.io/fleet/pkg/utils"
+	"go.goms.io/fleet/pkg/utils/blobstore"
 	"go.goms.io/fleet/pkg/utils/condition"
 	"go.goms.io/fleet/pkg/utils/controller"
 	"go.goms.io/fleet/pkg/utils/defaulter"
@@ -4578,3 +4579,53 @@ func TestShouldCreateNewResourceSnapshotNow(t *testing.T) {
 		})
 	}
 }
+
+func TestOffloadSelectedResources(t *testing.T) {
+	small := fleetv1beta1.ResourceContent{RawExtension: runtime.RawExtension{
+		Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"small","namespace":"app"}}`),
+	}}
+	big := fleetv1beta1.ResourceContent{RawExtension: runtime.RawExtension{
+		Raw: []byte(fmt.Sprintf(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"big","namespace":"app"},"data":{"key":"%0512d"}}`, 0)),
+	}}
+	selectedResources := []fleetv1beta1.ResourceContent{small, big}
+
+	tests := map[string]struct {
+		blobStore      *blobstore.MemoryStore
+		wantReferences []bool
+	}{
+		"no blob store": {
+			wantReferences: []bool{false, false},
+		},
+		"blob store in use": {
+			blobStore:      blobstore.NewMemoryStore(),
+			wantReferences: []bool{false, true},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := Reconciler{BlobOffloadThresholdBytes: 256}
+			if tc.blobStore != nil {
+				r.BlobStore = tc.blobStore
+			}
+			got, err := r.offloadSelectedResources(context.Background(), selectedResources)
+			if err != nil {
+				t.Fatalf("offloadSelectedResources() failed, got err %v, want no err", err)
+			}
+			if len(got) != len(tc.wantReferences) {
+				t.Fatalf("offloadSelectedResources() returned %d resources, want %d", len(got), len(tc.wantReferences))
+			}
+			for i := range got {
+				if gotReference := blobstore.IsReference(got[i].Raw); gotReference != tc.wantReferences[i] {
+					t.Errorf("offloadSelectedResources() resource %d is a reference: %t, want %t", i, gotReference, tc.wantReferences[i])
+				}
+				resolved, err := blobstore.Resolve(context.Background(), r.BlobStore, got[i].Raw)
+				if err != nil {
+					t.Fatalf("Resolve() failed, got err %v, want no err", err)
+				}
+				if diff := cmp.Diff(selectedResources[i].Raw, resolved); diff != "" {
+					t.Errorf("Resolve() mismatch (-want, +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
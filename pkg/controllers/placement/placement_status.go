@@ -373,6 +373,10 @@ func (r *Reconciler) setPerClusterPlacementStatus(
 			Reason:             condition.RolloutNotStartedYetReason,
 			Message:            "The rollout is being blocked by the rollout strategy",
 		}
+		if rolloutStartedCond.Reason == condition.RolloutWaitingForMaintenanceWindowReason {
+			cond.Reason = condition.RolloutWaitingForMaintenanceWindowReason
+			cond.Message = "The rollout is waiting for the maintenance windows to open"
+		}
 		meta.SetStatusCondition(&status.Conditions, cond)
 		res[condition.RolloutStartedCondition] = metav1.ConditionFalse
 		return res
@@ -423,6 +427,7 @@ func setResourcePlacementStatusBasedOnBinding(
 			// Note that configuration drifts can occur whether the manifests are applied
 			// successfully or not.
 			status.DriftedPlacements = binding.GetBindingStatus().DriftedPlacements
+			status.DriftRemediatedPlacements = binding.GetBindingStatus().DriftRemediatedPlacements
 		case condition.DiffReportedCondition:
 			if bindingCond.Status == metav1.ConditionTrue {
 				status.DiffedPlacements = binding.GetBindingStatus().DiffedPlacements
@@ -7469,6 +7469,44 @@ func TestSetPlacementStatusPerCluster(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:      "stale binding waiting for maintenance windows",
+			placement: crp.DeepCopy(),
+			binding: &fleetv1beta1.ClusterResourceBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Generation: 1,
+				},
+				Spec: fleetv1beta1.ResourceBindingSpec{
+					ResourceSnapshotName: "not-latest",
+				},
+				Status: fleetv1beta1.ResourceBindingStatus{
+					Conditions: []metav1.Condition{
+						{
+							Status:             metav1.ConditionFalse,
+							Type:               string(fleetv1beta1.ResourceBindingRolloutStarted),
+							Reason:             condition.RolloutWaitingForMaintenanceWindowReason,
+							ObservedGeneration: 1,
+						},
+					},
+				},
+			},
+			allConditionType: condition.CondTypesForApplyStrategies,
+			wantConditionStatusMap: map[condition.ResourceCondition]metav1.ConditionStatus{
+				condition.RolloutStartedCondition: metav1.ConditionFalse,
+			},
+			wantPerClusterPlacementStatus: fleetv1beta1.PerClusterPlacementStatus{
+				ClusterName:           cluster,
+				ObservedResourceIndex: "1",
+				Conditions: []metav1.Condition{
+					{
+						Status:             metav1.ConditionFalse,
+						Type:               string(fleetv1beta1.PerClusterRolloutStartedConditionType),
+						Reason:             condition.RolloutWaitingForMaintenanceWindowReason,
+						ObservedGeneration: placementGeneration,
+					},
+				},
+			},
+		},
 		{
 			name:      "stale binding with true rollout started condition",
 			placement: crp.DeepCopy(),
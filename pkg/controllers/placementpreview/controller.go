@@ -0,0 +1,413 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package placementpreview features a controller that evaluates ClusterResourcePlacementPreviews, i.e., it
+// finds out what a ClusterResourcePlacement would do without making the placement.
+//
+// For each preview, the controller selects the resources, runs the scheduler framework in simulation,
+// and renders the selected resources with the applicable overrides for each picked cluster, all in the
+// same way as the placement, the scheduler, and the work generator would do. The rendered resources are
+// then sent to the member clusters in preview works, which use the ReportDiff apply strategy and never
+// take over any existing object, so that the member agents report the diffs against the live objects
+// without changing anything in the member clusters.
+package placementpreview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	runtime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/controllers/workgenerator"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+	"go.goms.io/fleet/pkg/scheduler/queue"
+	"go.goms.io/fleet/pkg/utils"
+	"go.goms.io/fleet/pkg/utils/condition"
+	"go.goms.io/fleet/pkg/utils/controller"
+	"go.goms.io/fleet/pkg/utils/defaulter"
+	"go.goms.io/fleet/pkg/utils/informer"
+	"go.goms.io/fleet/pkg/utils/overrider"
+)
+
+const (
+	// previewWorkNameFmt is the format of the name of the preview work in the namespace of each picked cluster.
+	previewWorkNameFmt = "%s-preview"
+
+	// maxDiffedResourcePlacementLimit indicates the max number of diffed resource placements to include in the
+	// status of each cluster.
+	maxDiffedResourcePlacementLimit = 100
+
+	// The reasons of the conditions on the previews.
+	simulationSucceededReason = "SimulationSucceeded"
+	simulationFailedReason    = "SimulationFailed"
+	diffReportPendingReason   = "DiffReportPending"
+	diffReportedReason        = "DiffReported"
+	diffReportFailedReason    = "DiffReportFailed"
+)
+
+// Reconciler reconciles ClusterResourcePlacementPreview objects.
+type Reconciler struct {
+	client.Client
+	// InformerManager is used to check the scope of the selected resources.
+	InformerManager informer.Manager
+	// ResourceSelectorResolver selects the resources in the same way as the placement controller does.
+	ResourceSelectorResolver controller.ResourceSelectorResolver
+	// FrameworkFor returns the scheduling framework of the profile which a policy snapshot specifies;
+	// it is used to simulate the scheduling in the same way as the scheduler does.
+	FrameworkFor func(policy placementv1beta1.PolicySnapshotObj) (framework.Framework, error)
+}
+
+// Reconcile evaluates a preview once for each generation of its spec, and collects the diffs which the
+// member agents report afterwards.
+func (r *Reconciler) Reconcile(ctx context.Context, req runtime.Request) (runtime.Result, error) {
+	startTime := time.Now()
+	previewRef := klog.KRef("", req.Name)
+	klog.V(2).InfoS("PlacementPreview reconciliation starts", "clusterResourcePlacementPreview", previewRef)
+	defer func() {
+		latency := time.Since(startTime).Milliseconds()
+		klog.V(2).InfoS("PlacementPreview reconciliation ends", "clusterResourcePlacementPreview", previewRef, "latency", latency)
+	}()
+
+	var preview placementv1beta1.ClusterResourcePlacementPreview
+	if err := r.Client.Get(ctx, req.NamespacedName, &preview); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return runtime.Result{}, nil
+		}
+		klog.ErrorS(err, "Failed to get cluster resource placement preview", "clusterResourcePlacementPreview", previewRef)
+		return runtime.Result{}, controller.NewAPIServerError(true, err)
+	}
+	if preview.DeletionTimestamp != nil {
+		// The preview works are garbage collected along with the preview.
+		return runtime.Result{}, nil
+	}
+	oldStatus := preview.Status.DeepCopy()
+
+	simulatedCond := preview.GetCondition(string(placementv1beta1.PlacementPreviewConditionTypeSimulated))
+	if simulatedCond == nil || simulatedCond.ObservedGeneration != preview.Generation {
+		if err := r.simulate(ctx, &preview); err != nil {
+			if !errors.Is(err, controller.ErrUserError) {
+				return runtime.Result{}, err
+			}
+			klog.V(2).InfoS("Failed to simulate the placement preview", "clusterResourcePlacementPreview", previewRef, "error", err)
+			preview.Status = placementv1beta1.PlacementPreviewStatus{}
+			preview.SetConditions(metav1.Condition{
+				Type:               string(placementv1beta1.PlacementPreviewConditionTypeSimulated),
+				Status:             metav1.ConditionFalse,
+				Reason:             simulationFailedReason,
+				Message:            err.Error(),
+				ObservedGeneration: preview.Generation,
+			})
+			return runtime.Result{}, r.updateStatus(ctx, &preview, oldStatus)
+		}
+	} else if !condition.IsConditionStatusTrue(simulatedCond, preview.Generation) {
+		// The simulation has failed for the current generation; there are no diffs to collect.
+		return runtime.Result{}, nil
+	}
+
+	if !preview.Spec.SkipMemberClusterDiff {
+		if err := r.collectDiffs(ctx, &preview); err != nil {
+			return runtime.Result{}, err
+		}
+	}
+	return runtime.Result{}, r.updateStatus(ctx, &preview, oldStatus)
+}
+
+// simulate selects the resources, simulates the scheduling, renders the selected resources with the overrides,
+// and creates the preview works for the picked clusters; the results are set in the preview status.
+func (r *Reconciler) simulate(ctx context.Context, preview *placementv1beta1.ClusterResourcePlacementPreview) error {
+	previewRef := klog.KObj(preview)
+
+	placementName := preview.Spec.PlacementName
+	if placementName == "" {
+		placementName = preview.Name
+	}
+	crp := &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{Name: placementName},
+		Spec:       *preview.Spec.PlacementSpec.DeepCopy(),
+	}
+	defaulter.SetPlacementDefaults(crp)
+
+	_, selectedResources, selectedResourceIDs, err := r.ResourceSelectorResolver.SelectResourcesForPlacement(crp)
+	if err != nil {
+		klog.ErrorS(err, "Failed to select the resources", "clusterResourcePlacementPreview", previewRef)
+		return err
+	}
+
+	policy := controller.BuildPolicySnapshot(crp, 0, "")
+	fw, err := r.FrameworkFor(policy)
+	if err != nil {
+		klog.ErrorS(err, "Failed to find the scheduling framework", "clusterResourcePlacementPreview", previewRef)
+		return controller.NewUserError(err)
+	}
+	decisions, err := fw.SimulateSchedulingFor(ctx, queue.PlacementKey(placementName), policy)
+	if err != nil {
+		klog.ErrorS(err, "Failed to simulate the scheduling", "clusterResourcePlacementPreview", previewRef)
+		return err
+	}
+
+	cros, ros, err := overrider.FetchAllMatchingOverridesForResources(ctx, r.Client, r.InformerManager, placementName, selectedResources)
+	if err != nil {
+		klog.ErrorS(err, "Failed to fetch the matching overrides", "clusterResourcePlacementPreview", previewRef)
+		return err
+	}
+
+	renderer := &workgenerator.Reconciler{
+		Client:           r.Client,
+		InformerManager:  r.InformerManager,
+		HelmChartFetcher: r.ResourceSelectorResolver.HelmChartFetcher,
+	}
+	clusters := make([]placementv1beta1.ClusterPlacementPreview, 0, len(decisions))
+	activeWorks := make(map[types.NamespacedName]bool)
+	for i := range decisions {
+		clusterPreview := placementv1beta1.ClusterPlacementPreview{ClusterDecision: decisions[i]}
+		if !decisions[i].Selected {
+			clusters = append(clusters, clusterPreview)
+			continue
+		}
+		clusterName := decisions[i].ClusterName
+		croNames, roNames, err := overrider.PickFromResourceMatchedOverridesForTargetCluster(ctx, r.Client, clusterName, cros, ros)
+		if err != nil {
+			klog.ErrorS(err, "Failed to pick the overrides for the cluster", "clusterResourcePlacementPreview", previewRef, "cluster", clusterName)
+			return err
+		}
+		clusterPreview.ApplicableClusterResourceOverrides = croNames
+		clusterPreview.ApplicableResourceOverrides = roNames
+
+		var cluster clusterv1beta1.MemberCluster
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: clusterName}, &cluster); err != nil {
+			klog.ErrorS(err, "Failed to get the member cluster", "clusterResourcePlacementPreview", previewRef, "cluster", clusterName)
+			return controller.NewAPIServerError(true, err)
+		}
+		binding := &placementv1beta1.ClusterResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: preview.Name},
+			Spec: placementv1beta1.ResourceBindingSpec{
+				TargetCluster:                    clusterName,
+				ClusterResourceOverrideSnapshots: croNames,
+				ResourceOverrideSnapshots:        roNames,
+			},
+		}
+		manifests, err := renderer.RenderManifests(ctx, binding, &cluster, selectedResources)
+		if err != nil {
+			klog.ErrorS(err, "Failed to render the selected resources for the cluster", "clusterResourcePlacementPreview", previewRef, "cluster", clusterName)
+			return err
+		}
+		meta.SetStatusCondition(&clusterPreview.Conditions, metav1.Condition{
+			Type:               string(placementv1beta1.PlacementPreviewConditionTypeSimulated),
+			Status:             metav1.ConditionTrue,
+			Reason:             simulationSucceededReason,
+			Message:            fmt.Sprintf("Rendered %d manifest(s) for the cluster", len(manifests)),
+			ObservedGeneration: preview.Generation,
+		})
+
+		if !preview.Spec.SkipMemberClusterDiff {
+			work, err := r.upsertPreviewWork(ctx, preview, clusterName, crp.Spec.Strategy.ApplyStrategy, manifests)
+			if err != nil {
+				return err
+			}
+			activeWorks[types.NamespacedName{Namespace: work.Namespace, Name: work.Name}] = true
+		}
+		clusters = append(clusters, clusterPreview)
+	}
+
+	if err := r.deleteStalePreviewWorks(ctx, preview, activeWorks); err != nil {
+		return err
+	}
+
+	preview.Status = placementv1beta1.PlacementPreviewStatus{
+		SelectedResources: selectedResourceIDs,
+		Clusters:          clusters,
+	}
+	preview.SetConditions(metav1.Condition{
+		Type:               string(placementv1beta1.PlacementPreviewConditionTypeSimulated),
+		Status:             metav1.ConditionTrue,
+		Reason:             simulationSucceededReason,
+		Message:            fmt.Sprintf("Selected %d resource(s) and simulated the scheduling on %d cluster(s)", len(selectedResourceIDs), len(clusters)),
+		ObservedGeneration: preview.Generation,
+	})
+	klog.V(2).InfoS("Simulated the placement preview", "clusterResourcePlacementPreview", previewRef, "numberOfResources", len(selectedResourceIDs), "numberOfClusters", len(clusters))
+	return nil
+}
+
+// upsertPreviewWork creates or updates the preview work of a preview for a picked cluster.
+//
+// The work always uses the ReportDiff apply strategy and never takes over any existing object, so that
+// the member agent only reports the diffs; the comparison options are kept as the placement specifies.
+func (r *Reconciler) upsertPreviewWork(
+	ctx context.Context,
+	preview *placementv1beta1.ClusterResourcePlacementPreview,
+	clusterName string,
+	placementApplyStrategy *placementv1beta1.ApplyStrategy,
+	manifests []placementv1beta1.Manifest,
+) (*placementv1beta1.Work, error) {
+	applyStrategy := &placementv1beta1.ApplyStrategy{}
+	if placementApplyStrategy != nil {
+		applyStrategy = placementApplyStrategy.DeepCopy()
+	}
+	applyStrategy.Type = placementv1beta1.ApplyStrategyTypeReportDiff
+	applyStrategy.WhenToTakeOver = placementv1beta1.WhenToTakeOverTypeNever
+	applyStrategy.DriftRemediation = nil
+
+	work := &placementv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf(previewWorkNameFmt, preview.Name),
+			Namespace: fmt.Sprintf(utils.NamespaceNameFormat, clusterName),
+		},
+	}
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, work, func() error {
+		if work.Labels == nil {
+			work.Labels = make(map[string]string)
+		}
+		work.Labels[placementv1beta1.PlacementPreviewLabel] = preview.Name
+		work.Spec.Workload = placementv1beta1.WorkloadTemplate{Manifests: manifests}
+		work.Spec.ApplyStrategy = applyStrategy
+		return controllerutil.SetControllerReference(preview, work, r.Client.Scheme())
+	})
+	if err != nil {
+		klog.ErrorS(err, "Failed to create or update the preview work", "clusterResourcePlacementPreview", klog.KObj(preview), "work", klog.KObj(work))
+		return nil, controller.NewAPIServerError(false, err)
+	}
+	klog.V(2).InfoS("Synced the preview work", "clusterResourcePlacementPreview", klog.KObj(preview), "work", klog.KObj(work), "operation", op)
+	return work, nil
+}
+
+// deleteStalePreviewWorks deletes the preview works of a preview which are no longer needed, e.g., the works
+// for the clusters which are no longer picked.
+func (r *Reconciler) deleteStalePreviewWorks(ctx context.Context, preview *placementv1beta1.ClusterResourcePlacementPreview, activeWorks map[types.NamespacedName]bool) error {
+	var workList placementv1beta1.WorkList
+	if err := r.Client.List(ctx, &workList, client.MatchingLabels{placementv1beta1.PlacementPreviewLabel: preview.Name}); err != nil {
+		klog.ErrorS(err, "Failed to list the preview works", "clusterResourcePlacementPreview", klog.KObj(preview))
+		return controller.NewAPIServerError(true, err)
+	}
+	for i := range workList.Items {
+		work := &workList.Items[i]
+		if activeWorks[types.NamespacedName{Namespace: work.Namespace, Name: work.Name}] {
+			continue
+		}
+		if err := r.Client.Delete(ctx, work); err != nil && !k8serrors.IsNotFound(err) {
+			klog.ErrorS(err, "Failed to delete the stale preview work", "clusterResourcePlacementPreview", klog.KObj(preview), "work", klog.KObj(work))
+			return controller.NewAPIServerError(false, err)
+		}
+		klog.V(2).InfoS("Deleted the stale preview work", "clusterResourcePlacementPreview", klog.KObj(preview), "work", klog.KObj(work))
+	}
+	return nil
+}
+
+// collectDiffs collects the diffs which the member agents have reported in the status of the preview works.
+func (r *Reconciler) collectDiffs(ctx context.Context, preview *placementv1beta1.ClusterResourcePlacementPreview) error {
+	var pending, failed, reported int
+	for i := range preview.Status.Clusters {
+		clusterPreview := &preview.Status.Clusters[i]
+		if !clusterPreview.Selected {
+			continue
+		}
+		diffCond := metav1.Condition{
+			Type:               string(placementv1beta1.PlacementPreviewConditionTypeDiffReported),
+			Status:             metav1.ConditionUnknown,
+			Reason:             diffReportPendingReason,
+			Message:            "The member agent has not reported the diffs yet",
+			ObservedGeneration: preview.Generation,
+		}
+		clusterPreview.DiffedPlacements = nil
+
+		var work placementv1beta1.Work
+		workKey := types.NamespacedName{
+			Namespace: fmt.Sprintf(utils.NamespaceNameFormat, clusterPreview.ClusterName),
+			Name:      fmt.Sprintf(previewWorkNameFmt, preview.Name),
+		}
+		if err := r.Client.Get(ctx, workKey, &work); err != nil && !k8serrors.IsNotFound(err) {
+			klog.ErrorS(err, "Failed to get the preview work", "clusterResourcePlacementPreview", klog.KObj(preview), "work", workKey)
+			return controller.NewAPIServerError(true, err)
+		}
+		workDiffCond := meta.FindStatusCondition(work.Status.Conditions, placementv1beta1.WorkConditionTypeDiffReported)
+		switch {
+		case condition.IsConditionStatusTrue(workDiffCond, work.Generation):
+			diffs := workgenerator.ExtractDiffedResourcePlacementsFromWork(&work)
+			if len(diffs) > maxDiffedResourcePlacementLimit {
+				diffs = diffs[:maxDiffedResourcePlacementLimit]
+			}
+			clusterPreview.DiffedPlacements = diffs
+			diffCond.Status = metav1.ConditionTrue
+			diffCond.Reason = diffReportedReason
+			diffCond.Message = fmt.Sprintf("Found %d resource(s) with diffs", len(diffs))
+			reported++
+		case condition.IsConditionStatusFalse(workDiffCond, work.Generation):
+			diffCond.Status = metav1.ConditionFalse
+			diffCond.Reason = diffReportFailedReason
+			diffCond.Message = fmt.Sprintf("The member agent has failed to report the diffs: %s", workDiffCond.Message)
+			failed++
+		default:
+			pending++
+		}
+		meta.SetStatusCondition(&clusterPreview.Conditions, diffCond)
+	}
+
+	diffCond := metav1.Condition{
+		Type:               string(placementv1beta1.PlacementPreviewConditionTypeDiffReported),
+		Status:             metav1.ConditionTrue,
+		Reason:             diffReportedReason,
+		Message:            fmt.Sprintf("The member agents of %d cluster(s) have reported the diffs", reported),
+		ObservedGeneration: preview.Generation,
+	}
+	switch {
+	case failed > 0:
+		diffCond.Status = metav1.ConditionFalse
+		diffCond.Reason = diffReportFailedReason
+		diffCond.Message = fmt.Sprintf("The member agents of %d cluster(s) have failed to report the diffs", failed)
+	case pending > 0:
+		diffCond.Status = metav1.ConditionUnknown
+		diffCond.Reason = diffReportPendingReason
+		diffCond.Message = fmt.Sprintf("The member agents of %d cluster(s) have not reported the diffs yet", pending)
+	}
+	preview.SetConditions(diffCond)
+	return nil
+}
+
+// updateStatus updates the status of a preview if it has changed.
+func (r *Reconciler) updateStatus(ctx context.Context, preview *placementv1beta1.ClusterResourcePlacementPreview, oldStatus *placementv1beta1.PlacementPreviewStatus) error {
+	if equality.Semantic.DeepEqual(oldStatus, &preview.Status) {
+		return nil
+	}
+	if err := r.Client.Status().Update(ctx, preview); err != nil {
+		klog.ErrorS(err, "Failed to update the placement preview status", "clusterResourcePlacementPreview", klog.KObj(preview))
+		return controller.NewUpdateIgnoreConflictError(err)
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the manager; the controller also watches the preview works
+// so that the diffs are collected as soon as the member agents report them.
+func (r *Reconciler) SetupWithManager(mgr runtime.Manager) error {
+	return runtime.NewControllerManagedBy(mgr).Named("placement-preview-controller").
+		For(&placementv1beta1.ClusterResourcePlacementPreview{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&placementv1beta1.Work{}, handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(),
+			&placementv1beta1.ClusterResourcePlacementPreview{}, handler.OnlyControllerOwner())).
+		Complete(r)
+}
@@ -0,0 +1,428 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package placementpreview
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	runtime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+	"go.goms.io/fleet/pkg/scheduler/queue"
+	"go.goms.io/fleet/pkg/utils"
+	"go.goms.io/fleet/pkg/utils/controller"
+	"go.goms.io/fleet/test/utils/informer"
+)
+
+const (
+	previewName = "test-preview"
+	roleName    = "test-role"
+)
+
+// fakeFramework is a scheduling framework which returns preset scheduling decisions.
+type fakeFramework struct {
+	framework.Framework
+	decisions []placementv1beta1.ClusterDecision
+}
+
+func (f *fakeFramework) SimulateSchedulingFor(_ context.Context, _ queue.PlacementKey, _ placementv1beta1.PolicySnapshotObj) ([]placementv1beta1.ClusterDecision, error) {
+	return f.decisions, nil
+}
+
+func testScheme(t *testing.T) *k8sruntime.Scheme {
+	scheme := k8sruntime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Failed to add placement v1beta1 scheme: %v", err)
+	}
+	if err := clusterv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Failed to add cluster v1beta1 scheme: %v", err)
+	}
+	return scheme
+}
+
+func previewWorkKey(clusterName string) types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: fmt.Sprintf(utils.NamespaceNameFormat, clusterName),
+		Name:      fmt.Sprintf(previewWorkNameFmt, previewName),
+	}
+}
+
+func newTestReconciler(t *testing.T, fakeClient client.Client, frameworkErr error) *Reconciler {
+	role := &rbacv1.ClusterRole{
+		TypeMeta:   metav1.TypeMeta{APIVersion: utils.ClusterRoleGVK.GroupVersion().String(), Kind: utils.ClusterRoleGVK.Kind},
+		ObjectMeta: metav1.ObjectMeta{Name: roleName},
+	}
+	uRole, err := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(role)
+	if err != nil {
+		t.Fatalf("Failed to convert the cluster role: %v", err)
+	}
+	informerManager := &informer.FakeManager{
+		APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
+		IsClusterScopedResource: true,
+		Listers: map[schema.GroupVersionResource]*informer.FakeLister{
+			utils.ClusterRoleGVR: {Objects: []k8sruntime.Object{&unstructured.Unstructured{Object: uRole}}},
+		},
+	}
+	restMapper := meta.NewDefaultRESTMapper(nil)
+	restMapper.Add(utils.ClusterRoleGVK, meta.RESTScopeRoot)
+	return &Reconciler{
+		Client:          fakeClient,
+		InformerManager: informerManager,
+		ResourceSelectorResolver: controller.ResourceSelectorResolver{
+			RestMapper:      restMapper,
+			InformerManager: informerManager,
+			ResourceConfig:  utils.NewResourceConfig(false),
+		},
+		FrameworkFor: func(_ placementv1beta1.PolicySnapshotObj) (framework.Framework, error) {
+			if frameworkErr != nil {
+				return nil, frameworkErr
+			}
+			return &fakeFramework{
+				decisions: []placementv1beta1.ClusterDecision{
+					{ClusterName: "cluster-1", Selected: true},
+					{ClusterName: "cluster-2", Selected: true},
+					{ClusterName: "cluster-3", Reason: "filtered"},
+				},
+			}, nil
+		},
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	preview := &placementv1beta1.ClusterResourcePlacementPreview{
+		ObjectMeta: metav1.ObjectMeta{Name: previewName, Generation: 1},
+		Spec: placementv1beta1.PlacementPreviewSpec{
+			PlacementName: "test-crp",
+			PlacementSpec: placementv1beta1.PlacementSpec{
+				ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{
+					{
+						Group:   utils.ClusterRoleGVK.Group,
+						Version: utils.ClusterRoleGVK.Version,
+						Kind:    utils.ClusterRoleGVK.Kind,
+						Name:    roleName,
+					},
+				},
+				Strategy: placementv1beta1.RolloutStrategy{
+					ApplyStrategy: &placementv1beta1.ApplyStrategy{
+						Type:             placementv1beta1.ApplyStrategyTypeServerSideApply,
+						ComparisonOption: placementv1beta1.ComparisonOptionTypeFullComparison,
+						WhenToTakeOver:   placementv1beta1.WhenToTakeOverTypeAlways,
+					},
+				},
+			},
+		},
+	}
+	cro := &placementv1beta1.ClusterResourceOverrideSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "cro-1",
+			Labels: map[string]string{placementv1beta1.IsLatestSnapshotLabel: "true"},
+		},
+		Spec: placementv1beta1.ClusterResourceOverrideSnapshotSpec{
+			OverrideSpec: placementv1beta1.ClusterResourceOverrideSpec{
+				ClusterResourceSelectors: []placementv1beta1.ResourceSelectorTerm{
+					{
+						Group:   utils.ClusterRoleGVK.Group,
+						Version: utils.ClusterRoleGVK.Version,
+						Kind:    utils.ClusterRoleGVK.Kind,
+						Name:    roleName,
+					},
+				},
+				Policy: &placementv1beta1.OverridePolicy{
+					OverrideRules: []placementv1beta1.OverrideRule{
+						{
+							ClusterSelector: &placementv1beta1.ClusterSelector{
+								ClusterSelectorTerms: []placementv1beta1.ClusterSelectorTerm{
+									{
+										LabelSelector: &metav1.LabelSelector{
+											MatchLabels: map[string]string{"env": "prod"},
+										},
+									},
+								},
+							},
+							JSONPatchOverrides: []placementv1beta1.JSONPatchOverride{
+								{
+									Operator: placementv1beta1.JSONPatchOverrideOpAdd,
+									Path:     "/metadata/labels",
+									Value:    apiextensionsv1.JSON{Raw: []byte(`{"env":"prod"}`)},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	staleWork := &placementv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf(previewWorkNameFmt, previewName),
+			Namespace: fmt.Sprintf(utils.NamespaceNameFormat, "cluster-3"),
+			Labels:    map[string]string{placementv1beta1.PlacementPreviewLabel: previewName},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(testScheme(t)).
+		WithObjects(preview, cro, staleWork,
+			&clusterv1beta1.MemberCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster-1", Labels: map[string]string{"env": "prod"}}},
+			&clusterv1beta1.MemberCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster-2"}}).
+		WithStatusSubresource(preview, staleWork).
+		Build()
+	r := newTestReconciler(t, fakeClient, nil)
+	ctx := context.Background()
+	req := runtime.Request{NamespacedName: types.NamespacedName{Name: previewName}}
+
+	// The first reconciliation simulates the preview and creates the preview works.
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile() = %v, want no error", err)
+	}
+	got := &placementv1beta1.ClusterResourcePlacementPreview{}
+	if err := fakeClient.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("Get() preview = %v, want no error", err)
+	}
+	simulated := metav1.Condition{
+		Type:               string(placementv1beta1.PlacementPreviewConditionTypeSimulated),
+		Status:             metav1.ConditionTrue,
+		Reason:             simulationSucceededReason,
+		ObservedGeneration: 1,
+	}
+	pending := metav1.Condition{
+		Type:               string(placementv1beta1.PlacementPreviewConditionTypeDiffReported),
+		Status:             metav1.ConditionUnknown,
+		Reason:             diffReportPendingReason,
+		ObservedGeneration: 1,
+	}
+	wantStatus := placementv1beta1.PlacementPreviewStatus{
+		SelectedResources: []placementv1beta1.ResourceIdentifier{
+			{
+				Group:   utils.ClusterRoleGVK.Group,
+				Version: utils.ClusterRoleGVK.Version,
+				Kind:    utils.ClusterRoleGVK.Kind,
+				Name:    roleName,
+			},
+		},
+		Clusters: []placementv1beta1.ClusterPlacementPreview{
+			{
+				ClusterDecision:                    placementv1beta1.ClusterDecision{ClusterName: "cluster-1", Selected: true},
+				ApplicableClusterResourceOverrides: []string{"cro-1"},
+				Conditions:                         []metav1.Condition{simulated, pending},
+			},
+			{
+				ClusterDecision: placementv1beta1.ClusterDecision{ClusterName: "cluster-2", Selected: true},
+				Conditions:      []metav1.Condition{simulated, pending},
+			},
+			{
+				ClusterDecision: placementv1beta1.ClusterDecision{ClusterName: "cluster-3", Reason: "filtered"},
+			},
+		},
+		Conditions: []metav1.Condition{simulated, pending},
+	}
+	cmpOptions := []cmp.Option{
+		cmpopts.IgnoreFields(metav1.Condition{}, "Message", "LastTransitionTime"),
+		cmpopts.EquateEmpty(),
+	}
+	if diff := cmp.Diff(wantStatus, got.Status, cmpOptions...); diff != "" {
+		t.Errorf("preview status mismatch (-want, +got):\n%s", diff)
+	}
+
+	wantApplyStrategy := &placementv1beta1.ApplyStrategy{
+		Type:             placementv1beta1.ApplyStrategyTypeReportDiff,
+		ComparisonOption: placementv1beta1.ComparisonOptionTypeFullComparison,
+		WhenToApply:      placementv1beta1.WhenToApplyTypeAlways,
+		WhenToTakeOver:   placementv1beta1.WhenToTakeOverTypeNever,
+		// Set by the defaulter.
+		ServerSideApplyConfig: &placementv1beta1.ServerSideApplyConfig{},
+	}
+	wantRoles := map[string]string{
+		"cluster-1": `{"apiVersion":"rbac.authorization.k8s.io/v1","kind":"ClusterRole","metadata":{"labels":{"env":"prod"},"name":"test-role"},"rules":null}`,
+		"cluster-2": `{"apiVersion":"rbac.authorization.k8s.io/v1","kind":"ClusterRole","metadata":{"name":"test-role"},"rules":null}`,
+	}
+	for clusterName, wantRole := range wantRoles {
+		work := &placementv1beta1.Work{}
+		if err := fakeClient.Get(ctx, previewWorkKey(clusterName), work); err != nil {
+			t.Fatalf("Get() preview work for %s = %v, want no error", clusterName, err)
+		}
+		if diff := cmp.Diff(wantApplyStrategy, work.Spec.ApplyStrategy); diff != "" {
+			t.Errorf("preview work apply strategy for %s mismatch (-want, +got):\n%s", clusterName, diff)
+		}
+		if len(work.Spec.Workload.Manifests) != 1 {
+			t.Fatalf("preview work for %s has %d manifests, want 1", clusterName, len(work.Spec.Workload.Manifests))
+		}
+		if diff := cmp.Diff(wantRole, string(work.Spec.Workload.Manifests[0].Raw), cmpopts.AcyclicTransformer("ParseJSON", func(in string) (out map[string]interface{}) {
+			if err := json.Unmarshal([]byte(in), &out); err != nil {
+				t.Fatalf("Failed to unmarshal the manifest: %v", err)
+			}
+			return out
+		})); diff != "" {
+			t.Errorf("preview work manifest for %s mismatch (-want, +got):\n%s", clusterName, diff)
+		}
+		if work.Labels[placementv1beta1.PlacementPreviewLabel] != previewName {
+			t.Errorf("preview work labels for %s = %v, want the preview label", clusterName, work.Labels)
+		}
+		if len(work.OwnerReferences) != 1 || work.OwnerReferences[0].Name != previewName {
+			t.Errorf("preview work owner references for %s = %v, want the preview", clusterName, work.OwnerReferences)
+		}
+	}
+	if err := fakeClient.Get(ctx, previewWorkKey("cluster-3"), &placementv1beta1.Work{}); !k8serrors.IsNotFound(err) {
+		t.Errorf("Get() stale preview work = %v, want not found", err)
+	}
+
+	// The member agents report the diffs; the next reconciliation collects them.
+	diffs := []placementv1beta1.PatchDetail{{Path: "/"}}
+	for _, clusterName := range []string{"cluster-1", "cluster-2"} {
+		work := &placementv1beta1.Work{}
+		if err := fakeClient.Get(ctx, previewWorkKey(clusterName), work); err != nil {
+			t.Fatalf("Get() preview work for %s = %v, want no error", clusterName, err)
+		}
+		work.Status.Conditions = []metav1.Condition{
+			{
+				Type:               placementv1beta1.WorkConditionTypeDiffReported,
+				Status:             metav1.ConditionTrue,
+				Reason:             "DiffReported",
+				ObservedGeneration: work.Generation,
+			},
+		}
+		if clusterName == "cluster-1" {
+			work.Status.ManifestConditions = []placementv1beta1.ManifestCondition{
+				{
+					Identifier: placementv1beta1.WorkResourceIdentifier{
+						Group:   utils.ClusterRoleGVK.Group,
+						Version: utils.ClusterRoleGVK.Version,
+						Kind:    utils.ClusterRoleGVK.Kind,
+						Name:    roleName,
+					},
+					DiffDetails: &placementv1beta1.DiffDetails{ObservedDiffs: diffs},
+				},
+			}
+		}
+		if err := fakeClient.Status().Update(ctx, work); err != nil {
+			t.Fatalf("Update() preview work status for %s = %v, want no error", clusterName, err)
+		}
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile() = %v, want no error", err)
+	}
+	if err := fakeClient.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("Get() preview = %v, want no error", err)
+	}
+	reported := pending
+	reported.Status = metav1.ConditionTrue
+	reported.Reason = diffReportedReason
+	wantStatus.Conditions = []metav1.Condition{simulated, reported}
+	wantStatus.Clusters[0].Conditions = []metav1.Condition{simulated, reported}
+	wantStatus.Clusters[0].DiffedPlacements = []placementv1beta1.DiffedResourcePlacement{
+		{
+			ResourceIdentifier: placementv1beta1.ResourceIdentifier{
+				Group:   utils.ClusterRoleGVK.Group,
+				Version: utils.ClusterRoleGVK.Version,
+				Kind:    utils.ClusterRoleGVK.Kind,
+				Name:    roleName,
+			},
+			ObservedDiffs: diffs,
+		},
+	}
+	wantStatus.Clusters[1].Conditions = []metav1.Condition{simulated, reported}
+	if diff := cmp.Diff(wantStatus, got.Status, append(cmpOptions, cmpopts.IgnoreTypes(metav1.Time{}))...); diff != "" {
+		t.Errorf("preview status mismatch (-want, +got):\n%s", diff)
+	}
+}
+
+func TestReconcile_SimulationFailed(t *testing.T) {
+	roleSelector := placementv1beta1.ResourceSelectorTerm{
+		Group:   utils.ClusterRoleGVK.Group,
+		Version: utils.ClusterRoleGVK.Version,
+		Kind:    utils.ClusterRoleGVK.Kind,
+		Name:    roleName,
+	}
+	tests := []struct {
+		name         string
+		selector     placementv1beta1.ResourceSelectorTerm
+		frameworkErr error
+	}{
+		{
+			name: "unknown resource kind",
+			selector: placementv1beta1.ResourceSelectorTerm{
+				Group:   "example.com",
+				Version: "v1",
+				Kind:    "Unknown",
+				Name:    "test",
+			},
+		},
+		{
+			name:         "unknown scheduling profile",
+			selector:     roleSelector,
+			frameworkErr: errors.New("unknown scheduling profile"),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			preview := &placementv1beta1.ClusterResourcePlacementPreview{
+				ObjectMeta: metav1.ObjectMeta{Name: previewName, Generation: 2},
+				Spec: placementv1beta1.PlacementPreviewSpec{
+					PlacementSpec: placementv1beta1.PlacementSpec{
+						ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{tc.selector},
+					},
+				},
+			}
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(testScheme(t)).
+				WithObjects(preview).
+				WithStatusSubresource(preview).
+				Build()
+			r := newTestReconciler(t, fakeClient, tc.frameworkErr)
+			req := runtime.Request{NamespacedName: types.NamespacedName{Name: previewName}}
+			if _, err := r.Reconcile(context.Background(), req); err != nil {
+				t.Fatalf("Reconcile() = %v, want no error", err)
+			}
+			got := &placementv1beta1.ClusterResourcePlacementPreview{}
+			if err := fakeClient.Get(context.Background(), req.NamespacedName, got); err != nil {
+				t.Fatalf("Get() preview = %v, want no error", err)
+			}
+			wantStatus := placementv1beta1.PlacementPreviewStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:               string(placementv1beta1.PlacementPreviewConditionTypeSimulated),
+						Status:             metav1.ConditionFalse,
+						Reason:             simulationFailedReason,
+						ObservedGeneration: 2,
+					},
+				},
+			}
+			if diff := cmp.Diff(wantStatus, got.Status,
+				cmpopts.IgnoreFields(metav1.Condition{}, "Message", "LastTransitionTime"), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("preview status mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
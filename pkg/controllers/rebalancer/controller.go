@@ -0,0 +1,429 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package rebalancer features a controller that periodically re-scores the clusters which
+// ClusterResourcePlacements of the PickN placement type have been scheduled to, and migrates a
+// placement to a better cluster when the improvement in score crosses a threshold.
+//
+// A migration always creates the new binding first; the old binding is evicted, via the eviction
+// API (which enforces the ClusterResourcePlacementDisruptionBudget of the placement), only after
+// the new binding becomes available.
+package rebalancer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	runtime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+	"go.goms.io/fleet/pkg/scheduler/framework/uniquename"
+	"go.goms.io/fleet/pkg/scheduler/queue"
+	bindingutils "go.goms.io/fleet/pkg/utils/binding"
+	"go.goms.io/fleet/pkg/utils/condition"
+	"go.goms.io/fleet/pkg/utils/controller"
+	"go.goms.io/fleet/pkg/utils/defaulter"
+)
+
+const (
+	// The reasons of the events which the rebalancer emits on placements.
+	rebalanceStartedReason         = "RebalanceStarted"
+	rebalanceCompletedReason       = "RebalanceCompleted"
+	rebalanceAbandonedReason       = "RebalanceAbandoned"
+	rebalanceEvictionBlockedReason = "RebalanceEvictionBlocked"
+
+	// rebalanceDecisionReasonFmt is the reason of the cluster decision on a binding created by the rebalancer.
+	rebalanceDecisionReasonFmt = "Rebalanced from cluster \"%s\" to cluster \"%s\" (affinity score: %d, topology spread score: %d): score improved by %d"
+
+	// inProgressRequeueDelay is the delay before the rebalancer checks on an in-progress migration again.
+	inProgressRequeueDelay = 15 * time.Second
+)
+
+// Reconciler reconciles ClusterResourcePlacements of the PickN placement type for rebalancing.
+type Reconciler struct {
+	client.Client
+	// UncachedReader is used to list bindings directly from the API server, so that the rebalancer
+	// never acts upon an out-of-date view of the bindings.
+	UncachedReader client.Reader
+	// Recorder is the event recorder for reporting the rebalancing decisions on the placements.
+	Recorder record.EventRecorder
+	// FrameworkFor returns the scheduling framework of the profile which a policy snapshot specifies;
+	// it is used to re-score the clusters in the same way as the scheduler does.
+	FrameworkFor func(policy placementv1beta1.PolicySnapshotObj) (framework.Framework, error)
+	// Interval is the interval at which each placement is re-scored.
+	Interval time.Duration
+	// ScoreThreshold is the minimum improvement in the total cluster score which warrants a migration.
+	ScoreThreshold int32
+}
+
+// migration describes the move of a placement from the target cluster of a binding to another cluster.
+type migration struct {
+	source      *placementv1beta1.ClusterResourceBinding
+	target      *framework.ScoredCluster
+	improvement int64
+}
+
+// Reconcile re-scores the clusters of a placement, starts a migration if it is warranted, and moves an
+// in-progress migration forward.
+func (r *Reconciler) Reconcile(ctx context.Context, req runtime.Request) (runtime.Result, error) {
+	startTime := time.Now()
+	crpName := req.NamespacedName.Name
+	klog.V(2).InfoS("Rebalancer reconciliation starts", "clusterResourcePlacement", crpName)
+	defer func() {
+		latency := time.Since(startTime).Milliseconds()
+		klog.V(2).InfoS("Rebalancer reconciliation ends", "clusterResourcePlacement", crpName, "latency", latency)
+	}()
+
+	var crp placementv1beta1.ClusterResourcePlacement
+	if err := r.Client.Get(ctx, req.NamespacedName, &crp); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return runtime.Result{}, nil
+		}
+		klog.ErrorS(err, "Failed to get cluster resource placement", "clusterResourcePlacement", crpName)
+		return runtime.Result{}, controller.NewAPIServerError(true, err)
+	}
+	defaulter.SetPlacementDefaults(&crp)
+	if crp.DeletionTimestamp != nil ||
+		crp.Spec.Policy == nil || crp.Spec.Policy.PlacementType != placementv1beta1.PickNPlacementType ||
+		crp.Spec.Strategy.Type == placementv1beta1.ExternalRolloutStrategyType {
+		// Only placements of the PickN placement type which are rolled out by the rollout controller
+		// are rebalanced.
+		klog.V(2).InfoS("Placement is not eligible for rebalancing", "clusterResourcePlacement", crpName)
+		return runtime.Result{}, nil
+	}
+
+	policy, err := r.fetchScheduledPolicySnapshot(ctx, &crp)
+	if err != nil {
+		return runtime.Result{}, err
+	}
+	if policy == nil {
+		// The scheduler has not finished scheduling the latest policy yet.
+		klog.V(2).InfoS("Latest policy snapshot has not been scheduled yet", "clusterResourcePlacement", crpName)
+		return runtime.Result{RequeueAfter: r.Interval}, nil
+	}
+
+	var bindingList placementv1beta1.ClusterResourceBindingList
+	if err := r.UncachedReader.List(ctx, &bindingList, client.MatchingLabels{placementv1beta1.PlacementTrackingLabel: crp.Name}); err != nil {
+		klog.ErrorS(err, "Failed to list cluster resource bindings", "clusterResourcePlacement", crpName)
+		return runtime.Result{}, controller.NewAPIServerError(false, err)
+	}
+	bindings := bindingList.Items
+
+	if replacement, source := findPendingReplacement(bindings); replacement != nil {
+		return r.progressMigration(ctx, &crp, replacement, source)
+	}
+
+	if !isSettled(bindings, policy, int(*crp.Spec.Policy.NumberOfClusters)) {
+		klog.V(2).InfoS("Placement has not settled yet", "clusterResourcePlacement", crpName)
+		return runtime.Result{RequeueAfter: r.Interval}, nil
+	}
+
+	fw, err := r.FrameworkFor(policy)
+	if err != nil {
+		klog.ErrorS(err, "Failed to find the scheduling framework", "clusterResourcePlacement", crpName)
+		return runtime.Result{RequeueAfter: r.Interval}, nil
+	}
+	m, err := r.findMigration(ctx, fw, &crp, policy, bindings)
+	if err != nil {
+		return runtime.Result{}, err
+	}
+	if m == nil {
+		return runtime.Result{RequeueAfter: r.Interval}, nil
+	}
+	if err := r.startMigration(ctx, &crp, policy, m); err != nil {
+		return runtime.Result{}, err
+	}
+	return runtime.Result{RequeueAfter: inProgressRequeueDelay}, nil
+}
+
+// fetchScheduledPolicySnapshot returns the latest policy snapshot of a placement if the scheduler has
+// finished scheduling it, or nil otherwise.
+func (r *Reconciler) fetchScheduledPolicySnapshot(ctx context.Context, crp *placementv1beta1.ClusterResourcePlacement) (placementv1beta1.PolicySnapshotObj, error) {
+	policyList, err := controller.FetchLatestPolicySnapshot(ctx, r.Client, types.NamespacedName{Name: crp.Name})
+	if err != nil {
+		return nil, controller.NewAPIServerError(true, err)
+	}
+	policies := policyList.GetPolicySnapshotObjs()
+	if len(policies) != 1 {
+		return nil, nil
+	}
+	policy := policies[0]
+	if !condition.IsConditionStatusTrue(policy.GetCondition(string(placementv1beta1.PolicySnapshotScheduled)), policy.GetGeneration()) {
+		return nil, nil
+	}
+	return policy, nil
+}
+
+// findPendingReplacement returns a binding created by the rebalancer whose source binding has not been
+// evicted yet, along with the source binding.
+func findPendingReplacement(bindings []placementv1beta1.ClusterResourceBinding) (replacement, source *placementv1beta1.ClusterResourceBinding) {
+	active := make(map[string]*placementv1beta1.ClusterResourceBinding, len(bindings))
+	for i := range bindings {
+		if isActive(&bindings[i]) {
+			active[bindings[i].Name] = &bindings[i]
+		}
+	}
+	for _, binding := range active {
+		if sourceName, ok := binding.Annotations[placementv1beta1.RebalanceSourceBindingAnnotation]; ok && active[sourceName] != nil {
+			return binding, active[sourceName]
+		}
+	}
+	return nil, nil
+}
+
+// isActive returns true if a binding is scheduled or bound, and is not being deleted.
+func isActive(binding *placementv1beta1.ClusterResourceBinding) bool {
+	return binding.DeletionTimestamp == nil &&
+		(binding.Spec.State == placementv1beta1.BindingStateScheduled || binding.Spec.State == placementv1beta1.BindingStateBound)
+}
+
+// isAvailable returns true if a binding is available for its current generation.
+func isAvailable(binding *placementv1beta1.ClusterResourceBinding) bool {
+	return condition.IsConditionStatusTrue(binding.GetCondition(string(placementv1beta1.ResourceBindingAvailable)), binding.Generation)
+}
+
+// isSettled returns true if a placement has exactly the desired number of bindings, all of which are
+// bound in accordance with the latest policy snapshot and are available; the rebalancer only starts a
+// migration for a settled placement, so that it never races with the scheduler or the rollout controller.
+func isSettled(bindings []placementv1beta1.ClusterResourceBinding, policy placementv1beta1.PolicySnapshotObj, numOfClusters int) bool {
+	if len(bindings) != numOfClusters {
+		return false
+	}
+	for i := range bindings {
+		binding := &bindings[i]
+		if binding.DeletionTimestamp != nil ||
+			binding.Spec.State != placementv1beta1.BindingStateBound ||
+			binding.Spec.SchedulingPolicySnapshotName != policy.GetName() ||
+			!isAvailable(binding) {
+			return false
+		}
+	}
+	return true
+}
+
+// findMigration re-scores the target cluster of each binding against the clusters that the placement
+// has not been scheduled to, and returns the migration with the largest improvement which crosses the
+// score threshold (if any).
+func (r *Reconciler) findMigration(
+	ctx context.Context,
+	fw framework.Framework,
+	crp *placementv1beta1.ClusterResourcePlacement,
+	policy placementv1beta1.PolicySnapshotObj,
+	bindings []placementv1beta1.ClusterResourceBinding,
+) (*migration, error) {
+	occupied := make(map[string]bool, len(bindings))
+	for i := range bindings {
+		occupied[bindings[i].Spec.TargetCluster] = true
+	}
+	// Sort the bindings by their names for stable results.
+	sorted := make([]*placementv1beta1.ClusterResourceBinding, 0, len(bindings))
+	for i := range bindings {
+		sorted = append(sorted, &bindings[i])
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	var best *migration
+	for _, binding := range sorted {
+		currentCluster := binding.Spec.TargetCluster
+		scored, err := fw.ScoreClustersFor(ctx, queue.PlacementKey(crp.Name), policy, currentCluster)
+		if err != nil {
+			klog.ErrorS(err, "Failed to score clusters", "clusterResourcePlacement", klog.KObj(crp), "excludedCluster", currentCluster)
+			return nil, err
+		}
+
+		var current, candidate *framework.ScoredCluster
+		for _, sc := range scored {
+			switch {
+			case sc.Cluster.Name == currentCluster:
+				current = sc
+			case occupied[sc.Cluster.Name]:
+				// The placement already has a binding on the cluster.
+			case candidate == nil || candidate.Score.Total() < sc.Score.Total() ||
+				(candidate.Score.Total() == sc.Score.Total() && sc.Cluster.Name < candidate.Cluster.Name):
+				// Rank the candidates by their total scores, i.e., the same metric that the
+				// improvement is measured with.
+				candidate = sc
+			}
+		}
+		if current == nil || candidate == nil {
+			// The current cluster no longer passes the filter plugins, which the rebalancer leaves to
+			// the scheduling policy (the requirements are ignored during execution); or there is
+			// no other cluster to migrate to.
+			continue
+		}
+
+		improvement := candidate.Score.Total() - current.Score.Total()
+		klog.V(2).InfoS("Re-scored the cluster of a binding", "clusterResourcePlacement", klog.KObj(crp), "binding", klog.KObj(binding),
+			"currentCluster", currentCluster, "currentScore", current.Score, "candidateCluster", candidate.Cluster.Name, "candidateScore", candidate.Score)
+		if improvement < int64(r.ScoreThreshold) {
+			continue
+		}
+		if best == nil || improvement > best.improvement {
+			best = &migration{source: binding, target: candidate, improvement: improvement}
+		}
+	}
+	return best, nil
+}
+
+// startMigration creates a new binding on the target cluster of a migration, which replaces the
+// source binding.
+func (r *Reconciler) startMigration(ctx context.Context, crp *placementv1beta1.ClusterResourcePlacement, policy placementv1beta1.PolicySnapshotObj, m *migration) error {
+	targetCluster := m.target.Cluster.Name
+	name, err := uniquename.NewBindingName(crp.Name, targetCluster)
+	if err != nil {
+		return controller.NewUnexpectedBehaviorError(fmt.Errorf("failed to generate the name of the binding for cluster %q: %w", targetCluster, err))
+	}
+	affinityScore := m.target.Score.AffinityScore
+	topologySpreadScore := m.target.Score.TopologySpreadScore
+	binding := &placementv1beta1.ClusterResourceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				placementv1beta1.PlacementTrackingLabel: crp.Name,
+			},
+			Annotations: map[string]string{
+				placementv1beta1.RebalanceSourceBindingAnnotation: m.source.Name,
+			},
+			Finalizers: []string{placementv1beta1.SchedulerBindingCleanupFinalizer},
+		},
+		Spec: placementv1beta1.ResourceBindingSpec{
+			State: placementv1beta1.BindingStateScheduled,
+			// Leave the associated resource snapshot name empty; it is up to the rollout controller
+			// to fulfill this field.
+			SchedulingPolicySnapshotName: policy.GetName(),
+			TargetCluster:                targetCluster,
+			ClusterDecision: placementv1beta1.ClusterDecision{
+				ClusterName: targetCluster,
+				Selected:    true,
+				ClusterScore: &placementv1beta1.ClusterScore{
+					AffinityScore:       &affinityScore,
+					TopologySpreadScore: &topologySpreadScore,
+				},
+				Reason: fmt.Sprintf(rebalanceDecisionReasonFmt, m.source.Spec.TargetCluster, targetCluster, affinityScore, topologySpreadScore, m.improvement),
+			},
+		},
+	}
+	if err := r.Client.Create(ctx, binding); err != nil {
+		klog.ErrorS(err, "Failed to create the replacement binding", "clusterResourcePlacement", klog.KObj(crp), "binding", klog.KObj(binding))
+		return controller.NewAPIServerError(false, err)
+	}
+	klog.V(2).InfoS("Started a migration", "clusterResourcePlacement", klog.KObj(crp), "sourceBinding", klog.KObj(m.source), "binding", klog.KObj(binding))
+	r.Recorder.Eventf(crp, corev1.EventTypeNormal, rebalanceStartedReason,
+		"Migrating from cluster %s to cluster %s as the score improves by %d; binding %s is created", m.source.Spec.TargetCluster, targetCluster, m.improvement, binding.Name)
+	return nil
+}
+
+// progressMigration moves an in-progress migration forward: once the replacement binding becomes
+// available, it requests the eviction of the source binding, which is subject to the disruption budget
+// of the placement.
+func (r *Reconciler) progressMigration(
+	ctx context.Context,
+	crp *placementv1beta1.ClusterResourcePlacement,
+	replacement, source *placementv1beta1.ClusterResourceBinding,
+) (runtime.Result, error) {
+	crpRef := klog.KObj(crp)
+	if bindingutils.HasBindingFailed(replacement) {
+		// The placement cannot run on the new cluster; give up the migration.
+		annotations := replacement.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[placementv1beta1.PreviousBindingStateAnnotation] = string(replacement.Spec.State)
+		replacement.SetAnnotations(annotations)
+		replacement.Spec.State = placementv1beta1.BindingStateUnscheduled
+		if err := r.Client.Update(ctx, replacement); err != nil {
+			klog.ErrorS(err, "Failed to mark the replacement binding as unscheduled", "clusterResourcePlacement", crpRef, "binding", klog.KObj(replacement))
+			return runtime.Result{}, controller.NewUpdateIgnoreConflictError(err)
+		}
+		r.Recorder.Eventf(crp, corev1.EventTypeWarning, rebalanceAbandonedReason,
+			"Abandoned the migration from cluster %s to cluster %s as binding %s has failed", source.Spec.TargetCluster, replacement.Spec.TargetCluster, replacement.Name)
+		return runtime.Result{RequeueAfter: r.Interval}, nil
+	}
+	if !isAvailable(replacement) {
+		klog.V(2).InfoS("Waiting for the replacement binding to become available", "clusterResourcePlacement", crpRef, "binding", klog.KObj(replacement))
+		return runtime.Result{RequeueAfter: inProgressRequeueDelay}, nil
+	}
+
+	var eviction placementv1beta1.ClusterResourcePlacementEviction
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: source.Name}, &eviction); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return runtime.Result{}, controller.NewAPIServerError(true, err)
+		}
+		eviction = placementv1beta1.ClusterResourcePlacementEviction{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: source.Name,
+			},
+			Spec: placementv1beta1.PlacementEvictionSpec{
+				PlacementName: crp.Name,
+				ClusterName:   source.Spec.TargetCluster,
+			},
+		}
+		if err := r.Client.Create(ctx, &eviction); err != nil {
+			klog.ErrorS(err, "Failed to create the eviction for the source binding", "clusterResourcePlacement", crpRef, "eviction", klog.KObj(&eviction))
+			return runtime.Result{}, controller.NewAPIServerError(false, err)
+		}
+		klog.V(2).InfoS("Requested the eviction of the source binding", "clusterResourcePlacement", crpRef, "binding", klog.KObj(source))
+		return runtime.Result{RequeueAfter: inProgressRequeueDelay}, nil
+	}
+
+	validCond := eviction.GetCondition(string(placementv1beta1.PlacementEvictionConditionTypeValid))
+	executedCond := eviction.GetCondition(string(placementv1beta1.PlacementEvictionConditionTypeExecuted))
+	switch {
+	case condition.IsConditionStatusFalse(validCond, eviction.Generation):
+		r.Recorder.Eventf(crp, corev1.EventTypeWarning, rebalanceEvictionBlockedReason,
+			"Cannot evict binding %s from cluster %s: %s", source.Name, source.Spec.TargetCluster, validCond.Message)
+	case executedCond == nil:
+		// The eviction has not been executed yet.
+		return runtime.Result{RequeueAfter: inProgressRequeueDelay}, nil
+	case executedCond.Status == metav1.ConditionTrue:
+		r.Recorder.Eventf(crp, corev1.EventTypeNormal, rebalanceCompletedReason,
+			"Migrated from cluster %s to cluster %s", source.Spec.TargetCluster, replacement.Spec.TargetCluster)
+	default:
+		// The eviction is blocked, most likely by the disruption budget; the rebalancer retries at the
+		// next interval.
+		r.Recorder.Eventf(crp, corev1.EventTypeWarning, rebalanceEvictionBlockedReason,
+			"Cannot evict binding %s from cluster %s: %s", source.Name, source.Spec.TargetCluster, executedCond.Message)
+	}
+
+	// The eviction has reached a terminal state; clean it up so that it can be retried if needed.
+	if err := r.Client.Delete(ctx, &eviction); err != nil && !k8serrors.IsNotFound(err) {
+		klog.ErrorS(err, "Failed to delete the eviction for the source binding", "clusterResourcePlacement", crpRef, "eviction", klog.KObj(&eviction))
+		return runtime.Result{}, controller.NewAPIServerError(false, err)
+	}
+	return runtime.Result{RequeueAfter: r.Interval}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(mgr runtime.Manager) error {
+	return runtime.NewControllerManagedBy(mgr).Named("rebalancer").
+		For(&placementv1beta1.ClusterResourcePlacement{}).
+		// Placements are re-scored periodically; status updates do not warrant an immediate run.
+		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		Complete(r)
+}
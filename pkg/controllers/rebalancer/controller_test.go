@@ -0,0 +1,492 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rebalancer
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+	"go.goms.io/fleet/pkg/scheduler/queue"
+)
+
+const (
+	crpName         = "test-crp"
+	policyName      = "test-crp-1"
+	clusterName1    = "bravelion"
+	clusterName2    = "jumpingcat"
+	clusterName3    = "smartfish"
+	clusterName4    = "singingbutterfly"
+	bindingName1    = "test-crp-bravelion"
+	bindingName2    = "test-crp-jumpingcat"
+	replacementName = "test-crp-smartfish"
+)
+
+// fakeFramework is a scheduling framework which returns preset scores, keyed by the excluded cluster.
+type fakeFramework struct {
+	framework.Framework
+	scores map[string]map[string]framework.ClusterScore
+}
+
+func (f *fakeFramework) ScoreClustersFor(_ context.Context, _ queue.PlacementKey, _ placementv1beta1.PolicySnapshotObj, excludedCluster string) (framework.ScoredClusters, error) {
+	var scored framework.ScoredClusters
+	for name, score := range f.scores[excludedCluster] {
+		scored = append(scored, &framework.ScoredCluster{
+			Cluster: &clusterv1beta1.MemberCluster{ObjectMeta: metav1.ObjectMeta{Name: name}},
+			Score:   &score,
+		})
+	}
+	return scored, nil
+}
+
+func newCRP() *placementv1beta1.ClusterResourcePlacement {
+	return &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{Name: crpName},
+		Spec: placementv1beta1.PlacementSpec{
+			Policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:    placementv1beta1.PickNPlacementType,
+				NumberOfClusters: ptr.To(int32(2)),
+			},
+		},
+	}
+}
+
+func newPolicySnapshot() *placementv1beta1.ClusterSchedulingPolicySnapshot {
+	return &placementv1beta1.ClusterSchedulingPolicySnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       policyName,
+			Generation: 1,
+			Labels: map[string]string{
+				placementv1beta1.PlacementTrackingLabel: crpName,
+				placementv1beta1.IsLatestSnapshotLabel:  "true",
+			},
+		},
+		Status: placementv1beta1.SchedulingPolicySnapshotStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:               string(placementv1beta1.PolicySnapshotScheduled),
+					Status:             metav1.ConditionTrue,
+					ObservedGeneration: 1,
+					Reason:             "Scheduled",
+					LastTransitionTime: metav1.Now(),
+				},
+			},
+		},
+	}
+}
+
+func newBinding(name, clusterName string, state placementv1beta1.BindingState, available bool) *placementv1beta1.ClusterResourceBinding {
+	binding := &placementv1beta1.ClusterResourceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			Generation: 1,
+			Labels: map[string]string{
+				placementv1beta1.PlacementTrackingLabel: crpName,
+			},
+		},
+		Spec: placementv1beta1.ResourceBindingSpec{
+			State:                        state,
+			TargetCluster:                clusterName,
+			SchedulingPolicySnapshotName: policyName,
+		},
+	}
+	if available {
+		binding.Status.Conditions = []metav1.Condition{
+			{
+				Type:               string(placementv1beta1.ResourceBindingAvailable),
+				Status:             metav1.ConditionTrue,
+				ObservedGeneration: 1,
+				Reason:             "Available",
+				LastTransitionTime: metav1.Now(),
+			},
+		}
+	}
+	return binding
+}
+
+func newReplacement(available bool) *placementv1beta1.ClusterResourceBinding {
+	binding := newBinding(replacementName, clusterName3, placementv1beta1.BindingStateBound, available)
+	binding.Annotations = map[string]string{placementv1beta1.RebalanceSourceBindingAnnotation: bindingName1}
+	return binding
+}
+
+func newEviction(conditions ...metav1.Condition) *placementv1beta1.ClusterResourcePlacementEviction {
+	return &placementv1beta1.ClusterResourcePlacementEviction{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       bindingName1,
+			Generation: 1,
+		},
+		Spec: placementv1beta1.PlacementEvictionSpec{
+			PlacementName: crpName,
+			ClusterName:   clusterName1,
+		},
+		Status: placementv1beta1.PlacementEvictionStatus{
+			Conditions: conditions,
+		},
+	}
+}
+
+func evictionCondition(conditionType placementv1beta1.PlacementEvictionConditionType, status metav1.ConditionStatus, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:               string(conditionType),
+		Status:             status,
+		ObservedGeneration: 1,
+		Reason:             "Test",
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	}
+}
+
+// TestReconcile tests the Reconcile method.
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add placement v1beta1 scheme: %v", err)
+	}
+
+	// Moving away from bravelion improves the score by 30; moving away from jumpingcat, by 10.
+	scores := map[string]map[string]framework.ClusterScore{
+		clusterName1: {clusterName1: {AffinityScore: 10}, clusterName3: {AffinityScore: 40}},
+		clusterName2: {clusterName2: {AffinityScore: 30}, clusterName3: {AffinityScore: 40}},
+	}
+
+	testCases := []struct {
+		name            string
+		objs            []client.Object
+		threshold       int32
+		wantResult      ctrl.Result
+		wantEventReason string
+		// wantReplacement is the target cluster of the replacement binding, if one is expected.
+		wantReplacement string
+		wantEviction    bool
+		wantUnscheduled string
+	}{
+		{
+			name: "improvement crosses the threshold",
+			objs: []client.Object{
+				newCRP(),
+				newPolicySnapshot(),
+				newBinding(bindingName1, clusterName1, placementv1beta1.BindingStateBound, true),
+				newBinding(bindingName2, clusterName2, placementv1beta1.BindingStateBound, true),
+			},
+			threshold:       20,
+			wantResult:      ctrl.Result{RequeueAfter: inProgressRequeueDelay},
+			wantEventReason: rebalanceStartedReason,
+			wantReplacement: clusterName3,
+		},
+		{
+			name: "improvement below the threshold",
+			objs: []client.Object{
+				newCRP(),
+				newPolicySnapshot(),
+				newBinding(bindingName1, clusterName1, placementv1beta1.BindingStateBound, true),
+				newBinding(bindingName2, clusterName2, placementv1beta1.BindingStateBound, true),
+			},
+			threshold:  50,
+			wantResult: ctrl.Result{RequeueAfter: time.Minute},
+		},
+		{
+			name: "placement has not settled",
+			objs: []client.Object{
+				newCRP(),
+				newPolicySnapshot(),
+				newBinding(bindingName1, clusterName1, placementv1beta1.BindingStateBound, true),
+				newBinding(bindingName2, clusterName2, placementv1beta1.BindingStateScheduled, false),
+			},
+			threshold:  20,
+			wantResult: ctrl.Result{RequeueAfter: time.Minute},
+		},
+		{
+			name: "replacement is not available yet",
+			objs: []client.Object{
+				newCRP(),
+				newPolicySnapshot(),
+				newBinding(bindingName1, clusterName1, placementv1beta1.BindingStateBound, true),
+				newBinding(bindingName2, clusterName2, placementv1beta1.BindingStateBound, true),
+				newReplacement(false),
+			},
+			threshold:  20,
+			wantResult: ctrl.Result{RequeueAfter: inProgressRequeueDelay},
+		},
+		{
+			name: "replacement is available",
+			objs: []client.Object{
+				newCRP(),
+				newPolicySnapshot(),
+				newBinding(bindingName1, clusterName1, placementv1beta1.BindingStateBound, true),
+				newBinding(bindingName2, clusterName2, placementv1beta1.BindingStateBound, true),
+				newReplacement(true),
+			},
+			threshold:    20,
+			wantResult:   ctrl.Result{RequeueAfter: inProgressRequeueDelay},
+			wantEviction: true,
+		},
+		{
+			name: "eviction is blocked by the disruption budget",
+			objs: []client.Object{
+				newCRP(),
+				newPolicySnapshot(),
+				newBinding(bindingName1, clusterName1, placementv1beta1.BindingStateBound, true),
+				newBinding(bindingName2, clusterName2, placementv1beta1.BindingStateBound, true),
+				newReplacement(true),
+				newEviction(
+					evictionCondition(placementv1beta1.PlacementEvictionConditionTypeValid, metav1.ConditionTrue, ""),
+					evictionCondition(placementv1beta1.PlacementEvictionConditionTypeExecuted, metav1.ConditionFalse, "disruption budget is not met"),
+				),
+			},
+			threshold:       20,
+			wantResult:      ctrl.Result{RequeueAfter: time.Minute},
+			wantEventReason: rebalanceEvictionBlockedReason,
+		},
+		{
+			name: "eviction is executed",
+			objs: []client.Object{
+				newCRP(),
+				newPolicySnapshot(),
+				newBinding(bindingName1, clusterName1, placementv1beta1.BindingStateBound, true),
+				newBinding(bindingName2, clusterName2, placementv1beta1.BindingStateBound, true),
+				newReplacement(true),
+				newEviction(
+					evictionCondition(placementv1beta1.PlacementEvictionConditionTypeValid, metav1.ConditionTrue, ""),
+					evictionCondition(placementv1beta1.PlacementEvictionConditionTypeExecuted, metav1.ConditionTrue, ""),
+				),
+			},
+			threshold:       20,
+			wantResult:      ctrl.Result{RequeueAfter: time.Minute},
+			wantEventReason: rebalanceCompletedReason,
+		},
+		{
+			name: "replacement has failed",
+			objs: []client.Object{
+				newCRP(),
+				newPolicySnapshot(),
+				newBinding(bindingName1, clusterName1, placementv1beta1.BindingStateBound, true),
+				newBinding(bindingName2, clusterName2, placementv1beta1.BindingStateBound, true),
+				func() client.Object {
+					binding := newReplacement(false)
+					binding.Status.Conditions = []metav1.Condition{
+						{
+							Type:               string(placementv1beta1.ResourceBindingApplied),
+							Status:             metav1.ConditionFalse,
+							ObservedGeneration: 1,
+							Reason:             "ApplyFailed",
+							LastTransitionTime: metav1.Now(),
+						},
+					}
+					return binding
+				}(),
+			},
+			threshold:       20,
+			wantResult:      ctrl.Result{RequeueAfter: time.Minute},
+			wantEventReason: rebalanceAbandonedReason,
+			wantUnscheduled: replacementName,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.objs...).WithStatusSubresource(tc.objs...).Build()
+			recorder := record.NewFakeRecorder(10)
+			r := &Reconciler{
+				Client:         fakeClient,
+				UncachedReader: fakeClient,
+				Recorder:       recorder,
+				FrameworkFor: func(_ placementv1beta1.PolicySnapshotObj) (framework.Framework, error) {
+					return &fakeFramework{scores: scores}, nil
+				},
+				Interval:       time.Minute,
+				ScoreThreshold: tc.threshold,
+			}
+
+			got, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: crpName}})
+			if err != nil {
+				t.Fatalf("Reconcile() = %v, want no error", err)
+			}
+			if got != tc.wantResult {
+				t.Errorf("Reconcile() = %v, want %v", got, tc.wantResult)
+			}
+
+			select {
+			case event := <-recorder.Events:
+				if tc.wantEventReason == "" || !strings.Contains(event, tc.wantEventReason) {
+					t.Errorf("Reconcile() emitted event %q, want reason %q", event, tc.wantEventReason)
+				}
+			default:
+				if tc.wantEventReason != "" {
+					t.Errorf("Reconcile() emitted no event, want reason %q", tc.wantEventReason)
+				}
+			}
+
+			var bindingList placementv1beta1.ClusterResourceBindingList
+			if err := fakeClient.List(ctx, &bindingList); err != nil {
+				t.Fatalf("failed to list bindings: %v", err)
+			}
+			var gotReplacement string
+			for _, binding := range bindingList.Items {
+				if binding.Name == tc.wantUnscheduled {
+					if binding.Spec.State != placementv1beta1.BindingStateUnscheduled {
+						t.Errorf("binding %s state = %s, want %s", binding.Name, binding.Spec.State, placementv1beta1.BindingStateUnscheduled)
+					}
+					wantAnnotations := map[string]string{
+						placementv1beta1.RebalanceSourceBindingAnnotation: bindingName1,
+						placementv1beta1.PreviousBindingStateAnnotation:   string(placementv1beta1.BindingStateBound),
+					}
+					if diff := cmp.Diff(binding.Annotations, wantAnnotations); diff != "" {
+						t.Errorf("binding %s annotations diff (-got, +want): %s", binding.Name, diff)
+					}
+				}
+				source, ok := binding.Annotations[placementv1beta1.RebalanceSourceBindingAnnotation]
+				if !ok || binding.Name == replacementName {
+					// The binding is not created in this reconciliation.
+					continue
+				}
+				if source != bindingName1 {
+					t.Errorf("replacement binding source = %s, want %s", source, bindingName1)
+				}
+				if binding.Spec.State != placementv1beta1.BindingStateScheduled {
+					t.Errorf("replacement binding state = %s, want %s", binding.Spec.State, placementv1beta1.BindingStateScheduled)
+				}
+				gotReplacement = binding.Spec.TargetCluster
+			}
+			if gotReplacement != tc.wantReplacement {
+				t.Errorf("replacement binding target cluster = %q, want %q", gotReplacement, tc.wantReplacement)
+			}
+
+			var eviction placementv1beta1.ClusterResourcePlacementEviction
+			err = fakeClient.Get(ctx, types.NamespacedName{Name: bindingName1}, &eviction)
+			switch {
+			case tc.wantEviction && err != nil:
+				t.Errorf("failed to get the eviction: %v", err)
+			case !tc.wantEviction && !k8serrors.IsNotFound(err):
+				t.Errorf("get the eviction = %v, want not found", err)
+			}
+		})
+	}
+}
+
+// TestFindMigration tests the findMigration method.
+func TestFindMigration(t *testing.T) {
+	bindings := []placementv1beta1.ClusterResourceBinding{
+		*newBinding(bindingName1, clusterName1, placementv1beta1.BindingStateBound, true),
+		*newBinding(bindingName2, clusterName2, placementv1beta1.BindingStateBound, true),
+	}
+
+	testCases := []struct {
+		name            string
+		scores          map[string]map[string]framework.ClusterScore
+		threshold       int32
+		wantSource      string
+		wantTarget      string
+		wantImprovement int64
+	}{
+		{
+			name: "candidate with the highest total score is picked",
+			scores: map[string]map[string]framework.ClusterScore{
+				// smartfish has a higher topology spread score, but a lower total score than singingbutterfly.
+				clusterName1: {
+					clusterName1: {AffinityScore: 10},
+					clusterName3: {TopologySpreadScore: 2, AffinityScore: 10},
+					clusterName4: {TopologySpreadScore: 1, AffinityScore: 50},
+				},
+			},
+			threshold:       20,
+			wantSource:      bindingName1,
+			wantTarget:      clusterName4,
+			wantImprovement: 41,
+		},
+		{
+			name: "candidates with the same total score are picked by name",
+			scores: map[string]map[string]framework.ClusterScore{
+				clusterName1: {
+					clusterName1: {AffinityScore: 10},
+					clusterName3: {AffinityScore: 30, ExtenderScore: 20},
+					clusterName4: {AffinityScore: 50},
+				},
+			},
+			threshold:       20,
+			wantSource:      bindingName1,
+			wantTarget:      clusterName4,
+			wantImprovement: 40,
+		},
+		{
+			name: "binding with the largest improvement is picked",
+			scores: map[string]map[string]framework.ClusterScore{
+				clusterName1: {
+					clusterName1: {AffinityScore: 10},
+					clusterName3: {AffinityScore: 40},
+				},
+				clusterName2: {
+					clusterName2: {AffinityScore: 10},
+					clusterName3: {AffinityScore: 40, ResourceFitScore: 20},
+				},
+			},
+			threshold:       20,
+			wantSource:      bindingName2,
+			wantTarget:      clusterName3,
+			wantImprovement: 50,
+		},
+		{
+			name: "improvement below the threshold",
+			scores: map[string]map[string]framework.ClusterScore{
+				clusterName1: {
+					clusterName1: {AffinityScore: 10},
+					clusterName3: {TopologySpreadScore: 5, AffinityScore: 10},
+				},
+			},
+			threshold: 20,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &Reconciler{ScoreThreshold: tc.threshold}
+			got, err := r.findMigration(context.Background(), &fakeFramework{scores: tc.scores}, newCRP(), newPolicySnapshot(), bindings)
+			if err != nil {
+				t.Fatalf("findMigration() = %v, want no error", err)
+			}
+			if tc.wantSource == "" {
+				if got != nil {
+					t.Fatalf("findMigration() = %s -> %s, want no migration", got.source.Name, got.target.Cluster.Name)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatalf("findMigration() = nil, want %s -> %s", tc.wantSource, tc.wantTarget)
+			}
+			if got.source.Name != tc.wantSource || got.target.Cluster.Name != tc.wantTarget || got.improvement != tc.wantImprovement {
+				t.Errorf("findMigration() = %s -> %s (improvement %d), want %s -> %s (improvement %d)",
+					got.source.Name, got.target.Cluster.Name, got.improvement, tc.wantSource, tc.wantTarget, tc.wantImprovement)
+			}
+		})
+	}
+}
@@ -207,6 +207,10 @@ func (r *Reconciler) triggerAffectedPlacementsForDeletedRes(key keys.ClusterWide
 func findPlacementsSelectedDeletedResV1Beta1(res keys.ClusterWideKey, placementList []placementv1beta1.PlacementObj) []string {
 	matchedPlacements := make([]string, 0)
 	for _, placement := range placementList {
+		if isResourceSourceOfPlacement(res, placement) {
+			matchedPlacements = append(matchedPlacements, placement.GetName())
+			continue
+		}
 		for _, selectedRes := range placement.GetPlacementStatus().SelectedResources {
 			// Perform an expedient conversion as the cluster-wide key is currently bound
 			// to v1alpha1 APIs.
@@ -329,6 +333,11 @@ func collectAllAffectedPlacementsV1Beta1(key keys.ClusterWideKey, res *unstructu
 		if match {
 			continue
 		}
+		// the placement renders resources from this object
+		if isResourceSourceOfPlacement(key, placement) {
+			placements[placement.GetName()] = true
+			continue
+		}
 		// check if object match any placement's resource selectors
 		// For the resource placement, we do not compare the namespace in the selector.
 		// We assume the namespace is the same as the resource placement's namespace and webhook/CEL
@@ -359,6 +368,28 @@ func collectAllAffectedPlacementsV1Beta1(key keys.ClusterWideKey, res *unstructu
 	return placements
 }
 
+// isResourceSourceOfPlacement returns true if the object is a ConfigMap which stores a Kustomize directory
+// referenced by the resource sources of the placement.
+func isResourceSourceOfPlacement(key keys.ClusterWideKey, placement placementv1beta1.PlacementObj) bool {
+	if key.GroupVersionKind() != utils.ConfigMapGVK {
+		return false
+	}
+	for _, source := range placement.GetPlacementSpec().ResourceSources {
+		if source.Kustomize == nil || source.Kustomize.ConfigMapName != key.Name {
+			continue
+		}
+		// The ConfigMap of a resourcePlacement is in the placement namespace if not set.
+		namespace := source.Kustomize.ConfigMapNamespace
+		if namespace == "" {
+			namespace = placement.GetNamespace()
+		}
+		if namespace == key.Namespace {
+			return true
+		}
+	}
+	return false
+}
+
 // convertToClusterResourcePlacements converts a list of runtime.Object to ClusterResourcePlacement objects
 func convertToClusterResourcePlacements(objects []runtime.Object) []placementv1beta1.PlacementObj {
 	placements := make([]placementv1beta1.PlacementObj, 0, len(objects))
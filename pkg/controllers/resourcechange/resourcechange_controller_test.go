@@ -152,6 +152,34 @@ func TestFindPlacementsSelectedDeletedResV1Beta1(t *testing.T) {
 			},
 			placementName: []string{},
 		},
+		"match a placement that rendered resources from the deleted ConfigMap": {
+			clusterWideKey: keys.ClusterWideKey{
+				ResourceIdentifier: placementv1beta1.ResourceIdentifier{
+					Version:   "v1",
+					Kind:      "ConfigMap",
+					Name:      "kustomization",
+					Namespace: "bar",
+				},
+			},
+			crpList: []*placementv1beta1.ClusterResourcePlacement{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "resource-rendered",
+					},
+					Spec: placementv1beta1.PlacementSpec{
+						ResourceSources: []placementv1beta1.ResourceSource{
+							{
+								Kustomize: &placementv1beta1.KustomizeSource{
+									ConfigMapName:      "kustomization",
+									ConfigMapNamespace: "bar",
+								},
+							},
+						},
+					},
+				},
+			},
+			placementName: []string{"resource-rendered"},
+		},
 		"does not match placement that has not selected any resource": {
 			clusterWideKey: keys.ClusterWideKey{ResourceIdentifier: deletedResV1Alpha1},
 			crpList: []*placementv1beta1.ClusterResourcePlacement{
@@ -799,6 +827,50 @@ func TestCollectAllAffectedPlacementsV1Beta1_ClusterResourcePlacement(t *testing
 			},
 			wantCRP: make(map[string]bool),
 		},
+		"match a placement rendering resources from the ConfigMap": {
+			key: keys.ClusterWideKey{
+				ResourceIdentifier: placementv1beta1.ResourceIdentifier{
+					Version:   "v1",
+					Kind:      "ConfigMap",
+					Name:      "kustomization",
+					Namespace: "test-namespace",
+				},
+			},
+			res: matchRes,
+			crpList: []*placementv1beta1.ClusterResourcePlacement{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "resource-rendered",
+					},
+					Spec: placementv1beta1.PlacementSpec{
+						ResourceSources: []placementv1beta1.ResourceSource{
+							{
+								Kustomize: &placementv1beta1.KustomizeSource{
+									ConfigMapName:      "kustomization",
+									ConfigMapNamespace: "test-namespace",
+								},
+							},
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "resource-not-rendered",
+					},
+					Spec: placementv1beta1.PlacementSpec{
+						ResourceSources: []placementv1beta1.ResourceSource{
+							{
+								Kustomize: &placementv1beta1.KustomizeSource{
+									ConfigMapName:      "kustomization",
+									ConfigMapNamespace: "other-namespace",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantCRP: map[string]bool{"resource-rendered": true},
+		},
 	}
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -1091,6 +1163,50 @@ func TestCollectAllAffectedPlacementsV1Beta1_ResourcePlacement(t *testing.T) {
 			},
 			wantRP: make(map[string]bool),
 		},
+		"match ResourcePlacement rendering resources from the ConfigMap": {
+			key: keys.ClusterWideKey{
+				ResourceIdentifier: placementv1beta1.ResourceIdentifier{
+					Version:   "v1",
+					Kind:      "ConfigMap",
+					Name:      "kustomization",
+					Namespace: "test-namespace",
+				},
+			},
+			res: matchRes,
+			rpList: []*placementv1beta1.ResourcePlacement{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "resource-rendered",
+						Namespace: "test-namespace",
+					},
+					Spec: placementv1beta1.PlacementSpec{
+						ResourceSources: []placementv1beta1.ResourceSource{
+							{
+								Kustomize: &placementv1beta1.KustomizeSource{
+									ConfigMapName: "kustomization",
+								},
+							},
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "resource-not-rendered",
+						Namespace: "test-namespace",
+					},
+					Spec: placementv1beta1.PlacementSpec{
+						ResourceSources: []placementv1beta1.ResourceSource{
+							{
+								Kustomize: &placementv1beta1.KustomizeSource{
+									ConfigMapName: "other-kustomization",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantRP: map[string]bool{"resource-rendered": true},
+		},
 	}
 
 	for name, tt := range tests {
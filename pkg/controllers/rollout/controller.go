@@ -39,12 +39,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
 	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
 	bindingutils "go.goms.io/fleet/pkg/utils/binding"
 	"go.goms.io/fleet/pkg/utils/condition"
 	"go.goms.io/fleet/pkg/utils/controller"
 	"go.goms.io/fleet/pkg/utils/defaulter"
 	"go.goms.io/fleet/pkg/utils/informer"
+	"go.goms.io/fleet/pkg/utils/maintenancewindow"
 	"go.goms.io/fleet/pkg/utils/overrider"
 )
 
@@ -309,6 +311,9 @@ func waitForResourcesToCleanUp(allBindings []placementv1beta1.BindingObj, placem
 type toBeUpdatedBinding struct {
 	currentBinding placementv1beta1.BindingObj
 	desiredBinding placementv1beta1.BindingObj // only valid for scheduled or bound binding
+	// waitingForMaintenanceWindow is true if the binding is stale but cannot be updated because the
+	// maintenance windows of the placement or of the target cluster are closed.
+	waitingForMaintenanceWindow bool
 }
 
 func createUpdateInfo(binding placementv1beta1.BindingObj,
@@ -379,6 +384,10 @@ func (r *Reconciler) pickBindingsToRoll(
 	// resource/override snapshots, but might or might not have the refresh status information.
 	upToDateBoundBindings := make([]toBeUpdatedBinding, 0)
 
+	// Those are the bindings that need to be bound or updated to the latest resources but are held
+	// because the maintenance windows are closed.
+	waitingForMaintenanceWindowBindings := make([]toBeUpdatedBinding, 0)
+
 	// calculate the cutoff time for a binding to be applied before so that it can be considered ready
 	placementSpec := placementObj.GetPlacementSpec()
 	readyTimeCutOff := time.Now().Add(-time.Duration(*placementSpec.Strategy.RollingUpdate.UnavailablePeriodSeconds) * time.Second)
@@ -389,6 +398,22 @@ func (r *Reconciler) pickBindingsToRoll(
 	minWaitTime := time.Duration(*placementSpec.Strategy.RollingUpdate.UnavailablePeriodSeconds) * time.Second
 	allReady := true
 	placementKObj := klog.KObj(placementObj)
+	// Wait for the earliest maintenance window to open if any binding is held by the maintenance windows.
+	var maintenanceWindowWaitTime time.Duration
+	placementWindows := maintenancewindow.FromPlacementWindows(placementSpec.Strategy.RollingUpdate.MaintenanceWindows)
+	now := time.Now()
+	// The maintenance windows of the member clusters are looked up at most once per reconciliation.
+	var clusterWindows map[string][]maintenancewindow.Window
+	checkMaintenanceWindows := func(clusterName string) (bool, time.Duration, error) {
+		if clusterWindows == nil {
+			var err error
+			if clusterWindows, err = r.listClusterMaintenanceWindows(ctx); err != nil {
+				return false, 0, err
+			}
+		}
+		open, waitTime := isMaintenanceWindowOpen(placementWindows, clusterWindows[clusterName], clusterName, now)
+		return open, waitTime, nil
+	}
 	for idx := range allBindings {
 		binding := allBindings[idx]
 		bindingKObj := klog.KObj(binding)
@@ -422,6 +447,16 @@ func (r *Reconciler) pickBindingsToRoll(
 		case placementv1beta1.BindingStateScheduled:
 			// the scheduler has picked a cluster for this binding
 			schedulerTargetedBinds = append(schedulerTargetedBinds, binding)
+			open, waitTime, err := checkMaintenanceWindows(bindingSpec.TargetCluster)
+			if err != nil {
+				return nil, nil, nil, false, 0, err
+			}
+			if !open {
+				klog.V(2).InfoS("Found a scheduled binding held by the maintenance windows", "placement", placementKObj, "binding", bindingKObj)
+				waitingForMaintenanceWindowBindings = append(waitingForMaintenanceWindowBindings, toBeUpdatedBinding{currentBinding: binding, waitingForMaintenanceWindow: true})
+				maintenanceWindowWaitTime = minPositiveDuration(maintenanceWindowWaitTime, waitTime)
+				continue
+			}
 			// this binding has not been bound yet, so it is an update candidate
 			// PickFromResourceMatchedOverridesForTargetCluster always returns the ordered list of the overrides.
 			cro, ro, err := overrider.PickFromResourceMatchedOverridesForTargetCluster(ctx, r.Client, bindingSpec.TargetCluster, matchedCROs, matchedROs)
@@ -459,6 +494,16 @@ func (r *Reconciler) pickBindingsToRoll(
 				}
 				// The binding needs update if it's not pointing to the latest resource binding or the overrides.
 				if bindingSpec.ResourceSnapshotName != masterResourceSnapshot.GetName() || !equality.Semantic.DeepEqual(bindingSpec.ClusterResourceOverrideSnapshots, cro) || !equality.Semantic.DeepEqual(bindingSpec.ResourceOverrideSnapshots, ro) {
+					open, waitTime, err := checkMaintenanceWindows(bindingSpec.TargetCluster)
+					if err != nil {
+						return nil, nil, nil, false, 0, err
+					}
+					if !open {
+						klog.V(2).InfoS("Found a bound binding held by the maintenance windows", "placement", placementKObj, "binding", bindingKObj)
+						waitingForMaintenanceWindowBindings = append(waitingForMaintenanceWindowBindings, toBeUpdatedBinding{currentBinding: binding, waitingForMaintenanceWindow: true})
+						maintenanceWindowWaitTime = minPositiveDuration(maintenanceWindowWaitTime, waitTime)
+						continue
+					}
 					updateInfo := createUpdateInfo(binding, masterResourceSnapshot, cro, ro)
 					if bindingFailed {
 						// the binding has been applied but failed to apply, we can safely update it to latest resources without affecting max unavailable count
@@ -480,6 +525,14 @@ func (r *Reconciler) pickBindingsToRoll(
 	if allReady {
 		minWaitTime = 0
 	}
+	if len(waitingForMaintenanceWindowBindings) > 0 {
+		// Make sure the rollout resumes once the maintenance window opens.
+		if allReady {
+			minWaitTime = maintenanceWindowWaitTime
+		} else {
+			minWaitTime = minPositiveDuration(minWaitTime, maintenanceWindowWaitTime)
+		}
+	}
 
 	// Calculate target number
 	targetNumber := r.calculateRealTarget(placementObj, schedulerTargetedBinds)
@@ -487,20 +540,61 @@ func (r *Reconciler) pickBindingsToRoll(
 		"targetNumber", targetNumber, "readyBindingNumber", len(readyBindings), "canBeUnavailableBindingNumber", len(canBeUnavailableBindings),
 		"canBeReadyBindingNumber", len(canBeReadyBindings), "boundingCandidateNumber", len(boundingCandidates),
 		"removeCandidateNumber", len(removeCandidates), "updateCandidateNumber", len(updateCandidates), "applyFailedUpdateCandidateNumber",
-		len(applyFailedUpdateCandidates), "minWaitTime", minWaitTime)
+		len(applyFailedUpdateCandidates), "waitingForMaintenanceWindowNumber", len(waitingForMaintenanceWindowBindings), "minWaitTime", minWaitTime)
 
 	// the list of bindings that are to be updated by this rolling phase
 	toBeUpdatedBindingList := make([]toBeUpdatedBinding, 0)
-	if len(removeCandidates)+len(updateCandidates)+len(boundingCandidates)+len(applyFailedUpdateCandidates) == 0 {
+	if len(removeCandidates)+len(updateCandidates)+len(boundingCandidates)+len(applyFailedUpdateCandidates)+len(waitingForMaintenanceWindowBindings) == 0 {
 		return toBeUpdatedBindingList, nil, upToDateBoundBindings, false, minWaitTime, nil
 	}
 
 	toBeUpdatedBindingList, staleUnselectedBinding := determineBindingsToUpdate(placementObj, removeCandidates, updateCandidates, boundingCandidates, applyFailedUpdateCandidates, targetNumber,
 		readyBindings, canBeReadyBindings, canBeUnavailableBindings)
+	// The bindings held by the maintenance windows are stale as well.
+	staleUnselectedBinding = append(staleUnselectedBinding, waitingForMaintenanceWindowBindings...)
 
 	return toBeUpdatedBindingList, staleUnselectedBinding, upToDateBoundBindings, true, minWaitTime, nil
 }
 
+// listClusterMaintenanceWindows lists the member clusters and returns their maintenance windows by cluster name.
+// The member clusters that are gone are left out; their bindings will be removed by the scheduler.
+func (r *Reconciler) listClusterMaintenanceWindows(ctx context.Context) (map[string][]maintenancewindow.Window, error) {
+	var clusterList clusterv1beta1.MemberClusterList
+	if err := r.Client.List(ctx, &clusterList); err != nil {
+		klog.ErrorS(err, "Failed to list the member clusters")
+		return nil, controller.NewAPIServerError(true, err)
+	}
+	clusterWindows := make(map[string][]maintenancewindow.Window, len(clusterList.Items))
+	for i := range clusterList.Items {
+		cluster := &clusterList.Items[i]
+		clusterWindows[cluster.Name] = maintenancewindow.FromClusterWindows(cluster.Spec.MaintenanceWindows)
+	}
+	return clusterWindows, nil
+}
+
+// isMaintenanceWindowOpen checks if both the maintenance windows of the placement and of the target cluster are open.
+// If not, it also returns how long to wait for them to open.
+// Invalid maintenance windows are considered closed so that the rollout is held until they are fixed.
+func isMaintenanceWindowOpen(placementWindows, clusterWindows []maintenancewindow.Window, clusterName string, now time.Time) (bool, time.Duration) {
+	open, waitTime, err := maintenancewindow.AllOpen(now, placementWindows, clusterWindows)
+	if err != nil {
+		klog.ErrorS(controller.NewUserError(err), "Found invalid maintenance windows, holding the rollout", "memberCluster", clusterName)
+		return false, 0
+	}
+	return open, waitTime
+}
+
+// minPositiveDuration returns the smaller of the two durations ignoring the non-positive ones.
+func minPositiveDuration(a, b time.Duration) time.Duration {
+	if a <= 0 {
+		return b
+	}
+	if b <= 0 {
+		return a
+	}
+	return min(a, b)
+}
+
 // determineBindingsToUpdate determines which bindings to update
 func determineBindingsToUpdate(
 	placementObj placementv1beta1.PlacementObj,
@@ -729,6 +823,9 @@ func (r *Reconciler) SetupWithManagerForClusterResourcePlacement(mgr runtime.Man
 		// controller also watches ClusterResourcePlacement objects,
 		// so that it can push apply strategy updates to all bindings right away.
 		Watches(&placementv1beta1.ClusterResourcePlacement{}, placementHandlerFuncs()).
+		// The rollout controller also watches MemberCluster objects, so that the rollouts held by
+		// the maintenance windows of a cluster resume right away when the windows change.
+		Watches(&clusterv1beta1.MemberCluster{}, r.memberClusterHandlerFuncs(true)).
 		Complete(r)
 }
 
@@ -749,6 +846,9 @@ func (r *Reconciler) SetupWithManagerForResourcePlacement(mgr runtime.Manager) e
 		// controller also watches ResourcePlacement objects,
 		// so that it can push apply strategy updates to all bindings right away.
 		Watches(&placementv1beta1.ResourcePlacement{}, placementHandlerFuncs()).
+		// The rollout controller also watches MemberCluster objects, so that the rollouts held by
+		// the maintenance windows of a cluster resume right away when the windows change.
+		Watches(&clusterv1beta1.MemberCluster{}, r.memberClusterHandlerFuncs(false)).
 		Complete(r)
 }
 
@@ -822,6 +922,18 @@ func placementHandlerFuncs() handler.Funcs {
 	}
 }
 
+// memberClusterHandlerFuncs returns the handler functions for member cluster events.
+func (r *Reconciler) memberClusterHandlerFuncs(enqueueCRP bool) handler.Funcs {
+	return handler.Funcs{
+		// Ignore all Create, Delete, and Generic events; the scheduler creates and removes the bindings
+		// on the member clusters, which trigger the rollout controller.
+		UpdateFunc: func(ctx context.Context, e event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			klog.V(2).InfoS("Handling a member cluster update event", "memberCluster", klog.KObj(e.ObjectNew), "enqueueCRP", enqueueCRP)
+			r.handleMemberClusterUpdated(ctx, e.ObjectOld, e.ObjectNew, q, enqueueCRP)
+		},
+	}
+}
+
 // handleClusterResourceOverrideSnapshot parse the clusterResourceOverrideSnapshot label and enqueue the CRP name associated
 // with the clusterResourceOverrideSnapshot if set.
 func handleClusterResourceOverrideSnapshot(o client.Object, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
@@ -1047,6 +1159,15 @@ func (r *Reconciler) updateStaleBindingsStatus(ctx context.Context, staleBinding
 			continue
 		}
 		errs.Go(func() error {
+			if binding.waitingForMaintenanceWindow {
+				return r.updateBindingStatusWithCondition(cctx, binding.currentBinding, metav1.Condition{
+					Type:               string(placementv1beta1.ResourceBindingRolloutStarted),
+					Status:             metav1.ConditionFalse,
+					ObservedGeneration: binding.currentBinding.GetGeneration(),
+					Reason:             condition.RolloutWaitingForMaintenanceWindowReason,
+					Message:            "The resources cannot be updated to the latest because the maintenance windows are closed",
+				})
+			}
 			return r.updateBindingStatus(cctx, binding.currentBinding, false)
 		})
 	}
@@ -1088,6 +1209,11 @@ func (r *Reconciler) updateBindingStatus(ctx context.Context, binding placementv
 			Message:            "Detected the new changes on the resources and started the rollout process",
 		}
 	}
+	return r.updateBindingStatusWithCondition(ctx, binding, cond)
+}
+
+// updateBindingStatusWithCondition sets the given condition on a BindingObj and updates its status.
+func (r *Reconciler) updateBindingStatusWithCondition(ctx context.Context, binding placementv1beta1.BindingObj, cond metav1.Condition) error {
 	binding.SetConditions(cond)
 	if err := r.Client.Status().Update(ctx, binding); err != nil {
 		klog.ErrorS(err, "Failed to update binding status", "binding", klog.KObj(binding), "condition", cond)
@@ -1157,6 +1283,37 @@ func (r *Reconciler) processApplyStrategyUpdates(
 	return applyStrategyUpdated, errs.Wait()
 }
 
+// handleMemberClusterUpdated enqueues the placements which have bindings on a member cluster when the
+// maintenance windows of the cluster have changed.
+func (r *Reconciler) handleMemberClusterUpdated(ctx context.Context, objectOld, objectNew client.Object, q workqueue.TypedRateLimitingInterface[reconcile.Request], enqueueCRP bool) {
+	oldCluster, oldOK := objectOld.(*clusterv1beta1.MemberCluster)
+	newCluster, newOK := objectNew.(*clusterv1beta1.MemberCluster)
+	if !oldOK || !newOK {
+		klog.ErrorS(controller.NewUnexpectedBehaviorError(fmt.Errorf("failed to cast runtime objects in update event to member cluster objects")), "Failed to process update event")
+		return
+	}
+	if equality.Semantic.DeepEqual(oldCluster.Spec.MaintenanceWindows, newCluster.Spec.MaintenanceWindows) {
+		return
+	}
+
+	var bindingList placementv1beta1.BindingObjList
+	if enqueueCRP {
+		bindingList = &placementv1beta1.ClusterResourceBindingList{}
+	} else {
+		bindingList = &placementv1beta1.ResourceBindingList{}
+	}
+	if err := r.Client.List(ctx, bindingList); err != nil {
+		klog.ErrorS(err, "Failed to list the bindings to enqueue their placements", "memberCluster", klog.KObj(newCluster))
+		return
+	}
+	klog.V(2).InfoS("Detected an update to the maintenance windows of the member cluster", "memberCluster", klog.KObj(newCluster))
+	for _, binding := range bindingList.GetBindingObjs() {
+		if binding.GetBindingSpec().TargetCluster == newCluster.Name {
+			enqueueBinding(binding, q)
+		}
+	}
+}
+
 // handlePlacement handles the update event of a placement object (ClusterResourcePlacement or ResourcePlacement),
 // which the rollout controller watches.
 func handlePlacement(newPlacementObj, oldPlacementObj client.Object, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
@@ -1204,5 +1361,22 @@ func handlePlacement(newPlacementObj, oldPlacementObj client.Object, q workqueue
 		return
 	}
 
+	// Check if the maintenance windows have been updated, so that the rollout held by them resumes right away.
+	if !equality.Semantic.DeepEqual(placementMaintenanceWindows(newPlacementSpec), placementMaintenanceWindows(oldPlacementSpec)) {
+		klog.V(2).InfoS("Detected an update to the maintenance windows on the placement", "placement", klog.KObj(newPlacement))
+		q.Add(reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: newPlacement.GetName(), Namespace: newPlacement.GetNamespace()},
+		})
+		return
+	}
+
 	klog.V(2).InfoS("No update to apply strategy detected; ignore the placement Update event", "placement", klog.KObj(newPlacement))
 }
+
+// placementMaintenanceWindows returns the maintenance windows of the rolling update strategy of a placement.
+func placementMaintenanceWindows(spec *placementv1beta1.PlacementSpec) []placementv1beta1.MaintenanceWindow {
+	if spec.Strategy.RollingUpdate == nil {
+		return nil
+	}
+	return spec.Strategy.RollingUpdate.MaintenanceWindows
+}
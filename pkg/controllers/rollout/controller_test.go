@@ -33,6 +33,7 @@ import (
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -2773,6 +2774,194 @@ func generateDefaultRollingUpdateConfig() *placementv1beta1.RollingUpdateConfig
 	}
 }
 
+func TestPickBindingsToRollWithMaintenanceWindows(t *testing.T) {
+	// The window opens every minute and stays open for an hour, so it is always open.
+	alwaysOpenWindows := []placementv1beta1.MaintenanceWindow{{Schedule: "* * * * *", Duration: metav1.Duration{Duration: time.Hour}}}
+	// The window opens on February 30th, so it is never open.
+	neverOpenWindow := placementv1beta1.MaintenanceWindow{Schedule: "0 0 30 2 *", Duration: metav1.Duration{Duration: time.Hour}}
+	tests := map[string]struct {
+		bindings                      []*placementv1beta1.ClusterResourceBinding
+		placementWindows              []placementv1beta1.MaintenanceWindow
+		clusters                      []clusterv1beta1.MemberCluster
+		wantTobeUpdatedClusters       []string
+		wantWaitingForWindowsClusters []string
+	}{
+		"placement maintenance window is open": {
+			bindings: []*placementv1beta1.ClusterResourceBinding{
+				generateClusterResourceBinding(placementv1beta1.BindingStateScheduled, "snapshot-1", cluster1),
+			},
+			placementWindows:        alwaysOpenWindows,
+			wantTobeUpdatedClusters: []string{cluster1},
+		},
+		"placement maintenance window is closed": {
+			bindings: []*placementv1beta1.ClusterResourceBinding{
+				generateClusterResourceBinding(placementv1beta1.BindingStateScheduled, "snapshot-1", cluster1),
+				generateReadyClusterResourceBinding(placementv1beta1.BindingStateBound, "snapshot-1", cluster2),
+			},
+			placementWindows:              []placementv1beta1.MaintenanceWindow{neverOpenWindow},
+			wantWaitingForWindowsClusters: []string{cluster1, cluster2},
+		},
+		"member cluster maintenance window is closed": {
+			bindings: []*placementv1beta1.ClusterResourceBinding{
+				generateReadyClusterResourceBinding(placementv1beta1.BindingStateBound, "snapshot-1", cluster1),
+				generateReadyClusterResourceBinding(placementv1beta1.BindingStateBound, "snapshot-1", cluster2),
+				generateClusterResourceBinding(placementv1beta1.BindingStateScheduled, "snapshot-1", cluster3),
+			},
+			placementWindows: alwaysOpenWindows,
+			clusters: []clusterv1beta1.MemberCluster{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: cluster2},
+					Spec: clusterv1beta1.MemberClusterSpec{
+						MaintenanceWindows: []clusterv1beta1.MaintenanceWindow{
+							{Schedule: neverOpenWindow.Schedule, Duration: neverOpenWindow.Duration},
+						},
+					},
+				},
+			},
+			wantTobeUpdatedClusters:       []string{cluster1, cluster3},
+			wantWaitingForWindowsClusters: []string{cluster2},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var objects []client.Object
+			for i := range tt.clusters {
+				objects = append(objects, &tt.clusters[i])
+			}
+			clusterLookups := 0
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(serviceScheme(t)).
+				WithObjects(objects...).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+						if _, ok := obj.(*clusterv1beta1.MemberCluster); ok {
+							clusterLookups++
+						}
+						return c.Get(ctx, key, obj, opts...)
+					},
+					List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+						if _, ok := list.(*clusterv1beta1.MemberClusterList); ok {
+							clusterLookups++
+						}
+						return c.List(ctx, list, opts...)
+					},
+				}).
+				Build()
+			r := Reconciler{
+				Client: fakeClient,
+			}
+			rollingUpdate := generateDefaultRollingUpdateConfig()
+			rollingUpdate.MaxUnavailable = ptr.To(intstr.FromString("50%"))
+			rollingUpdate.MaintenanceWindows = tt.placementWindows
+			crp := clusterResourcePlacementForTest("test",
+				createPlacementPolicyForTest(placementv1beta1.PickAllPlacementType, 0),
+				createPlacementRolloutStrategyForTest(placementv1beta1.RollingUpdateRolloutStrategyType, rollingUpdate, nil))
+			resourceSnapshot := &placementv1beta1.ClusterResourceSnapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "snapshot-2",
+				},
+			}
+			gotUpdatedBindings, gotStaleBindings, _, gotNeedRoll, _, err := r.pickBindingsToRoll(context.Background(), controller.ConvertCRBArrayToBindingObjs(tt.bindings), resourceSnapshot, crp, nil, nil)
+			if err != nil {
+				t.Fatalf("pickBindingsToRoll() error = %v, want no error", err)
+			}
+			if !gotNeedRoll {
+				t.Errorf("pickBindingsToRoll() = needRoll false, want true")
+			}
+			gotTobeUpdatedClusters := make([]string, 0, len(gotUpdatedBindings))
+			for _, binding := range gotUpdatedBindings {
+				gotTobeUpdatedClusters = append(gotTobeUpdatedClusters, binding.currentBinding.GetBindingSpec().TargetCluster)
+			}
+			gotWaitingForWindowsClusters := make([]string, 0, len(gotStaleBindings))
+			for _, binding := range gotStaleBindings {
+				if binding.waitingForMaintenanceWindow {
+					gotWaitingForWindowsClusters = append(gotWaitingForWindowsClusters, binding.currentBinding.GetBindingSpec().TargetCluster)
+				}
+			}
+			if diff := cmp.Diff(tt.wantTobeUpdatedClusters, gotTobeUpdatedClusters, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("pickBindingsToRoll() toBeUpdatedBindings clusters mismatch (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantWaitingForWindowsClusters, gotWaitingForWindowsClusters, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("pickBindingsToRoll() bindings waiting for maintenance windows mismatch (-want, +got):\n%s", diff)
+			}
+			if clusterLookups != 1 {
+				t.Errorf("pickBindingsToRoll() looked up the member clusters %d times, want 1", clusterLookups)
+			}
+		})
+	}
+}
+
+func TestHandleMemberClusterUpdated(t *testing.T) {
+	windows := []clusterv1beta1.MaintenanceWindow{{Schedule: "0 2 * * *", Duration: metav1.Duration{Duration: time.Hour}}}
+	newCluster := func(windows []clusterv1beta1.MaintenanceWindow) *clusterv1beta1.MemberCluster {
+		return &clusterv1beta1.MemberCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: cluster1},
+			Spec:       clusterv1beta1.MemberClusterSpec{MaintenanceWindows: windows},
+		}
+	}
+	newBinding := func(name, namespace, placementName, clusterName string) client.Object {
+		spec := placementv1beta1.ResourceBindingSpec{TargetCluster: clusterName}
+		meta := metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{placementv1beta1.PlacementTrackingLabel: placementName},
+		}
+		if namespace == "" {
+			return &placementv1beta1.ClusterResourceBinding{ObjectMeta: meta, Spec: spec}
+		}
+		return &placementv1beta1.ResourceBinding{ObjectMeta: meta, Spec: spec}
+	}
+	bindings := []client.Object{
+		newBinding("crb-1", "", "crp-1", cluster1),
+		newBinding("crb-2", "", "crp-2", cluster2),
+		newBinding("rb-1", "test-namespace", "rp-1", cluster1),
+		newBinding("rb-2", "test-namespace", "rp-2", cluster2),
+	}
+	tests := map[string]struct {
+		oldCluster      *clusterv1beta1.MemberCluster
+		newCluster      *clusterv1beta1.MemberCluster
+		enqueueCRP      bool
+		wantEnqueueKeys []reconcile.Request
+	}{
+		"maintenance windows are added, enqueue the cluster resource placements on the cluster": {
+			oldCluster: newCluster(nil),
+			newCluster: newCluster(windows),
+			enqueueCRP: true,
+			wantEnqueueKeys: []reconcile.Request{
+				{NamespacedName: types.NamespacedName{Name: "crp-1"}},
+			},
+		},
+		"maintenance windows are removed, enqueue the resource placements on the cluster": {
+			oldCluster: newCluster(windows),
+			newCluster: newCluster(nil),
+			wantEnqueueKeys: []reconcile.Request{
+				{NamespacedName: types.NamespacedName{Name: "rp-1", Namespace: "test-namespace"}},
+			},
+		},
+		"maintenance windows are not changed": {
+			oldCluster: newCluster(windows),
+			newCluster: func() *clusterv1beta1.MemberCluster {
+				cluster := newCluster(windows)
+				cluster.Labels = map[string]string{"env": "prod"}
+				return cluster
+			}(),
+			enqueueCRP: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(serviceScheme(t)).
+				WithObjects(bindings...).
+				Build()
+			r := Reconciler{Client: fakeClient}
+			queue := &controllertest.Queue{TypedInterface: workqueue.NewTypedRateLimitingQueue[reconcile.Request](workqueue.DefaultTypedItemBasedRateLimiter[reconcile.Request]())}
+			r.handleMemberClusterUpdated(context.Background(), tt.oldCluster, tt.newCluster, queue, tt.enqueueCRP)
+			validateEnqueueBehavior(t, queue, tt.wantEnqueueKeys)
+		})
+	}
+}
+
 func TestUpdateStaleBindingsStatus(t *testing.T) {
 	currentTime := time.Now()
 	oldTransitionTime := metav1.NewTime(currentTime.Add(-1 * time.Hour))
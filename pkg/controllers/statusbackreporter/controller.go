@@ -77,6 +77,13 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	// Works created for placement previews only report diffs; there is no original resource
+	// to back-report statuses to.
+	if _, isPreview := work.Labels[placementv1beta1.PlacementPreviewLabel]; isPreview {
+		klog.V(2).InfoS("Skip status back-reporting to original resources as the Work object is created for a placement preview", "work", workRef)
+		return ctrl.Result{}, nil
+	}
+
 	// Perform a sanity check; make sure that mirroring back to original resources can be done, i.e.,
 	// the scheduling policy is set to the PickFixed type with exactly one target cluster, or the PickN
 	// type with the number of clusters set to 1. The logic also checks if the report back strategy still
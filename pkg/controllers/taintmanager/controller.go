@@ -0,0 +1,369 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package taintmanager features a controller that enforces the NoExecute taints on MemberClusters,
+// similar to the taint-based eviction in Kubernetes.
+//
+// The controller taints a MemberCluster with the NoExecute unhealthy taint once the member agent has
+// stopped sending heartbeats, has reported that the cluster is unhealthy, or has left the fleet for
+// a configurable period of time, and removes the taint once the cluster recovers. It then evicts the
+// ClusterResourcePlacements which do not tolerate the NoExecute taints on a MemberCluster (or whose
+// tolerations have expired) from the cluster, via the eviction API, which enforces the
+// ClusterResourcePlacementDisruptionBudgets of the placements; the scheduler then picks other clusters
+// for the placements as applicable, as the NoExecute taints also keep new placements away.
+//
+// Placements of the PickFixed placement type are never evicted, as their clusters are picked by
+// the users; ResourcePlacements are not evicted either, as the eviction API does not cover them.
+package taintmanager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	runtime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/tainttoleration"
+	"go.goms.io/fleet/pkg/utils/condition"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+const (
+	// The reasons of the events which the taint manager emits on member clusters.
+	unhealthyTaintAddedReason   = "UnhealthyTaintAdded"
+	unhealthyTaintRemovedReason = "UnhealthyTaintRemoved"
+	taintEvictionStartedReason  = "TaintEvictionStarted"
+	taintEvictionDoneReason     = "TaintEvictionCompleted"
+	taintEvictionBlockedReason  = "TaintEvictionBlocked"
+
+	// taintEvictionNameFmt is the format of the names of the evictions which the taint manager creates;
+	// the name of the binding to evict follows the prefix.
+	taintEvictionNameFmt = "taint-%s"
+
+	// evictionRetryDelay is the delay before the taint manager retries an eviction which is blocked,
+	// most likely by the disruption budget of the placement.
+	evictionRetryDelay = time.Minute
+)
+
+// Reconciler reconciles MemberClusters for tainting the unhealthy ones and evicting the placements
+// which do not tolerate their NoExecute taints.
+type Reconciler struct {
+	client.Client
+	// Recorder is the event recorder for reporting the taints and the evictions on the member clusters.
+	Recorder record.EventRecorder
+	// ClusterUnhealthyThreshold is the duration without heartbeats after which a member cluster
+	// is considered unhealthy.
+	ClusterUnhealthyThreshold time.Duration
+	// UnhealthyTaintDelay is the duration for which a member cluster must have been unhealthy (or
+	// left) before it is tainted with the NoExecute unhealthy taint.
+	UnhealthyTaintDelay time.Duration
+}
+
+// Reconcile syncs the unhealthy taint of a member cluster, and evicts the placements which do not
+// tolerate the NoExecute taints of the cluster.
+func (r *Reconciler) Reconcile(ctx context.Context, req runtime.Request) (runtime.Result, error) {
+	startTime := time.Now()
+	mcName := req.NamespacedName.Name
+	klog.V(2).InfoS("Taint manager reconciliation starts", "memberCluster", mcName)
+	defer func() {
+		latency := time.Since(startTime).Milliseconds()
+		klog.V(2).InfoS("Taint manager reconciliation ends", "memberCluster", mcName, "latency", latency)
+	}()
+
+	var mc clusterv1beta1.MemberCluster
+	if err := r.Client.Get(ctx, req.NamespacedName, &mc); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return runtime.Result{}, nil
+		}
+		klog.ErrorS(err, "Failed to get member cluster", "memberCluster", mcName)
+		return runtime.Result{}, controller.NewAPIServerError(true, err)
+	}
+	if mc.DeletionTimestamp != nil {
+		// The placements are removed from a leaving member cluster by the scheduler.
+		klog.V(2).InfoS("Member cluster is leaving the fleet", "memberCluster", mcName)
+		return runtime.Result{}, nil
+	}
+
+	now := time.Now()
+	taintRequeueAfter, err := r.syncUnhealthyTaint(ctx, &mc, now)
+	if err != nil {
+		return runtime.Result{}, err
+	}
+	evictionRequeueAfter, err := r.evictPlacements(ctx, &mc, now)
+	if err != nil {
+		return runtime.Result{}, err
+	}
+	return runtime.Result{RequeueAfter: minPositiveDuration(taintRequeueAfter, evictionRequeueAfter)}, nil
+}
+
+// unhealthySince returns the time since which a member cluster has been unhealthy (or left), if it is.
+func (r *Reconciler) unhealthySince(mc *clusterv1beta1.MemberCluster, now time.Time) (time.Time, bool) {
+	memberAgentStatus := mc.GetAgentStatus(clusterv1beta1.MemberAgent)
+	if memberAgentStatus == nil {
+		// The member agent has not joined yet; no placement can have been scheduled to the cluster.
+		return time.Time{}, false
+	}
+
+	var since time.Time
+	unhealthy := false
+	markUnhealthy := func(t time.Time) {
+		if !unhealthy || t.Before(since) {
+			since = t
+			unhealthy = true
+		}
+	}
+	if heartbeatExpiry := memberAgentStatus.LastReceivedHeartbeat.Add(r.ClusterUnhealthyThreshold); !now.Before(heartbeatExpiry) {
+		// The member agent has lost its heartbeat connection to the hub cluster.
+		markUnhealthy(heartbeatExpiry)
+	}
+	if joinedCond := mc.GetAgentCondition(clusterv1beta1.MemberAgent, clusterv1beta1.AgentJoined); joinedCond != nil && joinedCond.Status == metav1.ConditionFalse {
+		// The member agent has left the fleet.
+		markUnhealthy(joinedCond.LastTransitionTime.Time)
+	}
+	if healthyCond := mc.GetAgentCondition(clusterv1beta1.MemberAgent, clusterv1beta1.AgentHealthy); healthyCond != nil && healthyCond.Status == metav1.ConditionFalse {
+		// The member agent reports that the cluster is unhealthy.
+		markUnhealthy(healthyCond.LastTransitionTime.Time)
+	}
+	return since, unhealthy
+}
+
+// syncUnhealthyTaint adds the unhealthy taint to a member cluster which has been unhealthy for longer
+// than the taint delay, and removes it from a member cluster which has recovered; it also records the
+// time at which each NoExecute taint (including the ones added by the users) is added.
+//
+// It returns the duration after which the member cluster is due to be tainted, should it stay (or become)
+// unhealthy.
+func (r *Reconciler) syncUnhealthyTaint(ctx context.Context, mc *clusterv1beta1.MemberCluster, now time.Time) (time.Duration, error) {
+	since, unhealthy := r.unhealthySince(mc, now)
+	wantTaint := unhealthy && !now.Before(since.Add(r.UnhealthyTaintDelay))
+
+	var requeueAfter time.Duration
+	switch {
+	case unhealthy && !wantTaint:
+		requeueAfter = since.Add(r.UnhealthyTaintDelay).Sub(now)
+	case !unhealthy:
+		if memberAgentStatus := mc.GetAgentStatus(clusterv1beta1.MemberAgent); memberAgentStatus != nil {
+			// Check again in case the heartbeats stop.
+			requeueAfter = memberAgentStatus.LastReceivedHeartbeat.Add(r.ClusterUnhealthyThreshold + r.UnhealthyTaintDelay).Sub(now)
+		}
+	}
+
+	taints := make([]clusterv1beta1.Taint, 0, len(mc.Spec.Taints)+1)
+	hasTaint, removed, stamped := false, false, false
+	for _, taint := range mc.Spec.Taints {
+		if taint.Key == clusterv1beta1.UnhealthyTaintKey && taint.Effect == corev1.TaintEffectNoExecute {
+			if !wantTaint {
+				removed = true
+				continue
+			}
+			hasTaint = true
+		}
+		if taint.Effect == corev1.TaintEffectNoExecute && taint.TimeAdded == nil {
+			taint.TimeAdded = &metav1.Time{Time: now}
+			stamped = true
+		}
+		taints = append(taints, taint)
+	}
+	added := wantTaint && !hasTaint
+	if added {
+		taints = append(taints, clusterv1beta1.Taint{
+			Key:       clusterv1beta1.UnhealthyTaintKey,
+			Effect:    corev1.TaintEffectNoExecute,
+			TimeAdded: &metav1.Time{Time: now},
+		})
+	}
+	if !added && !removed && !stamped {
+		return requeueAfter, nil
+	}
+
+	mc.Spec.Taints = taints
+	if err := r.Client.Update(ctx, mc); err != nil {
+		klog.ErrorS(err, "Failed to update the taints of the member cluster", "memberCluster", klog.KObj(mc))
+		return 0, controller.NewUpdateIgnoreConflictError(err)
+	}
+	if added {
+		klog.V(2).InfoS("Tainted the unhealthy member cluster", "memberCluster", klog.KObj(mc), "unhealthySince", since)
+		r.Recorder.Eventf(mc, corev1.EventTypeWarning, unhealthyTaintAddedReason,
+			"Added the %s taint, as the cluster has been unhealthy since %s", clusterv1beta1.UnhealthyTaintKey, since.Format(time.RFC3339))
+	}
+	if removed {
+		klog.V(2).InfoS("Removed the unhealthy taint from the recovered member cluster", "memberCluster", klog.KObj(mc))
+		r.Recorder.Eventf(mc, corev1.EventTypeNormal, unhealthyTaintRemovedReason,
+			"Removed the %s taint, as the cluster has recovered", clusterv1beta1.UnhealthyTaintKey)
+	}
+	return requeueAfter, nil
+}
+
+// evictPlacements requests the evictions of the placements which do not tolerate the NoExecute taints
+// of a member cluster, and cleans up the evictions which have finished.
+//
+// It returns the duration after which the next eviction is due, or the blocked evictions are to be retried.
+func (r *Reconciler) evictPlacements(ctx context.Context, mc *clusterv1beta1.MemberCluster, now time.Time) (time.Duration, error) {
+	mcRef := klog.KObj(mc)
+	var requeueAfter time.Duration
+
+	// Clean up the evictions which have finished.
+	var evictionList placementv1beta1.ClusterResourcePlacementEvictionList
+	if err := r.Client.List(ctx, &evictionList, client.MatchingLabels{placementv1beta1.TaintEvictionClusterLabel: mc.Name}); err != nil {
+		klog.ErrorS(err, "Failed to list the taint evictions", "memberCluster", mcRef)
+		return 0, controller.NewAPIServerError(true, err)
+	}
+	pending := make(map[string]bool, len(evictionList.Items))
+	for i := range evictionList.Items {
+		eviction := &evictionList.Items[i]
+		done, retryAfter := r.checkEviction(mc, eviction, now)
+		if !done {
+			pending[eviction.Name] = true
+			requeueAfter = minPositiveDuration(requeueAfter, retryAfter)
+			continue
+		}
+		if err := r.Client.Delete(ctx, eviction); err != nil && !k8serrors.IsNotFound(err) {
+			klog.ErrorS(err, "Failed to delete the taint eviction", "memberCluster", mcRef, "eviction", klog.KObj(eviction))
+			return 0, controller.NewAPIServerError(false, err)
+		}
+	}
+
+	if !hasNoExecuteTaint(mc.Spec.Taints) {
+		return requeueAfter, nil
+	}
+
+	var bindingList placementv1beta1.ClusterResourceBindingList
+	if err := r.Client.List(ctx, &bindingList); err != nil {
+		klog.ErrorS(err, "Failed to list cluster resource bindings", "memberCluster", mcRef)
+		return 0, controller.NewAPIServerError(true, err)
+	}
+	for i := range bindingList.Items {
+		binding := &bindingList.Items[i]
+		if binding.Spec.TargetCluster != mc.Name || binding.DeletionTimestamp != nil ||
+			(binding.Spec.State != placementv1beta1.BindingStateScheduled && binding.Spec.State != placementv1beta1.BindingStateBound) {
+			continue
+		}
+		evictionName := fmt.Sprintf(taintEvictionNameFmt, binding.Name)
+		if pending[evictionName] {
+			continue
+		}
+
+		crpName := binding.GetLabels()[placementv1beta1.PlacementTrackingLabel]
+		var crp placementv1beta1.ClusterResourcePlacement
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: crpName}, &crp); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			klog.ErrorS(err, "Failed to get cluster resource placement", "memberCluster", mcRef, "clusterResourcePlacement", crpName)
+			return 0, controller.NewAPIServerError(true, err)
+		}
+		if crp.DeletionTimestamp != nil || (crp.Spec.Policy != nil && crp.Spec.Policy.PlacementType == placementv1beta1.PickFixedPlacementType) {
+			continue
+		}
+
+		evictAt, found := tainttoleration.EvictionTime(mc.Spec.Taints, crp.Spec.Tolerations(), now)
+		if !found {
+			continue
+		}
+		if now.Before(evictAt) {
+			requeueAfter = minPositiveDuration(requeueAfter, evictAt.Sub(now))
+			continue
+		}
+
+		eviction := &placementv1beta1.ClusterResourcePlacementEviction{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: evictionName,
+				Labels: map[string]string{
+					placementv1beta1.TaintEvictionClusterLabel: mc.Name,
+				},
+			},
+			Spec: placementv1beta1.PlacementEvictionSpec{
+				PlacementName: crp.Name,
+				ClusterName:   mc.Name,
+			},
+		}
+		if err := r.Client.Create(ctx, eviction); err != nil && !k8serrors.IsAlreadyExists(err) {
+			klog.ErrorS(err, "Failed to create the taint eviction", "memberCluster", mcRef, "eviction", klog.KObj(eviction))
+			return 0, controller.NewAPIServerError(false, err)
+		}
+		klog.V(2).InfoS("Requested the eviction of a placement from the tainted member cluster", "memberCluster", mcRef, "clusterResourcePlacement", crpName, "binding", klog.KObj(binding))
+		r.Recorder.Eventf(mc, corev1.EventTypeNormal, taintEvictionStartedReason,
+			"Evicting placement %s, as it does not tolerate the NoExecute taints of the cluster", crp.Name)
+		requeueAfter = minPositiveDuration(requeueAfter, evictionRetryDelay)
+	}
+	return requeueAfter, nil
+}
+
+// checkEviction checks if a taint eviction has finished, i.e., it has been executed, has turned out to
+// be invalid, or has been blocked for longer than the retry delay (in which case it is to be re-created
+// if still due); otherwise, it returns the duration after which the eviction is to be checked again.
+func (r *Reconciler) checkEviction(mc *clusterv1beta1.MemberCluster, eviction *placementv1beta1.ClusterResourcePlacementEviction, now time.Time) (bool, time.Duration) {
+	placementName := eviction.Spec.PlacementName
+	validCond := eviction.GetCondition(string(placementv1beta1.PlacementEvictionConditionTypeValid))
+	executedCond := eviction.GetCondition(string(placementv1beta1.PlacementEvictionConditionTypeExecuted))
+	switch {
+	case condition.IsConditionStatusFalse(validCond, eviction.Generation):
+		// The placement or its binding is gone, most likely.
+		klog.V(2).InfoS("Taint eviction is invalid", "memberCluster", klog.KObj(mc), "eviction", klog.KObj(eviction), "message", validCond.Message)
+		return true, 0
+	case executedCond == nil:
+		// The eviction has not been executed yet.
+		return false, evictionRetryDelay
+	case executedCond.Status == metav1.ConditionTrue:
+		r.Recorder.Eventf(mc, corev1.EventTypeNormal, taintEvictionDoneReason, "Evicted placement %s", placementName)
+		return true, 0
+	}
+	retryAt := eviction.CreationTimestamp.Add(evictionRetryDelay)
+	if now.Before(retryAt) {
+		return false, retryAt.Sub(now)
+	}
+	r.Recorder.Eventf(mc, corev1.EventTypeWarning, taintEvictionBlockedReason,
+		"Cannot evict placement %s: %s", placementName, executedCond.Message)
+	return true, 0
+}
+
+// hasNoExecuteTaint returns true if any of the taints has the NoExecute effect.
+func hasNoExecuteTaint(taints []clusterv1beta1.Taint) bool {
+	for i := range taints {
+		if taints[i].Effect == corev1.TaintEffectNoExecute {
+			return true
+		}
+	}
+	return false
+}
+
+// minPositiveDuration returns the smaller of two durations, ignoring the non-positive ones.
+func minPositiveDuration(a, b time.Duration) time.Duration {
+	switch {
+	case a <= 0:
+		return max(b, 0)
+	case b <= 0:
+		return a
+	default:
+		return min(a, b)
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(mgr runtime.Manager) error {
+	return runtime.NewControllerManagedBy(mgr).Named("taint-manager").
+		For(&clusterv1beta1.MemberCluster{}).
+		Complete(r)
+}
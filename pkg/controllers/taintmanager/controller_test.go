@@ -0,0 +1,446 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taintmanager
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+const (
+	mcName      = "bravelion"
+	crpName     = "test-crp"
+	bindingName = "test-crp-bravelion"
+
+	unhealthyThreshold = time.Minute
+	taintDelay         = 5 * time.Minute
+)
+
+var (
+	evictionName = "taint-" + bindingName
+)
+
+func newMemberCluster(lastHeartbeat time.Time, healthy bool, taints ...clusterv1beta1.Taint) *clusterv1beta1.MemberCluster {
+	healthyStatus := metav1.ConditionTrue
+	if !healthy {
+		healthyStatus = metav1.ConditionFalse
+	}
+	return &clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: mcName,
+		},
+		Spec: clusterv1beta1.MemberClusterSpec{
+			Taints: taints,
+		},
+		Status: clusterv1beta1.MemberClusterStatus{
+			AgentStatus: []clusterv1beta1.AgentStatus{
+				{
+					Type: clusterv1beta1.MemberAgent,
+					Conditions: []metav1.Condition{
+						{
+							Type:               string(clusterv1beta1.AgentJoined),
+							Status:             metav1.ConditionTrue,
+							Reason:             "Joined",
+							LastTransitionTime: metav1.NewTime(lastHeartbeat.Add(-time.Hour)),
+						},
+						{
+							Type:               string(clusterv1beta1.AgentHealthy),
+							Status:             healthyStatus,
+							Reason:             "Test",
+							LastTransitionTime: metav1.NewTime(lastHeartbeat),
+						},
+					},
+					LastReceivedHeartbeat: metav1.NewTime(lastHeartbeat),
+				},
+			},
+		},
+	}
+}
+
+func unhealthyTaint(timeAdded time.Time) clusterv1beta1.Taint {
+	return clusterv1beta1.Taint{
+		Key:       clusterv1beta1.UnhealthyTaintKey,
+		Effect:    corev1.TaintEffectNoExecute,
+		TimeAdded: &metav1.Time{Time: timeAdded},
+	}
+}
+
+func newCRP(placementType placementv1beta1.PlacementType, tolerations ...placementv1beta1.Toleration) *placementv1beta1.ClusterResourcePlacement {
+	return &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{Name: crpName},
+		Spec: placementv1beta1.PlacementSpec{
+			Policy: &placementv1beta1.PlacementPolicy{
+				PlacementType: placementType,
+				Tolerations:   tolerations,
+			},
+		},
+	}
+}
+
+func newBinding() *placementv1beta1.ClusterResourceBinding {
+	return &placementv1beta1.ClusterResourceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: bindingName,
+			Labels: map[string]string{
+				placementv1beta1.PlacementTrackingLabel: crpName,
+			},
+		},
+		Spec: placementv1beta1.ResourceBindingSpec{
+			State:         placementv1beta1.BindingStateBound,
+			TargetCluster: mcName,
+		},
+	}
+}
+
+func newEviction(createdAt time.Time, conditions ...metav1.Condition) *placementv1beta1.ClusterResourcePlacementEviction {
+	return &placementv1beta1.ClusterResourcePlacementEviction{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              evictionName,
+			Generation:        1,
+			CreationTimestamp: metav1.NewTime(createdAt),
+			Labels: map[string]string{
+				placementv1beta1.TaintEvictionClusterLabel: mcName,
+			},
+		},
+		Spec: placementv1beta1.PlacementEvictionSpec{
+			PlacementName: crpName,
+			ClusterName:   mcName,
+		},
+		Status: placementv1beta1.PlacementEvictionStatus{
+			Conditions: conditions,
+		},
+	}
+}
+
+func evictionCondition(conditionType placementv1beta1.PlacementEvictionConditionType, status metav1.ConditionStatus, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:               string(conditionType),
+		Status:             status,
+		ObservedGeneration: 1,
+		Reason:             "Test",
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	}
+}
+
+// TestReconcile tests the Reconcile method.
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clusterv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add cluster v1beta1 scheme: %v", err)
+	}
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add placement v1beta1 scheme: %v", err)
+	}
+
+	now := time.Now()
+	userTaint := clusterv1beta1.Taint{
+		Key:    "example.com/decommissioned",
+		Effect: corev1.TaintEffectNoExecute,
+	}
+	noScheduleTaint := clusterv1beta1.Taint{
+		Key:    "example.com/maintenance",
+		Effect: corev1.TaintEffectNoSchedule,
+	}
+
+	testCases := []struct {
+		name   string
+		objs   []client.Object
+		wantOK func(got ctrl.Result) bool
+		// wantTaintKeys are the keys of the wanted taints on the member cluster.
+		wantTaintKeys    []string
+		wantEviction     bool
+		wantEventReasons []string
+	}{
+		{
+			name: "healthy cluster",
+			objs: []client.Object{
+				newMemberCluster(now, true, noScheduleTaint),
+				newCRP(placementv1beta1.PickNPlacementType),
+				newBinding(),
+			},
+			wantOK: func(got ctrl.Result) bool {
+				return got.RequeueAfter > unhealthyThreshold && got.RequeueAfter <= unhealthyThreshold+taintDelay
+			},
+			wantTaintKeys: []string{noScheduleTaint.Key},
+		},
+		{
+			name: "heartbeat lost within the taint delay",
+			objs: []client.Object{
+				newMemberCluster(now.Add(-2*unhealthyThreshold), true),
+				newCRP(placementv1beta1.PickNPlacementType),
+				newBinding(),
+			},
+			wantOK: func(got ctrl.Result) bool {
+				return got.RequeueAfter > 0 && got.RequeueAfter <= taintDelay-unhealthyThreshold
+			},
+		},
+		{
+			name: "heartbeat lost beyond the taint delay",
+			objs: []client.Object{
+				newMemberCluster(now.Add(-unhealthyThreshold-taintDelay-time.Second), true, noScheduleTaint),
+				newCRP(placementv1beta1.PickNPlacementType),
+				newBinding(),
+			},
+			wantOK: func(got ctrl.Result) bool {
+				return got.RequeueAfter == evictionRetryDelay
+			},
+			wantTaintKeys:    []string{noScheduleTaint.Key, clusterv1beta1.UnhealthyTaintKey},
+			wantEviction:     true,
+			wantEventReasons: []string{unhealthyTaintAddedReason, taintEvictionStartedReason},
+		},
+		{
+			name: "cluster reported unhealthy beyond the taint delay",
+			objs: []client.Object{
+				newMemberCluster(now.Add(-taintDelay-time.Second), false),
+				newCRP(placementv1beta1.PickAllPlacementType),
+				newBinding(),
+			},
+			wantOK: func(got ctrl.Result) bool {
+				return got.RequeueAfter == evictionRetryDelay
+			},
+			wantTaintKeys:    []string{clusterv1beta1.UnhealthyTaintKey},
+			wantEviction:     true,
+			wantEventReasons: []string{unhealthyTaintAddedReason, taintEvictionStartedReason},
+		},
+		{
+			name: "recovered cluster",
+			objs: []client.Object{
+				newMemberCluster(now, true, unhealthyTaint(now.Add(-time.Hour)), noScheduleTaint),
+				newCRP(placementv1beta1.PickNPlacementType),
+				newBinding(),
+			},
+			wantOK: func(got ctrl.Result) bool {
+				return got.RequeueAfter > unhealthyThreshold && got.RequeueAfter <= unhealthyThreshold+taintDelay
+			},
+			wantTaintKeys:    []string{noScheduleTaint.Key},
+			wantEventReasons: []string{unhealthyTaintRemovedReason},
+		},
+		{
+			name: "placement tolerates the taint for a limited time",
+			objs: []client.Object{
+				newMemberCluster(now.Add(-time.Hour), true, unhealthyTaint(now.Add(-time.Minute))),
+				newCRP(placementv1beta1.PickNPlacementType, placementv1beta1.Toleration{
+					Key:               clusterv1beta1.UnhealthyTaintKey,
+					Operator:          corev1.TolerationOpExists,
+					Effect:            corev1.TaintEffectNoExecute,
+					TolerationSeconds: ptr.To(int64(300)),
+				}),
+				newBinding(),
+			},
+			wantOK: func(got ctrl.Result) bool {
+				return got.RequeueAfter > 3*time.Minute && got.RequeueAfter <= 4*time.Minute
+			},
+			wantTaintKeys: []string{clusterv1beta1.UnhealthyTaintKey},
+		},
+		{
+			name: "placement toleration has expired",
+			objs: []client.Object{
+				newMemberCluster(now.Add(-time.Hour), true, unhealthyTaint(now.Add(-10*time.Minute))),
+				newCRP(placementv1beta1.PickNPlacementType, placementv1beta1.Toleration{
+					Key:               clusterv1beta1.UnhealthyTaintKey,
+					Operator:          corev1.TolerationOpExists,
+					Effect:            corev1.TaintEffectNoExecute,
+					TolerationSeconds: ptr.To(int64(300)),
+				}),
+				newBinding(),
+			},
+			wantOK: func(got ctrl.Result) bool {
+				return got.RequeueAfter == evictionRetryDelay
+			},
+			wantTaintKeys:    []string{clusterv1beta1.UnhealthyTaintKey},
+			wantEviction:     true,
+			wantEventReasons: []string{taintEvictionStartedReason},
+		},
+		{
+			name: "placement tolerates the taint forever",
+			objs: []client.Object{
+				newMemberCluster(now.Add(-time.Hour), true, unhealthyTaint(now.Add(-time.Hour))),
+				newCRP(placementv1beta1.PickNPlacementType, placementv1beta1.Toleration{
+					Operator: corev1.TolerationOpExists,
+				}),
+				newBinding(),
+			},
+			wantOK: func(got ctrl.Result) bool {
+				return got.RequeueAfter == 0
+			},
+			wantTaintKeys: []string{clusterv1beta1.UnhealthyTaintKey},
+		},
+		{
+			name: "placement of the PickFixed placement type",
+			objs: []client.Object{
+				newMemberCluster(now.Add(-time.Hour), true, unhealthyTaint(now.Add(-time.Hour))),
+				newCRP(placementv1beta1.PickFixedPlacementType),
+				newBinding(),
+			},
+			wantOK: func(got ctrl.Result) bool {
+				return got.RequeueAfter == 0
+			},
+			wantTaintKeys: []string{clusterv1beta1.UnhealthyTaintKey},
+		},
+		{
+			name: "NoExecute taint added by the user on a healthy cluster",
+			objs: []client.Object{
+				newMemberCluster(now, true, userTaint),
+				newCRP(placementv1beta1.PickNPlacementType),
+				newBinding(),
+			},
+			wantOK: func(got ctrl.Result) bool {
+				return got.RequeueAfter == evictionRetryDelay
+			},
+			wantTaintKeys:    []string{userTaint.Key},
+			wantEviction:     true,
+			wantEventReasons: []string{taintEvictionStartedReason},
+		},
+		{
+			name: "eviction is executed",
+			objs: []client.Object{
+				newMemberCluster(now.Add(-time.Hour), true, unhealthyTaint(now.Add(-time.Hour))),
+				newCRP(placementv1beta1.PickNPlacementType),
+				newEviction(now.Add(-time.Second),
+					evictionCondition(placementv1beta1.PlacementEvictionConditionTypeValid, metav1.ConditionTrue, ""),
+					evictionCondition(placementv1beta1.PlacementEvictionConditionTypeExecuted, metav1.ConditionTrue, ""),
+				),
+			},
+			wantOK: func(got ctrl.Result) bool {
+				return got.RequeueAfter == 0
+			},
+			wantTaintKeys:    []string{clusterv1beta1.UnhealthyTaintKey},
+			wantEventReasons: []string{taintEvictionDoneReason},
+		},
+		{
+			name: "eviction is blocked by the disruption budget",
+			objs: []client.Object{
+				newMemberCluster(now.Add(-time.Hour), true, unhealthyTaint(now.Add(-time.Hour))),
+				newCRP(placementv1beta1.PickNPlacementType),
+				newBinding(),
+				newEviction(now.Add(-time.Second),
+					evictionCondition(placementv1beta1.PlacementEvictionConditionTypeValid, metav1.ConditionTrue, ""),
+					evictionCondition(placementv1beta1.PlacementEvictionConditionTypeExecuted, metav1.ConditionFalse, "disruption budget is not met"),
+				),
+			},
+			wantOK: func(got ctrl.Result) bool {
+				return got.RequeueAfter > 0 && got.RequeueAfter < evictionRetryDelay
+			},
+			wantTaintKeys: []string{clusterv1beta1.UnhealthyTaintKey},
+			wantEviction:  true,
+		},
+		{
+			name: "blocked eviction is retried",
+			objs: []client.Object{
+				newMemberCluster(now.Add(-time.Hour), true, unhealthyTaint(now.Add(-time.Hour))),
+				newCRP(placementv1beta1.PickNPlacementType),
+				newBinding(),
+				newEviction(now.Add(-2*evictionRetryDelay),
+					evictionCondition(placementv1beta1.PlacementEvictionConditionTypeValid, metav1.ConditionTrue, ""),
+					evictionCondition(placementv1beta1.PlacementEvictionConditionTypeExecuted, metav1.ConditionFalse, "disruption budget is not met"),
+				),
+			},
+			wantOK: func(got ctrl.Result) bool {
+				return got.RequeueAfter == evictionRetryDelay
+			},
+			wantTaintKeys:    []string{clusterv1beta1.UnhealthyTaintKey},
+			wantEviction:     true,
+			wantEventReasons: []string{taintEvictionBlockedReason, taintEvictionStartedReason},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.objs...).WithStatusSubresource(tc.objs...).Build()
+			recorder := record.NewFakeRecorder(10)
+			r := &Reconciler{
+				Client:                    fakeClient,
+				Recorder:                  recorder,
+				ClusterUnhealthyThreshold: unhealthyThreshold,
+				UnhealthyTaintDelay:       taintDelay,
+			}
+
+			got, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: mcName}})
+			if err != nil {
+				t.Fatalf("Reconcile() = %v, want no error", err)
+			}
+			if !tc.wantOK(got) {
+				t.Errorf("Reconcile() = %v, not as expected", got)
+			}
+
+			var mc clusterv1beta1.MemberCluster
+			if err := fakeClient.Get(ctx, types.NamespacedName{Name: mcName}, &mc); err != nil {
+				t.Fatalf("failed to get the member cluster: %v", err)
+			}
+			gotTaintKeys := make([]string, 0, len(mc.Spec.Taints))
+			for _, taint := range mc.Spec.Taints {
+				gotTaintKeys = append(gotTaintKeys, taint.Key)
+				if taint.Effect == corev1.TaintEffectNoExecute && taint.TimeAdded == nil {
+					t.Errorf("NoExecute taint %s has no time added", taint.Key)
+				}
+			}
+			if diff := cmp.Diff(gotTaintKeys, tc.wantTaintKeys, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("member cluster taints mismatch (-got, +want):\n%s", diff)
+			}
+
+			var evictionList placementv1beta1.ClusterResourcePlacementEvictionList
+			if err := fakeClient.List(ctx, &evictionList); err != nil {
+				t.Fatalf("failed to list the evictions: %v", err)
+			}
+			gotEviction := false
+			for _, eviction := range evictionList.Items {
+				if eviction.Name != evictionName || eviction.Labels[placementv1beta1.TaintEvictionClusterLabel] != mcName ||
+					eviction.Spec.PlacementName != crpName || eviction.Spec.ClusterName != mcName {
+					t.Errorf("unexpected eviction %+v", eviction)
+					continue
+				}
+				gotEviction = true
+			}
+			if gotEviction != tc.wantEviction {
+				t.Errorf("eviction found = %t, want %t", gotEviction, tc.wantEviction)
+			}
+
+			var gotEventReasons []string
+			for len(recorder.Events) > 0 {
+				event := <-recorder.Events
+				// The events are in the form of "<type> <reason> <message>".
+				gotEventReasons = append(gotEventReasons, strings.Fields(event)[1])
+			}
+			sort.Strings(gotEventReasons)
+			wantEventReasons := append([]string{}, tc.wantEventReasons...)
+			sort.Strings(wantEventReasons)
+			if diff := cmp.Diff(gotEventReasons, wantEventReasons, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("event reasons mismatch (-got, +want):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updaterun
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/condition"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+// isStageInCanaryPhase returns true if the stage has a canary phase that has not succeeded yet.
+func isStageInCanaryPhase(stageStatus *placementv1beta1.StageUpdatingStatus, generation int64) bool {
+	if stageStatus.CanaryStatus == nil {
+		return false
+	}
+	return !condition.IsConditionStatusTrue(meta.FindStatusCondition(stageStatus.CanaryStatus.Conditions, string(placementv1beta1.CanaryConditionSucceeded)), generation)
+}
+
+// handleCanaryCompletion handles the canary phase of a stage after all the canary clusters are updated.
+// It waits for the bake time to elapse and the after-canary tasks to complete before marking the canary phase as succeeded
+// so that the rest of the clusters in the stage can be updated.
+// Returns the wait time and any error encountered.
+func (r *Reconciler) handleCanaryCompletion(
+	ctx context.Context,
+	updatingStageIndex int,
+	updateRun placementv1beta1.UpdateRunObj,
+	updatingStageStatus *placementv1beta1.StageUpdatingStatus,
+) (time.Duration, error) {
+	updateRunRef := klog.KObj(updateRun)
+	updatingStage := &updateRun.GetUpdateRunStatus().UpdateStrategySnapshot.Stages[updatingStageIndex]
+	canaryStatus := updatingStageStatus.CanaryStatus
+	if updatingStage.Canary == nil {
+		unexpectedErr := controller.NewUnexpectedBehaviorError(fmt.Errorf("the stage `%s` has canary status but no canary config", updatingStage.Name))
+		klog.ErrorS(unexpectedErr, "Failed to find the canary config of the stage", "stage", updatingStage.Name, "updateRun", updateRunRef)
+		return 0, fmt.Errorf("%w: %s", errStagedUpdatedAborted, unexpectedErr.Error())
+	}
+
+	// All the canary clusters in the stage have been updated.
+	markUpdateRunWaiting(updateRun, fmt.Sprintf(condition.UpdateRunWaitingMessageFmt, "after-canary", updatingStageStatus.StageName))
+	markStageUpdatingWaiting(updatingStageStatus, updateRun.GetGeneration(), "All canary clusters in the stage are updated, waiting for the bake time and after-canary tasks to complete")
+	markCanaryClustersUpdated(canaryStatus, updateRun.GetGeneration())
+	klog.V(2).InfoS("The stage has finished updating all canary clusters", "stage", updatingStage.Name, "updateRun", updateRunRef)
+
+	// Check if the bake time has elapsed.
+	if !condition.IsConditionStatusTrue(meta.FindStatusCondition(canaryStatus.Conditions, string(placementv1beta1.CanaryConditionBakeTimeElapsed)), updateRun.GetGeneration()) {
+		if updatingStage.Canary.BakeTime != nil {
+			bakeStartTime := meta.FindStatusCondition(canaryStatus.Conditions, string(placementv1beta1.CanaryConditionClustersUpdated)).LastTransitionTime.Time
+			bakeWaitTime := time.Until(bakeStartTime.Add(updatingStage.Canary.BakeTime.Duration))
+			if bakeWaitTime > 0 {
+				klog.V(2).InfoS("The canary clusters are still baking", "bakeStartTime", bakeStartTime, "bakeTime", updatingStage.Canary.BakeTime, "stage", updatingStage.Name, "updateRun", updateRunRef)
+				return bakeWaitTime, nil
+			}
+		}
+		markCanaryBakeTimeElapsed(canaryStatus, updateRun.GetGeneration())
+		klog.V(2).InfoS("The bake time of the canary clusters has elapsed", "stage", updatingStage.Name, "updateRun", updateRunRef)
+	}
+
+	// Check if the after canary tasks are completed.
+	for i, task := range updatingStage.Canary.AfterCanaryTasks {
+		switch task.Type {
+		case placementv1beta1.StageTaskTypeApproval, placementv1beta1.StageTaskTypeHealthCheck:
+			approved, err := r.handleStageApprovalTask(ctx, &canaryStatus.AfterCanaryTaskStatus[i], updatingStage, updateRun, placementv1beta1.AfterCanaryTaskLabelValue, task.HealthCheck)
+			if err != nil {
+				return 0, err
+			}
+			if !approved {
+				if task.Type == placementv1beta1.StageTaskTypeHealthCheck {
+					return healthCheckInterval(task.HealthCheck), nil
+				}
+				return stageUpdatingWaitTime, nil
+			}
+		default:
+			// Approval and HealthCheck are the only supported after canary tasks.
+			unexpectedErr := controller.NewUnexpectedBehaviorError(fmt.Errorf("found unsupported task type in after canary tasks: %s", task.Type))
+			klog.ErrorS(unexpectedErr, "Task type is not supported in after canary tasks", "stage", updatingStage.Name, "updateRun", updateRunRef, "taskType", task.Type)
+			return 0, fmt.Errorf("%w: %s", errStagedUpdatedAborted, unexpectedErr.Error())
+		}
+	}
+
+	// The canary phase has succeeded, continue to update the rest of the clusters in the stage.
+	klog.V(2).InfoS("The canary phase of the stage has succeeded", "stage", updatingStage.Name, "updateRun", updateRunRef)
+	markCanarySucceeded(canaryStatus, updateRun.GetGeneration())
+	markStageUpdatingProgressStarted(updatingStageStatus, updateRun.GetGeneration())
+	markUpdateRunProgressing(updateRun)
+	// No need to wait to update the rest of the clusters.
+	return 0, nil
+}
+
+// markCanaryClustersUpdated marks the canary clusters as updated in memory.
+func markCanaryClustersUpdated(canaryStatus *placementv1beta1.CanaryStatus, generation int64) {
+	meta.SetStatusCondition(&canaryStatus.Conditions, metav1.Condition{
+		Type:               string(placementv1beta1.CanaryConditionClustersUpdated),
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             condition.StageCanaryClustersUpdatedReason,
+		Message:            "All canary clusters in the stage are updated",
+	})
+}
+
+// markCanaryBakeTimeElapsed marks the bake time of the canary clusters as elapsed in memory.
+func markCanaryBakeTimeElapsed(canaryStatus *placementv1beta1.CanaryStatus, generation int64) {
+	meta.SetStatusCondition(&canaryStatus.Conditions, metav1.Condition{
+		Type:               string(placementv1beta1.CanaryConditionBakeTimeElapsed),
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             condition.StageCanaryBakeTimeElapsedReason,
+		Message:            "Bake time elapsed",
+	})
+}
+
+// markCanarySucceeded marks the canary phase as succeeded in memory.
+func markCanarySucceeded(canaryStatus *placementv1beta1.CanaryStatus, generation int64) {
+	meta.SetStatusCondition(&canaryStatus.Conditions, metav1.Condition{
+		Type:               string(placementv1beta1.CanaryConditionSucceeded),
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             condition.StageCanarySucceededReason,
+		Message:            "The canary phase is completed successfully, updating the rest of the clusters in the stage",
+	})
+}
@@ -0,0 +1,357 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updaterun
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/condition"
+)
+
+const (
+	canaryTestUpdateRunName = "test-update-run"
+	canaryTestStageName     = "test-stage"
+)
+
+func TestCalculateCanaryClusterCount(t *testing.T) {
+	tests := []struct {
+		name         string
+		canary       *placementv1beta1.CanaryConfig
+		clusterCount int
+		wantCount    int
+		wantErr      bool
+	}{
+		{
+			name:         "default to one canary cluster",
+			canary:       &placementv1beta1.CanaryConfig{},
+			clusterCount: 5,
+			wantCount:    1,
+		},
+		{
+			name:         "integer value",
+			canary:       &placementv1beta1.CanaryConfig{Clusters: ptr.To(intstr.FromInt32(2))},
+			clusterCount: 5,
+			wantCount:    2,
+		},
+		{
+			name:         "integer value larger than the number of clusters",
+			canary:       &placementv1beta1.CanaryConfig{Clusters: ptr.To(intstr.FromInt32(10))},
+			clusterCount: 3,
+			wantCount:    3,
+		},
+		{
+			name:         "percentage value rounded down",
+			canary:       &placementv1beta1.CanaryConfig{Clusters: ptr.To(intstr.FromString("50%"))},
+			clusterCount: 5,
+			wantCount:    2,
+		},
+		{
+			name:         "percentage value rounded down to zero uses one cluster",
+			canary:       &placementv1beta1.CanaryConfig{Clusters: ptr.To(intstr.FromString("10%"))},
+			clusterCount: 5,
+			wantCount:    1,
+		},
+		{
+			name:         "invalid percentage value",
+			canary:       &placementv1beta1.CanaryConfig{Clusters: ptr.To(intstr.FromString("abc"))},
+			clusterCount: 5,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotCount, err := calculateCanaryClusterCount(tt.canary, tt.clusterCount)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("calculateCanaryClusterCount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if gotCount != tt.wantCount {
+				t.Errorf("calculateCanaryClusterCount() = %d, want %d", gotCount, tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestValidateAfterCanaryTask(t *testing.T) {
+	tests := []struct {
+		name    string
+		tasks   []placementv1beta1.StageTask
+		wantErr bool
+	}{
+		{
+			name: "valid approval task",
+			tasks: []placementv1beta1.StageTask{
+				{Type: placementv1beta1.StageTaskTypeApproval},
+			},
+		},
+		{
+			name: "valid health check task",
+			tasks: []placementv1beta1.StageTask{
+				{
+					Type: placementv1beta1.StageTaskTypeHealthCheck,
+					HealthCheck: &placementv1beta1.HealthCheckConfig{
+						Prometheus: &placementv1beta1.PrometheusHealthCheck{
+							Address:   "http://prometheus:9090",
+							Query:     "up",
+							Operator:  placementv1beta1.HealthCheckOperatorEqual,
+							Threshold: "1",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "timed wait task is not allowed",
+			tasks: []placementv1beta1.StageTask{
+				{Type: placementv1beta1.StageTaskTypeTimedWait, WaitTime: &metav1.Duration{Duration: time.Minute}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "approval task with wait time",
+			tasks: []placementv1beta1.StageTask{
+				{Type: placementv1beta1.StageTaskTypeApproval, WaitTime: &metav1.Duration{Duration: time.Minute}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "more than one task",
+			tasks: []placementv1beta1.StageTask{
+				{Type: placementv1beta1.StageTaskTypeApproval},
+				{Type: placementv1beta1.StageTaskTypeApproval},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateAfterCanaryTask(tt.tasks); (err != nil) != tt.wantErr {
+				t.Errorf("validateAfterCanaryTask() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIsStageInCanaryPhase(t *testing.T) {
+	tests := []struct {
+		name        string
+		stageStatus *placementv1beta1.StageUpdatingStatus
+		want        bool
+	}{
+		{
+			name:        "stage without canary",
+			stageStatus: &placementv1beta1.StageUpdatingStatus{},
+			want:        false,
+		},
+		{
+			name: "canary not succeeded",
+			stageStatus: &placementv1beta1.StageUpdatingStatus{
+				CanaryStatus: &placementv1beta1.CanaryStatus{Clusters: []string{"cluster-1"}},
+			},
+			want: true,
+		},
+		{
+			name: "canary succeeded",
+			stageStatus: &placementv1beta1.StageUpdatingStatus{
+				CanaryStatus: &placementv1beta1.CanaryStatus{
+					Clusters: []string{"cluster-1"},
+					Conditions: []metav1.Condition{
+						{
+							Type:               string(placementv1beta1.CanaryConditionSucceeded),
+							Status:             metav1.ConditionTrue,
+							ObservedGeneration: 1,
+						},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "canary succeeded in an older generation",
+			stageStatus: &placementv1beta1.StageUpdatingStatus{
+				CanaryStatus: &placementv1beta1.CanaryStatus{
+					Clusters: []string{"cluster-1"},
+					Conditions: []metav1.Condition{
+						{
+							Type:               string(placementv1beta1.CanaryConditionSucceeded),
+							Status:             metav1.ConditionTrue,
+							ObservedGeneration: 0,
+						},
+					},
+				},
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isStageInCanaryPhase(tt.stageStatus, 1); got != tt.want {
+				t.Errorf("isStageInCanaryPhase() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandleCanaryCompletion(t *testing.T) {
+	approvalRequestName := fmt.Sprintf(placementv1beta1.AfterCanaryApprovalTaskNameFmt, canaryTestUpdateRunName, canaryTestStageName)
+	tests := []struct {
+		name             string
+		canary           *placementv1beta1.CanaryConfig
+		canaryConditions []metav1.Condition
+		approvalRequest  *placementv1beta1.ClusterApprovalRequest
+		wantWaitTime     time.Duration
+		wantBaking       bool
+		wantConditions   []placementv1beta1.CanaryConditionType
+		wantSucceeded    bool
+	}{
+		{
+			name:           "no bake time and no tasks",
+			canary:         &placementv1beta1.CanaryConfig{},
+			wantConditions: []placementv1beta1.CanaryConditionType{placementv1beta1.CanaryConditionClustersUpdated, placementv1beta1.CanaryConditionBakeTimeElapsed, placementv1beta1.CanaryConditionSucceeded},
+			wantSucceeded:  true,
+		},
+		{
+			name:           "bake time not elapsed",
+			canary:         &placementv1beta1.CanaryConfig{BakeTime: &metav1.Duration{Duration: time.Hour}},
+			wantBaking:     true,
+			wantConditions: []placementv1beta1.CanaryConditionType{placementv1beta1.CanaryConditionClustersUpdated},
+		},
+		{
+			name:   "bake time elapsed",
+			canary: &placementv1beta1.CanaryConfig{BakeTime: &metav1.Duration{Duration: time.Minute}},
+			canaryConditions: []metav1.Condition{
+				{
+					Type:               string(placementv1beta1.CanaryConditionClustersUpdated),
+					Status:             metav1.ConditionTrue,
+					ObservedGeneration: 1,
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-2 * time.Minute)),
+				},
+			},
+			wantConditions: []placementv1beta1.CanaryConditionType{placementv1beta1.CanaryConditionClustersUpdated, placementv1beta1.CanaryConditionBakeTimeElapsed, placementv1beta1.CanaryConditionSucceeded},
+			wantSucceeded:  true,
+		},
+		{
+			name: "approval request created and waiting for approval",
+			canary: &placementv1beta1.CanaryConfig{
+				AfterCanaryTasks: []placementv1beta1.StageTask{{Type: placementv1beta1.StageTaskTypeApproval}},
+			},
+			wantWaitTime:   stageUpdatingWaitTime,
+			wantConditions: []placementv1beta1.CanaryConditionType{placementv1beta1.CanaryConditionClustersUpdated, placementv1beta1.CanaryConditionBakeTimeElapsed},
+		},
+		{
+			name: "approval request approved",
+			canary: &placementv1beta1.CanaryConfig{
+				AfterCanaryTasks: []placementv1beta1.StageTask{{Type: placementv1beta1.StageTaskTypeApproval}},
+			},
+			approvalRequest: &placementv1beta1.ClusterApprovalRequest{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: approvalRequestName,
+				},
+				Spec: placementv1beta1.ApprovalRequestSpec{
+					TargetUpdateRun: canaryTestUpdateRunName,
+					TargetStage:     canaryTestStageName,
+				},
+				Status: placementv1beta1.ApprovalRequestStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   string(placementv1beta1.ApprovalRequestConditionApproved),
+							Status: metav1.ConditionTrue,
+						},
+					},
+				},
+			},
+			wantConditions: []placementv1beta1.CanaryConditionType{placementv1beta1.CanaryConditionClustersUpdated, placementv1beta1.CanaryConditionBakeTimeElapsed, placementv1beta1.CanaryConditionSucceeded},
+			wantSucceeded:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			canaryStatus := &placementv1beta1.CanaryStatus{
+				Clusters:              []string{"cluster-1"},
+				AfterCanaryTaskStatus: make([]placementv1beta1.StageTaskStatus, len(tt.canary.AfterCanaryTasks)),
+				Conditions:            tt.canaryConditions,
+			}
+			for i, task := range tt.canary.AfterCanaryTasks {
+				canaryStatus.AfterCanaryTaskStatus[i] = placementv1beta1.StageTaskStatus{Type: task.Type, ApprovalRequestName: approvalRequestName}
+			}
+			updateRun := &placementv1beta1.ClusterStagedUpdateRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       canaryTestUpdateRunName,
+					Generation: 1,
+				},
+				Status: placementv1beta1.UpdateRunStatus{
+					UpdateStrategySnapshot: &placementv1beta1.UpdateStrategySpec{
+						Stages: []placementv1beta1.StageConfig{{Name: canaryTestStageName, Canary: tt.canary}},
+					},
+					StagesStatus: []placementv1beta1.StageUpdatingStatus{
+						{StageName: canaryTestStageName, CanaryStatus: canaryStatus},
+					},
+				},
+			}
+			objects := []client.Object{updateRun}
+			objectsWithStatus := []client.Object{updateRun}
+			if tt.approvalRequest != nil {
+				objects = append(objects, tt.approvalRequest)
+				objectsWithStatus = append(objectsWithStatus, tt.approvalRequest)
+			}
+			scheme := runtime.NewScheme()
+			_ = placementv1beta1.AddToScheme(scheme)
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(objects...).
+				WithStatusSubresource(objectsWithStatus...).
+				Build()
+			r := Reconciler{Client: fakeClient}
+
+			stageStatus := &updateRun.Status.StagesStatus[0]
+			gotWaitTime, err := r.handleCanaryCompletion(context.Background(), 0, updateRun, stageStatus)
+			if err != nil {
+				t.Fatalf("handleCanaryCompletion() got error: %v", err)
+			}
+			if tt.wantBaking {
+				if gotWaitTime <= 0 || gotWaitTime > tt.canary.BakeTime.Duration {
+					t.Errorf("handleCanaryCompletion() wait time = %v, want in (0, %v]", gotWaitTime, tt.canary.BakeTime.Duration)
+				}
+			} else if gotWaitTime != tt.wantWaitTime {
+				t.Errorf("handleCanaryCompletion() wait time = %v, want %v", gotWaitTime, tt.wantWaitTime)
+			}
+			if len(stageStatus.CanaryStatus.Conditions) != len(tt.wantConditions) {
+				t.Fatalf("handleCanaryCompletion() canary conditions = %v, want types %v", stageStatus.CanaryStatus.Conditions, tt.wantConditions)
+			}
+			for _, condType := range tt.wantConditions {
+				if !condition.IsConditionStatusTrue(meta.FindStatusCondition(stageStatus.CanaryStatus.Conditions, string(condType)), 1) {
+					t.Errorf("handleCanaryCompletion() canary condition %s is not true", condType)
+				}
+			}
+			progressing := meta.FindStatusCondition(stageStatus.Conditions, string(placementv1beta1.StageUpdatingConditionProgressing))
+			if gotSucceeded := condition.IsConditionStatusTrue(progressing, 1); gotSucceeded != tt.wantSucceeded {
+				t.Errorf("handleCanaryCompletion() stage progressing = %v, want %v", gotSucceeded, tt.wantSucceeded)
+			}
+		})
+	}
+}
@@ -21,9 +21,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -62,6 +64,14 @@ type Reconciler struct {
 	recorder record.EventRecorder
 	// the informer contains the cache for all the resources we need to check the resource scope.
 	InformerManager informer.Manager
+	// HealthCheckClient is the HTTP client used to evaluate the health queries of HealthCheck stage tasks;
+	// redirects are never followed. A client with a timeout of healthCheckQueryTimeout is used if it is not set.
+	HealthCheckClient *http.Client
+	// AllowedPrometheusAddresses are the base URLs of the Prometheus-compatible HTTP APIs which the health checks
+	// can query. Health checks against any other address fail without being evaluated.
+	AllowedPrometheusAddresses []string
+	// healthProbes evaluates the health checks of HealthCheck stage tasks in the background.
+	healthProbes healthProber
 }
 
 func (r *Reconciler) Reconcile(ctx context.Context, req runtime.Request) (runtime.Result, error) {
@@ -75,6 +85,10 @@ func (r *Reconciler) Reconcile(ctx context.Context, req runtime.Request) (runtim
 	updateRun, err := controller.FetchUpdateRunFromRequest(ctx, r.Client, req)
 	if err != nil {
 		klog.ErrorS(err, "Failed to get updateRun object", "updateRun", req.NamespacedName)
+		if apierrors.IsNotFound(err) {
+			// The updateRun is gone, e.g., its finalizer was removed by others; drop its health check results.
+			r.healthProbes.forgetUpdateRun(req.NamespacedName)
+		}
 		return runtime.Result{}, client.IgnoreNotFound(err)
 	}
 
@@ -138,7 +152,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, req runtime.Request) (runtim
 		// Check if the updateRun is finished.
 		finishedCond := meta.FindStatusCondition(updateRunStatus.Conditions, string(placementv1beta1.StagedUpdateRunConditionSucceeded))
 		if condition.IsConditionStatusTrue(finishedCond, updateRun.GetGeneration()) || condition.IsConditionStatusFalse(finishedCond, updateRun.GetGeneration()) {
+			if isRollbackInProgress(updateRun) {
+				return r.handleRollback(ctx, updateRun, state, runObjRef)
+			}
 			klog.V(2).InfoS("The updateRun is finished", "finishedSuccessfully", finishedCond.Status, "updateRun", runObjRef)
+			r.healthProbes.forgetUpdateRun(req.NamespacedName)
 			return runtime.Result{}, nil
 		}
 		var validateErr error
@@ -146,7 +164,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req runtime.Request) (runtim
 		if updatingStageIndex, toBeUpdatedBindings, toBeDeletedBindings, validateErr = r.validate(ctx, updateRun); validateErr != nil {
 			// errStagedUpdatedAborted cannot be retried.
 			if errors.Is(validateErr, errStagedUpdatedAborted) {
-				return runtime.Result{}, r.recordUpdateRunFailed(ctx, updateRun, validateErr.Error())
+				return r.handleUpdateRunAborted(ctx, updateRun, validateErr, runObjRef)
 			}
 			return runtime.Result{}, validateErr
 		}
@@ -168,7 +186,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req runtime.Request) (runtim
 		finished, waitTime, execErr := r.execute(ctx, updateRun, updatingStageIndex, toBeUpdatedBindings, toBeDeletedBindings)
 		if errors.Is(execErr, errStagedUpdatedAborted) {
 			// errStagedUpdatedAborted cannot be retried.
-			return runtime.Result{}, r.recordUpdateRunFailed(ctx, updateRun, execErr.Error())
+			return r.handleUpdateRunAborted(ctx, updateRun, execErr, runObjRef)
 		}
 
 		if finished {
@@ -183,7 +201,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req runtime.Request) (runtim
 		finished, waitTime, stopErr := r.stop(updateRun, updatingStageIndex, toBeUpdatedBindings, toBeDeletedBindings)
 		if errors.Is(stopErr, errStagedUpdatedAborted) {
 			// errStagedUpdatedAborted cannot be retried.
-			return runtime.Result{}, r.recordUpdateRunFailed(ctx, updateRun, stopErr.Error())
+			return r.handleUpdateRunAborted(ctx, updateRun, stopErr, runObjRef)
 		}
 
 		if finished {
@@ -236,6 +254,7 @@ func (r *Reconciler) handleDelete(ctx context.Context, updateRun placementv1beta
 		return false, 0, controller.NewAPIServerError(false, err)
 	}
 	klog.V(2).InfoS("Deleted all approvalRequests associated with the updateRun", "updateRun", runObjRef)
+	r.healthProbes.forgetUpdateRun(types.NamespacedName{Namespace: updateRun.GetNamespace(), Name: updateRun.GetName()})
 
 	// Delete the update run status metric.
 	hubmetrics.FleetUpdateRunStatusLastTimestampSeconds.DeletePartialMatch(prometheus.Labels{"namespace": updateRun.GetNamespace(), "name": updateRun.GetName()})
@@ -558,6 +577,13 @@ func updateAllStatusConditionsGeneration(updateRunStatus *placementv1beta1.Updat
 		// Update deletion stage conditions.
 		updateAllStageStatusConditionsGeneration(deletionStageStatus, generation)
 	}
+
+	// Update rollback stage conditions if it exists.
+	if updateRunStatus.RollbackStatus != nil {
+		for i := range updateRunStatus.RollbackStatus.StagesStatus {
+			updateAllStageStatusConditionsGeneration(&updateRunStatus.RollbackStatus.StagesStatus[i], generation)
+		}
+	}
 }
 
 // updateAllStageStatusConditionsGeneration updates all conditions' ObservedGeneration in the given stage status.
@@ -587,4 +613,16 @@ func updateAllStageStatusConditionsGeneration(stageStatus *placementv1beta1.Stag
 			stageStatus.Clusters[j].Conditions[k].ObservedGeneration = generation
 		}
 	}
+
+	// Update canary conditions and after canary task conditions.
+	if stageStatus.CanaryStatus != nil {
+		for j := range stageStatus.CanaryStatus.Conditions {
+			stageStatus.CanaryStatus.Conditions[j].ObservedGeneration = generation
+		}
+		for j := range stageStatus.CanaryStatus.AfterCanaryTaskStatus {
+			for k := range stageStatus.CanaryStatus.AfterCanaryTaskStatus[j].Conditions {
+				stageStatus.CanaryStatus.AfterCanaryTaskStatus[j].Conditions[k].ObservedGeneration = generation
+			}
+		}
+	}
 }
@@ -33,10 +33,12 @@ import (
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
 	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
 	bindingutils "go.goms.io/fleet/pkg/utils/binding"
 	"go.goms.io/fleet/pkg/utils/condition"
 	"go.goms.io/fleet/pkg/utils/controller"
+	"go.goms.io/fleet/pkg/utils/maintenancewindow"
 )
 
 var (
@@ -83,7 +85,7 @@ func (r *Reconciler) execute(
 		if !approved {
 			markStageUpdatingWaiting(updatingStageStatus, updateRun.GetGeneration(), "Not all before-stage tasks are completed, waiting for approval")
 			markUpdateRunWaiting(updateRun, fmt.Sprintf(condition.UpdateRunWaitingMessageFmt, "before-stage", updatingStageStatus.StageName))
-			return false, stageUpdatingWaitTime, nil
+			return false, beforeStageTasksWaitTime(&updateRunStatus.UpdateStrategySnapshot.Stages[updatingStageIndex]), nil
 		}
 		maxConcurrency, err := calculateMaxConcurrencyValue(updateRunStatus, updatingStageIndex)
 		if err != nil {
@@ -112,14 +114,14 @@ func (r *Reconciler) checkBeforeStageTasksStatus(ctx context.Context, updatingSt
 	updatingStageStatus := &updateRunStatus.StagesStatus[updatingStageIndex]
 	for i, task := range updatingStage.BeforeStageTasks {
 		switch task.Type {
-		case placementv1beta1.StageTaskTypeApproval:
-			approved, err := r.handleStageApprovalTask(ctx, &updatingStageStatus.BeforeStageTaskStatus[i], updatingStage, updateRun, placementv1beta1.BeforeStageTaskLabelValue)
+		case placementv1beta1.StageTaskTypeApproval, placementv1beta1.StageTaskTypeHealthCheck:
+			approved, err := r.handleStageApprovalTask(ctx, &updatingStageStatus.BeforeStageTaskStatus[i], updatingStage, updateRun, placementv1beta1.BeforeStageTaskLabelValue, task.HealthCheck)
 			if err != nil {
 				return false, err
 			}
-			return approved, nil // Ideally there should be only one approval task in before stage tasks.
+			return approved, nil // Ideally there should be only one approval or health check task in before stage tasks.
 		default:
-			// Approval is the only supported before stage task.
+			// Approval and HealthCheck are the only supported before stage tasks.
 			unexpectedErr := controller.NewUnexpectedBehaviorError(fmt.Errorf("found unsupported task type in before stage tasks: %s", task.Type))
 			klog.ErrorS(unexpectedErr, "Task type is not supported in before stage tasks", "stage", updatingStage.Name, "updateRun", updateRunRef, "taskType", task.Type)
 			return false, fmt.Errorf("%w: %s", errStagedUpdatedAborted, unexpectedErr.Error())
@@ -150,12 +152,26 @@ func (r *Reconciler) executeUpdatingStage(
 		toBeUpdatedBindingsMap[bindingSpec.TargetCluster] = binding
 	}
 
+	// A cluster only starts updating when the maintenance windows of the strategy, the stage and the cluster are all open.
+	strategyWindows := maintenancewindow.FromPlacementWindows(updateRunStatus.UpdateStrategySnapshot.MaintenanceWindows)
+	stageWindows := maintenancewindow.FromPlacementWindows(updateRunStatus.UpdateStrategySnapshot.Stages[updatingStageIndex].MaintenanceWindows)
+	var waitingForWindowClusterNames []string
+	var maintenanceWindowWaitTime time.Duration
+	now := time.Now()
+
+	// Only the canary clusters at the front of the stage are updated until the canary phase succeeds.
+	inCanaryPhase := isStageInCanaryPhase(updatingStageStatus, updateRun.GetGeneration())
+	toBeUpdatedClusterCount := len(updatingStageStatus.Clusters)
+	if inCanaryPhase {
+		toBeUpdatedClusterCount = min(len(updatingStageStatus.CanaryStatus.Clusters), toBeUpdatedClusterCount)
+	}
+
 	finishedClusterCount := 0
 	clusterUpdatingCount := 0
 	var stuckClusterNames []string
 	var clusterUpdateErrors []error
 	// Go through each cluster in the stage and check if it's updating/succeeded/failed.
-	for i := 0; i < len(updatingStageStatus.Clusters) && clusterUpdatingCount < maxConcurrency; i++ {
+	for i := 0; i < toBeUpdatedClusterCount && clusterUpdatingCount < maxConcurrency; i++ {
 		clusterStatus := &updatingStageStatus.Clusters[i]
 		clusterUpdateSucceededCond := meta.FindStatusCondition(clusterStatus.Conditions, string(placementv1beta1.ClusterUpdatingConditionSucceeded))
 		if condition.IsConditionStatusTrue(clusterUpdateSucceededCond, updateRun.GetGeneration()) {
@@ -177,6 +193,21 @@ func (r *Reconciler) executeUpdatingStage(
 		if !condition.IsConditionStatusTrue(clusterStartedCond, updateRun.GetGeneration()) {
 			// The cluster has not started updating yet.
 			if !isBindingSyncedWithClusterStatus(resourceSnapshotName, updateRun, binding, clusterStatus) {
+				open, waitTime, err := r.isMaintenanceWindowOpen(ctx, clusterStatus.ClusterName, now, strategyWindows, stageWindows)
+				if err != nil {
+					clusterUpdateErrors = append(clusterUpdateErrors, err)
+					continue
+				}
+				if !open {
+					// The cluster still occupies a slot so that the clusters are updated in order.
+					klog.V(2).InfoS("The cluster is waiting for the maintenance windows to open", "cluster", clusterStatus.ClusterName, "stage", updatingStageStatus.StageName, "updateRun", updateRunRef)
+					markClusterUpdatingWaitingForMaintenanceWindow(clusterStatus, updateRun.GetGeneration())
+					waitingForWindowClusterNames = append(waitingForWindowClusterNames, clusterStatus.ClusterName)
+					if waitTime > 0 && (maintenanceWindowWaitTime == 0 || waitTime < maintenanceWindowWaitTime) {
+						maintenanceWindowWaitTime = waitTime
+					}
+					continue
+				}
 				klog.V(2).InfoS("Found the first cluster that needs to be updated", "cluster", clusterStatus.ClusterName, "stage", updatingStageStatus.StageName, "updateRun", updateRunRef)
 				// The binding is not up-to-date with the cluster status.
 				bindingSpec := binding.GetBindingSpec()
@@ -191,7 +222,7 @@ func (r *Reconciler) executeUpdatingStage(
 					continue
 				}
 				klog.V(2).InfoS("Updated the status of a binding to bound", "binding", klog.KObj(binding), "cluster", clusterStatus.ClusterName, "stage", updatingStageStatus.StageName, "updateRun", updateRunRef)
-				if err := r.updateBindingRolloutStarted(ctx, binding, updateRun); err != nil {
+				if err := r.updateBindingRolloutStarted(ctx, binding, updateRun, updateRunSpec.ResourceSnapshotIndex); err != nil {
 					clusterUpdateErrors = append(clusterUpdateErrors, err)
 					continue
 				}
@@ -206,13 +237,13 @@ func (r *Reconciler) executeUpdatingStage(
 						continue
 					}
 					klog.V(2).InfoS("Updated the status of a binding to bound", "binding", klog.KObj(binding), "cluster", clusterStatus.ClusterName, "stage", updatingStageStatus.StageName, "updateRun", updateRunRef)
-					if err := r.updateBindingRolloutStarted(ctx, binding, updateRun); err != nil {
+					if err := r.updateBindingRolloutStarted(ctx, binding, updateRun, updateRunSpec.ResourceSnapshotIndex); err != nil {
 						clusterUpdateErrors = append(clusterUpdateErrors, err)
 						continue
 					}
 				} else if !condition.IsConditionStatusTrue(meta.FindStatusCondition(binding.GetBindingStatus().Conditions, string(placementv1beta1.ResourceBindingRolloutStarted)), binding.GetGeneration()) {
 					klog.V(2).InfoS("The binding is bound and up-to-date but the generation is updated by the scheduler, update rolloutStarted status again", "binding", klog.KObj(binding), "cluster", clusterStatus.ClusterName, "stage", updatingStageStatus.StageName, "updateRun", updateRunRef)
-					if err := r.updateBindingRolloutStarted(ctx, binding, updateRun); err != nil {
+					if err := r.updateBindingRolloutStarted(ctx, binding, updateRun, updateRunSpec.ResourceSnapshotIndex); err != nil {
 						clusterUpdateErrors = append(clusterUpdateErrors, err)
 						continue
 					}
@@ -261,6 +292,13 @@ func (r *Reconciler) executeUpdatingStage(
 			if timeElapsed > updateRunStuckThreshold {
 				klog.V(2).InfoS("Time waiting for cluster update to finish passes threshold, mark the update run as stuck", "time elapsed", timeElapsed, "threshold", updateRunStuckThreshold, "cluster", clusterStatus.ClusterName, "stage", updatingStageStatus.StageName, "updateRun", updateRunRef)
 				stuckClusterNames = append(stuckClusterNames, clusterStatus.ClusterName)
+				if stuckTimeout, ok := rollbackStuckTimeout(updateRun); ok && timeElapsed > updateRunStuckThreshold+stuckTimeout {
+					stuckErr := controller.NewUserError(fmt.Errorf("the cluster `%s` in the stage %s has been stuck updating for longer than the stuck timeout %s of the rollback policy",
+						clusterStatus.ClusterName, updatingStageStatus.StageName, stuckTimeout))
+					klog.ErrorS(stuckErr, "The cluster update is stuck for too long, failing the updateRun", "time elapsed", timeElapsed, "updateRun", updateRunRef)
+					markClusterUpdatingFailed(clusterStatus, updateRun.GetGeneration(), stuckErr.Error())
+					clusterUpdateErrors = append(clusterUpdateErrors, fmt.Errorf("%w: %s", errStagedUpdatedAborted, stuckErr.Error()))
+				}
 			}
 		}
 	}
@@ -274,6 +312,21 @@ func (r *Reconciler) executeUpdatingStage(
 		return 0, utilerrors.NewAggregate(clusterUpdateErrors)
 	}
 
+	if len(waitingForWindowClusterNames) > 0 && len(waitingForWindowClusterNames) == clusterUpdatingCount {
+		// None of the clusters can make progress until the maintenance windows open.
+		markStageUpdatingWaiting(updatingStageStatus, updateRun.GetGeneration(), "Waiting for the maintenance windows to open")
+		markUpdateRunWaiting(updateRun, fmt.Sprintf(condition.UpdateRunWaitingForMaintenanceWindowMessageFmt, generateStuckClustersString(waitingForWindowClusterNames), updatingStageStatus.StageName))
+		if maintenanceWindowWaitTime == 0 {
+			// None of the windows will open on its own, check again later in case the windows are changed.
+			return stageUpdatingWaitTime, nil
+		}
+		return maintenanceWindowWaitTime, nil
+	}
+
+	if inCanaryPhase && finishedClusterCount == toBeUpdatedClusterCount {
+		return r.handleCanaryCompletion(ctx, updatingStageIndex, updateRun, updatingStageStatus)
+	}
+
 	if finishedClusterCount == len(updatingStageStatus.Clusters) {
 		return r.handleStageCompletion(ctx, updatingStageIndex, updateRun, updatingStageStatus)
 	}
@@ -408,12 +461,25 @@ func (r *Reconciler) checkAfterStageTasksStatus(ctx context.Context, updatingSta
 				klog.V(2).InfoS("The after stage wait task has completed", "stage", updatingStage.Name, "updateRun", updateRunRef)
 			}
 		case placementv1beta1.StageTaskTypeApproval:
-			approved, err := r.handleStageApprovalTask(ctx, &updatingStageStatus.AfterStageTaskStatus[i], updatingStage, updateRun, placementv1beta1.AfterStageTaskLabelValue)
+			approved, err := r.handleStageApprovalTask(ctx, &updatingStageStatus.AfterStageTaskStatus[i], updatingStage, updateRun, placementv1beta1.AfterStageTaskLabelValue, nil)
+			if err != nil {
+				return false, -1, err
+			}
+			if !approved {
+				passed = false
+			}
+		case placementv1beta1.StageTaskTypeHealthCheck:
+			approved, err := r.handleStageApprovalTask(ctx, &updatingStageStatus.AfterStageTaskStatus[i], updatingStage, updateRun, placementv1beta1.AfterStageTaskLabelValue, task.HealthCheck)
 			if err != nil {
 				return false, -1, err
 			}
 			if !approved {
 				passed = false
+				// Recheck the health query after the interval unless a timed wait task needs to be rechecked earlier.
+				interval := healthCheckInterval(task.HealthCheck)
+				if afterStageWaitTime < 0 || interval < afterStageWaitTime {
+					afterStageWaitTime = interval
+				}
 			}
 		}
 	}
@@ -424,6 +490,8 @@ func (r *Reconciler) checkAfterStageTasksStatus(ctx context.Context, updatingSta
 }
 
 // handleStageApprovalTask handles the approval task logic for before or after stage tasks.
+// If healthCheck is set, the task is a health check task whose approval request is approved or rejected
+// by the controller based on the result of the health check instead of waiting for a user.
 // It returns true if the task is approved, false otherwise, and any error encountered.
 func (r *Reconciler) handleStageApprovalTask(
 	ctx context.Context,
@@ -431,6 +499,7 @@ func (r *Reconciler) handleStageApprovalTask(
 	updatingStage *placementv1beta1.StageConfig,
 	updateRun placementv1beta1.UpdateRunObj,
 	stageTaskType string,
+	healthCheck *placementv1beta1.HealthCheckConfig,
 ) (bool, error) {
 	updateRunRef := klog.KObj(updateRun)
 
@@ -442,6 +511,10 @@ func (r *Reconciler) handleStageApprovalTask(
 
 	// Check if the approval request has been created.
 	approvalRequest := buildApprovalRequestObject(types.NamespacedName{Name: stageTaskStatus.ApprovalRequestName, Namespace: updateRun.GetNamespace()}, updatingStage.Name, updateRun.GetName(), stageTaskType)
+	if healthCheck != nil {
+		// The label lets the webhook reject the manual approval of the request.
+		approvalRequest.GetLabels()[placementv1beta1.IsHealthCheckApprovalLabel] = "true"
+	}
 	requestRef := klog.KObj(approvalRequest)
 	if err := r.Client.Create(ctx, approvalRequest); err != nil {
 		if apierrors.IsAlreadyExists(err) {
@@ -457,9 +530,29 @@ func (r *Reconciler) handleStageApprovalTask(
 				klog.ErrorS(unexpectedErr, "Found an approval request targeting wrong stage", "approvalRequestTask", requestRef, "stage", updatingStage.Name, "updateRun", updateRunRef)
 				return false, fmt.Errorf("%w: %s", errStagedUpdatedAborted, unexpectedErr.Error())
 			}
+			if healthCheck != nil {
+				if err = r.gradeHealthCheckApprovalRequest(ctx, approvalRequest, healthCheck, updateRun); err != nil {
+					// retriable err
+					return false, err
+				}
+			}
 			approvalRequestStatus := approvalRequest.GetApprovalRequestStatus()
+			approvedCond := meta.FindStatusCondition(approvalRequestStatus.Conditions, string(placementv1beta1.ApprovalRequestConditionApproved))
+			if healthCheck != nil {
+				// The request is rejected by the controller when the health check fails.
+				if condition.IsConditionStatusFalse(approvedCond, approvalRequest.GetGeneration()) && approvedCond.Reason == condition.ApprovalRequestHealthCheckFailedReason {
+					failedErr := controller.NewUserError(fmt.Errorf("the health check of stage `%s` failed (%s): %s", updatingStage.Name, approvedCond.Reason, approvedCond.Message))
+					klog.ErrorS(failedErr, "The health check approval request has been rejected", "approvalRequestTask", requestRef, "stage", updatingStage.Name, "updateRun", updateRunRef)
+					return false, fmt.Errorf("%w: %s", errStagedUpdatedAborted, failedErr.Error())
+				}
+			}
 			approvalAccepted := condition.IsConditionStatusTrue(meta.FindStatusCondition(approvalRequestStatus.Conditions, string(placementv1beta1.ApprovalRequestConditionApprovalAccepted)), approvalRequest.GetGeneration())
-			approved := condition.IsConditionStatusTrue(meta.FindStatusCondition(approvalRequestStatus.Conditions, string(placementv1beta1.ApprovalRequestConditionApproved)), approvalRequest.GetGeneration())
+			approved := condition.IsConditionStatusTrue(approvedCond, approvalRequest.GetGeneration())
+			if healthCheck != nil && approved && approvedCond.Reason != condition.ApprovalRequestHealthCheckPassedReason {
+				// Only the controller can approve a health check approval request; ignore the approval from anyone else.
+				klog.V(2).InfoS("Ignoring the approval of the health check approval request which is not made by the controller", "reason", approvedCond.Reason, "approvalRequestTask", requestRef, "stage", updatingStage.Name, "updateRun", updateRunRef)
+				approved = false
+			}
 			if !approvalAccepted && !approved {
 				klog.V(2).InfoS("The approval request has not been approved yet", "approvalRequestTask", requestRef, "stage", updatingStage.Name, "updateRun", updateRunRef)
 				return false, nil
@@ -492,8 +585,8 @@ func (r *Reconciler) handleStageApprovalTask(
 	return true, nil
 }
 
-// updateBindingRolloutStarted updates the binding status to indicate the rollout has started.
-func (r *Reconciler) updateBindingRolloutStarted(ctx context.Context, binding placementv1beta1.BindingObj, updateRun placementv1beta1.UpdateRunObj) error {
+// updateBindingRolloutStarted updates the binding status to indicate the rollout of the given resource snapshot index has started.
+func (r *Reconciler) updateBindingRolloutStarted(ctx context.Context, binding placementv1beta1.BindingObj, updateRun placementv1beta1.UpdateRunObj, resourceSnapshotIndex string) error {
 	// first reset the condition to reflect the latest lastTransitionTime
 	binding.RemoveCondition(string(placementv1beta1.ResourceBindingRolloutStarted))
 	cond := metav1.Condition{
@@ -501,7 +594,7 @@ func (r *Reconciler) updateBindingRolloutStarted(ctx context.Context, binding pl
 		Status:             metav1.ConditionTrue,
 		ObservedGeneration: binding.GetGeneration(),
 		Reason:             condition.RolloutStartedReason,
-		Message:            fmt.Sprintf("Detected the new changes on the resources and started the rollout process, resourceSnapshotIndex: %s, updateRun: %s", updateRun.GetUpdateRunSpec().ResourceSnapshotIndex, updateRun.GetName()),
+		Message:            fmt.Sprintf("Detected the new changes on the resources and started the rollout process, resourceSnapshotIndex: %s, updateRun: %s", resourceSnapshotIndex, updateRun.GetName()),
 	}
 	binding.SetConditions(cond)
 	if err := r.Client.Status().Update(ctx, binding); err != nil {
@@ -531,6 +624,16 @@ func (r *Reconciler) updateApprovalRequestAccepted(ctx context.Context, appReq p
 	return nil
 }
 
+// beforeStageTasksWaitTime returns the time to wait before rechecking the before stage tasks of a stage.
+func beforeStageTasksWaitTime(updatingStage *placementv1beta1.StageConfig) time.Duration {
+	for _, task := range updatingStage.BeforeStageTasks {
+		if task.Type == placementv1beta1.StageTaskTypeHealthCheck {
+			return healthCheckInterval(task.HealthCheck)
+		}
+	}
+	return stageUpdatingWaitTime
+}
+
 // calculateMaxConcurrencyValue calculates the actual max concurrency value for a stage.
 // It converts the IntOrString maxConcurrency (which can be an integer or percentage) to an integer value
 // based on the total number of clusters in the stage. The value is rounded down with 1 at minimum.
@@ -560,6 +663,28 @@ func aggregateUpdateRunStatus(updateRun placementv1beta1.UpdateRunObj, stageName
 	}
 }
 
+// isMaintenanceWindowOpen checks if the given maintenance windows and the maintenance windows of the member cluster are all open.
+// If not, it also returns how long to wait for them to open.
+// Invalid maintenance windows on the member cluster are considered closed so that the update is held until they are fixed.
+func (r *Reconciler) isMaintenanceWindowOpen(ctx context.Context, clusterName string, now time.Time, windows ...[]maintenancewindow.Window) (bool, time.Duration, error) {
+	var cluster clusterv1beta1.MemberCluster
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: clusterName}, &cluster); err != nil {
+		if !apierrors.IsNotFound(err) {
+			klog.ErrorS(err, "Failed to get the member cluster", "memberCluster", clusterName)
+			return false, 0, controller.NewAPIServerError(true, err)
+		}
+		klog.V(2).InfoS("The member cluster is not found, skip checking its maintenance windows", "memberCluster", clusterName)
+	} else {
+		windows = append(windows, maintenancewindow.FromClusterWindows(cluster.Spec.MaintenanceWindows))
+	}
+	open, waitTime, err := maintenancewindow.AllOpen(now, windows...)
+	if err != nil {
+		klog.ErrorS(controller.NewUserError(err), "Found invalid maintenance windows, holding the update", "memberCluster", clusterName)
+		return false, 0, nil
+	}
+	return open, waitTime, nil
+}
+
 // isBindingSyncedWithClusterStatus checks if the binding is up-to-date with the cluster status.
 func isBindingSyncedWithClusterStatus(resourceSnapshotName string, updateRun placementv1beta1.UpdateRunObj, binding placementv1beta1.BindingObj, cluster *placementv1beta1.ClusterUpdatingStatus) bool {
 	bindingSpec := binding.GetBindingSpec()
@@ -791,6 +916,18 @@ func markClusterUpdatingStarted(clusterUpdatingStatus *placementv1beta1.ClusterU
 	})
 }
 
+// markClusterUpdatingWaitingForMaintenanceWindow marks the cluster updating status as not started because the
+// maintenance windows are closed in memory.
+func markClusterUpdatingWaitingForMaintenanceWindow(clusterUpdatingStatus *placementv1beta1.ClusterUpdatingStatus, generation int64) {
+	meta.SetStatusCondition(&clusterUpdatingStatus.Conditions, metav1.Condition{
+		Type:               string(placementv1beta1.ClusterUpdatingConditionStarted),
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             condition.ClusterUpdatingWaitingForMaintenanceWindowReason,
+		Message:            "Waiting for the maintenance windows to open",
+	})
+}
+
 // markClusterUpdatingSucceeded marks the cluster updating status as succeeded in memory.
 func markClusterUpdatingSucceeded(clusterUpdatingStatus *placementv1beta1.ClusterUpdatingStatus, generation int64) {
 	meta.SetStatusCondition(&clusterUpdatingStatus.Conditions, metav1.Condition{
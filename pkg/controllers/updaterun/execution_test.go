@@ -34,6 +34,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
 	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
 	"go.goms.io/fleet/pkg/utils/condition"
 )
@@ -779,6 +780,81 @@ func TestExecuteUpdatingStage_Error(t *testing.T) {
 			wantErr:         errors.New("cluster updating encountered an error at stage"),
 			wantWaitTime:    0,
 		},
+		{
+			name: "cluster stuck for longer than the stuck timeout of the rollback policy",
+			updateRun: &placementv1beta1.ClusterStagedUpdateRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "test-update-run",
+					Generation: 1,
+				},
+				Spec: placementv1beta1.UpdateRunSpec{
+					PlacementName:         "test-placement",
+					ResourceSnapshotIndex: "1",
+					RollbackPolicy: &placementv1beta1.RollbackPolicy{
+						Type:         placementv1beta1.RollbackTypeOnFailure,
+						StuckTimeout: &metav1.Duration{Duration: 10 * time.Minute},
+					},
+				},
+				Status: placementv1beta1.UpdateRunStatus{
+					ResourceSnapshotIndexUsed: "1",
+					StagesStatus: []placementv1beta1.StageUpdatingStatus{
+						{
+							StageName: "test-stage",
+							Clusters: []placementv1beta1.ClusterUpdatingStatus{
+								{
+									ClusterName: "cluster-1",
+									Conditions: []metav1.Condition{
+										{
+											Type:               string(placementv1beta1.ClusterUpdatingConditionStarted),
+											Status:             metav1.ConditionTrue,
+											ObservedGeneration: 1,
+											Reason:             condition.ClusterUpdatingStartedReason,
+											// Stuck for more than the stuck threshold (5 minutes) plus the stuck timeout.
+											LastTransitionTime: metav1.NewTime(time.Now().Add(-20 * time.Minute)),
+										},
+									},
+								},
+							},
+						},
+					},
+					UpdateStrategySnapshot: &placementv1beta1.UpdateStrategySpec{
+						Stages: []placementv1beta1.StageConfig{
+							{
+								Name:           "test-stage",
+								MaxConcurrency: &intstr.IntOrString{Type: intstr.Int, IntVal: 1},
+							},
+						},
+					},
+				},
+			},
+			bindings: []placementv1beta1.BindingObj{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "binding-1",
+						Generation: 1,
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						TargetCluster:        "cluster-1",
+						ResourceSnapshotName: "test-placement-1-snapshot",
+						State:                placementv1beta1.BindingStateBound,
+					},
+					Status: placementv1beta1.ResourceBindingStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:               string(placementv1beta1.ResourceBindingRolloutStarted),
+								Status:             metav1.ConditionTrue,
+								ObservedGeneration: 1,
+								Reason:             condition.RolloutStartedReason,
+							},
+						},
+					},
+				},
+			},
+			interceptorFunc: nil,
+			wantErr:         errors.New("has been stuck updating for longer than the stuck timeout 10m0s"),
+			wantAbortErr:    true,
+			wantWaitTime:    0,
+		},
 	}
 
 	for _, tt := range tests {
@@ -786,6 +862,7 @@ func TestExecuteUpdatingStage_Error(t *testing.T) {
 			ctx := context.Background()
 			scheme := runtime.NewScheme()
 			_ = placementv1beta1.AddToScheme(scheme)
+			_ = clusterv1beta1.AddToScheme(scheme)
 
 			var fakeClient client.Client
 			objs := make([]client.Object, len(tt.bindings))
@@ -0,0 +1,513 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updaterun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/condition"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+var (
+	// defaultHealthCheckInterval is the default time to wait between two evaluations of a health check.
+	defaultHealthCheckInterval = 30 * time.Second
+
+	// defaultHealthCheckTimeout is the default time a health check is allowed to stay unhealthy before it fails.
+	defaultHealthCheckTimeout = 10 * time.Minute
+
+	// healthCheckQueryTimeout is the timeout of a single health query.
+	// Put it as a variable for convenient testing.
+	healthCheckQueryTimeout = 10 * time.Second
+
+	// defaultHealthCheckClient is the HTTP client used to evaluate the health queries if none is configured.
+	defaultHealthCheckClient = &http.Client{Timeout: healthCheckQueryTimeout}
+)
+
+const (
+	// prometheusInstantQueryPath is the path of the instant query endpoint of the Prometheus HTTP API.
+	prometheusInstantQueryPath = "/api/v1/query"
+
+	// maxHealthCheckResponseBytes is the maximum size of a health query response the controller reads.
+	maxHealthCheckResponseBytes = 1 << 20
+)
+
+// prometheusQueryResponse is the subset of the Prometheus HTTP API query response the controller understands.
+type prometheusQueryResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	Data   struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// prometheusVectorSample is a single sample of an instant vector returned by the Prometheus HTTP API.
+type prometheusVectorSample struct {
+	Metric map[string]string `json:"metric"`
+	Value  []interface{}     `json:"value"`
+}
+
+// healthProbeKey identifies the approval request of a health check stage task.
+type healthProbeKey struct {
+	types.NamespacedName
+	uid types.UID
+	// updateRun is the update run which the approval request is created for.
+	updateRun types.NamespacedName
+}
+
+// healthProbeResult is the result of one evaluation of a health check.
+type healthProbeResult struct {
+	healthy bool
+	message string
+}
+
+// normalizePrometheusAddress returns the scheme, host and path of a Prometheus address in a canonical form,
+// so that the addresses of the health checks can be compared with the allowed addresses.
+func normalizePrometheusAddress(address string) (string, error) {
+	u, err := url.Parse(address)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("scheme %q is not http or https", u.Scheme)
+	}
+	if u.User != nil || u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("the address cannot have user info, a query or a fragment")
+	}
+	return strings.ToLower(u.Scheme) + "://" + strings.ToLower(u.Host) + strings.TrimSuffix(u.EscapedPath(), "/"), nil
+}
+
+// isPrometheusAddressAllowed returns whether a health check may query the given Prometheus address, i.e., the
+// address is one of the addresses allowed by the hub agent.
+func (r *Reconciler) isPrometheusAddressAllowed(address string) bool {
+	normalized, err := normalizePrometheusAddress(address)
+	if err != nil {
+		return false
+	}
+	for _, allowed := range r.AllowedPrometheusAddresses {
+		if allowedNormalized, err := normalizePrometheusAddress(allowed); err == nil && allowedNormalized == normalized {
+			return true
+		}
+	}
+	return false
+}
+
+// healthProber evaluates the health checks of health check stage tasks in the background, so that a slow or
+// unreachable metrics endpoint does not block the reconciliation of update runs.
+// The results are only kept in memory: after a restart, the health checks are evaluated again, and as the
+// timeouts of the health checks start from the creation of the approval requests, they are not extended.
+// Its zero value is ready to use.
+type healthProber struct {
+	mu sync.Mutex
+	// inFlight tracks the approval requests whose health checks are being evaluated.
+	inFlight map[healthProbeKey]bool
+	// results keeps the result of the last completed evaluation for each approval request until it is consumed.
+	results map[healthProbeKey]healthProbeResult
+}
+
+// probe returns the result of the last completed evaluation for the given approval request, if there is one
+// that has not been consumed yet, and starts a new evaluation in the background if none is in flight.
+// The evaluation runs with the given context, i.e., the context of the reconciliation which starts it, so that
+// it is cancelled when the controller stops.
+func (p *healthProber) probe(ctx context.Context, key healthProbeKey, evaluate func(ctx context.Context) (bool, string)) (healthProbeResult, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.inFlight == nil {
+		p.inFlight = make(map[healthProbeKey]bool)
+		p.results = make(map[healthProbeKey]healthProbeResult)
+	}
+	result, found := p.results[key]
+	delete(p.results, key)
+	if !p.inFlight[key] {
+		p.inFlight[key] = true
+		go func() {
+			healthy, message := evaluate(ctx)
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			// Drop the result if the approval request has been forgotten in the meantime.
+			if p.inFlight[key] {
+				delete(p.inFlight, key)
+				p.results[key] = healthProbeResult{healthy: healthy, message: message}
+			}
+		}()
+	}
+	return result, found
+}
+
+// forget drops the state kept for the given approval request.
+func (p *healthProber) forget(key healthProbeKey) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.inFlight, key)
+	delete(p.results, key)
+}
+
+// forgetUpdateRun drops the state kept for all the approval requests of the given update run.
+func (p *healthProber) forgetUpdateRun(updateRun types.NamespacedName) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for key := range p.inFlight {
+		if key.updateRun == updateRun {
+			delete(p.inFlight, key)
+		}
+	}
+	for key := range p.results {
+		if key.updateRun == updateRun {
+			delete(p.results, key)
+		}
+	}
+}
+
+// healthCheckInterval returns the interval between two evaluations of the given health check.
+func healthCheckInterval(healthCheck *placementv1beta1.HealthCheckConfig) time.Duration {
+	if healthCheck == nil || healthCheck.Interval == nil || healthCheck.Interval.Duration <= 0 {
+		return defaultHealthCheckInterval
+	}
+	return healthCheck.Interval.Duration
+}
+
+// healthCheckTimeout returns the time the given health check is allowed to stay unhealthy.
+func healthCheckTimeout(healthCheck *placementv1beta1.HealthCheckConfig) time.Duration {
+	if healthCheck == nil || healthCheck.Timeout == nil || healthCheck.Timeout.Duration <= 0 {
+		return defaultHealthCheckTimeout
+	}
+	return healthCheck.Timeout.Duration
+}
+
+// gradeHealthCheckApprovalRequest evaluates the health check of a stage task and approves or rejects
+// the approval request created for the task accordingly.
+// A Prometheus health check is evaluated in the background and the approval request is graded with the result
+// of the last completed evaluation; an availability health check is evaluated on every call.
+// The approval request is rejected right away if the health check queries an address which is not allowed.
+// It is left untouched if it has already been approved or rejected by the controller, if no evaluation has completed yet, or if
+// the health check is not passing yet but has not timed out.
+func (r *Reconciler) gradeHealthCheckApprovalRequest(
+	ctx context.Context,
+	approvalRequest placementv1beta1.ApprovalRequestObj,
+	healthCheck *placementv1beta1.HealthCheckConfig,
+	updateRun placementv1beta1.UpdateRunObj,
+) error {
+	requestRef := klog.KObj(approvalRequest)
+	updateRunRef := klog.KObj(updateRun)
+	approvalRequestStatus := approvalRequest.GetApprovalRequestStatus()
+	probeKey := healthProbeKey{
+		NamespacedName: types.NamespacedName{Namespace: approvalRequest.GetNamespace(), Name: approvalRequest.GetName()},
+		uid:            approvalRequest.GetUID(),
+		updateRun:      types.NamespacedName{Namespace: updateRun.GetNamespace(), Name: updateRun.GetName()},
+	}
+	if isHealthCheckGraded(meta.FindStatusCondition(approvalRequestStatus.Conditions, string(placementv1beta1.ApprovalRequestConditionApproved))) {
+		klog.V(2).InfoS("The health check approval request has already been graded", "approvalRequest", requestRef, "updateRun", updateRunRef)
+		r.healthProbes.forget(probeKey)
+		return nil
+	}
+
+	cond := metav1.Condition{
+		Type:               string(placementv1beta1.ApprovalRequestConditionApproved),
+		ObservedGeneration: approvalRequest.GetGeneration(),
+	}
+	if healthCheck.Prometheus != nil && !r.isPrometheusAddressAllowed(healthCheck.Prometheus.Address) {
+		// Never send requests to the addresses which are not allowed; the health check can never pass.
+		klog.V(2).InfoS("The health check queries an address which is not allowed", "address", healthCheck.Prometheus.Address, "approvalRequest", requestRef, "updateRun", updateRunRef)
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = condition.ApprovalRequestHealthCheckFailedReason
+		cond.Message = fmt.Sprintf("The Prometheus address %q is not one of the addresses allowed by the hub agent", healthCheck.Prometheus.Address)
+		return r.updateHealthCheckApprovalRequest(ctx, approvalRequest, cond, probeKey, updateRun)
+	}
+
+	var healthy bool
+	var message string
+	if healthCheck.Availability != nil {
+		// The availability check only reads the bindings from the cache, so it is evaluated right away.
+		var err error
+		if healthy, message, err = r.evaluateAvailabilityHealthCheck(ctx, healthCheck.Availability, updateRun); err != nil {
+			return err
+		}
+	} else {
+		result, found := r.healthProbes.probe(ctx, probeKey, func(ctx context.Context) (bool, string) {
+			return r.evaluateHealthCheck(ctx, healthCheck)
+		})
+		if !found {
+			klog.V(2).InfoS("The health check is being evaluated, will retry", "approvalRequest", requestRef, "updateRun", updateRunRef)
+			return nil
+		}
+		healthy, message = result.healthy, result.message
+	}
+	cond.Message = message
+	switch {
+	case healthy:
+		klog.V(2).InfoS("The health check has passed", "approvalRequest", requestRef, "updateRun", updateRunRef)
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = condition.ApprovalRequestHealthCheckPassedReason
+	case time.Since(approvalRequest.GetCreationTimestamp().Time) >= healthCheckTimeout(healthCheck):
+		klog.V(2).InfoS("The health check has not passed before the timeout", "message", message, "approvalRequest", requestRef, "updateRun", updateRunRef)
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = condition.ApprovalRequestHealthCheckFailedReason
+		cond.Message = fmt.Sprintf("The health check did not pass within %s: %s", healthCheckTimeout(healthCheck), message)
+	default:
+		klog.V(2).InfoS("The health check has not passed yet, will retry", "message", message, "approvalRequest", requestRef, "updateRun", updateRunRef)
+		return nil
+	}
+	return r.updateHealthCheckApprovalRequest(ctx, approvalRequest, cond, probeKey, updateRun)
+}
+
+// isHealthCheckGraded returns whether the Approved condition of a health check approval request is set by the
+// controller; an Approved condition set by anyone else is overwritten when the request is graded.
+func isHealthCheckGraded(approvedCond *metav1.Condition) bool {
+	return approvedCond != nil && (approvedCond.Reason == condition.ApprovalRequestHealthCheckPassedReason ||
+		approvedCond.Reason == condition.ApprovalRequestHealthCheckFailedReason)
+}
+
+// updateHealthCheckApprovalRequest sets the Approved condition of a health check approval request.
+func (r *Reconciler) updateHealthCheckApprovalRequest(
+	ctx context.Context,
+	approvalRequest placementv1beta1.ApprovalRequestObj,
+	cond metav1.Condition,
+	probeKey healthProbeKey,
+	updateRun placementv1beta1.UpdateRunObj,
+) error {
+	requestRef := klog.KObj(approvalRequest)
+	updateRunRef := klog.KObj(updateRun)
+	approvalRequestStatus := approvalRequest.GetApprovalRequestStatus()
+	meta.SetStatusCondition(&approvalRequestStatus.Conditions, cond)
+	if err := r.Client.Status().Update(ctx, approvalRequest); err != nil {
+		klog.ErrorS(err, "Failed to update the health check approval request status", "approvalRequest", requestRef, "condition", cond, "updateRun", updateRunRef)
+		return controller.NewUpdateIgnoreConflictError(err)
+	}
+	r.healthProbes.forget(probeKey)
+	klog.V(2).InfoS("Graded the health check approval request", "approvalRequest", requestRef, "condition", cond, "updateRun", updateRunRef)
+	return nil
+}
+
+// evaluateHealthCheck evaluates the health check once.
+// It returns whether the check passed and a human-readable message describing the result.
+// Errors encountered when querying are reported as a failing check so that they are retried until the timeout.
+func (r *Reconciler) evaluateHealthCheck(ctx context.Context, healthCheck *placementv1beta1.HealthCheckConfig) (bool, string) {
+	if healthCheck == nil || healthCheck.Prometheus == nil {
+		return false, "no Prometheus health query is specified"
+	}
+	promCheck := healthCheck.Prometheus
+	samples, err := r.queryPrometheus(ctx, promCheck)
+	if err != nil {
+		klog.ErrorS(err, "Failed to evaluate the health query", "address", promCheck.Address, "query", promCheck.Query)
+		return false, fmt.Sprintf("failed to evaluate the health query: %v", err)
+	}
+	return compareHealthCheckSamples(samples, promCheck.Operator, promCheck.Threshold)
+}
+
+// evaluateAvailabilityHealthCheck evaluates an availability health check against the bindings of the clusters which
+// the update run has updated so far.
+// It returns whether the check passed, a human-readable message describing the result, and any error encountered
+// when listing the bindings.
+func (r *Reconciler) evaluateAvailabilityHealthCheck(
+	ctx context.Context,
+	availability *placementv1beta1.AvailabilityHealthCheck,
+	updateRun placementv1beta1.UpdateRunObj,
+) (bool, string, error) {
+	updateRunRef := klog.KObj(updateRun)
+	updateRunSpec := updateRun.GetUpdateRunSpec()
+	updateRunStatus := updateRun.GetUpdateRunStatus()
+	// The parse error is ignored because the index is validated when the update run is initialized.
+	resourceIndex, _ := strconv.Atoi(updateRunStatus.ResourceSnapshotIndexUsed)
+	resourceSnapshotName := fmt.Sprintf(placementv1beta1.ResourceSnapshotNameFmt, updateRunSpec.PlacementName, resourceIndex)
+	var minAvailableDuration time.Duration
+	if availability.MinAvailableDuration != nil {
+		minAvailableDuration = availability.MinAvailableDuration.Duration
+	}
+
+	var updatedClusters []string
+	for i := range updateRunStatus.StagesStatus {
+		for _, clusterStatus := range updateRunStatus.StagesStatus[i].Clusters {
+			succeededCond := meta.FindStatusCondition(clusterStatus.Conditions, string(placementv1beta1.ClusterUpdatingConditionSucceeded))
+			if condition.IsConditionStatusTrue(succeededCond, updateRun.GetGeneration()) {
+				updatedClusters = append(updatedClusters, clusterStatus.ClusterName)
+			}
+		}
+	}
+	if len(updatedClusters) == 0 {
+		return true, "no cluster has been updated yet", nil
+	}
+
+	placementKey := types.NamespacedName{Name: updateRunSpec.PlacementName, Namespace: updateRun.GetNamespace()}
+	bindings, err := controller.ListBindingsFromKey(ctx, r.Client, placementKey, true)
+	if err != nil {
+		klog.ErrorS(err, "Failed to list the bindings of the placement", "placement", placementKey, "updateRun", updateRunRef)
+		return false, "", err
+	}
+	bindingsMap := make(map[string]placementv1beta1.BindingObj, len(bindings))
+	for _, binding := range bindings {
+		bindingsMap[binding.GetBindingSpec().TargetCluster] = binding
+	}
+
+	var unavailable []string
+	for _, clusterName := range updatedClusters {
+		binding, ok := bindingsMap[clusterName]
+		if !ok {
+			unavailable = append(unavailable, fmt.Sprintf("%s (no binding found)", clusterName))
+			continue
+		}
+		if binding.GetBindingSpec().ResourceSnapshotName != resourceSnapshotName {
+			unavailable = append(unavailable, fmt.Sprintf("%s (binding is not on resource snapshot %s)", clusterName, resourceSnapshotName))
+			continue
+		}
+		availCond := binding.GetCondition(string(placementv1beta1.ResourceBindingAvailable))
+		if !condition.IsConditionStatusTrue(availCond, binding.GetGeneration()) {
+			unavailable = append(unavailable, fmt.Sprintf("%s (resources are not available)", clusterName))
+			continue
+		}
+		if time.Since(availCond.LastTransitionTime.Time) < minAvailableDuration {
+			unavailable = append(unavailable, fmt.Sprintf("%s (resources have not been available for %s yet)", clusterName, minAvailableDuration))
+		}
+	}
+	if len(unavailable) > 0 {
+		return false, fmt.Sprintf("%d of %d updated clusters are not available: %s", len(unavailable), len(updatedClusters), strings.Join(unavailable, ", ")), nil
+	}
+	return true, fmt.Sprintf("all %d updated clusters are available", len(updatedClusters)), nil
+}
+
+// compareHealthCheckSamples checks whether every sample satisfies the comparison against the threshold.
+func compareHealthCheckSamples(samples []float64, operator placementv1beta1.HealthCheckOperator, threshold string) (bool, string) {
+	if len(samples) == 0 {
+		return false, "the health query returned no samples"
+	}
+	thresholdValue, err := strconv.ParseFloat(threshold, 64)
+	if err != nil {
+		return false, fmt.Sprintf("failed to parse the threshold %q: %v", threshold, err)
+	}
+	for _, sample := range samples {
+		var satisfied bool
+		switch operator {
+		case placementv1beta1.HealthCheckOperatorLessThan:
+			satisfied = sample < thresholdValue
+		case placementv1beta1.HealthCheckOperatorLessThanOrEqual:
+			satisfied = sample <= thresholdValue
+		case placementv1beta1.HealthCheckOperatorGreaterThan:
+			satisfied = sample > thresholdValue
+		case placementv1beta1.HealthCheckOperatorGreaterThanOrEqual:
+			satisfied = sample >= thresholdValue
+		case placementv1beta1.HealthCheckOperatorEqual:
+			satisfied = sample == thresholdValue
+		default:
+			return false, fmt.Sprintf("unsupported health check operator %q", operator)
+		}
+		if !satisfied {
+			return false, fmt.Sprintf("the health query returned sample %v, want %s %s", sample, operator, threshold)
+		}
+	}
+	return true, fmt.Sprintf("all %d sample(s) returned by the health query are %s %s", len(samples), operator, threshold)
+}
+
+// queryPrometheus runs an instant query against a Prometheus-compatible HTTP API and returns the sample values.
+func (r *Reconciler) queryPrometheus(ctx context.Context, promCheck *placementv1beta1.PrometheusHealthCheck) ([]float64, error) {
+	queryURL, err := url.Parse(strings.TrimSuffix(promCheck.Address, "/") + prometheusInstantQueryPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address %q: %w", promCheck.Address, err)
+	}
+	queryURL.RawQuery = url.Values{"query": []string{promCheck.Query}}.Encode()
+
+	ctx, cancel := context.WithTimeout(ctx, healthCheckQueryTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, queryURL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build the query request: %w", err)
+	}
+	httpClient := *defaultHealthCheckClient
+	if r.HealthCheckClient != nil {
+		httpClient = *r.HealthCheckClient
+	}
+	// Never follow the redirects, which could point the hub agent to the addresses which are not allowed.
+	httpClient.CheckRedirect = func(_ *http.Request, _ []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send the query request: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxHealthCheckResponseBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the query response: %w", err)
+	}
+
+	var queryResp prometheusQueryResponse
+	if err := json.Unmarshal(body, &queryResp); err != nil {
+		return nil, fmt.Errorf("failed to decode the query response (HTTP status %d): %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK || queryResp.Status != "success" {
+		return nil, fmt.Errorf("the query failed with HTTP status %d: %s", resp.StatusCode, queryResp.Error)
+	}
+
+	switch queryResp.Data.ResultType {
+	case "scalar":
+		var value []interface{}
+		if err := json.Unmarshal(queryResp.Data.Result, &value); err != nil {
+			return nil, fmt.Errorf("failed to decode the scalar result: %w", err)
+		}
+		sample, err := parsePrometheusSampleValue(value)
+		if err != nil {
+			return nil, err
+		}
+		return []float64{sample}, nil
+	case "vector":
+		var vector []prometheusVectorSample
+		if err := json.Unmarshal(queryResp.Data.Result, &vector); err != nil {
+			return nil, fmt.Errorf("failed to decode the vector result: %w", err)
+		}
+		samples := make([]float64, 0, len(vector))
+		for _, s := range vector {
+			sample, err := parsePrometheusSampleValue(s.Value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid sample for metric %v: %w", s.Metric, err)
+			}
+			samples = append(samples, sample)
+		}
+		return samples, nil
+	default:
+		return nil, fmt.Errorf("unsupported result type %q, want scalar or vector", queryResp.Data.ResultType)
+	}
+}
+
+// parsePrometheusSampleValue parses a [<unix_time>, "<value>"] pair returned by the Prometheus HTTP API.
+func parsePrometheusSampleValue(value []interface{}) (float64, error) {
+	if len(value) != 2 {
+		return 0, fmt.Errorf("malformed sample %v", value)
+	}
+	str, ok := value[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("malformed sample value %v", value[1])
+	}
+	sample, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse sample value %q: %w", str, err)
+	}
+	return sample, nil
+}
@@ -0,0 +1,704 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updaterun
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/condition"
+)
+
+// newFakeMetricsServer starts a fake Prometheus-compatible HTTP API server which answers every
+// instant query with the given status code and body.
+func newFakeMetricsServer(t *testing.T, statusCode int, body string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != prometheusInstantQueryPath || req.URL.Query().Get("query") == "" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestQueryPrometheus(t *testing.T) {
+	tests := []struct {
+		name        string
+		statusCode  int
+		body        string
+		wantSamples []float64
+		wantErrMsg  string
+	}{
+		{
+			name:        "scalar result",
+			statusCode:  http.StatusOK,
+			body:        `{"status":"success","data":{"resultType":"scalar","result":[1700000000.123,"0.5"]}}`,
+			wantSamples: []float64{0.5},
+		},
+		{
+			name:        "vector result",
+			statusCode:  http.StatusOK,
+			body:        `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"cluster":"a"},"value":[1700000000,"1"]},{"metric":{"cluster":"b"},"value":[1700000000,"2.5"]}]}}`,
+			wantSamples: []float64{1, 2.5},
+		},
+		{
+			name:        "empty vector result",
+			statusCode:  http.StatusOK,
+			body:        `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+			wantSamples: []float64{},
+		},
+		{
+			name:       "query error",
+			statusCode: http.StatusBadRequest,
+			body:       `{"status":"error","errorType":"bad_data","error":"parse error"}`,
+			wantErrMsg: "the query failed with HTTP status 400: parse error",
+		},
+		{
+			name:       "unsupported result type",
+			statusCode: http.StatusOK,
+			body:       `{"status":"success","data":{"resultType":"matrix","result":[]}}`,
+			wantErrMsg: "unsupported result type \"matrix\"",
+		},
+		{
+			name:       "malformed sample",
+			statusCode: http.StatusOK,
+			body:       `{"status":"success","data":{"resultType":"scalar","result":[1700000000,"NaN-ish"]}}`,
+			wantErrMsg: "failed to parse sample value",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeMetricsServer(t, tt.statusCode, tt.body)
+			r := Reconciler{}
+			got, err := r.queryPrometheus(context.Background(), &placementv1beta1.PrometheusHealthCheck{
+				Address: server.URL,
+				Query:   "sum(up)",
+			})
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("queryPrometheus() error = %v, want error containing %q", err, tt.wantErrMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("queryPrometheus() error = %v, want no error", err)
+			}
+			if diff := cmp.Diff(tt.wantSamples, got); diff != "" {
+				t.Errorf("queryPrometheus() samples mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestQueryPrometheus_Redirect(t *testing.T) {
+	target := newFakeMetricsServer(t, http.StatusOK, `{"status":"success","data":{"resultType":"scalar","result":[1700000000,"1"]}}`)
+	var redirected atomic.Bool
+	redirector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		redirected.Store(true)
+		http.Redirect(w, req, target.URL+req.URL.RequestURI(), http.StatusFound)
+	}))
+	t.Cleanup(redirector.Close)
+
+	r := Reconciler{}
+	if _, err := r.queryPrometheus(context.Background(), &placementv1beta1.PrometheusHealthCheck{Address: redirector.URL, Query: "sum(up)"}); err == nil {
+		t.Errorf("queryPrometheus() = nil, want error for a redirect")
+	}
+	if !redirected.Load() {
+		t.Errorf("queryPrometheus() did not send the request")
+	}
+}
+
+func TestIsPrometheusAddressAllowed(t *testing.T) {
+	r := Reconciler{AllowedPrometheusAddresses: []string{"http://prometheus.monitoring:9090", "https://metrics.example.com/prometheus/", "not a URL\x7f"}}
+	tests := []struct {
+		address string
+		want    bool
+	}{
+		{address: "http://prometheus.monitoring:9090", want: true},
+		{address: "http://Prometheus.Monitoring:9090/", want: true},
+		{address: "https://metrics.example.com/prometheus", want: true},
+		{address: "https://prometheus.monitoring:9090", want: false},
+		{address: "http://prometheus.monitoring:9091", want: false},
+		{address: "https://metrics.example.com/other", want: false},
+		{address: "https://metrics.example.com/prometheus?x=1", want: false},
+		{address: "http://user@prometheus.monitoring:9090", want: false},
+		{address: "http://169.254.169.254/latest/meta-data", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			if got := r.isPrometheusAddressAllowed(tt.address); got != tt.want {
+				t.Errorf("isPrometheusAddressAllowed(%q) = %t, want %t", tt.address, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareHealthCheckSamples(t *testing.T) {
+	tests := []struct {
+		name      string
+		samples   []float64
+		operator  placementv1beta1.HealthCheckOperator
+		threshold string
+		want      bool
+	}{
+		{
+			name:      "no samples",
+			operator:  placementv1beta1.HealthCheckOperatorLessThan,
+			threshold: "1",
+			want:      false,
+		},
+		{
+			name:      "less than, all samples pass",
+			samples:   []float64{0.01, 0.02},
+			operator:  placementv1beta1.HealthCheckOperatorLessThan,
+			threshold: "0.05",
+			want:      true,
+		},
+		{
+			name:      "less than, one sample fails",
+			samples:   []float64{0.01, 0.05},
+			operator:  placementv1beta1.HealthCheckOperatorLessThan,
+			threshold: "0.05",
+			want:      false,
+		},
+		{
+			name:      "less than or equal",
+			samples:   []float64{0.05},
+			operator:  placementv1beta1.HealthCheckOperatorLessThanOrEqual,
+			threshold: "0.05",
+			want:      true,
+		},
+		{
+			name:      "greater than",
+			samples:   []float64{3},
+			operator:  placementv1beta1.HealthCheckOperatorGreaterThan,
+			threshold: "3",
+			want:      false,
+		},
+		{
+			name:      "greater than or equal",
+			samples:   []float64{3, 4},
+			operator:  placementv1beta1.HealthCheckOperatorGreaterThanOrEqual,
+			threshold: "3",
+			want:      true,
+		},
+		{
+			name:      "equal",
+			samples:   []float64{1},
+			operator:  placementv1beta1.HealthCheckOperatorEqual,
+			threshold: "1",
+			want:      true,
+		},
+		{
+			name:      "invalid threshold",
+			samples:   []float64{1},
+			operator:  placementv1beta1.HealthCheckOperatorEqual,
+			threshold: "one",
+			want:      false,
+		},
+		{
+			name:      "unsupported operator",
+			samples:   []float64{1},
+			operator:  "NotEqual",
+			threshold: "1",
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, msg := compareHealthCheckSamples(tt.samples, tt.operator, tt.threshold)
+			if got != tt.want {
+				t.Errorf("compareHealthCheckSamples() = %v (message: %s), want %v", got, msg, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckAfterStageTasksStatus_HealthCheck(t *testing.T) {
+	stageName := "stage-0"
+	updateRunName := "test-health-check-update-run"
+	approvalRequestName := fmt.Sprintf(placementv1beta1.AfterStageHealthCheckTaskNameFmt, updateRunName, stageName)
+	healthyBody := `{"status":"success","data":{"resultType":"scalar","result":[1700000000,"0.01"]}}`
+	unhealthyBody := `{"status":"success","data":{"resultType":"scalar","result":[1700000000,"0.5"]}}`
+
+	tests := []struct {
+		name                 string
+		body                 string
+		requestCreationTime  time.Time
+		existingRequestConds []metav1.Condition
+		disallowAddress      bool
+		wantPassed           bool
+		wantWaitTime         time.Duration
+		wantApprovedCond     *metav1.Condition
+		wantErrAborted       bool
+	}{
+		{
+			name:                "healthy query approves the request",
+			body:                healthyBody,
+			requestCreationTime: time.Now(),
+			wantPassed:          true,
+			wantWaitTime:        0,
+			wantApprovedCond: &metav1.Condition{
+				Type:   string(placementv1beta1.ApprovalRequestConditionApproved),
+				Status: metav1.ConditionTrue,
+				Reason: condition.ApprovalRequestHealthCheckPassedReason,
+			},
+		},
+		{
+			name:                "unhealthy query within the timeout retries",
+			body:                unhealthyBody,
+			requestCreationTime: time.Now(),
+			wantPassed:          false,
+			wantWaitTime:        time.Second * 5,
+		},
+		{
+			name:                "query error within the timeout retries",
+			body:                `not json`,
+			requestCreationTime: time.Now(),
+			wantPassed:          false,
+			wantWaitTime:        time.Second * 5,
+		},
+		{
+			name:                "unhealthy query after the timeout rejects the request",
+			body:                unhealthyBody,
+			requestCreationTime: time.Now().Add(-time.Hour),
+			wantApprovedCond: &metav1.Condition{
+				Type:   string(placementv1beta1.ApprovalRequestConditionApproved),
+				Status: metav1.ConditionFalse,
+				Reason: condition.ApprovalRequestHealthCheckFailedReason,
+			},
+			wantErrAborted: true,
+		},
+		{
+			name:                "address which is not allowed rejects the request right away",
+			body:                healthyBody,
+			requestCreationTime: time.Now(),
+			disallowAddress:     true,
+			wantApprovedCond: &metav1.Condition{
+				Type:   string(placementv1beta1.ApprovalRequestConditionApproved),
+				Status: metav1.ConditionFalse,
+				Reason: condition.ApprovalRequestHealthCheckFailedReason,
+			},
+			wantErrAborted: true,
+		},
+		{
+			name:                "manual rejection is overwritten by the health check",
+			body:                healthyBody,
+			requestCreationTime: time.Now(),
+			existingRequestConds: []metav1.Condition{
+				{
+					Type:   string(placementv1beta1.ApprovalRequestConditionApproved),
+					Status: metav1.ConditionFalse,
+					Reason: "ManuallyRejected",
+				},
+			},
+			wantPassed:   true,
+			wantWaitTime: 0,
+			wantApprovedCond: &metav1.Condition{
+				Type:   string(placementv1beta1.ApprovalRequestConditionApproved),
+				Status: metav1.ConditionTrue,
+				Reason: condition.ApprovalRequestHealthCheckPassedReason,
+			},
+		},
+		{
+			name:                "manual approval is ignored while the health check is not passing",
+			body:                unhealthyBody,
+			requestCreationTime: time.Now(),
+			existingRequestConds: []metav1.Condition{
+				{
+					Type:   string(placementv1beta1.ApprovalRequestConditionApproved),
+					Status: metav1.ConditionTrue,
+					Reason: "ManuallyApproved",
+				},
+			},
+			wantPassed:   false,
+			wantWaitTime: time.Second * 5,
+			wantApprovedCond: &metav1.Condition{
+				Type:   string(placementv1beta1.ApprovalRequestConditionApproved),
+				Status: metav1.ConditionTrue,
+				Reason: "ManuallyApproved",
+			},
+		},
+		{
+			name:                "graded request is not re-evaluated",
+			body:                unhealthyBody,
+			requestCreationTime: time.Now().Add(-time.Hour),
+			existingRequestConds: []metav1.Condition{
+				{
+					Type:   string(placementv1beta1.ApprovalRequestConditionApproved),
+					Status: metav1.ConditionTrue,
+					Reason: condition.ApprovalRequestHealthCheckPassedReason,
+				},
+			},
+			wantPassed:   true,
+			wantWaitTime: 0,
+			wantApprovedCond: &metav1.Condition{
+				Type:   string(placementv1beta1.ApprovalRequestConditionApproved),
+				Status: metav1.ConditionTrue,
+				Reason: condition.ApprovalRequestHealthCheckPassedReason,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeMetricsServer(t, http.StatusOK, tt.body)
+			healthCheck := &placementv1beta1.HealthCheckConfig{
+				Prometheus: &placementv1beta1.PrometheusHealthCheck{
+					Address:   server.URL,
+					Query:     "sum(rate(http_requests_errors_total[5m]))",
+					Operator:  placementv1beta1.HealthCheckOperatorLessThan,
+					Threshold: "0.05",
+				},
+				Interval: &metav1.Duration{Duration: 5 * time.Second},
+				Timeout:  &metav1.Duration{Duration: 10 * time.Minute},
+			}
+			updateRun := &placementv1beta1.ClusterStagedUpdateRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: updateRunName,
+				},
+				Status: placementv1beta1.UpdateRunStatus{
+					UpdateStrategySnapshot: &placementv1beta1.UpdateStrategySpec{
+						Stages: []placementv1beta1.StageConfig{
+							{
+								Name: stageName,
+								AfterStageTasks: []placementv1beta1.StageTask{
+									{
+										Type:        placementv1beta1.StageTaskTypeHealthCheck,
+										HealthCheck: healthCheck,
+									},
+								},
+							},
+						},
+					},
+					StagesStatus: []placementv1beta1.StageUpdatingStatus{
+						{
+							StageName: stageName,
+							AfterStageTaskStatus: []placementv1beta1.StageTaskStatus{
+								{
+									Type:                placementv1beta1.StageTaskTypeHealthCheck,
+									ApprovalRequestName: approvalRequestName,
+								},
+							},
+						},
+					},
+				},
+			}
+			approvalRequest := buildApprovalRequestObject(client.ObjectKey{Name: approvalRequestName}, stageName, updateRunName, placementv1beta1.AfterStageTaskLabelValue)
+			approvalRequest.SetCreationTimestamp(metav1.NewTime(tt.requestCreationTime))
+			approvalRequest.GetApprovalRequestStatus().Conditions = tt.existingRequestConds
+
+			scheme := runtime.NewScheme()
+			_ = placementv1beta1.AddToScheme(scheme)
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(updateRun, approvalRequest).
+				WithStatusSubresource(updateRun, approvalRequest).
+				Build()
+			r := Reconciler{
+				Client:                     fakeClient,
+				HealthCheckClient:          server.Client(),
+				AllowedPrometheusAddresses: []string{server.URL + "/"},
+			}
+			if tt.disallowAddress {
+				r.AllowedPrometheusAddresses = []string{"http://prometheus.monitoring:9090"}
+			}
+
+			// The first check starts the evaluation of the health check in the background, and the second one grades
+			// the approval request with its result.
+			if _, _, err := r.checkAfterStageTasksStatus(context.Background(), 0, updateRun); err != nil && !tt.wantErrAborted {
+				t.Fatalf("checkAfterStageTasksStatus() error = %v, want no error", err)
+			}
+			waitForHealthProbes(t, &r.healthProbes)
+			gotPassed, gotWaitTime, err := r.checkAfterStageTasksStatus(context.Background(), 0, updateRun)
+			if tt.wantErrAborted {
+				if !errors.Is(err, errStagedUpdatedAborted) {
+					t.Fatalf("checkAfterStageTasksStatus() error = %v, want aborted error", err)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("checkAfterStageTasksStatus() error = %v, want no error", err)
+				}
+				if gotPassed != tt.wantPassed {
+					t.Errorf("checkAfterStageTasksStatus() passed = %v, want %v", gotPassed, tt.wantPassed)
+				}
+				if gotWaitTime != tt.wantWaitTime {
+					t.Errorf("checkAfterStageTasksStatus() waitTime = %v, want %v", gotWaitTime, tt.wantWaitTime)
+				}
+			}
+
+			gotRequest := &placementv1beta1.ClusterApprovalRequest{}
+			if err := fakeClient.Get(context.Background(), client.ObjectKey{Name: approvalRequestName}, gotRequest); err != nil {
+				t.Fatalf("failed to get the approval request: %v", err)
+			}
+			gotApprovedCond := meta.FindStatusCondition(gotRequest.Status.Conditions, string(placementv1beta1.ApprovalRequestConditionApproved))
+			if diff := cmp.Diff(tt.wantApprovedCond, gotApprovedCond, cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime", "ObservedGeneration", "Message")); diff != "" {
+				t.Errorf("approval request Approved condition mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// waitForHealthProbes waits until no health check evaluation is in flight.
+func waitForHealthProbes(t *testing.T, p *healthProber) {
+	t.Helper()
+	deadline := time.Now().Add(healthCheckQueryTimeout)
+	for time.Now().Before(deadline) {
+		p.mu.Lock()
+		inFlight := len(p.inFlight)
+		p.mu.Unlock()
+		if inFlight == 0 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("health check evaluations are still in flight after %s", healthCheckQueryTimeout)
+}
+
+func TestHealthProber(t *testing.T) {
+	key := healthProbeKey{NamespacedName: types.NamespacedName{Name: "test-request"}, uid: "test-uid", updateRun: types.NamespacedName{Name: "test-run"}}
+	release := make(chan struct{})
+	var evaluations atomic.Int32
+	evaluate := func(_ context.Context) (bool, string) {
+		<-release
+		return true, fmt.Sprintf("evaluation %d", evaluations.Add(1))
+	}
+
+	p := &healthProber{}
+	if _, found := p.probe(context.Background(), key, evaluate); found {
+		t.Fatalf("probe() found a result before any evaluation completed")
+	}
+	// No new evaluation is started while one is in flight.
+	if _, found := p.probe(context.Background(), key, evaluate); found {
+		t.Fatalf("probe() found a result while the evaluation is in flight")
+	}
+	close(release)
+	waitForHealthProbes(t, p)
+
+	result, found := p.probe(context.Background(), key, evaluate)
+	if !found {
+		t.Fatalf("probe() found no result after the evaluation completed")
+	}
+	if want := (healthProbeResult{healthy: true, message: "evaluation 1"}); result != want {
+		t.Errorf("probe() = %+v, want %+v", result, want)
+	}
+	// The result is consumed once returned, and the evaluation started above is dropped once forgotten.
+	p.forget(key)
+	waitForHealthProbes(t, p)
+	if _, found := p.probe(context.Background(), key, evaluate); found {
+		t.Errorf("probe() found a result of a forgotten approval request")
+	}
+	p.forget(key)
+}
+
+func TestHealthProber_ForgetUpdateRun(t *testing.T) {
+	run := types.NamespacedName{Namespace: "test-ns", Name: "test-run"}
+	otherRun := types.NamespacedName{Namespace: "test-ns", Name: "other-run"}
+	key := healthProbeKey{NamespacedName: types.NamespacedName{Namespace: "test-ns", Name: "request-0"}, uid: "uid-0", updateRun: run}
+	inFlightKey := healthProbeKey{NamespacedName: types.NamespacedName{Namespace: "test-ns", Name: "request-1"}, uid: "uid-1", updateRun: run}
+	otherKey := healthProbeKey{NamespacedName: types.NamespacedName{Namespace: "test-ns", Name: "request-2"}, uid: "uid-2", updateRun: otherRun}
+
+	p := &healthProber{}
+	done := func(_ context.Context) (bool, string) { return true, "healthy" }
+	p.probe(context.Background(), key, done)
+	p.probe(context.Background(), otherKey, done)
+	waitForHealthProbes(t, p)
+	// The evaluation in flight is bound to the context of the reconciliation which starts it.
+	ctx, cancel := context.WithCancel(context.Background())
+	p.probe(ctx, inFlightKey, func(ctx context.Context) (bool, string) {
+		<-ctx.Done()
+		return false, ctx.Err().Error()
+	})
+
+	p.forgetUpdateRun(run)
+	cancel()
+	waitForHealthProbes(t, p)
+	p.mu.Lock()
+	_, keyFound := p.results[key]
+	_, inFlightKeyFound := p.results[inFlightKey]
+	_, otherKeyFound := p.results[otherKey]
+	p.mu.Unlock()
+	if keyFound || inFlightKeyFound {
+		t.Errorf("forgetUpdateRun() kept the results of the update run")
+	}
+	if !otherKeyFound {
+		t.Errorf("forgetUpdateRun() dropped the result of another update run")
+	}
+}
+
+func TestEvaluateAvailabilityHealthCheck(t *testing.T) {
+	placementName := "test-placement"
+	generation := int64(2)
+	resourceSnapshotName := fmt.Sprintf(placementv1beta1.ResourceSnapshotNameFmt, placementName, 1)
+	succeededCluster := func(name string) placementv1beta1.ClusterUpdatingStatus {
+		return placementv1beta1.ClusterUpdatingStatus{
+			ClusterName: name,
+			Conditions: []metav1.Condition{
+				{
+					Type:               string(placementv1beta1.ClusterUpdatingConditionSucceeded),
+					Status:             metav1.ConditionTrue,
+					ObservedGeneration: generation,
+				},
+			},
+		}
+	}
+	binding := func(cluster, snapshotName string, availStatus metav1.ConditionStatus, availableSince time.Time) *placementv1beta1.ClusterResourceBinding {
+		return &placementv1beta1.ClusterResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "binding-" + cluster,
+				Generation: 1,
+				Labels: map[string]string{
+					placementv1beta1.PlacementTrackingLabel: placementName,
+				},
+			},
+			Spec: placementv1beta1.ResourceBindingSpec{
+				TargetCluster:        cluster,
+				ResourceSnapshotName: snapshotName,
+			},
+			Status: placementv1beta1.ResourceBindingStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:               string(placementv1beta1.ResourceBindingAvailable),
+						Status:             availStatus,
+						ObservedGeneration: 1,
+						LastTransitionTime: metav1.NewTime(availableSince),
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name                 string
+		clusters             []placementv1beta1.ClusterUpdatingStatus
+		bindings             []client.Object
+		minAvailableDuration *metav1.Duration
+		wantHealthy          bool
+		wantMessage          string
+	}{
+		{
+			name: "no updated cluster passes",
+			clusters: []placementv1beta1.ClusterUpdatingStatus{
+				{ClusterName: "cluster-1"},
+			},
+			wantHealthy: true,
+			wantMessage: "no cluster has been updated yet",
+		},
+		{
+			name:     "available updated clusters pass",
+			clusters: []placementv1beta1.ClusterUpdatingStatus{succeededCluster("cluster-1"), succeededCluster("cluster-2"), {ClusterName: "cluster-3"}},
+			bindings: []client.Object{
+				binding("cluster-1", resourceSnapshotName, metav1.ConditionTrue, time.Now().Add(-time.Hour)),
+				binding("cluster-2", resourceSnapshotName, metav1.ConditionTrue, time.Now().Add(-time.Hour)),
+			},
+			minAvailableDuration: &metav1.Duration{Duration: 10 * time.Minute},
+			wantHealthy:          true,
+			wantMessage:          "all 2 updated clusters are available",
+		},
+		{
+			name:     "unavailable updated cluster fails",
+			clusters: []placementv1beta1.ClusterUpdatingStatus{succeededCluster("cluster-1"), succeededCluster("cluster-2")},
+			bindings: []client.Object{
+				binding("cluster-1", resourceSnapshotName, metav1.ConditionTrue, time.Now().Add(-time.Hour)),
+				binding("cluster-2", resourceSnapshotName, metav1.ConditionFalse, time.Now().Add(-time.Hour)),
+			},
+			wantHealthy: false,
+			wantMessage: "1 of 2 updated clusters are not available: cluster-2 (resources are not available)",
+		},
+		{
+			name:     "recently available cluster fails",
+			clusters: []placementv1beta1.ClusterUpdatingStatus{succeededCluster("cluster-1")},
+			bindings: []client.Object{
+				binding("cluster-1", resourceSnapshotName, metav1.ConditionTrue, time.Now()),
+			},
+			minAvailableDuration: &metav1.Duration{Duration: 10 * time.Minute},
+			wantHealthy:          false,
+			wantMessage:          "1 of 1 updated clusters are not available: cluster-1 (resources have not been available for 10m0s yet)",
+		},
+		{
+			name:     "binding on another resource snapshot fails",
+			clusters: []placementv1beta1.ClusterUpdatingStatus{succeededCluster("cluster-1")},
+			bindings: []client.Object{
+				binding("cluster-1", fmt.Sprintf(placementv1beta1.ResourceSnapshotNameFmt, placementName, 0), metav1.ConditionTrue, time.Now().Add(-time.Hour)),
+			},
+			wantHealthy: false,
+			wantMessage: fmt.Sprintf("1 of 1 updated clusters are not available: cluster-1 (binding is not on resource snapshot %s)", resourceSnapshotName),
+		},
+		{
+			name:        "missing binding fails",
+			clusters:    []placementv1beta1.ClusterUpdatingStatus{succeededCluster("cluster-1")},
+			wantHealthy: false,
+			wantMessage: "1 of 1 updated clusters are not available: cluster-1 (no binding found)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updateRun := &placementv1beta1.ClusterStagedUpdateRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "test-update-run",
+					Generation: generation,
+				},
+				Spec: placementv1beta1.UpdateRunSpec{
+					PlacementName: placementName,
+				},
+				Status: placementv1beta1.UpdateRunStatus{
+					ResourceSnapshotIndexUsed: "1",
+					StagesStatus: []placementv1beta1.StageUpdatingStatus{
+						{
+							StageName: "stage-0",
+							Clusters:  tt.clusters,
+						},
+					},
+				},
+			}
+			scheme := runtime.NewScheme()
+			_ = placementv1beta1.AddToScheme(scheme)
+			r := Reconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.bindings...).Build(),
+			}
+			availability := &placementv1beta1.AvailabilityHealthCheck{MinAvailableDuration: tt.minAvailableDuration}
+			gotHealthy, gotMessage, err := r.evaluateAvailabilityHealthCheck(context.Background(), availability, updateRun)
+			if err != nil {
+				t.Fatalf("evaluateAvailabilityHealthCheck() error = %v, want no error", err)
+			}
+			if gotHealthy != tt.wantHealthy || gotMessage != tt.wantMessage {
+				t.Errorf("evaluateAvailabilityHealthCheck() = (%v, %q), want (%v, %q)", gotHealthy, gotMessage, tt.wantHealthy, tt.wantMessage)
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
 	"sort"
 	"strconv"
 	"strings"
@@ -28,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -37,6 +39,7 @@ import (
 	"go.goms.io/fleet/pkg/utils/condition"
 	"go.goms.io/fleet/pkg/utils/controller"
 	"go.goms.io/fleet/pkg/utils/defaulter"
+	"go.goms.io/fleet/pkg/utils/maintenancewindow"
 	"go.goms.io/fleet/pkg/utils/overrider"
 )
 
@@ -337,13 +340,31 @@ func (r *Reconciler) computeRunStageStatus(
 	// Map to track clusters and ensure they appear in one and only one stage.
 	allSelectedClusters := make(map[string]struct{}, len(scheduledBindings))
 	allPlacedClusters := make(map[string]struct{})
+	// The clusters whose bindings are only scheduled have no resources placed yet.
+	newlyScheduledClusters := make(map[string]bool)
 	for _, binding := range scheduledBindings {
 		allSelectedClusters[binding.GetBindingSpec().TargetCluster] = struct{}{}
+		if binding.GetBindingSpec().State == placementv1beta1.BindingStateScheduled {
+			newlyScheduledClusters[binding.GetBindingSpec().TargetCluster] = true
+		}
 	}
 	stagesStatus := make([]placementv1beta1.StageUpdatingStatus, 0, len(updateRunStatus.UpdateStrategySnapshot.Stages))
 
+	if err := validateMaintenanceWindows(updateRunStatus.UpdateStrategySnapshot.MaintenanceWindows); err != nil {
+		klog.ErrorS(err, "Failed to validate the maintenance windows", "updateStrategy", strategyKey, "updateRun", updateRunRef)
+		// no more retries here.
+		invalidWindowsErr := controller.NewUserError(fmt.Errorf("the maintenance windows are invalid, updateStrategy: `%s`, err: %s", strategyKey, err.Error()))
+		return fmt.Errorf("%w: %s", errValidationFailed, invalidWindowsErr.Error())
+	}
+
 	// Apply the label selectors from the UpdateStrategy to filter the clusters.
 	for _, stage := range updateRunStatus.UpdateStrategySnapshot.Stages {
+		if err := validateMaintenanceWindows(stage.MaintenanceWindows); err != nil {
+			klog.ErrorS(err, "Failed to validate the maintenance windows of the stage", "updateStrategy", strategyKey, "stageName", stage.Name, "updateRun", updateRunRef)
+			// no more retries here.
+			invalidWindowsErr := controller.NewUserError(fmt.Errorf("the maintenance windows are invalid, updateStrategy: `%s`, stage: %s, err: %s", strategyKey, stage.Name, err.Error()))
+			return fmt.Errorf("%w: %s", errValidationFailed, invalidWindowsErr.Error())
+		}
 		if err := validateBeforeStageTask(stage.BeforeStageTasks); err != nil {
 			klog.ErrorS(err, "Failed to validate the before stage tasks", "updateStrategy", strategyKey, "stageName", stage.Name, "updateRun", updateRunRef)
 			// no more retries here.
@@ -356,6 +377,14 @@ func (r *Reconciler) computeRunStageStatus(
 			invalidAfterStageErr := controller.NewUserError(fmt.Errorf("the after stage tasks are invalid, updateStrategy: `%s`, stage: %s, err: %s", strategyKey, stage.Name, err.Error()))
 			return fmt.Errorf("%w: %s", errValidationFailed, invalidAfterStageErr.Error())
 		}
+		if stage.Canary != nil {
+			if err := validateAfterCanaryTask(stage.Canary.AfterCanaryTasks); err != nil {
+				klog.ErrorS(err, "Failed to validate the after canary tasks", "updateStrategy", strategyKey, "stageName", stage.Name, "updateRun", updateRunRef)
+				// no more retries here.
+				invalidAfterCanaryErr := controller.NewUserError(fmt.Errorf("the after canary tasks are invalid, updateStrategy: `%s`, stage: %s, err: %s", strategyKey, stage.Name, err.Error()))
+				return fmt.Errorf("%w: %s", errValidationFailed, invalidAfterCanaryErr.Error())
+			}
+		}
 
 		curStageUpdatingStatus := placementv1beta1.StageUpdatingStatus{StageName: stage.Name}
 		var curStageClusters []clusterv1beta1.MemberCluster
@@ -422,23 +451,57 @@ func (r *Reconciler) computeRunStageStatus(
 		for i, cluster := range curStageClusters {
 			klog.V(2).InfoS("Adding a cluster to the stage", "cluster", cluster.Name, "updateStrategy", strategyKey, "stageName", stage.Name, "updateRun", updateRunRef)
 			curStageUpdatingStatus.Clusters[i].ClusterName = cluster.Name
+			curStageUpdatingStatus.Clusters[i].NewlyScheduled = newlyScheduledClusters[cluster.Name]
 		}
 
 		// Create the before stage tasks.
 		curStageUpdatingStatus.BeforeStageTaskStatus = make([]placementv1beta1.StageTaskStatus, len(stage.BeforeStageTasks))
 		for i, task := range stage.BeforeStageTasks {
 			curStageUpdatingStatus.BeforeStageTaskStatus[i].Type = task.Type
-			if task.Type == placementv1beta1.StageTaskTypeApproval {
+			switch task.Type {
+			case placementv1beta1.StageTaskTypeApproval:
 				curStageUpdatingStatus.BeforeStageTaskStatus[i].ApprovalRequestName = fmt.Sprintf(placementv1beta1.BeforeStageApprovalTaskNameFmt, updateRun.GetName(), stage.Name)
+			case placementv1beta1.StageTaskTypeHealthCheck:
+				curStageUpdatingStatus.BeforeStageTaskStatus[i].ApprovalRequestName = fmt.Sprintf(placementv1beta1.BeforeStageHealthCheckTaskNameFmt, updateRun.GetName(), stage.Name)
 			}
 		}
 		// Create the after stage tasks.
 		curStageUpdatingStatus.AfterStageTaskStatus = make([]placementv1beta1.StageTaskStatus, len(stage.AfterStageTasks))
 		for i, task := range stage.AfterStageTasks {
 			curStageUpdatingStatus.AfterStageTaskStatus[i].Type = task.Type
-			if task.Type == placementv1beta1.StageTaskTypeApproval {
+			switch task.Type {
+			case placementv1beta1.StageTaskTypeApproval:
 				curStageUpdatingStatus.AfterStageTaskStatus[i].ApprovalRequestName = fmt.Sprintf(placementv1beta1.AfterStageApprovalTaskNameFmt, updateRun.GetName(), stage.Name)
+			case placementv1beta1.StageTaskTypeHealthCheck:
+				curStageUpdatingStatus.AfterStageTaskStatus[i].ApprovalRequestName = fmt.Sprintf(placementv1beta1.AfterStageHealthCheckTaskNameFmt, updateRun.GetName(), stage.Name)
+			}
+		}
+		// Create the canary status.
+		if stage.Canary != nil && len(curStageClusters) > 0 {
+			canaryClusterCount, err := calculateCanaryClusterCount(stage.Canary, len(curStageClusters))
+			if err != nil {
+				klog.ErrorS(err, "Failed to calculate the number of canary clusters", "updateStrategy", strategyKey, "stageName", stage.Name, "updateRun", updateRunRef)
+				// no more retries here.
+				invalidCanaryErr := controller.NewUserError(fmt.Errorf("the canary clusters are invalid, updateStrategy: `%s`, stage: %s, err: %s", strategyKey, stage.Name, err.Error()))
+				return fmt.Errorf("%w: %s", errValidationFailed, invalidCanaryErr.Error())
+			}
+			canaryStatus := &placementv1beta1.CanaryStatus{
+				Clusters:              make([]string, canaryClusterCount),
+				AfterCanaryTaskStatus: make([]placementv1beta1.StageTaskStatus, len(stage.Canary.AfterCanaryTasks)),
 			}
+			for i := range canaryClusterCount {
+				canaryStatus.Clusters[i] = curStageClusters[i].Name
+			}
+			for i, task := range stage.Canary.AfterCanaryTasks {
+				canaryStatus.AfterCanaryTaskStatus[i].Type = task.Type
+				switch task.Type {
+				case placementv1beta1.StageTaskTypeApproval:
+					canaryStatus.AfterCanaryTaskStatus[i].ApprovalRequestName = fmt.Sprintf(placementv1beta1.AfterCanaryApprovalTaskNameFmt, updateRun.GetName(), stage.Name)
+				case placementv1beta1.StageTaskTypeHealthCheck:
+					canaryStatus.AfterCanaryTaskStatus[i].ApprovalRequestName = fmt.Sprintf(placementv1beta1.AfterCanaryHealthCheckTaskNameFmt, updateRun.GetName(), stage.Name)
+				}
+			}
+			curStageUpdatingStatus.CanaryStatus = canaryStatus
 		}
 		stagesStatus = append(stagesStatus, curStageUpdatingStatus)
 	}
@@ -469,11 +532,14 @@ func validateBeforeStageTask(tasks []placementv1beta1.StageTask) error {
 		return fmt.Errorf("beforeStageTasks can have at most one task")
 	}
 	for i, task := range tasks {
-		if task.Type != placementv1beta1.StageTaskTypeApproval {
-			return fmt.Errorf("task %d of type %s is not allowed in beforeStageTasks, allowed type: Approval", i, task.Type)
+		if task.Type != placementv1beta1.StageTaskTypeApproval && task.Type != placementv1beta1.StageTaskTypeHealthCheck {
+			return fmt.Errorf("task %d of type %s is not allowed in beforeStageTasks, allowed types: Approval, HealthCheck", i, task.Type)
 		}
 		if task.WaitTime != nil {
-			return fmt.Errorf("task %d of type Approval cannot have wait duration set", i)
+			return fmt.Errorf("task %d of type %s cannot have wait duration set", i, task.Type)
+		}
+		if err := validateStageTaskHealthCheck(i, task); err != nil {
+			return err
 		}
 	}
 	return nil
@@ -482,8 +548,12 @@ func validateBeforeStageTask(tasks []placementv1beta1.StageTask) error {
 // validateAfterStageTask validates the afterStageTasks in the stage defined in the UpdateStrategy.
 // The error returned from this function is not retriable.
 func validateAfterStageTask(tasks []placementv1beta1.StageTask) error {
-	if len(tasks) == 2 && tasks[0].Type == tasks[1].Type {
-		return fmt.Errorf("afterStageTasks cannot have two tasks of the same type: %s", tasks[0].Type)
+	seenTypes := make(map[placementv1beta1.StageTaskType]bool, len(tasks))
+	for _, task := range tasks {
+		if seenTypes[task.Type] {
+			return fmt.Errorf("afterStageTasks cannot have two tasks of the same type: %s", task.Type)
+		}
+		seenTypes[task.Type] = true
 	}
 	for i, task := range tasks {
 		if task.Type == placementv1beta1.StageTaskTypeTimedWait {
@@ -494,6 +564,89 @@ func validateAfterStageTask(tasks []placementv1beta1.StageTask) error {
 				return fmt.Errorf("task %d of type TimedWait has wait duration <= 0", i)
 			}
 		}
+		if task.Type == placementv1beta1.StageTaskTypeHealthCheck && task.WaitTime != nil {
+			return fmt.Errorf("task %d of type HealthCheck cannot have wait duration set", i)
+		}
+		if err := validateStageTaskHealthCheck(i, task); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateAfterCanaryTask validates the afterCanaryTasks in the canary phase of the stage defined in the UpdateStrategy.
+// The error returned from this function is not retriable.
+func validateAfterCanaryTask(tasks []placementv1beta1.StageTask) error {
+	if len(tasks) > 1 {
+		return fmt.Errorf("afterCanaryTasks can have at most one task")
+	}
+	for i, task := range tasks {
+		if task.Type != placementv1beta1.StageTaskTypeApproval && task.Type != placementv1beta1.StageTaskTypeHealthCheck {
+			return fmt.Errorf("task %d of type %s is not allowed in afterCanaryTasks, allowed types: Approval, HealthCheck", i, task.Type)
+		}
+		if task.WaitTime != nil {
+			return fmt.Errorf("task %d of type %s cannot have wait duration set", i, task.Type)
+		}
+		if err := validateStageTaskHealthCheck(i, task); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateMaintenanceWindows validates the maintenance windows defined in the UpdateStrategy.
+// The error returned from this function is not retriable.
+func validateMaintenanceWindows(windows []placementv1beta1.MaintenanceWindow) error {
+	for i, w := range maintenancewindow.FromPlacementWindows(windows) {
+		if err := maintenancewindow.Validate(w); err != nil {
+			return fmt.Errorf("maintenance window %d is invalid: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// calculateCanaryClusterCount calculates the number of canary clusters in a stage with the given number of clusters.
+// It converts the IntOrString canary clusters (which can be an integer or percentage) to an integer value.
+// The value is rounded down with 1 at minimum and the number of clusters in the stage at maximum.
+func calculateCanaryClusterCount(canary *placementv1beta1.CanaryConfig, clusterCount int) (int, error) {
+	canaryClusters := intstr.FromInt32(1)
+	if canary.Clusters != nil {
+		canaryClusters = *canary.Clusters
+	}
+	canaryClusterCount, err := intstr.GetScaledValueFromIntOrPercent(&canaryClusters, clusterCount, false)
+	if err != nil {
+		return 0, err
+	}
+	if canaryClusterCount < 1 {
+		canaryClusterCount = 1
+	}
+	return min(canaryClusterCount, clusterCount), nil
+}
+
+// validateStageTaskHealthCheck validates the health check configuration of a before or after stage task.
+// The error returned from this function is not retriable.
+func validateStageTaskHealthCheck(index int, task placementv1beta1.StageTask) error {
+	if task.Type != placementv1beta1.StageTaskTypeHealthCheck {
+		if task.HealthCheck != nil {
+			return fmt.Errorf("task %d of type %s cannot have health check set", index, task.Type)
+		}
+		return nil
+	}
+	if task.HealthCheck == nil || (task.HealthCheck.Prometheus == nil) == (task.HealthCheck.Availability == nil) {
+		return fmt.Errorf("task %d of type HealthCheck must have exactly one of prometheus and availability set", index)
+	}
+	if task.HealthCheck.Availability != nil {
+		return nil
+	}
+	promCheck := task.HealthCheck.Prometheus
+	if _, err := url.ParseRequestURI(promCheck.Address); err != nil {
+		return fmt.Errorf("task %d of type HealthCheck has an invalid address: %w", index, err)
+	}
+	if len(promCheck.Query) == 0 {
+		return fmt.Errorf("task %d of type HealthCheck has an empty query", index)
+	}
+	if _, err := strconv.ParseFloat(promCheck.Threshold, 64); err != nil {
+		return fmt.Errorf("task %d of type HealthCheck has an invalid threshold: %w", index, err)
 	}
 	return nil
 }
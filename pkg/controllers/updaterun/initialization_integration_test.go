@@ -977,21 +977,21 @@ func generateSucceededInitializationStatus(
 			{
 				StageName: "stage1",
 				Clusters: []placementv1beta1.ClusterUpdatingStatus{
-					{ClusterName: "cluster-9", ClusterResourceOverrideSnapshots: []string{clusterResourceOverride.Name}},
-					{ClusterName: "cluster-7", ClusterResourceOverrideSnapshots: []string{clusterResourceOverride.Name}},
-					{ClusterName: "cluster-5", ClusterResourceOverrideSnapshots: []string{clusterResourceOverride.Name}},
-					{ClusterName: "cluster-3", ClusterResourceOverrideSnapshots: []string{clusterResourceOverride.Name}},
-					{ClusterName: "cluster-1", ClusterResourceOverrideSnapshots: []string{clusterResourceOverride.Name}},
+					{ClusterName: "cluster-9", ClusterResourceOverrideSnapshots: []string{clusterResourceOverride.Name}, NewlyScheduled: true},
+					{ClusterName: "cluster-7", ClusterResourceOverrideSnapshots: []string{clusterResourceOverride.Name}, NewlyScheduled: true},
+					{ClusterName: "cluster-5", ClusterResourceOverrideSnapshots: []string{clusterResourceOverride.Name}, NewlyScheduled: true},
+					{ClusterName: "cluster-3", ClusterResourceOverrideSnapshots: []string{clusterResourceOverride.Name}, NewlyScheduled: true},
+					{ClusterName: "cluster-1", ClusterResourceOverrideSnapshots: []string{clusterResourceOverride.Name}, NewlyScheduled: true},
 				},
 			},
 			{
 				StageName: "stage2",
 				Clusters: []placementv1beta1.ClusterUpdatingStatus{
-					{ClusterName: "cluster-0"},
-					{ClusterName: "cluster-2"},
-					{ClusterName: "cluster-4"},
-					{ClusterName: "cluster-6"},
-					{ClusterName: "cluster-8"},
+					{ClusterName: "cluster-0", NewlyScheduled: true},
+					{ClusterName: "cluster-2", NewlyScheduled: true},
+					{ClusterName: "cluster-4", NewlyScheduled: true},
+					{ClusterName: "cluster-6", NewlyScheduled: true},
+					{ClusterName: "cluster-8", NewlyScheduled: true},
 				},
 			},
 		},
@@ -1050,9 +1050,9 @@ func generateSucceededInitializationStatusForSmallClusters(
 			{
 				StageName: "stage1",
 				Clusters: []placementv1beta1.ClusterUpdatingStatus{
-					{ClusterName: "cluster-0"},
-					{ClusterName: "cluster-1"},
-					{ClusterName: "cluster-2"},
+					{ClusterName: "cluster-0", NewlyScheduled: true},
+					{ClusterName: "cluster-1", NewlyScheduled: true},
+					{ClusterName: "cluster-2", NewlyScheduled: true},
 				},
 			},
 		},
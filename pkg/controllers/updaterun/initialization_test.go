@@ -65,7 +65,7 @@ func TestValidateBeforeStageTask(t *testing.T) {
 				},
 			},
 			wantErr:    true,
-			wantErrMsg: fmt.Sprintf("task %d of type %s is not allowed in beforeStageTasks, allowed type: Approval", 0, placementv1beta1.StageTaskTypeTimedWait),
+			wantErrMsg: fmt.Sprintf("task %d of type %s is not allowed in beforeStageTasks, allowed types: Approval, HealthCheck", 0, placementv1beta1.StageTaskTypeTimedWait),
 		},
 		{
 			name: "invalid BeforeTasks, with duration for Approval",
@@ -78,6 +78,84 @@ func TestValidateBeforeStageTask(t *testing.T) {
 			wantErr:    true,
 			wantErrMsg: fmt.Sprintf("task %d of type Approval cannot have wait duration set", 0),
 		},
+		{
+			name: "valid BeforeTasks, with HealthCheck",
+			task: []placementv1beta1.StageTask{
+				{
+					Type: placementv1beta1.StageTaskTypeHealthCheck,
+					HealthCheck: &placementv1beta1.HealthCheckConfig{
+						Prometheus: &placementv1beta1.PrometheusHealthCheck{
+							Address:   "http://prometheus:9090",
+							Query:     "sum(rate(errors_total[5m]))",
+							Operator:  placementv1beta1.HealthCheckOperatorLessThan,
+							Threshold: "0.05",
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid BeforeTasks, HealthCheck without health query",
+			task: []placementv1beta1.StageTask{
+				{
+					Type: placementv1beta1.StageTaskTypeHealthCheck,
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: fmt.Sprintf("task %d of type HealthCheck must have exactly one of prometheus and availability set", 0),
+		},
+		{
+			name: "valid BeforeTasks, with availability HealthCheck",
+			task: []placementv1beta1.StageTask{
+				{
+					Type: placementv1beta1.StageTaskTypeHealthCheck,
+					HealthCheck: &placementv1beta1.HealthCheckConfig{
+						Availability: &placementv1beta1.AvailabilityHealthCheck{
+							MinAvailableDuration: &metav1.Duration{Duration: time.Minute},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid BeforeTasks, HealthCheck with both health query and availability check",
+			task: []placementv1beta1.StageTask{
+				{
+					Type: placementv1beta1.StageTaskTypeHealthCheck,
+					HealthCheck: &placementv1beta1.HealthCheckConfig{
+						Prometheus: &placementv1beta1.PrometheusHealthCheck{
+							Address:   "http://prometheus:9090",
+							Query:     "up",
+							Operator:  placementv1beta1.HealthCheckOperatorEqual,
+							Threshold: "1",
+						},
+						Availability: &placementv1beta1.AvailabilityHealthCheck{},
+					},
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: fmt.Sprintf("task %d of type HealthCheck must have exactly one of prometheus and availability set", 0),
+		},
+		{
+			name: "invalid BeforeTasks, Approval with health check",
+			task: []placementv1beta1.StageTask{
+				{
+					Type: placementv1beta1.StageTaskTypeApproval,
+					HealthCheck: &placementv1beta1.HealthCheckConfig{
+						Prometheus: &placementv1beta1.PrometheusHealthCheck{
+							Address:   "http://prometheus:9090",
+							Query:     "up",
+							Operator:  placementv1beta1.HealthCheckOperatorEqual,
+							Threshold: "1",
+						},
+					},
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: fmt.Sprintf("task %d of type Approval cannot have health check set", 0),
+		},
 	}
 
 	for _, tt := range tests {
@@ -150,6 +228,59 @@ func TestValidateAfterStageTask(t *testing.T) {
 			wantErr: true,
 			errMsg:  "task 0 of type TimedWait has wait duration <= 0",
 		},
+		{
+			name: "valid AfterTasks, with all task types",
+			task: []placementv1beta1.StageTask{
+				{
+					Type: placementv1beta1.StageTaskTypeApproval,
+				},
+				{
+					Type:     placementv1beta1.StageTaskTypeTimedWait,
+					WaitTime: ptr.To(metav1.Duration{Duration: 5 * time.Minute}),
+				},
+				{
+					Type: placementv1beta1.StageTaskTypeHealthCheck,
+					HealthCheck: &placementv1beta1.HealthCheckConfig{
+						Prometheus: &placementv1beta1.PrometheusHealthCheck{
+							Address:   "http://prometheus:9090",
+							Query:     "up",
+							Operator:  placementv1beta1.HealthCheckOperatorEqual,
+							Threshold: "1",
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid AfterTasks, HealthCheck with invalid threshold",
+			task: []placementv1beta1.StageTask{
+				{
+					Type: placementv1beta1.StageTaskTypeHealthCheck,
+					HealthCheck: &placementv1beta1.HealthCheckConfig{
+						Prometheus: &placementv1beta1.PrometheusHealthCheck{
+							Address:   "http://prometheus:9090",
+							Query:     "up",
+							Operator:  placementv1beta1.HealthCheckOperatorEqual,
+							Threshold: "one",
+						},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "task 0 of type HealthCheck has an invalid threshold: strconv.ParseFloat: parsing \"one\": invalid syntax",
+		},
+		{
+			name: "invalid AfterTasks, HealthCheck with wait duration",
+			task: []placementv1beta1.StageTask{
+				{
+					Type:     placementv1beta1.StageTaskTypeHealthCheck,
+					WaitTime: ptr.To(metav1.Duration{Duration: 1 * time.Minute}),
+				},
+			},
+			wantErr: true,
+			errMsg:  "task 0 of type HealthCheck cannot have wait duration set",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,515 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updaterun
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	runtime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/condition"
+	"go.goms.io/fleet/pkg/utils/controller"
+	"go.goms.io/fleet/pkg/utils/labels"
+)
+
+// isRollbackOnFailureEnabled returns true if the updateRun should roll back the updated clusters when it fails.
+func isRollbackOnFailureEnabled(updateRun placementv1beta1.UpdateRunObj) bool {
+	rollbackPolicy := updateRun.GetUpdateRunSpec().RollbackPolicy
+	return rollbackPolicy != nil && rollbackPolicy.Type == placementv1beta1.RollbackTypeOnFailure
+}
+
+// rollbackStuckTimeout returns the time for which the updateRun may be stuck on a cluster before it fails and is
+// rolled back, and whether such a timeout is set.
+func rollbackStuckTimeout(updateRun placementv1beta1.UpdateRunObj) (time.Duration, bool) {
+	if !isRollbackOnFailureEnabled(updateRun) {
+		return 0, false
+	}
+	stuckTimeout := updateRun.GetUpdateRunSpec().RollbackPolicy.StuckTimeout
+	if stuckTimeout == nil {
+		return 0, false
+	}
+	return stuckTimeout.Duration, true
+}
+
+// isRollbackInProgress returns true if the updateRun has started rolling back but has not finished yet.
+func isRollbackInProgress(updateRun placementv1beta1.UpdateRunObj) bool {
+	updateRunStatus := updateRun.GetUpdateRunStatus()
+	if updateRunStatus.RollbackStatus == nil {
+		return false
+	}
+	rolledBackCond := meta.FindStatusCondition(updateRunStatus.Conditions, string(placementv1beta1.StagedUpdateRunConditionRolledBack))
+	return !condition.IsConditionStatusTrue(rolledBackCond, updateRun.GetGeneration()) &&
+		!condition.IsConditionStatusFalse(rolledBackCond, updateRun.GetGeneration())
+}
+
+// initializeRollbackStatus records the clusters that need to be rolled back in the updateRun status in memory.
+// The stages are recorded in the reverse order of the update and so are the clusters in each stage.
+// Only the clusters that have started updating are recorded, except for the newly scheduled ones, which had
+// no resources placed before the updateRun and thus have nothing to roll back to.
+// It returns false if no cluster has started updating and thus there is nothing to roll back.
+func initializeRollbackStatus(updateRun placementv1beta1.UpdateRunObj) bool {
+	updateRunStatus := updateRun.GetUpdateRunStatus()
+	rollbackStatus := &placementv1beta1.RollbackStatus{}
+	for i := len(updateRunStatus.StagesStatus) - 1; i >= 0; i-- {
+		stageStatus := &updateRunStatus.StagesStatus[i]
+		var clusters []placementv1beta1.ClusterUpdatingStatus
+		for j := len(stageStatus.Clusters) - 1; j >= 0; j-- {
+			clusterStatus := &stageStatus.Clusters[j]
+			if !condition.IsConditionStatusTrue(meta.FindStatusCondition(clusterStatus.Conditions, string(placementv1beta1.ClusterUpdatingConditionStarted)), updateRun.GetGeneration()) {
+				continue
+			}
+			if clusterStatus.NewlyScheduled {
+				klog.V(2).InfoS("The cluster is newly scheduled by the updateRun, skip rolling back the cluster", "cluster", clusterStatus.ClusterName, "stage", stageStatus.StageName, "updateRun", klog.KObj(updateRun))
+				continue
+			}
+			clusters = append(clusters, placementv1beta1.ClusterUpdatingStatus{
+				ClusterName:                      clusterStatus.ClusterName,
+				ResourceOverrideSnapshots:        clusterStatus.ResourceOverrideSnapshots,
+				ClusterResourceOverrideSnapshots: clusterStatus.ClusterResourceOverrideSnapshots,
+			})
+		}
+		if len(clusters) > 0 {
+			rollbackStatus.StagesStatus = append(rollbackStatus.StagesStatus, placementv1beta1.StageUpdatingStatus{
+				StageName: stageStatus.StageName,
+				Clusters:  clusters,
+			})
+		}
+	}
+	if len(rollbackStatus.StagesStatus) == 0 {
+		klog.V(2).InfoS("No cluster has started updating, skip rolling back the updateRun", "updateRun", klog.KObj(updateRun))
+		return false
+	}
+	updateRunStatus.RollbackStatus = rollbackStatus
+	markUpdateRunRollingBack(updateRun)
+	return true
+}
+
+// handleUpdateRunAborted records the updateRun as failed after it is aborted, and starts rolling back the updated
+// clusters if the rollback policy of the updateRun asks for it.
+func (r *Reconciler) handleUpdateRunAborted(ctx context.Context, updateRun placementv1beta1.UpdateRunObj, abortErr error, runObjRef klog.ObjectRef) (runtime.Result, error) {
+	if isRollbackOnFailureEnabled(updateRun) && initializeRollbackStatus(updateRun) {
+		klog.V(2).InfoS("The updateRun failed, rolling back the updated clusters", "updateRun", runObjRef)
+		if err := r.recordUpdateRunFailed(ctx, updateRun, abortErr.Error()); err != nil {
+			return runtime.Result{}, err
+		}
+		// The status update does not trigger a new reconciliation, requeue to start the rollback.
+		return runtime.Result{Requeue: true}, nil
+	}
+	return runtime.Result{}, r.recordUpdateRunFailed(ctx, updateRun, abortErr.Error())
+}
+
+// handleRollback drives the rollback of a failed updateRun and records the result in the updateRun status.
+func (r *Reconciler) handleRollback(ctx context.Context, updateRun placementv1beta1.UpdateRunObj, state placementv1beta1.State, runObjRef klog.ObjectRef) (runtime.Result, error) {
+	klog.V(2).InfoS("Continue to roll back the failed updateRun", "updateRun", runObjRef)
+	finished, waitTime, rollbackErr := r.rollback(ctx, updateRun)
+	if errors.Is(rollbackErr, errStagedUpdatedAborted) {
+		// errStagedUpdatedAborted cannot be retried.
+		return runtime.Result{}, r.recordRollbackFailed(ctx, updateRun, rollbackErr.Error())
+	}
+
+	if finished {
+		klog.V(2).InfoS("The updateRun is rolled back", "updateRun", runObjRef)
+		return runtime.Result{}, r.recordRollbackSucceeded(ctx, updateRun)
+	}
+
+	return r.handleIncompleteUpdateRun(ctx, updateRun, waitTime, rollbackErr, state, runObjRef)
+}
+
+// rollback rolls back the clusters updated by a failed updateRun to the previous resource snapshot,
+// one stage at a time following the order recorded in the rollback status.
+// It returns a boolean indicating if the rollback is completed,
+// the time to wait before rechecking the cluster rollback status, and any error encountered.
+func (r *Reconciler) rollback(ctx context.Context, updateRun placementv1beta1.UpdateRunObj) (finished bool, waitTime time.Duration, err error) {
+	updateRunStatus := updateRun.GetUpdateRunStatus()
+	rollbackStatus := updateRunStatus.RollbackStatus
+	var rollbackStageStatus *placementv1beta1.StageUpdatingStatus
+
+	// Set up defer function to mark the rolling back stage as failed when the rollback is aborted.
+	defer func() {
+		if errors.Is(err, errStagedUpdatedAborted) && rollbackStageStatus != nil {
+			klog.InfoS("The rollback is aborted due to unrecoverable behavior, marking the stage as failed", "stage", rollbackStageStatus.StageName, "updateRun", klog.KObj(updateRun))
+			markStageUpdatingFailed(rollbackStageStatus, updateRun.GetGeneration(), err.Error())
+		}
+	}()
+
+	markUpdateRunRollingBack(updateRun)
+	if rollbackStatus.ResourceSnapshotIndex == "" {
+		previousIndex, findErr := r.findPreviousResourceSnapshotIndex(ctx, updateRun)
+		if findErr != nil {
+			return false, 0, findErr
+		}
+		if err := r.setPreviousOverrideSnapshots(ctx, updateRun, previousIndex); err != nil {
+			return false, 0, err
+		}
+		rollbackStatus.ResourceSnapshotIndex = previousIndex
+	}
+
+	for i := range rollbackStatus.StagesStatus {
+		if condition.IsConditionStatusTrue(meta.FindStatusCondition(rollbackStatus.StagesStatus[i].Conditions, string(placementv1beta1.StageUpdatingConditionSucceeded)), updateRun.GetGeneration()) {
+			continue
+		}
+		rollbackStageStatus = &rollbackStatus.StagesStatus[i]
+		maxConcurrency, concurrencyErr := calculateRollbackMaxConcurrencyValue(updateRunStatus, rollbackStageStatus.StageName)
+		if concurrencyErr != nil {
+			return false, 0, fmt.Errorf("%w: %s", errStagedUpdatedAborted, concurrencyErr.Error())
+		}
+		waitTime, err = r.rollbackStage(ctx, updateRun, rollbackStageStatus, maxConcurrency)
+		// The rollback has not finished yet.
+		return false, waitTime, err
+	}
+	// All the stages have been rolled back.
+	return true, 0, nil
+}
+
+// findPreviousResourceSnapshotIndex finds the latest resource snapshot index of the placement that is older than the one
+// used by the updateRun. The snapshots are retained according to the RevisionHistoryLimit of the placement.
+func (r *Reconciler) findPreviousResourceSnapshotIndex(ctx context.Context, updateRun placementv1beta1.UpdateRunObj) (string, error) {
+	updateRunRef := klog.KObj(updateRun)
+	updateRunStatus := updateRun.GetUpdateRunStatus()
+	placementKey := types.NamespacedName{Name: updateRun.GetUpdateRunSpec().PlacementName, Namespace: updateRun.GetNamespace()}
+
+	usedIndex, err := strconv.Atoi(updateRunStatus.ResourceSnapshotIndexUsed)
+	if err != nil {
+		unexpectedErr := controller.NewUnexpectedBehaviorError(fmt.Errorf("invalid resource snapshot index used `%s`: %w", updateRunStatus.ResourceSnapshotIndexUsed, err))
+		klog.ErrorS(unexpectedErr, "Failed to parse the resource snapshot index used by the updateRun", "updateRun", updateRunRef)
+		return "", fmt.Errorf("%w: %s", errStagedUpdatedAborted, unexpectedErr.Error())
+	}
+
+	resourceSnapshotList, err := controller.ListAllResourceSnapshots(ctx, r.Client, placementKey)
+	if err != nil {
+		klog.ErrorS(err, "Failed to list the resource snapshots of the placement", "placement", placementKey, "updateRun", updateRunRef)
+		return "", err
+	}
+
+	previousIndex := -1
+	for _, resourceSnapshot := range resourceSnapshotList.GetResourceSnapshotObjs() {
+		// only master has this annotation.
+		if len(resourceSnapshot.GetAnnotations()[placementv1beta1.ResourceGroupHashAnnotation]) == 0 {
+			continue
+		}
+		index, err := labels.ExtractResourceIndexFromResourceSnapshot(resourceSnapshot)
+		if err != nil {
+			klog.ErrorS(err, "Failed to parse the resource index of the resource snapshot", "resourceSnapshot", klog.KObj(resourceSnapshot), "updateRun", updateRunRef)
+			continue
+		}
+		if index < usedIndex && index > previousIndex {
+			previousIndex = index
+		}
+	}
+
+	if previousIndex < 0 {
+		noSnapshotErr := controller.NewUserError(fmt.Errorf("no resource snapshot older than index %d is retained for placement `%s`, "+
+			"please increase the revisionHistoryLimit of the placement to keep more resource snapshots", usedIndex, placementKey))
+		klog.ErrorS(noSnapshotErr, "Failed to find the resource snapshot to roll back to", "updateRun", updateRunRef)
+		return "", fmt.Errorf("%w: %s", errStagedUpdatedAborted, noSnapshotErr.Error())
+	}
+	klog.V(2).InfoS("Found the resource snapshot to roll back to", "resourceSnapshotIndex", previousIndex, "placement", placementKey, "updateRun", updateRunRef)
+	return strconv.Itoa(previousIndex), nil
+}
+
+// setPreviousOverrideSnapshots sets the override snapshots of the clusters to roll back to the ones recorded by the latest
+// other updateRun of the same placement which has rolled out the given resource snapshot index to them, so that the clusters
+// get back what they had before the failed updateRun. The clusters not updated by such an updateRun keep the override
+// snapshots of the failed updateRun.
+func (r *Reconciler) setPreviousOverrideSnapshots(ctx context.Context, updateRun placementv1beta1.UpdateRunObj, resourceSnapshotIndex string) error {
+	updateRunRef := klog.KObj(updateRun)
+	placementName := updateRun.GetUpdateRunSpec().PlacementName
+
+	var updateRunList placementv1beta1.UpdateRunObjList
+	var listOptions []client.ListOption
+	if updateRun.GetNamespace() == "" {
+		updateRunList = &placementv1beta1.ClusterStagedUpdateRunList{}
+	} else {
+		updateRunList = &placementv1beta1.StagedUpdateRunList{}
+		listOptions = append(listOptions, client.InNamespace(updateRun.GetNamespace()))
+	}
+	if err := r.Client.List(ctx, updateRunList, listOptions...); err != nil {
+		klog.ErrorS(err, "Failed to list the updateRuns", "updateRun", updateRunRef)
+		return controller.NewAPIServerError(true, err)
+	}
+	var previousRuns []placementv1beta1.UpdateRunObj
+	for _, run := range updateRunList.GetUpdateRunObjs() {
+		if run.GetName() != updateRun.GetName() && run.GetUpdateRunSpec().PlacementName == placementName &&
+			run.GetUpdateRunStatus().ResourceSnapshotIndexUsed == resourceSnapshotIndex {
+			previousRuns = append(previousRuns, run)
+		}
+	}
+	// Look at the latest updateRun first.
+	sort.SliceStable(previousRuns, func(i, j int) bool {
+		createdI, createdJ := previousRuns[i].GetCreationTimestamp(), previousRuns[j].GetCreationTimestamp()
+		return createdJ.Before(&createdI)
+	})
+
+	rollbackStatus := updateRun.GetUpdateRunStatus().RollbackStatus
+	for i := range rollbackStatus.StagesStatus {
+		for j := range rollbackStatus.StagesStatus[i].Clusters {
+			clusterStatus := &rollbackStatus.StagesStatus[i].Clusters[j]
+			previousClusterStatus := findUpdatedClusterStatus(previousRuns, clusterStatus.ClusterName)
+			if previousClusterStatus == nil {
+				klog.V(2).InfoS("No updateRun has rolled out the previous resource snapshot to the cluster, keep the current override snapshots",
+					"cluster", clusterStatus.ClusterName, "resourceSnapshotIndex", resourceSnapshotIndex, "updateRun", updateRunRef)
+				continue
+			}
+			clusterStatus.ResourceOverrideSnapshots = previousClusterStatus.ResourceOverrideSnapshots
+			clusterStatus.ClusterResourceOverrideSnapshots = previousClusterStatus.ClusterResourceOverrideSnapshots
+		}
+	}
+	return nil
+}
+
+// findUpdatedClusterStatus returns the status of the given cluster in the first updateRun that has updated it successfully.
+func findUpdatedClusterStatus(updateRuns []placementv1beta1.UpdateRunObj, clusterName string) *placementv1beta1.ClusterUpdatingStatus {
+	for _, run := range updateRuns {
+		runStatus := run.GetUpdateRunStatus()
+		for i := range runStatus.StagesStatus {
+			for j := range runStatus.StagesStatus[i].Clusters {
+				clusterStatus := &runStatus.StagesStatus[i].Clusters[j]
+				if clusterStatus.ClusterName == clusterName &&
+					condition.IsConditionStatusTrue(meta.FindStatusCondition(clusterStatus.Conditions, string(placementv1beta1.ClusterUpdatingConditionSucceeded)), run.GetGeneration()) {
+					return clusterStatus
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// rollbackStage rolls back the clusters in a single stage by re-pointing their bindings to the previous resource snapshot.
+func (r *Reconciler) rollbackStage(
+	ctx context.Context,
+	updateRun placementv1beta1.UpdateRunObj,
+	rollbackStageStatus *placementv1beta1.StageUpdatingStatus,
+	maxConcurrency int,
+) (time.Duration, error) {
+	updateRunStatus := updateRun.GetUpdateRunStatus()
+	updateRunSpec := updateRun.GetUpdateRunSpec()
+	updateRunRef := klog.KObj(updateRun)
+	resourceSnapshotIndex := updateRunStatus.RollbackStatus.ResourceSnapshotIndex
+	// The parse error is ignored because the index is found by parsing the resource snapshot labels.
+	resourceIndex, _ := strconv.Atoi(resourceSnapshotIndex)
+	resourceSnapshotName := fmt.Sprintf(placementv1beta1.ResourceSnapshotNameFmt, updateRunSpec.PlacementName, resourceIndex)
+
+	placementKey := types.NamespacedName{Name: updateRunSpec.PlacementName, Namespace: updateRun.GetNamespace()}
+	bindings, err := controller.ListBindingsFromKey(ctx, r.Client, placementKey, true)
+	if err != nil {
+		klog.ErrorS(err, "Failed to list the bindings of the placement", "placement", placementKey, "updateRun", updateRunRef)
+		return 0, err
+	}
+	bindingsMap := make(map[string]placementv1beta1.BindingObj, len(bindings))
+	for _, binding := range bindings {
+		bindingsMap[binding.GetBindingSpec().TargetCluster] = binding
+	}
+
+	// Mark the stage as started in case it's not.
+	markStageUpdatingProgressStarted(rollbackStageStatus, updateRun.GetGeneration())
+	finishedClusterCount := 0
+	clusterRollingBackCount := 0
+	var clusterRollbackErrors []error
+	// Go through each cluster in the stage and check if it's rolling back/succeeded/failed.
+	for i := 0; i < len(rollbackStageStatus.Clusters) && clusterRollingBackCount < maxConcurrency; i++ {
+		clusterStatus := &rollbackStageStatus.Clusters[i]
+		clusterSucceededCond := meta.FindStatusCondition(clusterStatus.Conditions, string(placementv1beta1.ClusterUpdatingConditionSucceeded))
+		if condition.IsConditionStatusTrue(clusterSucceededCond, updateRun.GetGeneration()) {
+			// The cluster has been rolled back successfully.
+			finishedClusterCount++
+			continue
+		}
+		clusterRollingBackCount++
+		if condition.IsConditionStatusFalse(clusterSucceededCond, updateRun.GetGeneration()) {
+			failedErr := fmt.Errorf("the cluster `%s` in the stage %s has failed to roll back", clusterStatus.ClusterName, rollbackStageStatus.StageName)
+			klog.ErrorS(failedErr, "The cluster has failed to be rolled back", "updateRun", updateRunRef)
+			clusterRollbackErrors = append(clusterRollbackErrors, fmt.Errorf("%w: %s", errStagedUpdatedAborted, failedErr.Error()))
+			continue
+		}
+
+		binding, exist := bindingsMap[clusterStatus.ClusterName]
+		if !exist {
+			// The binding is gone, e.g. the cluster is no longer selected, so there is nothing to roll back.
+			klog.V(2).InfoS("The binding of the cluster no longer exists, skip rolling back the cluster", "cluster", clusterStatus.ClusterName, "stage", rollbackStageStatus.StageName, "updateRun", updateRunRef)
+			markClusterUpdatingStarted(clusterStatus, updateRun.GetGeneration())
+			markClusterUpdatingSucceeded(clusterStatus, updateRun.GetGeneration())
+			finishedClusterCount++
+			clusterRollingBackCount--
+			continue
+		}
+
+		bindingSpec := binding.GetBindingSpec()
+		rolloutStarted := condition.IsConditionStatusTrue(meta.FindStatusCondition(binding.GetBindingStatus().Conditions, string(placementv1beta1.ResourceBindingRolloutStarted)), binding.GetGeneration())
+		clusterStartedCond := meta.FindStatusCondition(clusterStatus.Conditions, string(placementv1beta1.ClusterUpdatingConditionStarted))
+		if !condition.IsConditionStatusTrue(clusterStartedCond, updateRun.GetGeneration()) {
+			// The cluster has not started rolling back yet.
+			if !isBindingSyncedWithClusterStatus(resourceSnapshotName, updateRun, binding, clusterStatus) || bindingSpec.State != placementv1beta1.BindingStateBound {
+				bindingSpec.State = placementv1beta1.BindingStateBound
+				bindingSpec.ResourceSnapshotName = resourceSnapshotName
+				bindingSpec.ResourceOverrideSnapshots = clusterStatus.ResourceOverrideSnapshots
+				bindingSpec.ClusterResourceOverrideSnapshots = clusterStatus.ClusterResourceOverrideSnapshots
+				bindingSpec.ApplyStrategy = updateRunStatus.ApplyStrategy
+				if err := r.Client.Update(ctx, binding); err != nil {
+					klog.ErrorS(err, "Failed to update binding to roll back to the previous resource snapshot", "binding", klog.KObj(binding), "updateRun", updateRunRef)
+					clusterRollbackErrors = append(clusterRollbackErrors, controller.NewUpdateIgnoreConflictError(err))
+					continue
+				}
+				klog.V(2).InfoS("Updated the binding to roll back to the previous resource snapshot", "binding", klog.KObj(binding), "resourceSnapshotIndex", resourceSnapshotIndex, "cluster", clusterStatus.ClusterName, "stage", rollbackStageStatus.StageName, "updateRun", updateRunRef)
+				if err := r.updateBindingRolloutStarted(ctx, binding, updateRun, resourceSnapshotIndex); err != nil {
+					clusterRollbackErrors = append(clusterRollbackErrors, err)
+					continue
+				}
+			} else if !rolloutStarted {
+				klog.V(2).InfoS("The binding is rolled back but the rolloutStarted status has not been updated", "binding", klog.KObj(binding), "cluster", clusterStatus.ClusterName, "stage", rollbackStageStatus.StageName, "updateRun", updateRunRef)
+				if err := r.updateBindingRolloutStarted(ctx, binding, updateRun, resourceSnapshotIndex); err != nil {
+					clusterRollbackErrors = append(clusterRollbackErrors, err)
+					continue
+				}
+			}
+			markClusterUpdatingStarted(clusterStatus, updateRun.GetGeneration())
+			// Need to continue as we need to process at most maxConcurrency number of clusters in parallel.
+			continue
+		}
+
+		// Now the cluster has to be rolling back, the binding should point to the previous resource snapshot and the binding should be bound.
+		inSync := isBindingSyncedWithClusterStatus(resourceSnapshotName, updateRun, binding, clusterStatus)
+		if !inSync || !rolloutStarted || bindingSpec.State != placementv1beta1.BindingStateBound {
+			preemptedErr := controller.NewUserError(fmt.Errorf("the binding of the rolling back cluster `%s` in the stage `%s` is not up-to-date with the desired status, "+
+				"please check the status of binding `%s` and see if there is a concurrent updateRun referencing the same placement and updating the same cluster",
+				clusterStatus.ClusterName, rollbackStageStatus.StageName, klog.KObj(binding)))
+			klog.ErrorS(preemptedErr, "The binding has been changed during rolling back",
+				"bindingSpecInSync", inSync, "bindingState", bindingSpec.State,
+				"bindingRolloutStarted", rolloutStarted, "binding", klog.KObj(binding), "updateRun", updateRunRef)
+			markClusterUpdatingFailed(clusterStatus, updateRun.GetGeneration(), preemptedErr.Error())
+			clusterRollbackErrors = append(clusterRollbackErrors, fmt.Errorf("%w: %s", errStagedUpdatedAborted, preemptedErr.Error()))
+			continue
+		}
+
+		finished, rollbackErr := checkClusterUpdateResult(binding, clusterStatus, rollbackStageStatus, updateRun)
+		if rollbackErr != nil {
+			clusterRollbackErrors = append(clusterRollbackErrors, rollbackErr)
+		}
+		if finished {
+			finishedClusterCount++
+			// The cluster has finished successfully, we can process another cluster in this round.
+			clusterRollingBackCount--
+		}
+	}
+
+	// Aggregate and return errors.
+	if len(clusterRollbackErrors) > 0 {
+		// Even though we aggregate errors, we can still check if one of the errors is a staged update aborted error by using errors.Is in the caller.
+		return 0, utilerrors.NewAggregate(clusterRollbackErrors)
+	}
+
+	if finishedClusterCount == len(rollbackStageStatus.Clusters) {
+		klog.V(2).InfoS("The stage has finished rolling back all clusters", "stage", rollbackStageStatus.StageName, "updateRun", updateRunRef)
+		markStageRollbackSucceeded(rollbackStageStatus, updateRun.GetGeneration())
+		// No need to wait to get to the next stage.
+		return 0, nil
+	}
+
+	// Some clusters are still rolling back.
+	return clusterUpdatingWaitTime, nil
+}
+
+// calculateRollbackMaxConcurrencyValue calculates the max concurrency value for rolling back a stage.
+// The rollback follows the max concurrency of the stage with the same name in the update strategy snapshot.
+func calculateRollbackMaxConcurrencyValue(status *placementv1beta1.UpdateRunStatus, stageName string) (int, error) {
+	for i := range status.StagesStatus {
+		if status.StagesStatus[i].StageName == stageName {
+			return calculateMaxConcurrencyValue(status, i)
+		}
+	}
+	return 0, controller.NewUnexpectedBehaviorError(fmt.Errorf("the rolling back stage `%s` is not found in the stages status", stageName))
+}
+
+// recordRollbackSucceeded records the rolled back condition in the updateRun status.
+func (r *Reconciler) recordRollbackSucceeded(ctx context.Context, updateRun placementv1beta1.UpdateRunObj) error {
+	updateRunStatus := updateRun.GetUpdateRunStatus()
+	meta.SetStatusCondition(&updateRunStatus.Conditions, metav1.Condition{
+		Type:               string(placementv1beta1.StagedUpdateRunConditionRolledBack),
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: updateRun.GetGeneration(),
+		Reason:             condition.UpdateRunRolledBackReason,
+		Message:            fmt.Sprintf("All the updated clusters are rolled back to resource snapshot index %s", updateRunStatus.RollbackStatus.ResourceSnapshotIndex),
+	})
+	if updateErr := r.Client.Status().Update(ctx, updateRun); updateErr != nil {
+		klog.ErrorS(updateErr, "Failed to update the updateRun status as rolled back", "updateRun", klog.KObj(updateRun))
+		// updateErr can be retried.
+		return controller.NewUpdateIgnoreConflictError(updateErr)
+	}
+	return nil
+}
+
+// recordRollbackFailed records the rollback failed condition in the updateRun status.
+func (r *Reconciler) recordRollbackFailed(ctx context.Context, updateRun placementv1beta1.UpdateRunObj, message string) error {
+	updateRunStatus := updateRun.GetUpdateRunStatus()
+	meta.SetStatusCondition(&updateRunStatus.Conditions, metav1.Condition{
+		Type:               string(placementv1beta1.StagedUpdateRunConditionRolledBack),
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: updateRun.GetGeneration(),
+		Reason:             condition.UpdateRunRollbackFailedReason,
+		Message:            message,
+	})
+	if updateErr := r.Client.Status().Update(ctx, updateRun); updateErr != nil {
+		klog.ErrorS(updateErr, "Failed to update the updateRun status as rollback failed", "updateRun", klog.KObj(updateRun))
+		// updateErr can be retried.
+		return controller.NewUpdateIgnoreConflictError(updateErr)
+	}
+	return nil
+}
+
+// markUpdateRunRollingBack marks the updateRun as rolling back in memory.
+func markUpdateRunRollingBack(updateRun placementv1beta1.UpdateRunObj) {
+	updateRunStatus := updateRun.GetUpdateRunStatus()
+	meta.SetStatusCondition(&updateRunStatus.Conditions, metav1.Condition{
+		Type:               string(placementv1beta1.StagedUpdateRunConditionRolledBack),
+		Status:             metav1.ConditionUnknown,
+		ObservedGeneration: updateRun.GetGeneration(),
+		Reason:             condition.UpdateRunRollingBackReason,
+		Message:            "The updated clusters are being rolled back to the previous resource snapshot",
+	})
+}
+
+// markStageRollbackSucceeded marks the stage rollback status as succeeded in memory.
+func markStageRollbackSucceeded(stageUpdatingStatus *placementv1beta1.StageUpdatingStatus, generation int64) {
+	if stageUpdatingStatus.EndTime == nil {
+		stageUpdatingStatus.EndTime = &metav1.Time{Time: time.Now()}
+	}
+	meta.SetStatusCondition(&stageUpdatingStatus.Conditions, metav1.Condition{
+		Type:               string(placementv1beta1.StageUpdatingConditionProgressing),
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             condition.StageUpdatingSucceededReason,
+		Message:            "All clusters in the stage are rolled back",
+	})
+	meta.SetStatusCondition(&stageUpdatingStatus.Conditions, metav1.Condition{
+		Type:               string(placementv1beta1.StageUpdatingConditionSucceeded),
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             condition.StageUpdatingSucceededReason,
+		Message:            "Stage rollback completed successfully",
+	})
+}
@@ -0,0 +1,677 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updaterun
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/condition"
+)
+
+const (
+	rollbackTestPlacementName = "test-placement"
+	rollbackTestStageName     = "test-stage"
+)
+
+func clusterStatusWithConditions(clusterName string, conds ...metav1.Condition) placementv1beta1.ClusterUpdatingStatus {
+	return placementv1beta1.ClusterUpdatingStatus{
+		ClusterName: clusterName,
+		Conditions:  conds,
+	}
+}
+
+func clusterCondition(condType placementv1beta1.ClusterUpdatingStatusConditionType, status metav1.ConditionStatus) metav1.Condition {
+	return metav1.Condition{
+		Type:               string(condType),
+		Status:             status,
+		ObservedGeneration: 1,
+	}
+}
+
+func TestInitializeRollbackStatus(t *testing.T) {
+	tests := []struct {
+		name               string
+		stagesStatus       []placementv1beta1.StageUpdatingStatus
+		wantInitialized    bool
+		wantRollbackStatus *placementv1beta1.RollbackStatus
+	}{
+		{
+			name: "should record the started clusters in reverse order",
+			stagesStatus: []placementv1beta1.StageUpdatingStatus{
+				{
+					StageName: "stage-1",
+					Clusters: []placementv1beta1.ClusterUpdatingStatus{
+						clusterStatusWithConditions("cluster-1",
+							clusterCondition(placementv1beta1.ClusterUpdatingConditionStarted, metav1.ConditionTrue),
+							clusterCondition(placementv1beta1.ClusterUpdatingConditionSucceeded, metav1.ConditionTrue)),
+						clusterStatusWithConditions("cluster-2",
+							clusterCondition(placementv1beta1.ClusterUpdatingConditionStarted, metav1.ConditionTrue),
+							clusterCondition(placementv1beta1.ClusterUpdatingConditionSucceeded, metav1.ConditionTrue)),
+					},
+				},
+				{
+					StageName: "stage-2",
+					Clusters: []placementv1beta1.ClusterUpdatingStatus{
+						{
+							ClusterName:                      "cluster-3",
+							ClusterResourceOverrideSnapshots: []string{"cro-1"},
+							ResourceOverrideSnapshots:        []placementv1beta1.NamespacedName{{Name: "ro-1", Namespace: "ns"}},
+							Conditions: []metav1.Condition{
+								clusterCondition(placementv1beta1.ClusterUpdatingConditionStarted, metav1.ConditionTrue),
+								clusterCondition(placementv1beta1.ClusterUpdatingConditionSucceeded, metav1.ConditionTrue),
+							},
+						},
+						clusterStatusWithConditions("cluster-4",
+							clusterCondition(placementv1beta1.ClusterUpdatingConditionStarted, metav1.ConditionTrue),
+							clusterCondition(placementv1beta1.ClusterUpdatingConditionSucceeded, metav1.ConditionFalse)),
+						clusterStatusWithConditions("cluster-5"),
+					},
+				},
+				{
+					StageName: "stage-3",
+					Clusters: []placementv1beta1.ClusterUpdatingStatus{
+						clusterStatusWithConditions("cluster-6"),
+					},
+				},
+			},
+			wantInitialized: true,
+			wantRollbackStatus: &placementv1beta1.RollbackStatus{
+				StagesStatus: []placementv1beta1.StageUpdatingStatus{
+					{
+						StageName: "stage-2",
+						Clusters: []placementv1beta1.ClusterUpdatingStatus{
+							{ClusterName: "cluster-4"},
+							{
+								ClusterName:                      "cluster-3",
+								ClusterResourceOverrideSnapshots: []string{"cro-1"},
+								ResourceOverrideSnapshots:        []placementv1beta1.NamespacedName{{Name: "ro-1", Namespace: "ns"}},
+							},
+						},
+					},
+					{
+						StageName: "stage-1",
+						Clusters: []placementv1beta1.ClusterUpdatingStatus{
+							{ClusterName: "cluster-2"},
+							{ClusterName: "cluster-1"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "should not roll back the newly scheduled clusters",
+			stagesStatus: []placementv1beta1.StageUpdatingStatus{
+				{
+					StageName: "stage-1",
+					Clusters: []placementv1beta1.ClusterUpdatingStatus{
+						clusterStatusWithConditions("cluster-1",
+							clusterCondition(placementv1beta1.ClusterUpdatingConditionStarted, metav1.ConditionTrue),
+							clusterCondition(placementv1beta1.ClusterUpdatingConditionSucceeded, metav1.ConditionTrue)),
+						{
+							ClusterName:    "cluster-2",
+							NewlyScheduled: true,
+							Conditions: []metav1.Condition{
+								clusterCondition(placementv1beta1.ClusterUpdatingConditionStarted, metav1.ConditionTrue),
+								clusterCondition(placementv1beta1.ClusterUpdatingConditionSucceeded, metav1.ConditionFalse),
+							},
+						},
+					},
+				},
+			},
+			wantInitialized: true,
+			wantRollbackStatus: &placementv1beta1.RollbackStatus{
+				StagesStatus: []placementv1beta1.StageUpdatingStatus{
+					{
+						StageName: "stage-1",
+						Clusters: []placementv1beta1.ClusterUpdatingStatus{
+							{ClusterName: "cluster-1"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "should not roll back if only newly scheduled clusters have started updating",
+			stagesStatus: []placementv1beta1.StageUpdatingStatus{
+				{
+					StageName: "stage-1",
+					Clusters: []placementv1beta1.ClusterUpdatingStatus{
+						{
+							ClusterName:    "cluster-1",
+							NewlyScheduled: true,
+							Conditions: []metav1.Condition{
+								clusterCondition(placementv1beta1.ClusterUpdatingConditionStarted, metav1.ConditionTrue),
+							},
+						},
+					},
+				},
+			},
+			wantInitialized: false,
+		},
+		{
+			name: "should not roll back if no cluster has started updating",
+			stagesStatus: []placementv1beta1.StageUpdatingStatus{
+				{
+					StageName: "stage-1",
+					Clusters: []placementv1beta1.ClusterUpdatingStatus{
+						clusterStatusWithConditions("cluster-1"),
+					},
+				},
+			},
+			wantInitialized: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updateRun := &placementv1beta1.ClusterStagedUpdateRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "test-update-run",
+					Generation: 1,
+				},
+				Status: placementv1beta1.UpdateRunStatus{
+					StagesStatus: tt.stagesStatus,
+				},
+			}
+			gotInitialized := initializeRollbackStatus(updateRun)
+			if gotInitialized != tt.wantInitialized {
+				t.Fatalf("initializeRollbackStatus() = %v, want %v", gotInitialized, tt.wantInitialized)
+			}
+			if diff := cmp.Diff(tt.wantRollbackStatus, updateRun.Status.RollbackStatus); diff != "" {
+				t.Errorf("initializeRollbackStatus() rollback status mismatch (-want +got):\n%s", diff)
+			}
+			if gotInProgress := isRollbackInProgress(updateRun); gotInProgress != tt.wantInitialized {
+				t.Errorf("isRollbackInProgress() = %v, want %v", gotInProgress, tt.wantInitialized)
+			}
+		})
+	}
+}
+
+func TestFindPreviousResourceSnapshotIndex(t *testing.T) {
+	resourceSnapshot := func(placementName string, index int, isMaster bool) *placementv1beta1.ClusterResourceSnapshot {
+		snapshot := &placementv1beta1.ClusterResourceSnapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: fmt.Sprintf(placementv1beta1.ResourceSnapshotNameFmt, placementName, index),
+				Labels: map[string]string{
+					placementv1beta1.PlacementTrackingLabel: placementName,
+					placementv1beta1.ResourceIndexLabel:     fmt.Sprint(index),
+				},
+			},
+		}
+		if isMaster {
+			snapshot.Annotations = map[string]string{placementv1beta1.ResourceGroupHashAnnotation: "hash"}
+		} else {
+			snapshot.Name = fmt.Sprintf(placementv1beta1.ResourceSnapshotNameWithSubindexFmt, placementName, index, 0)
+		}
+		return snapshot
+	}
+	tests := []struct {
+		name              string
+		usedIndex         string
+		resourceSnapshots []client.Object
+		wantIndex         string
+		wantErrMsg        string
+	}{
+		{
+			name:      "should find the latest retained older master snapshot",
+			usedIndex: "3",
+			resourceSnapshots: []client.Object{
+				resourceSnapshot(rollbackTestPlacementName, 0, true),
+				resourceSnapshot(rollbackTestPlacementName, 1, true),
+				resourceSnapshot(rollbackTestPlacementName, 2, false),
+				resourceSnapshot(rollbackTestPlacementName, 3, true),
+				resourceSnapshot("other-placement", 2, true),
+			},
+			wantIndex: "1",
+		},
+		{
+			name:      "should abort if no older snapshot is retained",
+			usedIndex: "1",
+			resourceSnapshots: []client.Object{
+				resourceSnapshot(rollbackTestPlacementName, 1, true),
+				resourceSnapshot("other-placement", 0, true),
+			},
+			wantErrMsg: "no resource snapshot older than index 1 is retained",
+		},
+		{
+			name:       "should abort if the used index is invalid",
+			usedIndex:  "invalid",
+			wantErrMsg: "invalid resource snapshot index used",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			_ = placementv1beta1.AddToScheme(scheme)
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.resourceSnapshots...).Build()
+			r := Reconciler{Client: fakeClient}
+			updateRun := &placementv1beta1.ClusterStagedUpdateRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-update-run"},
+				Spec:       placementv1beta1.UpdateRunSpec{PlacementName: rollbackTestPlacementName},
+				Status:     placementv1beta1.UpdateRunStatus{ResourceSnapshotIndexUsed: tt.usedIndex},
+			}
+			gotIndex, gotErr := r.findPreviousResourceSnapshotIndex(context.Background(), updateRun)
+			if tt.wantErrMsg != "" {
+				if gotErr == nil || !strings.Contains(gotErr.Error(), tt.wantErrMsg) {
+					t.Fatalf("findPreviousResourceSnapshotIndex() error = %v, want error containing %q", gotErr, tt.wantErrMsg)
+				}
+				if !errors.Is(gotErr, errStagedUpdatedAborted) {
+					t.Fatalf("findPreviousResourceSnapshotIndex() want aborted error but got %v", gotErr)
+				}
+				return
+			}
+			if gotErr != nil {
+				t.Fatalf("findPreviousResourceSnapshotIndex() got unexpected error: %v", gotErr)
+			}
+			if gotIndex != tt.wantIndex {
+				t.Errorf("findPreviousResourceSnapshotIndex() = %s, want %s", gotIndex, tt.wantIndex)
+			}
+		})
+	}
+}
+
+func TestRollbackStage(t *testing.T) {
+	previousSnapshotName := fmt.Sprintf(placementv1beta1.ResourceSnapshotNameFmt, rollbackTestPlacementName, 1)
+	newSnapshotName := fmt.Sprintf(placementv1beta1.ResourceSnapshotNameFmt, rollbackTestPlacementName, 2)
+	binding := func(snapshotName string, conds ...metav1.Condition) *placementv1beta1.ClusterResourceBinding {
+		return &placementv1beta1.ClusterResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "binding-1",
+				Generation: 1,
+				Labels:     map[string]string{placementv1beta1.PlacementTrackingLabel: rollbackTestPlacementName},
+			},
+			Spec: placementv1beta1.ResourceBindingSpec{
+				State:                placementv1beta1.BindingStateBound,
+				ResourceSnapshotName: snapshotName,
+				TargetCluster:        "cluster-1",
+			},
+			Status: placementv1beta1.ResourceBindingStatus{
+				Conditions: conds,
+			},
+		}
+	}
+	bindingCondition := func(condType placementv1beta1.ResourceBindingConditionType) metav1.Condition {
+		return metav1.Condition{
+			Type:               string(condType),
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: 1,
+			Reason:             "test",
+		}
+	}
+	tests := []struct {
+		name                      string
+		clusterStatus             placementv1beta1.ClusterUpdatingStatus
+		binding                   *placementv1beta1.ClusterResourceBinding
+		wantWaitTime              time.Duration
+		wantErrAborted            bool
+		wantClusterConds          []metav1.Condition
+		wantStageSucceeded        bool
+		wantBindingSnapshotName   string
+		wantBindingRolloutStarted bool
+	}{
+		{
+			name:          "should re-point the binding to the previous resource snapshot",
+			clusterStatus: clusterStatusWithConditions("cluster-1"),
+			binding: binding(newSnapshotName,
+				bindingCondition(placementv1beta1.ResourceBindingRolloutStarted),
+				bindingCondition(placementv1beta1.ResourceBindingAvailable)),
+			wantWaitTime: clusterUpdatingWaitTime,
+			wantClusterConds: []metav1.Condition{
+				{
+					Type:               string(placementv1beta1.ClusterUpdatingConditionStarted),
+					Status:             metav1.ConditionTrue,
+					ObservedGeneration: 1,
+					Reason:             condition.ClusterUpdatingStartedReason,
+				},
+			},
+			wantBindingSnapshotName:   previousSnapshotName,
+			wantBindingRolloutStarted: true,
+		},
+		{
+			name: "should mark the stage succeeded once the rolled back cluster is available",
+			clusterStatus: clusterStatusWithConditions("cluster-1",
+				clusterCondition(placementv1beta1.ClusterUpdatingConditionStarted, metav1.ConditionTrue)),
+			binding: binding(previousSnapshotName,
+				bindingCondition(placementv1beta1.ResourceBindingRolloutStarted),
+				bindingCondition(placementv1beta1.ResourceBindingAvailable)),
+			wantWaitTime: 0,
+			wantClusterConds: []metav1.Condition{
+				clusterCondition(placementv1beta1.ClusterUpdatingConditionStarted, metav1.ConditionTrue),
+				{
+					Type:               string(placementv1beta1.ClusterUpdatingConditionSucceeded),
+					Status:             metav1.ConditionTrue,
+					ObservedGeneration: 1,
+					Reason:             condition.ClusterUpdatingSucceededReason,
+				},
+			},
+			wantStageSucceeded:        true,
+			wantBindingSnapshotName:   previousSnapshotName,
+			wantBindingRolloutStarted: true,
+		},
+		{
+			name:          "should skip the cluster whose binding no longer exists",
+			clusterStatus: clusterStatusWithConditions("cluster-1"),
+			wantWaitTime:  0,
+			wantClusterConds: []metav1.Condition{
+				{
+					Type:               string(placementv1beta1.ClusterUpdatingConditionStarted),
+					Status:             metav1.ConditionTrue,
+					ObservedGeneration: 1,
+					Reason:             condition.ClusterUpdatingStartedReason,
+				},
+				{
+					Type:               string(placementv1beta1.ClusterUpdatingConditionSucceeded),
+					Status:             metav1.ConditionTrue,
+					ObservedGeneration: 1,
+					Reason:             condition.ClusterUpdatingSucceededReason,
+				},
+			},
+			wantStageSucceeded: true,
+		},
+		{
+			name: "should abort if the binding is changed during rolling back",
+			clusterStatus: clusterStatusWithConditions("cluster-1",
+				clusterCondition(placementv1beta1.ClusterUpdatingConditionStarted, metav1.ConditionTrue)),
+			binding: binding(newSnapshotName,
+				bindingCondition(placementv1beta1.ResourceBindingRolloutStarted)),
+			wantErrAborted: true,
+			wantClusterConds: []metav1.Condition{
+				clusterCondition(placementv1beta1.ClusterUpdatingConditionStarted, metav1.ConditionTrue),
+				{
+					Type:               string(placementv1beta1.ClusterUpdatingConditionSucceeded),
+					Status:             metav1.ConditionFalse,
+					ObservedGeneration: 1,
+					Reason:             condition.ClusterUpdatingFailedReason,
+				},
+			},
+			wantBindingSnapshotName:   newSnapshotName,
+			wantBindingRolloutStarted: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updateRun := &placementv1beta1.ClusterStagedUpdateRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "test-update-run",
+					Generation: 1,
+				},
+				Spec: placementv1beta1.UpdateRunSpec{
+					PlacementName: rollbackTestPlacementName,
+				},
+				Status: placementv1beta1.UpdateRunStatus{
+					ResourceSnapshotIndexUsed: "2",
+					RollbackStatus: &placementv1beta1.RollbackStatus{
+						ResourceSnapshotIndex: "1",
+						StagesStatus: []placementv1beta1.StageUpdatingStatus{
+							{
+								StageName: rollbackTestStageName,
+								Clusters:  []placementv1beta1.ClusterUpdatingStatus{tt.clusterStatus},
+							},
+						},
+					},
+				},
+			}
+			var objects []client.Object
+			if tt.binding != nil {
+				objects = append(objects, tt.binding)
+			}
+			scheme := runtime.NewScheme()
+			_ = placementv1beta1.AddToScheme(scheme)
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(objects...).
+				WithStatusSubresource(objects...).
+				Build()
+			r := Reconciler{Client: fakeClient}
+			rollbackStageStatus := &updateRun.Status.RollbackStatus.StagesStatus[0]
+
+			gotWaitTime, gotErr := r.rollbackStage(context.Background(), updateRun, rollbackStageStatus, 1)
+			if tt.wantErrAborted {
+				if !errors.Is(gotErr, errStagedUpdatedAborted) {
+					t.Fatalf("rollbackStage() want aborted error but got %v", gotErr)
+				}
+			} else if gotErr != nil {
+				t.Fatalf("rollbackStage() got unexpected error: %v", gotErr)
+			}
+			if gotWaitTime != tt.wantWaitTime {
+				t.Errorf("rollbackStage() waitTime = %v, want %v", gotWaitTime, tt.wantWaitTime)
+			}
+			if diff := cmp.Diff(tt.wantClusterConds, rollbackStageStatus.Clusters[0].Conditions, cmpOptions...); diff != "" {
+				t.Errorf("rollbackStage() cluster conditions mismatch (-want +got):\n%s", diff)
+			}
+			gotStageSucceeded := condition.IsConditionStatusTrue(meta.FindStatusCondition(rollbackStageStatus.Conditions, string(placementv1beta1.StageUpdatingConditionSucceeded)), 1)
+			if gotStageSucceeded != tt.wantStageSucceeded {
+				t.Errorf("rollbackStage() stage succeeded = %v, want %v", gotStageSucceeded, tt.wantStageSucceeded)
+			}
+			if tt.binding == nil {
+				return
+			}
+			var gotBinding placementv1beta1.ClusterResourceBinding
+			if err := fakeClient.Get(context.Background(), types.NamespacedName{Name: tt.binding.Name}, &gotBinding); err != nil {
+				t.Fatalf("failed to get binding: %v", err)
+			}
+			if gotBinding.Spec.ResourceSnapshotName != tt.wantBindingSnapshotName {
+				t.Errorf("binding resourceSnapshotName = %s, want %s", gotBinding.Spec.ResourceSnapshotName, tt.wantBindingSnapshotName)
+			}
+			gotRolloutStarted := meta.IsStatusConditionTrue(gotBinding.Status.Conditions, string(placementv1beta1.ResourceBindingRolloutStarted))
+			if gotRolloutStarted != tt.wantBindingRolloutStarted {
+				t.Errorf("binding rolloutStarted = %v, want %v", gotRolloutStarted, tt.wantBindingRolloutStarted)
+			}
+		})
+	}
+}
+
+func TestHandleUpdateRunAborted(t *testing.T) {
+	startedStagesStatus := []placementv1beta1.StageUpdatingStatus{
+		{
+			StageName: rollbackTestStageName,
+			Clusters: []placementv1beta1.ClusterUpdatingStatus{
+				clusterStatusWithConditions("cluster-1",
+					clusterCondition(placementv1beta1.ClusterUpdatingConditionStarted, metav1.ConditionTrue)),
+			},
+		},
+	}
+	tests := []struct {
+		name             string
+		rollbackPolicy   *placementv1beta1.RollbackPolicy
+		stagesStatus     []placementv1beta1.StageUpdatingStatus
+		wantResult       ctrl.Result
+		wantRollingBack  bool
+		wantFailedReason string
+	}{
+		{
+			name:             "should only record the failure without a rollback policy",
+			stagesStatus:     startedStagesStatus,
+			wantResult:       ctrl.Result{},
+			wantFailedReason: condition.UpdateRunFailedReason,
+		},
+		{
+			name:             "should start rolling back the updated clusters",
+			rollbackPolicy:   &placementv1beta1.RollbackPolicy{Type: placementv1beta1.RollbackTypeOnFailure},
+			stagesStatus:     startedStagesStatus,
+			wantResult:       ctrl.Result{Requeue: true},
+			wantRollingBack:  true,
+			wantFailedReason: condition.UpdateRunFailedReason,
+		},
+		{
+			name:           "should only record the failure if no cluster has started updating",
+			rollbackPolicy: &placementv1beta1.RollbackPolicy{Type: placementv1beta1.RollbackTypeOnFailure},
+			stagesStatus: []placementv1beta1.StageUpdatingStatus{
+				{
+					StageName: rollbackTestStageName,
+					Clusters:  []placementv1beta1.ClusterUpdatingStatus{clusterStatusWithConditions("cluster-1")},
+				},
+			},
+			wantResult:       ctrl.Result{},
+			wantFailedReason: condition.UpdateRunFailedReason,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updateRun := &placementv1beta1.ClusterStagedUpdateRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "test-update-run",
+					Generation: 1,
+				},
+				Spec: placementv1beta1.UpdateRunSpec{
+					PlacementName:  rollbackTestPlacementName,
+					RollbackPolicy: tt.rollbackPolicy,
+				},
+				Status: placementv1beta1.UpdateRunStatus{
+					StagesStatus: tt.stagesStatus,
+				},
+			}
+			scheme := runtime.NewScheme()
+			_ = placementv1beta1.AddToScheme(scheme)
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(updateRun).
+				WithStatusSubresource(updateRun).
+				Build()
+			r := Reconciler{Client: fakeClient}
+
+			abortErr := fmt.Errorf("%w: the cluster status is invalid", errStagedUpdatedAborted)
+			gotResult, err := r.handleUpdateRunAborted(context.Background(), updateRun, abortErr, klog.KObj(updateRun))
+			if err != nil {
+				t.Fatalf("handleUpdateRunAborted() got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.wantResult, gotResult); diff != "" {
+				t.Errorf("handleUpdateRunAborted() result mismatch (-want +got):\n%s", diff)
+			}
+
+			var gotUpdateRun placementv1beta1.ClusterStagedUpdateRun
+			if err := fakeClient.Get(context.Background(), types.NamespacedName{Name: updateRun.Name}, &gotUpdateRun); err != nil {
+				t.Fatalf("failed to get the updateRun: %v", err)
+			}
+			succeededCond := meta.FindStatusCondition(gotUpdateRun.Status.Conditions, string(placementv1beta1.StagedUpdateRunConditionSucceeded))
+			if !condition.IsConditionStatusFalse(succeededCond, 1) || succeededCond.Reason != tt.wantFailedReason {
+				t.Errorf("updateRun succeeded condition = %+v, want false with reason %s", succeededCond, tt.wantFailedReason)
+			}
+			if gotRollingBack := isRollbackInProgress(&gotUpdateRun); gotRollingBack != tt.wantRollingBack {
+				t.Errorf("isRollbackInProgress() = %v, want %v", gotRollingBack, tt.wantRollingBack)
+			}
+		})
+	}
+}
+
+func TestSetPreviousOverrideSnapshots(t *testing.T) {
+	succeededCluster := func(clusterName string, generation int64, cro string) placementv1beta1.ClusterUpdatingStatus {
+		return placementv1beta1.ClusterUpdatingStatus{
+			ClusterName:                      clusterName,
+			ClusterResourceOverrideSnapshots: []string{cro},
+			Conditions: []metav1.Condition{
+				{
+					Type:               string(placementv1beta1.ClusterUpdatingConditionSucceeded),
+					Status:             metav1.ConditionTrue,
+					ObservedGeneration: generation,
+				},
+			},
+		}
+	}
+	previousRun := func(name, placementName, index string, created time.Time, clusters ...placementv1beta1.ClusterUpdatingStatus) *placementv1beta1.ClusterStagedUpdateRun {
+		return &placementv1beta1.ClusterStagedUpdateRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Generation:        1,
+				CreationTimestamp: metav1.NewTime(created),
+			},
+			Spec: placementv1beta1.UpdateRunSpec{
+				PlacementName: placementName,
+			},
+			Status: placementv1beta1.UpdateRunStatus{
+				ResourceSnapshotIndexUsed: index,
+				StagesStatus: []placementv1beta1.StageUpdatingStatus{
+					{
+						StageName: rollbackTestStageName,
+						Clusters:  clusters,
+					},
+				},
+			},
+		}
+	}
+	now := time.Now()
+	updateRun := &placementv1beta1.ClusterStagedUpdateRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "test-update-run",
+			Generation:        1,
+			CreationTimestamp: metav1.NewTime(now),
+		},
+		Spec: placementv1beta1.UpdateRunSpec{
+			PlacementName: rollbackTestPlacementName,
+		},
+		Status: placementv1beta1.UpdateRunStatus{
+			ResourceSnapshotIndexUsed: "2",
+			RollbackStatus: &placementv1beta1.RollbackStatus{
+				StagesStatus: []placementv1beta1.StageUpdatingStatus{
+					{
+						StageName: rollbackTestStageName,
+						Clusters: []placementv1beta1.ClusterUpdatingStatus{
+							{ClusterName: "cluster-1", ClusterResourceOverrideSnapshots: []string{"cro-current"}},
+							{ClusterName: "cluster-2", ClusterResourceOverrideSnapshots: []string{"cro-current"}},
+							{ClusterName: "cluster-3", ClusterResourceOverrideSnapshots: []string{"cro-current"}},
+						},
+					},
+				},
+			},
+		},
+	}
+	objects := []client.Object{
+		updateRun,
+		previousRun("older-run", rollbackTestPlacementName, "1", now.Add(-2*time.Hour),
+			succeededCluster("cluster-1", 1, "cro-older"),
+			succeededCluster("cluster-2", 1, "cro-older")),
+		previousRun("newer-run", rollbackTestPlacementName, "1", now.Add(-time.Hour),
+			succeededCluster("cluster-1", 1, "cro-newer"),
+			// The cluster status is stale and thus ignored.
+			succeededCluster("cluster-2", 0, "cro-stale")),
+		previousRun("other-index-run", rollbackTestPlacementName, "0", now.Add(-time.Minute),
+			succeededCluster("cluster-3", 1, "cro-other-index")),
+		previousRun("other-placement-run", "other-placement", "1", now.Add(-time.Minute),
+			succeededCluster("cluster-3", 1, "cro-other-placement")),
+	}
+	scheme := runtime.NewScheme()
+	_ = placementv1beta1.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	r := Reconciler{Client: fakeClient}
+
+	if err := r.setPreviousOverrideSnapshots(context.Background(), updateRun, "1"); err != nil {
+		t.Fatalf("setPreviousOverrideSnapshots() got unexpected error: %v", err)
+	}
+	want := []placementv1beta1.ClusterUpdatingStatus{
+		{ClusterName: "cluster-1", ClusterResourceOverrideSnapshots: []string{"cro-newer"}},
+		{ClusterName: "cluster-2", ClusterResourceOverrideSnapshots: []string{"cro-older"}},
+		{ClusterName: "cluster-3", ClusterResourceOverrideSnapshots: []string{"cro-current"}},
+	}
+	if diff := cmp.Diff(want, updateRun.Status.RollbackStatus.StagesStatus[0].Clusters); diff != "" {
+		t.Errorf("setPreviousOverrideSnapshots() clusters mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workapplier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/jsonpath"
+	"k8s.io/klog/v2"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+// availabilityRuleSet is a set of availability rules indexed by the group and kind of the
+// resource types they apply to.
+type availabilityRuleSet map[schema.GroupKind][]*fleetv1beta1.AvailabilityRule
+
+// listAvailabilityRules lists all the availability rules in the hub cluster, if the availability
+// rules feature is enabled.
+func (r *Reconciler) listAvailabilityRules(ctx context.Context) (availabilityRuleSet, error) {
+	if !r.enableAvailabilityRules {
+		return nil, nil
+	}
+
+	ruleList := &fleetv1beta1.AvailabilityRuleList{}
+	if err := r.hubClient.List(ctx, ruleList); err != nil {
+		klog.ErrorS(err, "Failed to list availability rules")
+		return nil, controller.NewAPIServerError(true, err)
+	}
+	return newAvailabilityRuleSet(ruleList.Items), nil
+}
+
+// newAvailabilityRuleSet builds an availability rule set from a list of rules.
+func newAvailabilityRuleSet(rules []fleetv1beta1.AvailabilityRule) availabilityRuleSet {
+	ruleSet := make(availabilityRuleSet, len(rules))
+	for idx := range rules {
+		rule := &rules[idx]
+		gk := schema.GroupKind{Group: rule.Spec.ResourceType.Group, Kind: rule.Spec.ResourceType.Kind}
+		ruleSet[gk] = append(ruleSet[gk], rule)
+	}
+	// Sort the rules by their names so that the same rule is always picked if multiple
+	// rules apply to the same resource type.
+	for _, rs := range ruleSet {
+		slices.SortFunc(rs, func(a, b *fleetv1beta1.AvailabilityRule) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+	}
+	return ruleSet
+}
+
+// ruleFor returns the availability rule that applies to the given resource type, if any.
+//
+// A rule with a matching version takes precedence over a rule that applies to all versions.
+func (s availabilityRuleSet) ruleFor(gvk schema.GroupVersionKind) *fleetv1beta1.AvailabilityRule {
+	var versionlessRule *fleetv1beta1.AvailabilityRule
+	for _, rule := range s[gvk.GroupKind()] {
+		switch {
+		case rule.Spec.ResourceType.Version == gvk.Version:
+			return rule
+		case len(rule.Spec.ResourceType.Version) == 0 && versionlessRule == nil:
+			versionlessRule = rule
+		}
+	}
+	return versionlessRule
+}
+
+// trackInMemberClusterObjAvailabilityByRule tracks the availability of an object in the member
+// cluster based on an availability rule.
+func trackInMemberClusterObjAvailabilityByRule(
+	rule *fleetv1beta1.AvailabilityRule,
+	inMemberClusterObj *unstructured.Unstructured,
+) (ManifestProcessingAvailabilityResultType, error) {
+	ruleRef := klog.KObj(rule)
+	objRef := klog.KObj(inMemberClusterObj)
+
+	// Check if the status reflects the latest generation of the object.
+	if len(rule.Spec.ObservedGenerationJSONPath) > 0 {
+		values, err := findValuesAtJSONPath(rule.Spec.ObservedGenerationJSONPath, inMemberClusterObj)
+		if err != nil {
+			return AvailabilityResultTypeFailed, fmt.Errorf("failed to evaluate the observed generation JSONPath of availability rule %s: %w", rule.Name, err)
+		}
+		if len(values) == 0 || values[0] != strconv.FormatInt(inMemberClusterObj.GetGeneration(), 10) {
+			klog.V(2).InfoS("The status of the object is stale; consider it to be not yet available",
+				"availabilityRule", ruleRef, "inMemberClusterObj", objRef, "observedGeneration", values)
+			return AvailabilityResultTypeNotYetAvailable, nil
+		}
+	}
+
+	for idx := range rule.Spec.FailedWhen {
+		satisfied, err := evaluateStatusExpression(&rule.Spec.FailedWhen[idx], inMemberClusterObj)
+		if err != nil {
+			return AvailabilityResultTypeFailed, fmt.Errorf("failed to evaluate failedWhen expression %d of availability rule %s: %w", idx, rule.Name, err)
+		}
+		if satisfied {
+			return AvailabilityResultTypeFailed, fmt.Errorf("the object has failed as reported by failedWhen expression %d (%s) of availability rule %s",
+				idx, rule.Spec.FailedWhen[idx].JSONPath, rule.Name)
+		}
+	}
+
+	for idx := range rule.Spec.ProgressingWhen {
+		satisfied, err := evaluateStatusExpression(&rule.Spec.ProgressingWhen[idx], inMemberClusterObj)
+		if err != nil {
+			return AvailabilityResultTypeFailed, fmt.Errorf("failed to evaluate progressingWhen expression %d of availability rule %s: %w", idx, rule.Name, err)
+		}
+		if satisfied {
+			klog.V(2).InfoS("The object is still progressing; consider it to be not yet available",
+				"availabilityRule", ruleRef, "inMemberClusterObj", objRef, "expression", idx)
+			return AvailabilityResultTypeNotYetAvailable, nil
+		}
+	}
+
+	for idx := range rule.Spec.AvailableWhen {
+		satisfied, err := evaluateStatusExpression(&rule.Spec.AvailableWhen[idx], inMemberClusterObj)
+		if err != nil {
+			return AvailabilityResultTypeFailed, fmt.Errorf("failed to evaluate availableWhen expression %d of availability rule %s: %w", idx, rule.Name, err)
+		}
+		if !satisfied {
+			klog.V(2).InfoS("The object does not satisfy all the availableWhen expressions yet",
+				"availabilityRule", ruleRef, "inMemberClusterObj", objRef, "expression", idx)
+			return AvailabilityResultTypeNotYetAvailable, nil
+		}
+	}
+
+	klog.V(2).InfoS("The object is available as reported by an availability rule",
+		"availabilityRule", ruleRef, "inMemberClusterObj", objRef)
+	return AvailabilityResultTypeAvailable, nil
+}
+
+// evaluateStatusExpression returns if an object satisfies a status expression.
+func evaluateStatusExpression(expr *fleetv1beta1.StatusExpression, obj *unstructured.Unstructured) (bool, error) {
+	values, err := findValuesAtJSONPath(expr.JSONPath, obj)
+	if err != nil {
+		return false, err
+	}
+
+	switch expr.Operator {
+	case fleetv1beta1.StatusExpressionOperatorIn:
+		return slices.ContainsFunc(values, func(v string) bool { return slices.Contains(expr.Values, v) }), nil
+	case fleetv1beta1.StatusExpressionOperatorNotIn:
+		return len(values) > 0 && !slices.ContainsFunc(values, func(v string) bool { return slices.Contains(expr.Values, v) }), nil
+	case fleetv1beta1.StatusExpressionOperatorExists:
+		return len(values) > 0, nil
+	case fleetv1beta1.StatusExpressionOperatorDoesNotExist:
+		return len(values) == 0, nil
+	default:
+		return false, fmt.Errorf("unknown status expression operator %q", expr.Operator)
+	}
+}
+
+// findValuesAtJSONPath returns the string representations of all the values found at a JSONPath
+// in an object.
+func findValuesAtJSONPath(path string, obj *unstructured.Unstructured) ([]string, error) {
+	path = strings.TrimSpace(path)
+	if !strings.HasPrefix(path, "{") {
+		// Allow users to omit the surrounding braces, as kubectl does.
+		path = fmt.Sprintf("{%s}", path)
+	}
+
+	jp := jsonpath.New("availability").AllowMissingKeys(true)
+	if err := jp.Parse(path); err != nil {
+		return nil, fmt.Errorf("failed to parse JSONPath %q: %w", path, err)
+	}
+	results, err := jp.FindResults(obj.Object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate JSONPath %q: %w", path, err)
+	}
+
+	values := make([]string, 0, len(results))
+	for _, result := range results {
+		for _, v := range result {
+			if v.Kind() == reflect.Interface {
+				v = v.Elem()
+			}
+			if !v.IsValid() {
+				continue
+			}
+			if v.Kind() == reflect.String {
+				values = append(values, v.String())
+				continue
+			}
+			// Format non-string values (numbers, booleans, and composite values) in JSON.
+			data, err := json.Marshal(v.Interface())
+			if err != nil {
+				return nil, fmt.Errorf("failed to format the value found at JSONPath %q: %w", path, err)
+			}
+			values = append(values, string(data))
+		}
+	}
+	return values, nil
+}
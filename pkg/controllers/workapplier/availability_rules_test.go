@@ -0,0 +1,278 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workapplier
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// certificateRule is an availability rule for cert-manager Certificate objects.
+var certificateRule = &fleetv1beta1.AvailabilityRule{
+	ObjectMeta: metav1.ObjectMeta{
+		Name: "certificates",
+	},
+	Spec: fleetv1beta1.AvailabilityRuleSpec{
+		ResourceType: fleetv1beta1.AvailabilityRuleResourceType{
+			Group: "cert-manager.io",
+			Kind:  "Certificate",
+		},
+		ObservedGenerationJSONPath: ".status.observedGeneration",
+		AvailableWhen: []fleetv1beta1.StatusExpression{
+			{
+				JSONPath: `{.status.conditions[?(@.type=="Ready")].status}`,
+				Operator: fleetv1beta1.StatusExpressionOperatorIn,
+				Values:   []string{"True"},
+			},
+		},
+		ProgressingWhen: []fleetv1beta1.StatusExpression{
+			{
+				JSONPath: `{.status.conditions[?(@.type=="Issuing")].status}`,
+				Operator: fleetv1beta1.StatusExpressionOperatorIn,
+				Values:   []string{"True"},
+			},
+		},
+		FailedWhen: []fleetv1beta1.StatusExpression{
+			{
+				JSONPath: `{.status.failedIssuanceAttempts}`,
+				Operator: fleetv1beta1.StatusExpressionOperatorNotIn,
+				Values:   []string{"0"},
+			},
+		},
+	},
+}
+
+func newCertificate(generation int64, status map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "cert-manager.io/v1",
+			"kind":       "Certificate",
+			"metadata": map[string]interface{}{
+				"name":       "web-tls",
+				"namespace":  nsName,
+				"generation": generation,
+			},
+		},
+	}
+	if status != nil {
+		obj.Object["status"] = status
+	}
+	return obj
+}
+
+func statusCondition(condType, status string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":   condType,
+		"status": status,
+	}
+}
+
+// TestTrackInMemberClusterObjAvailabilityByRule tests the trackInMemberClusterObjAvailabilityByRule function.
+func TestTrackInMemberClusterObjAvailabilityByRule(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		rule                   *fleetv1beta1.AvailabilityRule
+		inMemberClusterObj     *unstructured.Unstructured
+		wantAvailabilityResTyp ManifestProcessingAvailabilityResultType
+		wantErred              bool
+	}{
+		{
+			name:                   "no status",
+			rule:                   certificateRule,
+			inMemberClusterObj:     newCertificate(1, nil),
+			wantAvailabilityResTyp: AvailabilityResultTypeNotYetAvailable,
+		},
+		{
+			name: "stale status",
+			rule: certificateRule,
+			inMemberClusterObj: newCertificate(2, map[string]interface{}{
+				"observedGeneration": int64(1),
+				"conditions":         []interface{}{statusCondition("Ready", "True")},
+			}),
+			wantAvailabilityResTyp: AvailabilityResultTypeNotYetAvailable,
+		},
+		{
+			name: "available",
+			rule: certificateRule,
+			inMemberClusterObj: newCertificate(2, map[string]interface{}{
+				"observedGeneration":     int64(2),
+				"failedIssuanceAttempts": int64(0),
+				"conditions":             []interface{}{statusCondition("Ready", "True")},
+			}),
+			wantAvailabilityResTyp: AvailabilityResultTypeAvailable,
+		},
+		{
+			name: "not ready",
+			rule: certificateRule,
+			inMemberClusterObj: newCertificate(1, map[string]interface{}{
+				"observedGeneration": int64(1),
+				"conditions":         []interface{}{statusCondition("Ready", "False")},
+			}),
+			wantAvailabilityResTyp: AvailabilityResultTypeNotYetAvailable,
+		},
+		{
+			name: "progressing",
+			rule: certificateRule,
+			inMemberClusterObj: newCertificate(1, map[string]interface{}{
+				"observedGeneration": int64(1),
+				"conditions": []interface{}{
+					statusCondition("Ready", "True"),
+					statusCondition("Issuing", "True"),
+				},
+			}),
+			wantAvailabilityResTyp: AvailabilityResultTypeNotYetAvailable,
+		},
+		{
+			name: "failed",
+			rule: certificateRule,
+			inMemberClusterObj: newCertificate(1, map[string]interface{}{
+				"observedGeneration":     int64(1),
+				"failedIssuanceAttempts": int64(3),
+				"conditions":             []interface{}{statusCondition("Ready", "False")},
+			}),
+			wantAvailabilityResTyp: AvailabilityResultTypeFailed,
+			wantErred:              true,
+		},
+		{
+			name: "invalid JSONPath",
+			rule: &fleetv1beta1.AvailabilityRule{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "invalid",
+				},
+				Spec: fleetv1beta1.AvailabilityRuleSpec{
+					AvailableWhen: []fleetv1beta1.StatusExpression{
+						{
+							JSONPath: "{.status.conditions[?(@.type==}",
+							Operator: fleetv1beta1.StatusExpressionOperatorExists,
+						},
+					},
+				},
+			},
+			inMemberClusterObj:     newCertificate(1, map[string]interface{}{}),
+			wantAvailabilityResTyp: AvailabilityResultTypeFailed,
+			wantErred:              true,
+		},
+		{
+			name: "exists and does not exist",
+			rule: &fleetv1beta1.AvailabilityRule{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "phases",
+				},
+				Spec: fleetv1beta1.AvailabilityRuleSpec{
+					AvailableWhen: []fleetv1beta1.StatusExpression{
+						{
+							JSONPath: ".status.url",
+							Operator: fleetv1beta1.StatusExpressionOperatorExists,
+						},
+						{
+							JSONPath: ".status.error",
+							Operator: fleetv1beta1.StatusExpressionOperatorDoesNotExist,
+						},
+					},
+				},
+			},
+			inMemberClusterObj: newCertificate(1, map[string]interface{}{
+				"url": "https://example.com",
+			}),
+			wantAvailabilityResTyp: AvailabilityResultTypeAvailable,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			availabilityResTyp, err := trackInMemberClusterObjAvailabilityByRule(tc.rule, tc.inMemberClusterObj)
+			if tc.wantErred {
+				if err == nil {
+					t.Fatalf("trackInMemberClusterObjAvailabilityByRule() = nil, want error")
+				}
+			} else if err != nil {
+				t.Fatalf("trackInMemberClusterObjAvailabilityByRule() = %v, want no error", err)
+			}
+			if availabilityResTyp != tc.wantAvailabilityResTyp {
+				t.Errorf("trackInMemberClusterObjAvailabilityByRule() = %v, want %v", availabilityResTyp, tc.wantAvailabilityResTyp)
+			}
+		})
+	}
+}
+
+// TestAvailabilityRuleSetRuleFor tests the ruleFor method of availabilityRuleSet.
+func TestAvailabilityRuleSetRuleFor(t *testing.T) {
+	newRule := func(name, group, version, kind string) fleetv1beta1.AvailabilityRule {
+		return fleetv1beta1.AvailabilityRule{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: fleetv1beta1.AvailabilityRuleSpec{
+				ResourceType: fleetv1beta1.AvailabilityRuleResourceType{
+					Group:   group,
+					Version: version,
+					Kind:    kind,
+				},
+			},
+		}
+	}
+	ruleSet := newAvailabilityRuleSet([]fleetv1beta1.AvailabilityRule{
+		newRule("rollouts-z", "argoproj.io", "", "Rollout"),
+		newRule("rollouts-a", "argoproj.io", "", "Rollout"),
+		newRule("rollouts-v1alpha1", "argoproj.io", "v1alpha1", "Rollout"),
+		newRule("configmaps", "", "", "ConfigMap"),
+	})
+
+	testCases := []struct {
+		name         string
+		gvk          schema.GroupVersionKind
+		wantRuleName string
+	}{
+		{
+			name:         "rule with matching version",
+			gvk:          schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "Rollout"},
+			wantRuleName: "rollouts-v1alpha1",
+		},
+		{
+			name:         "rules for all versions (pick by name)",
+			gvk:          schema.GroupVersionKind{Group: "argoproj.io", Version: "v1", Kind: "Rollout"},
+			wantRuleName: "rollouts-a",
+		},
+		{
+			name:         "core API group",
+			gvk:          schema.GroupVersionKind{Group: "", Version: "v1", Kind: "ConfigMap"},
+			wantRuleName: "configmaps",
+		},
+		{
+			name: "no rule",
+			gvk:  schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotRuleName string
+			if rule := ruleSet.ruleFor(tc.gvk); rule != nil {
+				gotRuleName = rule.Name
+			}
+			if diff := cmp.Diff(gotRuleName, tc.wantRuleName); diff != "" {
+				t.Errorf("ruleFor() mismatch (-got, +want):\n%s", diff)
+			}
+		})
+	}
+}
@@ -41,6 +41,12 @@ func (r *Reconciler) trackInMemberClusterObjAvailability(ctx context.Context, bu
 	//
 	// This is concurrency-safe as the bundles slice has been pre-allocated.
 
+	// Retrieve the availability rules (if enabled) that users have specified in the hub cluster.
+	availabilityRules, err := r.listAvailabilityRules(ctx)
+	if err != nil {
+		return err
+	}
+
 	// Prepare a child context.
 	// Cancel the child context anyway to avoid leaks.
 	childCtx, cancel := context.WithCancel(ctx)
@@ -65,7 +71,15 @@ func (r *Reconciler) trackInMemberClusterObjAvailability(ctx context.Context, bu
 			return
 		}
 
-		availabilityResTyp, err := trackInMemberClusterObjAvailabilityByGVR(bundle.gvr, bundle.inMemberClusterObj)
+		var availabilityResTyp ManifestProcessingAvailabilityResultType
+		var err error
+		if rule := availabilityRules.ruleFor(bundle.inMemberClusterObj.GroupVersionKind()); rule != nil {
+			// Users have specified an availability rule for the resource type; it takes precedence
+			// over the built-in availability check.
+			availabilityResTyp, err = trackInMemberClusterObjAvailabilityByRule(rule, bundle.inMemberClusterObj)
+		} else {
+			availabilityResTyp, err = trackInMemberClusterObjAvailabilityByGVR(bundle.gvr, bundle.inMemberClusterObj)
+		}
 		if err != nil {
 			// An unexpected error has occurred during the availability check.
 			bundle.availabilityErr = err
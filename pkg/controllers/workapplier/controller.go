@@ -45,9 +45,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/blobstore"
 	"go.goms.io/fleet/pkg/utils/controller"
 	"go.goms.io/fleet/pkg/utils/defaulter"
 	parallelizerutil "go.goms.io/fleet/pkg/utils/parallelizer"
+	"go.goms.io/fleet/pkg/utils/resource"
 )
 
 const (
@@ -119,6 +121,18 @@ type Reconciler struct {
 	priLinearEqCoeffA int
 	priLinearEqCoeffB int
 	pqSetupOnce       sync.Once
+	// enableAvailabilityRules controls whether the work applier tracks the availability of
+	// applied objects with the availability rules users specify in the hub cluster.
+	enableAvailabilityRules bool
+	// enableRedactionRules controls whether the work applier redacts the drift and diff details
+	// with the redaction rules users specify in the hub cluster.
+	enableRedactionRules bool
+	// redactionHashKey is the key with which the work applier hashes values in the Hash mode of
+	// redaction rules; see LoadRedactionHashKey.
+	redactionHashKey []byte
+	// blobStore is the external store for the manifests that are too large to be kept in the Work
+	// objects; it is nil if no external store is in use.
+	blobStore blobstore.Store
 }
 
 // NewReconciler returns a new Work object reconciler for the work applier.
@@ -134,6 +148,10 @@ func NewReconciler(
 	usePriorityQueue bool,
 	priorityLinearEquationCoeffA *int,
 	priorityLinearEquationCoeffB *int,
+	enableAvailabilityRules bool,
+	enableRedactionRules bool,
+	redactionHashKey []byte,
+	blobStore blobstore.Store,
 ) *Reconciler {
 	if requeueRateLimiter == nil {
 		klog.V(2).InfoS("requeue rate limiter is not set; using the default rate limiter")
@@ -149,23 +167,32 @@ func NewReconciler(
 		priorityLinearEquationCoeffA = ptr.To(-3)
 		priorityLinearEquationCoeffB = ptr.To(int(highestPriorityLevel))
 	}
+	if enableRedactionRules && len(redactionHashKey) == 0 {
+		// The hashed values change whenever the member agent restarts with a random key.
+		klog.V(2).InfoS("redaction hash key is not set; using a random key")
+		redactionHashKey = newRedactionHashKey()
+	}
 
 	return &Reconciler{
-		controllerName:       controllerName,
-		hubClient:            hubClient,
-		spokeDynamicClient:   spokeDynamicClient,
-		spokeClient:          spokeClient,
-		restMapper:           restMapper,
-		recorder:             recorder,
-		concurrentReconciles: concurrentReconciles,
-		parallelizer:         parallelizer,
-		workNameSpace:        workNameSpace,
-		joined:               atomic.NewBool(false),
-		deletionWaitTime:     deletionWaitTime,
-		requeueRateLimiter:   requeueRateLimiter,
-		usePriorityQueue:     usePriorityQueue,
-		priLinearEqCoeffA:    *priorityLinearEquationCoeffA,
-		priLinearEqCoeffB:    *priorityLinearEquationCoeffB,
+		controllerName:          controllerName,
+		hubClient:               hubClient,
+		spokeDynamicClient:      spokeDynamicClient,
+		spokeClient:             spokeClient,
+		restMapper:              restMapper,
+		recorder:                recorder,
+		concurrentReconciles:    concurrentReconciles,
+		parallelizer:            parallelizer,
+		workNameSpace:           workNameSpace,
+		joined:                  atomic.NewBool(false),
+		deletionWaitTime:        deletionWaitTime,
+		requeueRateLimiter:      requeueRateLimiter,
+		usePriorityQueue:        usePriorityQueue,
+		priLinearEqCoeffA:       *priorityLinearEquationCoeffA,
+		priLinearEqCoeffB:       *priorityLinearEquationCoeffB,
+		enableAvailabilityRules: enableAvailabilityRules,
+		enableRedactionRules:    enableRedactionRules,
+		redactionHashKey:        redactionHashKey,
+		blobStore:               blobStore,
 	}
 }
 
@@ -183,6 +210,7 @@ const (
 	// The result types for apply op failures.
 	ApplyOrReportDiffResTypeDecodingErred                  ManifestProcessingApplyOrReportDiffResultType = "DecodingErred"
 	ApplyOrReportDiffResTypeFoundGenerateName              ManifestProcessingApplyOrReportDiffResultType = "FoundGenerateName"
+	ApplyOrReportDiffResTypeInvalidApplyWave               ManifestProcessingApplyOrReportDiffResultType = "InvalidApplyWave"
 	ApplyOrReportDiffResTypeDuplicated                     ManifestProcessingApplyOrReportDiffResultType = "Duplicated"
 	ApplyOrReportDiffResTypeFailedToFindObjInMemberCluster ManifestProcessingApplyOrReportDiffResultType = "FailedToFindObjInMemberCluster"
 	ApplyOrReportDiffResTypeFailedToTakeOver               ManifestProcessingApplyOrReportDiffResultType = "FailedToTakeOver"
@@ -190,6 +218,7 @@ const (
 	ApplyOrReportDiffResTypeFailedToRunDriftDetection      ManifestProcessingApplyOrReportDiffResultType = "FailedToRunDriftDetection"
 	ApplyOrReportDiffResTypeFoundDrifts                    ManifestProcessingApplyOrReportDiffResultType = "FoundDrifts"
 	ApplyOrReportDiffResTypeFoundDriftsInDegradedMode      ManifestProcessingApplyOrReportDiffResultType = "FoundDriftsInDegradedMode"
+	ApplyOrReportDiffResTypeWaitingForPreviousWave         ManifestProcessingApplyOrReportDiffResultType = "WaitingForPreviousWave"
 	// Note that the reason string below uses the same value as kept in the old work applier.
 	ApplyOrReportDiffResTypeFailedToApply ManifestProcessingApplyOrReportDiffResultType = "ManifestApplyFailed"
 
@@ -229,6 +258,7 @@ var (
 	manifestProcessingApplyResTypSet = set.New(
 		ApplyOrReportDiffResTypeDecodingErred,
 		ApplyOrReportDiffResTypeFoundGenerateName,
+		ApplyOrReportDiffResTypeInvalidApplyWave,
 		ApplyOrReportDiffResTypeDuplicated,
 		ApplyOrReportDiffResTypeFailedToFindObjInMemberCluster,
 		ApplyOrReportDiffResTypeFailedToTakeOver,
@@ -236,6 +266,7 @@ var (
 		ApplyOrReportDiffResTypeFailedToRunDriftDetection,
 		ApplyOrReportDiffResTypeFoundDrifts,
 		ApplyOrReportDiffResTypeFoundDriftsInDegradedMode,
+		ApplyOrReportDiffResTypeWaitingForPreviousWave,
 		ApplyOrReportDiffResTypeFailedToApply,
 		ApplyOrReportDiffResTypeAppliedWithFailedDriftDetection,
 		ApplyOrReportDiffResTypeApplied,
@@ -301,6 +332,9 @@ type manifestProcessingBundle struct {
 	// Configuration drifts/diffs detected during the apply op or the diff reporting op.
 	drifts []fleetv1beta1.PatchDetail
 	diffs  []fleetv1beta1.PatchDetail
+	// The drift remediation to perform with the apply op, if the drifts found in the pre-apply
+	// drift detection are to be reverted per the drift remediation policy.
+	driftRemediation *fleetv1beta1.DriftRemediation
 }
 
 // Reconcile implement the control loop logic for Work object.
@@ -365,8 +399,18 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	// TO-DO (chenyu1): evaluate if it is necessary to add support for objects with generate
 	// names.
 
+	// Decompress the manifests if they are kept in the compressed form.
+	//
+	// Note that the reconciliation loop must stop here if the manifests cannot be retrieved; otherwise
+	// the previously applied manifests would be considered as left over and get removed.
+	manifests, err := resource.ManifestsOf(ctx, &work.Spec.Workload, r.blobStore)
+	if err != nil {
+		klog.ErrorS(err, "Failed to retrieve the manifests in the Work object", "work", workRef)
+		return ctrl.Result{}, err
+	}
+
 	// Prepare the bundles.
-	bundles := prepareManifestProcessingBundles(work)
+	bundles := prepareManifestProcessingBundles(manifests)
 
 	// Pre-process the manifests to apply.
 	//
@@ -606,11 +650,11 @@ func (r *Reconciler) ensureAppliedWork(ctx context.Context, work *fleetv1beta1.W
 }
 
 // prepareManifestProcessingBundles prepares the manifest processing bundles.
-func prepareManifestProcessingBundles(work *fleetv1beta1.Work) []*manifestProcessingBundle {
+func prepareManifestProcessingBundles(manifests []fleetv1beta1.Manifest) []*manifestProcessingBundle {
 	// Pre-allocate the bundles.
-	bundles := make([]*manifestProcessingBundle, 0, len(work.Spec.Workload.Manifests))
-	for idx := range work.Spec.Workload.Manifests {
-		manifest := work.Spec.Workload.Manifests[idx]
+	bundles := make([]*manifestProcessingBundle, 0, len(manifests))
+	for idx := range manifests {
+		manifest := manifests[idx]
 		bundles = append(bundles, &manifestProcessingBundle{
 			manifest: &manifest,
 		})
@@ -331,7 +331,7 @@ func TestPrepareManifestProcessingBundles(t *testing.T) {
 		},
 	}
 
-	bundles := prepareManifestProcessingBundles(work)
+	bundles := prepareManifestProcessingBundles(work.Spec.Workload.Manifests)
 	wantBundles := []*manifestProcessingBundle{
 		{
 			manifest: &work.Spec.Workload.Manifests[0],
@@ -91,7 +91,7 @@ func (r *Reconciler) takeOverPreExistingObject(
 	//
 	// Note that the default takeover action is AlwaysApply.
 	if applyStrategy.WhenToTakeOver == fleetv1beta1.WhenToTakeOverTypeIfNoDiff {
-		configDiffs, diffCalculatedInDegradedMode, err := r.diffBetweenManifestAndInMemberClusterObjects(ctx, gvr, manifestObj, inMemberClusterObjCopy, applyStrategy.ComparisonOption)
+		configDiffs, diffCalculatedInDegradedMode, err := r.diffBetweenManifestAndInMemberClusterObjects(ctx, gvr, manifestObj, inMemberClusterObjCopy, applyStrategy)
 		switch {
 		case err != nil:
 			return nil, nil, false, fmt.Errorf("failed to calculate configuration diffs between the manifest object and the object from the member cluster: %w", err)
@@ -115,24 +115,48 @@ func (r *Reconciler) takeOverPreExistingObject(
 }
 
 // diffBetweenManifestAndInMemberClusterObjects calculates the differences between the manifest object
-// and its corresponding object in the member cluster.
+// and its corresponding object in the member cluster, in accordance with the comparison option and
+// the ignore difference rules in the apply strategy.
 func (r *Reconciler) diffBetweenManifestAndInMemberClusterObjects(
 	ctx context.Context,
 	gvr *schema.GroupVersionResource,
 	manifestObj, inMemberClusterObj *unstructured.Unstructured,
-	cmpOption fleetv1beta1.ComparisonOptionType,
+	applyStrategy *fleetv1beta1.ApplyStrategy,
 ) ([]fleetv1beta1.PatchDetail, bool, error) {
-	switch cmpOption {
+	var patchDetails []fleetv1beta1.PatchDetail
+	var diffCalculatedInDegradedMode bool
+	var err error
+	switch applyStrategy.ComparisonOption {
 	case fleetv1beta1.ComparisonOptionTypePartialComparison:
-		return r.partialDiffBetweenManifestAndInMemberClusterObjects(ctx, gvr, manifestObj, inMemberClusterObj)
+		patchDetails, diffCalculatedInDegradedMode, err = r.partialDiffBetweenManifestAndInMemberClusterObjects(ctx, gvr, manifestObj, inMemberClusterObj)
 	case fleetv1beta1.ComparisonOptionTypeFullComparison:
 		// For the full comparison, Fleet compares directly the JSON representations of the
 		// manifest object and the object in the member cluster.
-		patchDetails, err := preparePatchDetails(manifestObj, inMemberClusterObj)
-		return patchDetails, false, err
+		patchDetails, err = preparePatchDetails(manifestObj, inMemberClusterObj)
 	default:
 		return nil, false, fmt.Errorf("an invalid comparison option is specified")
 	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Drop the differences that the user has asked Fleet to ignore.
+	patchDetails, err = removeIgnoredDifferences(applyStrategy.IgnoreDifferences, manifestObj, inMemberClusterObj, patchDetails)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to remove ignored differences: %w", err)
+	}
+	if len(patchDetails) == 0 {
+		return patchDetails, diffCalculatedInDegradedMode, nil
+	}
+
+	// Redact the values that the user-specified redaction rules cover, in addition to the
+	// built-in redaction of sensitive fields.
+	redactionRules, err := r.listRedactionRules(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to list redaction rules: %w", err)
+	}
+	patchDetails = redactPatchDetailsByRules(redactionRules.rulesFor(manifestObj.GroupVersionKind()), patchDetails, r.redactionHashKey)
+	return patchDetails, diffCalculatedInDegradedMode, nil
 }
 
 // partialDiffBetweenManifestAndInMemberClusterObjects calculates the differences between the
@@ -407,10 +431,10 @@ func obscureSensitiveFieldsInPatchDetails(
 			// Obscure all patch details that concerns the Secret object's data.
 
 			if len(pd.ValueInHub) > 0 {
-				pd.ValueInHub = "(redacted for security reasons)"
+				pd.ValueInHub = redactedValuePlaceholder
 			}
 			if len(pd.ValueInMember) > 0 {
-				pd.ValueInMember = "(redacted for security reasons)"
+				pd.ValueInMember = redactedValuePlaceholder
 			}
 		}
 	}
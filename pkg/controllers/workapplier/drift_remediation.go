@@ -0,0 +1,129 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workapplier
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/defaulter"
+)
+
+const (
+	// maxDriftRemediationHistoryLength is the maximum number of drift remediations kept in the
+	// status of a manifest.
+	maxDriftRemediationHistoryLength = 5
+	// maxRemediatedDriftPaths is the maximum number of drifted field paths kept in a drift
+	// remediation record.
+	maxRemediatedDriftPaths = 20
+
+	driftRemediatedEventReason = "DriftRemediated"
+)
+
+// prepareDriftRemediationIfApplicable checks if the drifts found on a resource should be
+// reverted, as dictated by the drift remediation policy in the apply strategy; if so,
+// it returns a record of the remediation to perform.
+func prepareDriftRemediationIfApplicable(
+	bundle *manifestProcessingBundle,
+	work *fleetv1beta1.Work,
+	drifts []fleetv1beta1.PatchDetail,
+	now time.Time,
+) *fleetv1beta1.DriftRemediation {
+	policy := work.Spec.ApplyStrategy.DriftRemediation
+	if policy == nil || len(drifts) == 0 {
+		return nil
+	}
+	if isDriftRemediationSkipped(bundle) {
+		klog.V(2).InfoS("Drift remediation is skipped as requested by the annotation",
+			"manifestObj", klog.KObj(bundle.manifestObj), "work", klog.KObj(work))
+		return nil
+	}
+
+	// Find out when the drifts were first observed.
+	firstDriftedObservedTime := metav1.NewTime(now)
+	for idx := range work.Status.ManifestConditions {
+		manifestCond := &work.Status.ManifestConditions[idx]
+		if manifestCond.DriftDetails == nil || manifestCond.DriftDetails.FirstDriftedObservedTime.IsZero() {
+			continue
+		}
+		wriStr, err := formatWRIString(&manifestCond.Identifier)
+		if err != nil || wriStr != bundle.workResourceIdentifierStr {
+			continue
+		}
+		firstDriftedObservedTime = manifestCond.DriftDetails.FirstDriftedObservedTime
+		break
+	}
+
+	gracePeriodSeconds := ptr.Deref(policy.GracePeriodSeconds, int32(defaulter.DefaultDriftRemediationGracePeriodSeconds))
+	gracePeriod := time.Duration(gracePeriodSeconds) * time.Second
+	if now.Sub(firstDriftedObservedTime.Time) < gracePeriod {
+		klog.V(2).InfoS("Drifts are found but the remediation grace period has not elapsed yet",
+			"manifestObj", klog.KObj(bundle.manifestObj), "work", klog.KObj(work),
+			"firstDriftedObservedTime", firstDriftedObservedTime, "gracePeriod", gracePeriod)
+		return nil
+	}
+
+	paths := make([]string, 0, min(len(drifts), maxRemediatedDriftPaths))
+	for idx := 0; idx < len(drifts) && idx < maxRemediatedDriftPaths; idx++ {
+		paths = append(paths, drifts[idx].Path)
+	}
+	var observedInMemberClusterGen int64
+	if bundle.inMemberClusterObj != nil {
+		observedInMemberClusterGen = bundle.inMemberClusterObj.GetGeneration()
+	}
+	return &fleetv1beta1.DriftRemediation{
+		RemediationTime:                   metav1.NewTime(now),
+		FirstDriftedObservedTime:          firstDriftedObservedTime,
+		ObservedInMemberClusterGeneration: observedInMemberClusterGen,
+		RemediatedDriftPaths:              paths,
+	}
+}
+
+// isDriftRemediationSkipped returns whether a resource has opted out of drift remediation,
+// either in its manifest or on the member cluster side.
+func isDriftRemediationSkipped(bundle *manifestProcessingBundle) bool {
+	if bundle.manifestObj != nil && bundle.manifestObj.GetAnnotations()[fleetv1beta1.SkipDriftRemediationAnnotation] == "true" {
+		return true
+	}
+	return bundle.inMemberClusterObj != nil && bundle.inMemberClusterObj.GetAnnotations()[fleetv1beta1.SkipDriftRemediationAnnotation] == "true"
+}
+
+// recordDriftRemediationEvent emits an event on the Work object about a completed drift remediation.
+func (r *Reconciler) recordDriftRemediationEvent(work *fleetv1beta1.Work, bundle *manifestProcessingBundle) {
+	if r.recorder == nil {
+		return
+	}
+	r.recorder.Eventf(work, corev1.EventTypeNormal, driftRemediatedEventReason,
+		"Reverted the drifts on %s, which were first observed at %s",
+		bundle.workResourceIdentifierStr,
+		bundle.driftRemediation.FirstDriftedObservedTime.UTC().Format(time.RFC3339))
+}
+
+// appendDriftRemediationHistory appends a drift remediation to the history, dropping the
+// oldest entries if the history grows too long.
+func appendDriftRemediationHistory(history []fleetv1beta1.DriftRemediation, remediation fleetv1beta1.DriftRemediation) []fleetv1beta1.DriftRemediation {
+	history = append(history, remediation)
+	if len(history) > maxDriftRemediationHistoryLength {
+		history = history[len(history)-maxDriftRemediationHistoryLength:]
+	}
+	return history
+}
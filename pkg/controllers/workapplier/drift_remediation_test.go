@@ -0,0 +1,159 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workapplier
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// TestPrepareDriftRemediationIfApplicable tests the prepareDriftRemediationIfApplicable function.
+func TestPrepareDriftRemediationIfApplicable(t *testing.T) {
+	now := time.Now()
+	wri := fleetv1beta1.WorkResourceIdentifier{
+		Group:     "apps",
+		Version:   "v1",
+		Kind:      "Deployment",
+		Resource:  "deployments",
+		Name:      "web",
+		Namespace: nsName,
+	}
+	wriStr, err := formatWRIString(&wri)
+	if err != nil {
+		t.Fatalf("formatWRIString() = %v, want no error", err)
+	}
+	drifts := []fleetv1beta1.PatchDetail{
+		{Path: "/spec/replicas", ValueInHub: "1", ValueInMember: "3"},
+	}
+	newBundle := func(annotations map[string]string) *manifestProcessingBundle {
+		obj := &unstructured.Unstructured{}
+		obj.SetGeneration(2)
+		obj.SetAnnotations(annotations)
+		return &manifestProcessingBundle{
+			id:                        &wri,
+			workResourceIdentifierStr: wriStr,
+			manifestObj:               &unstructured.Unstructured{},
+			inMemberClusterObj:        obj,
+		}
+	}
+	newWork := func(policy *fleetv1beta1.DriftRemediationPolicy, firstDriftedObservedTime time.Time) *fleetv1beta1.Work {
+		work := &fleetv1beta1.Work{
+			Spec: fleetv1beta1.WorkSpec{
+				ApplyStrategy: &fleetv1beta1.ApplyStrategy{
+					WhenToApply:      fleetv1beta1.WhenToApplyTypeIfNotDrifted,
+					DriftRemediation: policy,
+				},
+			},
+		}
+		if !firstDriftedObservedTime.IsZero() {
+			work.Status.ManifestConditions = []fleetv1beta1.ManifestCondition{
+				{
+					Identifier: wri,
+					DriftDetails: &fleetv1beta1.DriftDetails{
+						FirstDriftedObservedTime: metav1.NewTime(firstDriftedObservedTime),
+					},
+				},
+			}
+		}
+		return work
+	}
+	oneHourPolicy := &fleetv1beta1.DriftRemediationPolicy{GracePeriodSeconds: ptr.To(int32(3600))}
+
+	testCases := []struct {
+		name   string
+		bundle *manifestProcessingBundle
+		work   *fleetv1beta1.Work
+		want   *fleetv1beta1.DriftRemediation
+	}{
+		{
+			name:   "no remediation policy",
+			bundle: newBundle(nil),
+			work:   newWork(nil, now.Add(-2*time.Hour)),
+		},
+		{
+			name:   "grace period not elapsed",
+			bundle: newBundle(nil),
+			work:   newWork(oneHourPolicy, now.Add(-30*time.Minute)),
+		},
+		{
+			name:   "drifts first observed now",
+			bundle: newBundle(nil),
+			work:   newWork(oneHourPolicy, time.Time{}),
+		},
+		{
+			name:   "opted out with annotation",
+			bundle: newBundle(map[string]string{fleetv1beta1.SkipDriftRemediationAnnotation: "true"}),
+			work:   newWork(oneHourPolicy, now.Add(-2*time.Hour)),
+		},
+		{
+			name:   "grace period elapsed",
+			bundle: newBundle(nil),
+			work:   newWork(oneHourPolicy, now.Add(-2*time.Hour)),
+			want: &fleetv1beta1.DriftRemediation{
+				RemediationTime:                   metav1.NewTime(now),
+				FirstDriftedObservedTime:          metav1.NewTime(now.Add(-2 * time.Hour)),
+				ObservedInMemberClusterGeneration: 2,
+				RemediatedDriftPaths:              []string{"/spec/replicas"},
+			},
+		},
+		{
+			name:   "zero grace period",
+			bundle: newBundle(nil),
+			work:   newWork(&fleetv1beta1.DriftRemediationPolicy{GracePeriodSeconds: ptr.To(int32(0))}, time.Time{}),
+			want: &fleetv1beta1.DriftRemediation{
+				RemediationTime:                   metav1.NewTime(now),
+				FirstDriftedObservedTime:          metav1.NewTime(now),
+				ObservedInMemberClusterGeneration: 2,
+				RemediatedDriftPaths:              []string{"/spec/replicas"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := prepareDriftRemediationIfApplicable(tc.bundle, tc.work, drifts, now)
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("prepareDriftRemediationIfApplicable() mismatch (-got, +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestAppendDriftRemediationHistory tests the appendDriftRemediationHistory function.
+func TestAppendDriftRemediationHistory(t *testing.T) {
+	newRemediation := func(gen int64) fleetv1beta1.DriftRemediation {
+		return fleetv1beta1.DriftRemediation{ObservedInMemberClusterGeneration: gen}
+	}
+
+	var history []fleetv1beta1.DriftRemediation
+	for gen := int64(1); gen <= 7; gen++ {
+		history = appendDriftRemediationHistory(history, newRemediation(gen))
+	}
+	want := []fleetv1beta1.DriftRemediation{
+		newRemediation(3), newRemediation(4), newRemediation(5), newRemediation(6), newRemediation(7),
+	}
+	if diff := cmp.Diff(history, want); diff != "" {
+		t.Errorf("appendDriftRemediationHistory() mismatch (-got, +want):\n%s", diff)
+	}
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workapplier
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/structured-merge-diff/v6/fieldpath"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// ignoreDifferencesRulesFor returns the ignore difference rules that apply to the given object.
+func ignoreDifferencesRulesFor(rules []fleetv1beta1.IgnoreDifferenceRule, obj *unstructured.Unstructured) []*fleetv1beta1.IgnoreDifferenceRule {
+	gvk := obj.GroupVersionKind()
+	var matched []*fleetv1beta1.IgnoreDifferenceRule
+	for idx := range rules {
+		rule := &rules[idx]
+		if rule.Group != gvk.Group || rule.Kind != gvk.Kind {
+			continue
+		}
+		if len(rule.Name) > 0 && rule.Name != obj.GetName() {
+			continue
+		}
+		if len(rule.Namespace) > 0 && rule.Namespace != obj.GetNamespace() {
+			continue
+		}
+		matched = append(matched, rule)
+	}
+	return matched
+}
+
+// removeIgnoredDifferences removes the patch details that are covered by the ignore difference
+// rules from the list of patch details.
+//
+// inMemberClusterObj is the object in the member cluster; its managed fields are used to
+// evaluate the managed fields managers specified in the rules.
+func removeIgnoredDifferences(
+	rules []fleetv1beta1.IgnoreDifferenceRule,
+	manifestObj, inMemberClusterObj *unstructured.Unstructured,
+	details []fleetv1beta1.PatchDetail,
+) ([]fleetv1beta1.PatchDetail, error) {
+	matchedRules := ignoreDifferencesRulesFor(rules, manifestObj)
+	if len(matchedRules) == 0 {
+		return details, nil
+	}
+
+	var pointers []string
+	var managers []string
+	for _, rule := range matchedRules {
+		pointers = append(pointers, rule.JSONPointers...)
+		managers = append(managers, rule.ManagedFieldsManagers...)
+	}
+	managedFields, err := fieldsManagedBy(inMemberClusterObj, managers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve the fields managed by %v: %w", managers, err)
+	}
+
+	kept := make([]fleetv1beta1.PatchDetail, 0, len(details))
+	for _, pd := range details {
+		ignored := slices.ContainsFunc(pointers, func(p string) bool {
+			return jsonPointerCoversPath(p, pd.Path)
+		})
+		if !ignored && managedFields != nil {
+			ignored = managedFieldsCoverPath(managedFields, inMemberClusterObj.Object, pd.Path)
+		}
+		if ignored {
+			klog.V(2).InfoS("Ignore a difference as specified in the apply strategy",
+				"manifestObj", klog.KObj(manifestObj), "path", pd.Path)
+			continue
+		}
+		kept = append(kept, pd)
+	}
+	return kept, nil
+}
+
+// fieldsManagedBy returns the set of fields in an object that are managed by any of the given
+// field managers, or nil if no managers are given.
+func fieldsManagedBy(obj *unstructured.Unstructured, managers []string) (*fieldpath.Set, error) {
+	if len(managers) == 0 || obj == nil {
+		return nil, nil
+	}
+
+	managed := &fieldpath.Set{}
+	for _, entry := range obj.GetManagedFields() {
+		if !slices.Contains(managers, entry.Manager) || entry.FieldsV1 == nil {
+			continue
+		}
+		fields := &fieldpath.Set{}
+		if err := fields.FromJSON(bytes.NewReader(entry.FieldsV1.Raw)); err != nil {
+			return nil, fmt.Errorf("failed to parse the managed fields of manager %s: %w", entry.Manager, err)
+		}
+		managed = managed.Union(fields)
+	}
+	return managed, nil
+}
+
+// managedFieldsCoverPath returns whether the field at the given path (a JSON pointer) or any
+// of its parent fields is in the set of managed fields.
+//
+// The object is used to translate list indices in the path to the path elements that managed
+// fields use for list items (e.g., keys for associative lists).
+func managedFieldsCoverPath(managed *fieldpath.Set, obj interface{}, path string) bool {
+	cur := obj
+	set := managed
+	for _, seg := range strings.Split(strings.TrimPrefix(path, "/"), "/") {
+		seg = strings.ReplaceAll(strings.ReplaceAll(seg, "~1", "/"), "~0", "~")
+
+		var pe fieldpath.PathElement
+		switch v := cur.(type) {
+		case map[string]interface{}:
+			pe = fieldpath.PathElement{FieldName: &seg}
+			cur = v[seg]
+		case []interface{}:
+			idx, err := strconv.Atoi(seg)
+			if err != nil || idx < 0 || idx >= len(v) {
+				return false
+			}
+			var found bool
+			if pe, found = findListItemPathElement(set, v[idx], idx); !found {
+				return false
+			}
+			cur = v[idx]
+		default:
+			return false
+		}
+
+		if set.Members.Has(pe) {
+			return true
+		}
+		child, ok := set.Children.Get(pe)
+		if !ok {
+			return false
+		}
+		set = child
+	}
+	return false
+}
+
+// findListItemPathElement finds the path element in the set of managed fields that refers to
+// the given list item.
+func findListItemPathElement(set *fieldpath.Set, item interface{}, idx int) (fieldpath.PathElement, bool) {
+	var found fieldpath.PathElement
+	var ok bool
+	matches := func(pe fieldpath.PathElement) {
+		if ok {
+			return
+		}
+		switch {
+		case pe.Index != nil:
+			ok = *pe.Index == idx
+		case pe.Value != nil:
+			ok = reflect.DeepEqual((*pe.Value).Unstructured(), item)
+		case pe.Key != nil:
+			itemMap, isMap := item.(map[string]interface{})
+			if !isMap {
+				return
+			}
+			ok = true
+			for _, f := range *pe.Key {
+				if !reflect.DeepEqual(f.Value.Unstructured(), itemMap[f.Name]) {
+					ok = false
+					break
+				}
+			}
+		}
+		if ok {
+			found = pe
+		}
+	}
+	set.Members.Iterate(matches)
+	set.Children.Iterate(matches)
+	return found, ok
+}
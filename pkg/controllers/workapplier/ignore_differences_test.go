@@ -0,0 +1,153 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workapplier
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+func newIgnoreDifferencesTestDeployment(managedFields []metav1.ManagedFieldsEntry) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]interface{}{
+				"name":      "web",
+				"namespace": nsName,
+			},
+			"spec": map[string]interface{}{
+				"replicas": int64(3),
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"containers": []interface{}{
+							map[string]interface{}{
+								"name":  "web",
+								"image": "nginx",
+							},
+							map[string]interface{}{
+								"name":  "istio-proxy",
+								"image": "istio/proxyv2",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	obj.SetManagedFields(managedFields)
+	return obj
+}
+
+// TestRemoveIgnoredDifferences tests the removeIgnoredDifferences function.
+func TestRemoveIgnoredDifferences(t *testing.T) {
+	details := []fleetv1beta1.PatchDetail{
+		{Path: "/spec/replicas", ValueInHub: "1", ValueInMember: "3"},
+		{Path: "/spec/template/spec/containers/0/image", ValueInHub: "nginx:1.27", ValueInMember: "nginx"},
+		{Path: "/spec/template/spec/containers/1", ValueInMember: "map[image:istio/proxyv2 name:istio-proxy]"},
+	}
+	sidecarManagedFields := []metav1.ManagedFieldsEntry{
+		{
+			Manager:    "sidecar-injector",
+			Operation:  metav1.ManagedFieldsOperationUpdate,
+			FieldsType: "FieldsV1",
+			FieldsV1: &metav1.FieldsV1{
+				Raw: []byte(`{"f:spec":{"f:template":{"f:spec":{"f:containers":{"k:{\"name\":\"istio-proxy\"}":{".":{},"f:image":{},"f:name":{}}}}}}}`),
+			},
+		},
+		{
+			Manager:    "hpa-controller",
+			Operation:  metav1.ManagedFieldsOperationUpdate,
+			FieldsType: "FieldsV1",
+			FieldsV1: &metav1.FieldsV1{
+				Raw: []byte(`{"f:spec":{"f:replicas":{}}}`),
+			},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		rules         []fleetv1beta1.IgnoreDifferenceRule
+		managedFields []metav1.ManagedFieldsEntry
+		want          []fleetv1beta1.PatchDetail
+	}{
+		{
+			name: "no rules",
+			want: details,
+		},
+		{
+			name: "rule for a different kind",
+			rules: []fleetv1beta1.IgnoreDifferenceRule{
+				{Group: "apps", Kind: "StatefulSet", JSONPointers: []string{"/spec/replicas"}},
+			},
+			want: details,
+		},
+		{
+			name: "rule for a different name",
+			rules: []fleetv1beta1.IgnoreDifferenceRule{
+				{Group: "apps", Kind: "Deployment", Name: "api", JSONPointers: []string{"/spec/replicas"}},
+			},
+			want: details,
+		},
+		{
+			name: "JSON pointers",
+			rules: []fleetv1beta1.IgnoreDifferenceRule{
+				{Group: "apps", Kind: "Deployment", Name: "web", Namespace: nsName, JSONPointers: []string{"/spec/replicas", "/spec/template/spec/containers/1"}},
+			},
+			want: []fleetv1beta1.PatchDetail{
+				{Path: "/spec/template/spec/containers/0/image", ValueInHub: "nginx:1.27", ValueInMember: "nginx"},
+			},
+		},
+		{
+			name: "managed fields managers",
+			rules: []fleetv1beta1.IgnoreDifferenceRule{
+				{Group: "apps", Kind: "Deployment", ManagedFieldsManagers: []string{"sidecar-injector", "hpa-controller"}},
+			},
+			managedFields: sidecarManagedFields,
+			want: []fleetv1beta1.PatchDetail{
+				{Path: "/spec/template/spec/containers/0/image", ValueInHub: "nginx:1.27", ValueInMember: "nginx"},
+			},
+		},
+		{
+			name: "managed fields managers with no managed fields",
+			rules: []fleetv1beta1.IgnoreDifferenceRule{
+				{Group: "apps", Kind: "Deployment", ManagedFieldsManagers: []string{"sidecar-injector"}},
+			},
+			managedFields: sidecarManagedFields[1:],
+			want:          details,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			manifestObj := newIgnoreDifferencesTestDeployment(nil)
+			inMemberClusterObj := newIgnoreDifferencesTestDeployment(tc.managedFields)
+			got, err := removeIgnoredDifferences(tc.rules, manifestObj, inMemberClusterObj, append([]fleetv1beta1.PatchDetail{}, details...))
+			if err != nil {
+				t.Fatalf("removeIgnoredDifferences() = %v, want no error", err)
+			}
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("removeIgnoredDifferences() mismatch (-got, +want):\n%s", diff)
+			}
+		})
+	}
+}
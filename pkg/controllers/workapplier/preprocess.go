@@ -29,6 +29,7 @@ import (
 	"k8s.io/klog/v2"
 
 	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/blobstore"
 	"go.goms.io/fleet/pkg/utils/condition"
 	"go.goms.io/fleet/pkg/utils/controller"
 	"go.goms.io/fleet/pkg/utils/defaulter"
@@ -55,7 +56,7 @@ func (r *Reconciler) preProcessManifests(
 		// At this moment the bundles are just created.
 		bundle := bundles[pieces]
 
-		gvr, manifestObj, err := r.decodeManifest(bundle.manifest)
+		gvr, manifestObj, err := r.decodeManifest(childCtx, bundle.manifest)
 		// Build the identifier. Note that this would return an identifier even if the decoding
 		// fails.
 		bundle.id = buildWorkResourceIdentifier(pieces, gvr, manifestObj)
@@ -75,6 +76,14 @@ func (r *Reconciler) preProcessManifests(
 			return
 		}
 
+		// Reject objects with an invalid apply wave annotation.
+		if _, _, err := userDefinedWaveNumberOf(manifestObj); err != nil {
+			klog.V(2).InfoS("Rejected an object with an invalid apply wave annotation", "manifestObj", klog.KObj(manifestObj), "work", klog.KObj(work), "err", err)
+			bundle.applyOrReportDiffErr = err
+			bundle.applyOrReportDiffResTyp = ApplyOrReportDiffResTypeInvalidApplyWave
+			return
+		}
+
 		bundle.manifestObj = manifestObj
 		bundle.gvr = gvr
 
@@ -222,9 +231,19 @@ func (r *Reconciler) writeAheadManifestProcessingAttempts(
 }
 
 // Decodes the manifest JSON into a Kubernetes unstructured object.
-func (r *Reconciler) decodeManifest(manifest *fleetv1beta1.Manifest) (*schema.GroupVersionResource, *unstructured.Unstructured, error) {
+//
+// If the manifest is a reference to content in the blob store, the content is fetched and decoded instead.
+func (r *Reconciler) decodeManifest(ctx context.Context, manifest *fleetv1beta1.Manifest) (*schema.GroupVersionResource, *unstructured.Unstructured, error) {
+	if r.blobStore == nil && blobstore.IsReference(manifest.Raw) {
+		// Do not apply the reference itself, which carries none of the content of the manifest.
+		return &schema.GroupVersionResource{}, nil, fmt.Errorf("failed to fetch the manifest from the blob store: %w", blobstore.ErrNoStore)
+	}
+	raw, err := blobstore.Resolve(ctx, r.blobStore, manifest.Raw)
+	if err != nil {
+		return &schema.GroupVersionResource{}, nil, fmt.Errorf("failed to fetch the manifest from the blob store: %w", err)
+	}
 	unstructuredObj := &unstructured.Unstructured{}
-	if err := unstructuredObj.UnmarshalJSON(manifest.Raw); err != nil {
+	if err := unstructuredObj.UnmarshalJSON(raw); err != nil {
 		return &schema.GroupVersionResource{}, nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
 	}
 
@@ -19,6 +19,7 @@ package workapplier
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -34,6 +35,7 @@ import (
 	"k8s.io/klog/v2"
 
 	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/blobstore"
 	"go.goms.io/fleet/pkg/utils/condition"
 	"go.goms.io/fleet/pkg/utils/parallelizer"
 )
@@ -89,6 +91,26 @@ func TestBuildWorkResourceIdentifier(t *testing.T) {
 	}
 }
 
+// TestDecodeManifest_ReferenceWithoutBlobStore tests that the decodeManifest method does not decode
+// a reference to a blob when no blob store is in use.
+func TestDecodeManifest_ReferenceWithoutBlobStore(t *testing.T) {
+	ctx := context.Background()
+	raw := []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"big-config","namespace":"app"},"data":{"key":"` + strings.Repeat("a", 64) + `"}}`)
+	ref, err := blobstore.Offload(ctx, blobstore.NewMemoryStore(), raw, 16)
+	if err != nil {
+		t.Fatalf("Offload() = %v, want no error", err)
+	}
+
+	r := &Reconciler{}
+	_, obj, err := r.decodeManifest(ctx, &fleetv1beta1.Manifest{RawExtension: runtime.RawExtension{Raw: ref}})
+	if err == nil || !strings.Contains(err.Error(), blobstore.ErrNoStore.Error()) {
+		t.Errorf("decodeManifest() = %v, want %v", err, blobstore.ErrNoStore)
+	}
+	if obj != nil {
+		t.Errorf("decodeManifest() = %v, want no object", obj)
+	}
+}
+
 // TestCheckForDuplicatedManifests tests the checkForDuplicatedManifests function.
 func TestCheckForDuplicatedManifests(t *testing.T) {
 	wriStr1 := fmt.Sprintf("GV=/v1, Kind=Namespace, Namespace=, Name=%s", nsName)
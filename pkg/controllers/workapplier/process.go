@@ -19,6 +19,7 @@ package workapplier
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -77,6 +78,21 @@ func (r *Reconciler) processManifests(
 	for idx := range processingWaves {
 		bundlesInWave := processingWaves[idx].bundles
 
+		// If users have assigned objects to this wave explicitly (via the apply wave annotation),
+		// hold the wave (and all the waves after it) until all the objects in the previous waves
+		// have become available. The work applier will retry in the next reconciliation loop.
+		if idx > 0 && processingWaves[idx].hasUserDefinedWaveNum {
+			if err := r.trackInMemberClusterObjAvailability(ctx, flattenBundlesInWaves(processingWaves[:idx]), klog.KObj(work)); err != nil {
+				return err
+			}
+			if blockingWave, isBlocked := findFirstUnavailableWave(processingWaves[:idx]); isBlocked {
+				klog.V(2).InfoS("Some objects in a previous wave are not yet available; holding the remaining waves",
+					"waveNumber", processingWaves[idx].num, "blockingWaveNumber", blockingWave, "work", klog.KObj(work))
+				markBundlesAsWaitingForPreviousWave(processingWaves[idx:], blockingWave)
+				return nil
+			}
+		}
+
 		// TO-DO (chenyu1): evaluate if there is a need to avoid repeated closure
 		// assignment just for capturing variables.
 		doWork := func(piece int) {
@@ -107,6 +123,39 @@ func (r *Reconciler) processManifests(
 	return nil
 }
 
+// flattenBundlesInWaves returns all the bundles in the given waves.
+func flattenBundlesInWaves(waves []*bundleProcessingWave) []*manifestProcessingBundle {
+	bundles := make([]*manifestProcessingBundle, 0, len(waves)*5)
+	for _, wave := range waves {
+		bundles = append(bundles, wave.bundles...)
+	}
+	return bundles
+}
+
+// findFirstUnavailableWave returns the number of the first wave (if any) in which some objects
+// have not been applied or have not become available yet.
+func findFirstUnavailableWave(waves []*bundleProcessingWave) (waveNumber, bool) {
+	for _, wave := range waves {
+		for _, bundle := range wave.bundles {
+			if !isManifestObjectApplied(bundle.applyOrReportDiffResTyp) || !isAppliedObjectAvailable(bundle.availabilityResTyp) {
+				return wave.num, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// markBundlesAsWaitingForPreviousWave marks all the bundles in the given waves as not processed
+// as they are waiting for the objects in a previous wave to become available.
+func markBundlesAsWaitingForPreviousWave(waves []*bundleProcessingWave, blockingWave waveNumber) {
+	for _, wave := range waves {
+		for _, bundle := range wave.bundles {
+			bundle.applyOrReportDiffResTyp = ApplyOrReportDiffResTypeWaitingForPreviousWave
+			bundle.applyOrReportDiffErr = fmt.Errorf("waiting for the objects in apply wave %d to become available", blockingWave)
+		}
+	}
+}
+
 // processOneManifest processes a manifest (in the JSON format) embedded in the Work object.
 func (r *Reconciler) processOneManifest(
 	ctx context.Context,
@@ -178,6 +227,9 @@ func (r *Reconciler) processOneManifest(
 		// Update the bundle with the newly applied object, if an apply op has been run.
 		bundle.inMemberClusterObj = appliedObj
 	}
+	if bundle.driftRemediation != nil {
+		r.recordDriftRemediationEvent(work, bundle)
+	}
 	klog.V(2).InfoS("Apply process completed",
 		"manifestObj", manifestObjRef, "GVR", *bundle.gvr, "work", workRef)
 
@@ -374,7 +426,7 @@ func (r *Reconciler) reportDiffOnlyIfApplicable(
 	configDiffs, diffCalculatedInDegradedMode, err := r.diffBetweenManifestAndInMemberClusterObjects(ctx,
 		bundle.gvr,
 		bundle.manifestObj, bundle.inMemberClusterObj,
-		work.Spec.ApplyStrategy.ComparisonOption)
+		work.Spec.ApplyStrategy)
 	switch {
 	case err != nil:
 		// Failed to calculate the configuration diffs.
@@ -466,7 +518,16 @@ func (r *Reconciler) performPreApplyDriftDetectionIfApplicable(
 		drifts, driftsCalculatedInDegradedMode, err := r.diffBetweenManifestAndInMemberClusterObjects(ctx,
 			bundle.gvr,
 			bundle.manifestObj, bundle.inMemberClusterObj,
-			work.Spec.ApplyStrategy.ComparisonOption)
+			work.Spec.ApplyStrategy)
+		if err == nil {
+			// Check if the drifts should be reverted as dictated by the drift remediation policy.
+			if bundle.driftRemediation = prepareDriftRemediationIfApplicable(bundle, work, drifts, time.Now()); bundle.driftRemediation != nil {
+				klog.V(2).InfoS("Drifts are found; revert them as the remediation grace period has elapsed",
+					"work", klog.KObj(work), "GVR", *bundle.gvr, "manifestObj", klog.KObj(bundle.manifestObj),
+					"firstDriftedObservedTime", bundle.driftRemediation.FirstDriftedObservedTime)
+				return false
+			}
+		}
 		switch {
 		case err != nil:
 			// An unexpected error has occurred.
@@ -549,7 +610,7 @@ func (r *Reconciler) performPostApplyDriftDetectionIfApplicable(
 	drifts, driftsCalculatedInDegradedMode, err := r.diffBetweenManifestAndInMemberClusterObjects(ctx,
 		bundle.gvr,
 		bundle.manifestObj, bundle.inMemberClusterObj,
-		work.Spec.ApplyStrategy.ComparisonOption)
+		work.Spec.ApplyStrategy)
 	switch {
 	case err != nil:
 		// An unexpected error has occurred.
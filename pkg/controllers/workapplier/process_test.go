@@ -97,3 +97,126 @@ func TestShouldInitiateTakeOverAttempt(t *testing.T) {
 		})
 	}
 }
+
+// TestFindFirstUnavailableWave tests the findFirstUnavailableWave function.
+func TestFindFirstUnavailableWave(t *testing.T) {
+	testCases := []struct {
+		name          string
+		waves         []*bundleProcessingWave
+		wantWave      waveNumber
+		wantIsBlocked bool
+	}{
+		{
+			name: "all available",
+			waves: []*bundleProcessingWave{
+				{
+					num: 0,
+					bundles: []*manifestProcessingBundle{
+						{
+							applyOrReportDiffResTyp: ApplyOrReportDiffResTypeApplied,
+							availabilityResTyp:      AvailabilityResultTypeAvailable,
+						},
+					},
+				},
+				{
+					num: 1,
+					bundles: []*manifestProcessingBundle{
+						{
+							applyOrReportDiffResTyp: ApplyOrReportDiffResTypeAppliedWithFailedDriftDetection,
+							availabilityResTyp:      AvailabilityResultTypeNotTrackable,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "not yet available",
+			waves: []*bundleProcessingWave{
+				{
+					num: 1,
+					bundles: []*manifestProcessingBundle{
+						{
+							applyOrReportDiffResTyp: ApplyOrReportDiffResTypeApplied,
+							availabilityResTyp:      AvailabilityResultTypeAvailable,
+						},
+					},
+				},
+				{
+					num: 4,
+					bundles: []*manifestProcessingBundle{
+						{
+							applyOrReportDiffResTyp: ApplyOrReportDiffResTypeApplied,
+							availabilityResTyp:      AvailabilityResultTypeAvailable,
+						},
+						{
+							applyOrReportDiffResTyp: ApplyOrReportDiffResTypeApplied,
+							availabilityResTyp:      AvailabilityResultTypeNotYetAvailable,
+						},
+					},
+				},
+			},
+			wantWave:      4,
+			wantIsBlocked: true,
+		},
+		{
+			name: "failed to apply",
+			waves: []*bundleProcessingWave{
+				{
+					num: 2,
+					bundles: []*manifestProcessingBundle{
+						{
+							applyOrReportDiffResTyp: ApplyOrReportDiffResTypeFailedToApply,
+							availabilityResTyp:      AvailabilityResultTypeSkipped,
+						},
+					},
+				},
+				{
+					num: 3,
+					bundles: []*manifestProcessingBundle{
+						{
+							applyOrReportDiffResTyp: ApplyOrReportDiffResTypeApplied,
+							availabilityResTyp:      AvailabilityResultTypeNotYetAvailable,
+						},
+					},
+				},
+			},
+			wantWave:      2,
+			wantIsBlocked: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			wave, isBlocked := findFirstUnavailableWave(tc.waves)
+			if wave != tc.wantWave || isBlocked != tc.wantIsBlocked {
+				t.Errorf("findFirstUnavailableWave() = (%d, %t), want (%d, %t)", wave, isBlocked, tc.wantWave, tc.wantIsBlocked)
+			}
+		})
+	}
+}
+
+// TestMarkBundlesAsWaitingForPreviousWave tests the markBundlesAsWaitingForPreviousWave function.
+func TestMarkBundlesAsWaitingForPreviousWave(t *testing.T) {
+	waves := []*bundleProcessingWave{
+		{
+			num:     5,
+			bundles: []*manifestProcessingBundle{{}, {}},
+		},
+		{
+			num:     lastWave,
+			bundles: []*manifestProcessingBundle{{}},
+		},
+	}
+
+	markBundlesAsWaitingForPreviousWave(waves, 4)
+	for _, wave := range waves {
+		for idx, bundle := range wave.bundles {
+			if bundle.applyOrReportDiffResTyp != ApplyOrReportDiffResTypeWaitingForPreviousWave {
+				t.Errorf("wave %d, bundle %d: applyOrReportDiffResTyp = %s, want %s", wave.num, idx, bundle.applyOrReportDiffResTyp, ApplyOrReportDiffResTypeWaitingForPreviousWave)
+			}
+			if bundle.applyOrReportDiffErr == nil {
+				t.Errorf("wave %d, bundle %d: applyOrReportDiffErr = nil, want error", wave.num, idx)
+			}
+		}
+	}
+}
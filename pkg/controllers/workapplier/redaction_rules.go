@@ -0,0 +1,248 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workapplier
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+const (
+	redactedValuePlaceholder = "(redacted for security reasons)"
+	// hashedValueFmt is the format of redacted values in the Hash mode; only a prefix of the
+	// hex-encoded HMAC-SHA256 digest is kept, which suffices for telling whether a value has changed.
+	hashedValueFmt    = "(redacted for security reasons; hmac-sha256:%s)"
+	hashedValuePrefix = 16
+	// redactionHashKeySize is the size, in bytes, of the key with which values are hashed in the
+	// Hash mode.
+	redactionHashKeySize = 32
+
+	// RedactionHashKeySecretDataKey is the key of the data entry which keeps the redaction hash key
+	// in its Secret.
+	RedactionHashKeySecretDataKey = "key"
+)
+
+// newRedactionHashKey returns a random key with which the work applier hashes values in the Hash
+// mode. The key never leaves the member cluster, so that the hashed values cannot be brute-forced
+// by anyone who can only read the drift and diff details.
+func newRedactionHashKey() []byte {
+	key := make([]byte, redactionHashKeySize)
+	// rand.Read never returns an error.
+	_, _ = rand.Read(key)
+	return key
+}
+
+// LoadRedactionHashKey returns the key with which the work applier hashes values in the Hash mode,
+// which is kept in the given Secret in the member cluster; the Secret is created with a random key
+// if it does not exist yet. Keeping the key in a Secret makes the hashed values stable across
+// restarts of the member agent and across its replicas, so that a hash only changes when the value
+// changes.
+func LoadRedactionHashKey(ctx context.Context, memberClient client.Client, secretKey types.NamespacedName) ([]byte, error) {
+	secret := &corev1.Secret{}
+	err := memberClient.Get(ctx, secretKey, secret)
+	switch {
+	case apierrors.IsNotFound(err):
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      secretKey.Name,
+				Namespace: secretKey.Namespace,
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{
+				RedactionHashKeySecretDataKey: newRedactionHashKey(),
+			},
+		}
+		err = memberClient.Create(ctx, secret)
+		if apierrors.IsAlreadyExists(err) {
+			// Another replica has created the Secret in the meantime; use its key instead.
+			err = memberClient.Get(ctx, secretKey, secret)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the redaction hash key secret %s: %w", secretKey, err)
+		}
+		klog.V(2).InfoS("Created the redaction hash key secret", "secret", secretKey)
+	case err != nil:
+		return nil, fmt.Errorf("failed to get the redaction hash key secret %s: %w", secretKey, err)
+	}
+
+	key := secret.Data[RedactionHashKeySecretDataKey]
+	if len(key) < redactionHashKeySize {
+		return nil, fmt.Errorf("the redaction hash key in secret %s has %d bytes, want at least %d bytes in the %q entry", secretKey, len(key), redactionHashKeySize, RedactionHashKeySecretDataKey)
+	}
+	return key, nil
+}
+
+// redactionRuleSet is a set of redaction rules indexed by the group and kind of the resource
+// types they apply to.
+type redactionRuleSet map[schema.GroupKind][]*fleetv1beta1.RedactionRule
+
+// listRedactionRules lists all the redaction rules in the hub cluster, if the redaction rules
+// feature is enabled.
+func (r *Reconciler) listRedactionRules(ctx context.Context) (redactionRuleSet, error) {
+	if !r.enableRedactionRules {
+		return nil, nil
+	}
+
+	ruleList := &fleetv1beta1.RedactionRuleList{}
+	if err := r.hubClient.List(ctx, ruleList); err != nil {
+		klog.ErrorS(err, "Failed to list redaction rules")
+		return nil, controller.NewAPIServerError(true, err)
+	}
+	return newRedactionRuleSet(ruleList.Items), nil
+}
+
+// newRedactionRuleSet builds a redaction rule set from a list of rules.
+func newRedactionRuleSet(rules []fleetv1beta1.RedactionRule) redactionRuleSet {
+	ruleSet := make(redactionRuleSet, len(rules))
+	for idx := range rules {
+		rule := &rules[idx]
+		gk := schema.GroupKind{Group: rule.Spec.ResourceType.Group, Kind: rule.Spec.ResourceType.Kind}
+		ruleSet[gk] = append(ruleSet[gk], rule)
+	}
+	return ruleSet
+}
+
+// rulesFor returns all the redaction rules that apply to the given resource type.
+//
+// Unlike availability rules, all the matching redaction rules are in effect at the same time.
+func (s redactionRuleSet) rulesFor(gvk schema.GroupVersionKind) []*fleetv1beta1.RedactionRule {
+	var rules []*fleetv1beta1.RedactionRule
+	for _, rule := range s[gvk.GroupKind()] {
+		if len(rule.Spec.ResourceType.Version) == 0 || rule.Spec.ResourceType.Version == gvk.Version {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// redactPatchDetailsByRules redacts the values in the patch details that are covered by any of
+// the given redaction rules.
+//
+// If multiple rules cover the same patch detail, the Redact mode takes precedence over the Hash
+// mode, as it reveals less information. Values are hashed with the given key in the Hash mode.
+func redactPatchDetailsByRules(rules []*fleetv1beta1.RedactionRule, details []fleetv1beta1.PatchDetail, hashKey []byte) []fleetv1beta1.PatchDetail {
+	if len(rules) == 0 {
+		return details
+	}
+
+	for idx := range details {
+		pd := &details[idx]
+		mode, covered := redactionModeForPath(rules, pd.Path)
+		if !covered {
+			continue
+		}
+		pd.ValueInHub = redactValue(pd.ValueInHub, mode, hashKey)
+		pd.ValueInMember = redactValue(pd.ValueInMember, mode, hashKey)
+	}
+	return details
+}
+
+// redactionModeForPath returns the mode in which the value at the given path should be redacted,
+// and whether the path is covered by any of the rules at all.
+func redactionModeForPath(rules []*fleetv1beta1.RedactionRule, path string) (fleetv1beta1.RedactionMode, bool) {
+	var mode fleetv1beta1.RedactionMode
+	covered := false
+	for _, rule := range rules {
+		ruleCovers, err := redactionRuleCoversPath(rule, path)
+		if err != nil {
+			// Fail closed: if a rule cannot be evaluated, redact the value completely.
+			klog.ErrorS(err, "Failed to evaluate a redaction rule; redact the value completely", "redactionRule", klog.KObj(rule), "path", path)
+			return fleetv1beta1.RedactionModeRedact, true
+		}
+		if !ruleCovers {
+			continue
+		}
+		covered = true
+		if rule.Spec.Mode != fleetv1beta1.RedactionModeHash {
+			// An empty mode is considered to be the Redact mode.
+			return fleetv1beta1.RedactionModeRedact, true
+		}
+		mode = fleetv1beta1.RedactionModeHash
+	}
+	return mode, covered
+}
+
+// redactionRuleCoversPath returns whether a redaction rule covers the given path.
+func redactionRuleCoversPath(rule *fleetv1beta1.RedactionRule, path string) (bool, error) {
+	for _, p := range rule.Spec.Paths {
+		if jsonPointerCoversPath(p, path) {
+			return true, nil
+		}
+	}
+	for _, expr := range rule.Spec.PathRegexes {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return false, fmt.Errorf("invalid path regex %q: %w", expr, err)
+		}
+		if re.MatchString(path) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// jsonPointerCoversPath returns whether the given JSON pointer refers to the field at the given
+// path or any of its parent fields. The segment `*` in the pointer matches any single segment.
+func jsonPointerCoversPath(pointer, path string) bool {
+	if pointer == "" || pointer == "/" {
+		// The pointer refers to the whole object.
+		return true
+	}
+	pointerSegs := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	pathSegs := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	if len(pointerSegs) > len(pathSegs) {
+		return false
+	}
+	for idx, seg := range pointerSegs {
+		if seg != "*" && seg != pathSegs[idx] {
+			return false
+		}
+	}
+	return true
+}
+
+// redactValue redacts a value in the given mode, using the given key in the Hash mode. Empty
+// values (i.e., the field is absent on one side) are kept as they are.
+func redactValue(value string, mode fleetv1beta1.RedactionMode, hashKey []byte) string {
+	if len(value) == 0 {
+		return value
+	}
+	if mode == fleetv1beta1.RedactionModeHash {
+		mac := hmac.New(sha256.New, hashKey)
+		// Writes to a hash never return an error.
+		_, _ = mac.Write([]byte(value))
+		return fmt.Sprintf(hashedValueFmt, hex.EncodeToString(mac.Sum(nil))[:hashedValuePrefix])
+	}
+	return redactedValuePlaceholder
+}
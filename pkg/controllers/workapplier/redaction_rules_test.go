@@ -0,0 +1,278 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workapplier
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+func newRedactionRule(name, version string, mode fleetv1beta1.RedactionMode, paths, pathRegexes []string) fleetv1beta1.RedactionRule {
+	return fleetv1beta1.RedactionRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: fleetv1beta1.RedactionRuleSpec{
+			ResourceType: fleetv1beta1.RedactionRuleResourceType{
+				Group:   "admissionregistration.k8s.io",
+				Version: version,
+				Kind:    "ValidatingWebhookConfiguration",
+			},
+			Paths:       paths,
+			PathRegexes: pathRegexes,
+			Mode:        mode,
+		},
+	}
+}
+
+// TestRedactionRuleSetRulesFor tests the rulesFor method.
+func TestRedactionRuleSetRulesFor(t *testing.T) {
+	ruleSet := newRedactionRuleSet([]fleetv1beta1.RedactionRule{
+		newRedactionRule("all-versions", "", fleetv1beta1.RedactionModeRedact, []string{"/webhooks"}, nil),
+		newRedactionRule("v1", "v1", fleetv1beta1.RedactionModeRedact, []string{"/webhooks"}, nil),
+		newRedactionRule("v1beta1", "v1beta1", fleetv1beta1.RedactionModeRedact, []string{"/webhooks"}, nil),
+	})
+
+	testCases := []struct {
+		name      string
+		gvk       schema.GroupVersionKind
+		wantRules []string
+	}{
+		{
+			name:      "rules with matching or no versions",
+			gvk:       schema.GroupVersionKind{Group: "admissionregistration.k8s.io", Version: "v1", Kind: "ValidatingWebhookConfiguration"},
+			wantRules: []string{"all-versions", "v1"},
+		},
+		{
+			name:      "rules with no versions only",
+			gvk:       schema.GroupVersionKind{Group: "admissionregistration.k8s.io", Version: "v2", Kind: "ValidatingWebhookConfiguration"},
+			wantRules: []string{"all-versions"},
+		},
+		{
+			name: "no matching rules",
+			gvk:  schema.GroupVersionKind{Group: "admissionregistration.k8s.io", Version: "v1", Kind: "MutatingWebhookConfiguration"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotRules []string
+			for _, rule := range ruleSet.rulesFor(tc.gvk) {
+				gotRules = append(gotRules, rule.Name)
+			}
+			if diff := cmp.Diff(gotRules, tc.wantRules); diff != "" {
+				t.Errorf("rulesFor() mismatch (-got, +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestRedactPatchDetailsByRules tests the redactPatchDetailsByRules function.
+func TestRedactPatchDetailsByRules(t *testing.T) {
+	// The hashes are the first 16 hex digits of the HMAC-SHA256 digests of "abc" and "def",
+	// keyed with hashKey.
+	hashKey := []byte("key")
+	hashedABC := "(redacted for security reasons; hmac-sha256:9c196e32dc0175f8)"
+	hashedDEF := "(redacted for security reasons; hmac-sha256:5ebcbab86f1ea651)"
+
+	testCases := []struct {
+		name    string
+		rules   []fleetv1beta1.RedactionRule
+		details []fleetv1beta1.PatchDetail
+		want    []fleetv1beta1.PatchDetail
+	}{
+		{
+			name: "no rules",
+			details: []fleetv1beta1.PatchDetail{
+				{Path: "/webhooks/0/clientConfig/caBundle", ValueInHub: "abc", ValueInMember: "def"},
+			},
+			want: []fleetv1beta1.PatchDetail{
+				{Path: "/webhooks/0/clientConfig/caBundle", ValueInHub: "abc", ValueInMember: "def"},
+			},
+		},
+		{
+			name: "paths with wildcards",
+			rules: []fleetv1beta1.RedactionRule{
+				newRedactionRule("ca-bundles", "", "", []string{"/webhooks/*/clientConfig/caBundle"}, nil),
+			},
+			details: []fleetv1beta1.PatchDetail{
+				{Path: "/webhooks/0/clientConfig/caBundle", ValueInHub: "abc", ValueInMember: "def"},
+				{Path: "/webhooks/1/clientConfig/caBundle", ValueInMember: "def"},
+				{Path: "/webhooks/1/clientConfig/url", ValueInHub: "abc", ValueInMember: "def"},
+			},
+			want: []fleetv1beta1.PatchDetail{
+				{Path: "/webhooks/0/clientConfig/caBundle", ValueInHub: redactedValuePlaceholder, ValueInMember: redactedValuePlaceholder},
+				{Path: "/webhooks/1/clientConfig/caBundle", ValueInMember: redactedValuePlaceholder},
+				{Path: "/webhooks/1/clientConfig/url", ValueInHub: "abc", ValueInMember: "def"},
+			},
+		},
+		{
+			name: "paths cover child fields only at segment boundaries",
+			rules: []fleetv1beta1.RedactionRule{
+				newRedactionRule("client-configs", "", fleetv1beta1.RedactionModeRedact, []string{"/webhooks/0/clientConfig"}, nil),
+			},
+			details: []fleetv1beta1.PatchDetail{
+				{Path: "/webhooks/0/clientConfig/caBundle", ValueInHub: "abc", ValueInMember: "def"},
+				{Path: "/webhooks/0/clientConfigs", ValueInHub: "abc", ValueInMember: "def"},
+			},
+			want: []fleetv1beta1.PatchDetail{
+				{Path: "/webhooks/0/clientConfig/caBundle", ValueInHub: redactedValuePlaceholder, ValueInMember: redactedValuePlaceholder},
+				{Path: "/webhooks/0/clientConfigs", ValueInHub: "abc", ValueInMember: "def"},
+			},
+		},
+		{
+			name: "path regexes in hash mode",
+			rules: []fleetv1beta1.RedactionRule{
+				newRedactionRule("ca-bundles", "", fleetv1beta1.RedactionModeHash, nil, []string{`(?i)cabundle$`}),
+			},
+			details: []fleetv1beta1.PatchDetail{
+				{Path: "/webhooks/0/clientConfig/caBundle", ValueInHub: "abc", ValueInMember: "def"},
+				{Path: "/webhooks/0/name", ValueInHub: "abc", ValueInMember: "def"},
+			},
+			want: []fleetv1beta1.PatchDetail{
+				{Path: "/webhooks/0/clientConfig/caBundle", ValueInHub: hashedABC, ValueInMember: hashedDEF},
+				{Path: "/webhooks/0/name", ValueInHub: "abc", ValueInMember: "def"},
+			},
+		},
+		{
+			name: "redact mode takes precedence over hash mode",
+			rules: []fleetv1beta1.RedactionRule{
+				newRedactionRule("hash", "", fleetv1beta1.RedactionModeHash, []string{"/webhooks"}, nil),
+				newRedactionRule("redact", "", fleetv1beta1.RedactionModeRedact, []string{"/webhooks/0"}, nil),
+			},
+			details: []fleetv1beta1.PatchDetail{
+				{Path: "/webhooks/0/name", ValueInHub: "abc", ValueInMember: "def"},
+				{Path: "/webhooks/1/name", ValueInHub: "abc", ValueInMember: "def"},
+			},
+			want: []fleetv1beta1.PatchDetail{
+				{Path: "/webhooks/0/name", ValueInHub: redactedValuePlaceholder, ValueInMember: redactedValuePlaceholder},
+				{Path: "/webhooks/1/name", ValueInHub: hashedABC, ValueInMember: hashedDEF},
+			},
+		},
+		{
+			name: "invalid regex redacts everything",
+			rules: []fleetv1beta1.RedactionRule{
+				newRedactionRule("invalid", "", fleetv1beta1.RedactionModeHash, nil, []string{`(`}),
+			},
+			details: []fleetv1beta1.PatchDetail{
+				{Path: "/webhooks/0/name", ValueInHub: "abc", ValueInMember: "def"},
+			},
+			want: []fleetv1beta1.PatchDetail{
+				{Path: "/webhooks/0/name", ValueInHub: redactedValuePlaceholder, ValueInMember: redactedValuePlaceholder},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rules := make([]*fleetv1beta1.RedactionRule, 0, len(tc.rules))
+			for idx := range tc.rules {
+				rules = append(rules, &tc.rules[idx])
+			}
+			got := redactPatchDetailsByRules(rules, tc.details, hashKey)
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("redactPatchDetailsByRules() mismatch (-got, +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestRedactValue tests the redactValue function.
+func TestRedactValue(t *testing.T) {
+	key1 := newRedactionHashKey()
+	key2 := newRedactionHashKey()
+
+	if got := redactValue("", fleetv1beta1.RedactionModeHash, key1); got != "" {
+		t.Errorf("redactValue() of an empty value = %q, want an empty value", got)
+	}
+	if got := redactValue("abc", fleetv1beta1.RedactionModeRedact, key1); got != redactedValuePlaceholder {
+		t.Errorf("redactValue() in the Redact mode = %q, want %q", got, redactedValuePlaceholder)
+	}
+	hashed1 := redactValue("abc", fleetv1beta1.RedactionModeHash, key1)
+	if got := redactValue("abc", fleetv1beta1.RedactionModeHash, key1); got != hashed1 {
+		t.Errorf("redactValue() with the same key = %q, want %q", got, hashed1)
+	}
+	if got := redactValue("abc", fleetv1beta1.RedactionModeHash, key2); got == hashed1 {
+		t.Errorf("redactValue() with a different key = %q, want a different digest", got)
+	}
+}
+
+// TestLoadRedactionHashKey tests the LoadRedactionHashKey function.
+func TestLoadRedactionHashKey(t *testing.T) {
+	ctx := context.Background()
+	secretKey := types.NamespacedName{Namespace: "fleet-system", Name: "fleet-redaction-hash-key"}
+
+	t.Run("key is kept across restarts", func(t *testing.T) {
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+
+		// The first start creates the Secret with a random key.
+		key1, err := LoadRedactionHashKey(ctx, fakeClient, secretKey)
+		if err != nil {
+			t.Fatalf("LoadRedactionHashKey() = %v, want no error", err)
+		}
+		if len(key1) != redactionHashKeySize {
+			t.Fatalf("LoadRedactionHashKey() returned a key of %d bytes, want %d bytes", len(key1), redactionHashKeySize)
+		}
+		hashed1 := redactValue("abc", fleetv1beta1.RedactionModeHash, key1)
+
+		// A restart loads the same key from the Secret, so the hashes stay the same.
+		key2, err := LoadRedactionHashKey(ctx, fakeClient, secretKey)
+		if err != nil {
+			t.Fatalf("LoadRedactionHashKey() after a restart = %v, want no error", err)
+		}
+		if got := redactValue("abc", fleetv1beta1.RedactionModeHash, key2); got != hashed1 {
+			t.Errorf("redactValue() after a restart = %q, want %q", got, hashed1)
+		}
+	})
+
+	t.Run("key in an existing secret", func(t *testing.T) {
+		key := []byte("0123456789abcdef0123456789abcdef")
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: secretKey.Namespace, Name: secretKey.Name},
+			Data:       map[string][]byte{RedactionHashKeySecretDataKey: key},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(secret).Build()
+		got, err := LoadRedactionHashKey(ctx, fakeClient, secretKey)
+		if err != nil {
+			t.Fatalf("LoadRedactionHashKey() = %v, want no error", err)
+		}
+		if diff := cmp.Diff(got, key); diff != "" {
+			t.Errorf("LoadRedactionHashKey() mismatch (-got, +want):\n%s", diff)
+		}
+	})
+
+	t.Run("key too short", func(t *testing.T) {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: secretKey.Namespace, Name: secretKey.Name},
+			Data:       map[string][]byte{RedactionHashKeySecretDataKey: []byte("short")},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(secret).Build()
+		if _, err := LoadRedactionHashKey(ctx, fakeClient, secretKey); err == nil {
+			t.Errorf("LoadRedactionHashKey() = nil, want error")
+		}
+	})
+}
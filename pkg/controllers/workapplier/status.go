@@ -148,6 +148,11 @@ func (r *Reconciler) refreshWorkStatus( //nolint:gocyclo
 			}
 		}
 
+		// Record the drift remediation, if one has been performed.
+		if bundle.driftRemediation != nil && isManifestObjectApplied(bundle.applyOrReportDiffResTyp) {
+			manifestCond.DriftRemediationHistory = appendDriftRemediationHistory(manifestCond.DriftRemediationHistory, *bundle.driftRemediation)
+		}
+
 		// Check if a first diffed timestamp has been set; if not, set it to the current time.
 		firstDiffedTimestamp := &now
 		if manifestCond.DiffDetails != nil && !manifestCond.DiffDetails.FirstDiffedObservedTime.IsZero() {
@@ -311,6 +311,10 @@ var _ = BeforeSuite(func() {
 		false, // Disable priority queueing.
 		nil,   // Use the default priority linear equation coefficients.
 		nil,   // Use the default priority linear equation coefficients.
+		false, // Disable availability rules.
+		false, // Disable redaction rules.
+		nil,   // Use a random redaction hash key.
+		nil,   // Do not use a blob store.
 	)
 	Expect(workApplier1.SetupWithManager(hubMgr1)).To(Succeed())
 
@@ -362,6 +366,10 @@ var _ = BeforeSuite(func() {
 		false, // Disable priority queueing.
 		nil,   // Use the default priority linear equation coefficients.
 		nil,   // Use the default priority linear equation coefficients.
+		false, // Disable availability rules.
+		false, // Disable redaction rules.
+		nil,   // Use a random redaction hash key.
+		nil,   // Do not use a blob store.
 	)
 	Expect(workApplier2.SetupWithManager(hubMgr2)).To(Succeed())
 
@@ -401,6 +409,10 @@ var _ = BeforeSuite(func() {
 		false, // Disable priority queueing.
 		nil,   // Use the default priority linear equation coefficients.
 		nil,   // Use the default priority linear equation coefficients.
+		false, // Disable availability rules.
+		false, // Disable redaction rules.
+		nil,   // Use a random redaction hash key.
+		nil,   // Do not use a blob store.
 	)
 	Expect(workApplier3.SetupWithManager(hubMgr3)).To(Succeed())
 
@@ -438,6 +450,10 @@ var _ = BeforeSuite(func() {
 		false, // Disable priority queueing.
 		nil,   // Use the default priority linear equation coefficients.
 		nil,   // Use the default priority linear equation coefficients.
+		false, // Disable availability rules.
+		false, // Disable redaction rules.
+		nil,   // Use a random redaction hash key.
+		nil,   // Do not use a blob store.
 	)
 	// Due to name conflicts, the third work applier must be set up manually.
 	Expect(workApplier4.SetupWithManager(hubMgr4)).To(Succeed())
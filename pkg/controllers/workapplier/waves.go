@@ -17,16 +17,22 @@ limitations under the License.
 package workapplier
 
 import (
+	"fmt"
 	"slices"
+	"strconv"
 
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
 )
 
 type waveNumber int
 
 const (
-	lastWave waveNumber = 999
+	firstWave waveNumber = 0
+	lastWave  waveNumber = 999
 )
 
 var (
@@ -110,10 +116,36 @@ var (
 type bundleProcessingWave struct {
 	num     waveNumber
 	bundles []*manifestProcessingBundle
+	// hasUserDefinedWaveNum is true if any of the bundles in the wave has been assigned to the
+	// wave via the apply wave annotation. Such waves are processed only after all the objects
+	// in the previous waves have become available.
+	hasUserDefinedWaveNum bool
+}
+
+// userDefinedWaveNumberOf returns the wave number that users have specified for a manifest object
+// via the apply wave annotation, if any.
+func userDefinedWaveNumberOf(manifestObj *unstructured.Unstructured) (num waveNumber, found bool, err error) {
+	if manifestObj == nil {
+		return 0, false, nil
+	}
+	val, ok := manifestObj.GetAnnotations()[fleetv1beta1.ApplyWaveAnnotation]
+	if !ok {
+		return 0, false, nil
+	}
+
+	parsed, err := strconv.Atoi(val)
+	if err != nil {
+		return 0, true, fmt.Errorf("the value of the annotation %s (%q) is not an integer", fleetv1beta1.ApplyWaveAnnotation, val)
+	}
+	if parsed < int(firstWave) || parsed > int(lastWave) {
+		return 0, true, fmt.Errorf("the value of the annotation %s (%d) is not in the range [%d, %d]", fleetv1beta1.ApplyWaveAnnotation, parsed, firstWave, lastWave)
+	}
+	return waveNumber(parsed), true, nil
 }
 
 // organizeBundlesIntoProcessingWaves organizes the list of bundles into different
-// waves of bundles for parallel processing based on their GVR information.
+// waves of bundles for parallel processing based on their GVR information and the apply
+// wave annotation (if any) on their manifest objects.
 func organizeBundlesIntoProcessingWaves(bundles []*manifestProcessingBundle, workRef klog.ObjectRef) []*bundleProcessingWave {
 	// Pre-allocate the map; 7 is the total count of default wave numbers, though
 	// not all wave numbers might be used.
@@ -160,10 +192,22 @@ func organizeBundlesIntoProcessingWaves(bundles []*manifestProcessingBundle, wor
 			waveNum = defaultWaveNum
 		}
 
+		// The wave number specified by users, if present, always takes precedence.
+		//
+		// Note that manifest objects with an invalid apply wave annotation have been rejected in
+		// the pre-processing stage; the error check here is added as a sanity check.
+		userDefinedWaveNum, foundUserDefinedWaveNum, err := userDefinedWaveNumberOf(bundle.manifestObj)
+		if err == nil && foundUserDefinedWaveNum {
+			waveNum = userDefinedWaveNum
+		}
+
 		wave := getOrAddWave(waveNum)
 		wave.bundles = append(wave.bundles, bundle)
+		if err == nil && foundUserDefinedWaveNum {
+			wave.hasUserDefinedWaveNum = true
+		}
 		klog.V(2).InfoS("Assigned manifest to a wave",
-			"waveNumber", waveNum,
+			"waveNumber", waveNum, "isUserDefined", foundUserDefinedWaveNum,
 			"manifestObj", klog.KObj(bundle.manifestObj), "GVR", *bundle.gvr, "work", workRef)
 	}
 
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/klog/v2"
 
@@ -38,6 +39,21 @@ func TestOrganizeBundlesIntoProcessingWaves(t *testing.T) {
 	}
 	workRef := klog.KObj(work)
 
+	newManifestObjWithApplyWave := func(name, applyWave string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetName(name)
+		if len(applyWave) > 0 {
+			obj.SetAnnotations(map[string]string{
+				fleetv1beta1.ApplyWaveAnnotation: applyWave,
+			})
+		}
+		return obj
+	}
+	crdManifestObj := newManifestObjWithApplyWave("widgets.example.com", "")
+	crManifestObj := newManifestObjWithApplyWave("widget", "2")
+	deployManifestObj := newManifestObjWithApplyWave("app", "10")
+	placeholderManifestObj := newManifestObjWithApplyWave("placeholder", "")
+
 	testCases := []struct {
 		name      string
 		bundles   []*manifestProcessingBundle
@@ -1105,6 +1121,123 @@ func TestOrganizeBundlesIntoProcessingWaves(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "user-defined wave numbers",
+			bundles: []*manifestProcessingBundle{
+				{
+					id: &fleetv1beta1.WorkResourceIdentifier{
+						Ordinal: 0,
+					},
+					gvr: &schema.GroupVersionResource{
+						Group:    "apiextensions.k8s.io",
+						Version:  "v1",
+						Resource: "customresourcedefinitions",
+					},
+					manifestObj: crdManifestObj,
+				},
+				{
+					id: &fleetv1beta1.WorkResourceIdentifier{
+						Ordinal: 1,
+					},
+					gvr: &schema.GroupVersionResource{
+						Group:    "example.com",
+						Version:  "v1",
+						Resource: "widgets",
+					},
+					manifestObj: crManifestObj,
+				},
+				{
+					id: &fleetv1beta1.WorkResourceIdentifier{
+						Ordinal: 2,
+					},
+					gvr: &schema.GroupVersionResource{
+						Group:    "apps",
+						Version:  "v1",
+						Resource: "deployments",
+					},
+					manifestObj: deployManifestObj,
+				},
+				{
+					id: &fleetv1beta1.WorkResourceIdentifier{
+						Ordinal: 3,
+					},
+					gvr: &schema.GroupVersionResource{
+						Group:    "dummy",
+						Version:  "v10",
+						Resource: "placeholders",
+					},
+					manifestObj: placeholderManifestObj,
+				},
+			},
+			wantWaves: []*bundleProcessingWave{
+				{
+					num: 1,
+					bundles: []*manifestProcessingBundle{
+						{
+							id: &fleetv1beta1.WorkResourceIdentifier{
+								Ordinal: 0,
+							},
+							gvr: &schema.GroupVersionResource{
+								Group:    "apiextensions.k8s.io",
+								Version:  "v1",
+								Resource: "customresourcedefinitions",
+							},
+							manifestObj: crdManifestObj,
+						},
+					},
+				},
+				{
+					num: 2,
+					bundles: []*manifestProcessingBundle{
+						{
+							id: &fleetv1beta1.WorkResourceIdentifier{
+								Ordinal: 1,
+							},
+							gvr: &schema.GroupVersionResource{
+								Group:    "example.com",
+								Version:  "v1",
+								Resource: "widgets",
+							},
+							manifestObj: crManifestObj,
+						},
+					},
+					hasUserDefinedWaveNum: true,
+				},
+				{
+					num: 10,
+					bundles: []*manifestProcessingBundle{
+						{
+							id: &fleetv1beta1.WorkResourceIdentifier{
+								Ordinal: 2,
+							},
+							gvr: &schema.GroupVersionResource{
+								Group:    "apps",
+								Version:  "v1",
+								Resource: "deployments",
+							},
+							manifestObj: deployManifestObj,
+						},
+					},
+					hasUserDefinedWaveNum: true,
+				},
+				{
+					num: lastWave,
+					bundles: []*manifestProcessingBundle{
+						{
+							id: &fleetv1beta1.WorkResourceIdentifier{
+								Ordinal: 3,
+							},
+							gvr: &schema.GroupVersionResource{
+								Group:    "dummy",
+								Version:  "v10",
+								Resource: "placeholders",
+							},
+							manifestObj: placeholderManifestObj,
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1119,3 +1252,76 @@ func TestOrganizeBundlesIntoProcessingWaves(t *testing.T) {
 		})
 	}
 }
+
+// TestUserDefinedWaveNumberOf tests the userDefinedWaveNumberOf function.
+func TestUserDefinedWaveNumberOf(t *testing.T) {
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		wantNum     waveNumber
+		wantFound   bool
+		wantErred   bool
+	}{
+		{
+			name: "no annotation",
+		},
+		{
+			name: "valid wave number",
+			annotations: map[string]string{
+				fleetv1beta1.ApplyWaveAnnotation: "5",
+			},
+			wantNum:   5,
+			wantFound: true,
+		},
+		{
+			name: "last wave",
+			annotations: map[string]string{
+				fleetv1beta1.ApplyWaveAnnotation: "999",
+			},
+			wantNum:   lastWave,
+			wantFound: true,
+		},
+		{
+			name: "not an integer",
+			annotations: map[string]string{
+				fleetv1beta1.ApplyWaveAnnotation: "early",
+			},
+			wantFound: true,
+			wantErred: true,
+		},
+		{
+			name: "negative wave number",
+			annotations: map[string]string{
+				fleetv1beta1.ApplyWaveAnnotation: "-1",
+			},
+			wantFound: true,
+			wantErred: true,
+		},
+		{
+			name: "wave number out of range",
+			annotations: map[string]string{
+				fleetv1beta1.ApplyWaveAnnotation: "1000",
+			},
+			wantFound: true,
+			wantErred: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			obj := &unstructured.Unstructured{}
+			obj.SetAnnotations(tc.annotations)
+			num, found, err := userDefinedWaveNumberOf(obj)
+			if tc.wantErred {
+				if err == nil {
+					t.Fatalf("userDefinedWaveNumberOf() = nil, want error")
+				}
+			} else if err != nil {
+				t.Fatalf("userDefinedWaveNumberOf() = %v, want no error", err)
+			}
+			if num != tc.wantNum || found != tc.wantFound {
+				t.Errorf("userDefinedWaveNumberOf() = (%d, %t), want (%d, %t)", num, found, tc.wantNum, tc.wantFound)
+			}
+		})
+	}
+}
@@ -51,8 +51,10 @@ import (
 	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
 	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
 	"go.goms.io/fleet/pkg/utils"
+	"go.goms.io/fleet/pkg/utils/blobstore"
 	"go.goms.io/fleet/pkg/utils/condition"
 	"go.goms.io/fleet/pkg/utils/controller"
+	"go.goms.io/fleet/pkg/utils/helm"
 	"go.goms.io/fleet/pkg/utils/informer"
 	"go.goms.io/fleet/pkg/utils/labels"
 	"go.goms.io/fleet/pkg/utils/resource"
@@ -65,6 +67,8 @@ var (
 	maxDriftedResourcePlacementLimit = 100
 	// maxDiffedResourcePlacementLimit indicates the max number of diffed resource placements to include in the status.
 	maxDiffedResourcePlacementLimit = 100
+	// maxDriftRemediatedResourcePlacementLimit indicates the max number of drift remediated resource placements to include in the status.
+	maxDriftRemediatedResourcePlacementLimit = 100
 
 	errResourceSnapshotNotFound = fmt.Errorf("the master resource snapshot is not found")
 )
@@ -80,6 +84,16 @@ type Reconciler struct {
 	// the informer contains the cache for all the resources we need.
 	// to check the resource scope
 	InformerManager informer.Manager
+	// BlobStore is the external store for the resources that are too large to be kept in the resource
+	// snapshots and the works; it is nil if no external store is in use.
+	BlobStore blobstore.Store
+	// BlobOffloadThresholdBytes is the size above which a manifest is saved in the blob store, with only
+	// a reference to it kept in the work.
+	BlobOffloadThresholdBytes int
+	// CompressManifests controls whether the manifests in the works are kept in the compressed form.
+	CompressManifests bool
+	// HelmChartFetcher fetches the charts of the HelmRelease objects in the resource snapshots to render them.
+	HelmChartFetcher helm.ChartFetcher
 }
 
 // Reconcile triggers a single binding reconcile round.
@@ -165,6 +179,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req controllerruntime.Reques
 	resourceBinding.GetBindingStatus().FailedPlacements = nil
 	resourceBinding.GetBindingStatus().DriftedPlacements = nil
 	resourceBinding.GetBindingStatus().DiffedPlacements = nil
+	resourceBinding.GetBindingStatus().DriftRemediatedPlacements = nil
 	if overrideSucceeded {
 		overrideReason := condition.OverriddenSucceededReason
 		overrideMessage := "Successfully applied the override rules on the resources"
@@ -496,6 +511,14 @@ func (r *Reconciler) syncAllWork(ctx context.Context, resourceBinding fleetv1bet
 		selectedRes := snapshot.GetResourceSnapshotSpec().SelectedResources
 		for j := range selectedRes {
 			selectedResource := selectedRes[j].DeepCopy()
+			// Resolve the selected resource if it has been offloaded to the blob store, so that the override
+			// rules can be applied and the envelopes can be unwrapped.
+			resolvedRaw, resolveErr := blobstore.Resolve(ctx, r.BlobStore, selectedResource.Raw)
+			if resolveErr != nil {
+				klog.ErrorS(resolveErr, "Failed to resolve the selected resource from the blob store", "snapshot", klog.KObj(snapshot), "selectedResourceIdx", j)
+				return false, false, resolveErr
+			}
+			selectedResource.Raw = resolvedRaw
 			// TODO: apply the override rules on the envelope resources by applying them on the work instead of the selected resource
 			resourceDeleted, overrideErr := r.applyOverrides(selectedResource, cluster, croMap, roMap)
 			if overrideErr != nil {
@@ -580,7 +603,8 @@ func (r *Reconciler) syncAllWork(ctx context.Context, resourceBinding fleetv1bet
 // processOneSelectedResource processes a single selected resource from the resource snapshot.
 //
 // If the selected resource is an envelope (either configMap-based or envelope-based), create a new dedicated
-// work object for the envelope. Otherwise, append the selected resource to the list of simple manifests.
+// work object for the envelope. If it is a HelmRelease, append the resources rendered from its chart to the
+// list of simple manifests. Otherwise, append the selected resource to the list of simple manifests.
 func (r *Reconciler) processOneSelectedResource(
 	ctx context.Context,
 	selectedResource *fleetv1beta1.ResourceContent,
@@ -640,15 +664,44 @@ func (r *Reconciler) processOneSelectedResource(
 		}
 		activeWork[work.Name] = work
 		newWork = append(newWork, work)
+	case helm.ReleaseGVK.GroupKind():
+		// The resource is a HelmRelease; add the resources rendered from its chart to the list of simple manifests.
+		manifests, err := r.renderHelmRelease(ctx, &uResource, resourceBinding)
+		if err != nil {
+			return nil, nil, err
+		}
+		for i := range manifests {
+			manifest, err := r.offloadManifest(ctx, manifests[i])
+			if err != nil {
+				klog.ErrorS(err, "Failed to offload the rendered resource to the blob store", "snapshot", klog.KObj(snapshot), "helmRelease", klog.KObj(&uResource))
+				return nil, nil, err
+			}
+			simpleManifests = append(simpleManifests, manifest)
+		}
 
 	default:
 		// The resource is not an envelope; add it to the list of simple manifests.
-		simpleManifests = append(simpleManifests, fleetv1beta1.Manifest(*selectedResource))
+		manifest, err := r.offloadManifest(ctx, fleetv1beta1.Manifest(*selectedResource))
+		if err != nil {
+			klog.ErrorS(err, "Failed to offload the selected resource to the blob store", "snapshot", klog.KObj(snapshot), "selectedResource", klog.KObj(&uResource))
+			return nil, nil, err
+		}
+		simpleManifests = append(simpleManifests, manifest)
 	}
 
 	return newWork, simpleManifests, nil
 }
 
+// offloadManifest saves a manifest in the blob store if its size exceeds the offload threshold, and returns
+// a reference to it in its place. The manifest is returned as it is if no blob store is in use.
+func (r *Reconciler) offloadManifest(ctx context.Context, manifest fleetv1beta1.Manifest) (fleetv1beta1.Manifest, error) {
+	raw, err := blobstore.Offload(ctx, r.BlobStore, manifest.Raw, r.BlobOffloadThresholdBytes)
+	if err != nil {
+		return fleetv1beta1.Manifest{}, err
+	}
+	return fleetv1beta1.Manifest{RawExtension: runtime.RawExtension{Raw: raw}}, nil
+}
+
 // syncApplyStrategy syncs the apply strategy specified on a binding object
 // to a Work object.
 func (r *Reconciler) syncApplyStrategy(
@@ -768,6 +821,16 @@ func generateSnapshotWorkObj(workName string, resourceBinding fleetv1beta1.Bindi
 func (r *Reconciler) upsertWork(ctx context.Context, newWork, existingWork *fleetv1beta1.Work, resourceSnapshot fleetv1beta1.ResourceSnapshotObj) (bool, error) {
 	workObj := klog.KObj(newWork)
 	resourceSnapshotObj := klog.KObj(resourceSnapshot)
+	if r.CompressManifests {
+		// The works for the envelopes are compressed the same way as the works for the other resources.
+		if err := resource.CompressManifests(ctx, &newWork.Spec.Workload, r.BlobStore, r.BlobOffloadThresholdBytes); err != nil {
+			klog.ErrorS(err, "Failed to compress the manifests in the work", "resourceSnapshot", resourceSnapshotObj, "work", workObj)
+			if errors.Is(err, resource.ErrManifestsTooLarge) {
+				return false, controller.NewUserError(err)
+			}
+			return false, err
+		}
+	}
 	if existingWork == nil {
 		if err := r.Client.Create(ctx, newWork); err != nil {
 			klog.ErrorS(err, "Failed to create the work associated with the resourceSnapshot", "resourceSnapshot", resourceSnapshotObj, "work", workObj)
@@ -786,10 +849,12 @@ func (r *Reconciler) upsertWork(ctx context.Context, newWork, existingWork *flee
 		// we already checked the label in fetchAllResourceSnapShots function so no need to check again
 		resourceIndex, _ := labels.ExtractResourceIndexFromResourceSnapshot(resourceSnapshot)
 		if workResourceIndex == resourceIndex {
-			// no need to do anything if the work is generated from the same resource/override snapshots.
+			// no need to do anything if the work is generated from the same resource/override snapshots
+			// and keeps its manifests in the same form, i.e., compressed or not.
 			// Note that apply strategy is updated separately beforehand.
 			if existingWork.Annotations[fleetv1beta1.ParentResourceOverrideSnapshotHashAnnotation] == newWork.Annotations[fleetv1beta1.ParentResourceOverrideSnapshotHashAnnotation] &&
-				existingWork.Annotations[fleetv1beta1.ParentClusterResourceOverrideSnapshotHashAnnotation] == newWork.Annotations[fleetv1beta1.ParentClusterResourceOverrideSnapshotHashAnnotation] {
+				existingWork.Annotations[fleetv1beta1.ParentClusterResourceOverrideSnapshotHashAnnotation] == newWork.Annotations[fleetv1beta1.ParentClusterResourceOverrideSnapshotHashAnnotation] &&
+				(existingWork.Spec.Workload.CompressedManifests == nil) == (newWork.Spec.Workload.CompressedManifests == nil) {
 				klog.V(2).InfoS("Work is associated with the desired resource/override snapshots", "existingROHash", existingWork.Annotations[fleetv1beta1.ParentResourceOverrideSnapshotHashAnnotation],
 					"existingCROHash", existingWork.Annotations[fleetv1beta1.ParentClusterResourceOverrideSnapshotHashAnnotation], "work", workObj)
 				return false, nil
@@ -808,7 +873,7 @@ func (r *Reconciler) upsertWork(ctx context.Context, newWork, existingWork *flee
 	existingWork.Annotations[fleetv1beta1.ParentResourceSnapshotNameAnnotation] = newWork.Annotations[fleetv1beta1.ParentResourceSnapshotNameAnnotation]
 	existingWork.Annotations[fleetv1beta1.ParentResourceOverrideSnapshotHashAnnotation] = newWork.Annotations[fleetv1beta1.ParentResourceOverrideSnapshotHashAnnotation]
 	existingWork.Annotations[fleetv1beta1.ParentClusterResourceOverrideSnapshotHashAnnotation] = newWork.Annotations[fleetv1beta1.ParentClusterResourceOverrideSnapshotHashAnnotation]
-	existingWork.Spec.Workload.Manifests = newWork.Spec.Workload.Manifests
+	existingWork.Spec.Workload = newWork.Spec.Workload
 	existingWork.Spec.ApplyStrategy = newWork.Spec.ApplyStrategy
 	if err := r.Client.Update(ctx, existingWork); err != nil {
 		klog.ErrorS(err, "Failed to update the work associated with the resourceSnapshot", "resourceSnapshot", resourceSnapshotObj, "work", workObj)
@@ -908,16 +973,22 @@ func setBindingStatus(works map[string]*fleetv1beta1.Work, resourceBinding fleet
 	resourceBinding.GetBindingStatus().FailedPlacements = nil
 	resourceBinding.GetBindingStatus().DiffedPlacements = nil
 	resourceBinding.GetBindingStatus().DriftedPlacements = nil
+	resourceBinding.GetBindingStatus().DriftRemediatedPlacements = nil
 	// collect and set the failed resource placements to the binding if not all the works are available
 	driftedResourcePlacements := make([]fleetv1beta1.DriftedResourcePlacement, 0, maxDriftedResourcePlacementLimit) // preallocate the memory
 	failedResourcePlacements := make([]fleetv1beta1.FailedResourcePlacement, 0, maxFailedResourcePlacementLimit)    // preallocate the memory
 	diffedResourcePlacements := make([]fleetv1beta1.DiffedResourcePlacement, 0, maxDiffedResourcePlacementLimit)    // preallocate the memory
+	var driftRemediatedResourcePlacements []fleetv1beta1.DriftRemediatedResourcePlacement
 	for _, w := range works {
 		if w.DeletionTimestamp != nil {
 			klog.V(2).InfoS("Ignoring the deleting work", "clusterResourceBinding", bindingRef, "work", klog.KObj(w))
 			continue // ignore the deleting work
 		}
 
+		// Drift remediations are history records; they are reported regardless of the current
+		// apply, availability check and diff reporting results.
+		driftRemediatedResourcePlacements = append(driftRemediatedResourcePlacements, extractDriftRemediatedResourcePlacementsFromWork(w)...)
+
 		// Populate the failed, diffed, and drifted placements based on the summarized status of the Applied,
 		// Available, and DiffReported conditions on all Work objects.
 		//
@@ -1027,6 +1098,19 @@ func setBindingStatus(works map[string]*fleetv1beta1.Work, resourceBinding fleet
 		resourceBinding.GetBindingStatus().DriftedPlacements = driftedResourcePlacements
 		klog.V(2).InfoS("Populated drifted manifests", "binding", bindingRef, "numberOfDriftedPlacements", len(driftedResourcePlacements))
 	}
+
+	// cut the list to keep only the max limit
+	if len(driftRemediatedResourcePlacements) > maxDriftRemediatedResourcePlacementLimit {
+		// Sort the slice
+		sort.Slice(driftRemediatedResourcePlacements, func(i, j int) bool {
+			return utils.LessFuncDriftRemediatedResourcePlacements(driftRemediatedResourcePlacements[i], driftRemediatedResourcePlacements[j])
+		})
+		driftRemediatedResourcePlacements = driftRemediatedResourcePlacements[0:maxDriftRemediatedResourcePlacementLimit]
+	}
+	if len(driftRemediatedResourcePlacements) > 0 {
+		resourceBinding.GetBindingStatus().DriftRemediatedPlacements = driftRemediatedResourcePlacements
+		klog.V(2).InfoS("Populated drift remediated manifests", "binding", bindingRef, "numberOfDriftRemediatedPlacements", len(driftRemediatedResourcePlacements))
+	}
 }
 
 // setAllWorkAppliedCondition sets the Applied condition on a binding
@@ -1419,6 +1503,43 @@ func extractDriftedResourcePlacementsFromWork(work *fleetv1beta1.Work) []fleetv1
 	return res
 }
 
+// extractDriftRemediatedResourcePlacementsFromWork extracts the drift remediated placements from work
+func extractDriftRemediatedResourcePlacementsFromWork(work *fleetv1beta1.Work) []fleetv1beta1.DriftRemediatedResourcePlacement {
+	// check if the work is generated by an enveloped object
+	envelopeType, isEnveloped := work.GetLabels()[fleetv1beta1.EnvelopeTypeLabel]
+	var envelopObjName, envelopObjNamespace string
+	if isEnveloped {
+		// If the work  generated by an enveloped object, it must contain those labels.
+		envelopObjName = work.GetLabels()[fleetv1beta1.EnvelopeNameLabel]
+		envelopObjNamespace = work.GetLabels()[fleetv1beta1.EnvelopeNamespaceLabel]
+	}
+	var res []fleetv1beta1.DriftRemediatedResourcePlacement
+	for _, manifestCondition := range work.Status.ManifestConditions {
+		if len(manifestCondition.DriftRemediationHistory) == 0 {
+			continue
+		}
+		remediatedManifest := fleetv1beta1.DriftRemediatedResourcePlacement{
+			ResourceIdentifier: fleetv1beta1.ResourceIdentifier{
+				Group:     manifestCondition.Identifier.Group,
+				Version:   manifestCondition.Identifier.Version,
+				Kind:      manifestCondition.Identifier.Kind,
+				Name:      manifestCondition.Identifier.Name,
+				Namespace: manifestCondition.Identifier.Namespace,
+			},
+			DriftRemediationHistory: manifestCondition.DriftRemediationHistory,
+		}
+		if isEnveloped {
+			remediatedManifest.ResourceIdentifier.Envelope = &fleetv1beta1.EnvelopeIdentifier{
+				Name:      envelopObjName,
+				Namespace: envelopObjNamespace,
+				Type:      fleetv1beta1.EnvelopeType(envelopeType),
+			}
+		}
+		res = append(res, remediatedManifest)
+	}
+	return res
+}
+
 // extractDiffedResourcePlacementsFromWork extracts the diffed placements from work
 func extractDiffedResourcePlacementsFromWork(work *fleetv1beta1.Work) []fleetv1beta1.DiffedResourcePlacement {
 	// check if the work is generated by an enveloped object
@@ -1508,6 +1629,10 @@ func workHandlerFuncs(enqueueCRB bool) handler.Funcs {
 					"Failed to process a delete event for work object")
 				return
 			}
+			if _, isPreview := evt.Object.GetLabels()[fleetv1beta1.PlacementPreviewLabel]; isPreview {
+				klog.V(2).InfoS("Ignoring the work created for a placement preview", "work", klog.KObj(evt.Object))
+				return
+			}
 			parentNamespaceName := evt.Object.GetLabels()[fleetv1beta1.ParentNamespaceLabel]
 			if shouldIgnoreWork(enqueueCRB, parentNamespaceName) {
 				klog.V(2).InfoS("Ignoring the work owned by different placement scope", "work", klog.KObj(evt.Object), "parentNamespaceName", parentNamespaceName, "enqueueCRP", enqueueCRB)
@@ -1534,6 +1659,10 @@ func workHandlerFuncs(enqueueCRB bool) handler.Funcs {
 					"Failed to process an update event for work object")
 				return
 			}
+			if _, isPreview := evt.ObjectNew.GetLabels()[fleetv1beta1.PlacementPreviewLabel]; isPreview {
+				klog.V(2).InfoS("Ignoring the work created for a placement preview", "work", klog.KObj(evt.ObjectNew))
+				return
+			}
 			parentNamespaceName := evt.ObjectNew.GetLabels()[fleetv1beta1.ParentNamespaceLabel]
 			if shouldIgnoreWork(enqueueCRB, parentNamespaceName) {
 				klog.V(2).InfoS("Ignoring the work owned by different placement scope", "work", klog.KObj(evt.ObjectNew), "parentNamespaceName", parentNamespaceName, "enqueueCRP", enqueueCRB)
@@ -19,6 +19,7 @@ limitations under the License.
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -38,8 +39,10 @@ import (
 
 	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
 	"go.goms.io/fleet/pkg/utils"
+	"go.goms.io/fleet/pkg/utils/blobstore"
 	"go.goms.io/fleet/pkg/utils/condition"
 	"go.goms.io/fleet/pkg/utils/controller"
+	"go.goms.io/fleet/pkg/utils/resource"
 	"go.goms.io/fleet/test/utils/informer"
 )
 
@@ -3079,6 +3082,133 @@ func TestExtractDriftedResourcePlacementsFromWork(t *testing.T) {
 	}
 }
 
+func TestExtractDriftRemediatedResourcePlacementsFromWork(t *testing.T) {
+	remediationTime := metav1.NewTime(time.Now())
+	history := []fleetv1beta1.DriftRemediation{
+		{
+			RemediationTime:                   remediationTime,
+			FirstDriftedObservedTime:          metav1.NewTime(remediationTime.Add(-time.Hour)),
+			ObservedInMemberClusterGeneration: 2,
+			RemediatedDriftPaths:              []string{"/spec/replicas"},
+		},
+	}
+	tests := []struct {
+		name string
+		work fleetv1beta1.Work
+		want []fleetv1beta1.DriftRemediatedResourcePlacement
+	}{
+		{
+			name: "work with drift remediation history",
+			work: fleetv1beta1.Work{
+				Status: fleetv1beta1.WorkStatus{
+					ManifestConditions: []fleetv1beta1.ManifestCondition{
+						{
+							Identifier: fleetv1beta1.WorkResourceIdentifier{
+								Ordinal:   0,
+								Group:     "",
+								Version:   "v1",
+								Kind:      "ConfigMap",
+								Name:      "config-name",
+								Namespace: "config-namespace",
+							},
+						},
+						{
+							Identifier: fleetv1beta1.WorkResourceIdentifier{
+								Ordinal:   1,
+								Group:     "apps",
+								Version:   "v1",
+								Kind:      "Deployment",
+								Name:      "deploy-name",
+								Namespace: "deploy-namespace",
+							},
+							DriftRemediationHistory: history,
+						},
+					},
+				},
+			},
+			want: []fleetv1beta1.DriftRemediatedResourcePlacement{
+				{
+					ResourceIdentifier: fleetv1beta1.ResourceIdentifier{
+						Group:     "apps",
+						Version:   "v1",
+						Kind:      "Deployment",
+						Name:      "deploy-name",
+						Namespace: "deploy-namespace",
+					},
+					DriftRemediationHistory: history,
+				},
+			},
+		},
+		{
+			name: "enveloped work with drift remediation history",
+			work: fleetv1beta1.Work{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						fleetv1beta1.EnvelopeTypeLabel:      string(fleetv1beta1.ResourceEnvelopeType),
+						fleetv1beta1.EnvelopeNameLabel:      "envelope",
+						fleetv1beta1.EnvelopeNamespaceLabel: "envelope-ns",
+					},
+				},
+				Status: fleetv1beta1.WorkStatus{
+					ManifestConditions: []fleetv1beta1.ManifestCondition{
+						{
+							Identifier: fleetv1beta1.WorkResourceIdentifier{
+								Ordinal:   0,
+								Group:     "",
+								Version:   "v1",
+								Kind:      "ConfigMap",
+								Name:      "config-name",
+								Namespace: "config-namespace",
+							},
+							DriftRemediationHistory: history,
+						},
+					},
+				},
+			},
+			want: []fleetv1beta1.DriftRemediatedResourcePlacement{
+				{
+					ResourceIdentifier: fleetv1beta1.ResourceIdentifier{
+						Group:     "",
+						Version:   "v1",
+						Kind:      "ConfigMap",
+						Name:      "config-name",
+						Namespace: "config-namespace",
+						Envelope: &fleetv1beta1.EnvelopeIdentifier{
+							Name:      "envelope",
+							Namespace: "envelope-ns",
+							Type:      fleetv1beta1.ResourceEnvelopeType,
+						},
+					},
+					DriftRemediationHistory: history,
+				},
+			},
+		},
+		{
+			name: "work without drift remediation history",
+			work: fleetv1beta1.Work{
+				Status: fleetv1beta1.WorkStatus{
+					ManifestConditions: []fleetv1beta1.ManifestCondition{
+						{
+							Identifier: fleetv1beta1.WorkResourceIdentifier{
+								Kind: "ConfigMap",
+								Name: "config-name",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := extractDriftRemediatedResourcePlacementsFromWork(&tc.work)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("extractDriftRemediatedResourcePlacementsFromWork() status mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestExtractDiffedResourcePlacementsFromWork(t *testing.T) {
 	var options = []cmp.Option{
 		cmpopts.SortSlices(func(s1, s2 string) bool {
@@ -3984,3 +4114,208 @@ func (s *conflictStatusWriter) Update(ctx context.Context, obj client.Object, _
 	}
 	return s.StatusWriter.Update(ctx, obj)
 }
+
+func TestOffloadManifest(t *testing.T) {
+	small := fleetv1beta1.Manifest{RawExtension: runtime.RawExtension{
+		Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"small","namespace":"app"}}`),
+	}}
+	big := fleetv1beta1.Manifest{RawExtension: runtime.RawExtension{
+		Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"big","namespace":"app"},"data":{"key":"` + strings.Repeat("x", 512) + `"}}`),
+	}}
+
+	tests := map[string]struct {
+		useBlobStore  bool
+		manifest      fleetv1beta1.Manifest
+		wantReference bool
+	}{
+		"no blob store": {
+			manifest: big,
+		},
+		"manifest under the threshold": {
+			useBlobStore: true,
+			manifest:     small,
+		},
+		"manifest over the threshold": {
+			useBlobStore:  true,
+			manifest:      big,
+			wantReference: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := &Reconciler{BlobOffloadThresholdBytes: 256}
+			if tt.useBlobStore {
+				r.BlobStore = blobstore.NewMemoryStore()
+			}
+			got, err := r.offloadManifest(context.Background(), tt.manifest)
+			if err != nil {
+				t.Fatalf("offloadManifest() got error %v, want no error", err)
+			}
+			if gotReference := blobstore.IsReference(got.Raw); gotReference != tt.wantReference {
+				t.Fatalf("offloadManifest() returned a reference: %t, want %t", gotReference, tt.wantReference)
+			}
+			resolved, err := blobstore.Resolve(context.Background(), r.BlobStore, got.Raw)
+			if err != nil {
+				t.Fatalf("Resolve() got error %v, want no error", err)
+			}
+			if diff := cmp.Diff(tt.manifest.Raw, resolved); diff != "" {
+				t.Errorf("Resolve() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpsertWork_CompressManifests(t *testing.T) {
+	ctx := context.Background()
+	manifests := []fleetv1beta1.Manifest{
+		{RawExtension: runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"config","namespace":"app"}}`)}},
+	}
+	resourceSnapshot := &fleetv1beta1.ClusterResourceSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "snapshot-2",
+			Labels: map[string]string{
+				fleetv1beta1.ResourceIndexLabel: "2",
+			},
+		},
+	}
+	existingWork := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-work",
+			Namespace: "test-namespace",
+			Labels: map[string]string{
+				fleetv1beta1.ParentResourceSnapshotIndexLabel: "1",
+			},
+		},
+		Spec: fleetv1beta1.WorkSpec{
+			Workload: fleetv1beta1.WorkloadTemplate{
+				Manifests: []fleetv1beta1.Manifest{{RawExtension: runtime.RawExtension{Raw: []byte("{}")}}},
+			},
+		},
+	}
+	newWork := existingWork.DeepCopy()
+	newWork.Labels[fleetv1beta1.ParentResourceSnapshotIndexLabel] = "2"
+	newWork.Spec.Workload.Manifests = manifests
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(serviceScheme(t)).
+		WithObjects(resourceSnapshot, existingWork).
+		Build()
+	reconciler := &Reconciler{
+		Client:            fakeClient,
+		InformerManager:   &informer.FakeManager{},
+		CompressManifests: true,
+	}
+	changed, err := reconciler.upsertWork(ctx, newWork, existingWork, resourceSnapshot)
+	if err != nil || !changed {
+		t.Fatalf("upsertWork() = %t, %v, want true, no error", changed, err)
+	}
+
+	upsertedWork := &fleetv1beta1.Work{}
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(newWork), upsertedWork); err != nil {
+		t.Fatalf("failed to get upserted work: %v", err)
+	}
+	if len(upsertedWork.Spec.Workload.Manifests) != 0 || upsertedWork.Spec.Workload.CompressedManifests == nil {
+		t.Fatalf("upsertWork() did not keep the manifests in the compressed form: %+v", upsertedWork.Spec.Workload)
+	}
+	got, err := resource.ManifestsOf(ctx, &upsertedWork.Spec.Workload, nil)
+	if err != nil {
+		t.Fatalf("ManifestsOf() got error %v, want no error", err)
+	}
+	if diff := cmp.Diff(manifests, got); diff != "" {
+		t.Errorf("ManifestsOf() mismatch (-want, +got):\n%s", diff)
+	}
+}
+
+func TestUpsertWork_CompressEnvelopeManifests(t *testing.T) {
+	ctx := context.Background()
+	configMapData := []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"config","namespace":"app"},"data":{"key":"value"}}`)
+	resourceEnvelope := &fleetv1beta1.ResourceEnvelope{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-envelope",
+			Namespace: "app",
+		},
+		Data: map[string]runtime.RawExtension{
+			"configmap": {Raw: configMapData},
+		},
+	}
+	resourceSnapshot := &fleetv1beta1.ClusterResourceSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "snapshot-1",
+			Labels: map[string]string{
+				fleetv1beta1.PlacementTrackingLabel: "test-crp",
+				fleetv1beta1.ResourceIndexLabel:     "1",
+			},
+		},
+	}
+	resourceBinding := &fleetv1beta1.ClusterResourceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-binding",
+			Labels: map[string]string{
+				fleetv1beta1.PlacementTrackingLabel: "test-crp",
+			},
+		},
+		Spec: fleetv1beta1.ResourceBindingSpec{
+			TargetCluster:        "test-cluster",
+			ResourceSnapshotName: resourceSnapshot.Name,
+		},
+	}
+	// The envelope work was created from the same snapshot before the compression was enabled.
+	existingWork := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-crp-envelope-work",
+			Namespace: "fleet-member-test-cluster",
+			Labels: map[string]string{
+				fleetv1beta1.ParentBindingLabel:               resourceBinding.Name,
+				fleetv1beta1.PlacementTrackingLabel:           "test-crp",
+				fleetv1beta1.ParentResourceSnapshotIndexLabel: "1",
+				fleetv1beta1.EnvelopeTypeLabel:                string(fleetv1beta1.ResourceEnvelopeType),
+				fleetv1beta1.EnvelopeNameLabel:                resourceEnvelope.Name,
+				fleetv1beta1.EnvelopeNamespaceLabel:           resourceEnvelope.Namespace,
+			},
+			Annotations: map[string]string{
+				fleetv1beta1.ParentResourceSnapshotNameAnnotation:                resourceSnapshot.Name,
+				fleetv1beta1.ParentResourceOverrideSnapshotHashAnnotation:        "resource-hash",
+				fleetv1beta1.ParentClusterResourceOverrideSnapshotHashAnnotation: "cluster-resource-hash",
+			},
+		},
+		Spec: fleetv1beta1.WorkSpec{
+			Workload: fleetv1beta1.WorkloadTemplate{
+				Manifests: []fleetv1beta1.Manifest{{RawExtension: runtime.RawExtension{Raw: configMapData}}},
+			},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(serviceScheme(t)).
+		WithObjects(resourceSnapshot, existingWork).
+		Build()
+	reconciler := &Reconciler{
+		Client:            fakeClient,
+		InformerManager:   &informer.FakeManager{},
+		CompressManifests: true,
+	}
+	newWork, err := reconciler.createOrUpdateEnvelopeCRWorkObj(ctx, resourceEnvelope, "test-crp", resourceBinding, resourceSnapshot, "resource-hash", "cluster-resource-hash")
+	if err != nil {
+		t.Fatalf("createOrUpdateEnvelopeCRWorkObj() got error %v, want no error", err)
+	}
+	changed, err := reconciler.upsertWork(ctx, newWork, existingWork.DeepCopy(), resourceSnapshot)
+	if err != nil || !changed {
+		t.Fatalf("upsertWork() = %t, %v, want true, no error", changed, err)
+	}
+
+	upsertedWork := &fleetv1beta1.Work{}
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(existingWork), upsertedWork); err != nil {
+		t.Fatalf("failed to get upserted work: %v", err)
+	}
+	if len(upsertedWork.Spec.Workload.Manifests) != 0 || upsertedWork.Spec.Workload.CompressedManifests == nil {
+		t.Fatalf("upsertWork() did not keep the manifests of the envelope work in the compressed form: %+v", upsertedWork.Spec.Workload)
+	}
+	got, err := resource.ManifestsOf(ctx, &upsertedWork.Spec.Workload, nil)
+	if err != nil {
+		t.Fatalf("ManifestsOf() got error %v, want no error", err)
+	}
+	want := []fleetv1beta1.Manifest{{RawExtension: runtime.RawExtension{Raw: configMapData}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ManifestsOf() mismatch (-want, +got):\n%s", diff)
+	}
+}
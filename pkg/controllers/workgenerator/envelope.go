@@ -50,6 +50,15 @@ func (r *Reconciler) createOrUpdateEnvelopeCRWorkObj(
 			"envelope", envelopeReader.GetEnvelopeObjRef())
 		return nil, err
 	}
+	for i := range manifests {
+		if manifests[i], err = r.offloadManifest(ctx, manifests[i]); err != nil {
+			klog.ErrorS(err, "Failed to offload a wrapped manifest to the blob store",
+				"resourceBinding", klog.KObj(binding),
+				"resourceSnapshot", klog.KObj(resourceSnapshot),
+				"envelope", envelopeReader.GetEnvelopeObjRef())
+			return nil, err
+		}
+	}
 	klog.V(2).InfoS("Successfully extracted wrapped manifests from the envelope",
 		"numOfResources", len(manifests),
 		"resourceBinding", klog.KObj(binding),
@@ -139,6 +148,12 @@ func extractManifestsFromEnvelopeCR(envelopeReader fleetv1beta1.EnvelopeReader)
 			wrappedErr := fmt.Errorf("a namespaced object %s (%v) in has been wrapped in a resource envelope from another namespace (%v)", k, resRef, envelopeReader.GetEnvelopeObjRef())
 			klog.ErrorS(wrappedErr, "Found an invalid manifest", "manifestKey", k, "envelope", envelopeReader.GetEnvelopeObjRef())
 			return nil, controller.NewUserError(wrappedErr)
+
+		// Check if the wrapped manifest carries the annotations reserved for the references to the resources offloaded by Fleet.
+		case hasBlobAnnotations(&uObj):
+			wrappedErr := fmt.Errorf("an object %s (%v) wrapped in the envelope %v has the reserved annotation %s or %s", k, resRef, envelopeReader.GetEnvelopeObjRef(), fleetv1beta1.BlobDigestAnnotation, fleetv1beta1.BlobSizeAnnotation)
+			klog.ErrorS(wrappedErr, "Found an invalid manifest", "manifestKey", k, "envelope", envelopeReader.GetEnvelopeObjRef())
+			return nil, controller.NewUserError(wrappedErr)
 		}
 
 		manifests = append(manifests, fleetv1beta1.Manifest{
@@ -184,7 +199,7 @@ func refreshWorkForEnvelopeCR(
 	work.Annotations[fleetv1beta1.ParentResourceOverrideSnapshotHashAnnotation] = resourceOverrideSnapshotHash
 	work.Annotations[fleetv1beta1.ParentClusterResourceOverrideSnapshotHashAnnotation] = clusterResourceOverrideSnapshotHash
 	// Update the work spec (the manifests and the apply strategy).
-	work.Spec.Workload.Manifests = manifests
+	work.Spec.Workload = fleetv1beta1.WorkloadTemplate{Manifests: manifests}
 	work.Spec.ApplyStrategy = resourceBinding.GetBindingSpec().ApplyStrategy
 }
 
@@ -234,3 +249,12 @@ func buildNewWorkForEnvelopeCR(
 		},
 	}
 }
+
+// hasBlobAnnotations returns whether an object has any of the annotations reserved for the references to
+// the resources offloaded by Fleet.
+func hasBlobAnnotations(obj *unstructured.Unstructured) bool {
+	annots := obj.GetAnnotations()
+	_, hasDigest := annots[fleetv1beta1.BlobDigestAnnotation]
+	_, hasSize := annots[fleetv1beta1.BlobSizeAnnotation]
+	return hasDigest || hasSize
+}
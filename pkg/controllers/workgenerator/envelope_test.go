@@ -83,6 +83,22 @@ func TestExtractManifestsFromEnvelopeCR(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "ResourceEnvelope with a resource that has the reserved blob annotation should fail",
+			envelopeReader: &fleetv1beta1.ResourceEnvelope{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-envelope",
+					Namespace: "default",
+				},
+				Data: map[string]runtime.RawExtension{
+					"resource1": {
+						Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"test-cm","namespace":"default","annotations":{"kubernetes-fleet.io/blob-digest":"sha256:0123456789abcdef"}}}`),
+					},
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name: "valid ClusterResourceEnvelope with one resource",
 			envelopeReader: &fleetv1beta1.ClusterResourceEnvelope{
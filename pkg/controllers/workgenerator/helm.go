@@ -0,0 +1,82 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workgenerator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/controller"
+	"go.goms.io/fleet/pkg/utils/helm"
+)
+
+// renderHelmRelease fetches and renders the chart in a HelmRelease object, which the overrides have been applied to,
+// into the manifests for the target cluster of a binding.
+func (r *Reconciler) renderHelmRelease(ctx context.Context, release *unstructured.Unstructured, resourceBinding fleetv1beta1.BindingObj) ([]fleetv1beta1.Manifest, error) {
+	if r.HelmChartFetcher == nil {
+		return nil, controller.NewUnexpectedBehaviorError(fmt.Errorf("no Helm chart fetcher is set up to render Helm release %s", klog.KObj(release)))
+	}
+	rendered, err := helm.RenderRelease(ctx, r.HelmChartFetcher, release)
+	var fetchErr *helm.FetchError
+	if errors.As(err, &fetchErr) {
+		// The chart repository might be unavailable for now; retry later.
+		klog.ErrorS(err, "Failed to fetch the chart of the Helm release", "helmRelease", klog.KObj(release), "resourceBinding", klog.KObj(resourceBinding))
+		return nil, fmt.Errorf("failed to fetch the chart of Helm release %s: %w", klog.KObj(release), err)
+	}
+	if err != nil {
+		klog.ErrorS(err, "Failed to render the Helm release", "helmRelease", klog.KObj(release), "resourceBinding", klog.KObj(resourceBinding))
+		return nil, controller.NewUserError(fmt.Errorf("failed to render Helm release %s: %w", klog.KObj(release), err))
+	}
+
+	manifests := make([]fleetv1beta1.Manifest, 0, len(rendered))
+	for _, obj := range rendered {
+		if obj.GetNamespace() == "" && !r.InformerManager.IsClusterScopedResources(obj.GroupVersionKind()) {
+			obj.SetNamespace(release.GetNamespace())
+		}
+		objRef := klog.KObj(obj)
+		// Perform some basic validation, as the values set by the overrides are not validated when the
+		// resource snapshot is created.
+		switch {
+		// A resourcePlacement can only place the resources in its own namespace.
+		case resourceBinding.GetNamespace() != "" && obj.GetNamespace() != resourceBinding.GetNamespace():
+			wrappedErr := fmt.Errorf("the object %s (%v) rendered from Helm release %s is not in the namespace %s of the placement", obj.GroupVersionKind(), objRef, klog.KObj(release), resourceBinding.GetNamespace())
+			klog.ErrorS(wrappedErr, "Found an invalid manifest", "helmRelease", klog.KObj(release))
+			return nil, controller.NewUserError(wrappedErr)
+
+		// Check if the rendered manifest carries the annotations reserved for the references to the resources offloaded by Fleet.
+		case hasBlobAnnotations(obj):
+			wrappedErr := fmt.Errorf("the object %s (%v) rendered from Helm release %s has the reserved annotation %s or %s", obj.GroupVersionKind(), objRef, klog.KObj(release), fleetv1beta1.BlobDigestAnnotation, fleetv1beta1.BlobSizeAnnotation)
+			klog.ErrorS(wrappedErr, "Found an invalid manifest", "helmRelease", klog.KObj(release))
+			return nil, controller.NewUserError(wrappedErr)
+		}
+
+		raw, err := obj.MarshalJSON()
+		if err != nil {
+			klog.ErrorS(err, "Failed to marshal the rendered object", "helmRelease", klog.KObj(release), "object", objRef)
+			return nil, controller.NewUnexpectedBehaviorError(err)
+		}
+		manifests = append(manifests, fleetv1beta1.Manifest{RawExtension: runtime.RawExtension{Raw: raw}})
+	}
+	klog.V(2).InfoS("Rendered the Helm release", "helmRelease", klog.KObj(release), "resourceBinding", klog.KObj(resourceBinding), "manifestCount", len(manifests))
+	return manifests, nil
+}
@@ -0,0 +1,313 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workgenerator
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+	"go.goms.io/fleet/pkg/utils/controller"
+	"go.goms.io/fleet/pkg/utils/helm"
+	"go.goms.io/fleet/test/utils/informer"
+)
+
+// buildChartArchive builds a chart archive with the given files in the chart directory.
+func buildChartArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: "app/" + name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatalf("WriteHeader() = %v", err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatalf("Write() = %v", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("Close() = %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("Close() = %v", err)
+	}
+	return buf.Bytes()
+}
+
+// fakeChartFetcher returns the same chart archive or error for any chart.
+type fakeChartFetcher struct {
+	archive []byte
+	err     error
+}
+
+// Fetch implements helm.ChartFetcher.
+func (f *fakeChartFetcher) Fetch(_ context.Context, _ *fleetv1beta1.HelmChart) ([]byte, error) {
+	return f.archive, f.err
+}
+
+func TestRenderHelmRelease(t *testing.T) {
+	archive := buildChartArchive(t, map[string]string{
+		"Chart.yaml":  "apiVersion: v2\nname: app\nversion: 1.0.0\n",
+		"values.yaml": "replicaCount: 1\n",
+		"templates/deployment.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ .Release.Name }}
+  {{- with .Values.namespace }}
+  namespace: {{ . }}
+  {{- end }}
+  {{- with .Values.annotations }}
+  annotations:
+    {{- toYaml . | nindent 4 }}
+  {{- end }}
+spec:
+  replicas: {{ .Values.replicaCount }}
+`,
+		"templates/clusterrole.yaml": `{{- if .Values.clusterRole }}
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: {{ .Release.Name }}
+{{- end }}
+`,
+	})
+	release := func(values string) *unstructured.Unstructured {
+		obj, err := helm.NewRelease(&fleetv1beta1.HelmSource{
+			Chart: fleetv1beta1.HelmChart{
+				Repository: "oci://registry.example.com/charts",
+				Name:       "app",
+				Version:    "1.0.0",
+			},
+			ReleaseName: "web",
+			Values:      &apiextensionsv1.JSON{Raw: []byte(values)},
+		}, "app", archive)
+		if err != nil {
+			t.Fatalf("NewRelease() = %v", err)
+		}
+		return obj
+	}
+	crb := &fleetv1beta1.ClusterResourceBinding{ObjectMeta: metav1.ObjectMeta{Name: "test-crb"}}
+	rb := &fleetv1beta1.ResourceBinding{ObjectMeta: metav1.ObjectMeta{Name: "test-rb", Namespace: "app"}}
+
+	tests := []struct {
+		name            string
+		release         *unstructured.Unstructured
+		resourceBinding fleetv1beta1.BindingObj
+		fetcher         helm.ChartFetcher
+		want            []fleetv1beta1.Manifest
+		wantErr         error
+		wantAnyErr      bool
+	}{
+		{
+			name:            "render the release for a clusterResourceBinding",
+			release:         release(`{"replicaCount": 3, "clusterRole": true}`),
+			resourceBinding: crb,
+			fetcher:         &fakeChartFetcher{archive: archive},
+			want: []fleetv1beta1.Manifest{
+				{RawExtension: runtime.RawExtension{Raw: []byte(`{"apiVersion":"rbac.authorization.k8s.io/v1","kind":"ClusterRole","metadata":{"name":"web"}}` + "\n")}},
+				{RawExtension: runtime.RawExtension{Raw: []byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web","namespace":"app"},"spec":{"replicas":3}}` + "\n")}},
+			},
+		},
+		{
+			name:            "render the release for a resourceBinding",
+			release:         release(`{}`),
+			resourceBinding: rb,
+			fetcher:         &fakeChartFetcher{archive: archive},
+			want: []fleetv1beta1.Manifest{
+				{RawExtension: runtime.RawExtension{Raw: []byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web","namespace":"app"},"spec":{"replicas":1}}` + "\n")}},
+			},
+		},
+		{
+			name:            "rendered resource outside of the namespace of a resourceBinding",
+			release:         release(`{"namespace": "other"}`),
+			resourceBinding: rb,
+			fetcher:         &fakeChartFetcher{archive: archive},
+			wantErr:         controller.ErrUserError,
+		},
+		{
+			name:            "rendered cluster-scoped resource for a resourceBinding",
+			release:         release(`{"clusterRole": true}`),
+			resourceBinding: rb,
+			fetcher:         &fakeChartFetcher{archive: archive},
+			wantErr:         controller.ErrUserError,
+		},
+		{
+			name:            "rendered resource with the reserved annotations",
+			release:         release(`{"annotations": {"kubernetes-fleet.io/blob-digest": "sha256:0"}}`),
+			resourceBinding: crb,
+			fetcher:         &fakeChartFetcher{archive: archive},
+			wantErr:         controller.ErrUserError,
+		},
+		{
+			name:            "rendered document which is not a resource",
+			release:         release(`{"replicaCount": "1\nfoo: [bar"}`),
+			resourceBinding: crb,
+			fetcher:         &fakeChartFetcher{archive: archive},
+			wantErr:         controller.ErrUserError,
+		},
+		{
+			name:            "chart which has changed since the snapshot was created",
+			release:         release(`{}`),
+			resourceBinding: crb,
+			fetcher:         &fakeChartFetcher{archive: buildChartArchive(t, map[string]string{"Chart.yaml": "apiVersion: v2\nname: app\nversion: 1.0.0\n"})},
+			wantErr:         controller.ErrUserError,
+		},
+		{
+			name:            "chart cannot be fetched",
+			release:         release(`{}`),
+			resourceBinding: crb,
+			fetcher:         &fakeChartFetcher{err: errors.New("unavailable")},
+			wantAnyErr:      true,
+		},
+		{
+			name:            "no chart fetcher",
+			release:         release(`{}`),
+			resourceBinding: crb,
+			wantErr:         controller.ErrUnexpectedBehavior,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &Reconciler{
+				InformerManager: &informer.FakeManager{
+					APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
+					IsClusterScopedResource: true,
+				},
+				HelmChartFetcher: tc.fetcher,
+			}
+			got, err := r.renderHelmRelease(context.Background(), tc.release, tc.resourceBinding)
+			if tc.wantAnyErr {
+				// The errors to fetch the charts are retried, instead of being reported as the user errors.
+				if err == nil || errors.Is(err, controller.ErrUserError) {
+					t.Fatalf("renderHelmRelease() = %v, want a retriable error", err)
+				}
+				return
+			}
+			if gotErr, wantErr := err != nil, tc.wantErr != nil; gotErr != wantErr || !errors.Is(err, tc.wantErr) {
+				t.Fatalf("renderHelmRelease() = %v, want error %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("renderHelmRelease() mismatch (-got, +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestRenderHelmRelease_WithOverrides verifies that the values set by the overrides on the HelmRelease
+// object are used to render the chart for the member clusters they select.
+func TestRenderHelmRelease_WithOverrides(t *testing.T) {
+	archive := buildChartArchive(t, map[string]string{
+		"Chart.yaml":                "apiVersion: v2\nname: app\nversion: 1.0.0\n",
+		"values.yaml":               "replicaCount: 1\n",
+		"templates/deployment.yaml": "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\nspec:\n  replicas: {{ .Values.replicaCount }}\n",
+	})
+	release, err := helm.NewRelease(&fleetv1beta1.HelmSource{
+		Chart: fleetv1beta1.HelmChart{
+			Repository: "oci://registry.example.com/charts",
+			Name:       "app",
+			Version:    "1.0.0",
+		},
+		ReleaseName: "web",
+	}, "app", archive)
+	if err != nil {
+		t.Fatalf("NewRelease() = %v", err)
+	}
+	raw, err := release.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON() = %v", err)
+	}
+	rules := []fleetv1beta1.OverrideRule{
+		{
+			ClusterSelector: &fleetv1beta1.ClusterSelector{
+				ClusterSelectorTerms: []fleetv1beta1.ClusterSelectorTerm{
+					{
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"env": "prod"},
+						},
+					},
+				},
+			},
+			JSONPatchOverrides: []fleetv1beta1.JSONPatchOverride{
+				{
+					Operator: fleetv1beta1.JSONPatchOverrideOpAdd,
+					Path:     "/spec/values",
+					Value:    apiextensionsv1.JSON{Raw: []byte(`{"replicaCount": 5}`)},
+				},
+			},
+		},
+	}
+	r := &Reconciler{
+		InformerManager: &informer.FakeManager{
+			APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
+			IsClusterScopedResource: true,
+		},
+		HelmChartFetcher: &fakeChartFetcher{archive: archive},
+	}
+
+	tests := []struct {
+		name         string
+		clusterLabel string
+		want         string
+	}{
+		{
+			name:         "cluster selected by the override",
+			clusterLabel: "prod",
+			want:         `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web","namespace":"app"},"spec":{"replicas":5}}`,
+		},
+		{
+			name:         "cluster not selected by the override",
+			clusterLabel: "test",
+			want:         `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web","namespace":"app"},"spec":{"replicas":1}}`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := &clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "member", Labels: map[string]string{"env": tc.clusterLabel}},
+			}
+			resource := &fleetv1beta1.ResourceContent{RawExtension: runtime.RawExtension{Raw: raw}}
+			if err := applyOverrideRules(resource, cluster, rules); err != nil {
+				t.Fatalf("applyOverrideRules() = %v", err)
+			}
+			var overridden unstructured.Unstructured
+			if err := overridden.UnmarshalJSON(resource.Raw); err != nil {
+				t.Fatalf("UnmarshalJSON() = %v", err)
+			}
+			got, err := r.renderHelmRelease(context.Background(), &overridden, &fleetv1beta1.ClusterResourceBinding{})
+			if err != nil {
+				t.Fatalf("renderHelmRelease() = %v", err)
+			}
+			if len(got) != 1 || string(got[0].Raw) != tc.want+"\n" {
+				t.Errorf("renderHelmRelease() = %v, want one manifest %s", got, tc.want)
+			}
+		})
+	}
+}
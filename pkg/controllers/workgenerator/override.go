@@ -26,6 +26,8 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/klog/v2"
 
 	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
@@ -195,10 +197,23 @@ func applyOverrideRules(resource *placementv1beta1.ResourceContent, cluster *clu
 			resource.Raw = nil
 			return nil
 		}
-		// Apply JSONPatchOverrides by default
-		if err = applyJSONPatchOverride(resource, cluster, rule.JSONPatchOverrides); err != nil {
-			klog.ErrorS(err, "Failed to apply JSON patch override")
-			return controller.NewUserError(err)
+		switch rule.OverrideType {
+		case placementv1beta1.MergePatchOverrideType:
+			if err = applyMergePatchOverride(resource, cluster, rule.MergePatchOverride); err != nil {
+				klog.ErrorS(err, "Failed to apply merge patch override")
+				return controller.NewUserError(err)
+			}
+		case placementv1beta1.CELOverrideType:
+			if err = applyCELOverride(resource, cluster, rule.CELOverrides); err != nil {
+				klog.ErrorS(err, "Failed to apply CEL override")
+				return controller.NewUserError(err)
+			}
+		default:
+			// Apply JSONPatchOverrides by default
+			if err = applyJSONPatchOverride(resource, cluster, rule.JSONPatchOverrides); err != nil {
+				klog.ErrorS(err, "Failed to apply JSON patch override")
+				return controller.NewUserError(err)
+			}
 		}
 	}
 	return nil
@@ -213,17 +228,11 @@ func applyJSONPatchOverride(resourceContent *placementv1beta1.ResourceContent, c
 	// go through the JSON patch overrides to replace the built-in variables before json Marshal
 	// as it may contain the built-in variables that cannot be marshaled directly
 	for i := range overrides {
-		// Process the JSON string to replace variables
-		jsonStr := string(overrides[i].Value.Raw)
-		// Replace the built-in ${MEMBER-CLUSTER-NAME} variable with the actual cluster name
-		jsonStr = strings.ReplaceAll(jsonStr, placementv1beta1.OverrideClusterNameVariable, cluster.Name)
-		// Replace label key variables with actual label values
-		jsonStr, err = replaceClusterLabelKeyVariables(jsonStr, cluster)
+		overrides[i].Value.Raw, err = renderOverrideValue(overrides[i].Value.Raw, cluster, overrides[i].EnableTemplate)
 		if err != nil {
-			klog.ErrorS(err, "Failed to replace cluster label key variables in JSON patch override")
+			klog.ErrorS(err, "Failed to render the value in JSON patch override", "path", overrides[i].Path)
 			return err
 		}
-		overrides[i].Value.Raw = []byte(jsonStr)
 	}
 
 	jsonPatchBytes, err := json.Marshal(overrides)
@@ -231,7 +240,11 @@ func applyJSONPatchOverride(resourceContent *placementv1beta1.ResourceContent, c
 		klog.ErrorS(err, "Failed to marshal JSON Patch overrides")
 		return err
 	}
+	return applyJSONPatch(resourceContent, jsonPatchBytes)
+}
 
+// applyJSONPatch applies an encoded JSON patch on the resource.
+func applyJSONPatch(resourceContent *placementv1beta1.ResourceContent, jsonPatchBytes []byte) error {
 	patch, err := jsonpatch.DecodePatch(jsonPatchBytes)
 	if err != nil {
 		klog.ErrorS(err, "Failed to decode the passed JSON document as an RFC 6902 patch")
@@ -247,6 +260,100 @@ func applyJSONPatchOverride(resourceContent *placementv1beta1.ResourceContent, c
 	return nil
 }
 
+// applyMergePatchOverride applies a strategic merge patch or a JSON merge patch on the selected resources.
+func applyMergePatchOverride(resourceContent *placementv1beta1.ResourceContent, cluster *clusterv1beta1.MemberCluster, override *placementv1beta1.MergePatchOverride) error {
+	if override == nil { // do nothing
+		return nil
+	}
+	patch, err := renderOverrideValue(override.Patch.Raw, cluster, override.EnableTemplate)
+	if err != nil {
+		klog.ErrorS(err, "Failed to render the merge patch override")
+		return err
+	}
+
+	var patchedObjectJSONBytes []byte
+	switch override.Type {
+	case placementv1beta1.JSONMergePatchType:
+		patchedObjectJSONBytes, err = jsonpatch.MergePatch(resourceContent.Raw, patch)
+	default:
+		var u unstructured.Unstructured
+		if err := u.UnmarshalJSON(resourceContent.Raw); err != nil {
+			klog.ErrorS(err, "Failed to unmarshal the resource")
+			return err
+		}
+		dataStruct, schemeErr := clientgoscheme.Scheme.New(u.GroupVersionKind())
+		if schemeErr != nil {
+			// The resource type has no strategic merge metadata (e.g., a custom resource); fall back to
+			// a JSON merge patch.
+			klog.V(2).InfoS("Applying the strategic merge patch as a JSON merge patch", "gvk", u.GroupVersionKind(), "resource", klog.KObj(&u))
+			patchedObjectJSONBytes, err = jsonpatch.MergePatch(resourceContent.Raw, patch)
+		} else {
+			patchedObjectJSONBytes, err = strategicpatch.StrategicMergePatch(resourceContent.Raw, patch, dataStruct)
+		}
+	}
+	if err != nil {
+		klog.ErrorS(err, "Failed to apply the merge patch to the resource", "type", override.Type)
+		return err
+	}
+	resourceContent.Raw = patchedObjectJSONBytes
+	return nil
+}
+
+// applyCELOverride applies the CEL overrides on the selected resources, one after another.
+func applyCELOverride(resourceContent *placementv1beta1.ResourceContent, cluster *clusterv1beta1.MemberCluster, overrides []placementv1beta1.CELOverride) error {
+	for i := range overrides {
+		var obj map[string]interface{}
+		if err := json.Unmarshal(resourceContent.Raw, &obj); err != nil {
+			klog.ErrorS(err, "Failed to unmarshal the resource")
+			return err
+		}
+		value, err := renderOverrideValue(overrides[i].Value.Raw, cluster, false)
+		if err != nil {
+			klog.ErrorS(err, "Failed to render the value in CEL override", "listPath", overrides[i].ListPath)
+			return err
+		}
+		ops, err := overrider.BuildJSONPatchForCELOverride(obj, cluster, &overrides[i], value)
+		if err != nil {
+			klog.ErrorS(err, "Failed to evaluate CEL override", "listPath", overrides[i].ListPath, "selector", overrides[i].Selector)
+			return err
+		}
+		if len(ops) == 0 {
+			klog.V(2).InfoS("No list item is selected by the CEL override", "listPath", overrides[i].ListPath, "selector", overrides[i].Selector)
+			continue
+		}
+		jsonPatchBytes, err := json.Marshal(ops)
+		if err != nil {
+			klog.ErrorS(err, "Failed to marshal the JSON patch of CEL override")
+			return err
+		}
+		if err := applyJSONPatch(resourceContent, jsonPatchBytes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderOverrideValue replaces the built-in variables in an override value and, if enabled, renders its templates.
+func renderOverrideValue(raw []byte, cluster *clusterv1beta1.MemberCluster, enableTemplate bool) ([]byte, error) {
+	if len(raw) == 0 {
+		return raw, nil
+	}
+	// Process the JSON string to replace variables
+	jsonStr := string(raw)
+	// Replace the built-in ${MEMBER-CLUSTER-NAME} variable with the actual cluster name
+	jsonStr = strings.ReplaceAll(jsonStr, placementv1beta1.OverrideClusterNameVariable, cluster.Name)
+	// Replace label key variables with actual label values
+	jsonStr, err := replaceClusterLabelKeyVariables(jsonStr, cluster)
+	if err != nil {
+		klog.ErrorS(err, "Failed to replace cluster label key variables in the override value")
+		return nil, err
+	}
+	if !enableTemplate {
+		return []byte(jsonStr), nil
+	}
+	return overrider.RenderJSONPatchOverrideValue([]byte(jsonStr), overrider.NewTemplateData(cluster))
+}
+
 // replaceClusterLabelKeyVariables finds all occurrences of the OverrideClusterLabelKeyVariablePrefix pattern
 // (e.g. ${MEMBER-CLUSTER-LABEL-KEY-region}) in the input string and replaces them with
 // the corresponding label values from the cluster.
@@ -18,6 +18,7 @@ package workgenerator
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"testing"
@@ -25,12 +26,15 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -2712,6 +2716,124 @@ func TestApplyJSONPatchOverride(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "templated values",
+			deployment: appsv1.Deployment{
+				TypeMeta: deploymentType,
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deployment-name",
+					Namespace: "deployment-namespace",
+					Labels: map[string]string{
+						"app": "nginx",
+					},
+				},
+				Spec: appsv1.DeploymentSpec{
+					Replicas: ptr.To(int32(1)),
+				},
+			},
+			overrides: []placementv1beta1.JSONPatchOverride{
+				{
+					Operator:       placementv1beta1.JSONPatchOverrideOpReplace,
+					Path:           "/spec/replicas",
+					Value:          apiextensionsv1.JSON{Raw: []byte(`"{{ index .Cluster.Properties \"kubernetes-fleet.io/node-count\" | int | mul 2 }}"`)},
+					EnableTemplate: true,
+				},
+				{
+					Operator: placementv1beta1.JSONPatchOverrideOpAdd,
+					Path:     "/metadata/annotations",
+					Value: apiextensionsv1.JSON{Raw: []byte(`{
+						"region": "{{ .Cluster.Labels.region | upper }}",
+						"env": "{{ index .Cluster.Annotations \"env\" | default \"dev\" }}",
+						"cluster": "{{ printf \"%s-%s\" .Cluster.Name (lower .Cluster.Labels.region) }}",
+						"cpu": "${MEMBER-CLUSTER-NAME}:{{ .Cluster.ResourceUsage.Allocatable.cpu }}"
+					}`)},
+					EnableTemplate: true,
+				},
+			},
+			cluster: &clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-1",
+					Labels: map[string]string{
+						"region": "EastUS",
+					},
+				},
+				Status: clusterv1beta1.MemberClusterStatus{
+					Properties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+						"kubernetes-fleet.io/node-count": {Value: "3"},
+					},
+					ResourceUsage: clusterv1beta1.ResourceUsage{
+						Allocatable: corev1.ResourceList{
+							corev1.ResourceCPU: k8sresource.MustParse("3500m"),
+						},
+					},
+				},
+			},
+			wantDeployment: appsv1.Deployment{
+				TypeMeta: deploymentType,
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deployment-name",
+					Namespace: "deployment-namespace",
+					Labels: map[string]string{
+						"app": "nginx",
+					},
+					Annotations: map[string]string{
+						"region":  "EASTUS",
+						"env":     "dev",
+						"cluster": "cluster-1-eastus",
+						"cpu":     "cluster-1:3500m",
+					},
+				},
+				Spec: appsv1.DeploymentSpec{
+					Replicas: ptr.To(int32(6)),
+				},
+			},
+		},
+		{
+			name: "template is not rendered if not enabled",
+			deployment: appsv1.Deployment{
+				TypeMeta: deploymentType,
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deployment-name",
+					Namespace: "deployment-namespace",
+				},
+			},
+			overrides: []placementv1beta1.JSONPatchOverride{
+				{
+					Operator: placementv1beta1.JSONPatchOverrideOpAdd,
+					Path:     "/metadata/annotations",
+					Value:    apiextensionsv1.JSON{Raw: []byte(`{"template": "{{ .Cluster.Name }}"}`)},
+				},
+			},
+			wantDeployment: appsv1.Deployment{
+				TypeMeta: deploymentType,
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deployment-name",
+					Namespace: "deployment-namespace",
+					Annotations: map[string]string{
+						"template": "{{ .Cluster.Name }}",
+					},
+				},
+			},
+		},
+		{
+			name: "templated value with missing key",
+			deployment: appsv1.Deployment{
+				TypeMeta: deploymentType,
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deployment-name",
+					Namespace: "deployment-namespace",
+				},
+			},
+			overrides: []placementv1beta1.JSONPatchOverride{
+				{
+					Operator:       placementv1beta1.JSONPatchOverrideOpAdd,
+					Path:           "/metadata/annotations",
+					Value:          apiextensionsv1.JSON{Raw: []byte(`{"region": "{{ .Cluster.Labels.region }}"}`)},
+					EnableTemplate: true,
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "replace with non-existent label key",
 			deployment: appsv1.Deployment{
@@ -2878,3 +3000,300 @@ func TestReplaceClusterLabelKeyVariables(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyMergePatchOverride(t *testing.T) {
+	deployment := `{
+		"apiVersion": "apps/v1",
+		"kind": "Deployment",
+		"metadata": {"name": "app", "namespace": "app", "labels": {"app": "nginx"}},
+		"spec": {"template": {"spec": {"containers": [
+			{"name": "sidecar", "image": "envoy:1.0"},
+			{"name": "app", "image": "nginx:1.0", "env": [{"name": "MODE", "value": "dev"}]}
+		]}}}
+	}`
+	customResource := `{
+		"apiVersion": "example.com/v1",
+		"kind": "Widget",
+		"metadata": {"name": "widget", "namespace": "app"},
+		"spec": {"size": 1, "ports": [80, 443]}
+	}`
+
+	testCases := []struct {
+		name     string
+		resource string
+		override *placementv1beta1.MergePatchOverride
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "strategic merge patch merges list items by key",
+			resource: deployment,
+			override: &placementv1beta1.MergePatchOverride{
+				Patch: apiextensionsv1.JSON{Raw: []byte(`{
+					"metadata": {"labels": {"cluster": "${MEMBER-CLUSTER-NAME}"}},
+					"spec": {"template": {"spec": {"containers": [
+						{"name": "app", "image": "nginx:2.0", "env": [{"name": "MODE", "value": "prod"}]}
+					]}}}
+				}`)},
+			},
+			want: `{
+				"apiVersion": "apps/v1",
+				"kind": "Deployment",
+				"metadata": {"name": "app", "namespace": "app", "labels": {"app": "nginx", "cluster": "cluster-1"}},
+				"spec": {"template": {"spec": {"containers": [
+					{"name": "sidecar", "image": "envoy:1.0"},
+					{"name": "app", "image": "nginx:2.0", "env": [{"name": "MODE", "value": "prod"}]}
+				]}}}
+			}`,
+		},
+		{
+			name:     "JSON merge patch replaces lists",
+			resource: deployment,
+			override: &placementv1beta1.MergePatchOverride{
+				Type: placementv1beta1.JSONMergePatchType,
+				Patch: apiextensionsv1.JSON{Raw: []byte(`{
+					"metadata": {"labels": {"app": null}},
+					"spec": {"template": {"spec": {"containers": [{"name": "app", "image": "nginx:2.0"}]}}}
+				}`)},
+			},
+			want: `{
+				"apiVersion": "apps/v1",
+				"kind": "Deployment",
+				"metadata": {"name": "app", "namespace": "app", "labels": {}},
+				"spec": {"template": {"spec": {"containers": [{"name": "app", "image": "nginx:2.0"}]}}}
+			}`,
+		},
+		{
+			name:     "strategic merge patch on custom resources falls back to JSON merge patch",
+			resource: customResource,
+			override: &placementv1beta1.MergePatchOverride{
+				Type:  placementv1beta1.StrategicMergePatchType,
+				Patch: apiextensionsv1.JSON{Raw: []byte(`{"spec": {"ports": [8080]}}`)},
+			},
+			want: `{
+				"apiVersion": "example.com/v1",
+				"kind": "Widget",
+				"metadata": {"name": "widget", "namespace": "app"},
+				"spec": {"size": 1, "ports": [8080]}
+			}`,
+		},
+		{
+			name:     "templated patch",
+			resource: customResource,
+			override: &placementv1beta1.MergePatchOverride{
+				Type:           placementv1beta1.JSONMergePatchType,
+				Patch:          apiextensionsv1.JSON{Raw: []byte(`{"spec": {"size": "{{ index .Cluster.Properties \"kubernetes-fleet.io/node-count\" | int }}"}}`)},
+				EnableTemplate: true,
+			},
+			want: `{
+				"apiVersion": "example.com/v1",
+				"kind": "Widget",
+				"metadata": {"name": "widget", "namespace": "app"},
+				"spec": {"size": 3, "ports": [80, 443]}
+			}`,
+		},
+		{
+			name:     "invalid patch",
+			resource: deployment,
+			override: &placementv1beta1.MergePatchOverride{
+				Patch: apiextensionsv1.JSON{Raw: []byte(`{"spec": `)},
+			},
+			wantErr: true,
+		},
+	}
+
+	cluster := &clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster-1",
+		},
+		Status: clusterv1beta1.MemberClusterStatus{
+			Properties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+				"kubernetes-fleet.io/node-count": {Value: "3"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rc := &placementv1beta1.ResourceContent{RawExtension: runtime.RawExtension{Raw: []byte(tc.resource)}}
+			err := applyMergePatchOverride(rc, cluster, tc.override)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("applyMergePatchOverride() = error %v, want %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			var got, want map[string]interface{}
+			if err := json.Unmarshal(rc.Raw, &got); err != nil {
+				t.Fatalf("Failed to unmarshal the result: %v, want nil", err)
+			}
+			if err := json.Unmarshal([]byte(tc.want), &want); err != nil {
+				t.Fatalf("Failed to unmarshal the wanted result: %v, want nil", err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("applyMergePatchOverride() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestApplyCELOverride(t *testing.T) {
+	deployment := `{
+		"apiVersion": "apps/v1",
+		"kind": "Deployment",
+		"metadata": {"name": "app", "namespace": "app"},
+		"spec": {"template": {"spec": {"containers": [
+			{"name": "sidecar", "image": "docker.io/envoy:1.0"},
+			{"name": "app", "image": "docker.io/nginx:1.0", "env": [{"name": "MODE", "value": "dev"}, {"name": "DEBUG", "value": "true"}]},
+			{"name": "debugger", "image": "docker.io/busybox"}
+		]}}}
+	}`
+
+	testCases := []struct {
+		name      string
+		overrides []placementv1beta1.CELOverride
+		want      string
+		wantErr   bool
+	}{
+		{
+			name: "replace a field of the list items selected by key",
+			overrides: []placementv1beta1.CELOverride{
+				{
+					ListPath: "/spec/template/spec/containers",
+					Selector: `item.name == "app"`,
+					Operator: placementv1beta1.JSONPatchOverrideOpReplace,
+					Path:     "/image",
+					Value:    apiextensionsv1.JSON{Raw: []byte(`"nginx:${MEMBER-CLUSTER-LABEL-KEY-version}"`)},
+				},
+				{
+					ListPath: "/spec/template/spec/containers",
+					Selector: `item.name == "app"`,
+					Operator: placementv1beta1.JSONPatchOverrideOpAdd,
+					Path:     "/env/-",
+					Value:    apiextensionsv1.JSON{Raw: []byte(`{"name": "CLUSTER", "value": "${MEMBER-CLUSTER-NAME}"}`)},
+				},
+			},
+			want: `{
+				"apiVersion": "apps/v1",
+				"kind": "Deployment",
+				"metadata": {"name": "app", "namespace": "app"},
+				"spec": {"template": {"spec": {"containers": [
+					{"name": "sidecar", "image": "docker.io/envoy:1.0"},
+					{"name": "app", "image": "nginx:2.0", "env": [{"name": "MODE", "value": "dev"}, {"name": "DEBUG", "value": "true"}, {"name": "CLUSTER", "value": "cluster-1"}]},
+					{"name": "debugger", "image": "docker.io/busybox"}
+				]}}}
+			}`,
+		},
+		{
+			name: "value expression",
+			overrides: []placementv1beta1.CELOverride{
+				{
+					ListPath:        "/spec/template/spec/containers",
+					Selector:        `item.image.startsWith("docker.io/")`,
+					Operator:        placementv1beta1.JSONPatchOverrideOpReplace,
+					Path:            "/image",
+					ValueExpression: `item.image.replace("docker.io/", cluster.labels["registry"] + "/")`,
+				},
+			},
+			want: `{
+				"apiVersion": "apps/v1",
+				"kind": "Deployment",
+				"metadata": {"name": "app", "namespace": "app"},
+				"spec": {"template": {"spec": {"containers": [
+					{"name": "sidecar", "image": "myregistry.io/envoy:1.0"},
+					{"name": "app", "image": "myregistry.io/nginx:1.0", "env": [{"name": "MODE", "value": "dev"}, {"name": "DEBUG", "value": "true"}]},
+					{"name": "debugger", "image": "myregistry.io/busybox"}
+				]}}}
+			}`,
+		},
+		{
+			name: "remove list items",
+			overrides: []placementv1beta1.CELOverride{
+				{
+					ListPath: "/spec/template/spec/containers",
+					Selector: `item.name != "app"`,
+					Operator: placementv1beta1.JSONPatchOverrideOpRemove,
+				},
+				{
+					ListPath: "/spec/template/spec/containers/0/env",
+					Selector: `item.name == "DEBUG"`,
+					Operator: placementv1beta1.JSONPatchOverrideOpRemove,
+				},
+			},
+			want: `{
+				"apiVersion": "apps/v1",
+				"kind": "Deployment",
+				"metadata": {"name": "app", "namespace": "app"},
+				"spec": {"template": {"spec": {"containers": [
+					{"name": "app", "image": "docker.io/nginx:1.0", "env": [{"name": "MODE", "value": "dev"}]}
+				]}}}
+			}`,
+		},
+		{
+			name: "no list item is selected",
+			overrides: []placementv1beta1.CELOverride{
+				{
+					ListPath: "/spec/template/spec/containers",
+					Selector: `item.name == "web"`,
+					Operator: placementv1beta1.JSONPatchOverrideOpReplace,
+					Path:     "/image",
+					Value:    apiextensionsv1.JSON{Raw: []byte(`"nginx:2.0"`)},
+				},
+			},
+			want: deployment,
+		},
+		{
+			name: "list does not exist",
+			overrides: []placementv1beta1.CELOverride{
+				{
+					ListPath: "/spec/template/spec/initContainers",
+					Selector: `item.name == "app"`,
+					Operator: placementv1beta1.JSONPatchOverrideOpRemove,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "selector does not evaluate to a boolean",
+			overrides: []placementv1beta1.CELOverride{
+				{
+					ListPath: "/spec/template/spec/containers",
+					Selector: `item.name`,
+					Operator: placementv1beta1.JSONPatchOverrideOpRemove,
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	cluster := &clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster-1",
+			Labels: map[string]string{
+				"version":  "2.0",
+				"registry": "myregistry.io",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rc := &placementv1beta1.ResourceContent{RawExtension: runtime.RawExtension{Raw: []byte(deployment)}}
+			err := applyCELOverride(rc, cluster, tc.overrides)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("applyCELOverride() = error %v, want %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			var got, want map[string]interface{}
+			if err := json.Unmarshal(rc.Raw, &got); err != nil {
+				t.Fatalf("Failed to unmarshal the result: %v, want nil", err)
+			}
+			if err := json.Unmarshal([]byte(tc.want), &want); err != nil {
+				t.Fatalf("Failed to unmarshal the wanted result: %v, want nil", err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("applyCELOverride() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workgenerator
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+	"go.goms.io/fleet/pkg/utils/controller"
+	"go.goms.io/fleet/pkg/utils/helm"
+)
+
+// RenderManifests renders the selected resources for the target cluster of a binding the same way as the
+// work generator does when it generates the works for the binding, i.e., it applies the overrides whose
+// snapshots are listed in the binding spec, drops the resources deleted by the overrides, unwraps the
+// envelopes, and renders the Helm releases. The binding does not have to exist in the system; this method
+// does not make any change to the system either.
+//
+// Unlike the works generated for a binding, the manifests wrapped in the envelopes are returned along with
+// the other manifests in one list.
+func (r *Reconciler) RenderManifests(
+	ctx context.Context,
+	resourceBinding fleetv1beta1.BindingObj,
+	cluster *clusterv1beta1.MemberCluster,
+	selectedResources []fleetv1beta1.ResourceContent,
+) ([]fleetv1beta1.Manifest, error) {
+	croMap, err := r.fetchClusterResourceOverrideSnapshots(ctx, resourceBinding)
+	if err != nil {
+		return nil, err
+	}
+	roMap, err := r.fetchResourceOverrideSnapshots(ctx, resourceBinding)
+	if err != nil {
+		return nil, err
+	}
+
+	manifests := make([]fleetv1beta1.Manifest, 0, len(selectedResources))
+	for i := range selectedResources {
+		selectedResource := selectedResources[i].DeepCopy()
+		resourceDeleted, err := r.applyOverrides(selectedResource, cluster, croMap, roMap)
+		if err != nil {
+			return nil, err
+		}
+		if resourceDeleted {
+			klog.V(2).InfoS("The resource is deleted by the override rules", "binding", klog.KObj(resourceBinding), "selectedResourceIdx", i)
+			continue
+		}
+
+		var uResource unstructured.Unstructured
+		if err := uResource.UnmarshalJSON(selectedResource.Raw); err != nil {
+			klog.ErrorS(err, "Selected resource has invalid content", "binding", klog.KObj(resourceBinding), "selectedResource", selectedResource.Raw)
+			return nil, controller.NewUnexpectedBehaviorError(err)
+		}
+		var envelopeReader fleetv1beta1.EnvelopeReader
+		switch uResource.GetObjectKind().GroupVersionKind().GroupKind() {
+		case utils.ClusterResourceEnvelopeGK:
+			envelopeReader = &fleetv1beta1.ClusterResourceEnvelope{}
+		case utils.ResourceEnvelopeGK:
+			envelopeReader = &fleetv1beta1.ResourceEnvelope{}
+		case helm.ReleaseGVK.GroupKind():
+			renderedManifests, err := r.renderHelmRelease(ctx, &uResource, resourceBinding)
+			if err != nil {
+				return nil, err
+			}
+			manifests = append(manifests, renderedManifests...)
+			continue
+		default:
+			manifests = append(manifests, fleetv1beta1.Manifest(*selectedResource))
+			continue
+		}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(uResource.Object, envelopeReader); err != nil {
+			klog.ErrorS(err, "Failed to convert the unstructured object to an envelope",
+				"binding", klog.KObj(resourceBinding), "selectedResource", klog.KObj(&uResource))
+			return nil, controller.NewUnexpectedBehaviorError(err)
+		}
+		wrappedManifests, err := extractManifestsFromEnvelopeCR(envelopeReader)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, wrappedManifests...)
+	}
+	return manifests, nil
+}
+
+// ExtractDiffedResourcePlacementsFromWork returns the resources which the member agent has found to be different
+// from their live objects in the member cluster, as reported in the work status.
+func ExtractDiffedResourcePlacementsFromWork(work *fleetv1beta1.Work) []fleetv1beta1.DiffedResourcePlacement {
+	return extractDiffedResourcePlacementsFromWork(work)
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workgenerator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+	"go.goms.io/fleet/pkg/utils/controller"
+	"go.goms.io/fleet/test/utils/informer"
+	"go.goms.io/fleet/test/utils/resource"
+)
+
+func TestRenderManifests(t *testing.T) {
+	fakeInformer := informer.FakeManager{
+		APIResources: map[schema.GroupVersionKind]bool{
+			utils.DeploymentGVK: true,
+		},
+		IsClusterScopedResource: false,
+	}
+	deployment := appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: utils.DeploymentGVK.GroupVersion().String(),
+			Kind:       utils.DeploymentGVK.Kind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "deployment-name",
+			Namespace: "app",
+		},
+	}
+	configMap := corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "config",
+			Namespace: "app",
+		},
+		Data: map[string]string{"key": "value"},
+	}
+	configMapContent := resource.CreateResourceContentForTest(t, configMap)
+	// The wrapped manifests are kept in the compact form once the envelope is serialized.
+	configMapContent.Raw = bytes.TrimSpace(configMapContent.Raw)
+	envelope := placementv1beta1.ResourceEnvelope{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: placementv1beta1.GroupVersion.String(),
+			Kind:       placementv1beta1.ResourceEnvelopeKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "envelope",
+			Namespace: "app",
+		},
+		Data: map[string]runtime.RawExtension{
+			"config.yaml": {Raw: configMapContent.Raw},
+		},
+	}
+	deploymentContent := resource.CreateResourceContentForTest(t, deployment)
+	selectedResources := []placementv1beta1.ResourceContent{
+		*deploymentContent,
+		*resource.CreateResourceContentForTest(t, envelope),
+	}
+	deleteDeploymentRO := &placementv1beta1.ResourceOverrideSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ro-1",
+			Namespace: "app",
+		},
+		Spec: placementv1beta1.ResourceOverrideSnapshotSpec{
+			OverrideSpec: placementv1beta1.ResourceOverrideSpec{
+				ResourceSelectors: []placementv1beta1.ResourceSelector{
+					{
+						Group:   utils.DeploymentGVK.Group,
+						Version: utils.DeploymentGVK.Version,
+						Kind:    utils.DeploymentGVK.Kind,
+						Name:    "deployment-name",
+					},
+				},
+				Policy: &placementv1beta1.OverridePolicy{
+					OverrideRules: []placementv1beta1.OverrideRule{
+						{
+							ClusterSelector: &placementv1beta1.ClusterSelector{}, // matching all the clusters
+							OverrideType:    placementv1beta1.DeleteOverrideType,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		objects       []client.Object
+		roSnapshots   []placementv1beta1.NamespacedName
+		wantManifests []placementv1beta1.Manifest
+		wantErr       error
+	}{
+		{
+			name: "no overrides",
+			wantManifests: []placementv1beta1.Manifest{
+				placementv1beta1.Manifest(*deploymentContent),
+				placementv1beta1.Manifest(*configMapContent),
+			},
+		},
+		{
+			name:        "resource deleted by the overrides",
+			objects:     []client.Object{deleteDeploymentRO},
+			roSnapshots: []placementv1beta1.NamespacedName{{Name: "ro-1", Namespace: "app"}},
+			wantManifests: []placementv1beta1.Manifest{
+				placementv1beta1.Manifest(*configMapContent),
+			},
+		},
+		{
+			name:        "override snapshot not found",
+			roSnapshots: []placementv1beta1.NamespacedName{{Name: "ro-1", Namespace: "app"}},
+			wantErr:     controller.ErrUserError,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(serviceScheme(t)).
+				WithObjects(tc.objects...).
+				Build()
+			r := Reconciler{
+				Client:          fakeClient,
+				InformerManager: &fakeInformer,
+			}
+			binding := &placementv1beta1.ClusterResourceBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "preview"},
+				Spec: placementv1beta1.ResourceBindingSpec{
+					TargetCluster:             "cluster-1",
+					ResourceOverrideSnapshots: tc.roSnapshots,
+				},
+			}
+			cluster := &clusterv1beta1.MemberCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster-1"}}
+			got, err := r.RenderManifests(context.Background(), binding, cluster, selectedResources)
+			if gotErr, wantErr := err != nil, tc.wantErr != nil; gotErr != wantErr || !errors.Is(err, tc.wantErr) {
+				t.Fatalf("RenderManifests() got error %v, want error %v", err, tc.wantErr)
+			}
+			if tc.wantErr != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.wantManifests, got); diff != "" {
+				t.Errorf("RenderManifests() manifests mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
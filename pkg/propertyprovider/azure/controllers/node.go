@@ -15,7 +15,7 @@ limitations under the License.
 */
 
 // Package controllers feature a number of controllers that are in use
-// by the Azure property provider.
+// by the Azure and the generic property providers.
 package controllers
 
 import (
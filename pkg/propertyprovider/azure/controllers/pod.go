@@ -15,17 +15,20 @@ limitations under the License.
 */
 
 // Package controllers feature a number of controllers that are in use
-// by the Azure property provider.
+// by the Azure and the generic property providers.
 package controllers
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"go.goms.io/fleet/pkg/propertyprovider/azure/trackers"
@@ -115,3 +118,61 @@ func (p *PodReconciler) SetupWithManager(mgr ctrl.Manager, controllerName string
 		For(&corev1.Pod{}).
 		Complete(p)
 }
+
+// PodCacheByObject returns the cache options for the pod objects that the pod reconciler watches.
+//
+// The options filter out the pods that should not be tracked, and drop the pod fields that
+// the pod reconciler does not use.
+func PodCacheByObject() cache.ByObject {
+	return cache.ByObject{
+		// Set up field selectors so that API server will not send out watch events that
+		// are not relevant to the pod watcher. This is essentially a trade-off between
+		// in-memory check overhead and encoding/transmission overhead; for large clusters
+		// with frequent pod creation/deletion ops, the trade-off seems to be worth it based
+		// on current experimentation results.
+		Field: fields.AndSelectors(
+			fields.OneTermNotEqualSelector("spec.nodeName", ""),
+			fields.OneTermNotEqualSelector("status.phase", string(corev1.PodSucceeded)),
+			fields.OneTermNotEqualSelector("status.phase", string(corev1.PodFailed)),
+		),
+		// Drop irrelevant fields from the pod object; this can significantly reduce the
+		// CPU and memory usage of the pod watcher, as less data is stored in cache.
+		Transform: func(obj interface{}) (interface{}, error) {
+			pod, ok := obj.(*corev1.Pod)
+			if !ok {
+				return nil, fmt.Errorf("failed to cast object to a pod object")
+			}
+
+			// The pod watcher only cares about a very limited set of pod fields,
+			// specifically the pod's current phase, node name, and resource requests.
+
+			// Drop unused metadata fields.
+			pod.ObjectMeta.Labels = nil
+			pod.ObjectMeta.Annotations = nil
+			pod.ObjectMeta.OwnerReferences = nil
+			pod.ObjectMeta.ManagedFields = nil
+
+			// Drop the rest of the pod status as they are irrelevant to the pod watcher.
+			pod.Status = corev1.PodStatus{
+				Phase: pod.Status.Phase,
+			}
+
+			// Drop the unwanted pod spec fields.
+			rebuiltedContainers := make([]corev1.Container, 0, len(pod.Spec.Containers))
+			for idx := range pod.Spec.Containers {
+				c := pod.Spec.Containers[idx]
+				rebuiltedContainers = append(rebuiltedContainers, corev1.Container{
+					Name:         c.Name,
+					Image:        c.Image,
+					Resources:    c.Resources,
+					ResizePolicy: c.ResizePolicy,
+				})
+			}
+			pod.Spec = corev1.PodSpec{
+				NodeName:   pod.Spec.NodeName,
+				Containers: rebuiltedContainers,
+			}
+			return pod, nil
+		},
+	}
+}
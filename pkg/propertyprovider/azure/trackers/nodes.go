@@ -47,7 +47,7 @@ const (
 	PricingDataShelfLife = time.Hour * 24
 )
 
-// supportedResourceNames is a list of resource names that the Azure property provider supports.
+// supportedResourceNames is a list of resource names that the trackers track by default.
 //
 // Currently the supported resources are CPU and memory.
 var supportedResourceNames []corev1.ResourceName = []corev1.ResourceName{
@@ -96,13 +96,27 @@ type NodeTracker struct {
 	// pricingProvider facilitates cost calculation.
 	pricingProvider PricingProvider
 
+	// resourceNames is the list of resources to track; if not set, the default list of
+	// supported resources is used.
+	resourceNames []corev1.ResourceName
+
 	// mu is a RWMutex that protects the tracker against concurrent access.
 	mu sync.RWMutex
 }
 
 // NewNodeTracker returns a node tracker.
 func NewNodeTracker(pp PricingProvider) *NodeTracker {
+	return NewNodeTrackerWithResourceNames(pp, nil)
+}
+
+// NewNodeTrackerWithResourceNames returns a node tracker which tracks the given list of resources,
+// e.g., extended resources such as GPUs in addition to CPU and memory.
+//
+// Note that the costs are always calculated based on the CPU and memory capacity; the list should
+// include both resources if cost calculation is needed.
+func NewNodeTrackerWithResourceNames(pp PricingProvider, resourceNames []corev1.ResourceName) *NodeTracker {
 	nt := &NodeTracker{
+		resourceNames:     resourceNames,
 		totalCapacity:     make(corev1.ResourceList),
 		totalAllocatable:  make(corev1.ResourceList),
 		capacityByNode:    make(map[string]corev1.ResourceList),
@@ -115,7 +129,7 @@ func NewNodeTracker(pp PricingProvider) *NodeTracker {
 		},
 	}
 
-	for _, rn := range supportedResourceNames {
+	for _, rn := range nt.trackedResourceNames() {
 		nt.totalCapacity[rn] = resource.Quantity{}
 		nt.totalAllocatable[rn] = resource.Quantity{}
 	}
@@ -123,6 +137,14 @@ func NewNodeTracker(pp PricingProvider) *NodeTracker {
 	return nt
 }
 
+// trackedResourceNames returns the list of resources that the node tracker tracks.
+func (nt *NodeTracker) trackedResourceNames() []corev1.ResourceName {
+	if nt.resourceNames != nil {
+		return nt.resourceNames
+	}
+	return supportedResourceNames
+}
+
 // calculateCosts calculates the per CPU core and per GB memory cost in the cluster. This method
 // is called every time a capacity or SKU change has been detected.
 //
@@ -293,7 +315,11 @@ func (nt *NodeTracker) calculateCosts() {
 func (nt *NodeTracker) trackSKU(node *corev1.Node) bool {
 	// It could happen that the label is absent from the node; empty string is handled as a regular
 	// SKU string by the provider and is not considered an error.
-	sku := node.Labels[AKSClusterNodeSKULabelName]
+	sku, found := node.Labels[AKSClusterNodeSKULabelName]
+	if !found {
+		// Fall back to the stable label, which might be the only one set in non-AKS clusters.
+		sku = node.Labels[corev1.LabelInstanceTypeStable]
+	}
 	registeredSKU, found := nt.skuByNode[node.Name]
 
 	switch {
@@ -346,7 +372,7 @@ func (nt *NodeTracker) trackAllocatableCapacity(node *corev1.Node) {
 		// is created; here, the provider still performs a sanity check to avoid
 		// any inconsistencies.
 		klog.V(2).InfoS("Node's allocatable capacity has been tracked", "node", klog.KObj(node))
-		for _, rn := range supportedResourceNames {
+		for _, rn := range nt.trackedResourceNames() {
 			c1 := ra[rn]
 			c2 := node.Status.Allocatable[rn]
 			if !c1.Equal(c2) {
@@ -367,7 +393,7 @@ func (nt *NodeTracker) trackAllocatableCapacity(node *corev1.Node) {
 		ra = make(corev1.ResourceList)
 
 		// The node's allocatable capacity has not been tracked.
-		for _, rn := range supportedResourceNames {
+		for _, rn := range nt.trackedResourceNames() {
 			a := node.Status.Allocatable[rn]
 			ra[rn] = a
 
@@ -395,7 +421,7 @@ func (nt *NodeTracker) trackTotalCapacity(node *corev1.Node) bool {
 		// is created; here, the provider still performs a sanity check to avoid
 		// any inconsistencies.
 		klog.V(2).InfoS("Node's total capacity has been tracked", "node", klog.KObj(node))
-		for _, rn := range supportedResourceNames {
+		for _, rn := range nt.trackedResourceNames() {
 			c1 := rc[rn]
 			c2 := node.Status.Capacity[rn]
 			if !c1.Equal(c2) {
@@ -419,7 +445,7 @@ func (nt *NodeTracker) trackTotalCapacity(node *corev1.Node) bool {
 		klog.V(4).InfoS("Node's total capacity has not been tracked yet", "node", klog.KObj(node))
 		rc = make(corev1.ResourceList)
 
-		for _, rn := range supportedResourceNames {
+		for _, rn := range nt.trackedResourceNames() {
 			c := node.Status.Capacity[rn]
 			rc[rn] = c
 
@@ -476,7 +502,7 @@ func (nt *NodeTracker) untrackSKU(nodeName string) {
 func (nt *NodeTracker) untrackTotalCapacity(nodeName string) {
 	rc, ok := nt.capacityByNode[nodeName]
 	if ok {
-		for _, rn := range supportedResourceNames {
+		for _, rn := range nt.trackedResourceNames() {
 			c := rc[rn]
 			tc := nt.totalCapacity[rn]
 			tc.Sub(c)
@@ -491,7 +517,7 @@ func (nt *NodeTracker) untrackTotalCapacity(nodeName string) {
 func (nt *NodeTracker) untrackAllocatableCapacity(nodeName string) {
 	ra, ok := nt.allocatableByNode[nodeName]
 	if ok {
-		for _, rn := range supportedResourceNames {
+		for _, rn := range nt.trackedResourceNames() {
 			a := ra[rn]
 			ta := nt.totalAllocatable[rn]
 			ta.Sub(a)
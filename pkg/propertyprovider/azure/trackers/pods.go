@@ -31,24 +31,43 @@ type PodTracker struct {
 
 	requestedByPod map[string]corev1.ResourceList
 
+	// resourceNames is the list of resources to track; if not set, the default list of
+	// supported resources is used.
+	resourceNames []corev1.ResourceName
+
 	// mu is a RWMutex that protects the tracker against concurrent access.
 	mu sync.RWMutex
 }
 
 // NewPodTracker returns a pod tracker.
 func NewPodTracker() *PodTracker {
+	return NewPodTrackerWithResourceNames(nil)
+}
+
+// NewPodTrackerWithResourceNames returns a pod tracker which tracks the requests of the given
+// list of resources.
+func NewPodTrackerWithResourceNames(resourceNames []corev1.ResourceName) *PodTracker {
 	pt := &PodTracker{
+		resourceNames:  resourceNames,
 		totalRequested: make(corev1.ResourceList),
 		requestedByPod: make(map[string]corev1.ResourceList),
 	}
 
-	for _, rn := range supportedResourceNames {
+	for _, rn := range pt.trackedResourceNames() {
 		pt.totalRequested[rn] = resource.Quantity{}
 	}
 
 	return pt
 }
 
+// trackedResourceNames returns the list of resources that the pod tracker tracks.
+func (pt *PodTracker) trackedResourceNames() []corev1.ResourceName {
+	if pt.resourceNames != nil {
+		return pt.resourceNames
+	}
+	return supportedResourceNames
+}
+
 // AddOrUpdate starts tracking a pod or updates the stats about a pod that has been
 // tracked.
 func (pt *PodTracker) AddOrUpdate(pod *corev1.Pod) {
@@ -57,7 +76,7 @@ func (pt *PodTracker) AddOrUpdate(pod *corev1.Pod) {
 
 	requestsAcrossAllContainers := make(corev1.ResourceList)
 	for _, container := range pod.Spec.Containers {
-		for _, rn := range supportedResourceNames {
+		for _, rn := range pt.trackedResourceNames() {
 			r := requestsAcrossAllContainers[rn]
 			r.Add(container.Resources.Requests[rn])
 			requestsAcrossAllContainers[rn] = r
@@ -72,7 +91,7 @@ func (pt *PodTracker) AddOrUpdate(pod *corev1.Pod) {
 		// At this moment, a pod's requested resources are immutable after the pod
 		// is created; in-place vertical scaling is not yet possible. However, the provider
 		// here still performs a sanity check to avoid any inconsistencies.
-		for _, rn := range supportedResourceNames {
+		for _, rn := range pt.trackedResourceNames() {
 			r1 := rp[rn]
 			r2 := requestsAcrossAllContainers[rn]
 			if !r1.Equal(r2) {
@@ -92,7 +111,7 @@ func (pt *PodTracker) AddOrUpdate(pod *corev1.Pod) {
 		rp = make(corev1.ResourceList)
 
 		// The pod's requested resources have not been tracked.
-		for _, rn := range supportedResourceNames {
+		for _, rn := range pt.trackedResourceNames() {
 			r := requestsAcrossAllContainers[rn]
 			rp[rn] = r
 
@@ -113,7 +132,7 @@ func (pt *PodTracker) Remove(podIdentifier string) {
 	rp, ok := pt.requestedByPod[podIdentifier]
 	if ok {
 		// Untrack the pod's requested resources.
-		for _, rn := range supportedResourceNames {
+		for _, rn := range pt.trackedResourceNames() {
 			r := rp[rn]
 			tr := pt.totalRequested[rn]
 			tr.Sub(r)
@@ -0,0 +1,68 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package external features a property provider for Fleet which delegates the property collection
+// to an out-of-process property provider, e.g., a sidecar container of the Fleet member agent.
+//
+// The two talk over HTTP on a Unix domain socket, with the following protocol:
+//
+//   - The Fleet member agent sends a GET request to the CollectPath each time it collects the
+//     cluster properties, and cancels the request if the out-of-process property provider does
+//     not respond in time.
+//   - The out-of-process property provider responds with the status code 200 and a JSON-encoded
+//     CollectResponse if the collection succeeds; or with any other status code, optionally with a
+//     JSON-encoded ErrorResponse, if it fails.
+//
+// Out-of-process property providers written in Go can implement the propertyprovider.PropertyProvider
+// interface and serve it with NewHandler and Serve.
+package external
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+)
+
+const (
+	// CollectPath is the HTTP path at which the out-of-process property provider serves the property
+	// collection requests.
+	CollectPath = "/v1/collect"
+)
+
+// CollectResponse is the body of the response to a successful property collection request.
+type CollectResponse struct {
+	// Properties is an array of non-resource properties and their values. The key should be the
+	// name of the property, which is a Kubernetes label name; the value is the property data.
+	//
+	// The observation time of a property is set to the time of the request if omitted.
+	// +optional
+	Properties map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue `json:"properties,omitempty"`
+
+	// Resources is a group of resources, described by their allocatable capacity and
+	// available capacity.
+	// +optional
+	Resources clusterv1beta1.ResourceUsage `json:"resources,omitempty"`
+
+	// Conditions is an array of conditions that explains the property collection status.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ErrorResponse is the body of the response to a failed property collection request.
+type ErrorResponse struct {
+	// Message explains why the property collection has failed.
+	Message string `json:"message"`
+}
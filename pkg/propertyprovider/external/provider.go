@@ -0,0 +1,168 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+
+	"go.goms.io/fleet/pkg/propertyprovider"
+	"go.goms.io/fleet/pkg/utils/validator"
+)
+
+const (
+	// collectURL is the URL of the property collection requests; the host part is not used, as
+	// the requests are always sent over the Unix domain socket.
+	collectURL = "http://property-provider" + CollectPath
+
+	// maxResponseBytes is the maximum size of a response from the out-of-process property provider.
+	maxResponseBytes = 4 << 20
+	// maxErrorMessageBytes is the maximum size of an error message from the out-of-process property
+	// provider that is reported in the conditions.
+	maxErrorMessageBytes = 1024
+)
+
+// PropertyProvider is the Fleet property provider which collects the properties from an
+// out-of-process property provider.
+type PropertyProvider struct {
+	socketPath string
+	timeout    time.Duration
+	httpClient *http.Client
+}
+
+// Verify that the external property provider implements the PropertyProvider interface.
+var _ propertyprovider.PropertyProvider = &PropertyProvider{}
+
+// Start starts the external property provider.
+//
+// Note that it does not check if the out-of-process property provider is up and running, as
+// sidecar containers might start after the Fleet member agent; failures to reach the out-of-process
+// property provider are reported at each property collection instead.
+func (p *PropertyProvider) Start(_ context.Context, _ *rest.Config) error {
+	klog.V(2).InfoS("Starting external property provider", "socketPath", p.socketPath, "timeout", p.timeout)
+	dialer := &net.Dialer{}
+	p.httpClient = &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return dialer.DialContext(ctx, "unix", p.socketPath)
+			},
+			DisableKeepAlives: true,
+		},
+	}
+	return nil
+}
+
+// Collect collects the properties from the out-of-process property provider.
+func (p *PropertyProvider) Collect(ctx context.Context) propertyprovider.PropertyCollectionResponse {
+	startTime := time.Now()
+	res, err := p.collect(ctx)
+	if err != nil {
+		klog.ErrorS(err, "Failed to collect properties from the external property provider", "socketPath", p.socketPath)
+		return propertyprovider.PropertyCollectionResponse{Err: err}
+	}
+	klog.V(2).InfoS("Collected properties from the external property provider", "socketPath", p.socketPath, "latency", time.Since(startTime))
+	return res
+}
+
+// collect sends a property collection request to the out-of-process property provider.
+func (p *PropertyProvider) collect(ctx context.Context) (propertyprovider.PropertyCollectionResponse, error) {
+	childCtx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(childCtx, http.MethodGet, collectURL, nil)
+	if err != nil {
+		return propertyprovider.PropertyCollectionResponse{}, fmt.Errorf("failed to build the request: %w", err)
+	}
+	now := metav1.Now()
+	httpRes, err := p.httpClient.Do(req)
+	if err != nil {
+		return propertyprovider.PropertyCollectionResponse{}, fmt.Errorf("failed to call the property provider at %s: %w", p.socketPath, err)
+	}
+	defer httpRes.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(httpRes.Body, maxResponseBytes+1))
+	if err != nil {
+		return propertyprovider.PropertyCollectionResponse{}, fmt.Errorf("failed to read the response: %w", err)
+	}
+	if len(body) > maxResponseBytes {
+		return propertyprovider.PropertyCollectionResponse{}, fmt.Errorf("the response exceeds the limit of %d bytes", maxResponseBytes)
+	}
+
+	if httpRes.StatusCode != http.StatusOK {
+		return propertyprovider.PropertyCollectionResponse{}, fmt.Errorf("the property provider returned status code %d: %s", httpRes.StatusCode, errorMessage(body))
+	}
+
+	var collectRes CollectResponse
+	if err := json.Unmarshal(body, &collectRes); err != nil {
+		return propertyprovider.PropertyCollectionResponse{}, fmt.Errorf("failed to decode the response: %w", err)
+	}
+	for name, value := range collectRes.Properties {
+		if err := validator.ValidatePropertyName(string(name)); err != nil {
+			return propertyprovider.PropertyCollectionResponse{}, fmt.Errorf("invalid property %q: %w", name, err)
+		}
+		if value.ObservationTime.IsZero() {
+			value.ObservationTime = now
+			collectRes.Properties[name] = value
+		}
+	}
+	if collectRes.Resources.ObservationTime.IsZero() {
+		collectRes.Resources.ObservationTime = now
+	}
+	return propertyprovider.PropertyCollectionResponse{
+		Properties: collectRes.Properties,
+		Resources:  collectRes.Resources,
+		Conditions: collectRes.Conditions,
+	}, nil
+}
+
+// errorMessage extracts the error message from the body of a failed response.
+func errorMessage(body []byte) string {
+	msg := strings.TrimSpace(string(body))
+	var errRes ErrorResponse
+	if err := json.Unmarshal(body, &errRes); err == nil && len(errRes.Message) > 0 {
+		msg = errRes.Message
+	}
+	if len(msg) > maxErrorMessageBytes {
+		msg = msg[:maxErrorMessageBytes] + "..."
+	}
+	if len(msg) == 0 {
+		msg = "no error message"
+	}
+	return msg
+}
+
+// New returns a new external property provider, which talks with the out-of-process property
+// provider at the given Unix domain socket, and cancels the property collection requests if the
+// out-of-process property provider does not respond within the timeout.
+//
+// Note that the Fleet member agent has its own deadline for property collection; the timeout
+// should be shorter than that for the failures to be reported in time.
+func New(socketPath string, timeout time.Duration) propertyprovider.PropertyProvider {
+	return &PropertyProvider{
+		socketPath: socketPath,
+		timeout:    timeout,
+	}
+}
@@ -0,0 +1,242 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	"go.goms.io/fleet/pkg/propertyprovider"
+)
+
+const (
+	sloPropertyName = "example.com/slo-availability"
+)
+
+var (
+	observationTime = metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+)
+
+// fakePropertyProvider is a property provider which returns a fixed response.
+type fakePropertyProvider struct {
+	res propertyprovider.PropertyCollectionResponse
+}
+
+func (f *fakePropertyProvider) Collect(_ context.Context) propertyprovider.PropertyCollectionResponse {
+	return f.res
+}
+
+func (f *fakePropertyProvider) Start(_ context.Context, _ *rest.Config) error {
+	return nil
+}
+
+// TestCollect tests the Collect method of the external property provider.
+func TestCollect(t *testing.T) {
+	testCases := []struct {
+		name     string
+		handler  http.Handler
+		noServer bool
+		want     propertyprovider.PropertyCollectionResponse
+		wantErr  bool
+	}{
+		{
+			name: "properties collected",
+			handler: NewHandler(&fakePropertyProvider{
+				res: propertyprovider.PropertyCollectionResponse{
+					Properties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+						sloPropertyName: {
+							Value:           "99.95",
+							ObservationTime: observationTime,
+						},
+						propertyprovider.NodeCountProperty: {
+							Value: "3",
+						},
+					},
+					Resources: clusterv1beta1.ResourceUsage{
+						Capacity: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("12"),
+						},
+						Allocatable: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("10"),
+						},
+					},
+					Conditions: []metav1.Condition{
+						{
+							Type:   "SLODataCollectionSucceeded",
+							Status: metav1.ConditionTrue,
+							Reason: "Collected",
+						},
+					},
+				},
+			}),
+			want: propertyprovider.PropertyCollectionResponse{
+				Properties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+					sloPropertyName: {
+						Value:           "99.95",
+						ObservationTime: observationTime,
+					},
+					propertyprovider.NodeCountProperty: {
+						Value: "3",
+					},
+				},
+				Resources: clusterv1beta1.ResourceUsage{
+					Capacity: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("12"),
+					},
+					Allocatable: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("10"),
+					},
+				},
+				Conditions: []metav1.Condition{
+					{
+						Type:   "SLODataCollectionSucceeded",
+						Status: metav1.ConditionTrue,
+						Reason: "Collected",
+					},
+				},
+			},
+		},
+		{
+			name: "property provider failed",
+			handler: NewHandler(&fakePropertyProvider{
+				res: propertyprovider.PropertyCollectionResponse{
+					Err: errors.New("SLO data is not available"),
+				},
+			}),
+			wantErr: true,
+		},
+		{
+			name: "invalid property name",
+			handler: NewHandler(&fakePropertyProvider{
+				res: propertyprovider.PropertyCollectionResponse{
+					Properties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+						"example.com/-slo": {
+							Value: "99.95",
+						},
+					},
+				},
+			}),
+			wantErr: true,
+		},
+		{
+			name: "unexpected status code",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				http.Error(w, "not ready", http.StatusServiceUnavailable)
+			}),
+			wantErr: true,
+		},
+		{
+			name: "malformed response",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte("{"))
+			}),
+			wantErr: true,
+		},
+		{
+			name: "timed out",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				select {
+				case <-req.Context().Done():
+				case <-time.After(time.Second * 5):
+				}
+			}),
+			wantErr: true,
+		},
+		{
+			name:     "property provider not running",
+			noServer: true,
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			socketPath := filepath.Join(t.TempDir(), "pp.sock")
+			serverDoneCh := make(chan error, 1)
+			if !tc.noServer {
+				go func() {
+					serverDoneCh <- Serve(ctx, socketPath, tc.handler)
+				}()
+				defer func() {
+					cancel()
+					if err := <-serverDoneCh; err != nil {
+						t.Errorf("Serve() = %v, want no error", err)
+					}
+				}()
+			}
+
+			p := New(socketPath, time.Millisecond*500)
+			if err := p.Start(ctx, nil); err != nil {
+				t.Fatalf("Start() = %v, want no error", err)
+			}
+
+			if !tc.noServer {
+				// Wait until the server is up.
+				for i := 0; i < 20; i++ {
+					if _, err := os.Stat(socketPath); err == nil {
+						break
+					}
+					time.Sleep(time.Millisecond * 50)
+				}
+			}
+			res := p.Collect(ctx)
+			if gotErr := res.Err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Collect() error = %v, want error %t", res.Err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+
+			if diff := cmp.Diff(res, tc.want,
+				cmpopts.IgnoreFields(clusterv1beta1.PropertyValue{}, "ObservationTime"),
+				cmpopts.IgnoreFields(clusterv1beta1.ResourceUsage{}, "ObservationTime"),
+				cmpopts.EquateEmpty(),
+			); diff != "" {
+				t.Errorf("Collect() response diff (-got, +want):\n%s", diff)
+			}
+			// Verify that the observation times have been kept if reported, or set otherwise.
+			for name, value := range res.Properties {
+				wantValue := tc.want.Properties[name]
+				if !wantValue.ObservationTime.IsZero() && !value.ObservationTime.Equal(&wantValue.ObservationTime) {
+					t.Errorf("observation time of property %s = %v, want %v", name, value.ObservationTime, wantValue.ObservationTime)
+				}
+				if value.ObservationTime.IsZero() {
+					t.Errorf("observation time of property %s is not set", name)
+				}
+			}
+			if res.Resources.ObservationTime.IsZero() {
+				t.Errorf("observation time of resources is not set")
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"go.goms.io/fleet/pkg/propertyprovider"
+)
+
+const (
+	// serverShutdownTimeout is the time the server waits for the on-going requests to complete
+	// when it shuts down.
+	serverShutdownTimeout = time.Second * 5
+)
+
+// NewHandler returns an HTTP handler which serves the property collection requests from the Fleet
+// member agent with the given property provider.
+//
+// The property provider should have been started before the handler serves any request.
+func NewHandler(pp propertyprovider.PropertyProvider) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(CollectPath, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			writeJSON(w, http.StatusMethodNotAllowed, ErrorResponse{Message: fmt.Sprintf("method %s is not allowed", req.Method)})
+			return
+		}
+		res := pp.Collect(req.Context())
+		if res.Err != nil {
+			klog.ErrorS(res.Err, "Failed to collect properties")
+			writeJSON(w, http.StatusInternalServerError, ErrorResponse{Message: res.Err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, CollectResponse{
+			Properties: res.Properties,
+			Resources:  res.Resources,
+			Conditions: res.Conditions,
+		})
+	})
+	return mux
+}
+
+// writeJSON writes a JSON-encoded response.
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		klog.ErrorS(err, "Failed to write the response")
+	}
+}
+
+// Serve serves the HTTP handler at the given Unix domain socket until the context is cancelled.
+//
+// A stale socket file left at the path, e.g., by a previous run, is removed before serving.
+func Serve(ctx context.Context, socketPath string, handler http.Handler) error {
+	if err := os.Remove(socketPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to remove the stale socket %s: %w", socketPath, err)
+	}
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return fmt.Errorf("failed to listen at socket %s: %w", socketPath, err)
+	}
+
+	server := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: time.Second * 5,
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Serve(listener)
+	}()
+	klog.V(2).InfoS("Serving property collection requests", "socketPath", socketPath)
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("failed to serve at socket %s: %w", socketPath, err)
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), serverShutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			return fmt.Errorf("failed to shut down the server at socket %s: %w", socketPath, err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generic
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"go.goms.io/fleet/pkg/propertyprovider/azure/trackers"
+)
+
+// StaticPricingProvider is a pricing provider which serves a static pricing table, as
+// supplied by the operator in a ConfigMap.
+//
+// Each entry in the data of the ConfigMap maps an instance type, as specified by the
+// node.kubernetes.io/instance-type label on nodes, to the hourly cost of a node of the
+// instance type; use the key `undefined` to specify the hourly cost of nodes without
+// the label.
+type StaticPricingProvider struct {
+	mu     sync.RWMutex
+	prices map[string]float64
+}
+
+var _ trackers.PricingProvider = &StaticPricingProvider{}
+
+// NewStaticPricingProvider returns a static pricing provider with no pricing data.
+func NewStaticPricingProvider() *StaticPricingProvider {
+	return &StaticPricingProvider{
+		prices: make(map[string]float64),
+	}
+}
+
+// OnDemandPrice returns the hourly cost of an instance type.
+func (s *StaticPricingProvider) OnDemandPrice(instanceType string) (float64, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(instanceType) == 0 {
+		instanceType = trackers.ReservedNameForUndefinedSKU
+	}
+	price, found := s.prices[instanceType]
+	return price, found
+}
+
+// LastUpdated returns the last time the pricing data was updated.
+//
+// The static pricing data never goes stale; the current time is always returned so that
+// the costs are re-calculated with the latest pricing data upon each collection, which is
+// cheap as the calculation runs on a per-instance-type basis.
+func (s *StaticPricingProvider) LastUpdated() time.Time {
+	return time.Now()
+}
+
+// Set replaces the pricing data with the data of a pricing ConfigMap. Invalid entries,
+// i.e., ones whose values are not non-negative numbers, are skipped; their keys are returned.
+func (s *StaticPricingProvider) Set(data map[string]string) []string {
+	prices, invalidKeys := parsePricingData(data)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prices = prices
+	return invalidKeys
+}
+
+// parsePricingData parses the data of a pricing ConfigMap.
+func parsePricingData(data map[string]string) (prices map[string]float64, invalidKeys []string) {
+	prices = make(map[string]float64, len(data))
+	for instanceType, v := range data {
+		price, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil || price < 0 {
+			invalidKeys = append(invalidKeys, instanceType)
+			continue
+		}
+		prices[instanceType] = price
+	}
+	// Sort the invalid keys for stability reasons.
+	slices.Sort(invalidKeys)
+	return prices, invalidKeys
+}
+
+// pricingConfigMapReconciler reconciles the pricing ConfigMap.
+type pricingConfigMapReconciler struct {
+	pricingProvider *StaticPricingProvider
+	client          client.Client
+}
+
+// Reconcile reconciles the pricing ConfigMap.
+func (r *pricingConfigMapReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	cmRef := klog.KRef(req.Namespace, req.Name)
+	klog.V(2).InfoS("Reconciliation starts for the pricing ConfigMap in the generic property provider", "configMap", cmRef)
+	defer klog.V(2).InfoS("Reconciliation ends for the pricing ConfigMap in the generic property provider", "configMap", cmRef)
+
+	cm := &corev1.ConfigMap{}
+	if err := r.client.Get(ctx, req.NamespacedName, cm); err != nil {
+		if errors.IsNotFound(err) {
+			// The pricing ConfigMap is gone; drop all the pricing data, which will fail the
+			// cost calculation until the ConfigMap is re-created.
+			klog.V(2).InfoS("Pricing ConfigMap is not found; drop all pricing data", "configMap", cmRef)
+			r.pricingProvider.Set(nil)
+			return ctrl.Result{}, nil
+		}
+		klog.ErrorS(err, "Failed to get the pricing ConfigMap", "configMap", cmRef)
+		return ctrl.Result{}, err
+	}
+
+	if invalidKeys := r.pricingProvider.Set(cm.Data); len(invalidKeys) > 0 {
+		// Invalid entries are user errors; retrying will not help.
+		err := fmt.Errorf("the prices of instance types %v are not non-negative numbers", invalidKeys)
+		klog.ErrorS(err, "Skipped invalid entries in the pricing ConfigMap", "configMap", cmRef)
+	}
+	klog.V(2).InfoS("Loaded the pricing data", "configMap", cmRef, "instanceTypeCount", len(cm.Data))
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the pricing ConfigMap reconciler with the manager.
+//
+// Note that the cache of the manager is expected to watch the pricing ConfigMap only.
+func (r *pricingConfigMapReconciler) SetupWithManager(mgr ctrl.Manager, controllerName string) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(controllerName).
+		For(&corev1.ConfigMap{}).
+		Complete(r)
+}
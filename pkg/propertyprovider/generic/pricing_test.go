@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generic
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// TestStaticPricingProvider tests the static pricing provider.
+func TestStaticPricingProvider(t *testing.T) {
+	testCases := []struct {
+		name            string
+		data            map[string]string
+		instanceType    string
+		wantPrice       float64
+		wantFound       bool
+		wantInvalidKeys []string
+	}{
+		{
+			name: "known instance type",
+			data: map[string]string{
+				"m5.large":  "0.096",
+				"m5.xlarge": " 0.192\n",
+			},
+			instanceType: "m5.xlarge",
+			wantPrice:    0.192,
+			wantFound:    true,
+		},
+		{
+			name: "unknown instance type",
+			data: map[string]string{
+				"m5.large": "0.096",
+			},
+			instanceType: "m5.xlarge",
+		},
+		{
+			name: "nodes without the instance type label",
+			data: map[string]string{
+				"undefined": "1.5",
+			},
+			instanceType: "",
+			wantPrice:    1.5,
+			wantFound:    true,
+		},
+		{
+			name: "invalid entries",
+			data: map[string]string{
+				"m5.large":  "0.096",
+				"m5.xlarge": "cheap",
+				"bm.gpu":    "-1",
+			},
+			instanceType:    "m5.xlarge",
+			wantInvalidKeys: []string{"bm.gpu", "m5.xlarge"},
+		},
+		{
+			name:         "no data",
+			instanceType: "m5.large",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pp := NewStaticPricingProvider()
+			invalidKeys := pp.Set(tc.data)
+			if diff := cmp.Diff(invalidKeys, tc.wantInvalidKeys); diff != "" {
+				t.Errorf("Set() invalid keys diff (-got, +want):\n%s", diff)
+			}
+			price, found := pp.OnDemandPrice(tc.instanceType)
+			if price != tc.wantPrice || found != tc.wantFound {
+				t.Errorf("OnDemandPrice(%q) = (%v, %t), want (%v, %t)", tc.instanceType, price, found, tc.wantPrice, tc.wantFound)
+			}
+		})
+	}
+}
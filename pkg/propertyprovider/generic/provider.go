@@ -0,0 +1,529 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package generic features a cloud-neutral property provider for Fleet, which works with any
+// Kubernetes cluster, e.g., an on-premises or a bare-metal one.
+package generic
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	"go.goms.io/fleet/pkg/propertyprovider"
+	"go.goms.io/fleet/pkg/propertyprovider/azure/controllers"
+	"go.goms.io/fleet/pkg/propertyprovider/azure/trackers"
+)
+
+const (
+	// A list of properties that the generic property provider collects in addition to the
+	// Fleet required ones.
+
+	// PerCPUCoreCostProperty is a property that describes the average hourly cost of a CPU core in
+	// a Kubernetes cluster, as calculated with the static pricing data.
+	PerCPUCoreCostProperty = "kubernetes-fleet.io/per-cpu-core-cost"
+	// PerGBMemoryCostProperty is a property that describes the average hourly cost of one GB of memory
+	// in a Kubernetes cluster, as calculated with the static pricing data.
+	PerGBMemoryCostProperty = "kubernetes-fleet.io/per-gb-memory-cost"
+
+	// NodeCountPerInstanceTypePropertyTmpl is the property template for the node count per instance
+	// type in a Kubernetes cluster.
+	NodeCountPerInstanceTypePropertyTmpl = "kubernetes-fleet.io/instance-types/%s/node-count"
+
+	// ZoneCountProperty is a property that describes the number of zones in which a Kubernetes
+	// cluster has nodes.
+	ZoneCountProperty = "kubernetes-fleet.io/zone-count"
+	// NodeCountPerZonePropertyTmpl is the property template for the node count per zone in
+	// a Kubernetes cluster.
+	NodeCountPerZonePropertyTmpl = "kubernetes-fleet.io/zones/%s/node-count"
+
+	// The GPU properties, which sum up the quantities of all the GPU resources that the generic
+	// property provider tracks.
+	//
+	// The GPU resources are also reported individually in the resource usage of a cluster;
+	// however, as the names of the GPU resources feature a prefix (e.g., nvidia.com/gpu), they
+	// cannot be used in resource property selectors.
+	TotalGPUCapacityProperty       = "kubernetes-fleet.io/total-gpu"
+	AllocatableGPUCapacityProperty = "kubernetes-fleet.io/allocatable-gpu"
+	AvailableGPUCapacityProperty   = "kubernetes-fleet.io/available-gpu"
+
+	CostPrecisionTemplate = "%.3f"
+)
+
+const (
+	// The condition related values in use by the generic property provider.
+	CostPropertiesCollectionSucceededCondType   = "ClusterCostPropertiesCollectionSucceeded"
+	CostPropertiesCollectionSucceededReason     = "CostsCalculated"
+	CostPropertiesCollectionDegradedReason      = "CostsCalculationDegraded"
+	CostPropertiesCollectionFailedReason        = "CostsCalculationFailed"
+	CostPropertiesCollectionSucceededMsg        = "All cost properties have been collected successfully"
+	CostPropertiesCollectionDegradedMsgTemplate = "Cost properties are collected in a degraded mode with the following warning(s): %v"
+	CostPropertiesCollectionFailedMsgTemplate   = "An error has occurred when collecting cost properties: %v"
+)
+
+var (
+	// k8sVersionCacheTTL is the TTL for the cached Kubernetes version.
+	k8sVersionCacheTTL = 15 * time.Minute
+
+	// DefaultGPUResourceNames is the list of GPU extended resources that the generic property
+	// provider tracks by default, as registered by the device plugins of the major GPU vendors.
+	DefaultGPUResourceNames = []corev1.ResourceName{
+		"nvidia.com/gpu",
+		"amd.com/gpu",
+		"gpu.intel.com/i915",
+	}
+)
+
+// PropertyProvider is the generic property provider for Fleet.
+type PropertyProvider struct {
+	// The trackers.
+	podTracker  *trackers.PodTracker
+	nodeTracker *trackers.NodeTracker
+	zoneTracker *zoneTracker
+
+	// The GPU extended resources to track.
+	gpuResourceNames []corev1.ResourceName
+
+	// The pricing ConfigMap from which the static pricing data is read; cost collection is
+	// disabled if it is not set.
+	pricingConfigMap *types.NamespacedName
+	pricingProvider  *StaticPricingProvider
+
+	// The discovery client to get k8s cluster version.
+	discoveryClient discovery.ServerVersionInterface
+
+	// The feature flags.
+	isAvailableResourcesCollectionEnabled bool
+
+	// The controller manager in use by the generic property provider; this field is mostly reserved
+	// for testing purposes.
+	mgr ctrl.Manager
+	// The names in use by the controllers managed by the property provider; these fields are exposed
+	// to avoid name conflicts, though at this moment are mostly reserved for testing purposes.
+	nodeControllerName      string
+	zoneControllerName      string
+	podControllerName       string
+	configMapControllerName string
+
+	// Cache for Kubernetes version information with TTL.
+	k8sVersionMutex              sync.Mutex
+	cachedK8sVersion             string
+	cachedK8sVersionObservedTime time.Time
+
+	// Cached cluster certificate authority data (base64 encoded).
+	clusterCertificateAuthority             []byte
+	clusterCertificateAuthorityObservedTime time.Time
+}
+
+// Verify that the generic property provider implements the PropertyProvider interface at compile time.
+var _ propertyprovider.PropertyProvider = &PropertyProvider{}
+
+// Start starts the generic property provider.
+func (p *PropertyProvider) Start(ctx context.Context, config *rest.Config) error {
+	klog.V(2).Info("Starting generic property provider")
+
+	byObject := map[client.Object]cache.ByObject{
+		&corev1.Pod{}: controllers.PodCacheByObject(),
+	}
+	if p.pricingConfigMap != nil {
+		// Watch the pricing ConfigMap only.
+		byObject[&corev1.ConfigMap{}] = cache.ByObject{
+			Namespaces: map[string]cache.Config{
+				p.pricingConfigMap.Namespace: {},
+			},
+			Field: fields.OneTermEqualSelector("metadata.name", p.pricingConfigMap.Name),
+		}
+	}
+	mgr, err := ctrl.NewManager(config, ctrl.Options{
+		Scheme: scheme.Scheme,
+		Cache: cache.Options{
+			ByObject: byObject,
+		},
+		// Disable metric serving for the generic property provider controller manager.
+		//
+		// Note that this will not stop the metrics from being collected and exported; as they
+		// are registered via a top-level variable as a part of the controller runtime package,
+		// which is also used by the Fleet member agent.
+		Metrics: metricsserver.Options{
+			BindAddress: "0",
+		},
+		// Disable health probe serving for the generic property provider controller manager.
+		HealthProbeBindAddress: "0",
+		// Disable leader election for the generic property provider; as with the Azure property
+		// provider, it is started only when an instance of the Fleet member agent wins the leader
+		// election.
+		LeaderElection: false,
+	})
+	if err != nil {
+		klog.ErrorS(err, "Failed to start generic property provider")
+		return err
+	}
+	p.mgr = mgr
+
+	resourceNames := []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory}
+	resourceNames = append(resourceNames, p.gpuResourceNames...)
+
+	if p.pricingConfigMap != nil {
+		klog.V(2).InfoS("Setting up the pricing ConfigMap reconciler", "configMap", *p.pricingConfigMap)
+		p.pricingProvider = NewStaticPricingProvider()
+		configMapReconciler := &pricingConfigMapReconciler{
+			pricingProvider: p.pricingProvider,
+			client:          mgr.GetClient(),
+		}
+		if err := configMapReconciler.SetupWithManager(mgr, p.configMapControllerName); err != nil {
+			klog.ErrorS(err, "Failed to start the pricing ConfigMap reconciler in the generic property provider")
+			return err
+		}
+		p.nodeTracker = trackers.NewNodeTrackerWithResourceNames(p.pricingProvider, resourceNames)
+	} else {
+		klog.V(2).Info("Building a node tracker with no pricing provider")
+		p.nodeTracker = trackers.NewNodeTrackerWithResourceNames(nil, resourceNames)
+	}
+	p.zoneTracker = newZoneTracker()
+
+	p.discoveryClient = discovery.NewDiscoveryClientForConfigOrDie(config)
+	// Fetch the k8s version from the discovery client.
+	klog.V(2).Info("Fetching Kubernetes version from discovery client")
+	serverVersion, err := p.discoveryClient.ServerVersion()
+	if err != nil {
+		klog.ErrorS(err, "Failed to get Kubernetes server version from discovery client")
+		return err
+	}
+	// Update the cache with the new version.
+	p.cachedK8sVersion = serverVersion.GitVersion
+	p.cachedK8sVersionObservedTime = time.Now()
+
+	// Cache the cluster certificate authority data (base64 encoded).
+	if len(config.CAFile) > 0 {
+		cadata, err := os.ReadFile(config.CAFile)
+		if err != nil {
+			klog.ErrorS(err, "Failed to read cluster certificate authority data from file")
+			return err
+		}
+		p.clusterCertificateAuthority = cadata
+		p.clusterCertificateAuthorityObservedTime = time.Now()
+		klog.V(2).Info("Cached cluster certificate authority data from file")
+	} else if len(config.CAData) > 0 {
+		p.clusterCertificateAuthority = config.CAData
+		p.clusterCertificateAuthorityObservedTime = time.Now()
+		klog.V(2).Info("Cached cluster certificate authority data")
+	} else {
+		err := fmt.Errorf("rest.Config has empty CAFile and CAData")
+		klog.ErrorS(err, "No certificate authority data available in rest.Config")
+	}
+
+	// Set up the node reconcilers.
+	klog.V(2).Info("Setting up the node reconcilers")
+	nodeReconciler := &controllers.NodeReconciler{
+		NT:     p.nodeTracker,
+		Client: mgr.GetClient(),
+	}
+	if err := nodeReconciler.SetupWithManager(mgr, p.nodeControllerName); err != nil {
+		klog.ErrorS(err, "Failed to start the node reconciler in the generic property provider")
+		return err
+	}
+	zoneReconciler := &zoneReconciler{
+		zt:     p.zoneTracker,
+		client: mgr.GetClient(),
+	}
+	if err := zoneReconciler.SetupWithManager(mgr, p.zoneControllerName); err != nil {
+		klog.ErrorS(err, "Failed to start the zone reconciler in the generic property provider")
+		return err
+	}
+
+	if p.isAvailableResourcesCollectionEnabled {
+		klog.V(2).Info("Building a pod tracker")
+		p.podTracker = trackers.NewPodTrackerWithResourceNames(resourceNames)
+
+		klog.V(2).Info("Starting the pod reconciler")
+		podReconciler := &controllers.PodReconciler{
+			PT:     p.podTracker,
+			Client: mgr.GetClient(),
+		}
+		if err := podReconciler.SetupWithManager(mgr, p.podControllerName); err != nil {
+			klog.ErrorS(err, "Failed to start the pod reconciler in the generic property provider")
+			return err
+		}
+	}
+
+	// Start the controller manager.
+	//
+	// Note that the controller manager will run in a separate goroutine to avoid blocking
+	// the member agent.
+	go func() {
+		// This call will block until the context exits.
+		if err := mgr.Start(ctx); err != nil {
+			klog.ErrorS(err, "Failed to start the generic property provider controller manager")
+		}
+	}()
+
+	// Wait for the cache to sync; see the Azure property provider for the caveats.
+	mgr.GetCache().WaitForCacheSync(ctx)
+
+	return nil
+}
+
+// Collect collects the properties of a Kubernetes cluster.
+func (p *PropertyProvider) Collect(ctx context.Context) propertyprovider.PropertyCollectionResponse {
+	conds := make([]metav1.Condition, 0, 1)
+
+	// Collect the non-resource properties.
+	properties := make(map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue)
+
+	// Collect node-count related properties.
+	p.collectNodeCountRelatedProperties(ctx, properties)
+
+	// Collect the Kubernetes version.
+	p.collectK8sVersion(ctx, properties)
+
+	// Collect the cost properties (if enabled).
+	if p.pricingConfigMap != nil {
+		costConds := p.collectCosts(ctx, properties)
+		conds = append(conds, costConds...)
+	}
+
+	// Collect the resource properties.
+
+	// Collect the total and allocatable resource properties.
+	resources := clusterv1beta1.ResourceUsage{}
+	resources.Capacity = p.nodeTracker.TotalCapacity()
+	resources.Allocatable = p.nodeTracker.TotalAllocatable()
+
+	// Collect the available resource properties (if enabled).
+	if p.isAvailableResourcesCollectionEnabled {
+		p.collectAvailableResource(ctx, &resources)
+	}
+
+	// Collect the GPU properties.
+	p.collectGPUProperties(ctx, &resources, properties)
+
+	// insert the cluster certificate authority property (if available)
+	if len(p.clusterCertificateAuthority) > 0 {
+		properties[propertyprovider.ClusterCertificateAuthorityProperty] = clusterv1beta1.PropertyValue{
+			Value:           string(p.clusterCertificateAuthority),
+			ObservationTime: metav1.NewTime(p.clusterCertificateAuthorityObservedTime),
+		}
+	}
+
+	// Return the collection response.
+	return propertyprovider.PropertyCollectionResponse{
+		Properties: properties,
+		Resources:  resources,
+		Conditions: conds,
+	}
+}
+
+// collectNodeCountRelatedProperties collects the node-count related properties.
+func (p *PropertyProvider) collectNodeCountRelatedProperties(_ context.Context, properties map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue) {
+	now := metav1.Now()
+
+	// Collect the total node count as a property.
+	properties[propertyprovider.NodeCountProperty] = clusterv1beta1.PropertyValue{
+		Value:           fmt.Sprintf("%d", p.nodeTracker.NodeCount()),
+		ObservationTime: now,
+	}
+
+	// Collect the per-instance-type node counts as properties.
+	for instanceType, count := range p.nodeTracker.NodeCountPerSKU() {
+		pName := fmt.Sprintf(NodeCountPerInstanceTypePropertyTmpl, instanceType)
+		properties[clusterv1beta1.PropertyName(pName)] = clusterv1beta1.PropertyValue{
+			Value:           fmt.Sprintf("%d", count),
+			ObservationTime: now,
+		}
+	}
+
+	// Collect the zone count and the per-zone node counts as properties.
+	nodeCountPerZone := p.zoneTracker.NodeCountPerZone()
+	properties[ZoneCountProperty] = clusterv1beta1.PropertyValue{
+		Value:           fmt.Sprintf("%d", len(nodeCountPerZone)),
+		ObservationTime: now,
+	}
+	for zone, count := range nodeCountPerZone {
+		pName := fmt.Sprintf(NodeCountPerZonePropertyTmpl, zone)
+		properties[clusterv1beta1.PropertyName(pName)] = clusterv1beta1.PropertyValue{
+			Value:           fmt.Sprintf("%d", count),
+			ObservationTime: now,
+		}
+	}
+}
+
+// collectCosts collects the cost information.
+func (p *PropertyProvider) collectCosts(_ context.Context, properties map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue) []metav1.Condition {
+	perCPUCost, perGBMemoryCost, warnings, err := p.nodeTracker.Costs()
+	if err != nil {
+		// An error occurred when calculating costs; do no set the cost properties and
+		// track the error.
+		return []metav1.Condition{
+			{
+				Type:    CostPropertiesCollectionSucceededCondType,
+				Status:  metav1.ConditionFalse,
+				Reason:  CostPropertiesCollectionFailedReason,
+				Message: fmt.Sprintf(CostPropertiesCollectionFailedMsgTemplate, err),
+			},
+		}
+	}
+
+	properties[PerCPUCoreCostProperty] = clusterv1beta1.PropertyValue{
+		Value:           fmt.Sprintf(CostPrecisionTemplate, perCPUCost),
+		ObservationTime: metav1.Now(),
+	}
+	properties[PerGBMemoryCostProperty] = clusterv1beta1.PropertyValue{
+		Value:           fmt.Sprintf(CostPrecisionTemplate, perGBMemoryCost),
+		ObservationTime: metav1.Now(),
+	}
+	if len(warnings) > 0 {
+		return []metav1.Condition{
+			{
+				Type:    CostPropertiesCollectionSucceededCondType,
+				Status:  metav1.ConditionTrue,
+				Reason:  CostPropertiesCollectionDegradedReason,
+				Message: fmt.Sprintf(CostPropertiesCollectionDegradedMsgTemplate, warnings),
+			},
+		}
+	}
+	return []metav1.Condition{
+		{
+			Type:    CostPropertiesCollectionSucceededCondType,
+			Status:  metav1.ConditionTrue,
+			Reason:  CostPropertiesCollectionSucceededReason,
+			Message: CostPropertiesCollectionSucceededMsg,
+		},
+	}
+}
+
+// collectAvailableResource collects the available resource information.
+func (p *PropertyProvider) collectAvailableResource(_ context.Context, usage *clusterv1beta1.ResourceUsage) {
+	requested := p.podTracker.TotalRequested()
+	available := make(corev1.ResourceList)
+	for rn, allocatable := range usage.Allocatable {
+		left := allocatable.DeepCopy()
+		// As with the Azure property provider, report a zero value if the requested quantity
+		// exceeds the allocatable one due to temporary inconsistencies between the trackers.
+		if left.Cmp(requested[rn]) > 0 {
+			left.Sub(requested[rn])
+		} else {
+			left = resource.Quantity{}
+		}
+		available[rn] = left
+	}
+	usage.Available = available
+}
+
+// collectGPUProperties collects the GPU properties, which sum up the quantities of all the GPU
+// resources in the resource usage.
+func (p *PropertyProvider) collectGPUProperties(_ context.Context, usage *clusterv1beta1.ResourceUsage, properties map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue) {
+	if len(p.gpuResourceNames) == 0 {
+		return
+	}
+
+	now := metav1.Now()
+	sumGPUs := func(rl corev1.ResourceList) string {
+		total := resource.Quantity{}
+		for _, rn := range p.gpuResourceNames {
+			if q, ok := rl[rn]; ok {
+				total.Add(q)
+			}
+		}
+		return total.String()
+	}
+	properties[TotalGPUCapacityProperty] = clusterv1beta1.PropertyValue{
+		Value:           sumGPUs(usage.Capacity),
+		ObservationTime: now,
+	}
+	properties[AllocatableGPUCapacityProperty] = clusterv1beta1.PropertyValue{
+		Value:           sumGPUs(usage.Allocatable),
+		ObservationTime: now,
+	}
+	if p.isAvailableResourcesCollectionEnabled {
+		properties[AvailableGPUCapacityProperty] = clusterv1beta1.PropertyValue{
+			Value:           sumGPUs(usage.Available),
+			ObservationTime: now,
+		}
+	}
+}
+
+// collectK8sVersion collects the Kubernetes server version information.
+// It uses a cache with a 15-minute TTL to minimize API calls to the discovery client.
+func (p *PropertyProvider) collectK8sVersion(_ context.Context, properties map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue) {
+	now := time.Now()
+
+	// Check if we have a cached version that is still valid.
+	p.k8sVersionMutex.Lock()
+	defer p.k8sVersionMutex.Unlock()
+	if p.cachedK8sVersion != "" && now.Sub(p.cachedK8sVersionObservedTime) < k8sVersionCacheTTL {
+		// Cache is still valid, use the cached version.
+		properties[propertyprovider.K8sVersionProperty] = clusterv1beta1.PropertyValue{
+			Value:           p.cachedK8sVersion,
+			ObservationTime: metav1.NewTime(p.cachedK8sVersionObservedTime),
+		}
+		return
+	}
+
+	// Cache is expired or empty, fetch the version from the discovery client.
+	serverVersion, err := p.discoveryClient.ServerVersion()
+	if err != nil {
+		klog.ErrorS(err, "Failed to get Kubernetes server version from discovery client")
+		return
+	}
+
+	// Update the cache with the new version.
+	p.cachedK8sVersion = serverVersion.GitVersion
+	p.cachedK8sVersionObservedTime = now
+	properties[propertyprovider.K8sVersionProperty] = clusterv1beta1.PropertyValue{
+		Value:           p.cachedK8sVersion,
+		ObservationTime: metav1.NewTime(now),
+	}
+	klog.V(2).InfoS("Collected Kubernetes version", "version", p.cachedK8sVersion)
+}
+
+// New returns a new generic property provider.
+//
+// If a pricing ConfigMap is specified, the provider calculates the costs of the cluster with
+// the static pricing data in the ConfigMap; see StaticPricingProvider for the format of the
+// data. The provider tracks the given GPU extended resources in addition to CPU and memory.
+func New(
+	pricingConfigMap *types.NamespacedName,
+	gpuResourceNames []corev1.ResourceName,
+	isAvailableResourcesCollectionEnabled bool,
+) propertyprovider.PropertyProvider {
+	return &PropertyProvider{
+		pricingConfigMap: pricingConfigMap,
+		gpuResourceNames: gpuResourceNames,
+		// Use the default names.
+		nodeControllerName:                    "generic-property-provider-node-watcher",
+		zoneControllerName:                    "generic-property-provider-zone-watcher",
+		podControllerName:                     "generic-property-provider-pod-watcher",
+		configMapControllerName:               "generic-property-provider-pricing-watcher",
+		isAvailableResourcesCollectionEnabled: isAvailableResourcesCollectionEnabled,
+	}
+}
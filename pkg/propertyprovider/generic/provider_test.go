@@ -0,0 +1,403 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generic
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	"go.goms.io/fleet/pkg/propertyprovider"
+	"go.goms.io/fleet/pkg/propertyprovider/azure/trackers"
+)
+
+const (
+	nodeName1 = "node-1"
+	nodeName2 = "node-2"
+	nodeName3 = "node-3"
+
+	podName1 = "pod-1"
+	podName2 = "pod-2"
+
+	namespaceName = "work"
+
+	instanceType1 = "m5.large"
+	instanceType2 = "gpu.large"
+
+	zone1 = "zone-1"
+	zone2 = "zone-2"
+
+	gpuResourceName = corev1.ResourceName("nvidia.com/gpu")
+
+	k8sVersion = "v1.33.1"
+)
+
+var (
+	ignoreObservationTimeFieldInPropertyValue = cmpopts.IgnoreFields(clusterv1beta1.PropertyValue{}, "ObservationTime")
+)
+
+func TestCollect(t *testing.T) {
+	nodes := []corev1.Node{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: nodeName1,
+				Labels: map[string]string{
+					corev1.LabelInstanceTypeStable: instanceType1,
+					corev1.LabelTopologyZone:       zone1,
+				},
+			},
+			Status: corev1.NodeStatus{
+				Capacity: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("2"),
+					corev1.ResourceMemory: resource.MustParse("8Gi"),
+				},
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("1.5"),
+					corev1.ResourceMemory: resource.MustParse("7Gi"),
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: nodeName2,
+				Labels: map[string]string{
+					corev1.LabelInstanceTypeStable: instanceType2,
+					corev1.LabelTopologyZone:       zone1,
+				},
+			},
+			Status: corev1.NodeStatus{
+				Capacity: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("8"),
+					corev1.ResourceMemory: resource.MustParse("32Gi"),
+					gpuResourceName:       resource.MustParse("2"),
+				},
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("7.5"),
+					corev1.ResourceMemory: resource.MustParse("31Gi"),
+					gpuResourceName:       resource.MustParse("2"),
+				},
+			},
+		},
+		{
+			// A bare-metal node without the instance type label.
+			ObjectMeta: metav1.ObjectMeta{
+				Name: nodeName3,
+				Labels: map[string]string{
+					corev1.LabelTopologyZone: zone2,
+				},
+			},
+			Status: corev1.NodeStatus{
+				Capacity: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("4"),
+					corev1.ResourceMemory: resource.MustParse("16Gi"),
+				},
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("3"),
+					corev1.ResourceMemory: resource.MustParse("14Gi"),
+				},
+			},
+		},
+	}
+	pods := []corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      podName1,
+				Namespace: namespaceName,
+			},
+			Spec: corev1.PodSpec{
+				NodeName: nodeName2,
+				Containers: []corev1.Container{
+					{
+						Name: "trainer",
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("4"),
+								corev1.ResourceMemory: resource.MustParse("8Gi"),
+								gpuResourceName:       resource.MustParse("1"),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      podName2,
+				Namespace: namespaceName,
+			},
+			Spec: corev1.PodSpec{
+				NodeName: nodeName3,
+				Containers: []corev1.Container{
+					{
+						Name: "server",
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("1"),
+								corev1.ResourceMemory: resource.MustParse("2Gi"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	pricingConfigMap := &types.NamespacedName{Namespace: "fleet-system", Name: "pricing"}
+
+	nodeCountProperties := map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+		propertyprovider.NodeCountProperty: {
+			Value: "3",
+		},
+		clusterv1beta1.PropertyName(fmt.Sprintf(NodeCountPerInstanceTypePropertyTmpl, instanceType1)): {
+			Value: "1",
+		},
+		clusterv1beta1.PropertyName(fmt.Sprintf(NodeCountPerInstanceTypePropertyTmpl, instanceType2)): {
+			Value: "1",
+		},
+		clusterv1beta1.PropertyName(fmt.Sprintf(NodeCountPerInstanceTypePropertyTmpl, trackers.ReservedNameForUndefinedSKU)): {
+			Value: "1",
+		},
+		ZoneCountProperty: {
+			Value: "2",
+		},
+		clusterv1beta1.PropertyName(fmt.Sprintf(NodeCountPerZonePropertyTmpl, zone1)): {
+			Value: "2",
+		},
+		clusterv1beta1.PropertyName(fmt.Sprintf(NodeCountPerZonePropertyTmpl, zone2)): {
+			Value: "1",
+		},
+		propertyprovider.K8sVersionProperty: {
+			Value: k8sVersion,
+		},
+	}
+	withProperties := func(extra map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue) map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue {
+		properties := make(map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue, len(nodeCountProperties)+len(extra))
+		for k, v := range nodeCountProperties {
+			properties[k] = v
+		}
+		for k, v := range extra {
+			properties[k] = v
+		}
+		return properties
+	}
+
+	testCases := []struct {
+		name                                  string
+		pricingConfigMap                      *types.NamespacedName
+		pricingData                           map[string]string
+		gpuResourceNames                      []corev1.ResourceName
+		isAvailableResourcesCollectionEnabled bool
+		wantResponse                          propertyprovider.PropertyCollectionResponse
+	}{
+		{
+			name:             "all features enabled",
+			pricingConfigMap: pricingConfigMap,
+			pricingData: map[string]string{
+				instanceType1:                        "1.0",
+				instanceType2:                        "4.0",
+				trackers.ReservedNameForUndefinedSKU: "1.0",
+			},
+			gpuResourceNames:                      []corev1.ResourceName{gpuResourceName},
+			isAvailableResourcesCollectionEnabled: true,
+			wantResponse: propertyprovider.PropertyCollectionResponse{
+				Properties: withProperties(map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+					// The total hourly cost is 6.0, for 14 CPU cores and 56 GB of memory.
+					PerCPUCoreCostProperty: {
+						Value: "0.429",
+					},
+					PerGBMemoryCostProperty: {
+						Value: "0.107",
+					},
+					TotalGPUCapacityProperty: {
+						Value: "2",
+					},
+					AllocatableGPUCapacityProperty: {
+						Value: "2",
+					},
+					AvailableGPUCapacityProperty: {
+						Value: "1",
+					},
+				}),
+				Resources: clusterv1beta1.ResourceUsage{
+					Capacity: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("14"),
+						corev1.ResourceMemory: resource.MustParse("56Gi"),
+						gpuResourceName:       resource.MustParse("2"),
+					},
+					Allocatable: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("12"),
+						corev1.ResourceMemory: resource.MustParse("52Gi"),
+						gpuResourceName:       resource.MustParse("2"),
+					},
+					Available: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("7"),
+						corev1.ResourceMemory: resource.MustParse("42Gi"),
+						gpuResourceName:       resource.MustParse("1"),
+					},
+				},
+				Conditions: []metav1.Condition{
+					{
+						Type:    CostPropertiesCollectionSucceededCondType,
+						Status:  metav1.ConditionTrue,
+						Reason:  CostPropertiesCollectionSucceededReason,
+						Message: CostPropertiesCollectionSucceededMsg,
+					},
+				},
+			},
+		},
+		{
+			name:             "missing pricing data",
+			pricingConfigMap: pricingConfigMap,
+			pricingData: map[string]string{
+				instanceType1: "1.0",
+			},
+			gpuResourceNames: []corev1.ResourceName{gpuResourceName},
+			wantResponse: propertyprovider.PropertyCollectionResponse{
+				Properties: withProperties(map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+					TotalGPUCapacityProperty: {
+						Value: "2",
+					},
+					AllocatableGPUCapacityProperty: {
+						Value: "2",
+					},
+				}),
+				Resources: clusterv1beta1.ResourceUsage{
+					Capacity: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("14"),
+						corev1.ResourceMemory: resource.MustParse("56Gi"),
+						gpuResourceName:       resource.MustParse("2"),
+					},
+					Allocatable: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("12"),
+						corev1.ResourceMemory: resource.MustParse("52Gi"),
+						gpuResourceName:       resource.MustParse("2"),
+					},
+				},
+				Conditions: []metav1.Condition{
+					{
+						Type:    CostPropertiesCollectionSucceededCondType,
+						Status:  metav1.ConditionFalse,
+						Reason:  CostPropertiesCollectionFailedReason,
+						Message: fmt.Sprintf(CostPropertiesCollectionFailedMsgTemplate, fmt.Errorf("no pricing data is available for one or more of the node SKUs ([ %s]) in the cluster", instanceType2)),
+					},
+				},
+			},
+		},
+		{
+			name:                                  "no pricing data and no GPU resources",
+			isAvailableResourcesCollectionEnabled: true,
+			wantResponse: propertyprovider.PropertyCollectionResponse{
+				Properties: withProperties(nil),
+				Resources: clusterv1beta1.ResourceUsage{
+					Capacity: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("14"),
+						corev1.ResourceMemory: resource.MustParse("56Gi"),
+					},
+					Allocatable: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("12"),
+						corev1.ResourceMemory: resource.MustParse("52Gi"),
+					},
+					Available: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("7"),
+						corev1.ResourceMemory: resource.MustParse("42Gi"),
+					},
+				},
+				Conditions: []metav1.Condition{},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			// Build the trackers manually for testing purposes.
+			resourceNames := append([]corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory}, tc.gpuResourceNames...)
+			pricingProvider := NewStaticPricingProvider()
+			pricingProvider.Set(tc.pricingData)
+			nodeTracker := trackers.NewNodeTrackerWithResourceNames(pricingProvider, resourceNames)
+			podTracker := trackers.NewPodTrackerWithResourceNames(resourceNames)
+			zoneTracker := newZoneTracker()
+			for idx := range nodes {
+				nodeTracker.AddOrUpdate(&nodes[idx])
+				zoneTracker.AddOrUpdate(&nodes[idx])
+			}
+			for idx := range pods {
+				podTracker.AddOrUpdate(&pods[idx])
+			}
+			p := &PropertyProvider{
+				nodeTracker:                           nodeTracker,
+				podTracker:                            podTracker,
+				zoneTracker:                           zoneTracker,
+				gpuResourceNames:                      tc.gpuResourceNames,
+				pricingConfigMap:                      tc.pricingConfigMap,
+				pricingProvider:                       pricingProvider,
+				isAvailableResourcesCollectionEnabled: tc.isAvailableResourcesCollectionEnabled,
+				cachedK8sVersion:                      k8sVersion,
+				cachedK8sVersionObservedTime:          time.Now(),
+			}
+			res := p.Collect(ctx)
+			if diff := cmp.Diff(res, tc.wantResponse, ignoreObservationTimeFieldInPropertyValue); diff != "" {
+				t.Fatalf("Collect() property collection response diff (-got, +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestZoneTracker(t *testing.T) {
+	nodeInZone := func(name, zone string) *corev1.Node {
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{},
+			},
+		}
+		if len(zone) > 0 {
+			node.Labels[corev1.LabelTopologyZone] = zone
+		}
+		return node
+	}
+
+	zt := newZoneTracker()
+	zt.AddOrUpdate(nodeInZone(nodeName1, zone1))
+	zt.AddOrUpdate(nodeInZone(nodeName2, zone1))
+	zt.AddOrUpdate(nodeInZone(nodeName3, ""))
+	if diff := cmp.Diff(zt.NodeCountPerZone(), map[string]int{zone1: 2}); diff != "" {
+		t.Fatalf("NodeCountPerZone() diff after adding nodes (-got, +want):\n%s", diff)
+	}
+
+	// Move a node to another zone.
+	zt.AddOrUpdate(nodeInZone(nodeName2, zone2))
+	if diff := cmp.Diff(zt.NodeCountPerZone(), map[string]int{zone1: 1, zone2: 1}); diff != "" {
+		t.Fatalf("NodeCountPerZone() diff after updating a node (-got, +want):\n%s", diff)
+	}
+
+	// Remove the nodes, including one that is not tracked.
+	zt.Remove(nodeName1)
+	zt.Remove(nodeName3)
+	if diff := cmp.Diff(zt.NodeCountPerZone(), map[string]int{zone2: 1}); diff != "" {
+		t.Fatalf("NodeCountPerZone() diff after removing nodes (-got, +want):\n%s", diff)
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generic
+
+import (
+	"context"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"go.goms.io/fleet/pkg/propertyprovider/azure/trackers"
+)
+
+// zoneTracker tracks the zones of nodes, as specified by the topology.kubernetes.io/zone
+// label on nodes; nodes without the label are not tracked.
+type zoneTracker struct {
+	// zoneByNode tracks the zone of each node.
+	zoneByNode map[string]string
+	// nodeSetByZone tracks the nodes in each zone.
+	nodeSetByZone map[string]trackers.NodeSet
+
+	// mu is a RW mutex that protects the internal data of the tracker.
+	mu sync.RWMutex
+}
+
+// newZoneTracker returns a zone tracker.
+func newZoneTracker() *zoneTracker {
+	return &zoneTracker{
+		zoneByNode:    make(map[string]string),
+		nodeSetByZone: make(map[string]trackers.NodeSet),
+	}
+}
+
+// AddOrUpdate starts tracking the zone of a node, or updates the tracked zone.
+func (zt *zoneTracker) AddOrUpdate(node *corev1.Node) {
+	zt.mu.Lock()
+	defer zt.mu.Unlock()
+
+	zt.untrack(node.Name)
+	zone := node.Labels[corev1.LabelTopologyZone]
+	if len(zone) == 0 {
+		return
+	}
+	zt.zoneByNode[node.Name] = zone
+	ns := zt.nodeSetByZone[zone]
+	if ns == nil {
+		ns = make(trackers.NodeSet)
+		zt.nodeSetByZone[zone] = ns
+	}
+	ns[node.Name] = true
+}
+
+// Remove stops tracking the zone of a node.
+func (zt *zoneTracker) Remove(nodeName string) {
+	zt.mu.Lock()
+	defer zt.mu.Unlock()
+
+	zt.untrack(nodeName)
+}
+
+// untrack stops tracking the zone of a node.
+//
+// Note that this method assumes that the access lock has been acquired.
+func (zt *zoneTracker) untrack(nodeName string) {
+	zone, found := zt.zoneByNode[nodeName]
+	if !found {
+		return
+	}
+	delete(zt.zoneByNode, nodeName)
+	ns := zt.nodeSetByZone[zone]
+	delete(ns, nodeName)
+	if len(ns) == 0 {
+		delete(zt.nodeSetByZone, zone)
+	}
+}
+
+// NodeCountPerZone returns the number of nodes in each zone.
+func (zt *zoneTracker) NodeCountPerZone() map[string]int {
+	zt.mu.RLock()
+	defer zt.mu.RUnlock()
+
+	// Return a copy to avoid leaks/unexpected edits.
+	res := make(map[string]int, len(zt.nodeSetByZone))
+	for zone, ns := range zt.nodeSetByZone {
+		res[zone] = len(ns)
+	}
+	return res
+}
+
+// zoneReconciler reconciles Node objects for the zone tracker.
+type zoneReconciler struct {
+	zt     *zoneTracker
+	client client.Client
+}
+
+// Reconcile reconciles a node object.
+func (r *zoneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	nodeRef := klog.KRef(req.Namespace, req.Name)
+
+	node := &corev1.Node{}
+	if err := r.client.Get(ctx, req.NamespacedName, node); err != nil {
+		if errors.IsNotFound(err) {
+			// As with the node reconciler, the node is untracked only when it is actually gone.
+			klog.V(2).InfoS("Node is not found; untrack its zone", "node", nodeRef)
+			r.zt.Remove(req.Name)
+			return ctrl.Result{}, nil
+		}
+		klog.ErrorS(err, "Failed to get the node object", "node", nodeRef)
+		return ctrl.Result{}, err
+	}
+
+	klog.V(2).InfoS("Attempt to track the zone of the node", "node", nodeRef)
+	r.zt.AddOrUpdate(node)
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the zone reconciler with the manager.
+func (r *zoneReconciler) SetupWithManager(mgr ctrl.Manager, controllerName string) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(controllerName).
+		For(&corev1.Node{}).
+		Complete(r)
+}
@@ -41,6 +41,11 @@ type PropertyCollectionResponse struct {
 	// Last transition time of each added condition is omitted if set and will instead be added
 	// by the Fleet member agent.
 	Conditions []metav1.Condition
+	// Err, if set, signals that the property provider has failed to collect the properties.
+	//
+	// Fleet member agent will report the failure and keep the properties and resources from the
+	// last successful collection; all the other fields of the response are ignored.
+	Err error
 }
 
 // PropertyProvider is the interface that every property provider must implement.
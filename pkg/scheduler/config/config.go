@@ -0,0 +1,184 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package config features the versioned configuration of the scheduler, which defines the
+// scheduling profiles available to placements.
+package config
+
+import (
+	"fmt"
+	"os"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	apiErrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/yaml"
+
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/extender"
+)
+
+const (
+	// APIVersion is the API version of the scheduler configuration.
+	APIVersion = "scheduler.kubernetes-fleet.io/v1alpha1"
+	// Kind is the kind of the scheduler configuration.
+	Kind = "SchedulerConfiguration"
+
+	// AllPlugins can be specified in the disabled plugin list to disable all the default plugins.
+	AllPlugins = "*"
+
+	// MaxPluginWeight is the max. weight of a score plugin.
+	MaxPluginWeight int32 = 100
+)
+
+// SchedulerConfiguration is the configuration of the scheduler.
+type SchedulerConfiguration struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Profiles is the list of scheduling profiles. A placement picks a profile by its
+	// schedulerName; the default profile is used if the placement does not specify one.
+	// A profile named after the default profile overrides it.
+	Profiles []Profile `json:"profiles"`
+}
+
+// Profile is the configuration of a scheduling profile.
+type Profile struct {
+	// SchedulerName is the name of the profile.
+	SchedulerName string `json:"schedulerName"`
+
+	// Plugins specifies the plugins to enable or disable on top of the default plugins.
+	Plugins *Plugins `json:"plugins,omitempty"`
+
+	// PluginConfig specifies the weights and arguments of the plugins.
+	PluginConfig []PluginConfig `json:"pluginConfig,omitempty"`
+
+	// Extenders is the list of scheduler extenders, which run after the plugins.
+	Extenders []extender.Config `json:"extenders,omitempty"`
+}
+
+// Plugins specifies the plugins to enable or disable.
+type Plugins struct {
+	// Enabled is the list of plugins to enable, in the order they run; it is only useful
+	// for enabling plugins which are disabled by AllPlugins.
+	Enabled []string `json:"enabled,omitempty"`
+
+	// Disabled is the list of default plugins to disable; AllPlugins disables all of them.
+	Disabled []string `json:"disabled,omitempty"`
+}
+
+// PluginConfig specifies the weight and arguments of a plugin.
+type PluginConfig struct {
+	// Name is the name of the plugin.
+	Name string `json:"name"`
+
+	// Weight is the multiplier applied to the scores of the plugin; it defaults to 1 and
+	// only applies to score plugins. The clusters are ranked by the sum of the weighted scores
+	// of all the score plugins.
+	Weight *int32 `json:"weight,omitempty"`
+
+	// Args is the arguments of the plugin; the schema depends on the plugin. At this moment only
+	// the ResourceFit and TopologySpreadConstraints plugins accept arguments.
+	Args *runtime.RawExtension `json:"args,omitempty"`
+}
+
+// Load reads the scheduler configuration from a YAML or JSON file and validates it.
+func Load(path string) (*SchedulerConfiguration, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the scheduler configuration file %s: %w", path, err)
+	}
+	cfg := &SchedulerConfiguration{}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse the scheduler configuration file %s: %w", path, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid scheduler configuration in file %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Validate validates the scheduler configuration.
+//
+// Note that whether the plugins are known to the scheduler, and whether their arguments are valid,
+// is validated when the profiles are built.
+func (c *SchedulerConfiguration) Validate() error {
+	allErr := make([]error, 0)
+	if c.APIVersion != APIVersion || c.Kind != Kind {
+		allErr = append(allErr, fmt.Errorf("unsupported scheduler configuration %s/%s, want %s/%s", c.APIVersion, c.Kind, APIVersion, Kind))
+	}
+	names := make(map[string]bool, len(c.Profiles))
+	for i := range c.Profiles {
+		p := &c.Profiles[i]
+		if names[p.SchedulerName] {
+			allErr = append(allErr, fmt.Errorf("profile %q is duplicated", p.SchedulerName))
+		}
+		names[p.SchedulerName] = true
+		if err := p.Validate(); err != nil {
+			allErr = append(allErr, err)
+		}
+	}
+	return apiErrors.NewAggregate(allErr)
+}
+
+// Validate validates the configuration of a scheduling profile.
+func (p *Profile) Validate() error {
+	allErr := make([]error, 0)
+	if p.SchedulerName == "" {
+		allErr = append(allErr, fmt.Errorf("profile schedulerName must not be empty"))
+	}
+	if p.Plugins != nil {
+		disabled := make(map[string]bool, len(p.Plugins.Disabled))
+		for _, name := range p.Plugins.Disabled {
+			if name == "" {
+				allErr = append(allErr, fmt.Errorf("profile %q has a disabled plugin with an empty name", p.SchedulerName))
+			}
+			disabled[name] = true
+		}
+		enabled := make(map[string]bool, len(p.Plugins.Enabled))
+		for _, name := range p.Plugins.Enabled {
+			switch {
+			case name == "" || name == AllPlugins:
+				allErr = append(allErr, fmt.Errorf("profile %q has an enabled plugin with an invalid name %q", p.SchedulerName, name))
+			case enabled[name]:
+				allErr = append(allErr, fmt.Errorf("profile %q enables plugin %q more than once", p.SchedulerName, name))
+			case disabled[name]:
+				allErr = append(allErr, fmt.Errorf("profile %q both enables and disables plugin %q", p.SchedulerName, name))
+			}
+			enabled[name] = true
+		}
+	}
+	configured := make(map[string]bool, len(p.PluginConfig))
+	for _, pc := range p.PluginConfig {
+		if configured[pc.Name] {
+			allErr = append(allErr, fmt.Errorf("profile %q configures plugin %q more than once", p.SchedulerName, pc.Name))
+		}
+		configured[pc.Name] = true
+		if pc.Weight != nil && (*pc.Weight <= 0 || *pc.Weight > MaxPluginWeight) {
+			allErr = append(allErr, fmt.Errorf("profile %q must have a weight in the range of [1, %d] for plugin %q, got %d", p.SchedulerName, MaxPluginWeight, pc.Name, *pc.Weight))
+		}
+	}
+	extenders := make(map[string]bool, len(p.Extenders))
+	for i := range p.Extenders {
+		e := &p.Extenders[i]
+		if extenders[e.Name] {
+			allErr = append(allErr, fmt.Errorf("profile %q has duplicated extender %q", p.SchedulerName, e.Name))
+		}
+		extenders[e.Name] = true
+		if err := e.Validate(); err != nil {
+			allErr = append(allErr, fmt.Errorf("profile %q has an invalid extender: %w", p.SchedulerName, err))
+		}
+	}
+	return apiErrors.NewAggregate(allErr)
+}
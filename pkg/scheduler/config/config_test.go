@@ -0,0 +1,197 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/extender"
+)
+
+// TestLoad tests the Load function.
+func TestLoad(t *testing.T) {
+	testCases := []struct {
+		name       string
+		content    string
+		want       *SchedulerConfiguration
+		wantErrMsg string
+	}{
+		{
+			name: "valid configuration",
+			content: `
+apiVersion: scheduler.kubernetes-fleet.io/v1alpha1
+kind: SchedulerConfiguration
+profiles:
+- schedulerName: DefaultProfile
+  pluginConfig:
+  - name: ClusterAffinity
+    weight: 2
+- schedulerName: data-residency
+  plugins:
+    disabled:
+    - TopologySpreadConstraints
+    enabled:
+    - ResourceFit
+  pluginConfig:
+  - name: ResourceFit
+    args:
+      resources:
+      - name: cpu
+        weight: 1
+  extenders:
+  - name: data-residency
+    urlPrefix: https://extender.example.com/fleet
+    filterVerb: filter
+    timeout: 2s
+    ignorable: true
+`,
+			want: &SchedulerConfiguration{
+				TypeMeta: metav1.TypeMeta{APIVersion: APIVersion, Kind: Kind},
+				Profiles: []Profile{
+					{
+						SchedulerName: "DefaultProfile",
+						PluginConfig: []PluginConfig{
+							{Name: "ClusterAffinity", Weight: ptr.To(int32(2))},
+						},
+					},
+					{
+						SchedulerName: "data-residency",
+						Plugins: &Plugins{
+							Enabled:  []string{"ResourceFit"},
+							Disabled: []string{"TopologySpreadConstraints"},
+						},
+						PluginConfig: []PluginConfig{
+							{Name: "ResourceFit", Args: &runtime.RawExtension{Raw: []byte(`{"resources":[{"name":"cpu","weight":1}]}`)}},
+						},
+						Extenders: []extender.Config{
+							{
+								Name:       "data-residency",
+								URLPrefix:  "https://extender.example.com/fleet",
+								FilterVerb: "filter",
+								Timeout:    metav1.Duration{Duration: 2 * time.Second},
+								Ignorable:  true,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "unsupported version",
+			content: `
+apiVersion: scheduler.kubernetes-fleet.io/v1beta1
+kind: SchedulerConfiguration
+profiles:
+- schedulerName: DefaultProfile
+`,
+			wantErrMsg: "unsupported scheduler configuration",
+		},
+		{
+			name: "unknown field",
+			content: `
+apiVersion: scheduler.kubernetes-fleet.io/v1alpha1
+kind: SchedulerConfiguration
+profiles:
+- name: DefaultProfile
+`,
+			wantErrMsg: "failed to parse",
+		},
+		{
+			name: "duplicated profiles",
+			content: `
+apiVersion: scheduler.kubernetes-fleet.io/v1alpha1
+kind: SchedulerConfiguration
+profiles:
+- schedulerName: cost-aware
+- schedulerName: cost-aware
+`,
+			wantErrMsg: "profile \"cost-aware\" is duplicated",
+		},
+		{
+			name: "plugin both enabled and disabled",
+			content: `
+apiVersion: scheduler.kubernetes-fleet.io/v1alpha1
+kind: SchedulerConfiguration
+profiles:
+- schedulerName: cost-aware
+  plugins:
+    enabled:
+    - ClusterAffinity
+    disabled:
+    - ClusterAffinity
+`,
+			wantErrMsg: "both enables and disables",
+		},
+		{
+			name: "invalid weight",
+			content: `
+apiVersion: scheduler.kubernetes-fleet.io/v1alpha1
+kind: SchedulerConfiguration
+profiles:
+- schedulerName: cost-aware
+  pluginConfig:
+  - name: ClusterAffinity
+    weight: 0
+`,
+			wantErrMsg: "must have a weight",
+		},
+		{
+			name: "invalid extender",
+			content: `
+apiVersion: scheduler.kubernetes-fleet.io/v1alpha1
+kind: SchedulerConfiguration
+profiles:
+- schedulerName: cost-aware
+  extenders:
+  - name: contract-tier
+    urlPrefix: https://extender.example.com/fleet
+`,
+			wantErrMsg: "has an invalid extender",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "scheduler-config.yaml")
+			if err := os.WriteFile(path, []byte(tc.content), 0600); err != nil {
+				t.Fatalf("failed to write the configuration file: %v", err)
+			}
+			got, err := Load(path)
+			if tc.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErrMsg) {
+					t.Fatalf("Load() error = %v, want error containing %s", err, tc.wantErrMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v, want no error", err)
+			}
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("Load() diff (-got, +want): %s", diff)
+			}
+		})
+	}
+}
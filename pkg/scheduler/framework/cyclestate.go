@@ -42,6 +42,7 @@ type CycleStatePluginReadWriter interface {
 	Delete(key StateKey)
 
 	ListClusters() []clusterv1beta1.MemberCluster
+	ListPassedClusters() []clusterv1beta1.MemberCluster
 	HasScheduledOrBoundBindingFor(clusterName string) bool
 	HasObsoleteBindingFor(clusterName string) bool
 }
@@ -60,6 +61,10 @@ type CycleState struct {
 	// in the current scheduling cycle.
 	clusters []clusterv1beta1.MemberCluster
 
+	// passedClusters is the list of clusters that have passed the Filter plugins in the current
+	// scheduling cycle.
+	passedClusters []*clusterv1beta1.MemberCluster
+
 	// scheduledOrBoundBindings is a map that helps check if there is a scheduler or bound
 	// binding in the current cycle associated with the cluster.
 	scheduledOrBoundBindings map[string]bool
@@ -118,6 +123,25 @@ func (c *CycleState) ListClusters() []clusterv1beta1.MemberCluster {
 	return clusters
 }
 
+// ListPassedClusters returns the list of clusters that have passed the Filter plugins in the
+// current scheduling cycle, i.e., the clusters that the Score plugins will evaluate; it returns
+// no clusters before the Filter stage completes.
+//
+// Similar to ListClusters, this is a relatively expensive op, as it returns the copy of the clusters.
+func (c *CycleState) ListPassedClusters() []clusterv1beta1.MemberCluster {
+	clusters := make([]clusterv1beta1.MemberCluster, 0, len(c.passedClusters))
+	for _, cluster := range c.passedClusters {
+		clusters = append(clusters, *cluster)
+	}
+	return clusters
+}
+
+// SetPassedClusters sets the list of clusters that have passed the Filter plugins in the current
+// scheduling cycle; the scheduler calls it once the Filter stage completes.
+func (c *CycleState) SetPassedClusters(clusters []*clusterv1beta1.MemberCluster) {
+	c.passedClusters = clusters
+}
+
 // HasScheduledOrBoundBindingFor returns whether a cluster already has a scheduled or bound
 // binding associated.
 //
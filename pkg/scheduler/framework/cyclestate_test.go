@@ -87,6 +87,14 @@ func TestCycleStateBasicOps(t *testing.T) {
 		t.Fatalf("ListClusters() diff (-got, +want): %s", diff)
 	}
 
+	if passedClusters := cs.ListPassedClusters(); len(passedClusters) != 0 {
+		t.Fatalf("ListPassedClusters() = %v, want no clusters before filtering", passedClusters)
+	}
+	cs.SetPassedClusters([]*clusterv1beta1.MemberCluster{&clusters[0]})
+	if diff := cmp.Diff(cs.ListPassedClusters(), clusters[:1]); diff != "" {
+		t.Fatalf("ListPassedClusters() diff (-got, +want): %s", diff)
+	}
+
 	for _, binding := range scheduledOrBoundBindings {
 		if !cs.HasScheduledOrBoundBindingFor(binding.Spec.TargetCluster) {
 			t.Fatalf("HasScheduledOrBoundBindingFor(%v) = false, want true", binding.Spec.TargetCluster)
@@ -100,6 +100,15 @@ type Framework interface {
 	// RunSchedulingCycleFor performs scheduling for a resource placement, specifically
 	// its associated latest scheduling policy snapshot.
 	RunSchedulingCycleFor(ctx context.Context, placementKey queue.PlacementKey, policy placementv1beta1.PolicySnapshotObj) (result ctrl.Result, err error)
+
+	// ScoreClustersFor runs the filter and score plugins for a resource placement of the PickN
+	// placement type as if it had not been scheduled to the excluded cluster, without making any
+	// change to the system; it helps find out if a placement would be better off on another cluster.
+	ScoreClustersFor(ctx context.Context, placementKey queue.PlacementKey, policy placementv1beta1.PolicySnapshotObj, excludedCluster string) (ScoredClusters, error)
+
+	// SimulateSchedulingFor runs the scheduling cycles for a scheduling policy in simulation, without
+	// making any change to the system; it helps find out which clusters a placement would be scheduled to.
+	SimulateSchedulingFor(ctx context.Context, placementKey queue.PlacementKey, policy placementv1beta1.PolicySnapshotObj) ([]placementv1beta1.ClusterDecision, error)
 }
 
 // framework implements the Framework interface.
@@ -682,6 +691,8 @@ func (f *framework) runFilterPlugins(ctx context.Context, state *CycleState, pol
 	passed = passed[:passedIdx+1]
 	filtered = filtered[:filteredIdx+1]
 
+	// Make the passed clusters available to the PreScore and Score plugins.
+	state.SetPassedClusters(passed)
 	return passed, filtered, nil
 }
 
@@ -868,7 +879,11 @@ func (f *framework) runSchedulingCycleForPickNPlacementType(
 	// * currently there are too many selected clusters, or more specifically too many scheduled/bound bindings
 	//   in the system; or there are exactly the right number of selected clusters, but some obsolete bindings still linger
 	//   in the system.
-	if act, downscaleCount := shouldDownscale(policy, numOfClusters, len(scheduled)+len(bound), len(obsolete)); act {
+	//
+	// Note that bindings which the rebalancer has created to replace other scheduled/bound bindings are
+	// not counted, as the bindings they replace will be evicted once they become available.
+	present := len(scheduled) + len(bound) - countPendingReplacements(scheduled, bound)
+	if act, downscaleCount := shouldDownscale(policy, numOfClusters, present, len(obsolete)); act {
 		// Downscale if needed.
 		//
 		// To minimize interruptions, the scheduler picks scheduled bindings first, and then
@@ -907,7 +922,7 @@ func (f *framework) runSchedulingCycleForPickNPlacementType(
 
 	// Check if the scheduler needs to take action; a scheduling cycle is only needed if
 	// currently there are not enough number of bindings.
-	if !shouldSchedule(numOfClusters, len(bound)+len(scheduled)) {
+	if !shouldSchedule(numOfClusters, present) {
 		// No action is needed; however, a status refresh might be warranted.
 		//
 		// This is needed as a number of situations (e.g., POST/PUT failures) may lead to inconsistencies between
@@ -934,7 +949,7 @@ func (f *framework) runSchedulingCycleForPickNPlacementType(
 	// to identify clusters that already have placements, in accordance with the latest
 	// scheduling policy, on them. Such clusters will not be scored; it will not be included
 	// as a filtered out cluster, either.
-	scored, filtered, err := f.runAllPluginsForPickNPlacementType(ctx, state, policy, numOfClusters, len(bound)+len(scheduled), clusters)
+	scored, filtered, err := f.runAllPluginsForPickNPlacementType(ctx, state, policy, numOfClusters, present, clusters)
 	if err != nil {
 		klog.ErrorS(err, "Failed to run all plugins", "policySnapshot", policyRef)
 		return ctrl.Result{}, err
@@ -1238,6 +1253,9 @@ func (f *framework) runScorePluginsFor(ctx context.Context, state *CycleState, p
 		score, status := pl.Score(ctx, state, policy, cluster)
 		switch {
 		case status.IsSuccess():
+			if weight, ok := f.profile.scorePluginWeights[pl.Name()]; ok && score != nil {
+				score = score.Scale(weight)
+			}
 			scoreList[pl.Name()] = score
 		case status.IsInteralError():
 			return nil, status
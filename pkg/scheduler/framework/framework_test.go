@@ -71,7 +71,7 @@ var (
 	ignoredStatusFields                       = cmpopts.IgnoreFields(Status{}, "reasons", "err")
 	ignoredBindingWithPatchFields             = cmpopts.IgnoreFields(bindingWithPatch{}, "patch")
 	ignoredCondFields                         = cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime")
-	ignoreCycleStateFields                    = cmpopts.IgnoreFields(CycleState{}, "store", "clusters", "scheduledOrBoundBindings", "obsoleteBindings")
+	ignoreCycleStateFields                    = cmpopts.IgnoreFields(CycleState{}, "store", "clusters", "passedClusters", "scheduledOrBoundBindings", "obsoleteBindings")
 	ignoreClusterDecisionScoreAndReasonFields = cmpopts.IgnoreFields(placementv1beta1.ClusterDecision{}, "ClusterScore", "Reason")
 
 	lessFuncCluster = func(cluster1, cluster2 *clusterv1beta1.MemberCluster) bool {
@@ -4294,7 +4294,7 @@ func TestDownscale(t *testing.T) {
 	topologySpreadScore1 := int32(1)
 	affinityScore1 := int32(10)
 	topologySpreadScore2 := int32(0)
-	affinityScore2 := int32(20)
+	affinityScore2 := int32(5)
 
 	testCases := []struct {
 		name                 string
@@ -5059,6 +5059,7 @@ func TestRunScorePluginsFor(t *testing.T) {
 	testCases := []struct {
 		name               string
 		scorePlugins       []ScorePlugin
+		pluginWeights      map[string]int32
 		skippedPluginNames []string
 		wantStatus         *Status
 		wantScoreList      map[string]*ClusterScore
@@ -5116,6 +5117,40 @@ func TestRunScorePluginsFor(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "multiple plugins, weighted",
+			scorePlugins: []ScorePlugin{
+				&DummyAllPurposePlugin{
+					name: dummyScorePluginA,
+					scoreRunner: func(ctx context.Context, state CycleStatePluginReadWriter, policy placementv1beta1.PolicySnapshotObj, cluster *clusterv1beta1.MemberCluster) (score *ClusterScore, status *Status) {
+						return &ClusterScore{
+							TopologySpreadScore: 1,
+							AffinityScore:       20,
+						}, nil
+					},
+				},
+				&DummyAllPurposePlugin{
+					name: dummyScorePluginB,
+					scoreRunner: func(ctx context.Context, state CycleStatePluginReadWriter, policy placementv1beta1.PolicySnapshotObj, cluster *clusterv1beta1.MemberCluster) (score *ClusterScore, status *Status) {
+						return &ClusterScore{
+							AffinityScore: 10,
+						}, nil
+					},
+				},
+			},
+			pluginWeights: map[string]int32{
+				dummyScorePluginB: 3,
+			},
+			wantScoreList: map[string]*ClusterScore{
+				dummyScorePluginA: {
+					TopologySpreadScore: 1,
+					AffinityScore:       20,
+				},
+				dummyScorePluginB: {
+					AffinityScore: 30,
+				},
+			},
+		},
 		{
 			name: "multiple plugin, one success, one skipped",
 			scorePlugins: []ScorePlugin{
@@ -5190,6 +5225,9 @@ func TestRunScorePluginsFor(t *testing.T) {
 			for _, p := range tc.scorePlugins {
 				profile.WithScorePlugin(p)
 			}
+			for name, weight := range tc.pluginWeights {
+				profile.WithScorePluginWeight(name, weight)
+			}
 			f := &framework{
 				profile: profile,
 			}
@@ -5488,7 +5526,7 @@ func TestPickTopNScoredClusters(t *testing.T) {
 			},
 			Score: &ClusterScore{
 				TopologySpreadScore:            1,
-				AffinityScore:                  20,
+				AffinityScore:                  5,
 				ObsoletePlacementAffinityScore: 0,
 			},
 		},
@@ -5552,7 +5590,7 @@ func TestPickTopNScoredClusters(t *testing.T) {
 					},
 					Score: &ClusterScore{
 						TopologySpreadScore:            1,
-						AffinityScore:                  20,
+						AffinityScore:                  5,
 						ObsoletePlacementAffinityScore: 0,
 					},
 				},
@@ -5586,7 +5624,7 @@ func TestPickTopNScoredClusters(t *testing.T) {
 					},
 					Score: &ClusterScore{
 						TopologySpreadScore:            1,
-						AffinityScore:                  20,
+						AffinityScore:                  5,
 						ObsoletePlacementAffinityScore: 0,
 					},
 				},
@@ -566,6 +566,27 @@ func sortByClusterScoreAndName(bindings []placementv1beta1.BindingObj) (sorted [
 	return bindings
 }
 
+// countPendingReplacements counts the bindings which the rebalancer has created to replace other
+// scheduled or bound bindings that are still present.
+func countPendingReplacements(existing ...[]placementv1beta1.BindingObj) int {
+	names := make(map[string]bool)
+	for _, bindings := range existing {
+		for _, binding := range bindings {
+			names[binding.GetName()] = true
+		}
+	}
+
+	count := 0
+	for _, bindings := range existing {
+		for _, binding := range bindings {
+			if source, ok := binding.GetAnnotations()[placementv1beta1.RebalanceSourceBindingAnnotation]; ok && names[source] {
+				count++
+			}
+		}
+	}
+	return count
+}
+
 // shouldSchedule checks if the scheduler needs to perform some scheduling.
 func shouldSchedule(desiredCount, existingCount int) bool {
 	return desiredCount > existingCount
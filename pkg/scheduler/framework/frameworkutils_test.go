@@ -165,3 +165,46 @@ func TestGenerateBinding(t *testing.T) {
 		})
 	}
 }
+
+// TestCountPendingReplacements tests the countPendingReplacements function.
+func TestCountPendingReplacements(t *testing.T) {
+	newBinding := func(name, source string) placementv1beta1.BindingObj {
+		binding := &placementv1beta1.ClusterResourceBinding{}
+		binding.SetName(name)
+		if source != "" {
+			binding.SetAnnotations(map[string]string{placementv1beta1.RebalanceSourceBindingAnnotation: source})
+		}
+		return binding
+	}
+
+	tests := []struct {
+		name      string
+		scheduled []placementv1beta1.BindingObj
+		bound     []placementv1beta1.BindingObj
+		want      int
+	}{
+		{
+			name:  "no replacements",
+			bound: []placementv1beta1.BindingObj{newBinding("binding-1", ""), newBinding("binding-2", "")},
+			want:  0,
+		},
+		{
+			name:      "replacement of a present binding",
+			scheduled: []placementv1beta1.BindingObj{newBinding("binding-3", "binding-1")},
+			bound:     []placementv1beta1.BindingObj{newBinding("binding-1", ""), newBinding("binding-2", "")},
+			want:      1,
+		},
+		{
+			name:  "replacement of an evicted binding",
+			bound: []placementv1beta1.BindingObj{newBinding("binding-2", ""), newBinding("binding-3", "binding-1")},
+			want:  0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := countPendingReplacements(tt.scheduled, tt.bound); got != tt.want {
+				t.Errorf("countPendingReplacements() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
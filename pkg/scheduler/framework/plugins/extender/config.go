@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extender
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+
+	apiErrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/yaml"
+
+	"go.goms.io/fleet/pkg/propertyprovider"
+)
+
+const (
+	// maxWeight is the max. weight of an extender, which keeps the weighted scores from overflowing.
+	maxWeight int32 = 100
+)
+
+var (
+	// supportedCapacityTypes are the capacity types which the resource properties can be of.
+	supportedCapacityTypes = map[string]bool{
+		propertyprovider.TotalCapacityName:       true,
+		propertyprovider.AllocatableCapacityName: true,
+		propertyprovider.AvailableCapacityName:   true,
+	}
+)
+
+// LoadConfiguration reads the extender configuration from a YAML or JSON file and validates it.
+func LoadConfiguration(path string) (*Configuration, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the extender configuration file %s: %w", path, err)
+	}
+	cfg := &Configuration{}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse the extender configuration file %s: %w", path, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid extender configuration in file %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Validate validates the extender configuration.
+func (c *Configuration) Validate() error {
+	allErr := make([]error, 0)
+	names := make(map[string]bool, len(c.Extenders))
+	for i := range c.Extenders {
+		e := &c.Extenders[i]
+		if names[e.Name] {
+			allErr = append(allErr, fmt.Errorf("extender name %q is duplicated", e.Name))
+		}
+		names[e.Name] = true
+		if err := e.Validate(); err != nil {
+			allErr = append(allErr, err)
+		}
+	}
+	return apiErrors.NewAggregate(allErr)
+}
+
+// Validate validates the configuration of a single extender.
+func (c *Config) Validate() error {
+	allErr := make([]error, 0)
+	if c.Name == "" {
+		allErr = append(allErr, fmt.Errorf("extender name must not be empty"))
+	}
+	u, err := url.Parse(c.URLPrefix)
+	switch {
+	case err != nil:
+		allErr = append(allErr, fmt.Errorf("extender %q has an invalid urlPrefix %q: %w", c.Name, c.URLPrefix, err))
+	case u.Scheme != "http" && u.Scheme != "https":
+		allErr = append(allErr, fmt.Errorf("extender %q has an invalid urlPrefix %q: scheme must be http or https", c.Name, c.URLPrefix))
+	case u.Host == "":
+		allErr = append(allErr, fmt.Errorf("extender %q has an invalid urlPrefix %q: host must not be empty", c.Name, c.URLPrefix))
+	}
+	if c.FilterVerb == "" && c.ScoreVerb == "" {
+		allErr = append(allErr, fmt.Errorf("extender %q must specify at least one of filterVerb and scoreVerb", c.Name))
+	}
+	if c.ScoreVerb != "" && (c.Weight <= 0 || c.Weight > maxWeight) {
+		allErr = append(allErr, fmt.Errorf("extender %q must have a weight in the range of [1, %d] when scoreVerb is set, got %d", c.Name, maxWeight, c.Weight))
+	}
+	if c.Timeout.Duration < 0 {
+		allErr = append(allErr, fmt.Errorf("extender %q must have a non-negative timeout, got %s", c.Name, c.Timeout.Duration))
+	}
+	for _, name := range c.Properties {
+		capacityType, resourceName, isResourceProperty := parseResourcePropertyName(name)
+		switch {
+		case name == "":
+			allErr = append(allErr, fmt.Errorf("extender %q must not have an empty property name", c.Name))
+		case !isResourceProperty:
+			// Any non-resource property can be sent to the extender.
+		case !supportedCapacityTypes[capacityType] || resourceName == "":
+			allErr = append(allErr, fmt.Errorf("extender %q has an invalid resource property name %q", c.Name, name))
+		}
+	}
+	return apiErrors.NewAggregate(allErr)
+}
@@ -0,0 +1,251 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extender
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TestValidate tests the Validate method of Config.
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		name       string
+		config     Config
+		wantErrMsg string
+	}{
+		{
+			name: "valid filter extender",
+			config: Config{
+				Name:       extenderName,
+				URLPrefix:  "https://extender.example.com/fleet",
+				FilterVerb: "filter",
+				Timeout:    metav1.Duration{Duration: 2 * time.Second},
+				Ignorable:  true,
+			},
+		},
+		{
+			name: "valid score extender",
+			config: Config{
+				Name:      extenderName,
+				URLPrefix: "http://contract-tier.fleet-system.svc:8080",
+				ScoreVerb: "prioritize",
+				Weight:    5,
+			},
+		},
+		{
+			name: "empty name",
+			config: Config{
+				URLPrefix:  "https://extender.example.com/fleet",
+				FilterVerb: "filter",
+			},
+			wantErrMsg: "name must not be empty",
+		},
+		{
+			name: "no verbs",
+			config: Config{
+				Name:      extenderName,
+				URLPrefix: "https://extender.example.com/fleet",
+			},
+			wantErrMsg: "at least one of filterVerb and scoreVerb",
+		},
+		{
+			name: "invalid url prefix",
+			config: Config{
+				Name:       extenderName,
+				URLPrefix:  "extender.example.com/fleet",
+				FilterVerb: "filter",
+			},
+			wantErrMsg: "scheme must be http or https",
+		},
+		{
+			name: "score verb without weight",
+			config: Config{
+				Name:      extenderName,
+				URLPrefix: "https://extender.example.com/fleet",
+				ScoreVerb: "prioritize",
+			},
+			wantErrMsg: "must have a weight",
+		},
+		{
+			name: "negative timeout",
+			config: Config{
+				Name:       extenderName,
+				URLPrefix:  "https://extender.example.com/fleet",
+				FilterVerb: "filter",
+				Timeout:    metav1.Duration{Duration: -time.Second},
+			},
+			wantErrMsg: "non-negative timeout",
+		},
+		{
+			name: "valid properties",
+			config: Config{
+				Name:       extenderName,
+				URLPrefix:  "https://extender.example.com/fleet",
+				FilterVerb: "filter",
+				Properties: []string{"kubernetes-fleet.io/node-count", "resources.kubernetes-fleet.io/available-cpu"},
+			},
+		},
+		{
+			name: "invalid resource property",
+			config: Config{
+				Name:       extenderName,
+				URLPrefix:  "https://extender.example.com/fleet",
+				FilterVerb: "filter",
+				Properties: []string{"resources.kubernetes-fleet.io/reserved-cpu"},
+			},
+			wantErrMsg: "invalid resource property name",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.Validate()
+			if tc.wantErrMsg == "" {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want no error", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErrMsg) {
+				t.Fatalf("Validate() = %v, want error containing %s", err, tc.wantErrMsg)
+			}
+		})
+	}
+}
+
+// TestLoadConfiguration tests the LoadConfiguration function.
+func TestLoadConfiguration(t *testing.T) {
+	testCases := []struct {
+		name       string
+		content    string
+		want       *Configuration
+		wantErrMsg string
+	}{
+		{
+			name: "valid configuration",
+			content: `
+extenders:
+- name: data-residency
+  urlPrefix: https://extender.example.com/fleet
+  filterVerb: filter
+  timeout: 2s
+  ignorable: true
+- name: contract-tier
+  urlPrefix: http://contract-tier.fleet-system.svc:8080
+  scoreVerb: prioritize
+  weight: 5
+`,
+			want: &Configuration{
+				Extenders: []Config{
+					{
+						Name:       "data-residency",
+						URLPrefix:  "https://extender.example.com/fleet",
+						FilterVerb: "filter",
+						Timeout:    metav1.Duration{Duration: 2 * time.Second},
+						Ignorable:  true,
+					},
+					{
+						Name:      "contract-tier",
+						URLPrefix: "http://contract-tier.fleet-system.svc:8080",
+						ScoreVerb: "prioritize",
+						Weight:    5,
+					},
+				},
+			},
+		},
+		{
+			name: "unknown field",
+			content: `
+extenders:
+- name: data-residency
+  url: https://extender.example.com/fleet
+`,
+			wantErrMsg: "failed to parse",
+		},
+		{
+			name: "duplicated names",
+			content: `
+extenders:
+- name: data-residency
+  urlPrefix: https://extender.example.com/fleet
+  filterVerb: filter
+- name: data-residency
+  urlPrefix: https://extender.example.com/fleet
+  filterVerb: filter
+`,
+			wantErrMsg: "is duplicated",
+		},
+		{
+			name: "no verbs",
+			content: `
+extenders:
+- name: data-residency
+  urlPrefix: https://extender.example.com/fleet
+`,
+			wantErrMsg: "at least one of filterVerb and scoreVerb",
+		},
+		{
+			name: "invalid url prefix",
+			content: `
+extenders:
+- name: data-residency
+  urlPrefix: extender.example.com/fleet
+  filterVerb: filter
+`,
+			wantErrMsg: "scheme must be http or https",
+		},
+		{
+			name: "score verb without weight",
+			content: `
+extenders:
+- name: contract-tier
+  urlPrefix: https://extender.example.com/fleet
+  scoreVerb: prioritize
+`,
+			wantErrMsg: "must have a weight",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "extenders.yaml")
+			if err := os.WriteFile(path, []byte(tc.content), 0600); err != nil {
+				t.Fatalf("failed to write the configuration file: %v", err)
+			}
+			got, err := LoadConfiguration(path)
+			if tc.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErrMsg) {
+					t.Fatalf("LoadConfiguration() error = %v, want error containing %s", err, tc.wantErrMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadConfiguration() error = %v, want no error", err)
+			}
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("LoadConfiguration() diff (-got, +want): %s", diff)
+			}
+		})
+	}
+}
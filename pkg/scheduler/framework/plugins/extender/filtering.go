@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/klog/v2"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	reasonFmt = "cluster is filtered out by extender %s: %s"
+)
+
+type filterState struct {
+	// failedClusters maps the names of the filtered out clusters to the reasons.
+	failedClusters map[string]string
+}
+
+// PreFilter allows the plugin to connect to the PreFilter extension point in the scheduling
+// framework.
+//
+// The extender is called once per scheduling cycle with all the candidate clusters; the verdicts
+// are saved in the cycle state for the Filter calls.
+func (p *Plugin) PreFilter(
+	ctx context.Context,
+	state framework.CycleStatePluginReadWriter,
+	policy placementv1beta1.PolicySnapshotObj,
+) (status *framework.Status) {
+	if p.config.FilterVerb == "" {
+		// Note that this will also skip the Filter() extension point for the plugin.
+		return framework.NewNonErrorStatus(framework.Skip, p.Name(), "extender does not filter clusters")
+	}
+
+	result := &ExtenderFilterResult{}
+	err := p.call(ctx, p.config.FilterVerb, state.ListClusters(), policy, result)
+	if err == nil && result.Error != "" {
+		err = errors.New(result.Error)
+	}
+	if err != nil {
+		klog.ErrorS(err, "Failed to call the scheduler extender to filter clusters", "extender", p.config.Name, "policySnapshot", klog.KObj(policy), "ignorable", p.config.Ignorable)
+		return p.handleCallError(err, "PreFilter")
+	}
+
+	state.Write(framework.StateKey(p.Name()+filterStateKeySuffix), &filterState{failedClusters: result.FailedClusters})
+	return nil
+}
+
+// Filter allows the plugin to connect to the Filter extension point in the scheduling framework.
+func (p *Plugin) Filter(
+	_ context.Context,
+	state framework.CycleStatePluginReadWriter,
+	policy placementv1beta1.PolicySnapshotObj,
+	cluster *clusterv1beta1.MemberCluster,
+) (status *framework.Status) {
+	fs, err := readPluginState[filterState](state, framework.StateKey(p.Name()+filterStateKeySuffix))
+	if err != nil {
+		// This branch should never be reached, as a state has been set
+		// in the PreFilter stage.
+		return framework.FromError(err, p.Name(), "failed to read plugin state")
+	}
+
+	reason, filtered := fs.failedClusters[cluster.Name]
+	if !filtered {
+		return nil
+	}
+	klog.V(2).InfoS("Cluster is unschedulable, because the extender filters it out", "extender", p.config.Name, "policySnapshot", klog.KObj(policy), "cluster", klog.KObj(cluster), "reason", reason)
+	return framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name(), fmt.Sprintf(reasonFmt, p.config.Name, reason))
+}
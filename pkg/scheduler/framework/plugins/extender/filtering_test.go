@@ -0,0 +1,177 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extender
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	extenderName = "data-residency"
+	policyName   = "test-policy"
+
+	clusterName1 = "bravelion"
+	clusterName2 = "jumpingcat"
+)
+
+var (
+	ignoredStatusFields = cmpopts.IgnoreFields(framework.Status{}, "reasons", "err")
+)
+
+var (
+	policy = &placementv1beta1.ClusterSchedulingPolicySnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: policyName,
+		},
+	}
+	clusters = []clusterv1beta1.MemberCluster{
+		{ObjectMeta: metav1.ObjectMeta{Name: clusterName1}},
+		{ObjectMeta: metav1.ObjectMeta{Name: clusterName2}},
+	}
+)
+
+// newExtenderServer returns a test server which responds to the given verb with the given response
+// after verifying that the request carries the policy snapshot and the wanted clusters.
+func newExtenderServer(t *testing.T, verb string, statusCode int, resp interface{}, delay time.Duration, wantClusters []clusterv1beta1.MemberCluster) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/fleet/"+verb {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		args := &ExtenderArgs{}
+		if err := json.NewDecoder(r.Body).Decode(args); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if len(args.PolicySnapshot.Raw) == 0 {
+			t.Errorf("extender got args without a policy snapshot")
+		}
+		gotClusterNames := make([]string, 0, len(args.Clusters))
+		for _, cluster := range args.Clusters {
+			gotClusterNames = append(gotClusterNames, cluster.Name)
+		}
+		wantClusterNames := make([]string, 0, len(wantClusters))
+		for _, cluster := range wantClusters {
+			wantClusterNames = append(wantClusterNames, cluster.Name)
+		}
+		if diff := cmp.Diff(gotClusterNames, wantClusterNames); diff != "" {
+			t.Errorf("extender got args with clusters diff (-got, +want): %s", diff)
+		}
+		time.Sleep(delay)
+		w.WriteHeader(statusCode)
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+}
+
+// TestPreFilterAndFilter tests the PreFilter and Filter extension points of the plugin.
+func TestPreFilterAndFilter(t *testing.T) {
+	testCases := []struct {
+		name            string
+		filterVerb      string
+		ignorable       bool
+		statusCode      int
+		resp            interface{}
+		delay           time.Duration
+		wantPreFilter   *framework.Status
+		wantFilterByCls map[string]*framework.Status
+	}{
+		{
+			name:          "no filter verb",
+			wantPreFilter: framework.NewNonErrorStatus(framework.Skip, "Extender/"+extenderName),
+		},
+		{
+			name:       "some clusters are filtered out",
+			filterVerb: "filter",
+			statusCode: http.StatusOK,
+			resp: &ExtenderFilterResult{
+				FailedClusters: map[string]string{clusterName2: "data must stay in the EU"},
+			},
+			wantFilterByCls: map[string]*framework.Status{
+				clusterName1: nil,
+				clusterName2: framework.NewNonErrorStatus(framework.ClusterUnschedulable, "Extender/"+extenderName),
+			},
+		},
+		{
+			name:          "extender returns an error",
+			filterVerb:    "filter",
+			statusCode:    http.StatusOK,
+			resp:          &ExtenderFilterResult{Error: "contract tiers unavailable"},
+			wantPreFilter: framework.FromError(errors.New("contract tiers unavailable"), "Extender/"+extenderName),
+		},
+		{
+			name:          "extender returns an unexpected status code",
+			filterVerb:    "filter",
+			statusCode:    http.StatusInternalServerError,
+			resp:          &ExtenderFilterResult{},
+			wantPreFilter: framework.FromError(errors.New("unexpected status code"), "Extender/"+extenderName),
+		},
+		{
+			name:          "ignorable extender times out",
+			filterVerb:    "filter",
+			ignorable:     true,
+			statusCode:    http.StatusOK,
+			resp:          &ExtenderFilterResult{},
+			delay:         500 * time.Millisecond,
+			wantPreFilter: framework.NewNonErrorStatus(framework.Skip, "Extender/"+extenderName),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newExtenderServer(t, tc.filterVerb, tc.statusCode, tc.resp, tc.delay, clusters)
+			defer server.Close()
+			p := New(Config{
+				Name:       extenderName,
+				URLPrefix:  server.URL + "/fleet/",
+				FilterVerb: tc.filterVerb,
+				Timeout:    metav1.Duration{Duration: 100 * time.Millisecond},
+				Ignorable:  tc.ignorable,
+			})
+			ctx := context.Background()
+			state := framework.NewCycleState(clusters, nil)
+
+			status := p.PreFilter(ctx, state, policy)
+			if diff := cmp.Diff(status, tc.wantPreFilter, cmp.AllowUnexported(framework.Status{}), ignoredStatusFields); diff != "" {
+				t.Fatalf("p.PreFilter() status diff (-got, +want): %s", diff)
+			}
+			for i := range clusters {
+				want, ok := tc.wantFilterByCls[clusters[i].Name]
+				if !ok {
+					continue
+				}
+				status := p.Filter(ctx, state, policy, &clusters[i])
+				if diff := cmp.Diff(status, want, cmp.AllowUnexported(framework.Status{}), ignoredStatusFields); diff != "" {
+					t.Errorf("p.Filter(%s) status diff (-got, +want): %s", clusters[i].Name, diff)
+				}
+			}
+		})
+	}
+}
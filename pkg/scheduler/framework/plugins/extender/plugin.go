@@ -0,0 +1,255 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package extender features a scheduler plugin that delegates filtering and scoring to a remote
+// HTTP endpoint, so that placement rules can be implemented out of tree.
+package extender
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/propertyprovider"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	// defaultTimeout is the timeout of a single call to an extender if none is specified.
+	defaultTimeout = 5 * time.Second
+
+	// maxResponseBodySize is the max. size of a response body read from an extender.
+	maxResponseBodySize = 10 << 20
+
+	filterStateKeySuffix = "/filter"
+	scoreStateKeySuffix  = "/score"
+)
+
+// Plugin is the scheduler plugin that calls a scheduler extender to filter and score clusters.
+type Plugin struct {
+	// The name of the plugin.
+	name string
+
+	// The framework handle.
+	handle framework.Handle
+
+	// The configuration of the extender.
+	config Config
+
+	// The HTTP client used to call the extender.
+	client *http.Client
+}
+
+var (
+	// Verify that Plugin can connect to relevant extension points at compile time.
+	//
+	// This plugin leverages the following the extension points:
+	// * PreFilter
+	// * Filter
+	// * PreScore
+	// * Score
+	//
+	// Note that successful connection to any of the extension points implies that the
+	// plugin already implements the Plugin interface.
+	_ framework.PreFilterPlugin = &Plugin{}
+	_ framework.FilterPlugin    = &Plugin{}
+	_ framework.PreScorePlugin  = &Plugin{}
+	_ framework.ScorePlugin     = &Plugin{}
+)
+
+type extenderPluginOptions struct {
+	// The HTTP client used to call the extender.
+	client *http.Client
+}
+
+type Option func(*extenderPluginOptions)
+
+// WithHTTPClient sets the HTTP client used to call the extender.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *extenderPluginOptions) {
+		o.client = client
+	}
+}
+
+// New returns a new Plugin which calls the extender of the given configuration.
+func New(config Config, opts ...Option) Plugin {
+	options := extenderPluginOptions{
+		client: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if config.Timeout.Duration <= 0 {
+		config.Timeout.Duration = defaultTimeout
+	}
+
+	return Plugin{
+		name:   "Extender/" + config.Name,
+		config: config,
+		client: options.client,
+	}
+}
+
+// Name returns the name of the plugin.
+func (p *Plugin) Name() string {
+	return p.name
+}
+
+// SetUpWithFramework sets up this plugin with a scheduler framework.
+func (p *Plugin) SetUpWithFramework(handle framework.Handle) {
+	p.handle = handle
+}
+
+// call sends the policy snapshot and the given clusters to the extender at the given verb, and
+// decodes the response into result.
+func (p *Plugin) call(
+	ctx context.Context,
+	verb string,
+	clusters []clusterv1beta1.MemberCluster,
+	policy placementv1beta1.PolicySnapshotObj,
+	result interface{},
+) error {
+	args := &ExtenderArgs{
+		PolicySnapshot: runtime.RawExtension{Object: policy},
+		Clusters:       p.extenderClustersOf(clusters),
+	}
+	body, err := json.Marshal(args)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the extender args: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.config.Timeout.Duration)
+	defer cancel()
+	url := strings.TrimSuffix(p.config.URLPrefix, "/") + "/" + verb
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create the request to extender %s: %w", p.config.Name, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call extender %s: %w", p.config.Name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("extender %s returned unexpected status code %d at %s", p.config.Name, resp.StatusCode, url)
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBodySize)).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode the response from extender %s: %w", p.config.Name, err)
+	}
+	return nil
+}
+
+// extenderClustersOf returns the candidate clusters in the form sent to the extender, i.e., with only
+// their names, their labels, and the properties listed in the extender configuration.
+func (p *Plugin) extenderClustersOf(clusters []clusterv1beta1.MemberCluster) []ExtenderCluster {
+	extenderClusters := make([]ExtenderCluster, 0, len(clusters))
+	for i := range clusters {
+		cluster := &clusters[i]
+		ec := ExtenderCluster{
+			Name:   cluster.Name,
+			Labels: cluster.Labels,
+		}
+		for _, name := range p.config.Properties {
+			value, found := propertyValueOf(cluster, name)
+			if !found {
+				continue
+			}
+			if ec.Properties == nil {
+				ec.Properties = make(map[string]string, len(p.config.Properties))
+			}
+			ec.Properties[name] = value
+		}
+		extenderClusters = append(extenderClusters, ec)
+	}
+	return extenderClusters
+}
+
+// propertyValueOf returns the value of a property, resource or non-resource, of a cluster, and whether
+// the property is available in the cluster.
+func propertyValueOf(cluster *clusterv1beta1.MemberCluster, name string) (string, bool) {
+	capacityType, resourceName, isResourceProperty := parseResourcePropertyName(name)
+	if !isResourceProperty {
+		v, found := cluster.Status.Properties[clusterv1beta1.PropertyName(name)]
+		return v.Value, found
+	}
+
+	var capacity corev1.ResourceList
+	switch capacityType {
+	case propertyprovider.TotalCapacityName:
+		capacity = cluster.Status.ResourceUsage.Capacity
+	case propertyprovider.AllocatableCapacityName:
+		capacity = cluster.Status.ResourceUsage.Allocatable
+	case propertyprovider.AvailableCapacityName:
+		capacity = cluster.Status.ResourceUsage.Available
+	}
+	q, found := capacity[corev1.ResourceName(resourceName)]
+	if !found {
+		return "", false
+	}
+	return q.String(), true
+}
+
+// parseResourcePropertyName splits the name of a resource property, which is of the format
+// `resources.kubernetes-fleet.io/[CAPACITY_TYPE]-[RESOURCE_NAME]`, into the capacity type and the
+// resource name; it returns false if the name is not that of a resource property.
+func parseResourcePropertyName(name string) (capacityType, resourceName string, isResourceProperty bool) {
+	name, isResourceProperty = strings.CutPrefix(name, propertyprovider.ResourcePropertyNamePrefix)
+	if !isResourceProperty {
+		return "", "", false
+	}
+	capacityType, resourceName, _ = strings.Cut(name, "-")
+	return capacityType, resourceName, true
+}
+
+// handleCallError returns a Skip status if the extender is ignorable, or an InternalError status otherwise.
+func (p *Plugin) handleCallError(err error, stage string) *framework.Status {
+	if p.config.Ignorable {
+		return framework.NewNonErrorStatus(framework.Skip, p.Name(), fmt.Sprintf("ignorable extender failed at %s: %v", stage, err))
+	}
+	return framework.FromError(err, p.Name(), fmt.Sprintf("extender failed at %s", stage))
+}
+
+// readPluginState reads the plugin state stored under the given key from the cycle state.
+func readPluginState[T any](state framework.CycleStatePluginReadWriter, key framework.StateKey) (*T, error) {
+	// Read from the cycle state.
+	val, err := state.Read(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read value from the cycle state: %w", err)
+	}
+
+	// Cast the value to the right type.
+	ps, ok := val.(*T)
+	if !ok {
+		return nil, fmt.Errorf("failed to cast value %v to the right type", val)
+	}
+	if ps == nil {
+		return nil, errors.New("plugin state is nil")
+	}
+	return ps, nil
+}
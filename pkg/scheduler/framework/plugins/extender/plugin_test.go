@@ -0,0 +1,101 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extender
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+)
+
+// TestExtenderClustersOf tests the extenderClustersOf method.
+func TestExtenderClustersOf(t *testing.T) {
+	nodeCountProperty := "kubernetes-fleet.io/node-count"
+	availableCPUProperty := "resources.kubernetes-fleet.io/available-cpu"
+	allocatableMemoryProperty := "resources.kubernetes-fleet.io/allocatable-memory"
+	clusters := []clusterv1beta1.MemberCluster{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        clusterName1,
+				Labels:      map[string]string{"region": "eastus"},
+				Annotations: map[string]string{"note": "not sent"},
+			},
+			Spec: clusterv1beta1.MemberClusterSpec{
+				Identity: rbacv1.Subject{Kind: "ServiceAccount", Name: "fleet-member-agent"},
+			},
+			Status: clusterv1beta1.MemberClusterStatus{
+				Properties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+					clusterv1beta1.PropertyName(nodeCountProperty): {Value: "3"},
+					"kubernetes-fleet.io/per-cpu-core-cost":        {Value: "0.1"},
+				},
+				ResourceUsage: clusterv1beta1.ResourceUsage{
+					Available: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("2500m"),
+					},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: clusterName2},
+		},
+	}
+
+	testCases := []struct {
+		name       string
+		properties []string
+		want       []ExtenderCluster
+	}{
+		{
+			name: "no properties",
+			want: []ExtenderCluster{
+				{Name: clusterName1, Labels: map[string]string{"region": "eastus"}},
+				{Name: clusterName2},
+			},
+		},
+		{
+			name:       "configured properties",
+			properties: []string{nodeCountProperty, availableCPUProperty, allocatableMemoryProperty},
+			want: []ExtenderCluster{
+				{
+					Name:   clusterName1,
+					Labels: map[string]string{"region": "eastus"},
+					Properties: map[string]string{
+						nodeCountProperty:    "3",
+						availableCPUProperty: "2500m",
+					},
+				},
+				{Name: clusterName2},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := New(Config{Name: extenderName, Properties: tc.properties})
+			got := p.extenderClustersOf(clusters)
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("extenderClustersOf() mismatch (-got, +want):\n%s", diff)
+			}
+		})
+	}
+}
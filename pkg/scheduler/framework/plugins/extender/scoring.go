@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/klog/v2"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+type scoreState struct {
+	// scores maps the names of the clusters to the scores returned by the extender.
+	scores map[string]int32
+}
+
+// PreScore allows the plugin to connect to the PreScore extension point in the scheduling
+// framework.
+//
+// The extender is called once per scheduling cycle with the clusters that have passed the Filter
+// plugins; the scores are saved in the cycle state for the Score calls.
+func (p *Plugin) PreScore(
+	ctx context.Context,
+	state framework.CycleStatePluginReadWriter,
+	policy placementv1beta1.PolicySnapshotObj,
+) (status *framework.Status) {
+	if p.config.ScoreVerb == "" {
+		// Note that this will also skip the Score() extension point for the plugin.
+		return framework.NewNonErrorStatus(framework.Skip, p.Name(), "extender does not score clusters")
+	}
+
+	result := &ExtenderScoreResult{}
+	err := p.call(ctx, p.config.ScoreVerb, state.ListPassedClusters(), policy, result)
+	if err == nil && result.Error != "" {
+		err = errors.New(result.Error)
+	}
+	if err == nil {
+		for name, score := range result.Scores {
+			if score < MinExtenderScore || score > MaxExtenderScore {
+				err = fmt.Errorf("extender %s returned score %d for cluster %s, which is out of the range [%d, %d]",
+					p.config.Name, score, name, MinExtenderScore, MaxExtenderScore)
+				break
+			}
+		}
+	}
+	if err != nil {
+		klog.ErrorS(err, "Failed to call the scheduler extender to score clusters", "extender", p.config.Name, "policySnapshot", klog.KObj(policy), "ignorable", p.config.Ignorable)
+		return p.handleCallError(err, "PreScore")
+	}
+
+	state.Write(framework.StateKey(p.Name()+scoreStateKeySuffix), &scoreState{scores: result.Scores})
+	return nil
+}
+
+// Score allows the plugin to connect to the Score extension point in the scheduling framework.
+func (p *Plugin) Score(
+	_ context.Context,
+	state framework.CycleStatePluginReadWriter,
+	_ placementv1beta1.PolicySnapshotObj,
+	cluster *clusterv1beta1.MemberCluster,
+) (score *framework.ClusterScore, status *framework.Status) {
+	ss, err := readPluginState[scoreState](state, framework.StateKey(p.Name()+scoreStateKeySuffix))
+	if err != nil {
+		// This branch should never be reached, as a state has been set
+		// in the PreScore stage.
+		return nil, framework.FromError(err, p.Name(), "failed to read plugin state")
+	}
+
+	return &framework.ClusterScore{ExtenderScore: ss.scores[cluster.Name] * p.config.Weight}, nil
+}
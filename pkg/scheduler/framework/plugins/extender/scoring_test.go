@@ -0,0 +1,109 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extender
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+// TestPreScoreAndScore tests the PreScore and Score extension points of the plugin.
+func TestPreScoreAndScore(t *testing.T) {
+	testCases := []struct {
+		name           string
+		scoreVerb      string
+		ignorable      bool
+		resp           *ExtenderScoreResult
+		wantPreScore   *framework.Status
+		wantScoreByCls map[string]*framework.ClusterScore
+	}{
+		{
+			name:         "no score verb",
+			wantPreScore: framework.NewNonErrorStatus(framework.Skip, "Extender/"+extenderName),
+		},
+		{
+			name:      "weighted scores",
+			scoreVerb: "prioritize",
+			resp: &ExtenderScoreResult{
+				Scores: map[string]int32{clusterName1: 40},
+			},
+			wantScoreByCls: map[string]*framework.ClusterScore{
+				clusterName1: {ExtenderScore: 80},
+				clusterName2: {ExtenderScore: 0},
+			},
+		},
+		{
+			name:      "score out of range",
+			scoreVerb: "prioritize",
+			resp: &ExtenderScoreResult{
+				Scores: map[string]int32{clusterName1: MaxExtenderScore + 1},
+			},
+			wantPreScore: framework.FromError(errors.New("out of range"), "Extender/"+extenderName),
+		},
+		{
+			name:         "ignorable extender returns an error",
+			scoreVerb:    "prioritize",
+			ignorable:    true,
+			resp:         &ExtenderScoreResult{Error: "contract tiers unavailable"},
+			wantPreScore: framework.NewNonErrorStatus(framework.Skip, "Extender/"+extenderName),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Only the clusters that have passed the Filter plugins are sent to the extender.
+			passedClusters := clusters[:1]
+			server := newExtenderServer(t, tc.scoreVerb, http.StatusOK, tc.resp, 0, passedClusters)
+			defer server.Close()
+			p := New(Config{
+				Name:      extenderName,
+				URLPrefix: server.URL + "/fleet",
+				ScoreVerb: tc.scoreVerb,
+				Weight:    2,
+				Ignorable: tc.ignorable,
+			})
+			ctx := context.Background()
+			state := framework.NewCycleState(clusters, nil)
+			state.SetPassedClusters([]*clusterv1beta1.MemberCluster{&passedClusters[0]})
+
+			status := p.PreScore(ctx, state, policy)
+			if diff := cmp.Diff(status, tc.wantPreScore, cmp.AllowUnexported(framework.Status{}), ignoredStatusFields); diff != "" {
+				t.Fatalf("p.PreScore() status diff (-got, +want): %s", diff)
+			}
+			for i := range clusters {
+				want, ok := tc.wantScoreByCls[clusters[i].Name]
+				if !ok {
+					continue
+				}
+				score, status := p.Score(ctx, state, policy, &clusters[i])
+				if !status.IsSuccess() {
+					t.Fatalf("p.Score(%s) status = %v, want success", clusters[i].Name, status)
+				}
+				if diff := cmp.Diff(score, want); diff != "" {
+					t.Errorf("p.Score(%s) diff (-got, +want): %s", clusters[i].Name, diff)
+				}
+			}
+		})
+	}
+}
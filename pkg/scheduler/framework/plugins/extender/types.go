@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extender
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// MaxExtenderScore is the max. score an extender can assign to a cluster.
+	MaxExtenderScore int32 = 100
+	// MinExtenderScore is the min. score an extender can assign to a cluster.
+	MinExtenderScore int32 = 0
+)
+
+// Configuration is the configuration of the scheduler extenders of the default scheduling profile,
+// as read from the file passed with the --scheduler-extender-config-file flag of the hub agent.
+type Configuration struct {
+	// Extenders is the list of scheduler extenders.
+	Extenders []Config `json:"extenders"`
+}
+
+// Config is the configuration of a scheduler extender, i.e., a remote HTTP endpoint which
+// filters and/or scores clusters for a placement.
+type Config struct {
+	// Name is the name of the extender; it must be unique among the extenders of a profile.
+	Name string `json:"name"`
+
+	// URLPrefix is the prefix of the URLs at which the extender is available,
+	// e.g., https://extender.example.com/fleet.
+	URLPrefix string `json:"urlPrefix"`
+
+	// FilterVerb is the verb appended to the URL prefix for the filter call; the extender does
+	// not filter clusters if it is empty.
+	FilterVerb string `json:"filterVerb,omitempty"`
+
+	// ScoreVerb is the verb appended to the URL prefix for the score call; the extender does
+	// not score clusters if it is empty.
+	ScoreVerb string `json:"scoreVerb,omitempty"`
+
+	// Weight is the multiplier applied to the scores returned by the extender; it must be
+	// a positive number if ScoreVerb is set.
+	Weight int32 `json:"weight,omitempty"`
+
+	// Timeout is the timeout of a single call to the extender; it defaults to 5 seconds.
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+
+	// Ignorable specifies whether the scheduler should skip the extender, instead of failing the
+	// scheduling cycle, when the extender is unavailable or returns an error.
+	Ignorable bool `json:"ignorable,omitempty"`
+
+	// Properties is the list of the cluster properties, resource or non-resource, which are sent to the
+	// extender along with the names and the labels of the clusters, e.g.,
+	// resources.kubernetes-fleet.io/available-cpu; no property is sent if it is empty.
+	Properties []string `json:"properties,omitempty"`
+}
+
+// ExtenderArgs is the request body sent to an extender.
+type ExtenderArgs struct {
+	// PolicySnapshot is the scheduling policy snapshot being scheduled, i.e., a
+	// ClusterSchedulingPolicySnapshot or a SchedulingPolicySnapshot.
+	PolicySnapshot runtime.RawExtension `json:"policySnapshot"`
+
+	// Clusters is the list of candidate clusters.
+	Clusters []ExtenderCluster `json:"clusters"`
+}
+
+// ExtenderCluster is a candidate cluster as sent to an extender; only the information which the extender
+// needs is sent, instead of the full MemberCluster object.
+type ExtenderCluster struct {
+	// Name is the name of the cluster.
+	Name string `json:"name"`
+
+	// Labels are the labels of the cluster.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Properties maps the names of the properties listed in the extender configuration to their values
+	// in the cluster; the properties which are not available in the cluster are left out.
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+// ExtenderFilterResult is the response body returned by an extender for a filter call.
+type ExtenderFilterResult struct {
+	// FailedClusters maps the names of the clusters which the placement cannot be bound to
+	// to the reasons; clusters absent from the map pass the filter.
+	FailedClusters map[string]string `json:"failedClusters,omitempty"`
+
+	// Error is the error the extender has encountered, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// ExtenderScoreResult is the response body returned by an extender for a score call.
+type ExtenderScoreResult struct {
+	// Scores maps the names of the clusters to their scores, which must be in the range of
+	// [MinExtenderScore, MaxExtenderScore]; clusters absent from the map are scored 0.
+	Scores map[string]int32 `json:"scores,omitempty"`
+
+	// Error is the error the extender has encountered, if any.
+	Error string `json:"error,omitempty"`
+}
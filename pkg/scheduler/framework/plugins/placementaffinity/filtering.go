@@ -0,0 +1,65 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package placementaffinity
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	requiredAffinityViolationReasonTemplate     = "cluster does not host any placement selected by required placement affinity term %d"
+	requiredAntiAffinityViolationReasonTemplate = "cluster hosts placements %s selected by required placement anti-affinity terms"
+)
+
+// Filter allows the plugin to connect to the Filter extension point in the scheduling framework.
+func (p *Plugin) Filter(
+	_ context.Context,
+	state framework.CycleStatePluginReadWriter,
+	_ placementv1beta1.PolicySnapshotObj,
+	cluster *clusterv1beta1.MemberCluster,
+) (status *framework.Status) {
+	// Read the plugin state.
+	ps, err := p.readPluginState(state)
+	if err != nil {
+		// This branch should never be reached, as for any policy with present placement
+		// affinity terms, a common plugin state has been set at the PreFilter extension point.
+		return framework.FromError(err, p.Name(), "failed to read plugin state")
+	}
+
+	// The state is safe for concurrent reads.
+	var reasons []string
+	for i, clusters := range ps.requiredAffinityClusters {
+		if !clusters.Has(cluster.Name) {
+			reasons = append(reasons, fmt.Sprintf(requiredAffinityViolationReasonTemplate, i))
+		}
+	}
+	if placements, ok := ps.requiredAntiAffinityPlacements[cluster.Name]; ok {
+		reasons = append(reasons, fmt.Sprintf(requiredAntiAffinityViolationReasonTemplate, strings.Join(placements, ", ")))
+	}
+	if len(reasons) > 0 {
+		return framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name(), reasons...)
+	}
+
+	// All done.
+	return nil
+}
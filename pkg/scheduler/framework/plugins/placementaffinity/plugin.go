@@ -0,0 +1,197 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package placementaffinity features a scheduler plugin that enforces the placement affinity
+// and anti-affinity terms (if any) defined on a placement, i.e., co-locates the placement with,
+// or keeps it apart from, other placements.
+package placementaffinity
+
+import (
+	"context"
+	"fmt"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	// defaultPluginName is the default name for the placement affinity plugin.
+	defaultPluginName = "PlacementAffinity"
+)
+
+// Plugin is the scheduler plugin that enforces the placement affinity and anti-affinity terms
+// (if any) defined on a placement.
+type Plugin struct {
+	// The name of the plugin.
+	name string
+
+	// The framework handle.
+	handle framework.Handle
+}
+
+var (
+	// Verify that Plugin can connect to relevant extension points
+	// at compile time.
+	//
+	// This plugin leverages the following the extension points:
+	// * PreFilter
+	// * Filter
+	// * PreScore
+	// * Score
+	//
+	// Note that successful connection to any of the extension points implies that the
+	// plugin already implements the Plugin interface.
+	_ framework.PreFilterPlugin = &Plugin{}
+	_ framework.FilterPlugin    = &Plugin{}
+	_ framework.PreScorePlugin  = &Plugin{}
+	_ framework.ScorePlugin     = &Plugin{}
+)
+
+type placementAffinityPluginOptions struct {
+	// The name of the plugin.
+	name string
+}
+
+type Option func(*placementAffinityPluginOptions)
+
+var defaultPlacementAffinityPluginOptions = placementAffinityPluginOptions{
+	name: defaultPluginName,
+}
+
+// WithName sets the name of the plugin.
+func WithName(name string) Option {
+	return func(o *placementAffinityPluginOptions) {
+		o.name = name
+	}
+}
+
+// New returns a new Plugin.
+func New(opts ...Option) Plugin {
+	options := defaultPlacementAffinityPluginOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return Plugin{
+		name: options.name,
+	}
+}
+
+// Name returns the name of the plugin.
+func (p *Plugin) Name() string {
+	return p.name
+}
+
+// SetUpWithFramework sets up this plugin with a scheduler framework.
+func (p *Plugin) SetUpWithFramework(handle framework.Handle) {
+	p.handle = handle
+
+	// This plugin does not need to set up any informer.
+}
+
+// readPluginState reads the plugin state from the cycle state.
+func (p *Plugin) readPluginState(state framework.CycleStatePluginReadWriter) (*pluginState, error) {
+	// Read from the cycle state.
+	val, err := state.Read(framework.StateKey(p.Name()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read value from the cycle state: %w", err)
+	}
+
+	// Cast the value to the right type.
+	ps, ok := val.(*pluginState)
+	if !ok {
+		return nil, fmt.Errorf("failed to cast value %v to the right type", val)
+	}
+	return ps, nil
+}
+
+// PreFilter allows the plugin to connect to the PreFilter extension point in the scheduling
+// framework.
+//
+// Note that the scheduler will not run this extension point in parallel.
+func (p *Plugin) PreFilter(
+	ctx context.Context,
+	state framework.CycleStatePluginReadWriter,
+	policy placementv1beta1.PolicySnapshotObj,
+) (status *framework.Status) {
+	affinity, antiAffinity := placementAffinitiesOf(policy)
+	if affinity == nil && antiAffinity == nil {
+		// There are no placement affinity terms to enforce; skip.
+		//
+		// Note that this will lead the scheduler to skip this plugin in the next stage
+		// (Filter).
+		return framework.NewNonErrorStatus(framework.Skip, p.Name(), "no placement affinity term is present")
+	}
+
+	// Prepare some common states for future use. This helps avoid the cost of repeatedly
+	// calculating the same states at each extension point.
+	ps, err := p.preparePluginState(ctx, policy, affinity, antiAffinity)
+	if err != nil {
+		return framework.FromError(err, p.Name(), "failed to prepare plugin state")
+	}
+
+	// Save the plugin state.
+	state.Write(framework.StateKey(p.Name()), ps)
+
+	if len(ps.requiredAffinityClusters) == 0 && len(ps.requiredAntiAffinityPlacements) == 0 {
+		// There are no required placement affinity terms to enforce; skip.
+		//
+		// Note that this will lead the scheduler to skip this plugin in the next stage
+		// (Filter).
+		return framework.NewNonErrorStatus(framework.Skip, p.Name(), "no required placement affinity term is present")
+	}
+
+	// All done.
+	return nil
+}
+
+// PreScore allows the plugin to connect to the PreScore extension point in the scheduling
+// framework.
+func (p *Plugin) PreScore(
+	_ context.Context,
+	state framework.CycleStatePluginReadWriter,
+	policy placementv1beta1.PolicySnapshotObj,
+) (status *framework.Status) {
+	affinity, antiAffinity := placementAffinitiesOf(policy)
+	if (affinity == nil || len(affinity.PreferredDuringSchedulingIgnoredDuringExecution) == 0) &&
+		(antiAffinity == nil || len(antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution) == 0) {
+		// There are no preferred placement affinity terms to enforce; skip.
+		//
+		// Note that this will lead the scheduler to skip this plugin in the next stage
+		// (Score).
+		return framework.NewNonErrorStatus(framework.Skip, p.Name(), "no preferred placement affinity term is present")
+	}
+
+	// The plugin state has been prepared at the PreFilter extension point; verify that it is
+	// present.
+	if _, err := p.readPluginState(state); err != nil {
+		// This branch should never be reached, as for any policy with present placement
+		// affinity terms, a common plugin state has been set at the PreFilter extension point.
+		return framework.FromError(err, p.Name(), "failed to read plugin state")
+	}
+
+	// All done.
+	return nil
+}
+
+// placementAffinitiesOf returns the placement affinity and anti-affinity (if any) of a policy.
+func placementAffinitiesOf(policy placementv1beta1.PolicySnapshotObj) (*placementv1beta1.PlacementAffinity, *placementv1beta1.PlacementAntiAffinity) {
+	spec := policy.GetPolicySnapshotSpec()
+	if spec.Policy == nil || spec.Policy.Affinity == nil {
+		return nil, nil
+	}
+	return spec.Policy.Affinity.PlacementAffinity, spec.Policy.Affinity.PlacementAntiAffinity
+}
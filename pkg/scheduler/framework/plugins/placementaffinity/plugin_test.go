@@ -0,0 +1,267 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package placementaffinity
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/clustereligibilitychecker"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	clusterName1 = "bravelion"
+	clusterName2 = "jumpingcat"
+	clusterName3 = "smartfish"
+
+	crpName    = "web"
+	policyName = "web-1"
+)
+
+var (
+	ignoredStatusFields = cmpopts.IgnoreFields(framework.Status{}, "reasons", "err")
+)
+
+// Mock framework.Handle interface for set up the plugin.
+type MockHandle struct {
+	client client.Client
+}
+
+var (
+	_ framework.Handle = &MockHandle{}
+)
+
+func (mh *MockHandle) Client() client.Client               { return mh.client }
+func (mh *MockHandle) Manager() ctrl.Manager               { return nil }
+func (mh *MockHandle) UncachedReader() client.Reader       { return mh.client }
+func (mh *MockHandle) EventRecorder() record.EventRecorder { return nil }
+func (mh *MockHandle) ClusterEligibilityChecker() *clustereligibilitychecker.ClusterEligibilityChecker {
+	return nil
+}
+
+func newCRP(name string, labels map[string]string) *placementv1beta1.ClusterResourcePlacement {
+	return &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+	}
+}
+
+func newBinding(name, placementName, clusterName string, state placementv1beta1.BindingState) *placementv1beta1.ClusterResourceBinding {
+	return &placementv1beta1.ClusterResourceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				placementv1beta1.PlacementTrackingLabel: placementName,
+			},
+		},
+		Spec: placementv1beta1.ResourceBindingSpec{
+			State:         state,
+			TargetCluster: clusterName,
+		},
+	}
+}
+
+func selectorTerm(key, value string) placementv1beta1.PlacementAffinityTerm {
+	return placementv1beta1.PlacementAffinityTerm{
+		PlacementSelector: metav1.LabelSelector{
+			MatchLabels: map[string]string{key: value},
+		},
+	}
+}
+
+// TestPlugin tests the PreFilter, Filter, PreScore, and Score methods.
+func TestPlugin(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add placement v1beta1 scheme: %v", err)
+	}
+	objs := []client.Object{
+		newCRP(crpName, map[string]string{"app": "web"}),
+		newCRP("db", map[string]string{"app": "database"}),
+		newCRP("cp-a", map[string]string{"tier": "control-plane"}),
+		newCRP("cp-b", map[string]string{"tier": "control-plane"}),
+		newBinding("web-bravelion", crpName, clusterName1, placementv1beta1.BindingStateBound),
+		newBinding("db-bravelion", "db", clusterName1, placementv1beta1.BindingStateBound),
+		newBinding("cp-a-jumpingcat", "cp-a", clusterName2, placementv1beta1.BindingStateScheduled),
+		newBinding("cp-b-jumpingcat", "cp-b", clusterName2, placementv1beta1.BindingStateBound),
+		newBinding("cp-b-smartfish", "cp-b", clusterName3, placementv1beta1.BindingStateUnscheduled),
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	clusters := []*clusterv1beta1.MemberCluster{
+		{ObjectMeta: metav1.ObjectMeta{Name: clusterName1}},
+		{ObjectMeta: metav1.ObjectMeta{Name: clusterName2}},
+		{ObjectMeta: metav1.ObjectMeta{Name: clusterName3}},
+	}
+
+	testCases := []struct {
+		name              string
+		affinity          *placementv1beta1.Affinity
+		wantPreFilter     *framework.Status
+		wantFilter        map[string]*framework.Status
+		wantFilterReasons map[string][]string
+		wantPreScore      *framework.Status
+		wantScores        map[string]int32
+	}{
+		{
+			name:          "no placement affinity",
+			affinity:      &placementv1beta1.Affinity{},
+			wantPreFilter: framework.NewNonErrorStatus(framework.Skip, defaultPluginName),
+			wantPreScore:  framework.NewNonErrorStatus(framework.Skip, defaultPluginName),
+		},
+		{
+			name: "required affinity",
+			affinity: &placementv1beta1.Affinity{
+				PlacementAffinity: &placementv1beta1.PlacementAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: []placementv1beta1.PlacementAffinityTerm{
+						selectorTerm("app", "database"),
+					},
+				},
+			},
+			wantFilter: map[string]*framework.Status{
+				clusterName2: framework.NewNonErrorStatus(framework.ClusterUnschedulable, defaultPluginName),
+				clusterName3: framework.NewNonErrorStatus(framework.ClusterUnschedulable, defaultPluginName),
+			},
+			wantFilterReasons: map[string][]string{
+				clusterName2: {"cluster does not host any placement selected by required placement affinity term 0"},
+				clusterName3: {"cluster does not host any placement selected by required placement affinity term 0"},
+			},
+			wantPreScore: framework.NewNonErrorStatus(framework.Skip, defaultPluginName),
+		},
+		{
+			name: "required anti-affinity",
+			affinity: &placementv1beta1.Affinity{
+				PlacementAntiAffinity: &placementv1beta1.PlacementAntiAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: []placementv1beta1.PlacementAffinityTerm{
+						selectorTerm("tier", "control-plane"),
+					},
+				},
+			},
+			wantFilter: map[string]*framework.Status{
+				clusterName2: framework.NewNonErrorStatus(framework.ClusterUnschedulable, defaultPluginName),
+			},
+			wantFilterReasons: map[string][]string{
+				clusterName2: {"cluster hosts placements cp-a, cp-b selected by required placement anti-affinity terms"},
+			},
+			wantPreScore: framework.NewNonErrorStatus(framework.Skip, defaultPluginName),
+		},
+		{
+			name: "required anti-affinity which selects the placement itself",
+			affinity: &placementv1beta1.Affinity{
+				PlacementAntiAffinity: &placementv1beta1.PlacementAntiAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: []placementv1beta1.PlacementAffinityTerm{
+						selectorTerm("app", "web"),
+					},
+				},
+			},
+			wantPreFilter: framework.NewNonErrorStatus(framework.Skip, defaultPluginName),
+			wantPreScore:  framework.NewNonErrorStatus(framework.Skip, defaultPluginName),
+		},
+		{
+			name: "preferred affinity and anti-affinity",
+			affinity: &placementv1beta1.Affinity{
+				PlacementAffinity: &placementv1beta1.PlacementAffinity{
+					PreferredDuringSchedulingIgnoredDuringExecution: []placementv1beta1.WeightedPlacementAffinityTerm{
+						{Weight: 20, PlacementAffinityTerm: selectorTerm("app", "database")},
+					},
+				},
+				PlacementAntiAffinity: &placementv1beta1.PlacementAntiAffinity{
+					PreferredDuringSchedulingIgnoredDuringExecution: []placementv1beta1.WeightedPlacementAffinityTerm{
+						{Weight: 30, PlacementAffinityTerm: selectorTerm("tier", "control-plane")},
+					},
+				},
+			},
+			wantPreFilter: framework.NewNonErrorStatus(framework.Skip, defaultPluginName),
+			wantScores: map[string]int32{
+				clusterName1: 20,
+				clusterName2: -30,
+				clusterName3: 0,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			p := New()
+			p.SetUpWithFramework(&MockHandle{client: fakeClient})
+			policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: policyName,
+					Labels: map[string]string{
+						placementv1beta1.PlacementTrackingLabel: crpName,
+					},
+				},
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType: placementv1beta1.PickNPlacementType,
+						Affinity:      tc.affinity,
+					},
+				},
+			}
+			state := framework.NewCycleState(nil, nil)
+
+			status := p.PreFilter(ctx, state, policy)
+			if diff := cmp.Diff(status, tc.wantPreFilter, cmp.AllowUnexported(framework.Status{}), ignoredStatusFields); diff != "" {
+				t.Fatalf("PreFilter() status diff (-got, +want): %s", diff)
+			}
+			if status.IsSuccess() {
+				for _, cluster := range clusters {
+					status := p.Filter(ctx, state, policy, cluster)
+					if diff := cmp.Diff(status, tc.wantFilter[cluster.Name], cmp.AllowUnexported(framework.Status{}), ignoredStatusFields); diff != "" {
+						t.Errorf("Filter(%s) status diff (-got, +want): %s", cluster.Name, diff)
+					}
+					if status != nil {
+						if diff := cmp.Diff(status.Reasons(), tc.wantFilterReasons[cluster.Name]); diff != "" {
+							t.Errorf("Filter(%s) reasons diff (-got, +want): %s", cluster.Name, diff)
+						}
+					}
+				}
+			}
+
+			status = p.PreScore(ctx, state, policy)
+			if diff := cmp.Diff(status, tc.wantPreScore, cmp.AllowUnexported(framework.Status{}), ignoredStatusFields); diff != "" {
+				t.Fatalf("PreScore() status diff (-got, +want): %s", diff)
+			}
+			if status.IsSuccess() {
+				for _, cluster := range clusters {
+					score, status := p.Score(ctx, state, policy, cluster)
+					if status != nil {
+						t.Fatalf("Score(%s) status = %v, want nil", cluster.Name, status)
+					}
+					want := &framework.ClusterScore{AffinityScore: tc.wantScores[cluster.Name]}
+					if diff := cmp.Diff(score, want); diff != "" {
+						t.Errorf("Score(%s) diff (-got, +want): %s", cluster.Name, diff)
+					}
+				}
+			}
+		})
+	}
+}
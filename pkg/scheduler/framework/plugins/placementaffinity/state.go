@@ -0,0 +1,239 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package placementaffinity
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+// pluginState is the state which the placement affinity plugin prepares at the PreFilter stage
+// and shares with the Filter and Score stages.
+//
+// The state is read-only once prepared and is thus safe for concurrent reads.
+type pluginState struct {
+	// requiredAffinityClusters holds, for each required placement affinity term, the names of
+	// the clusters which host a placement selected by the term.
+	requiredAffinityClusters []sets.Set[string]
+
+	// requiredAntiAffinityPlacements maps the names of the clusters to the names of the
+	// placements selected by any of the required placement anti-affinity terms which the
+	// clusters host.
+	requiredAntiAffinityPlacements map[string][]string
+
+	// scores maps the names of the clusters to the scores derived from the preferred placement
+	// affinity and anti-affinity terms.
+	scores map[string]int32
+}
+
+// preparePluginState prepares the plugin state for a scheduling cycle.
+func (p *Plugin) preparePluginState(
+	ctx context.Context,
+	policy placementv1beta1.PolicySnapshotObj,
+	affinity *placementv1beta1.PlacementAffinity,
+	antiAffinity *placementv1beta1.PlacementAntiAffinity,
+) (*pluginState, error) {
+	placementName := policy.GetLabels()[placementv1beta1.PlacementTrackingLabel]
+	if placementName == "" {
+		return nil, fmt.Errorf("policy snapshot %s does not have the %s label", policy.GetName(), placementv1beta1.PlacementTrackingLabel)
+	}
+	placements, err := p.listPlacements(ctx, policy.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+
+	r := &hostResolver{
+		client:        p.handle.Client(),
+		namespace:     policy.GetNamespace(),
+		placementName: placementName,
+		placements:    placements,
+		hosts:         make(map[string]sets.Set[string]),
+	}
+	ps := &pluginState{
+		requiredAntiAffinityPlacements: make(map[string][]string),
+		scores:                         make(map[string]int32),
+	}
+
+	if affinity != nil {
+		for i := range affinity.RequiredDuringSchedulingIgnoredDuringExecution {
+			clusters, err := r.clustersHostingAny(ctx, &affinity.RequiredDuringSchedulingIgnoredDuringExecution[i])
+			if err != nil {
+				return nil, err
+			}
+			ps.requiredAffinityClusters = append(ps.requiredAffinityClusters, clusters)
+		}
+		for i := range affinity.PreferredDuringSchedulingIgnoredDuringExecution {
+			term := &affinity.PreferredDuringSchedulingIgnoredDuringExecution[i]
+			clusters, err := r.clustersHostingAny(ctx, &term.PlacementAffinityTerm)
+			if err != nil {
+				return nil, err
+			}
+			for cluster := range clusters {
+				ps.scores[cluster] += term.Weight
+			}
+		}
+	}
+
+	if antiAffinity != nil {
+		antiAffinityPlacements := make(map[string]sets.Set[string])
+		for i := range antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+			selected, err := r.selectPlacements(&antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution[i])
+			if err != nil {
+				return nil, err
+			}
+			for _, name := range selected {
+				clusters, err := r.clustersHosting(ctx, name)
+				if err != nil {
+					return nil, err
+				}
+				for cluster := range clusters {
+					if _, ok := antiAffinityPlacements[cluster]; !ok {
+						antiAffinityPlacements[cluster] = sets.New[string]()
+					}
+					antiAffinityPlacements[cluster].Insert(name)
+				}
+			}
+		}
+		for cluster, names := range antiAffinityPlacements {
+			ps.requiredAntiAffinityPlacements[cluster] = sets.List(names)
+		}
+		for i := range antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+			term := &antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution[i]
+			clusters, err := r.clustersHostingAny(ctx, &term.PlacementAffinityTerm)
+			if err != nil {
+				return nil, err
+			}
+			for cluster := range clusters {
+				ps.scores[cluster] -= term.Weight
+			}
+		}
+	}
+	return ps, nil
+}
+
+// listPlacements lists the placements which the placement affinity terms of a placement in the
+// given namespace might select, i.e., all the ClusterResourcePlacements for a
+// ClusterResourcePlacement, or all the ResourcePlacements in the same namespace for a
+// ResourcePlacement.
+func (p *Plugin) listPlacements(ctx context.Context, namespace string) ([]placementv1beta1.PlacementObj, error) {
+	var placementList placementv1beta1.PlacementObjList = &placementv1beta1.ClusterResourcePlacementList{}
+	var listOptions []client.ListOption
+	if namespace != "" {
+		placementList = &placementv1beta1.ResourcePlacementList{}
+		listOptions = append(listOptions, client.InNamespace(namespace))
+	}
+	if err := p.handle.Client().List(ctx, placementList, listOptions...); err != nil {
+		return nil, controller.NewAPIServerError(true, err)
+	}
+	placements := placementList.GetPlacementObjs()
+	// Sort the placements by their names for stable results.
+	sort.Slice(placements, func(i, j int) bool {
+		return placements[i].GetName() < placements[j].GetName()
+	})
+	return placements, nil
+}
+
+// hostResolver resolves the clusters which host the placements selected by placement affinity
+// terms; the clusters of each placement are cached for the duration of a scheduling cycle.
+type hostResolver struct {
+	// client is the (cached) client for listing bindings.
+	client client.Reader
+
+	// namespace is the namespace of the placement being scheduled.
+	namespace string
+	// placementName is the name of the placement being scheduled.
+	placementName string
+	// placements is the list of placements which the terms might select.
+	placements []placementv1beta1.PlacementObj
+
+	// hosts maps the names of the placements to the names of the clusters which host them.
+	hosts map[string]sets.Set[string]
+}
+
+// selectPlacements returns the names of the placements selected by a placement affinity term,
+// excluding the placement being scheduled.
+func (r *hostResolver) selectPlacements(term *placementv1beta1.PlacementAffinityTerm) ([]string, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&term.PlacementSelector)
+	if err != nil {
+		return nil, controller.NewUnexpectedBehaviorError(fmt.Errorf("failed to parse the placement selector: %w", err))
+	}
+	var selected []string
+	for _, placement := range r.placements {
+		if placement.GetName() == r.placementName {
+			// A placement never selects itself.
+			continue
+		}
+		if selector.Matches(labels.Set(placement.GetLabels())) {
+			selected = append(selected, placement.GetName())
+		}
+	}
+	return selected, nil
+}
+
+// clustersHosting returns the names of the clusters which a placement has been scheduled or
+// bound to.
+func (r *hostResolver) clustersHosting(ctx context.Context, placementName string) (sets.Set[string], error) {
+	if clusters, ok := r.hosts[placementName]; ok {
+		return clusters, nil
+	}
+
+	placementKey := types.NamespacedName{Namespace: r.namespace, Name: placementName}
+	bindings, err := controller.ListBindingsFromKey(ctx, r.client, placementKey, true)
+	if err != nil {
+		return nil, err
+	}
+	clusters := sets.New[string]()
+	for _, binding := range bindings {
+		if binding.GetDeletionTimestamp() != nil {
+			continue
+		}
+		spec := binding.GetBindingSpec()
+		if spec.State == placementv1beta1.BindingStateScheduled || spec.State == placementv1beta1.BindingStateBound {
+			clusters.Insert(spec.TargetCluster)
+		}
+	}
+	r.hosts[placementName] = clusters
+	return clusters, nil
+}
+
+// clustersHostingAny returns the names of the clusters which host any of the placements
+// selected by a placement affinity term.
+func (r *hostResolver) clustersHostingAny(ctx context.Context, term *placementv1beta1.PlacementAffinityTerm) (sets.Set[string], error) {
+	selected, err := r.selectPlacements(term)
+	if err != nil {
+		return nil, err
+	}
+	clusters := sets.New[string]()
+	for _, name := range selected {
+		hosts, err := r.clustersHosting(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		clusters = clusters.Union(hosts)
+	}
+	return clusters, nil
+}
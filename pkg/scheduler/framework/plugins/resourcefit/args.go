@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcefit
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// maxResourceWeight is the max. weight of a resource in the score of the plugin.
+	maxResourceWeight int64 = 100
+)
+
+// Args is the arguments of the resource fit plugin, which can be set in the plugin
+// configuration of a scheduling profile.
+type Args struct {
+	// Resources are the resources the plugin considers when scoring a cluster, with their
+	// weights. If unset, all the requested resources are considered with the same weight.
+	//
+	// Note that the plugin always checks all the requested resources when filtering clusters.
+	Resources []ResourceWeight `json:"resources,omitempty"`
+}
+
+// ResourceWeight is the weight of a resource in the score of the plugin.
+type ResourceWeight struct {
+	// Name is the name of the resource, e.g., cpu, memory, or nvidia.com/gpu.
+	Name corev1.ResourceName `json:"name"`
+
+	// Weight is the weight of the resource, in the range of [1, 100].
+	Weight int64 `json:"weight"`
+}
+
+// DecodeArgs decodes and validates the arguments of the plugin; unknown fields are rejected.
+func DecodeArgs(raw []byte) (Args, error) {
+	args := Args{}
+	if err := yaml.UnmarshalStrict(raw, &args); err != nil {
+		return Args{}, fmt.Errorf("failed to decode the arguments of plugin %q: %w", defaultPluginName, err)
+	}
+	if err := args.Validate(); err != nil {
+		return Args{}, fmt.Errorf("invalid arguments of plugin %q: %w", defaultPluginName, err)
+	}
+	return args, nil
+}
+
+// Validate validates the arguments of the plugin.
+func (a *Args) Validate() error {
+	allErr := make([]error, 0)
+	seen := make(map[corev1.ResourceName]bool, len(a.Resources))
+	for _, r := range a.Resources {
+		if r.Name == "" {
+			allErr = append(allErr, fmt.Errorf("resource name must not be empty"))
+		}
+		if seen[r.Name] {
+			allErr = append(allErr, fmt.Errorf("resource %q is duplicated", r.Name))
+		}
+		seen[r.Name] = true
+		if r.Weight <= 0 || r.Weight > maxResourceWeight {
+			allErr = append(allErr, fmt.Errorf("resource %q must have a weight in the range of [1, %d], got %d", r.Name, maxResourceWeight, r.Weight))
+		}
+	}
+	return apiErrors.NewAggregate(allErr)
+}
+
+// resourceWeights returns the weights of the resources keyed by their names, or nil if all the
+// requested resources are considered with the same weight.
+func (a *Args) resourceWeights() map[corev1.ResourceName]int64 {
+	if len(a.Resources) == 0 {
+		return nil
+	}
+	weights := make(map[corev1.ResourceName]int64, len(a.Resources))
+	for _, r := range a.Resources {
+		weights[r.Name] = r.Weight
+	}
+	return weights
+}
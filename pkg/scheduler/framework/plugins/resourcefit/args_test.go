@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcefit
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// TestDecodeArgs tests the DecodeArgs function.
+func TestDecodeArgs(t *testing.T) {
+	testCases := []struct {
+		name    string
+		raw     string
+		want    Args
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			raw:  `{}`,
+			want: Args{},
+		},
+		{
+			name: "resource weights",
+			raw:  `{"resources":[{"name":"cpu","weight":1},{"name":"memory","weight":2}]}`,
+			want: Args{Resources: []ResourceWeight{
+				{Name: corev1.ResourceCPU, Weight: 1},
+				{Name: corev1.ResourceMemory, Weight: 2},
+			}},
+		},
+		{
+			name:    "unknown field",
+			raw:     `{"scoringStrategy":"MostAllocated"}`,
+			wantErr: true,
+		},
+		{
+			name:    "empty resource name",
+			raw:     `{"resources":[{"weight":1}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "duplicated resource",
+			raw:     `{"resources":[{"name":"cpu","weight":1},{"name":"cpu","weight":2}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "weight out of range",
+			raw:     `{"resources":[{"name":"cpu","weight":101}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "missing weight",
+			raw:     `{"resources":[{"name":"cpu"}]}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := DecodeArgs([]byte(tc.raw))
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("DecodeArgs() = %v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DecodeArgs() = %v, want no error", err)
+			}
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("DecodeArgs() diff (-got, +want): %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcefit
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	insufficientResourceReasonTemplate = "insufficient %s: requested %s, available %s, reserved by other placements %s"
+)
+
+// Filter allows the plugin to connect to the Filter extension point in the scheduling framework.
+func (p *Plugin) Filter(
+	_ context.Context,
+	state framework.CycleStatePluginReadWriter,
+	_ placementv1beta1.PolicySnapshotObj,
+	cluster *clusterv1beta1.MemberCluster,
+) (status *framework.Status) {
+	if state.HasScheduledOrBoundBindingFor(cluster.Name) {
+		// The workloads of the placement have already been accounted for on this cluster.
+		return nil
+	}
+
+	// Read the plugin state.
+	ps, err := p.readPluginState(state)
+	if err != nil {
+		// This branch should never be reached, as the plugin state has been set at the
+		// PreFilter extension point.
+		return framework.FromError(err, p.Name(), "failed to read plugin state")
+	}
+
+	// The state is safe for concurrent reads.
+	reasons := insufficientResources(ps.requests, cluster.Status.ResourceUsage.Available, ps.reserved[cluster.Name])
+	if len(reasons) > 0 {
+		return framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name(), reasons...)
+	}
+
+	// All done.
+	return nil
+}
+
+// insufficientResources returns the reasons why the requested resources do not fit into the
+// available resources, minus the reserved ones.
+//
+// Resources which the cluster does not report are considered to be sufficient, as the
+// scheduler has no knowledge of them.
+func insufficientResources(requests, available, reserved corev1.ResourceList) []string {
+	names := make([]string, 0, len(requests))
+	for name := range requests {
+		names = append(names, string(name))
+	}
+	// Sort the resource names for stable reasons.
+	sort.Strings(names)
+
+	var reasons []string
+	for _, name := range names {
+		resourceName := corev1.ResourceName(name)
+		requested := requests[resourceName]
+		availableQuantity, ok := available[resourceName]
+		if !ok {
+			continue
+		}
+		reservedQuantity := reserved[resourceName]
+		free := availableQuantity.DeepCopy()
+		free.Sub(reservedQuantity)
+		if requested.Cmp(free) > 0 {
+			reasons = append(reasons, fmt.Sprintf(insufficientResourceReasonTemplate,
+				name, requested.String(), availableQuantity.String(), reservedQuantity.String()))
+		}
+	}
+	return reasons
+}
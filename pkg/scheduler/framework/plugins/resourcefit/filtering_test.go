@@ -0,0 +1,161 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcefit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	clusterName1 = "bravelion"
+	clusterName2 = "jumpingcat"
+
+	policyName = "test-policy"
+)
+
+var (
+	ignoredStatusFields = cmpopts.IgnoreFields(framework.Status{}, "reasons", "err")
+)
+
+func newCluster(name, allocatableCPU, availableCPU string) *clusterv1beta1.MemberCluster {
+	return &clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: clusterv1beta1.MemberClusterStatus{
+			ResourceUsage: clusterv1beta1.ResourceUsage{
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse(allocatableCPU),
+				},
+				Available: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse(availableCPU),
+				},
+			},
+		},
+	}
+}
+
+// TestFilter tests the Filter method.
+func TestFilter(t *testing.T) {
+	policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: policyName,
+		},
+	}
+	requests := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("2"),
+		corev1.ResourceMemory: resource.MustParse("4Gi"),
+	}
+
+	testCases := []struct {
+		name                   string
+		ps                     *pluginState
+		cluster                *clusterv1beta1.MemberCluster
+		scheduledOrBoundOnSame bool
+		want                   *framework.Status
+		wantReasons            []string
+	}{
+		{
+			name: "enough resources",
+			ps: &pluginState{
+				requests: requests,
+			},
+			cluster: newCluster(clusterName1, "8", "4"),
+		},
+		{
+			name: "insufficient resources",
+			ps: &pluginState{
+				requests: requests,
+			},
+			cluster:     newCluster(clusterName1, "8", "1"),
+			want:        framework.NewNonErrorStatus(framework.ClusterUnschedulable, defaultPluginName),
+			wantReasons: []string{"insufficient cpu: requested 2, available 1, reserved by other placements 0"},
+		},
+		{
+			name: "insufficient resources after reservations",
+			ps: &pluginState{
+				requests: requests,
+				reserved: map[string]corev1.ResourceList{
+					clusterName1: {corev1.ResourceCPU: resource.MustParse("3")},
+				},
+			},
+			cluster:     newCluster(clusterName1, "8", "4"),
+			want:        framework.NewNonErrorStatus(framework.ClusterUnschedulable, defaultPluginName),
+			wantReasons: []string{"insufficient cpu: requested 2, available 4, reserved by other placements 3"},
+		},
+		{
+			name: "reservations on other clusters",
+			ps: &pluginState{
+				requests: requests,
+				reserved: map[string]corev1.ResourceList{
+					clusterName2: {corev1.ResourceCPU: resource.MustParse("3")},
+				},
+			},
+			cluster: newCluster(clusterName1, "8", "4"),
+		},
+		{
+			name: "resource usage not reported",
+			ps: &pluginState{
+				requests: requests,
+			},
+			cluster: &clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterName1},
+			},
+		},
+		{
+			name: "already scheduled on the cluster",
+			ps: &pluginState{
+				requests: requests,
+			},
+			cluster:                newCluster(clusterName1, "8", "1"),
+			scheduledOrBoundOnSame: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := New()
+			var scheduledOrBound []placementv1beta1.BindingObj
+			if tc.scheduledOrBoundOnSame {
+				scheduledOrBound = append(scheduledOrBound, &placementv1beta1.ClusterResourceBinding{
+					Spec: placementv1beta1.ResourceBindingSpec{TargetCluster: tc.cluster.Name},
+				})
+			}
+			state := framework.NewCycleState(nil, nil, scheduledOrBound)
+			state.Write(framework.StateKey(p.Name()), tc.ps)
+
+			status := p.Filter(context.Background(), state, policy, tc.cluster)
+			if diff := cmp.Diff(status, tc.want, cmp.AllowUnexported(framework.Status{}), ignoredStatusFields); diff != "" {
+				t.Fatalf("Filter() status diff (-got, +want): %s", diff)
+			}
+			if status != nil {
+				if diff := cmp.Diff(status.Reasons(), tc.wantReasons); diff != "" {
+					t.Errorf("Filter() reasons diff (-got, +want): %s", diff)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package resourcefit features a scheduler plugin that filters out clusters which do not have
+// enough available resources for the workloads of a placement, and prefers clusters with more
+// headroom left after the placement.
+package resourcefit
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	// defaultPluginName is the default name for the resource fit plugin.
+	defaultPluginName = "ResourceFit"
+)
+
+// Plugin is the scheduler plugin that checks if the workloads of a placement fit into the
+// available resources of a cluster.
+type Plugin struct {
+	// The name of the plugin.
+	name string
+
+	// Whether the plugin should account for the ResourceBindings, i.e., the bindings of the
+	// namespace-scoped placements, in addition to the ClusterResourceBindings.
+	resourcePlacementEnabled bool
+
+	// The weights of the resources considered when scoring clusters; nil if all the requested
+	// resources are considered with the same weight.
+	resourceWeights map[corev1.ResourceName]int64
+
+	// The framework handle.
+	handle framework.Handle
+}
+
+var (
+	// Verify that Plugin can connect to relevant extension points
+	// at compile time.
+	//
+	// This plugin leverages the following the extension points:
+	// * PreFilter
+	// * Filter
+	// * PreScore
+	// * Score
+	//
+	// Note that successful connection to any of the extension points implies that the
+	// plugin already implements the Plugin interface.
+	_ framework.PreFilterPlugin = &Plugin{}
+	_ framework.FilterPlugin    = &Plugin{}
+	_ framework.PreScorePlugin  = &Plugin{}
+	_ framework.ScorePlugin     = &Plugin{}
+)
+
+type resourceFitPluginOptions struct {
+	// The name of the plugin.
+	name string
+
+	// Whether the plugin should account for the ResourceBindings.
+	resourcePlacementEnabled bool
+
+	// The arguments of the plugin.
+	args Args
+}
+
+type Option func(*resourceFitPluginOptions)
+
+var defaultResourceFitPluginOptions = resourceFitPluginOptions{
+	name: defaultPluginName,
+}
+
+// WithName sets the name of the plugin.
+func WithName(name string) Option {
+	return func(o *resourceFitPluginOptions) {
+		o.name = name
+	}
+}
+
+// WithResourcePlacementEnabled sets whether the plugin should account for the resources
+// reserved by the namespace-scoped placements (ResourcePlacements).
+func WithResourcePlacementEnabled(enabled bool) Option {
+	return func(o *resourceFitPluginOptions) {
+		o.resourcePlacementEnabled = enabled
+	}
+}
+
+// WithArgs sets the arguments of the plugin, which are expected to have been validated.
+func WithArgs(args Args) Option {
+	return func(o *resourceFitPluginOptions) {
+		o.args = args
+	}
+}
+
+// New returns a new Plugin.
+func New(opts ...Option) Plugin {
+	options := defaultResourceFitPluginOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return Plugin{
+		name:                     options.name,
+		resourcePlacementEnabled: options.resourcePlacementEnabled,
+		resourceWeights:          options.args.resourceWeights(),
+	}
+}
+
+// Name returns the name of the plugin.
+func (p *Plugin) Name() string {
+	return p.name
+}
+
+// SetUpWithFramework sets up this plugin with a scheduler framework.
+func (p *Plugin) SetUpWithFramework(handle framework.Handle) {
+	p.handle = handle
+
+	// This plugin does not need to set up any informer.
+}
+
+// readPluginState reads the plugin state from the cycle state.
+func (p *Plugin) readPluginState(state framework.CycleStatePluginReadWriter) (*pluginState, error) {
+	// Read from the cycle state.
+	val, err := state.Read(framework.StateKey(p.Name()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read value from the cycle state: %w", err)
+	}
+
+	// Cast the value to the right type.
+	ps, ok := val.(*pluginState)
+	if !ok {
+		return nil, fmt.Errorf("failed to cast value %v to the right type", val)
+	}
+	return ps, nil
+}
+
+// PreFilter allows the plugin to connect to the PreFilter extension point in the scheduling
+// framework.
+//
+// Note that the scheduler will not run this extension point in parallel.
+func (p *Plugin) PreFilter(
+	ctx context.Context,
+	state framework.CycleStatePluginReadWriter,
+	policy placementv1beta1.PolicySnapshotObj,
+) (status *framework.Status) {
+	// Prepare the resource requests of the placement and the resources reserved by the other
+	// placements on each cluster; the state is shared between the Filter and Score stages.
+	ps, err := p.preparePluginState(ctx, state, policy)
+	if err != nil {
+		return framework.FromError(err, p.Name(), "failed to prepare plugin state")
+	}
+
+	// Save the plugin state.
+	state.Write(framework.StateKey(p.Name()), ps)
+
+	if len(ps.requests) == 0 {
+		// The workloads of the placement do not request any resources; skip.
+		//
+		// Note that this will lead the scheduler to skip this plugin in the next stage
+		// (Filter).
+		return framework.NewNonErrorStatus(framework.Skip, p.Name(), "no resource is requested by the placement")
+	}
+
+	// All done.
+	return nil
+}
+
+// PreScore allows the plugin to connect to the PreScore extension point in the scheduling
+// framework.
+func (p *Plugin) PreScore(
+	_ context.Context,
+	state framework.CycleStatePluginReadWriter,
+	_ placementv1beta1.PolicySnapshotObj,
+) (status *framework.Status) {
+	// Read the plugin state.
+	ps, err := p.readPluginState(state)
+	if err != nil {
+		// This branch should never be reached, as the plugin state has been set at the
+		// PreFilter extension point.
+		return framework.FromError(err, p.Name(), "failed to read plugin state")
+	}
+
+	if len(ps.requests) == 0 {
+		// The workloads of the placement do not request any resources; skip.
+		//
+		// Note that this will lead the scheduler to skip this plugin in the next stage
+		// (Score).
+		return framework.NewNonErrorStatus(framework.Skip, p.Name(), "no resource is requested by the placement")
+	}
+
+	// All done.
+	return nil
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcefit
+
+import (
+	"encoding/json"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	resourcehelper "k8s.io/component-helpers/resource"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// requestsOfResourceSnapshots returns the total resource requests of the workloads selected in
+// a group of resource snapshots.
+//
+// Note that the workloads offloaded to the blob store (if any) are not accounted for, as only their
+// references are kept in the resource snapshots.
+func requestsOfResourceSnapshots(snapshots map[string]placementv1beta1.ResourceSnapshotObj) (corev1.ResourceList, error) {
+	total := corev1.ResourceList{}
+	for name, snapshot := range snapshots {
+		for i, res := range snapshot.GetResourceSnapshotSpec().SelectedResources {
+			if err := addRequestsOfManifest(total, res.Raw, true); err != nil {
+				return nil, fmt.Errorf("failed to read the resource requests of selected resource %d in resource snapshot %s: %w", i, name, err)
+			}
+		}
+	}
+	return total, nil
+}
+
+// addRequestsOfManifest adds the resource requests of a manifest, i.e., the requests of all
+// the pods it runs, to the total.
+//
+// Only pods, deployments, replica sets, stateful sets and jobs are accounted for; daemon sets
+// are not, as the number of pods they run depends on the nodes of a cluster. The manifests
+// wrapped in envelopes are accounted for if unwrapEnvelopes is true.
+func addRequestsOfManifest(total corev1.ResourceList, raw []byte, unwrapEnvelopes bool) error {
+	var typeMeta metav1.TypeMeta
+	if err := json.Unmarshal(raw, &typeMeta); err != nil {
+		return err
+	}
+
+	var podSpec *corev1.PodSpec
+	replicas := int64(1)
+	switch typeMeta.GroupVersionKind().GroupKind() {
+	case corev1.SchemeGroupVersion.WithKind("Pod").GroupKind():
+		var pod corev1.Pod
+		if err := json.Unmarshal(raw, &pod); err != nil {
+			return err
+		}
+		podSpec = &pod.Spec
+	case appsv1.SchemeGroupVersion.WithKind("Deployment").GroupKind():
+		var deploy appsv1.Deployment
+		if err := json.Unmarshal(raw, &deploy); err != nil {
+			return err
+		}
+		podSpec = &deploy.Spec.Template.Spec
+		replicas = replicasOrDefault(deploy.Spec.Replicas)
+	case appsv1.SchemeGroupVersion.WithKind("ReplicaSet").GroupKind():
+		var rs appsv1.ReplicaSet
+		if err := json.Unmarshal(raw, &rs); err != nil {
+			return err
+		}
+		podSpec = &rs.Spec.Template.Spec
+		replicas = replicasOrDefault(rs.Spec.Replicas)
+	case appsv1.SchemeGroupVersion.WithKind("StatefulSet").GroupKind():
+		var sts appsv1.StatefulSet
+		if err := json.Unmarshal(raw, &sts); err != nil {
+			return err
+		}
+		podSpec = &sts.Spec.Template.Spec
+		replicas = replicasOrDefault(sts.Spec.Replicas)
+	case batchv1.SchemeGroupVersion.WithKind("Job").GroupKind():
+		var job batchv1.Job
+		if err := json.Unmarshal(raw, &job); err != nil {
+			return err
+		}
+		podSpec = &job.Spec.Template.Spec
+		// A job runs at most as many pods in parallel as its completions.
+		replicas = replicasOrDefault(job.Spec.Parallelism)
+		if job.Spec.Completions != nil && int64(*job.Spec.Completions) < replicas {
+			replicas = int64(*job.Spec.Completions)
+		}
+	case placementv1beta1.GroupVersion.WithKind(placementv1beta1.ResourceEnvelopeKind).GroupKind(),
+		placementv1beta1.GroupVersion.WithKind(placementv1beta1.ClusterResourceEnvelopeKind).GroupKind():
+		if !unwrapEnvelopes {
+			return nil
+		}
+		var envelope struct {
+			Data map[string]runtime.RawExtension `json:"data"`
+		}
+		if err := json.Unmarshal(raw, &envelope); err != nil {
+			return err
+		}
+		for key, manifest := range envelope.Data {
+			if err := addRequestsOfManifest(total, manifest.Raw, false); err != nil {
+				return fmt.Errorf("failed to read the resource requests of manifest %s in the envelope: %w", key, err)
+			}
+		}
+		return nil
+	default:
+		// The manifest does not run any pods.
+		return nil
+	}
+
+	requests := resourcehelper.PodRequests(&corev1.Pod{Spec: *podSpec}, resourcehelper.PodResourcesOptions{})
+	addScaledResourceList(total, requests, replicas)
+	return nil
+}
+
+// replicasOrDefault returns the number of replicas, which defaults to 1 if not set.
+func replicasOrDefault(replicas *int32) int64 {
+	if replicas == nil {
+		return 1
+	}
+	return int64(*replicas)
+}
+
+// addScaledResourceList adds a resource list, with all the quantities multiplied by a factor,
+// to the total.
+func addScaledResourceList(total, list corev1.ResourceList, factor int64) {
+	if factor <= 0 {
+		return
+	}
+	for name, q := range list {
+		if q.IsZero() {
+			continue
+		}
+		scaled := *resource.NewMilliQuantity(q.MilliValue()*factor, q.Format)
+		if current, ok := total[name]; ok {
+			current.Add(scaled)
+			total[name] = current
+			continue
+		}
+		total[name] = scaled
+	}
+}
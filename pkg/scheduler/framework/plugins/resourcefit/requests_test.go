@@ -0,0 +1,225 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcefit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+func podTemplate(cpu, memory string) corev1.PodTemplateSpec {
+	return corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "app",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse(cpu),
+							corev1.ResourceMemory: resource.MustParse(memory),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func toRaw(t *testing.T, obj interface{}) []byte {
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatalf("failed to marshal object: %v", err)
+	}
+	return raw
+}
+
+// equalResourceLists compares two resource lists by the values of their quantities.
+func equalResourceLists(a, b corev1.ResourceList) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, q := range a {
+		other, ok := b[name]
+		if !ok || q.Cmp(other) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// TestAddRequestsOfManifest tests the addRequestsOfManifest function.
+func TestAddRequestsOfManifest(t *testing.T) {
+	deploy := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(int32(3)),
+			Template: podTemplate("500m", "1Gi"),
+		},
+	}
+
+	testCases := []struct {
+		name string
+		raw  []byte
+		want corev1.ResourceList
+	}{
+		{
+			name: "deployment",
+			raw:  toRaw(t, deploy),
+			want: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1500m"),
+				corev1.ResourceMemory: resource.MustParse("3Gi"),
+			},
+		},
+		{
+			name: "stateful set without replicas",
+			raw: toRaw(t, &appsv1.StatefulSet{
+				TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "StatefulSet"},
+				Spec: appsv1.StatefulSetSpec{
+					Template: podTemplate("2", "4Gi"),
+				},
+			}),
+			want: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			},
+		},
+		{
+			name: "job with fewer completions than parallelism",
+			raw: toRaw(t, &batchv1.Job{
+				TypeMeta: metav1.TypeMeta{APIVersion: "batch/v1", Kind: "Job"},
+				Spec: batchv1.JobSpec{
+					Parallelism: ptr.To(int32(4)),
+					Completions: ptr.To(int32(2)),
+					Template:    podTemplate("1", "1Gi"),
+				},
+			}),
+			want: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("2Gi"),
+			},
+		},
+		{
+			name: "deployment scaled to zero",
+			raw: toRaw(t, &appsv1.Deployment{
+				TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+				Spec: appsv1.DeploymentSpec{
+					Replicas: ptr.To(int32(0)),
+					Template: podTemplate("1", "1Gi"),
+				},
+			}),
+			want: corev1.ResourceList{},
+		},
+		{
+			name: "daemon set is ignored",
+			raw: toRaw(t, &appsv1.DaemonSet{
+				TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "DaemonSet"},
+				Spec: appsv1.DaemonSetSpec{
+					Template: podTemplate("1", "1Gi"),
+				},
+			}),
+			want: corev1.ResourceList{},
+		},
+		{
+			name: "config map is ignored",
+			raw: toRaw(t, &corev1.ConfigMap{
+				TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+				Data:     map[string]string{"key": "value"},
+			}),
+			want: corev1.ResourceList{},
+		},
+		{
+			name: "workload wrapped in an envelope",
+			raw: toRaw(t, &placementv1beta1.ResourceEnvelope{
+				TypeMeta: metav1.TypeMeta{APIVersion: placementv1beta1.GroupVersion.String(), Kind: placementv1beta1.ResourceEnvelopeKind},
+				Data: map[string]runtime.RawExtension{
+					"deploy.yaml": {Raw: toRaw(t, deploy)},
+				},
+			}),
+			want: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1500m"),
+				corev1.ResourceMemory: resource.MustParse("3Gi"),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := corev1.ResourceList{}
+			if err := addRequestsOfManifest(got, tc.raw, true); err != nil {
+				t.Fatalf("addRequestsOfManifest() = %v, want no error", err)
+			}
+			if !equalResourceLists(got, tc.want) {
+				t.Errorf("addRequestsOfManifest() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestRequestsOfResourceSnapshots tests the requestsOfResourceSnapshots function.
+func TestRequestsOfResourceSnapshots(t *testing.T) {
+	newSnapshot := func(name string, objs ...interface{}) placementv1beta1.ResourceSnapshotObj {
+		snapshot := &placementv1beta1.ClusterResourceSnapshot{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+		}
+		for _, obj := range objs {
+			snapshot.Spec.SelectedResources = append(snapshot.Spec.SelectedResources, placementv1beta1.ResourceContent{
+				RawExtension: runtime.RawExtension{Raw: toRaw(t, obj)},
+			})
+		}
+		return snapshot
+	}
+	pod := &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		Spec:     podTemplate("250m", "256Mi").Spec,
+	}
+
+	got, err := requestsOfResourceSnapshots(map[string]placementv1beta1.ResourceSnapshotObj{
+		"snapshot-0":   newSnapshot("snapshot-0", pod, pod),
+		"snapshot-0-1": newSnapshot("snapshot-0-1", pod),
+	})
+	if err != nil {
+		t.Fatalf("requestsOfResourceSnapshots() = %v, want no error", err)
+	}
+	want := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("750m"),
+		corev1.ResourceMemory: resource.MustParse("768Mi"),
+	}
+	if !equalResourceLists(got, want) {
+		t.Errorf("requestsOfResourceSnapshots() diff (-got, +want): %s", cmp.Diff(got, want))
+	}
+
+	if _, err := requestsOfResourceSnapshots(map[string]placementv1beta1.ResourceSnapshotObj{
+		"snapshot-0": &placementv1beta1.ClusterResourceSnapshot{
+			Spec: placementv1beta1.ResourceSnapshotSpec{
+				SelectedResources: []placementv1beta1.ResourceContent{{RawExtension: runtime.RawExtension{Raw: []byte("{")}}},
+			},
+		},
+	}); err == nil {
+		t.Errorf("requestsOfResourceSnapshots() = nil, want error for invalid manifest")
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcefit
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	// maxResourceFitScore is the score assigned to a cluster which would have all of its
+	// allocatable resources left after the placement.
+	maxResourceFitScore int32 = 100
+)
+
+// Score allows the plugin to connect to the Score extension point in the scheduling framework.
+//
+// A cluster scores higher if it has a larger share of its allocatable resources left after
+// the placement, averaged over the requested resources which the cluster reports; if the plugin
+// is configured with resource weights, only the weighted resources count, in proportion to
+// their weights.
+func (p *Plugin) Score(
+	_ context.Context,
+	state framework.CycleStatePluginReadWriter,
+	_ placementv1beta1.PolicySnapshotObj,
+	cluster *clusterv1beta1.MemberCluster,
+) (score *framework.ClusterScore, status *framework.Status) {
+	// Read the plugin state.
+	ps, err := p.readPluginState(state)
+	if err != nil {
+		// This branch should never be reached, as the plugin state has been set at the
+		// PreFilter extension point.
+		return nil, framework.FromError(err, p.Name(), "failed to read plugin state")
+	}
+
+	// The state is safe for concurrent reads.
+	usage := cluster.Status.ResourceUsage
+	return &framework.ClusterScore{
+		ResourceFitScore: headroomScore(ps.requests, usage.Allocatable, usage.Available, ps.reserved[cluster.Name], p.resourceWeights),
+	}, nil
+}
+
+// headroomScore returns the share, in the range of [0, maxResourceFitScore], of the allocatable
+// resources which would be left after the requested resources are deducted from the available
+// ones (minus the reserved ones), averaged over the requested resources with the given weights;
+// all the requested resources have a weight of 1 if no weights are given.
+func headroomScore(requests, allocatable, available, reserved corev1.ResourceList, weights map[corev1.ResourceName]int64) int32 {
+	var total, totalWeight int64
+	for name, requested := range requests {
+		weight := int64(1)
+		if weights != nil {
+			w, ok := weights[name]
+			if !ok {
+				continue
+			}
+			weight = w
+		}
+		allocatableQuantity, ok := allocatable[name]
+		if !ok || allocatableQuantity.MilliValue() <= 0 {
+			continue
+		}
+		availableQuantity, ok := available[name]
+		if !ok {
+			continue
+		}
+		left := availableQuantity.MilliValue() - requested.MilliValue()
+		if reservedQuantity, ok := reserved[name]; ok {
+			left -= reservedQuantity.MilliValue()
+		}
+		share := left * int64(maxResourceFitScore) / allocatableQuantity.MilliValue()
+		switch {
+		case share < 0:
+			share = 0
+		case share > int64(maxResourceFitScore):
+			share = int64(maxResourceFitScore)
+		}
+		total += share * weight
+		totalWeight += weight
+	}
+	if totalWeight == 0 {
+		// The cluster does not report any of the requested (and weighted) resources.
+		return 0
+	}
+	return int32(total / totalWeight)
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcefit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+// TestScore tests the Score method.
+func TestScore(t *testing.T) {
+	policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: policyName,
+		},
+	}
+
+	testCases := []struct {
+		name    string
+		opts    []Option
+		ps      *pluginState
+		cluster *clusterv1beta1.MemberCluster
+		want    *framework.ClusterScore
+	}{
+		{
+			name: "half of the allocatable resources left",
+			ps: &pluginState{
+				requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+			},
+			cluster: newCluster(clusterName1, "8", "6"),
+			want:    &framework.ClusterScore{ResourceFitScore: 50},
+		},
+		{
+			name: "reservations reduce the headroom",
+			ps: &pluginState{
+				requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+				reserved: map[string]corev1.ResourceList{
+					clusterName1: {corev1.ResourceCPU: resource.MustParse("2")},
+				},
+			},
+			cluster: newCluster(clusterName1, "8", "6"),
+			want:    &framework.ClusterScore{ResourceFitScore: 25},
+		},
+		{
+			name: "overcommitted",
+			ps: &pluginState{
+				requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+			},
+			cluster: newCluster(clusterName1, "8", "2"),
+			want:    &framework.ClusterScore{ResourceFitScore: 0},
+		},
+		{
+			name: "averaged over the reported resources",
+			ps: &pluginState{
+				requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("2"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+					"nvidia.com/gpu":      resource.MustParse("1"),
+				},
+			},
+			cluster: &clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterName1},
+				Status: clusterv1beta1.MemberClusterStatus{
+					ResourceUsage: clusterv1beta1.ResourceUsage{
+						Allocatable: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("10"),
+							corev1.ResourceMemory: resource.MustParse("4Gi"),
+						},
+						Available: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("10"),
+							corev1.ResourceMemory: resource.MustParse("3Gi"),
+						},
+					},
+				},
+			},
+			// (80 + 50) / 2
+			want: &framework.ClusterScore{ResourceFitScore: 65},
+		},
+		{
+			name: "weighted resources",
+			opts: []Option{WithArgs(Args{Resources: []ResourceWeight{
+				{Name: corev1.ResourceCPU, Weight: 1},
+				{Name: corev1.ResourceMemory, Weight: 3},
+			}})},
+			ps: &pluginState{
+				requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("2"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+			},
+			cluster: &clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterName1},
+				Status: clusterv1beta1.MemberClusterStatus{
+					ResourceUsage: clusterv1beta1.ResourceUsage{
+						Allocatable: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("10"),
+							corev1.ResourceMemory: resource.MustParse("4Gi"),
+						},
+						Available: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("10"),
+							corev1.ResourceMemory: resource.MustParse("3Gi"),
+						},
+					},
+				},
+			},
+			// (80 * 1 + 50 * 3) / 4
+			want: &framework.ClusterScore{ResourceFitScore: 57},
+		},
+		{
+			name: "requested resources without weights are ignored",
+			opts: []Option{WithArgs(Args{Resources: []ResourceWeight{
+				{Name: corev1.ResourceMemory, Weight: 1},
+			}})},
+			ps: &pluginState{
+				requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+			},
+			cluster: newCluster(clusterName1, "8", "6"),
+			want:    &framework.ClusterScore{ResourceFitScore: 0},
+		},
+		{
+			name: "resource usage not reported",
+			ps: &pluginState{
+				requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+			},
+			cluster: &clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterName1},
+			},
+			want: &framework.ClusterScore{ResourceFitScore: 0},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := New(tc.opts...)
+			state := framework.NewCycleState(nil, nil)
+			state.Write(framework.StateKey(p.Name()), tc.ps)
+
+			score, status := p.Score(context.Background(), state, policy, tc.cluster)
+			if status != nil {
+				t.Fatalf("Score() status = %v, want nil", status)
+			}
+			if diff := cmp.Diff(score, tc.want); diff != "" {
+				t.Errorf("Score() diff (-got, +want): %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcefit
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+	"go.goms.io/fleet/pkg/utils/condition"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+// pluginState is the state which the resource fit plugin prepares at the PreFilter stage and
+// shares with the Filter and Score stages.
+//
+// The state is read-only once prepared and is thus safe for concurrent reads.
+type pluginState struct {
+	// requests is the total resource requests of the workloads of the placement being scheduled.
+	requests corev1.ResourceList
+
+	// reserved maps the names of the clusters to the resources which have been reserved by the
+	// in-flight bindings of the other placements, i.e., the bindings whose workloads might not
+	// have been reflected in the resource usage reported by the cluster yet.
+	reserved map[string]corev1.ResourceList
+}
+
+// preparePluginState prepares the plugin state for a scheduling cycle.
+func (p *Plugin) preparePluginState(
+	ctx context.Context,
+	state framework.CycleStatePluginReadWriter,
+	policy placementv1beta1.PolicySnapshotObj,
+) (*pluginState, error) {
+	placementKey := types.NamespacedName{
+		Namespace: policy.GetNamespace(),
+		Name:      policy.GetLabels()[placementv1beta1.PlacementTrackingLabel],
+	}
+	if placementKey.Name == "" {
+		return nil, fmt.Errorf("policy snapshot %s does not have the %s label", policy.GetName(), placementv1beta1.PlacementTrackingLabel)
+	}
+
+	// Resource requests are cached by resource snapshot for the duration of the cycle, as
+	// many bindings might point to the same resource snapshot.
+	cache := make(map[string]corev1.ResourceList)
+	requests, err := p.requestsOfPlacement(ctx, placementKey, "", cache)
+	if err != nil {
+		return nil, err
+	}
+	ps := &pluginState{
+		requests: requests,
+		reserved: make(map[string]corev1.ResourceList),
+	}
+	if len(requests) == 0 {
+		// The reserved resources are of no use if the placement does not request any.
+		return ps, nil
+	}
+
+	clusters := state.ListClusters()
+	clusterByName := make(map[string]*clusterv1beta1.MemberCluster, len(clusters))
+	for i := range clusters {
+		clusterByName[clusters[i].Name] = &clusters[i]
+	}
+
+	bindings, err := p.listBindings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, binding := range bindings {
+		bindingPlacementKey := types.NamespacedName{
+			Namespace: binding.GetNamespace(),
+			Name:      binding.GetLabels()[placementv1beta1.PlacementTrackingLabel],
+		}
+		if bindingPlacementKey == placementKey || bindingPlacementKey.Name == "" {
+			// The bindings of the placement itself are not reservations.
+			continue
+		}
+		spec := binding.GetBindingSpec()
+		cluster, ok := clusterByName[spec.TargetCluster]
+		if !ok {
+			// The cluster is not a candidate in this cycle.
+			continue
+		}
+		if binding.GetDeletionTimestamp() != nil || spec.State == placementv1beta1.BindingStateUnscheduled {
+			// The binding is going away.
+			continue
+		}
+		if !isInFlight(binding, cluster) {
+			continue
+		}
+
+		bindingRequests, err := p.requestsOfPlacement(ctx, bindingPlacementKey, spec.ResourceSnapshotName, cache)
+		if err != nil {
+			return nil, err
+		}
+		reserved, ok := ps.reserved[cluster.Name]
+		if !ok {
+			reserved = corev1.ResourceList{}
+			ps.reserved[cluster.Name] = reserved
+		}
+		addScaledResourceList(reserved, bindingRequests, 1)
+	}
+	return ps, nil
+}
+
+// isInFlight returns true if the workloads of a binding might not have been reflected in the
+// resource usage reported by its target cluster yet, i.e., the binding has not become available
+// before the resource usage is observed.
+func isInFlight(binding placementv1beta1.BindingObj, cluster *clusterv1beta1.MemberCluster) bool {
+	availableCond := binding.GetCondition(string(placementv1beta1.ResourceBindingAvailable))
+	if !condition.IsConditionStatusTrue(availableCond, binding.GetGeneration()) {
+		return true
+	}
+	return !availableCond.LastTransitionTime.Before(&cluster.Status.ResourceUsage.ObservationTime)
+}
+
+// listBindings lists all the bindings in the fleet.
+func (p *Plugin) listBindings(ctx context.Context) ([]placementv1beta1.BindingObj, error) {
+	crbList := &placementv1beta1.ClusterResourceBindingList{}
+	if err := p.handle.Client().List(ctx, crbList); err != nil {
+		return nil, controller.NewAPIServerError(true, err)
+	}
+	bindings := crbList.GetBindingObjs()
+	if !p.resourcePlacementEnabled {
+		return bindings, nil
+	}
+
+	rbList := &placementv1beta1.ResourceBindingList{}
+	if err := p.handle.Client().List(ctx, rbList); err != nil {
+		return nil, controller.NewAPIServerError(true, err)
+	}
+	return append(bindings, rbList.GetBindingObjs()...), nil
+}
+
+// requestsOfPlacement returns the total resource requests of the workloads of a placement, as
+// selected by the given master resource snapshot, or by the latest one if the name is empty or
+// the snapshot no longer exists.
+func (p *Plugin) requestsOfPlacement(
+	ctx context.Context,
+	placementKey types.NamespacedName,
+	masterResourceSnapshotName string,
+	cache map[string]corev1.ResourceList,
+) (corev1.ResourceList, error) {
+	cacheKey := placementKey.String() + "/" + masterResourceSnapshotName
+	if requests, ok := cache[cacheKey]; ok {
+		return requests, nil
+	}
+
+	masterResourceSnapshot, err := p.fetchMasterResourceSnapshot(ctx, placementKey, masterResourceSnapshotName)
+	if err != nil {
+		return nil, err
+	}
+	if masterResourceSnapshot == nil {
+		// The resources of the placement have not been selected yet.
+		klog.V(2).InfoS("No resource snapshot is found for the placement", "placement", placementKey)
+		cache[cacheKey] = nil
+		return nil, nil
+	}
+	snapshots, err := controller.FetchAllResourceSnapshotsAlongWithMaster(ctx, p.handle.Client(),
+		controller.GetObjectKeyFromNamespaceName(placementKey.Namespace, placementKey.Name), masterResourceSnapshot)
+	if err != nil {
+		return nil, err
+	}
+	requests, err := requestsOfResourceSnapshots(snapshots)
+	if err != nil {
+		return nil, controller.NewUnexpectedBehaviorError(err)
+	}
+	cache[cacheKey] = requests
+	return requests, nil
+}
+
+// fetchMasterResourceSnapshot fetches the master resource snapshot of the given name, or the
+// latest one if the name is empty or the snapshot no longer exists.
+func (p *Plugin) fetchMasterResourceSnapshot(
+	ctx context.Context,
+	placementKey types.NamespacedName,
+	name string,
+) (placementv1beta1.ResourceSnapshotObj, error) {
+	if name != "" {
+		var snapshot placementv1beta1.ResourceSnapshotObj = &placementv1beta1.ClusterResourceSnapshot{}
+		if placementKey.Namespace != "" {
+			snapshot = &placementv1beta1.ResourceSnapshot{}
+		}
+		err := p.handle.Client().Get(ctx, client.ObjectKey{Namespace: placementKey.Namespace, Name: name}, snapshot)
+		switch {
+		case err == nil:
+			return snapshot, nil
+		case !apierrors.IsNotFound(err):
+			return nil, controller.NewAPIServerError(true, err)
+		}
+		// The resource snapshot has been garbage collected; fall back to the latest one.
+	}
+	return controller.FetchLatestMasterResourceSnapshot(ctx, p.handle.Client(), placementKey)
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcefit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/clustereligibilitychecker"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	crpName      = "test-placement"
+	otherCRPName = "other-placement"
+	clusterName3 = "smartfish"
+)
+
+// Mock framework.Handle interface for set up the plugin.
+type MockHandle struct {
+	client client.Client
+}
+
+var (
+	_ framework.Handle = &MockHandle{}
+)
+
+func (mh *MockHandle) Client() client.Client               { return mh.client }
+func (mh *MockHandle) Manager() ctrl.Manager               { return nil }
+func (mh *MockHandle) UncachedReader() client.Reader       { return mh.client }
+func (mh *MockHandle) EventRecorder() record.EventRecorder { return nil }
+func (mh *MockHandle) ClusterEligibilityChecker() *clustereligibilitychecker.ClusterEligibilityChecker {
+	return nil
+}
+
+func newMasterResourceSnapshot(t *testing.T, name, placementName string, isLatest bool, objs ...interface{}) *placementv1beta1.ClusterResourceSnapshot {
+	snapshot := &placementv1beta1.ClusterResourceSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				placementv1beta1.PlacementTrackingLabel: placementName,
+				placementv1beta1.IsLatestSnapshotLabel:  "false",
+				placementv1beta1.ResourceIndexLabel:     "0",
+			},
+			Annotations: map[string]string{
+				placementv1beta1.ResourceGroupHashAnnotation:         "hash",
+				placementv1beta1.NumberOfResourceSnapshotsAnnotation: "1",
+			},
+		},
+	}
+	if isLatest {
+		snapshot.Labels[placementv1beta1.IsLatestSnapshotLabel] = "true"
+	}
+	for _, obj := range objs {
+		snapshot.Spec.SelectedResources = append(snapshot.Spec.SelectedResources, placementv1beta1.ResourceContent{
+			RawExtension: runtime.RawExtension{Raw: toRaw(t, obj)},
+		})
+	}
+	return snapshot
+}
+
+func newBinding(name, placementName, clusterName, resourceSnapshotName string, availableSince *metav1.Time) *placementv1beta1.ClusterResourceBinding {
+	binding := &placementv1beta1.ClusterResourceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			Generation: 1,
+			Labels: map[string]string{
+				placementv1beta1.PlacementTrackingLabel: placementName,
+			},
+		},
+		Spec: placementv1beta1.ResourceBindingSpec{
+			State:                placementv1beta1.BindingStateBound,
+			TargetCluster:        clusterName,
+			ResourceSnapshotName: resourceSnapshotName,
+		},
+	}
+	if availableSince != nil {
+		binding.Status.Conditions = []metav1.Condition{
+			{
+				Type:               string(placementv1beta1.ResourceBindingAvailable),
+				Status:             metav1.ConditionTrue,
+				ObservedGeneration: 1,
+				LastTransitionTime: *availableSince,
+				Reason:             "Available",
+			},
+		}
+	}
+	return binding
+}
+
+// TestPreFilter tests the PreFilter method.
+func TestPreFilter(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add placement v1beta1 scheme: %v", err)
+	}
+
+	now := time.Now()
+	observedAt := metav1.NewTime(now.Add(-time.Minute))
+	availableBeforeObservation := metav1.NewTime(now.Add(-time.Hour))
+	availableAfterObservation := metav1.NewTime(now)
+
+	policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: policyName,
+			Labels: map[string]string{
+				placementv1beta1.PlacementTrackingLabel: crpName,
+			},
+		},
+	}
+	deploy := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(int32(2)),
+			Template: podTemplate("1", "1Gi"),
+		},
+	}
+	pod := &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		Spec:     podTemplate("3", "2Gi").Spec,
+	}
+	clusters := []clusterv1beta1.MemberCluster{
+		*newCluster(clusterName1, "8", "8"),
+		*newCluster(clusterName2, "8", "8"),
+	}
+	for i := range clusters {
+		clusters[i].Status.ResourceUsage.ObservationTime = observedAt
+	}
+
+	testCases := []struct {
+		name       string
+		objs       []client.Object
+		wantStatus *framework.Status
+		wantState  *pluginState
+	}{
+		{
+			name:       "no resource snapshot",
+			wantStatus: framework.NewNonErrorStatus(framework.Skip, defaultPluginName),
+			wantState: &pluginState{
+				reserved: map[string]corev1.ResourceList{},
+			},
+		},
+		{
+			name: "in-flight bindings of other placements",
+			objs: []client.Object{
+				newMasterResourceSnapshot(t, crpName+"-0-snapshot", crpName, true, deploy),
+				newMasterResourceSnapshot(t, otherCRPName+"-0-snapshot", otherCRPName, false, pod),
+				newMasterResourceSnapshot(t, otherCRPName+"-1-snapshot", otherCRPName, true, pod, pod),
+				// The binding of the placement itself does not reserve any resources.
+				newBinding("binding-1", crpName, clusterName1, crpName+"-0-snapshot", nil),
+				// The binding has not become available yet.
+				newBinding("binding-2", otherCRPName, clusterName1, otherCRPName+"-0-snapshot", nil),
+				// The binding has become available after the resource usage was observed.
+				newBinding("binding-3", otherCRPName, clusterName2, otherCRPName+"-0-snapshot", &availableAfterObservation),
+				// The binding was available before the resource usage was observed.
+				newBinding("binding-4", otherCRPName, clusterName2, otherCRPName+"-0-snapshot", &availableBeforeObservation),
+				// The binding has not been bound to a resource snapshot yet.
+				newBinding("binding-5", otherCRPName, clusterName2, "", nil),
+				// The cluster is not a candidate.
+				newBinding("binding-6", otherCRPName, clusterName3, otherCRPName+"-0-snapshot", nil),
+			},
+			wantState: &pluginState{
+				requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("2"),
+					corev1.ResourceMemory: resource.MustParse("2Gi"),
+				},
+				reserved: map[string]corev1.ResourceList{
+					clusterName1: {
+						corev1.ResourceCPU:    resource.MustParse("3"),
+						corev1.ResourceMemory: resource.MustParse("2Gi"),
+					},
+					clusterName2: {
+						corev1.ResourceCPU:    resource.MustParse("9"),
+						corev1.ResourceMemory: resource.MustParse("6Gi"),
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.objs...).Build()
+			p := New()
+			p.SetUpWithFramework(&MockHandle{client: fakeClient})
+			state := framework.NewCycleState(clusters, nil)
+
+			status := p.PreFilter(context.Background(), state, policy)
+			if diff := cmp.Diff(status, tc.wantStatus, cmp.AllowUnexported(framework.Status{}), ignoredStatusFields); diff != "" {
+				t.Fatalf("PreFilter() status diff (-got, +want): %s", diff)
+			}
+
+			ps, err := p.readPluginState(state)
+			if err != nil {
+				t.Fatalf("readPluginState() = %v, want no error", err)
+			}
+			if !equalResourceLists(ps.requests, tc.wantState.requests) {
+				t.Errorf("PreFilter() requests = %v, want %v", ps.requests, tc.wantState.requests)
+			}
+			if len(ps.reserved) != len(tc.wantState.reserved) {
+				t.Fatalf("PreFilter() reserved = %v, want %v", ps.reserved, tc.wantState.reserved)
+			}
+			for name, want := range tc.wantState.reserved {
+				if !equalResourceLists(ps.reserved[name], want) {
+					t.Errorf("PreFilter() reserved on cluster %s = %v, want %v", name, ps.reserved[name], want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package tainttoleration
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// EvictionTime returns the time at which the resources of a placement with the given tolerations
+// are due to be evicted from a cluster with the given taints, i.e., the earliest time at which one
+// of the NoExecute taints is no longer tolerated; it returns false if all the NoExecute taints are
+// tolerated forever (or there is none).
+//
+// A taint without TimeAdded set is considered to have been added at the given current time.
+func EvictionTime(taints []clusterv1beta1.Taint, tolerations []placementv1beta1.Toleration, now time.Time) (time.Time, bool) {
+	var evictAt time.Time
+	found := false
+	for _, taint := range taints {
+		if taint.Effect != corev1.TaintEffectNoExecute {
+			continue
+		}
+		timeAdded := now
+		if taint.TimeAdded != nil {
+			timeAdded = taint.TimeAdded.Time
+		}
+		tolerationSeconds, tolerated := noExecuteTolerationSeconds(taint, tolerations)
+		var taintEvictAt time.Time
+		switch {
+		case !tolerated:
+			taintEvictAt = timeAdded
+		case tolerationSeconds == nil:
+			// The taint is tolerated forever.
+			continue
+		default:
+			taintEvictAt = timeAdded.Add(time.Duration(*tolerationSeconds) * time.Second)
+		}
+		if !found || taintEvictAt.Before(evictAt) {
+			evictAt = taintEvictAt
+			found = true
+		}
+	}
+	return evictAt, found
+}
+
+// noExecuteTolerationSeconds returns the number of seconds for which the given tolerations tolerate
+// a NoExecute taint, which is the smallest TolerationSeconds among the matching tolerations, or nil if
+// none of them has TolerationSeconds set (i.e., the taint is tolerated forever); it returns false if
+// no toleration matches the taint.
+func noExecuteTolerationSeconds(taint clusterv1beta1.Taint, tolerations []placementv1beta1.Toleration) (*int64, bool) {
+	var minSeconds *int64
+	tolerated := false
+	for _, toleration := range tolerations {
+		if !canTolerationTolerateTaint(taint, toleration) {
+			continue
+		}
+		tolerated = true
+		if toleration.TolerationSeconds == nil {
+			continue
+		}
+		// Zero and negative values are treated as 0, i.e., evict immediately.
+		seconds := max(*toleration.TolerationSeconds, 0)
+		if minSeconds == nil || seconds < *minSeconds {
+			minSeconds = &seconds
+		}
+	}
+	return minSeconds, tolerated
+}
@@ -0,0 +1,146 @@
+package tainttoleration
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+func TestEvictionTime(t *testing.T) {
+	now := time.Now()
+	timeAdded := now.Add(-time.Minute)
+	unhealthyTaint := clusterv1beta1.Taint{
+		Key:       clusterv1beta1.UnhealthyTaintKey,
+		Effect:    corev1.TaintEffectNoExecute,
+		TimeAdded: &metav1.Time{Time: timeAdded},
+	}
+
+	tests := []struct {
+		name        string
+		taints      []clusterv1beta1.Taint
+		tolerations []placementv1beta1.Toleration
+		wantEvictAt time.Time
+		wantFound   bool
+	}{
+		{
+			name: "no NoExecute taint",
+			taints: []clusterv1beta1.Taint{
+				{
+					Key:    "key1",
+					Effect: corev1.TaintEffectNoSchedule,
+				},
+			},
+			wantFound: false,
+		},
+		{
+			name:        "NoExecute taint is not tolerated",
+			taints:      []clusterv1beta1.Taint{unhealthyTaint},
+			wantEvictAt: timeAdded,
+			wantFound:   true,
+		},
+		{
+			name: "NoExecute taint without time added is not tolerated",
+			taints: []clusterv1beta1.Taint{
+				{
+					Key:    "key1",
+					Effect: corev1.TaintEffectNoExecute,
+				},
+			},
+			wantEvictAt: now,
+			wantFound:   true,
+		},
+		{
+			name:   "NoExecute taint is tolerated forever",
+			taints: []clusterv1beta1.Taint{unhealthyTaint},
+			tolerations: []placementv1beta1.Toleration{
+				{
+					Operator: corev1.TolerationOpExists,
+				},
+			},
+			wantFound: false,
+		},
+		{
+			name:   "NoExecute taint is tolerated for the smallest toleration seconds",
+			taints: []clusterv1beta1.Taint{unhealthyTaint},
+			tolerations: []placementv1beta1.Toleration{
+				{
+					Key:      clusterv1beta1.UnhealthyTaintKey,
+					Operator: corev1.TolerationOpExists,
+					Effect:   corev1.TaintEffectNoExecute,
+				},
+				{
+					Key:               clusterv1beta1.UnhealthyTaintKey,
+					Operator:          corev1.TolerationOpExists,
+					Effect:            corev1.TaintEffectNoExecute,
+					TolerationSeconds: ptr.To(int64(600)),
+				},
+				{
+					Operator:          corev1.TolerationOpExists,
+					Effect:            corev1.TaintEffectNoExecute,
+					TolerationSeconds: ptr.To(int64(300)),
+				},
+			},
+			wantEvictAt: timeAdded.Add(300 * time.Second),
+			wantFound:   true,
+		},
+		{
+			name:   "negative toleration seconds",
+			taints: []clusterv1beta1.Taint{unhealthyTaint},
+			tolerations: []placementv1beta1.Toleration{
+				{
+					Key:               clusterv1beta1.UnhealthyTaintKey,
+					Operator:          corev1.TolerationOpExists,
+					Effect:            corev1.TaintEffectNoExecute,
+					TolerationSeconds: ptr.To(int64(-1)),
+				},
+			},
+			wantEvictAt: timeAdded,
+			wantFound:   true,
+		},
+		{
+			name: "earliest eviction time among multiple NoExecute taints",
+			taints: []clusterv1beta1.Taint{
+				unhealthyTaint,
+				{
+					Key:       "key1",
+					Value:     "value1",
+					Effect:    corev1.TaintEffectNoExecute,
+					TimeAdded: &metav1.Time{Time: now},
+				},
+			},
+			tolerations: []placementv1beta1.Toleration{
+				{
+					Key:               clusterv1beta1.UnhealthyTaintKey,
+					Operator:          corev1.TolerationOpExists,
+					Effect:            corev1.TaintEffectNoExecute,
+					TolerationSeconds: ptr.To(int64(300)),
+				},
+				{
+					Key:               "key1",
+					Operator:          corev1.TolerationOpEqual,
+					Value:             "value1",
+					TolerationSeconds: ptr.To(int64(60)),
+				},
+			},
+			wantEvictAt: now.Add(60 * time.Second),
+			wantFound:   true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gotEvictAt, gotFound := EvictionTime(tc.taints, tc.tolerations, now)
+			if gotFound != tc.wantFound {
+				t.Fatalf("EvictionTime() found = %t, want %t", gotFound, tc.wantFound)
+			}
+			if !gotEvictAt.Equal(tc.wantEvictAt) {
+				t.Errorf("EvictionTime() = %v, want %v", gotEvictAt, tc.wantEvictAt)
+			}
+		})
+	}
+}
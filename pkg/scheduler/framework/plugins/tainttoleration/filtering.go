@@ -34,6 +34,15 @@ func (p *Plugin) Filter(
 
 func findUntoleratedTaint(taints []clusterv1beta1.Taint, tolerations []placementv1beta1.Toleration) (*clusterv1beta1.Taint, bool) {
 	for _, taint := range taints {
+		if taint.Effect == corev1.TaintEffectNoExecute {
+			// A NoExecute taint must be tolerated forever for new resources to be placed on the cluster;
+			// a toleration with TolerationSeconds set only delays the eviction of the placed resources,
+			// otherwise the placement would keep being scheduled to and evicted from the cluster.
+			if tolerationSeconds, tolerated := noExecuteTolerationSeconds(taint, tolerations); !tolerated || tolerationSeconds != nil {
+				return &taint, true
+			}
+			continue
+		}
 		if !tolerationsTolerateTaint(taint, tolerations) {
 			return &taint, true
 		}
@@ -0,0 +1,52 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topologyspreadconstraints
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Args is the arguments of the topology spread constraints plugin, which can be set in the
+// plugin configuration of a scheduling profile.
+type Args struct {
+	// MaxSkewViolationPenalty is the penalty subtracted from the topology spread score of a
+	// cluster for every ScheduleAnyway topology spread constraint that placing the workloads
+	// on the cluster would violate. It must be positive and defaults to 1000.
+	MaxSkewViolationPenalty *int32 `json:"maxSkewViolationPenalty,omitempty"`
+}
+
+// DecodeArgs decodes and validates the arguments of the plugin; unknown fields are rejected.
+func DecodeArgs(raw []byte) (Args, error) {
+	args := Args{}
+	if err := yaml.UnmarshalStrict(raw, &args); err != nil {
+		return Args{}, fmt.Errorf("failed to decode the arguments of plugin %q: %w", defaultPluginName, err)
+	}
+	if err := args.Validate(); err != nil {
+		return Args{}, fmt.Errorf("invalid arguments of plugin %q: %w", defaultPluginName, err)
+	}
+	return args, nil
+}
+
+// Validate validates the arguments of the plugin.
+func (a *Args) Validate() error {
+	if a.MaxSkewViolationPenalty != nil && *a.MaxSkewViolationPenalty <= 0 {
+		return fmt.Errorf("maxSkewViolationPenalty must be positive, got %d", *a.MaxSkewViolationPenalty)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topologyspreadconstraints
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+// TestDecodeArgs tests the DecodeArgs function and how the decoded arguments apply to the plugin.
+func TestDecodeArgs(t *testing.T) {
+	testCases := []struct {
+		name        string
+		raw         string
+		wantPenalty int32
+		wantErr     bool
+	}{
+		{
+			name:        "empty",
+			raw:         `{}`,
+			wantPenalty: maxSkewViolationPenality,
+		},
+		{
+			name:        "custom penalty",
+			raw:         `{"maxSkewViolationPenalty":10}`,
+			wantPenalty: 10,
+		},
+		{
+			name:    "non-positive penalty",
+			raw:     `{"maxSkewViolationPenalty":0}`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown field",
+			raw:     `{"skewChangeScoreFactor":-2}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			args, err := DecodeArgs([]byte(tc.raw))
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("DecodeArgs() = %v, want error", args)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DecodeArgs() = %v, want no error", err)
+			}
+			p := New(WithArgs(args))
+			if p.maxSkewViolationPenalty != tc.wantPenalty {
+				t.Errorf("New(WithArgs()).maxSkewViolationPenalty = %d, want %d", p.maxSkewViolationPenalty, tc.wantPenalty)
+			}
+		})
+	}
+}
+
+// TestEvaluateAllConstraintsWithPenalty tests that the configured violation penalty applies
+// to the clusters which violate a ScheduleAnyway topology spread constraint.
+func TestEvaluateAllConstraintsWithPenalty(t *testing.T) {
+	clusters := []clusterv1beta1.MemberCluster{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   clusterName1,
+				Labels: map[string]string{topologyKey1: topologyValue1},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   clusterName2,
+				Labels: map[string]string{topologyKey1: topologyValue2},
+			},
+		},
+	}
+	bindings := []*placementv1beta1.ClusterResourceBinding{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: bindingName1,
+			},
+			Spec: placementv1beta1.ResourceBindingSpec{
+				TargetCluster: clusterName1,
+			},
+		},
+	}
+	scheduleAnyway := []*placementv1beta1.TopologySpreadConstraint{
+		{
+			MaxSkew:           ptr.To(int32(1)),
+			TopologyKey:       topologyKey1,
+			WhenUnsatisfiable: placementv1beta1.ScheduleAnyway,
+		},
+	}
+
+	state := framework.NewCycleState(clusters, nil, controller.ConvertCRBArrayToBindingObjs(bindings))
+	_, scores, err := evaluateAllConstraints(state, nil, scheduleAnyway, 10)
+	if err != nil {
+		t.Fatalf("evaluateAllConstraints() = %v, want no error", err)
+	}
+	if got, want := scores[clusterName1], int32(-10); got != want {
+		t.Errorf("evaluateAllConstraints() score of cluster %s = %d, want %d", clusterName1, got, want)
+	}
+}
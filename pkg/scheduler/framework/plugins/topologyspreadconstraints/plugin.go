@@ -34,7 +34,7 @@ const (
 	// Note that it should be a negative value, so that any provisional placement that reduces
 	// skewing will be assigned a positive topology spread score.
 	skewChangeScoreFactor = -1
-	// maxSkewViolationPenality is the penalty applied to topology spread score when a
+	// maxSkewViolationPenality is the default penalty applied to topology spread score when a
 	// provisional placement violates a topology spread constraint.
 	//
 	// Note that it should be a positive value, as the plugin will subtract this value from
@@ -55,6 +55,10 @@ type Plugin struct {
 	// The name of the plugin.
 	name string
 
+	// The penalty applied to topology spread score when a provisional placement violates
+	// a ScheduleAnyway topology spread constraint.
+	maxSkewViolationPenalty int32
+
 	// The framework handle.
 	handle framework.Handle
 }
@@ -82,6 +86,9 @@ var (
 type topologySpreadConstraintsPluginOptions struct {
 	// The name of the plugin.
 	name string
+
+	// The arguments of the plugin.
+	args Args
 }
 
 type Option func(*topologySpreadConstraintsPluginOptions)
@@ -97,6 +104,13 @@ func WithName(name string) Option {
 	}
 }
 
+// WithArgs sets the arguments of the plugin, which are expected to have been validated.
+func WithArgs(args Args) Option {
+	return func(o *topologySpreadConstraintsPluginOptions) {
+		o.args = args
+	}
+}
+
 // New returns a new Plugin.
 func New(opts ...Option) Plugin {
 	options := defaultTopologySpreadConstraintsPluginOptions
@@ -104,8 +118,14 @@ func New(opts ...Option) Plugin {
 		opt(&options)
 	}
 
+	maxSkewViolationPenalty := int32(maxSkewViolationPenality)
+	if options.args.MaxSkewViolationPenalty != nil {
+		maxSkewViolationPenalty = *options.args.MaxSkewViolationPenalty
+	}
+
 	return Plugin{
-		name: options.name,
+		name:                    options.name,
+		maxSkewViolationPenalty: maxSkewViolationPenalty,
 	}
 }
 
@@ -196,7 +216,7 @@ func (p *Plugin) PreFilter(
 	//
 	// Note that this will happen as long as there is one or more topology spread constraints
 	// in presence in the scheduling policy, regardless of its settings.
-	ps, err := prepareTopologySpreadConstraintsPluginState(state, policy)
+	ps, err := prepareTopologySpreadConstraintsPluginState(state, policy, p.maxSkewViolationPenalty)
 	if err != nil {
 		return framework.FromError(err, p.Name(), "failed to prepare plugin state")
 	}
@@ -182,10 +182,12 @@ func classifyConstraints(policy placementv1beta1.PolicySnapshotObj) (doNotSchedu
 // clusters being inspected in the current scheduling cycle.
 //
 // Note that every cluster that does not lead to violations will be assigned a score, even if
-// the cluster does not concern any of the topology spread constraints.
+// the cluster does not concern any of the topology spread constraints; violationPenalty is
+// subtracted from the score of a cluster for every ScheduleAnyway constraint it violates.
 func evaluateAllConstraints(
 	state framework.CycleStatePluginReadWriter,
 	doNotSchedule, scheduleAnyway []*placementv1beta1.TopologySpreadConstraint,
+	violationPenalty int32,
 ) (violations doNotScheduleViolations, scores topologySpreadScores, err error) {
 	violations = make(doNotScheduleViolations)
 	// Note that this function guarantees that all clusters that do not lead to violations of
@@ -286,7 +288,7 @@ func evaluateAllConstraints(
 			if violated {
 				// A violation happens; since this is a ScheduleAnyway topology spread constraint,
 				// a violation score penalty is applied to the score.
-				scores[clusterName(cluster.Name)] -= maxSkewViolationPenality
+				scores[clusterName(cluster.Name)] -= violationPenalty
 				continue
 			}
 			scores[clusterName(cluster.Name)] += skewChange * int32(skewChangeScoreFactor)
@@ -298,7 +300,11 @@ func evaluateAllConstraints(
 
 // prepareTopologySpreadConstraintsPluginState initializes the state for the plugin to use
 // in the scheduling cycle.
-func prepareTopologySpreadConstraintsPluginState(state framework.CycleStatePluginReadWriter, policy placementv1beta1.PolicySnapshotObj) (*pluginState, error) {
+func prepareTopologySpreadConstraintsPluginState(
+	state framework.CycleStatePluginReadWriter,
+	policy placementv1beta1.PolicySnapshotObj,
+	violationPenalty int32,
+) (*pluginState, error) {
 	// Classify the topology spread constraints.
 	doNotSchedule, scheduleAnyway := classifyConstraints(policy)
 
@@ -306,7 +312,7 @@ func prepareTopologySpreadConstraintsPluginState(state framework.CycleStatePlugi
 	//
 	// Specifically, check if a cluster violates any DoNotSchedule topology spread constraint,
 	// and how much of a skew change it will incur for each constraint.
-	violations, scores, err := evaluateAllConstraints(state, doNotSchedule, scheduleAnyway)
+	violations, scores, err := evaluateAllConstraints(state, doNotSchedule, scheduleAnyway, violationPenalty)
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare topology spread constraints plugin state: %w", err)
 	}
@@ -1617,7 +1617,7 @@ func TestEvaluateAllConstraints(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			state := framework.NewCycleState(tc.clusters, nil, controller.ConvertCRBArrayToBindingObjs(tc.bindings))
 
-			violations, scores, err := evaluateAllConstraints(state, tc.doNotSchedule, tc.scheduleAnyway)
+			violations, scores, err := evaluateAllConstraints(state, tc.doNotSchedule, tc.scheduleAnyway, maxSkewViolationPenality)
 			if err != nil {
 				t.Fatalf("evaluateAllConstraints() = %v, want no error", err)
 			}
@@ -2032,7 +2032,7 @@ func TestPrepareTopologySpreadConstraintsPluginState(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			state := framework.NewCycleState(tc.clusters, nil, controller.ConvertCRBArrayToBindingObjs(tc.bindings))
-			genPluginState, err := prepareTopologySpreadConstraintsPluginState(state, tc.policy)
+			genPluginState, err := prepareTopologySpreadConstraintsPluginState(state, tc.policy, maxSkewViolationPenality)
 			if err != nil {
 				t.Fatalf("prepareTopologySpreadConstraintsPluginState() = %v, want no error", err)
 			}
@@ -19,9 +19,8 @@ package framework
 // Profile specifies the scheduling profile a framework uses; it includes the plugins in use
 // by the framework at each extension point in order.
 //
-// At this moment, since Fleet does not support runtime profiles, all plugins are registered
-// directly to one universal profile, in their instantiated forms, rather than decoupled using
-// a factory registry and instantiated along with the profile's associated framework.
+// Plugins are registered to a profile in their instantiated forms; each profile is associated
+// with its own framework.
 type Profile struct {
 	name string
 
@@ -35,6 +34,10 @@ type Profile struct {
 	// This helps to avoid setting up same plugin multiple times with the framework if the plugin
 	// registers at multiple extension points.
 	registeredPlugins map[string]Plugin
+
+	// scorePluginWeights is a map of the weights of score plugins, keyed by their names; a score
+	// plugin without a weight set has a weight of 1.
+	scorePluginWeights map[string]int32
 }
 
 // WithPostBatchPlugin registers a PostBatchPlugin to the profile.
@@ -72,6 +75,13 @@ func (profile *Profile) WithScorePlugin(plugin ScorePlugin) *Profile {
 	return profile
 }
 
+// WithScorePluginWeight sets the weight of a ScorePlugin registered to the profile; the scores
+// the plugin assigns are multiplied by the weight.
+func (profile *Profile) WithScorePluginWeight(name string, weight int32) *Profile {
+	profile.scorePluginWeights[name] = weight
+	return profile
+}
+
 // Name returns the name of the profile.
 func (profile *Profile) Name() string {
 	return profile.name
@@ -80,7 +90,8 @@ func (profile *Profile) Name() string {
 // NewProfile creates scheduling profile.
 func NewProfile(name string) *Profile {
 	return &Profile{
-		name:              name,
-		registeredPlugins: map[string]Plugin{},
+		name:               name,
+		registeredPlugins:  map[string]Plugin{},
+		scorePluginWeights: map[string]int32{},
 	}
 }
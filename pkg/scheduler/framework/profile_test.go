@@ -41,6 +41,7 @@ func TestProfile(t *testing.T) {
 	profile.WithFilterPlugin(dummyAllPurposePlugin)
 	profile.WithPreScorePlugin(dummyAllPurposePlugin)
 	profile.WithScorePlugin(dummyAllPurposePlugin)
+	profile.WithScorePluginWeight(dummyPluginName, 2)
 
 	wantProfile := &Profile{
 		name:             dummyProfileName,
@@ -52,6 +53,9 @@ func TestProfile(t *testing.T) {
 		registeredPlugins: map[string]Plugin{
 			dummyPluginName: dummyPlugin,
 		},
+		scorePluginWeights: map[string]int32{
+			dummyPluginName: 2,
+		},
 	}
 
 	if !cmp.Equal(profile, wantProfile, cmp.AllowUnexported(Profile{}, DummyAllPurposePlugin{})) {
@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/queue"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+// ScoreClustersFor runs the filter and score plugins for a scheduling policy of the PickN placement
+// type as if the placement had not been scheduled to the excluded cluster, and returns the clusters
+// that pass all the filter plugins with their scores.
+//
+// The excluded cluster, if it passes all the filter plugins, is scored along with the clusters which
+// do not have a scheduled or bound binding of the placement yet; this allows the caller to compare a
+// cluster which a placement currently runs on with the alternatives on an equal footing.
+//
+// Note that this method does not make any change to the bindings or the policy snapshot.
+func (f *framework) ScoreClustersFor(ctx context.Context, placementKey queue.PlacementKey, policy placementv1beta1.PolicySnapshotObj, excludedCluster string) (ScoredClusters, error) {
+	policyRef := klog.KObj(policy)
+
+	if policy.GetPolicySnapshotSpec().Policy == nil || policy.GetPolicySnapshotSpec().Policy.PlacementType != placementv1beta1.PickNPlacementType {
+		err := fmt.Errorf("clusters can only be scored for policies of the PickN placement type")
+		klog.ErrorS(err, "Failed to score clusters", "policySnapshot", policyRef)
+		return nil, controller.NewUnexpectedBehaviorError(err)
+	}
+
+	namespace, name, err := controller.ExtractNamespaceNameFromKey(placementKey)
+	if err != nil {
+		klog.ErrorS(err, "Failed to extract namespace and name from placement key", "policySnapshot", policyRef)
+		return nil, err
+	}
+
+	clusters, err := f.collectClusters(ctx)
+	if err != nil {
+		klog.ErrorS(err, "Failed to collect clusters", "policySnapshot", policyRef)
+		return nil, err
+	}
+	bindings, err := controller.ListBindingsFromKey(ctx, f.uncachedReader, types.NamespacedName{Namespace: namespace, Name: name}, false)
+	if err != nil {
+		klog.ErrorS(err, "Failed to collect bindings", "policySnapshot", policyRef)
+		return nil, err
+	}
+	bound, scheduled, obsolete, _, _, _ := classifyBindings(policy, bindings, clusters)
+
+	// Leave out the bindings associated with the excluded cluster, so that plugins (e.g., the
+	// same placement affinity plugin and the topology spread constraints plugin) consider the
+	// cluster as a candidate.
+	bound = withoutBindingsFor(bound, excludedCluster)
+	scheduled = withoutBindingsFor(scheduled, excludedCluster)
+	state := NewCycleState(clusters, obsolete, bound, scheduled)
+	// Scoring is performed for one binding at a time.
+	state.desiredBatchSize = 1
+	state.batchSizeLimit = 1
+
+	if status := f.runPreFilterPlugins(ctx, state, policy); status.IsInteralError() {
+		klog.ErrorS(status.AsError(), "Failed to run pre filter plugins", "policySnapshot", policyRef)
+		return nil, controller.NewUnexpectedBehaviorError(status.AsError())
+	}
+	passed, _, err := f.runFilterPlugins(ctx, state, policy, clusters)
+	if err != nil {
+		klog.ErrorS(err, "Failed to run filter plugins", "policySnapshot", policyRef)
+		return nil, controller.NewUnexpectedBehaviorError(err)
+	}
+	if status := f.runPreScorePlugins(ctx, state, policy); status.IsInteralError() {
+		klog.ErrorS(status.AsError(), "Failed to run pre-score plugins", "policySnapshot", policyRef)
+		return nil, controller.NewUnexpectedBehaviorError(status.AsError())
+	}
+	scored, err := f.runScorePlugins(ctx, state, policy, passed)
+	if err != nil {
+		klog.ErrorS(err, "Failed to run score plugins", "policySnapshot", policyRef)
+		return nil, controller.NewUnexpectedBehaviorError(err)
+	}
+	return scored, nil
+}
+
+// withoutBindingsFor returns the bindings that are not associated with the given cluster.
+func withoutBindingsFor(bindings []placementv1beta1.BindingObj, clusterName string) []placementv1beta1.BindingObj {
+	kept := make([]placementv1beta1.BindingObj, 0, len(bindings))
+	for _, binding := range bindings {
+		if binding.GetBindingSpec().TargetCluster != clusterName {
+			kept = append(kept, binding)
+		}
+	}
+	return kept
+}
@@ -31,6 +31,12 @@ type ClusterScore struct {
 	// AffinityScore determines how much a binding would satisfy the affinity terms
 	// specified by the user.
 	AffinityScore int32
+	// ExtenderScore determines how much a cluster is preferred by the scheduler extenders
+	// configured in the profile, with the extender weights applied.
+	ExtenderScore int32
+	// ResourceFitScore determines how much headroom a cluster would have left after the
+	// workloads of a placement are placed on it.
+	ResourceFitScore int32
 	// ObsoletePlacementAffinityScore reflects if there has already been an obsolete binding from
 	// the same cluster resource placement associated with the cluster; it value range should
 	// be [0, 1], where 1 signals that an obsolete binding is present.
@@ -47,9 +53,24 @@ type ClusterScore struct {
 func (s1 *ClusterScore) Add(s2 *ClusterScore) {
 	s1.TopologySpreadScore += s2.TopologySpreadScore
 	s1.AffinityScore += s2.AffinityScore
+	s1.ExtenderScore += s2.ExtenderScore
+	s1.ResourceFitScore += s2.ResourceFitScore
 	s1.ObsoletePlacementAffinityScore += s2.ObsoletePlacementAffinityScore
 }
 
+// Scale returns a copy of a ClusterScore with all the scores multiplied by a weight.
+//
+// Note that this will panic if the score is nil.
+func (s1 *ClusterScore) Scale(weight int32) *ClusterScore {
+	return &ClusterScore{
+		TopologySpreadScore:            s1.TopologySpreadScore * weight,
+		AffinityScore:                  s1.AffinityScore * weight,
+		ExtenderScore:                  s1.ExtenderScore * weight,
+		ResourceFitScore:               s1.ResourceFitScore * weight,
+		ObsoletePlacementAffinityScore: s1.ObsoletePlacementAffinityScore * int(weight),
+	}
+}
+
 // Equal returns true if a ClusterScore is equal to another.
 func (s1 *ClusterScore) Equal(s2 *ClusterScore) bool {
 	switch {
@@ -63,20 +84,30 @@ func (s1 *ClusterScore) Equal(s2 *ClusterScore) bool {
 		// Both are not nils.
 		return s1.TopologySpreadScore == s2.TopologySpreadScore &&
 			s1.AffinityScore == s2.AffinityScore &&
+			s1.ExtenderScore == s2.ExtenderScore &&
+			s1.ResourceFitScore == s2.ResourceFitScore &&
 			s1.ObsoletePlacementAffinityScore == s2.ObsoletePlacementAffinityScore
 	}
 }
 
+// Total returns the sum of the scores of a ClusterScore which reflect how well a cluster meets the
+// scheduling policy, with the plugin weights already applied.
+//
+// Note that the obsolete placement affinity score is left out, as it only serves as a tie-breaker.
+func (s1 *ClusterScore) Total() int64 {
+	return int64(s1.TopologySpreadScore) + int64(s1.AffinityScore) + int64(s1.ExtenderScore) + int64(s1.ResourceFitScore)
+}
+
 // Less returns true if a ClusterScore is less than another.
 //
+// The scores are compared by their totals, so that the weights of the score plugins decide how much
+// each plugin contributes to the ranking of the clusters; when the totals are the same, the obsolete
+// placement affinity score breaks the tie.
+//
 // Note that this will panic if either score is nil.
 func (s1 *ClusterScore) Less(s2 *ClusterScore) bool {
-	if s1.TopologySpreadScore != s2.TopologySpreadScore {
-		return s1.TopologySpreadScore < s2.TopologySpreadScore
-	}
-
-	if s1.AffinityScore != s2.AffinityScore {
-		return s1.AffinityScore < s2.AffinityScore
+	if t1, t2 := s1.Total(), s2.Total(); t1 != t2 {
+		return t1 < t2
 	}
 
 	return s1.ObsoletePlacementAffinityScore < s2.ObsoletePlacementAffinityScore
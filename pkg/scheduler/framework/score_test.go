@@ -39,6 +39,8 @@ func TestClusterScoreAdd(t *testing.T) {
 	s2 := &ClusterScore{
 		TopologySpreadScore:            1,
 		AffinityScore:                  5,
+		ExtenderScore:                  20,
+		ResourceFitScore:               40,
 		ObsoletePlacementAffinityScore: 1,
 	}
 
@@ -46,6 +48,8 @@ func TestClusterScoreAdd(t *testing.T) {
 	want := &ClusterScore{
 		TopologySpreadScore:            1,
 		AffinityScore:                  5,
+		ExtenderScore:                  20,
+		ResourceFitScore:               40,
 		ObsoletePlacementAffinityScore: 1,
 	}
 	if diff := cmp.Diff(s1, want); diff != "" {
@@ -53,6 +57,32 @@ func TestClusterScoreAdd(t *testing.T) {
 	}
 }
 
+// TestClusterScoreScale tests the Scale() method of ClusterScore.
+func TestClusterScoreScale(t *testing.T) {
+	s := &ClusterScore{
+		TopologySpreadScore:            1,
+		AffinityScore:                  5,
+		ExtenderScore:                  20,
+		ResourceFitScore:               40,
+		ObsoletePlacementAffinityScore: 1,
+	}
+
+	got := s.Scale(3)
+	want := &ClusterScore{
+		TopologySpreadScore:            3,
+		AffinityScore:                  15,
+		ExtenderScore:                  60,
+		ResourceFitScore:               120,
+		ObsoletePlacementAffinityScore: 3,
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Fatalf("Scale() diff (-got, +want): %s", diff)
+	}
+	if s.AffinityScore != 5 {
+		t.Fatalf("Scale() modified the original score: %v", s)
+	}
+}
+
 // TestClusterScoreEqual tests the Equal() method of ClusterScore.
 func TestClusterScoreEqual(t *testing.T) {
 	testCases := []struct {
@@ -75,6 +105,18 @@ func TestClusterScoreEqual(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "s1 is not equal to s2 in extender score",
+			s1: &ClusterScore{
+				TopologySpreadScore: 1,
+				AffinityScore:       5,
+				ExtenderScore:       10,
+			},
+			s2: &ClusterScore{
+				TopologySpreadScore: 1,
+				AffinityScore:       5,
+			},
+		},
 		{
 			name: "s1 is not equal to s2",
 			s1: &ClusterScore{
@@ -119,6 +161,20 @@ func TestClusterScoreEqual(t *testing.T) {
 	}
 }
 
+func TestClusterScoreTotal(t *testing.T) {
+	score := &ClusterScore{
+		TopologySpreadScore:            -2,
+		AffinityScore:                  10,
+		ExtenderScore:                  300,
+		ResourceFitScore:               40,
+		ObsoletePlacementAffinityScore: 1,
+	}
+	want := int64(348)
+	if got := score.Total(); got != want {
+		t.Fatalf("Total() = %d, want %d", got, want)
+	}
+}
+
 func TestClusterScoreLess(t *testing.T) {
 	testCases := []struct {
 		name string
@@ -134,7 +190,7 @@ func TestClusterScoreLess(t *testing.T) {
 			},
 			s2: &ClusterScore{
 				TopologySpreadScore: 1,
-				AffinityScore:       20,
+				AffinityScore:       10,
 			},
 			want: true,
 		},
@@ -150,6 +206,78 @@ func TestClusterScoreLess(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "s1 is less than s2 in extender score",
+			s1: &ClusterScore{
+				TopologySpreadScore:            1,
+				AffinityScore:                  10,
+				ExtenderScore:                  0,
+				ObsoletePlacementAffinityScore: 1,
+			},
+			s2: &ClusterScore{
+				TopologySpreadScore:            1,
+				AffinityScore:                  10,
+				ExtenderScore:                  50,
+				ObsoletePlacementAffinityScore: 0,
+			},
+			want: true,
+		},
+		{
+			name: "s1 is less than s2 in resource fit score",
+			s1: &ClusterScore{
+				TopologySpreadScore:            1,
+				AffinityScore:                  10,
+				ExtenderScore:                  50,
+				ResourceFitScore:               30,
+				ObsoletePlacementAffinityScore: 1,
+			},
+			s2: &ClusterScore{
+				TopologySpreadScore:            1,
+				AffinityScore:                  10,
+				ExtenderScore:                  50,
+				ResourceFitScore:               70,
+				ObsoletePlacementAffinityScore: 0,
+			},
+			want: true,
+		},
+		{
+			name: "s1 is less than s2 in total score, with a higher topology spread score",
+			s1: &ClusterScore{
+				TopologySpreadScore: 1,
+				AffinityScore:       10,
+			},
+			s2: &ClusterScore{
+				TopologySpreadScore: 0,
+				AffinityScore:       20,
+			},
+			want: true,
+		},
+		{
+			name: "s1 is less than s2 in total score, with a higher weighted resource fit score",
+			s1: (&ClusterScore{
+				AffinityScore:    30,
+				ResourceFitScore: 10,
+			}).Scale(1),
+			s2: (&ClusterScore{
+				AffinityScore:    10,
+				ResourceFitScore: 20,
+			}).Scale(2),
+			want: true,
+		},
+		{
+			name: "s1 is less than s2 in total score, with negative topology spread scores",
+			s1: &ClusterScore{
+				TopologySpreadScore: -5,
+				AffinityScore:       20,
+				ExtenderScore:       10,
+			},
+			s2: &ClusterScore{
+				TopologySpreadScore: -1,
+				AffinityScore:       20,
+				ExtenderScore:       10,
+			},
+			want: true,
+		},
 		{
 			name: "s1 is less than s2 in active or creating binding score",
 			s1: &ClusterScore{
@@ -164,6 +292,20 @@ func TestClusterScoreLess(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "s1 is less than s2 in active or creating binding score, with the same total score",
+			s1: &ClusterScore{
+				TopologySpreadScore:            2,
+				AffinityScore:                  10,
+				ObsoletePlacementAffinityScore: 0,
+			},
+			s2: &ClusterScore{
+				TopologySpreadScore:            1,
+				AffinityScore:                  11,
+				ObsoletePlacementAffinityScore: 1,
+			},
+			want: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -568,7 +710,7 @@ func TestScoredClustersString(t *testing.T) {
 					},
 				},
 			},
-			expected: "ScoredClusters{Cluster{Name: cluster-a, Score: &{1 2 0}}}",
+			expected: "ScoredClusters{Cluster{Name: cluster-a, Score: &{1 2 0 0 0}}}",
 		},
 		{
 			name: "multiple clusters",
@@ -598,7 +740,7 @@ func TestScoredClustersString(t *testing.T) {
 					},
 				},
 			},
-			expected: "ScoredClusters{Cluster{Name: cluster-a, Score: &{100 50 1}}, Cluster{Name: cluster-b, Score: &{0 0 0}}, Cluster{Name: cluster-c, Score: &{-10 -5 0}}}",
+			expected: "ScoredClusters{Cluster{Name: cluster-a, Score: &{100 50 0 0 1}}, Cluster{Name: cluster-b, Score: &{0 0 0 0 0}}, Cluster{Name: cluster-c, Score: &{-10 -5 0 0 0}}}",
 		},
 	}
 
@@ -0,0 +1,124 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"k8s.io/klog/v2"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/queue"
+	"go.goms.io/fleet/pkg/utils/annotations"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+// SimulateSchedulingFor runs the scheduling cycles for a scheduling policy in simulation, and returns the
+// scheduling decisions which the scheduler would make for the policy.
+//
+// The policy is scheduled as if it were the latest policy of a placement which has no scheduled or bound
+// bindings yet, i.e., the way a newly created placement, or a placement whose scheduling policy has changed,
+// is scheduled. For policies of the PickN placement type, the scheduling cycles which the scheduler would
+// run in batches (as limited by the post-batch plugins) are all run in one go.
+//
+// Note that this method does not make any change to the bindings or the policy snapshot.
+func (f *framework) SimulateSchedulingFor(ctx context.Context, placementKey queue.PlacementKey, policy placementv1beta1.PolicySnapshotObj) ([]placementv1beta1.ClusterDecision, error) {
+	policyRef := klog.KObj(policy)
+
+	clusters, err := f.collectClusters(ctx)
+	if err != nil {
+		klog.ErrorS(err, "Failed to collect clusters", "policySnapshot", policyRef)
+		return nil, err
+	}
+
+	placementPolicy := policy.GetPolicySnapshotSpec().Policy
+	switch {
+	case placementPolicy == nil || placementPolicy.PlacementType == placementv1beta1.PickAllPlacementType:
+		state := NewCycleState(clusters, nil)
+		scored, filtered, err := f.runAllPluginsForPickAllPlacementType(ctx, state, policy, clusters)
+		if err != nil {
+			klog.ErrorS(err, "Failed to run all plugins (pickAll placement type)", "policySnapshot", policyRef)
+			return nil, err
+		}
+		sort.Sort(scored)
+		picked, _, _, err := crossReferencePickedClustersAndDeDupBindings(placementKey, policy, scored, nil, nil)
+		if err != nil {
+			klog.ErrorS(err, "Failed to cross-reference picked clusters", "policySnapshot", policyRef)
+			return nil, err
+		}
+		return newSchedulingDecisionsFromBindings(f.maxUnselectedClusterDecisionCount, nil, filtered, picked), nil
+	case placementPolicy.PlacementType == placementv1beta1.PickFixedPlacementType:
+		valid, invalid, notFound := f.crossReferenceClustersWithTargetNames(clusters, placementPolicy.ClusterNames)
+		return newSchedulingDecisionsForPickFixedPlacementType(valid, invalid, notFound), nil
+	case placementPolicy.PlacementType == placementv1beta1.PickNPlacementType:
+		return f.simulateSchedulingForPickNPlacementType(ctx, placementKey, policy, clusters)
+	default:
+		// This normally should never occur.
+		err := fmt.Errorf("the placement type %s is unknown", placementPolicy.PlacementType)
+		klog.ErrorS(err, "Failed to simulate scheduling", "policySnapshot", policyRef)
+		return nil, controller.NewUnexpectedBehaviorError(err)
+	}
+}
+
+// simulateSchedulingForPickNPlacementType runs the scheduling cycles for a scheduling policy of the PickN
+// placement type in simulation, until the scheduler would stop requeueing the policy.
+func (f *framework) simulateSchedulingForPickNPlacementType(
+	ctx context.Context,
+	placementKey queue.PlacementKey,
+	policy placementv1beta1.PolicySnapshotObj,
+	clusters []clusterv1beta1.MemberCluster,
+) ([]placementv1beta1.ClusterDecision, error) {
+	policyRef := klog.KObj(policy)
+
+	numOfClusters, err := annotations.ExtractNumOfClustersFromPolicySnapshot(policy)
+	if err != nil {
+		klog.ErrorS(err, "Failed to extract number of clusters required from policy snapshot", "policySnapshot", policyRef)
+		return nil, controller.NewUnexpectedBehaviorError(err)
+	}
+
+	// The bindings which the simulated scheduling cycles would have created so far; they are
+	// fed to the later cycles as scheduled bindings, as the scheduler would see them.
+	var scheduled []placementv1beta1.BindingObj
+	var notPicked ScoredClusters
+	var filtered []*filteredClusterWithStatus
+	for len(scheduled) < numOfClusters {
+		state := NewCycleState(clusters, nil, scheduled)
+		var scored ScoredClusters
+		scored, filtered, err = f.runAllPluginsForPickNPlacementType(ctx, state, policy, numOfClusters, len(scheduled), clusters)
+		if err != nil {
+			klog.ErrorS(err, "Failed to run all plugins (pickN placement type)", "policySnapshot", policyRef)
+			return nil, err
+		}
+		numOfClustersToPick := calcNumOfClustersToSelect(state.desiredBatchSize, state.batchSizeLimit, len(scored))
+		var picked ScoredClusters
+		picked, notPicked = pickTopNScoredClusters(scored, numOfClustersToPick)
+		toCreate, _, _, err := crossReferencePickedClustersAndDeDupBindings(placementKey, policy, picked, nil, nil)
+		if err != nil {
+			klog.ErrorS(err, "Failed to cross-reference picked clusters", "policySnapshot", policyRef)
+			return nil, err
+		}
+		scheduled = append(scheduled, toCreate...)
+
+		if !shouldRequeue(state.desiredBatchSize, state.batchSizeLimit, len(toCreate)) {
+			break
+		}
+	}
+	return newSchedulingDecisionsFromBindings(f.maxUnselectedClusterDecisionCount, notPicked, filtered, scheduled), nil
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/clustereligibilitychecker"
+	"go.goms.io/fleet/pkg/scheduler/queue"
+	"go.goms.io/fleet/pkg/utils/parallelizer"
+)
+
+// TestSimulateSchedulingFor tests the SimulateSchedulingFor method.
+func TestSimulateSchedulingFor(t *testing.T) {
+	regionLabel := "region"
+	// Clusters in the east region pass the filter plugin; each cluster is scored by its preset score.
+	clusterScores := map[string]int32{
+		"cluster-1": 30,
+		"cluster-2": 20,
+		"cluster-3": 10,
+		"cluster-4": 40,
+	}
+	var clusters []client.Object
+	for name := range clusterScores {
+		region := "east"
+		if name == "cluster-4" {
+			region = "west"
+		}
+		clusters = append(clusters, &clusterv1beta1.MemberCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{regionLabel: region},
+			},
+		})
+	}
+
+	pluginName := fmt.Sprintf(dummyAllPurposePluginNameFormat, 0)
+	newPlugin := func(batchSizeLimit int) *DummyAllPurposePlugin {
+		return &DummyAllPurposePlugin{
+			name: pluginName,
+			postBatchRunner: func(_ context.Context, _ CycleStatePluginReadWriter, _ placementv1beta1.PolicySnapshotObj) (int, *Status) {
+				return batchSizeLimit, nil
+			},
+			filterRunner: func(_ context.Context, state CycleStatePluginReadWriter, _ placementv1beta1.PolicySnapshotObj, cluster *clusterv1beta1.MemberCluster) *Status {
+				// Mimic the same placement affinity plugin, which filters out the clusters that have
+				// been picked already.
+				if state.HasScheduledOrBoundBindingFor(cluster.Name) || cluster.Labels[regionLabel] != "east" {
+					return NewNonErrorStatus(ClusterUnschedulable, pluginName)
+				}
+				return nil
+			},
+			scoreRunner: func(_ context.Context, _ CycleStatePluginReadWriter, _ placementv1beta1.PolicySnapshotObj, cluster *clusterv1beta1.MemberCluster) (*ClusterScore, *Status) {
+				return &ClusterScore{AffinityScore: clusterScores[cluster.Name]}, nil
+			},
+		}
+	}
+	newPolicy := func(policy *placementv1beta1.PlacementPolicy) *placementv1beta1.ClusterSchedulingPolicySnapshot {
+		snapshot := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+			ObjectMeta: metav1.ObjectMeta{Name: "preview"},
+			Spec:       placementv1beta1.SchedulingPolicySnapshotSpec{Policy: policy},
+		}
+		if policy != nil && policy.NumberOfClusters != nil {
+			snapshot.Annotations = map[string]string{
+				placementv1beta1.NumberOfClustersAnnotation: fmt.Sprintf("%d", *policy.NumberOfClusters),
+			}
+		}
+		return snapshot
+	}
+	selected := func(name string) placementv1beta1.ClusterDecision {
+		return placementv1beta1.ClusterDecision{
+			ClusterName: name,
+			Selected:    true,
+			ClusterScore: &placementv1beta1.ClusterScore{
+				AffinityScore:       ptr.To(clusterScores[name]),
+				TopologySpreadScore: ptr.To(int32(0)),
+			},
+		}
+	}
+	selectedWithoutScore := func(name string) placementv1beta1.ClusterDecision {
+		d := selected(name)
+		d.ClusterScore = &placementv1beta1.ClusterScore{AffinityScore: ptr.To(int32(0)), TopologySpreadScore: ptr.To(int32(0))}
+		return d
+	}
+	notSelected := func(name string) placementv1beta1.ClusterDecision {
+		return placementv1beta1.ClusterDecision{ClusterName: name}
+	}
+	notPicked := func(name string) placementv1beta1.ClusterDecision {
+		d := selected(name)
+		d.Selected = false
+		return d
+	}
+
+	testCases := []struct {
+		name           string
+		policy         *placementv1beta1.PlacementPolicy
+		batchSizeLimit int
+		want           []placementv1beta1.ClusterDecision
+	}{
+		{
+			name:           "no policy",
+			batchSizeLimit: 10,
+			want: []placementv1beta1.ClusterDecision{
+				selectedWithoutScore("cluster-1"),
+				selectedWithoutScore("cluster-2"),
+				selectedWithoutScore("cluster-3"),
+				notSelected("cluster-4"),
+			},
+		},
+		{
+			name: "pickFixed",
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType: placementv1beta1.PickFixedPlacementType,
+				ClusterNames:  []string{"cluster-1", "cluster-5"},
+			},
+			want: []placementv1beta1.ClusterDecision{
+				// The cluster is not eligible as its member agent has not joined yet.
+				notSelected("cluster-1"),
+				notSelected("cluster-5"),
+			},
+		},
+		{
+			name: "pickN",
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:    placementv1beta1.PickNPlacementType,
+				NumberOfClusters: ptr.To(int32(2)),
+			},
+			batchSizeLimit: 2,
+			want: []placementv1beta1.ClusterDecision{
+				selected("cluster-1"),
+				selected("cluster-2"),
+				notPicked("cluster-3"),
+				notSelected("cluster-4"),
+			},
+		},
+		{
+			name: "pickN in batches",
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:    placementv1beta1.PickNPlacementType,
+				NumberOfClusters: ptr.To(int32(2)),
+			},
+			batchSizeLimit: 1,
+			want: []placementv1beta1.ClusterDecision{
+				selected("cluster-1"),
+				selected("cluster-2"),
+				notPicked("cluster-3"),
+				notSelected("cluster-4"),
+			},
+		},
+		{
+			name: "pickN with not enough clusters",
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:    placementv1beta1.PickNPlacementType,
+				NumberOfClusters: ptr.To(int32(5)),
+			},
+			batchSizeLimit: 5,
+			want: []placementv1beta1.ClusterDecision{
+				selected("cluster-1"),
+				selected("cluster-2"),
+				selected("cluster-3"),
+				notSelected("cluster-4"),
+			},
+		},
+		{
+			name: "pickN with no cluster to pick",
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:    placementv1beta1.PickNPlacementType,
+				NumberOfClusters: ptr.To(int32(0)),
+			},
+			want: []placementv1beta1.ClusterDecision{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(clusters...).
+				Build()
+			profile := NewProfile("TestOnly")
+			plugin := newPlugin(tc.batchSizeLimit)
+			profile.WithPostBatchPlugin(plugin).WithFilterPlugin(plugin).WithScorePlugin(plugin)
+			f := &framework{
+				profile:                           profile,
+				client:                            fakeClient,
+				uncachedReader:                    fakeClient,
+				parallelizer:                      parallelizer.NewParallelizer(parallelizer.DefaultNumOfWorkers),
+				maxUnselectedClusterDecisionCount: 20,
+				clusterEligibilityChecker:         clustereligibilitychecker.New(),
+			}
+
+			got, err := f.SimulateSchedulingFor(context.Background(), queue.PlacementKey("test-placement"), newPolicy(tc.policy))
+			if err != nil {
+				t.Fatalf("SimulateSchedulingFor() = %v, want no error", err)
+			}
+			lessFunc := func(d1, d2 placementv1beta1.ClusterDecision) bool {
+				if d1.ClusterName != d2.ClusterName {
+					return d1.ClusterName < d2.ClusterName
+				}
+				return d1.Selected
+			}
+			if diff := cmp.Diff(got, tc.want,
+				cmpopts.IgnoreFields(placementv1beta1.ClusterDecision{}, "Reason"),
+				cmpopts.SortSlices(lessFunc), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("SimulateSchedulingFor() decisions mismatch (-got, +want):\n%s", diff)
+			}
+		})
+	}
+}
@@ -18,22 +18,38 @@ limitations under the License.
 package profile
 
 import (
+	"fmt"
+
+	apiErrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"go.goms.io/fleet/pkg/scheduler/config"
 	"go.goms.io/fleet/pkg/scheduler/framework"
 	"go.goms.io/fleet/pkg/scheduler/framework/plugins/clusteraffinity"
 	"go.goms.io/fleet/pkg/scheduler/framework/plugins/clustereligibility"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/extender"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/placementaffinity"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/resourcefit"
 	"go.goms.io/fleet/pkg/scheduler/framework/plugins/sameplacementaffinity"
 	"go.goms.io/fleet/pkg/scheduler/framework/plugins/tainttoleration"
 	"go.goms.io/fleet/pkg/scheduler/framework/plugins/topologyspreadconstraints"
 )
 
 const (
-	// defaultProfileName is the default name for the scheduling profile.
-	defaultProfileName = "DefaultProfile"
+	// DefaultProfileName is the name of the default scheduling profile, which schedules the placements
+	// that do not specify a scheduler name.
+	DefaultProfileName = "DefaultProfile"
 )
 
 // Options holds the configuration options for creating a scheduling profile.
 type Options struct {
 	ClusterAffinityPlugin *clusteraffinity.Plugin
+	// Extenders are the scheduler extenders of the default profile, which run after the built-in plugins.
+	// They can only be set if the scheduler configuration does not override the default profile.
+	Extenders []extender.Config
+
+	// ResourcePlacementEnabled specifies whether namespace-scoped placements are enabled in the
+	// fleet, which the plugins might need to account for.
+	ResourcePlacementEnabled bool
 }
 
 // NewDefaultProfile creates a default scheduling profile.
@@ -43,22 +59,213 @@ func NewDefaultProfile() *framework.Profile {
 
 // NewProfile creates a scheduling profile with the given options.
 func NewProfile(opts Options) *framework.Profile {
-	p := framework.NewProfile(defaultProfileName)
+	p := framework.NewProfile(DefaultProfileName)
+	for _, plugin := range defaultPlugins(opts) {
+		register(p, plugin)
+	}
+	for i := range opts.Extenders {
+		extenderPlugin := extender.New(opts.Extenders[i])
+		register(p, &extenderPlugin)
+	}
+	return p
+}
+
+// NewProfiles creates the scheduling profiles defined in the scheduler configuration with the given
+// options; the default profile is always included, unless the configuration overrides it.
+func NewProfiles(cfg *config.SchedulerConfiguration, opts Options) ([]*framework.Profile, error) {
+	profiles := make([]*framework.Profile, 0, len(cfg.Profiles)+1)
+	hasDefaultProfile := false
+	allErr := make([]error, 0)
+	for i := range cfg.Profiles {
+		p, err := NewProfileFromConfig(&cfg.Profiles[i], opts)
+		if err != nil {
+			allErr = append(allErr, err)
+			continue
+		}
+		if p.Name() == DefaultProfileName {
+			hasDefaultProfile = true
+			if len(opts.Extenders) > 0 {
+				allErr = append(allErr, fmt.Errorf("profile %q is overridden by the scheduler configuration, which must also specify its extenders", DefaultProfileName))
+			}
+		}
+		profiles = append(profiles, p)
+	}
+	if len(allErr) > 0 {
+		return nil, apiErrors.NewAggregate(allErr)
+	}
+	if !hasDefaultProfile {
+		profiles = append(profiles, NewProfile(opts))
+	}
+	return profiles, nil
+}
 
-	// default plugin list
+// NewProfileFromConfig creates a scheduling profile from its configuration with the given options.
+//
+// The enabled plugins run in the order of the default plugins, followed by the explicitly enabled
+// ones in the order they are specified, and then the extenders. The optional plugins run only if
+// they are explicitly enabled.
+func NewProfileFromConfig(cfg *config.Profile, opts Options) (*framework.Profile, error) {
+	defaults := defaultPlugins(opts)
+	optionals := optionalPlugins(opts)
+	known := make(map[string]framework.Plugin, len(defaults)+len(optionals))
+	for _, plugin := range append(defaults, optionals...) {
+		known[plugin.Name()] = plugin
+	}
+
+	allErr := make([]error, 0)
+	disabled := make(map[string]bool)
+	var explicitlyEnabled []string
+	if cfg.Plugins != nil {
+		for _, name := range cfg.Plugins.Disabled {
+			if _, ok := known[name]; !ok && name != config.AllPlugins {
+				allErr = append(allErr, fmt.Errorf("profile %q disables unknown plugin %q", cfg.SchedulerName, name))
+			}
+			disabled[name] = true
+		}
+		explicitlyEnabled = cfg.Plugins.Enabled
+	}
+
+	enabled := make(map[string]bool, len(defaults))
+	plugins := make([]framework.Plugin, 0, len(defaults))
+	if !disabled[config.AllPlugins] {
+		for _, plugin := range defaults {
+			if !disabled[plugin.Name()] {
+				enabled[plugin.Name()] = true
+				plugins = append(plugins, plugin)
+			}
+		}
+	}
+	for _, name := range explicitlyEnabled {
+		plugin, ok := known[name]
+		switch {
+		case !ok:
+			allErr = append(allErr, fmt.Errorf("profile %q enables unknown plugin %q", cfg.SchedulerName, name))
+		case !enabled[name]:
+			enabled[name] = true
+			plugins = append(plugins, plugin)
+		}
+	}
+	// The cluster eligibility plugin keeps the placements away from the clusters which have left
+	// the fleet or become unhealthy; it cannot be disabled.
+	clusterEligibilityPlugin := clustereligibility.New()
+	if eligibilityPluginName := clusterEligibilityPlugin.Name(); !enabled[eligibilityPluginName] {
+		allErr = append(allErr, fmt.Errorf("profile %q must not disable plugin %q", cfg.SchedulerName, eligibilityPluginName))
+	}
+
+	p := framework.NewProfile(cfg.SchedulerName)
+	for _, pc := range cfg.PluginConfig {
+		plugin, ok := known[pc.Name]
+		if !ok || !enabled[pc.Name] {
+			allErr = append(allErr, fmt.Errorf("profile %q configures plugin %q, which is not enabled", cfg.SchedulerName, pc.Name))
+			continue
+		}
+		if pc.Args != nil && len(pc.Args.Raw) > 0 {
+			configured, err := newPluginWithArgs(plugin, pc.Args.Raw, opts)
+			if err != nil {
+				allErr = append(allErr, fmt.Errorf("profile %q sets invalid arguments for plugin %q: %w", cfg.SchedulerName, pc.Name, err))
+				continue
+			}
+			for i := range plugins {
+				if plugins[i].Name() == pc.Name {
+					plugins[i] = configured
+				}
+			}
+		}
+		if pc.Weight != nil {
+			if _, ok := plugin.(framework.ScorePlugin); !ok {
+				allErr = append(allErr, fmt.Errorf("profile %q sets a weight for plugin %q, which is not a score plugin", cfg.SchedulerName, pc.Name))
+				continue
+			}
+			p.WithScorePluginWeight(pc.Name, *pc.Weight)
+		}
+	}
+
+	for _, plugin := range plugins {
+		register(p, plugin)
+	}
+	for i := range cfg.Extenders {
+		extenderPlugin := extender.New(cfg.Extenders[i])
+		register(p, &extenderPlugin)
+	}
+
+	if len(allErr) > 0 {
+		return nil, apiErrors.NewAggregate(allErr)
+	}
+	return p, nil
+}
+
+// defaultPlugins returns the default plugins in the order they run.
+func defaultPlugins(opts Options) []framework.Plugin {
 	clusterAffinityPlugin := clusteraffinity.New()
 	if opts.ClusterAffinityPlugin != nil {
 		clusterAffinityPlugin = *opts.ClusterAffinityPlugin
 	}
 	clusterEligibilityPlugin := clustereligibility.New()
+	taintTolerationPlugin := tainttoleration.New()
 	samePlacementAffinityPlugin := sameplacementaffinity.New()
 	topologySpreadConstraintsPlugin := topologyspreadconstraints.New()
-	taintTolerationPlugin := tainttoleration.New()
+	placementAffinityPlugin := placementaffinity.New()
 
-	p.WithPostBatchPlugin(&topologySpreadConstraintsPlugin).
-		WithPreFilterPlugin(&clusterAffinityPlugin).WithPreFilterPlugin(&topologySpreadConstraintsPlugin).
-		WithFilterPlugin(&clusterAffinityPlugin).WithFilterPlugin(&clusterEligibilityPlugin).WithFilterPlugin(&taintTolerationPlugin).WithFilterPlugin(&samePlacementAffinityPlugin).WithFilterPlugin(&topologySpreadConstraintsPlugin).
-		WithPreScorePlugin(&clusterAffinityPlugin).WithPreScorePlugin(&topologySpreadConstraintsPlugin).
-		WithScorePlugin(&clusterAffinityPlugin).WithScorePlugin(&samePlacementAffinityPlugin).WithScorePlugin(&topologySpreadConstraintsPlugin)
-	return p
+	return []framework.Plugin{
+		&clusterAffinityPlugin,
+		&clusterEligibilityPlugin,
+		&taintTolerationPlugin,
+		&samePlacementAffinityPlugin,
+		&topologySpreadConstraintsPlugin,
+		&placementAffinityPlugin,
+	}
+}
+
+// optionalPlugins returns the plugins which are available but not enabled by default.
+func optionalPlugins(opts Options) []framework.Plugin {
+	resourceFitPlugin := resourcefit.New(resourcefit.WithResourcePlacementEnabled(opts.ResourcePlacementEnabled))
+
+	return []framework.Plugin{
+		&resourceFitPlugin,
+	}
+}
+
+// newPluginWithArgs returns a copy of a built-in plugin configured with the given arguments,
+// which are decoded strictly into the typed arguments of the plugin.
+func newPluginWithArgs(plugin framework.Plugin, raw []byte, opts Options) (framework.Plugin, error) {
+	switch plugin.(type) {
+	case *resourcefit.Plugin:
+		args, err := resourcefit.DecodeArgs(raw)
+		if err != nil {
+			return nil, err
+		}
+		resourceFitPlugin := resourcefit.New(
+			resourcefit.WithResourcePlacementEnabled(opts.ResourcePlacementEnabled),
+			resourcefit.WithArgs(args),
+		)
+		return &resourceFitPlugin, nil
+	case *topologyspreadconstraints.Plugin:
+		args, err := topologyspreadconstraints.DecodeArgs(raw)
+		if err != nil {
+			return nil, err
+		}
+		topologySpreadConstraintsPlugin := topologyspreadconstraints.New(topologyspreadconstraints.WithArgs(args))
+		return &topologySpreadConstraintsPlugin, nil
+	default:
+		return nil, fmt.Errorf("the plugin does not accept any arguments")
+	}
+}
+
+// register registers a plugin to the profile at all the extension points it implements.
+func register(p *framework.Profile, plugin framework.Plugin) {
+	if pl, ok := plugin.(framework.PostBatchPlugin); ok {
+		p.WithPostBatchPlugin(pl)
+	}
+	if pl, ok := plugin.(framework.PreFilterPlugin); ok {
+		p.WithPreFilterPlugin(pl)
+	}
+	if pl, ok := plugin.(framework.FilterPlugin); ok {
+		p.WithFilterPlugin(pl)
+	}
+	if pl, ok := plugin.(framework.PreScorePlugin); ok {
+		p.WithPreScorePlugin(pl)
+	}
+	if pl, ok := plugin.(framework.ScorePlugin); ok {
+		p.WithScorePlugin(pl)
+	}
 }
@@ -25,6 +25,7 @@ import (
 	"sync"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	apiErrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
@@ -42,18 +43,26 @@ import (
 	"go.goms.io/fleet/pkg/utils/controller"
 )
 
+const (
+	// schedulingProfileNotFoundReason is the reason of the event emitted when the scheduling profile
+	// a placement specifies is not found.
+	schedulingProfileNotFoundReason = "SchedulingProfileNotFound"
+)
+
 // Scheduler is the scheduler for Fleet workloads.
 type Scheduler struct {
 	// name is the name of the scheduler.
 	name string
 
-	// framework is the scheduling framework in use by the scheduler.
-	//
-	// At this stage, a scheduler is always associated with one scheduling framework; in the long
-	// run, multiple frameworks may be supported, to allow the usage of varying scheduling configurations
-	// for different types of workloads.
+	// framework is the scheduling framework of the default profile in use by the scheduler; it
+	// schedules the placements that do not specify a scheduler name.
 	framework framework.Framework
 
+	// profileFrameworks is the scheduling frameworks of the named profiles in use by the scheduler,
+	// keyed by the profile names; they schedule the placements that specify a scheduler name, which
+	// allows the usage of varying scheduling configurations for different types of workloads.
+	profileFrameworks map[string]framework.Framework
+
 	// queue is the work queue in use by the scheduler; the scheduler pulls items from the queue and
 	// performs scheduling in accordance with them.
 	queue queue.PlacementSchedulingQueue
@@ -78,6 +87,16 @@ type Scheduler struct {
 	eventRecorder record.EventRecorder
 }
 
+// Option is the function for configuring a scheduler.
+type Option func(*Scheduler)
+
+// WithProfileFrameworks sets the scheduling frameworks of the named profiles, keyed by the profile names.
+func WithProfileFrameworks(frameworks map[string]framework.Framework) Option {
+	return func(s *Scheduler) {
+		s.profileFrameworks = frameworks
+	}
+}
+
 // NewScheduler creates a scheduler.
 func NewScheduler(
 	name string,
@@ -85,8 +104,9 @@ func NewScheduler(
 	queue queue.PlacementSchedulingQueue,
 	manager ctrl.Manager,
 	workerNumber int,
+	opts ...Option,
 ) *Scheduler {
-	return &Scheduler{
+	s := &Scheduler{
 		name:           name,
 		framework:      framework,
 		queue:          queue,
@@ -96,6 +116,10 @@ func NewScheduler(
 		workerNumber:   workerNumber,
 		eventRecorder:  manager.GetEventRecorderFor(name),
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // ScheduleOnce performs scheduling for one single item pulled from the work queue.
@@ -205,12 +229,25 @@ func (s *Scheduler) scheduleOnce(ctx context.Context, worker int) {
 		return
 	}
 
+	// Find the scheduling framework of the profile the placement specifies.
+	fw, err := s.FrameworkFor(latestPolicySnapshot)
+	if err != nil {
+		klog.ErrorS(err, "Failed to find the scheduling profile of placement", "placement", placementKey)
+		s.eventRecorder.Event(placement, corev1.EventTypeWarning, schedulingProfileNotFoundReason, err.Error())
+		// No requeue is needed; the scheduler will be triggered again when the placement
+		// specifies another profile.
+
+		// Untrack the key from the rate limiter.
+		s.queue.Forget(placementKey)
+		return
+	}
+
 	// Run the scheduling cycle.
 	//
 	// Note that the scheduler will enter this cycle as long as the placement is active and an active
 	// policy snapshot has been produced.
 	cycleStartTime := time.Now()
-	res, err := s.framework.RunSchedulingCycleFor(ctx, placementKey, latestPolicySnapshot)
+	res, err := fw.RunSchedulingCycleFor(ctx, placementKey, latestPolicySnapshot)
 	if err != nil {
 		if errors.Is(err, controller.ErrUnexpectedBehavior) {
 			// The placement is in an unexpected state; this is a scheduler-side error, and
@@ -400,6 +437,19 @@ func (s *Scheduler) lookupLatestPolicySnapshot(ctx context.Context, placement fl
 	}
 }
 
+// FrameworkFor returns the scheduling framework of the profile which a policy snapshot specifies.
+func (s *Scheduler) FrameworkFor(policy fleetv1beta1.PolicySnapshotObj) (framework.Framework, error) {
+	spec := policy.GetPolicySnapshotSpec()
+	if spec.Policy == nil || spec.Policy.SchedulerName == "" {
+		return s.framework, nil
+	}
+	fw, ok := s.profileFrameworks[spec.Policy.SchedulerName]
+	if !ok {
+		return nil, controller.NewUserError(fmt.Errorf("scheduling profile %q is not found", spec.Policy.SchedulerName))
+	}
+	return fw, nil
+}
+
 // addSchedulerCleanupFinalizer adds the scheduler cleanup finalizer to a placement (if it does not
 // have it yet).
 func (s *Scheduler) addSchedulerCleanUpFinalizer(ctx context.Context, placement fleetv1beta1.PlacementObj) error {
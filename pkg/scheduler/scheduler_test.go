@@ -34,6 +34,7 @@ import (
 
 	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
 	hubmetrics "go.goms.io/fleet/pkg/metrics/hub"
+	"go.goms.io/fleet/pkg/scheduler/framework"
 )
 
 const (
@@ -637,3 +638,74 @@ func TestObserveSchedulingCycleMetrics(t *testing.T) {
 		})
 	}
 }
+
+// fakeFramework is a scheduling framework which only identifies itself by name.
+type fakeFramework struct {
+	framework.Framework
+	name string
+}
+
+// TestFrameworkFor tests the FrameworkFor method.
+func TestFrameworkFor(t *testing.T) {
+	defaultFramework := &fakeFramework{name: "default"}
+	costAwareFramework := &fakeFramework{name: "cost-aware"}
+	s := &Scheduler{
+		framework: defaultFramework,
+		profileFrameworks: map[string]framework.Framework{
+			"cost-aware": costAwareFramework,
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		policy        *fleetv1beta1.PlacementPolicy
+		wantFramework framework.Framework
+		wantErr       bool
+	}{
+		{
+			name:          "no policy",
+			wantFramework: defaultFramework,
+		},
+		{
+			name:          "no scheduler name",
+			policy:        &fleetv1beta1.PlacementPolicy{PlacementType: fleetv1beta1.PickAllPlacementType},
+			wantFramework: defaultFramework,
+		},
+		{
+			name:          "named profile",
+			policy:        &fleetv1beta1.PlacementPolicy{SchedulerName: "cost-aware"},
+			wantFramework: costAwareFramework,
+		},
+		{
+			name:    "profile not found",
+			policy:  &fleetv1beta1.PlacementPolicy{SchedulerName: "data-residency"},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			policySnapshot := &fleetv1beta1.ClusterSchedulingPolicySnapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: policySnapshotName,
+				},
+				Spec: fleetv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: tc.policy,
+				},
+			}
+			got, err := s.FrameworkFor(policySnapshot)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("FrameworkFor() = %v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FrameworkFor() = %v, want no error", err)
+			}
+			if got != tc.wantFramework {
+				t.Errorf("FrameworkFor() = %v, want %v", got, tc.wantFramework)
+			}
+		})
+	}
+}
@@ -297,12 +297,17 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 }
 
 func isTaintsUpdatedOrDeleted(oldTaints []clusterv1beta1.Taint, newTaints []clusterv1beta1.Taint) bool {
+	// The time at which a taint was added is irrelevant to the scheduler; it is left out
+	// of the comparison.
+	taintKey := func(taint clusterv1beta1.Taint) clusterv1beta1.Taint {
+		return clusterv1beta1.Taint{Key: taint.Key, Value: taint.Value, Effect: taint.Effect}
+	}
 	newTaintsMap := make(map[clusterv1beta1.Taint]bool)
 	for _, newTaint := range newTaints {
-		newTaintsMap[newTaint] = true
+		newTaintsMap[taintKey(newTaint)] = true
 	}
 	for _, oldTaint := range oldTaints {
-		if !newTaintsMap[oldTaint] {
+		if !newTaintsMap[taintKey(oldTaint)] {
 			return true
 		}
 	}
@@ -0,0 +1,158 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package blobstore features a content-addressed store for resource payloads that are too large to be
+// kept in the hub cluster, e.g., big ConfigMaps or CRDs selected by a placement. Resource snapshots and
+// Work objects keep references to the payloads instead, and the payloads are fetched by their digests.
+package blobstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	// digestAlgorithmPrefix is the prefix of all digests, which identifies the hash algorithm in use.
+	digestAlgorithmPrefix = "sha256:"
+
+	fileScheme = "file"
+	s3Scheme   = "s3"
+)
+
+var (
+	// ErrNotFound is returned when the store has no content with the requested digest.
+	ErrNotFound = errors.New("blob not found")
+	// ErrNoStore is returned when a blob reference is found but no store has been configured.
+	ErrNoStore = errors.New("no blob store is configured")
+	// ErrIdentityMismatch is returned when the content that a blob reference points to is not the
+	// object that the reference stands for.
+	ErrIdentityMismatch = errors.New("blob content does not match the identity of the reference")
+)
+
+// Store is a content-addressed store for blobs.
+type Store interface {
+	// Put saves the content in the store and returns its digest. Saving the same content more than
+	// once is allowed; it refreshes the last modified time of the content, so that the content is not
+	// garbage collected before the objects which refer to it are saved.
+	Put(ctx context.Context, content []byte) (string, error)
+	// Get returns the content with the given digest; it returns ErrNotFound if the store has no such content.
+	// Content larger than maxSize bytes is rejected without being read in full.
+	Get(ctx context.Context, digest string, maxSize int64) ([]byte, error)
+	// Delete removes the content with the given digest from the store; deleting content which is not
+	// in the store has no effect.
+	Delete(ctx context.Context, digest string) error
+	// List returns all the blobs in the store.
+	List(ctx context.Context) ([]BlobInfo, error)
+}
+
+// BlobInfo describes a blob in a store.
+type BlobInfo struct {
+	// Digest is the digest of the content of the blob.
+	Digest string
+	// LastModified is the last time the content was saved in the store.
+	LastModified time.Time
+}
+
+// Digest returns the digest of the content, in the form of "sha256:<hex>".
+func Digest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return digestAlgorithmPrefix + hex.EncodeToString(sum[:])
+}
+
+// parseDigest validates a digest and returns its hex-encoded hash.
+func parseDigest(digest string) (string, error) {
+	hash, found := strings.CutPrefix(digest, digestAlgorithmPrefix)
+	if !found {
+		return "", fmt.Errorf("digest %q does not start with %q", digest, digestAlgorithmPrefix)
+	}
+	if len(hash) != sha256.Size*2 || strings.ToLower(hash) != hash {
+		return "", fmt.Errorf("digest %q does not have a lower-case hex encoded SHA-256 hash", digest)
+	}
+	if _, err := hex.DecodeString(hash); err != nil {
+		return "", fmt.Errorf("digest %q does not have a lower-case hex encoded SHA-256 hash: %w", digest, err)
+	}
+	return hash, nil
+}
+
+// verifyDigest checks that the content matches the digest.
+func verifyDigest(digest string, content []byte) error {
+	if got := Digest(content); got != digest {
+		return fmt.Errorf("blob content does not match its digest: got %s, want %s", got, digest)
+	}
+	return nil
+}
+
+// readBlob reads the content of a blob, which must not be larger than maxSize bytes.
+func readBlob(r io.Reader, digest string, maxSize int64) ([]byte, error) {
+	// Read one more byte to find out whether the content exceeds the size.
+	content, err := io.ReadAll(io.LimitReader(r, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob %s: %w", digest, err)
+	}
+	if int64(len(content)) > maxSize {
+		return nil, fmt.Errorf("blob %s exceeds the expected size of %d bytes", digest, maxSize)
+	}
+	return content, nil
+}
+
+// New builds a store from its URL. It returns nil if the URL is empty, i.e., no store is in use.
+//
+// The supported URLs are:
+//   - file:///<directory>, which keeps the blobs in a directory (e.g., a shared volume);
+//   - s3://<bucket>[/<prefix>][?endpoint=<endpoint URL>&region=<region>], which keeps the blobs in a bucket
+//     of an S3-compatible object storage service. The credentials are read from the AWS_ACCESS_KEY_ID,
+//     AWS_SECRET_ACCESS_KEY and (optionally) AWS_SESSION_TOKEN environment variables.
+func New(storeURL string) (Store, error) {
+	if len(storeURL) == 0 {
+		return nil, nil
+	}
+	u, err := url.Parse(storeURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the blob store URL: %w", err)
+	}
+
+	switch u.Scheme {
+	case fileScheme:
+		if len(u.Path) == 0 {
+			return nil, fmt.Errorf("blob store URL %q has no directory", storeURL)
+		}
+		return NewFilesystemStore(u.Path)
+	case s3Scheme:
+		if len(u.Host) == 0 {
+			return nil, fmt.Errorf("blob store URL %q has no bucket", storeURL)
+		}
+		query := u.Query()
+		return NewS3Store(S3Options{
+			Endpoint:        query.Get("endpoint"),
+			Region:          query.Get("region"),
+			Bucket:          u.Host,
+			Prefix:          strings.Trim(u.Path, "/"),
+			AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		})
+	default:
+		return nil, fmt.Errorf("blob store URL %q has an unsupported scheme; only %s and %s are supported", storeURL, fileScheme, s3Scheme)
+	}
+}
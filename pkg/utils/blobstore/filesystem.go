@@ -0,0 +1,155 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// filesystemStore keeps the blobs as files in a directory, laid out as <dir>/sha256/<first two hex digits>/<hex>.
+type filesystemStore struct {
+	dir string
+}
+
+// NewFilesystemStore returns a store that keeps the blobs in the given directory.
+func NewFilesystemStore(dir string) (Store, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create the blob store directory %s: %w", dir, err)
+	}
+	return &filesystemStore{dir: dir}, nil
+}
+
+func (s *filesystemStore) pathOf(hash string) string {
+	return filepath.Join(s.dir, "sha256", hash[:2], hash)
+}
+
+// Put implements the Store interface.
+func (s *filesystemStore) Put(_ context.Context, content []byte) (string, error) {
+	digest := Digest(content)
+	hash, err := parseDigest(digest)
+	if err != nil {
+		return "", err
+	}
+	path := s.pathOf(hash)
+	if _, err := os.Stat(path); err == nil {
+		// The content has been saved before; refresh its last modified time so that it is not garbage collected.
+		now := time.Now()
+		if err := os.Chtimes(path, now, now); err != nil {
+			return "", fmt.Errorf("failed to refresh blob %s: %w", digest, err)
+		}
+		return digest, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return "", fmt.Errorf("failed to create the directory for blob %s: %w", digest, err)
+	}
+	// Write to a temporary file first and rename it afterwards so that readers never see a
+	// partially written blob.
+	tmp, err := os.CreateTemp(filepath.Dir(path), hash+".tmp-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create a temporary file for blob %s: %w", digest, err)
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck // the file no longer exists once renamed.
+	if _, err := tmp.Write(content); err != nil {
+		_ = tmp.Close()
+		return "", fmt.Errorf("failed to write blob %s: %w", digest, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("failed to write blob %s: %w", digest, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", fmt.Errorf("failed to save blob %s: %w", digest, err)
+	}
+	return digest, nil
+}
+
+// Get implements the Store interface.
+func (s *filesystemStore) Get(_ context.Context, digest string, maxSize int64) ([]byte, error) {
+	hash, err := parseDigest(digest)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(s.pathOf(hash))
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, digest)
+	case err != nil:
+		return nil, fmt.Errorf("failed to read blob %s: %w", digest, err)
+	}
+	defer f.Close()
+	content, err := readBlob(f, digest, maxSize)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyDigest(digest, content); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+// Delete implements the Store interface.
+func (s *filesystemStore) Delete(_ context.Context, digest string) error {
+	hash, err := parseDigest(digest)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(s.pathOf(hash)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete blob %s: %w", digest, err)
+	}
+	return nil
+}
+
+// List implements the Store interface.
+func (s *filesystemStore) List(_ context.Context) ([]BlobInfo, error) {
+	var blobs []BlobInfo
+	err := filepath.WalkDir(filepath.Join(s.dir, "sha256"), func(path string, d fs.DirEntry, err error) error {
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			// No blob has been saved yet.
+			return nil
+		case err != nil:
+			return err
+		case d.IsDir():
+			return nil
+		}
+		digest := digestAlgorithmPrefix + d.Name()
+		if _, err := parseDigest(digest); err != nil {
+			// Skip the temporary files and any other files which are not blobs.
+			return nil
+		}
+		info, err := d.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			// The blob has been deleted since the directory was read.
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		blobs = append(blobs, BlobInfo{Digest: digest, LastModified: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the blobs: %w", err)
+	}
+	return blobs, nil
+}
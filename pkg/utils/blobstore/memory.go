@@ -0,0 +1,101 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blobstore
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps the blobs in memory. It serves as a local stand-in for the other stores in tests.
+type MemoryStore struct {
+	mu    sync.RWMutex
+	blobs map[string]memoryBlob
+}
+
+type memoryBlob struct {
+	content      []byte
+	lastModified time.Time
+}
+
+// NewMemoryStore returns an empty in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{blobs: make(map[string]memoryBlob)}
+}
+
+// Put implements the Store interface.
+func (s *MemoryStore) Put(_ context.Context, content []byte) (string, error) {
+	digest := Digest(content)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	blob, found := s.blobs[digest]
+	if !found {
+		blob.content = slices.Clone(content)
+	}
+	blob.lastModified = time.Now()
+	s.blobs[digest] = blob
+	return digest, nil
+}
+
+// Get implements the Store interface.
+func (s *MemoryStore) Get(_ context.Context, digest string, maxSize int64) ([]byte, error) {
+	if _, err := parseDigest(digest); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	blob, found := s.blobs[digest]
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, digest)
+	}
+	if int64(len(blob.content)) > maxSize {
+		return nil, fmt.Errorf("blob %s exceeds the expected size of %d bytes", digest, maxSize)
+	}
+	return slices.Clone(blob.content), nil
+}
+
+// Delete implements the Store interface.
+func (s *MemoryStore) Delete(_ context.Context, digest string) error {
+	if _, err := parseDigest(digest); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.blobs, digest)
+	return nil
+}
+
+// List implements the Store interface.
+func (s *MemoryStore) List(_ context.Context) ([]BlobInfo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	blobs := make([]BlobInfo, 0, len(s.blobs))
+	for digest, blob := range s.blobs {
+		blobs = append(blobs, BlobInfo{Digest: digest, LastModified: blob.lastModified})
+	}
+	return blobs, nil
+}
+
+// Len returns the number of blobs in the store.
+func (s *MemoryStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.blobs)
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// Offload saves an object (in its JSON form) in the store if its size exceeds the threshold, and returns
+// a reference to the saved content in its place. Otherwise, the object is returned as it is.
+//
+// The reference is an object of the same type and identity (name and namespace) as the original object,
+// which has the digest and the size of the content in its annotations; as a result, consumers that only
+// care about the identities of the objects can handle references just as regular objects.
+func Offload(ctx context.Context, store Store, raw []byte, threshold int) ([]byte, error) {
+	if store == nil || len(raw) <= threshold {
+		return raw, nil
+	}
+	var obj unstructured.Unstructured
+	if err := obj.UnmarshalJSON(raw); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the object to offload: %w", err)
+	}
+	if isReference(&obj) {
+		// The object is already a reference.
+		return raw, nil
+	}
+
+	digest, err := store.Put(ctx, raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to offload object %s %s: %w", obj.GroupVersionKind(), klog.KObj(&obj), err)
+	}
+	ref := &unstructured.Unstructured{}
+	ref.SetAPIVersion(obj.GetAPIVersion())
+	ref.SetKind(obj.GetKind())
+	ref.SetName(obj.GetName())
+	ref.SetNamespace(obj.GetNamespace())
+	ref.SetAnnotations(map[string]string{
+		placementv1beta1.BlobDigestAnnotation: digest,
+		placementv1beta1.BlobSizeAnnotation:   strconv.Itoa(len(raw)),
+	})
+	return ref.MarshalJSON()
+}
+
+// IsReference returns whether an object (in its JSON form) is a reference to content in a store, i.e.,
+// whether it has been offloaded by Fleet.
+func IsReference(raw []byte) bool {
+	_, ok := ReferenceDigest(raw)
+	return ok
+}
+
+// ReferenceDigest returns the digest of the content that an object (in its JSON form) refers to, if the object
+// is a reference to content in a store.
+func ReferenceDigest(raw []byte) (string, bool) {
+	// Skip the unmarshalling for most objects.
+	if !bytes.Contains(raw, []byte(placementv1beta1.BlobDigestAnnotation)) {
+		return "", false
+	}
+	var obj unstructured.Unstructured
+	if err := obj.UnmarshalJSON(raw); err != nil || !isReference(&obj) {
+		return "", false
+	}
+	return obj.GetAnnotations()[placementv1beta1.BlobDigestAnnotation], true
+}
+
+// isReference returns whether an object has the exact shape of the references that Offload builds: only
+// the type, the identity and the blob annotations are present. An object which merely carries the blob
+// annotations, e.g., a user object, is not a reference.
+func isReference(obj *unstructured.Unstructured) bool {
+	for field := range obj.Object {
+		if field != "apiVersion" && field != "kind" && field != "metadata" {
+			return false
+		}
+	}
+	metadata, ok := obj.Object["metadata"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	for field := range metadata {
+		if field != "name" && field != "namespace" && field != "annotations" {
+			return false
+		}
+	}
+	annotations := obj.GetAnnotations()
+	_, hasDigest := annotations[placementv1beta1.BlobDigestAnnotation]
+	_, hasSize := annotations[placementv1beta1.BlobSizeAnnotation]
+	return len(annotations) == 2 && hasDigest && hasSize
+}
+
+// Resolve returns the content that an object (in its JSON form) refers to if a store is in use and the
+// object is a reference to content in the store. Otherwise, the object is returned as it is.
+//
+// The content must be the object that the reference stands for, i.e., it must have the same type and
+// identity as the reference; this keeps a reference from pulling in the content of another object.
+func Resolve(ctx context.Context, store Store, raw []byte) ([]byte, error) {
+	if store == nil || !IsReference(raw) {
+		return raw, nil
+	}
+	var ref unstructured.Unstructured
+	if err := ref.UnmarshalJSON(raw); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the blob reference: %w", err)
+	}
+	digest := ref.GetAnnotations()[placementv1beta1.BlobDigestAnnotation]
+	size, err := strconv.ParseInt(ref.GetAnnotations()[placementv1beta1.BlobSizeAnnotation], 10, 64)
+	if err != nil || size < 0 {
+		return nil, fmt.Errorf("blob reference of object %s %s has an invalid size %q", ref.GroupVersionKind(), klog.KObj(&ref), ref.GetAnnotations()[placementv1beta1.BlobSizeAnnotation])
+	}
+	content, err := store.Get(ctx, digest, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve the blob reference of object %s %s: %w", ref.GroupVersionKind(), klog.KObj(&ref), err)
+	}
+	// Verify the content regardless of the store in use.
+	if err := verifyDigest(digest, content); err != nil {
+		return nil, err
+	}
+	var obj unstructured.Unstructured
+	if err := obj.UnmarshalJSON(content); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal blob %s of object %s %s: %w", digest, ref.GroupVersionKind(), klog.KObj(&ref), err)
+	}
+	if obj.GetAPIVersion() != ref.GetAPIVersion() || obj.GetKind() != ref.GetKind() ||
+		obj.GetNamespace() != ref.GetNamespace() || obj.GetName() != ref.GetName() {
+		return nil, fmt.Errorf("blob %s of object %s %s holds object %s %s: %w",
+			digest, ref.GroupVersionKind(), klog.KObj(&ref), obj.GroupVersionKind(), klog.KObj(&obj), ErrIdentityMismatch)
+	}
+	return content, nil
+}
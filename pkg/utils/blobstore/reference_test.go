@@ -0,0 +1,265 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+func newConfigMapJSON(t *testing.T, dataSize int) []byte {
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":      "big-config",
+				"namespace": "app",
+			},
+			"data": map[string]interface{}{
+				"key": strings.Repeat("x", dataSize),
+			},
+		},
+	}
+	raw, err := obj.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON() = %v, want no error", err)
+	}
+	return raw
+}
+
+// TestOffloadAndResolve tests the Offload and Resolve functions.
+func TestOffloadAndResolve(t *testing.T) {
+	ctx := context.Background()
+	small := newConfigMapJSON(t, 10)
+	big := newConfigMapJSON(t, 4096)
+
+	testCases := []struct {
+		name          string
+		store         *MemoryStore
+		raw           []byte
+		wantReference bool
+	}{
+		{
+			name: "no store",
+			raw:  big,
+		},
+		{
+			name:  "object under the threshold",
+			store: NewMemoryStore(),
+			raw:   small,
+		},
+		{
+			name:          "object over the threshold",
+			store:         NewMemoryStore(),
+			raw:           big,
+			wantReference: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var store Store
+			if tc.store != nil {
+				store = tc.store
+			}
+			got, err := Offload(ctx, store, tc.raw, 1024)
+			if err != nil {
+				t.Fatalf("Offload() = %v, want no error", err)
+			}
+			if gotReference := IsReference(got); gotReference != tc.wantReference {
+				t.Fatalf("IsReference(Offload()) = %t, want %t", gotReference, tc.wantReference)
+			}
+			if !tc.wantReference {
+				if diff := cmp.Diff(got, tc.raw); diff != "" {
+					t.Errorf("Offload() mismatch (-got, +want):\n%s", diff)
+				}
+				return
+			}
+
+			var ref unstructured.Unstructured
+			if err := ref.UnmarshalJSON(got); err != nil {
+				t.Fatalf("UnmarshalJSON() = %v, want no error", err)
+			}
+			if ref.GetKind() != "ConfigMap" || ref.GetName() != "big-config" || ref.GetNamespace() != "app" {
+				t.Errorf("Offload() = %s, want a reference with the identity of the original object", got)
+			}
+			wantAnnotations := map[string]string{
+				placementv1beta1.BlobDigestAnnotation: Digest(tc.raw),
+				placementv1beta1.BlobSizeAnnotation:   strconv.Itoa(len(tc.raw)),
+			}
+			if diff := cmp.Diff(ref.GetAnnotations(), wantAnnotations); diff != "" {
+				t.Errorf("Offload() annotations mismatch (-got, +want):\n%s", diff)
+			}
+
+			// Offloading a reference again should be a no-op.
+			again, err := Offload(ctx, store, got, 0)
+			if err != nil {
+				t.Fatalf("Offload() = %v, want no error", err)
+			}
+			if diff := cmp.Diff(again, got); diff != "" {
+				t.Errorf("Offload() of a reference mismatch (-got, +want):\n%s", diff)
+			}
+			if tc.store.Len() != 1 {
+				t.Errorf("store has %d blobs, want 1", tc.store.Len())
+			}
+
+			resolved, err := Resolve(ctx, store, got)
+			if err != nil {
+				t.Fatalf("Resolve() = %v, want no error", err)
+			}
+			if diff := cmp.Diff(resolved, tc.raw); diff != "" {
+				t.Errorf("Resolve() mismatch (-got, +want):\n%s", diff)
+			}
+			// References are not resolved if no store is in use.
+			unresolved, err := Resolve(ctx, nil, got)
+			if err != nil {
+				t.Fatalf("Resolve() = %v, want no error", err)
+			}
+			if diff := cmp.Diff(unresolved, got); diff != "" {
+				t.Errorf("Resolve() without a store mismatch (-got, +want):\n%s", diff)
+			}
+			if _, err := Resolve(ctx, NewMemoryStore(), got); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Resolve() = %v, want %v", err, ErrNotFound)
+			}
+		})
+	}
+}
+
+// TestIsReference tests the IsReference function.
+func TestIsReference(t *testing.T) {
+	annotations := map[string]interface{}{
+		placementv1beta1.BlobDigestAnnotation: Digest([]byte("content")),
+		placementv1beta1.BlobSizeAnnotation:   "7",
+	}
+	testCases := []struct {
+		name string
+		obj  map[string]interface{}
+		want bool
+	}{
+		{
+			name: "reference",
+			obj: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata": map[string]interface{}{
+					"name":        "big-config",
+					"namespace":   "app",
+					"annotations": annotations,
+				},
+			},
+			want: true,
+		},
+		{
+			name: "object without the blob annotations",
+			obj: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata": map[string]interface{}{
+					"name":      "big-config",
+					"namespace": "app",
+				},
+			},
+		},
+		{
+			name: "object with content",
+			obj: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata": map[string]interface{}{
+					"name":        "big-config",
+					"namespace":   "app",
+					"annotations": annotations,
+				},
+				"data": map[string]interface{}{"key": "value"},
+			},
+		},
+		{
+			name: "object with labels",
+			obj: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata": map[string]interface{}{
+					"name":        "big-config",
+					"namespace":   "app",
+					"labels":      map[string]interface{}{"app": "web"},
+					"annotations": annotations,
+				},
+			},
+		},
+		{
+			name: "object with other annotations",
+			obj: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata": map[string]interface{}{
+					"name":      "big-config",
+					"namespace": "app",
+					"annotations": map[string]interface{}{
+						placementv1beta1.BlobDigestAnnotation: Digest([]byte("content")),
+						placementv1beta1.BlobSizeAnnotation:   "7",
+						"example.com/owner":                   "team-a",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			raw, err := (&unstructured.Unstructured{Object: tc.obj}).MarshalJSON()
+			if err != nil {
+				t.Fatalf("MarshalJSON() = %v, want no error", err)
+			}
+			if got := IsReference(raw); got != tc.want {
+				t.Errorf("IsReference() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestResolve_IdentityMismatch tests that Resolve rejects the content of another object.
+func TestResolve_IdentityMismatch(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	raw := newConfigMapJSON(t, 4096)
+	ref, err := Offload(ctx, store, raw, 1024)
+	if err != nil {
+		t.Fatalf("Offload() = %v, want no error", err)
+	}
+
+	var obj unstructured.Unstructured
+	if err := obj.UnmarshalJSON(ref); err != nil {
+		t.Fatalf("UnmarshalJSON() = %v, want no error", err)
+	}
+	obj.SetNamespace("other-app")
+	forged, err := obj.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON() = %v, want no error", err)
+	}
+	if _, err := Resolve(ctx, store, forged); !errors.Is(err, ErrIdentityMismatch) {
+		t.Errorf("Resolve() = %v, want %v", err, ErrIdentityMismatch)
+	}
+}
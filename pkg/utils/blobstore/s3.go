@@ -0,0 +1,321 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+const (
+	defaultS3Region = "us-east-1"
+	// defaultS3RequestTimeout is the timeout of a request to the object storage service, including
+	// the reading of the response body, so that a stalled service does not block the controllers.
+	defaultS3RequestTimeout = time.Minute
+
+	// The maximum size of an error response body from the object storage service that is
+	// included in error messages.
+	maxS3ErrorBodySize = 1 << 10
+	// The maximum size of a page of the object list returned by the object storage service; a page
+	// has up to 1000 objects.
+	maxS3ListBodySize = 4 << 20
+
+	s3SigningName          = "s3"
+	amzContentSHA256Header = "X-Amz-Content-Sha256"
+)
+
+// S3Options are the options for a store that keeps the blobs in an S3-compatible object storage service.
+type S3Options struct {
+	// Endpoint is the URL of the service, e.g., https://minio.example.com:9000. It defaults to the
+	// regional AWS S3 endpoint.
+	Endpoint string
+	// Region is the region of the bucket; it defaults to us-east-1.
+	Region string
+	// Bucket is the name of the bucket.
+	Bucket string
+	// Prefix is the prefix of the object keys for the blobs, if any.
+	Prefix string
+	// AccessKeyID, SecretAccessKey and SessionToken are the credentials used to sign the requests.
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	// HTTPClient is the client for sending the requests; it defaults to a client with a timeout of
+	// defaultS3RequestTimeout.
+	HTTPClient *http.Client
+}
+
+// s3Store keeps the blobs as objects in a bucket, with the keys <prefix>/sha256/<hex>.
+//
+// Path-style requests are used so that the store works with S3-compatible services other than AWS S3;
+// the requests are signed with AWS Signature Version 4 by the signer of the AWS SDK.
+type s3Store struct {
+	opts     S3Options
+	endpoint *url.URL
+	signer   *v4.Signer
+	now      func() time.Time
+}
+
+// NewS3Store returns a store that keeps the blobs in a bucket of an S3-compatible object storage service.
+func NewS3Store(opts S3Options) (Store, error) {
+	if len(opts.Bucket) == 0 {
+		return nil, fmt.Errorf("no bucket is specified for the S3 blob store")
+	}
+	if len(opts.AccessKeyID) == 0 || len(opts.SecretAccessKey) == 0 {
+		return nil, fmt.Errorf("no credentials are specified for the S3 blob store")
+	}
+	if len(opts.Region) == 0 {
+		opts.Region = defaultS3Region
+	}
+	if len(opts.Endpoint) == 0 {
+		opts.Endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", opts.Region)
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = &http.Client{Timeout: defaultS3RequestTimeout}
+	}
+	endpoint, err := url.Parse(opts.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the S3 endpoint: %w", err)
+	}
+	if endpoint.Scheme != "http" && endpoint.Scheme != "https" {
+		return nil, fmt.Errorf("S3 endpoint %q must be an HTTP or HTTPS URL", opts.Endpoint)
+	}
+	signer := v4.NewSigner(func(o *v4.SignerOptions) {
+		// S3 expects the object keys in the canonical requests to be escaped only once.
+		o.DisableURIPathEscaping = true
+	})
+	return &s3Store{opts: opts, endpoint: endpoint, signer: signer, now: time.Now}, nil
+}
+
+// keyPrefix returns the prefix of the object keys for the blobs.
+func (s *s3Store) keyPrefix() string {
+	if len(s.opts.Prefix) > 0 {
+		return s.opts.Prefix + "/sha256/"
+	}
+	return "sha256/"
+}
+
+// bucketURL returns the URL of the bucket, with the given object key appended if it is not empty.
+func (s *s3Store) bucketURL(key string) *url.URL {
+	u := *s.endpoint
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + s.opts.Bucket
+	if len(key) > 0 {
+		u.Path += "/" + key
+	}
+	u.RawPath = ""
+	return &u
+}
+
+func (s *s3Store) objectURL(hash string) *url.URL {
+	return s.bucketURL(s.keyPrefix() + hash)
+}
+
+// Put implements the Store interface.
+func (s *s3Store) Put(ctx context.Context, content []byte) (string, error) {
+	digest := Digest(content)
+	hash, err := parseDigest(digest)
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(hash).String(), bytes.NewReader(content))
+	if err != nil {
+		return "", fmt.Errorf("failed to build the request for blob %s: %w", digest, err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := s.do(req, content)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload blob %s: %w", digest, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to upload blob %s: %w", digest, s3ResponseError(resp))
+	}
+	return digest, nil
+}
+
+// Get implements the Store interface.
+func (s *s3Store) Get(ctx context.Context, digest string, maxSize int64) ([]byte, error) {
+	hash, err := parseDigest(digest)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.objectURL(hash).String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build the request for blob %s: %w", digest, err)
+	}
+	resp, err := s.do(req, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download blob %s: %w", digest, err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, digest)
+	default:
+		return nil, fmt.Errorf("failed to download blob %s: %w", digest, s3ResponseError(resp))
+	}
+	content, err := readBlob(resp.Body, digest, maxSize)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyDigest(digest, content); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+// Delete implements the Store interface.
+func (s *s3Store) Delete(ctx context.Context, digest string) error {
+	hash, err := parseDigest(digest)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(hash).String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to build the request for blob %s: %w", digest, err)
+	}
+	resp, err := s.do(req, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete blob %s: %w", digest, err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return fmt.Errorf("failed to delete blob %s: %w", digest, s3ResponseError(resp))
+	}
+}
+
+// s3ListBucketResult is the subset of the response of the ListObjectsV2 API that the store understands.
+type s3ListBucketResult struct {
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+	Contents              []struct {
+		Key          string    `xml:"Key"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+}
+
+// List implements the Store interface.
+func (s *s3Store) List(ctx context.Context) ([]BlobInfo, error) {
+	var blobs []BlobInfo
+	continuationToken := ""
+	for {
+		u := s.bucketURL("")
+		query := url.Values{}
+		query.Set("list-type", "2")
+		query.Set("prefix", s.keyPrefix())
+		if len(continuationToken) > 0 {
+			query.Set("continuation-token", continuationToken)
+		}
+		u.RawQuery = query.Encode()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build the request for listing the blobs: %w", err)
+		}
+		result, err := s.listPage(req)
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range result.Contents {
+			digest := digestAlgorithmPrefix + path.Base(object.Key)
+			if _, err := parseDigest(digest); err != nil {
+				// Skip the objects which are not blobs.
+				continue
+			}
+			blobs = append(blobs, BlobInfo{Digest: digest, LastModified: object.LastModified})
+		}
+		if !result.IsTruncated || len(result.NextContinuationToken) == 0 {
+			return blobs, nil
+		}
+		continuationToken = result.NextContinuationToken
+	}
+}
+
+// listPage sends a request to the ListObjectsV2 API and parses the response.
+func (s *s3Store) listPage(req *http.Request) (*s3ListBucketResult, error) {
+	resp, err := s.do(req, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the blobs: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to list the blobs: %w", s3ResponseError(resp))
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxS3ListBodySize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the blobs: %w", err)
+	}
+	if len(body) > maxS3ListBodySize {
+		return nil, fmt.Errorf("failed to list the blobs: the response exceeds the limit of %d bytes", maxS3ListBodySize)
+	}
+	result := &s3ListBucketResult{}
+	if err := xml.Unmarshal(body, result); err != nil {
+		return nil, fmt.Errorf("failed to parse the blob list: %w", err)
+	}
+	return result, nil
+}
+
+// do signs and sends a request.
+func (s *s3Store) do(req *http.Request, payload []byte) (*http.Response, error) {
+	if err := s.sign(req, payload); err != nil {
+		return nil, err
+	}
+	return s.opts.HTTPClient.Do(req)
+}
+
+// sign signs a request with AWS Signature Version 4. All the headers set on the request at the time of signing,
+// along with the Host header and the hash of the payload, are signed.
+func (s *s3Store) sign(req *http.Request, payload []byte) error {
+	payloadHash := sha256Hex(payload)
+	// S3 requires the hash of the payload in a header.
+	req.Header.Set(amzContentSHA256Header, payloadHash)
+	creds := aws.Credentials{
+		AccessKeyID:     s.opts.AccessKeyID,
+		SecretAccessKey: s.opts.SecretAccessKey,
+		SessionToken:    s.opts.SessionToken,
+	}
+	if err := s.signer.SignHTTP(req.Context(), creds, req, payloadHash, s3SigningName, s.opts.Region, s.now()); err != nil {
+		return fmt.Errorf("failed to sign the request: %w", err)
+	}
+	return nil
+}
+
+// s3ResponseError builds an error from an unexpected response of the object storage service.
+func s3ResponseError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxS3ErrorBodySize))
+	return fmt.Errorf("unexpected response status %s: %s", resp.Status, strings.TrimSpace(string(body)))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
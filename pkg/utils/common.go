@@ -49,17 +49,19 @@ import (
 )
 
 const (
-	kubePrefix                 = "kube-"
-	fleetPrefix                = "fleet-"
-	fleetMemberNamespacePrefix = fleetPrefix + "member-"
-	FleetSystemNamespace       = fleetPrefix + "system"
-	NamespaceNameFormat        = fleetMemberNamespacePrefix + "%s"
-	RoleNameFormat             = fleetPrefix + "role-%s"
-	RoleBindingNameFormat      = fleetPrefix + "rolebinding-%s"
-	ValidationPathFmt          = "/validate-%s-%s-%s"
-	MutatingPathFmt            = "/mutate-%s-%s-%s"
-	lessGroupsStringFormat     = "groups: %v"
-	moreGroupsStringFormat     = "groups: [%s, %s, %s,......]"
+	kubePrefix                   = "kube-"
+	fleetPrefix                  = "fleet-"
+	fleetMemberNamespacePrefix   = fleetPrefix + "member-"
+	FleetSystemNamespace         = fleetPrefix + "system"
+	NamespaceNameFormat          = fleetMemberNamespacePrefix + "%s"
+	RoleNameFormat               = fleetPrefix + "role-%s"
+	RoleBindingNameFormat        = fleetPrefix + "rolebinding-%s"
+	ClusterRoleNameFormat        = fleetPrefix + "clusterrole-%s"
+	ClusterRoleBindingNameFormat = fleetPrefix + "clusterrolebinding-%s"
+	ValidationPathFmt            = "/validate-%s-%s-%s"
+	MutatingPathFmt              = "/mutate-%s-%s-%s"
+	lessGroupsStringFormat       = "groups: %v"
+	moreGroupsStringFormat       = "groups: [%s, %s, %s,......]"
 )
 
 const (
@@ -128,6 +130,18 @@ var (
 		APIGroups: []string{NetworkingGroupName},
 		Resources: []string{"*"},
 	}
+	// AvailabilityRuleReadRule allows member agents to read the cluster-scoped AvailabilityRule objects.
+	AvailabilityRuleReadRule = rbacv1.PolicyRule{
+		Verbs:     []string{"get", "list", "watch"},
+		APIGroups: []string{placementv1beta1.GroupVersion.Group},
+		Resources: []string{"availabilityrules"},
+	}
+	// RedactionRuleReadRule allows member agents to read the cluster-scoped RedactionRule objects.
+	RedactionRuleReadRule = rbacv1.PolicyRule{
+		Verbs:     []string{"get", "list", "watch"},
+		APIGroups: []string{placementv1beta1.GroupVersion.Group},
+		Resources: []string{"redactionrules"},
+	}
 )
 
 // Those are the GVR/GVKs in use by Fleet source code.
@@ -670,6 +684,41 @@ func IsDriftedResourcePlacementsEqual(oldDriftedResourcePlacements, newDriftedRe
 	return true
 }
 
+// LessFuncDriftRemediatedResourcePlacements is a less function for sorting drift remediated resource placements
+var LessFuncDriftRemediatedResourcePlacements = func(a, b placementv1beta1.DriftRemediatedResourcePlacement) bool {
+	var aStr, bStr string
+	if a.Envelope != nil {
+		aStr = fmt.Sprintf(ResourceIdentifierWithEnvelopeIdentifierStringFormat, a.Group, a.Version, a.Kind, a.Namespace, a.Name, a.Envelope.Type, a.Envelope.Namespace, a.Envelope.Name)
+	} else {
+		aStr = fmt.Sprintf(ResourceIdentifierStringFormat, a.Group, a.Version, a.Kind, a.Namespace, a.Name)
+	}
+	if b.Envelope != nil {
+		bStr = fmt.Sprintf(ResourceIdentifierWithEnvelopeIdentifierStringFormat, b.Group, b.Version, b.Kind, b.Namespace, b.Name, b.Envelope.Type, b.Envelope.Namespace, b.Envelope.Name)
+	} else {
+		bStr = fmt.Sprintf(ResourceIdentifierStringFormat, b.Group, b.Version, b.Kind, b.Namespace, b.Name)
+	}
+	return aStr < bStr
+}
+
+// IsDriftRemediatedResourcePlacementsEqual returns true if the two sets of drift remediated resource placements are equal.
+func IsDriftRemediatedResourcePlacementsEqual(oldPlacements, newPlacements []placementv1beta1.DriftRemediatedResourcePlacement) bool {
+	if len(oldPlacements) != len(newPlacements) {
+		return false
+	}
+	sort.Slice(oldPlacements, func(i, j int) bool {
+		return LessFuncDriftRemediatedResourcePlacements(oldPlacements[i], oldPlacements[j])
+	})
+	sort.Slice(newPlacements, func(i, j int) bool {
+		return LessFuncDriftRemediatedResourcePlacements(newPlacements[i], newPlacements[j])
+	})
+	for i := range oldPlacements {
+		if !equality.Semantic.DeepEqual(oldPlacements[i], newPlacements[i]) {
+			return false
+		}
+	}
+	return true
+}
+
 // LessFuncDiffedResourcePlacements is a less function for sorting drifted resource placements
 var LessFuncDiffedResourcePlacements = func(a, b placementv1beta1.DiffedResourcePlacement) bool {
 	var aStr, bStr string
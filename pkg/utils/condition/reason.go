@@ -47,6 +47,10 @@ const (
 	// RolloutStartedReason is the reason string of placement condition if rollout status is started.
 	RolloutStartedReason = "RolloutStarted"
 
+	// RolloutWaitingForMaintenanceWindowReason is the reason string of placement condition if the rollout is held
+	// because the maintenance windows are closed.
+	RolloutWaitingForMaintenanceWindowReason = "RolloutWaitingForMaintenanceWindow"
+
 	// OverriddenPendingReason is the reason string of placement condition when the selected resources are pending to override.
 	OverriddenPendingReason = "OverriddenPending"
 
@@ -179,6 +183,15 @@ const (
 	// UpdateRunSucceededReason is the reason string of condition if the staged update run succeeded.
 	UpdateRunSucceededReason = "UpdateRunSucceeded"
 
+	// UpdateRunRollingBackReason is the reason string of condition if the staged update run is rolling back the updated clusters.
+	UpdateRunRollingBackReason = "UpdateRunRollingBack"
+
+	// UpdateRunRolledBackReason is the reason string of condition if the staged update run rolled back all the updated clusters.
+	UpdateRunRolledBackReason = "UpdateRunRolledBack"
+
+	// UpdateRunRollbackFailedReason is the reason string of condition if the staged update run failed to roll back the updated clusters.
+	UpdateRunRollbackFailedReason = "UpdateRunRollbackFailed"
+
 	// StageUpdatingStartedReason is the reason string of condition if the stage updating has started.
 	StageUpdatingStartedReason = "StageUpdatingStarted"
 
@@ -197,9 +210,22 @@ const (
 	// StageUpdatingSucceededReason is the reason string of condition if the stage updating succeeded.
 	StageUpdatingSucceededReason = "StageUpdatingSucceeded"
 
+	// StageCanaryClustersUpdatedReason is the reason string of condition if all the canary clusters of the stage are updated.
+	StageCanaryClustersUpdatedReason = "StageCanaryClustersUpdated"
+
+	// StageCanaryBakeTimeElapsedReason is the reason string of condition if the bake time of the stage canary has elapsed.
+	StageCanaryBakeTimeElapsedReason = "StageCanaryBakeTimeElapsed"
+
+	// StageCanarySucceededReason is the reason string of condition if the canary phase of the stage succeeded.
+	StageCanarySucceededReason = "StageCanarySucceeded"
+
 	// ClusterUpdatingStartedReason is the reason string of condition if the cluster updating has started.
 	ClusterUpdatingStartedReason = "ClusterUpdatingStarted"
 
+	// ClusterUpdatingWaitingForMaintenanceWindowReason is the reason string of condition if the cluster updating
+	// has not started because the maintenance windows are closed.
+	ClusterUpdatingWaitingForMaintenanceWindowReason = "ClusterUpdatingWaitingForMaintenanceWindow"
+
 	// ClusterUpdatingFailedReason is the reason string of condition if the cluster updating failed.
 	ClusterUpdatingFailedReason = "ClusterUpdatingFailed"
 
@@ -218,8 +244,20 @@ const (
 	// ApprovalRequestApprovalAcceptedReason is the reason string of condition if the approval of the approval request has been accepted.
 	ApprovalRequestApprovalAcceptedReason = "ApprovalRequestApprovalAccepted"
 
+	// ApprovalRequestHealthCheckPassedReason is the reason string of condition if the approval request of a health check task
+	// has been approved by the controller because the health check passed.
+	ApprovalRequestHealthCheckPassedReason = "HealthCheckPassed"
+
+	// ApprovalRequestHealthCheckFailedReason is the reason string of condition if the approval request of a health check task
+	// has been rejected by the controller because the health check did not pass before the timeout.
+	ApprovalRequestHealthCheckFailedReason = "HealthCheckFailed"
+
 	// UpdateRunWaitingMessageFmt is the message format string of condition if the staged update run is waiting for stage tasks in a stage to complete.
 	UpdateRunWaitingMessageFmt = "The updateRun is waiting for %s tasks in stage %s to complete"
+
+	// UpdateRunWaitingForMaintenanceWindowMessageFmt is the message format string of condition if the staged update run
+	// is waiting for the maintenance windows of clusters in a stage to open.
+	UpdateRunWaitingForMaintenanceWindowMessageFmt = "The updateRun is waiting for the maintenance windows of clusters %s in stage %s to open"
 )
 
 // A group of condition reason & message string which is used to populate the ClusterResourcePlacementEviction condition.
@@ -39,6 +39,7 @@ import (
 
 	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
 	"go.goms.io/fleet/pkg/utils"
+	"go.goms.io/fleet/pkg/utils/helm"
 	"go.goms.io/fleet/pkg/utils/informer"
 )
 
@@ -106,20 +107,31 @@ type ResourceSelectorResolver struct {
 
 	// EnableWorkload indicates whether workload resources are allowed to be selected.
 	EnableWorkload bool
+
+	// HelmChartFetcher fetches the charts of the Helm sources of the placements.
+	HelmChartFetcher helm.ChartFetcher
 }
 
-// SelectResourcesForPlacement selects the resources according to the placement resourceSelectors.
+// SelectResourcesForPlacement selects the resources according to the placement resourceSelectors, along with
+// the resources rendered from the placement resourceSources.
 // It also generates an array of resource content and resource identifier based on the selected resources.
 // It also returns the number of envelope configmaps so the CRP controller can have the right expectation of the number of work objects.
 func (rs *ResourceSelectorResolver) SelectResourcesForPlacement(placementObj placementv1beta1.PlacementObj) (int, []placementv1beta1.ResourceContent, []placementv1beta1.ResourceIdentifier, error) {
 	envelopeObjCount := 0
-	selectedObjects, err := rs.gatherSelectedResource(types.NamespacedName{
+	placementKey := types.NamespacedName{
 		Name:      placementObj.GetName(),
 		Namespace: placementObj.GetNamespace(),
-	}, placementObj.GetPlacementSpec().ResourceSelectors)
+	}
+	placementSpec := placementObj.GetPlacementSpec()
+	selectedObjects, err := rs.gatherSelectedResource(placementKey, placementSpec.ResourceSelectors)
 	if err != nil {
 		return 0, nil, nil, err
 	}
+	if len(placementSpec.ResourceSources) != 0 {
+		if selectedObjects, err = rs.addRenderedResources(placementKey, selectedObjects, placementSpec.ResourceSources); err != nil {
+			return 0, nil, nil, err
+		}
+	}
 
 	resources := make([]placementv1beta1.ResourceContent, len(selectedObjects))
 	resourcesIDs := make([]placementv1beta1.ResourceIdentifier, len(selectedObjects))
@@ -177,6 +189,10 @@ func generateRawContent(object *unstructured.Unstructured) ([]byte, error) {
 		delete(annots, corev1.LastAppliedConfigAnnotation)
 		// Remove the revision annotation set by deployment
 		delete(annots, deployment.RevisionAnnotation)
+		// Remove the blob annotations reserved for the references to the resources offloaded by Fleet,
+		// so that a user object is never mistaken for one.
+		delete(annots, placementv1beta1.BlobDigestAnnotation)
+		delete(annots, placementv1beta1.BlobSizeAnnotation)
 		if len(annots) == 0 {
 			object.SetAnnotations(nil)
 		} else {
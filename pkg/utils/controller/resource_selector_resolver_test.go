@@ -76,6 +76,8 @@ func TestGenerateResourceContent(t *testing.T) {
 					Annotations: map[string]string{
 						corev1.LastAppliedConfigAnnotation: "svc-object-annotation-lac-value",
 						deployment.RevisionAnnotation:      "svc-object-revision-annotation-value",
+						fleetv1beta1.BlobDigestAnnotation:  "sha256:0123456789abcdef",
+						fleetv1beta1.BlobSizeAnnotation:    "1024",
 						"svc-annotation-key":               "svc-object-annotation-key-value",
 					},
 					OwnerReferences: []metav1.OwnerReference{
@@ -0,0 +1,231 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+	"go.goms.io/fleet/pkg/utils/helm"
+	"go.goms.io/fleet/pkg/utils/kustomize"
+)
+
+// addRenderedResources renders the resources from the resource sources of a placement and adds them to
+// the selected resources; the returned list is sorted in the same way as the selected resources.
+func (rs *ResourceSelectorResolver) addRenderedResources(placementKey types.NamespacedName, selected []*unstructured.Unstructured, sources []placementv1beta1.ResourceSource) ([]*unstructured.Unstructured, error) {
+	resourceMap := make(map[placementv1beta1.ResourceIdentifier]bool, len(selected))
+	for _, obj := range selected {
+		resourceMap[resourceIdentifierOf(obj)] = true
+	}
+	resources := selected
+	for i := range sources {
+		rendered, err := rs.renderResourceSource(placementKey, &sources[i])
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range rendered {
+			ri := resourceIdentifierOf(obj)
+			if resourceMap[ri] {
+				err := fmt.Errorf("found duplicate resource %+v rendered from resource source %d", ri, i)
+				klog.ErrorS(err, "User selected or rendered one resource more than once", "resource", ri, "placement", placementKey)
+				return nil, NewUserError(err)
+			}
+			resourceMap[ri] = true
+			resources = append(resources, obj)
+		}
+	}
+	sortResources(resources)
+	return resources, nil
+}
+
+// renderResourceSource renders the resources from one resource source of a placement.
+func (rs *ResourceSelectorResolver) renderResourceSource(placementKey types.NamespacedName, source *placementv1beta1.ResourceSource) ([]*unstructured.Unstructured, error) {
+	switch {
+	case source.Kustomize != nil:
+		return rs.renderKustomizeSource(placementKey, source.Kustomize)
+	case source.Helm != nil:
+		return rs.renderHelmSource(placementKey, source.Helm)
+	default:
+		return nil, NewUserError(fmt.Errorf("invalid placement %s: the resource source does not have any source type", placementKey))
+	}
+}
+
+// renderKustomizeSource renders the resources from a Kustomize source of a placement.
+func (rs *ResourceSelectorResolver) renderKustomizeSource(placementKey types.NamespacedName, source *placementv1beta1.KustomizeSource) ([]*unstructured.Unstructured, error) {
+	configMapKey := types.NamespacedName{
+		Name:      source.ConfigMapName,
+		Namespace: source.ConfigMapNamespace,
+	}
+	if placementKey.Namespace != "" {
+		if configMapKey.Namespace == "" {
+			configMapKey.Namespace = placementKey.Namespace
+		}
+		if configMapKey.Namespace != placementKey.Namespace {
+			return nil, NewUserError(fmt.Errorf("invalid placement %s: cannot render resources from ConfigMap %s outside of the placement namespace", placementKey, configMapKey))
+		}
+	} else if configMapKey.Namespace == "" {
+		return nil, NewUserError(fmt.Errorf("invalid placement %s: the namespace of the Kustomize ConfigMap %s is not set", placementKey, configMapKey.Name))
+	}
+
+	if !rs.InformerManager.IsInformerSynced(utils.ConfigMapGVR) {
+		return nil, NewExpectedBehaviorError(fmt.Errorf("informer cache for %+v is not synced yet", utils.ConfigMapGVR))
+	}
+	obj, err := rs.InformerManager.Lister(utils.ConfigMapGVR).ByNamespace(configMapKey.Namespace).Get(configMapKey.Name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, NewUserError(fmt.Errorf("invalid placement %s: the Kustomize ConfigMap %s is not found", placementKey, configMapKey))
+		}
+		klog.ErrorS(err, "Failed to get the Kustomize ConfigMap", "configMap", configMapKey, "placement", placementKey)
+		return nil, NewAPIServerError(true, err)
+	}
+	var configMap corev1.ConfigMap
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.(*unstructured.Unstructured).Object, &configMap); err != nil {
+		return nil, NewUnexpectedBehaviorError(fmt.Errorf("failed to convert the ConfigMap %s: %w", configMapKey, err))
+	}
+
+	rendered, err := kustomize.Render(configMap.Data)
+	if err != nil {
+		klog.ErrorS(err, "Failed to render the Kustomize directory", "configMap", configMapKey, "placement", placementKey)
+		return nil, NewUserError(fmt.Errorf("invalid placement %s: failed to render the Kustomize directory in ConfigMap %s: %w", placementKey, configMapKey, err))
+	}
+	for _, obj := range rendered {
+		if err := rs.validateRenderedResource(placementKey, obj); err != nil {
+			return nil, err
+		}
+	}
+	klog.V(2).InfoS("Rendered the resources from the Kustomize directory", "configMap", configMapKey, "placement", placementKey, "resourceCount", len(rendered))
+	return rendered, nil
+}
+
+// renderHelmSource renders the resources from a Helm source of a placement, and returns the HelmRelease
+// object which keeps the reference to the chart, the digest of the chart archive, and the values in the
+// resource snapshots. The chart is rendered with the values
+// in the source so that the invalid charts and rendered resources are reported early; the work generator renders
+// the chart again for each member cluster after applying the overrides on the HelmRelease object.
+func (rs *ResourceSelectorResolver) renderHelmSource(placementKey types.NamespacedName, source *placementv1beta1.HelmSource) ([]*unstructured.Unstructured, error) {
+	releaseNamespace := source.ReleaseNamespace
+	if placementKey.Namespace != "" {
+		if releaseNamespace == "" {
+			releaseNamespace = placementKey.Namespace
+		}
+		if releaseNamespace != placementKey.Namespace {
+			return nil, NewUserError(fmt.Errorf("invalid placement %s: cannot render Helm release %s in namespace %s outside of the placement namespace", placementKey, source.ReleaseName, releaseNamespace))
+		}
+	} else if releaseNamespace == "" {
+		return nil, NewUserError(fmt.Errorf("invalid placement %s: the namespace of Helm release %s is not set", placementKey, source.ReleaseName))
+	}
+	if rs.HelmChartFetcher == nil {
+		return nil, NewUnexpectedBehaviorError(fmt.Errorf("no Helm chart fetcher is set up to render Helm release %s of placement %s", source.ReleaseName, placementKey))
+	}
+
+	// The HTTP client of the fetcher bounds the time to fetch the chart.
+	archive, err := rs.HelmChartFetcher.Fetch(context.Background(), &source.Chart)
+	if err != nil {
+		klog.ErrorS(err, "Failed to fetch the Helm chart", "chart", source.Chart.Name, "version", source.Chart.Version, "repository", source.Chart.Repository, "placement", placementKey)
+		// The error is reported to the user, as it is mostly caused by an invalid chart reference; the placement
+		// is reconciled again later in case the error is transient.
+		return nil, NewUserError(fmt.Errorf("invalid placement %s: failed to fetch the chart of Helm release %s: %w", placementKey, source.ReleaseName, err))
+	}
+	release, err := helm.NewRelease(source, releaseNamespace, archive)
+	if err != nil {
+		return nil, NewUserError(fmt.Errorf("invalid placement %s: invalid Helm release %s: %w", placementKey, source.ReleaseName, err))
+	}
+	// The chart which has just been fetched is rendered from the cache of the fetcher.
+	rendered, err := helm.RenderRelease(context.Background(), rs.HelmChartFetcher, release)
+	if err != nil {
+		klog.ErrorS(err, "Failed to render the Helm chart", "chart", source.Chart.Name, "version", source.Chart.Version, "placement", placementKey)
+		return nil, NewUserError(fmt.Errorf("invalid placement %s: failed to render Helm release %s: %w", placementKey, source.ReleaseName, err))
+	}
+	// The work generator renders the chart every time it reconciles a binding; reject the charts which render
+	// different resources every time, e.g., with random passwords, as they would keep updating the works.
+	renderedAgain, err := helm.RenderRelease(context.Background(), rs.HelmChartFetcher, release)
+	if err != nil {
+		return nil, NewUserError(fmt.Errorf("invalid placement %s: failed to render Helm release %s: %w", placementKey, source.ReleaseName, err))
+	}
+	if !equality.Semantic.DeepEqual(rendered, renderedAgain) {
+		return nil, NewUserError(fmt.Errorf("invalid placement %s: Helm release %s renders different resources every time, which is not supported", placementKey, source.ReleaseName))
+	}
+	for _, obj := range rendered {
+		if obj.GetNamespace() == "" && !rs.InformerManager.IsClusterScopedResources(obj.GroupVersionKind()) {
+			obj.SetNamespace(releaseNamespace)
+		}
+		if err := rs.validateRenderedResource(placementKey, obj); err != nil {
+			return nil, err
+		}
+	}
+	klog.V(2).InfoS("Rendered the resources from the Helm chart", "release", klog.KObj(release), "chart", source.Chart.Name, "version", source.Chart.Version, "placement", placementKey, "resourceCount", len(rendered))
+	return []*unstructured.Unstructured{release}, nil
+}
+
+// validateRenderedResource validates that a rendered resource can be placed by the placement, the same way as
+// the selected resources are checked; the namespace of the placement is set on the resources rendered for a
+// resourcePlacement without a namespace.
+func (rs *ResourceSelectorResolver) validateRenderedResource(placementKey types.NamespacedName, obj *unstructured.Unstructured) error {
+	gvk := obj.GroupVersionKind()
+	if _, err := rs.RestMapper.RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+		return NewUserError(fmt.Errorf("invalid placement %s: failed to get GVR of the rendered resource %s %s: %w", placementKey, gvk, obj.GetName(), err))
+	}
+	if rs.ResourceConfig.IsResourceDisabled(gvk) {
+		return NewUserError(fmt.Errorf("invalid placement %s: the rendered resource %s %s is not allowed to propagate", placementKey, gvk, obj.GetName()))
+	}
+
+	isNamespacedResource := !rs.InformerManager.IsClusterScopedResources(gvk)
+	switch {
+	case placementKey.Namespace != "" && !isNamespacedResource:
+		return NewUserError(fmt.Errorf("invalid placement %s: cannot render cluster-scoped resource %s %s in a resourcePlacement", placementKey, gvk, obj.GetName()))
+	case placementKey.Namespace != "" && obj.GetNamespace() == "":
+		obj.SetNamespace(placementKey.Namespace)
+	case placementKey.Namespace != "" && obj.GetNamespace() != placementKey.Namespace:
+		return NewUserError(fmt.Errorf("invalid placement %s: cannot render resource %s %s/%s outside of the placement namespace", placementKey, gvk, obj.GetNamespace(), obj.GetName()))
+	case isNamespacedResource && obj.GetNamespace() == "":
+		return NewUserError(fmt.Errorf("invalid placement %s: the namespace of the rendered resource %s %s is not set", placementKey, gvk, obj.GetName()))
+	case !isNamespacedResource && obj.GetNamespace() != "":
+		// Kustomize might set the namespace on the cluster-scoped resources of a kind it does not know.
+		obj.SetNamespace("")
+	}
+
+	namespace := obj.GetNamespace()
+	if gvk == utils.NamespaceGVK {
+		namespace = obj.GetName()
+	}
+	if namespace != "" && !utils.ShouldPropagateNamespace(namespace, rs.SkippedNamespaces) {
+		return NewUserError(fmt.Errorf("invalid placement %s: namespace %s of the rendered resource %s %s is not allowed to propagate", placementKey, namespace, gvk, obj.GetName()))
+	}
+	return nil
+}
+
+// resourceIdentifierOf returns the resource identifier of an object.
+func resourceIdentifierOf(obj *unstructured.Unstructured) placementv1beta1.ResourceIdentifier {
+	gvk := obj.GroupVersionKind()
+	return placementv1beta1.ResourceIdentifier{
+		Group:     gvk.Group,
+		Version:   gvk.Version,
+		Kind:      gvk.Kind,
+		Name:      obj.GetName(),
+		Namespace: obj.GetNamespace(),
+	}
+}
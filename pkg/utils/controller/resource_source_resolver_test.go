@@ -0,0 +1,414 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+	"go.goms.io/fleet/pkg/utils/helm"
+	testinformer "go.goms.io/fleet/test/utils/informer"
+)
+
+func TestAddRenderedResources(t *testing.T) {
+	kustomizeConfigMap := func(namespace string, data map[string]interface{}) *unstructured.Unstructured {
+		return &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata": map[string]interface{}{
+					"name":      "kustomization",
+					"namespace": namespace,
+				},
+				"data": data,
+			},
+		}
+	}
+	deploymentYAML := "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: app\n"
+	renderedDeployment := func(namespace string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata": map[string]interface{}{
+					"name":      "app",
+					"namespace": namespace,
+				},
+			},
+		}
+	}
+	renderedNamespace := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Namespace",
+			"metadata": map[string]interface{}{
+				"name": "app",
+			},
+		},
+	}
+	crpKey := types.NamespacedName{Name: "test-crp"}
+	rpKey := types.NamespacedName{Name: "test-rp", Namespace: "app"}
+	sources := []placementv1beta1.ResourceSource{
+		{
+			Kustomize: &placementv1beta1.KustomizeSource{
+				ConfigMapName:      "kustomization",
+				ConfigMapNamespace: "app",
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		placementKey types.NamespacedName
+		selected     []*unstructured.Unstructured
+		sources      []placementv1beta1.ResourceSource
+		configMaps   []runtime.Object
+		want         []*unstructured.Unstructured
+		wantErr      error
+	}{
+		{
+			name:         "render resources for a clusterResourcePlacement",
+			placementKey: crpKey,
+			sources:      sources,
+			configMaps: []runtime.Object{kustomizeConfigMap("app", map[string]interface{}{
+				"kustomization.yaml": "namespace: app\nresources:\n- namespace.yaml\n- deployment.yaml\n",
+				"namespace.yaml":     "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: app\n",
+				"deployment.yaml":    deploymentYAML,
+			})},
+			want: []*unstructured.Unstructured{renderedNamespace, renderedDeployment("app")},
+		},
+		{
+			name:         "render resources in the placement namespace for a resourcePlacement",
+			placementKey: rpKey,
+			selected:     []*unstructured.Unstructured{renderedNamespace},
+			sources: []placementv1beta1.ResourceSource{
+				{
+					Kustomize: &placementv1beta1.KustomizeSource{
+						ConfigMapName: "kustomization",
+					},
+				},
+			},
+			configMaps: []runtime.Object{kustomizeConfigMap("app", map[string]interface{}{
+				"kustomization.yaml": "resources:\n- deployment.yaml\n",
+				"deployment.yaml":    deploymentYAML,
+			})},
+			want: []*unstructured.Unstructured{renderedNamespace, renderedDeployment("app")},
+		},
+		{
+			name:         "rendered resource duplicates a selected one",
+			placementKey: crpKey,
+			selected:     []*unstructured.Unstructured{renderedDeployment("app")},
+			sources:      sources,
+			configMaps: []runtime.Object{kustomizeConfigMap("app", map[string]interface{}{
+				"kustomization.yaml": "namespace: app\nresources:\n- deployment.yaml\n",
+				"deployment.yaml":    deploymentYAML,
+			})},
+			wantErr: ErrUserError,
+		},
+		{
+			name:         "rendered namespaced resource without a namespace for a clusterResourcePlacement",
+			placementKey: crpKey,
+			sources:      sources,
+			configMaps: []runtime.Object{kustomizeConfigMap("app", map[string]interface{}{
+				"kustomization.yaml": "resources:\n- deployment.yaml\n",
+				"deployment.yaml":    deploymentYAML,
+			})},
+			wantErr: ErrUserError,
+		},
+		{
+			name:         "rendered resource outside of the namespace of a resourcePlacement",
+			placementKey: rpKey,
+			sources:      sources,
+			configMaps: []runtime.Object{kustomizeConfigMap("app", map[string]interface{}{
+				"kustomization.yaml": "namespace: other\nresources:\n- deployment.yaml\n",
+				"deployment.yaml":    deploymentYAML,
+			})},
+			wantErr: ErrUserError,
+		},
+		{
+			name:         "rendered resource in a reserved namespace",
+			placementKey: crpKey,
+			sources:      sources,
+			configMaps: []runtime.Object{kustomizeConfigMap("app", map[string]interface{}{
+				"kustomization.yaml": "namespace: kube-system\nresources:\n- deployment.yaml\n",
+				"deployment.yaml":    deploymentYAML,
+			})},
+			wantErr: ErrUserError,
+		},
+		{
+			name:         "rendered resource of an unknown kind",
+			placementKey: crpKey,
+			sources:      sources,
+			configMaps: []runtime.Object{kustomizeConfigMap("app", map[string]interface{}{
+				"kustomization.yaml": "resources:\n- widget.yaml\n",
+				"widget.yaml":        "apiVersion: example.com/v1\nkind: Widget\nmetadata:\n  name: widget\n",
+			})},
+			wantErr: ErrUserError,
+		},
+		{
+			name:         "invalid kustomization",
+			placementKey: crpKey,
+			sources:      sources,
+			configMaps: []runtime.Object{kustomizeConfigMap("app", map[string]interface{}{
+				"deployment.yaml": deploymentYAML,
+			})},
+			wantErr: ErrUserError,
+		},
+		{
+			name:         "kustomize ConfigMap not found",
+			placementKey: crpKey,
+			sources:      sources,
+			wantErr:      ErrUserError,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rsr := &ResourceSelectorResolver{
+				ResourceConfig: utils.NewResourceConfig(false),
+				InformerManager: &testinformer.FakeManager{
+					APIResources: map[schema.GroupVersionKind]bool{
+						utils.NamespaceGVK:   true,
+						utils.ClusterRoleGVK: true,
+					},
+					IsClusterScopedResource: true,
+					Listers: map[schema.GroupVersionResource]*testinformer.FakeLister{
+						utils.ConfigMapGVR: {Objects: tc.configMaps},
+					},
+				},
+				RestMapper: newFakeRESTMapper(),
+			}
+			got, err := rsr.addRenderedResources(tc.placementKey, tc.selected, tc.sources)
+			if gotErr, wantErr := err != nil, tc.wantErr != nil; gotErr != wantErr || !errors.Is(err, tc.wantErr) {
+				t.Fatalf("addRenderedResources() = %v, want error %v", err, tc.wantErr)
+			}
+			if tc.wantErr != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("addRenderedResources() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// fakeChartFetcher returns the same chart archive or error for any chart.
+type fakeChartFetcher struct {
+	archive []byte
+	err     error
+}
+
+func (f *fakeChartFetcher) Fetch(_ context.Context, _ *placementv1beta1.HelmChart) ([]byte, error) {
+	return f.archive, f.err
+}
+
+// buildChartArchive builds a chart archive with the given files in the chart directory.
+func buildChartArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: "app/" + name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatalf("WriteHeader() = %v", err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatalf("Write() = %v", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("Close() = %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("Close() = %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestAddRenderedResources_Helm(t *testing.T) {
+	archive := buildChartArchive(t, map[string]string{
+		"Chart.yaml":  "apiVersion: v2\nname: app\nversion: 1.0.0\n",
+		"values.yaml": "replicaCount: 1\n",
+		"templates/deployment.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ .Release.Name }}
+  {{- with .Values.namespace }}
+  namespace: {{ . }}
+  {{- end }}
+  {{- if .Values.random }}
+  annotations:
+    token: {{ randAlphaNum 16 }}
+  {{- end }}
+spec:
+  replicas: {{ required "replicaCount is required" .Values.replicaCount }}
+`,
+	})
+	chart := placementv1beta1.HelmChart{
+		Repository: "oci://registry.example.com/charts",
+		Name:       "app",
+		Version:    "1.0.0",
+	}
+	release := func(namespace string, values string) *unstructured.Unstructured {
+		source := &placementv1beta1.HelmSource{Chart: chart, ReleaseName: "web"}
+		if values != "" {
+			source.Values = &apiextensionsv1.JSON{Raw: []byte(values)}
+		}
+		obj, err := helm.NewRelease(source, namespace, archive)
+		if err != nil {
+			t.Fatalf("NewRelease() = %v", err)
+		}
+		return obj
+	}
+	helmSources := func(namespace string, values string) []placementv1beta1.ResourceSource {
+		source := &placementv1beta1.HelmSource{Chart: chart, ReleaseName: "web", ReleaseNamespace: namespace}
+		if values != "" {
+			source.Values = &apiextensionsv1.JSON{Raw: []byte(values)}
+		}
+		return []placementv1beta1.ResourceSource{{Helm: source}}
+	}
+	selectedNamespace := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Namespace",
+			"metadata": map[string]interface{}{
+				"name": "app",
+			},
+		},
+	}
+	crpKey := types.NamespacedName{Name: "test-crp"}
+	rpKey := types.NamespacedName{Name: "test-rp", Namespace: "app"}
+
+	tests := []struct {
+		name         string
+		placementKey types.NamespacedName
+		selected     []*unstructured.Unstructured
+		sources      []placementv1beta1.ResourceSource
+		fetcher      helm.ChartFetcher
+		want         []*unstructured.Unstructured
+		wantErr      error
+	}{
+		{
+			name:         "keep the HelmRelease object for a clusterResourcePlacement",
+			placementKey: crpKey,
+			selected:     []*unstructured.Unstructured{selectedNamespace},
+			sources:      helmSources("app", `{"replicaCount": 2}`),
+			fetcher:      &fakeChartFetcher{archive: archive},
+			want:         []*unstructured.Unstructured{selectedNamespace, release("app", `{"replicaCount": 2}`)},
+		},
+		{
+			name:         "install the release in the placement namespace for a resourcePlacement",
+			placementKey: rpKey,
+			sources:      helmSources("", ""),
+			fetcher:      &fakeChartFetcher{archive: archive},
+			want:         []*unstructured.Unstructured{release("app", "")},
+		},
+		{
+			name:         "release namespace is not set for a clusterResourcePlacement",
+			placementKey: crpKey,
+			sources:      helmSources("", ""),
+			fetcher:      &fakeChartFetcher{archive: archive},
+			wantErr:      ErrUserError,
+		},
+		{
+			name:         "release namespace outside of the namespace of a resourcePlacement",
+			placementKey: rpKey,
+			sources:      helmSources("other", ""),
+			fetcher:      &fakeChartFetcher{archive: archive},
+			wantErr:      ErrUserError,
+		},
+		{
+			name:         "rendered resource outside of the namespace of a resourcePlacement",
+			placementKey: rpKey,
+			sources:      helmSources("", `{"namespace": "other"}`),
+			fetcher:      &fakeChartFetcher{archive: archive},
+			wantErr:      ErrUserError,
+		},
+		{
+			name:         "rendered resource in a reserved namespace",
+			placementKey: crpKey,
+			sources:      helmSources("app", `{"namespace": "kube-system"}`),
+			fetcher:      &fakeChartFetcher{archive: archive},
+			wantErr:      ErrUserError,
+		},
+		{
+			name:         "chart cannot be rendered with the values",
+			placementKey: crpKey,
+			sources:      helmSources("app", `{"replicaCount": null}`),
+			fetcher:      &fakeChartFetcher{archive: archive},
+			wantErr:      ErrUserError,
+		},
+		{
+			name:         "chart renders different resources every time",
+			placementKey: crpKey,
+			sources:      helmSources("app", `{"random": true}`),
+			fetcher:      &fakeChartFetcher{archive: archive},
+			wantErr:      ErrUserError,
+		},
+		{
+			name:         "chart cannot be fetched",
+			placementKey: crpKey,
+			sources:      helmSources("app", ""),
+			fetcher:      &fakeChartFetcher{err: errors.New("chart not found")},
+			wantErr:      ErrUserError,
+		},
+		{
+			name:         "no chart fetcher",
+			placementKey: crpKey,
+			sources:      helmSources("app", ""),
+			wantErr:      ErrUnexpectedBehavior,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rsr := &ResourceSelectorResolver{
+				ResourceConfig: utils.NewResourceConfig(false),
+				InformerManager: &testinformer.FakeManager{
+					APIResources: map[schema.GroupVersionKind]bool{
+						utils.NamespaceGVK:   true,
+						utils.ClusterRoleGVK: true,
+					},
+					IsClusterScopedResource: true,
+				},
+				RestMapper:       newFakeRESTMapper(),
+				HelmChartFetcher: tc.fetcher,
+			}
+			got, err := rsr.addRenderedResources(tc.placementKey, tc.selected, tc.sources)
+			if gotErr, wantErr := err != nil, tc.wantErr != nil; gotErr != wantErr || !errors.Is(err, tc.wantErr) {
+				t.Fatalf("addRenderedResources() = %v, want error %v", err, tc.wantErr)
+			}
+			if tc.wantErr != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("addRenderedResources() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
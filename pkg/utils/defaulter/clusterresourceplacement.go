@@ -38,6 +38,10 @@ const (
 	// DefaultMaxSkewValue is the default degree to which resources may be unevenly distributed.
 	DefaultMaxSkewValue = 1
 
+	// DefaultDriftRemediationGracePeriodSeconds is the default grace period before Fleet reverts a drift
+	// when a drift remediation policy is specified.
+	DefaultDriftRemediationGracePeriodSeconds = 3600
+
 	// DefaultRevisionHistoryLimitValue is the default value of RevisionHistoryLimit.
 	DefaultRevisionHistoryLimitValue = 10
 )
@@ -119,4 +123,7 @@ func SetDefaultsApplyStrategy(obj *fleetv1beta1.ApplyStrategy) {
 	if obj.WhenToTakeOver == "" {
 		obj.WhenToTakeOver = fleetv1beta1.WhenToTakeOverTypeAlways
 	}
+	if obj.DriftRemediation != nil && obj.DriftRemediation.GracePeriodSeconds == nil {
+		obj.DriftRemediation.GracePeriodSeconds = ptr.To(int32(DefaultDriftRemediationGracePeriodSeconds))
+	}
 }
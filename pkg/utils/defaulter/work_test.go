@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"k8s.io/utils/ptr"
 
 	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
 )
@@ -85,6 +86,30 @@ func TestSetDefaultsWork(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "nil drift remediation grace period",
+			work: placementv1beta1.Work{
+				Spec: placementv1beta1.WorkSpec{
+					ApplyStrategy: &placementv1beta1.ApplyStrategy{
+						WhenToApply:      placementv1beta1.WhenToApplyTypeIfNotDrifted,
+						DriftRemediation: &placementv1beta1.DriftRemediationPolicy{},
+					},
+				},
+			},
+			want: placementv1beta1.Work{
+				Spec: placementv1beta1.WorkSpec{
+					ApplyStrategy: &placementv1beta1.ApplyStrategy{
+						Type:             placementv1beta1.ApplyStrategyTypeClientSideApply,
+						ComparisonOption: placementv1beta1.ComparisonOptionTypePartialComparison,
+						WhenToApply:      placementv1beta1.WhenToApplyTypeIfNotDrifted,
+						WhenToTakeOver:   placementv1beta1.WhenToTakeOverTypeAlways,
+						DriftRemediation: &placementv1beta1.DriftRemediationPolicy{
+							GracePeriodSeconds: ptr.To(int32(DefaultDriftRemediationGracePeriodSeconds)),
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "client-side apply",
 			work: placementv1beta1.Work{
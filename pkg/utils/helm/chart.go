@@ -0,0 +1,74 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package helm features utilities for fetching Helm charts from chart repositories and OCI registries,
+// and for rendering the resources from them with the Helm chart loader and template engine.
+package helm
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+)
+
+const (
+	// MaxChartArchiveSize is the maximum size of a (compressed) chart archive that is fetched and loaded.
+	MaxChartArchiveSize = 2 * (1 << 20) // 2MB
+)
+
+// LoadArchive loads a chart from a chart archive, i.e., a gzipped tarball with the chart directory at its root,
+// the same way as `helm install` does. The subcharts of the chart must be vendored in its charts directory.
+func LoadArchive(archive []byte) (*chart.Chart, error) {
+	if len(archive) > MaxChartArchiveSize {
+		return nil, fmt.Errorf("the chart archive has %d bytes, which exceeds the limit of %d bytes", len(archive), MaxChartArchiveSize)
+	}
+	c, err := loader.LoadArchive(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the chart archive: %w", err)
+	}
+	if err := checkInstallable(c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// checkInstallable checks that a chart can be rendered into a release, i.e., it is an application chart and
+// all of its dependencies are vendored.
+func checkInstallable(c *chart.Chart) error {
+	if c.Metadata.Type != "" && c.Metadata.Type != "application" {
+		return fmt.Errorf("chart %s is a %s chart, which is not installable", c.Name(), c.Metadata.Type)
+	}
+	var missing []string
+	for _, dep := range c.Metadata.Dependencies {
+		found := false
+		for _, sub := range c.Dependencies() {
+			if sub.Name() == dep.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, dep.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("the dependencies of chart %s are found in Chart.yaml, but missing in the charts directory: %s", c.Name(), strings.Join(missing, ", "))
+	}
+	return nil
+}
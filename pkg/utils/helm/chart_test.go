@@ -0,0 +1,196 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helm
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const testChartYAML = "apiVersion: v2\nname: app\nversion: 1.0.0\nappVersion: \"2.0\"\n"
+
+// buildArchive builds a chart archive with the given files, keyed by their paths in the archive.
+func buildArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, name := range names {
+		header := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(files[name])), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatalf("WriteHeader() = %v", err)
+		}
+		if _, err := tw.Write([]byte(files[name])); err != nil {
+			t.Fatalf("Write() = %v", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("Close() = %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("Close() = %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestLoadArchive(t *testing.T) {
+	tests := []struct {
+		name          string
+		files         map[string]string
+		archive       []byte
+		wantName      string
+		wantTemplates []string
+		wantSubcharts []string
+		wantErr       bool
+	}{
+		{
+			name: "chart with all kinds of files",
+			files: map[string]string{
+				"app/Chart.yaml":                testChartYAML,
+				"app/values.yaml":               "replicaCount: 1\n",
+				"app/templates/deployment.yaml": "kind: Deployment\n",
+				"app/templates/_helpers.tpl":    "{{- define \"app.name\" -}}app{{- end }}\n",
+				"app/crds/widget.yaml":          "kind: CustomResourceDefinition\n",
+				"app/config/app.properties":     "key=value\n",
+			},
+			wantName:      "app",
+			wantTemplates: []string{"templates/_helpers.tpl", "templates/deployment.yaml"},
+		},
+		{
+			name: "chart with a vendored subchart",
+			files: map[string]string{
+				"app/Chart.yaml":                      testChartYAML + "dependencies:\n- name: redis\n  version: 1.0.0\n",
+				"app/templates/service.yaml":          "kind: Service\n",
+				"app/charts/redis/Chart.yaml":         "apiVersion: v2\nname: redis\nversion: 1.0.0\n",
+				"app/charts/redis/templates/sts.yaml": "kind: StatefulSet\n",
+			},
+			wantName:      "app",
+			wantTemplates: []string{"templates/service.yaml"},
+			wantSubcharts: []string{"redis"},
+		},
+		{
+			name:    "not a gzipped tarball",
+			archive: []byte("not an archive"),
+			wantErr: true,
+		},
+		{
+			name: "no Chart.yaml file",
+			files: map[string]string{
+				"app/values.yaml": "replicaCount: 1\n",
+			},
+			wantErr: true,
+		},
+		{
+			name: "file outside of the chart directory",
+			files: map[string]string{
+				"app/Chart.yaml":        testChartYAML,
+				"app/../../etc/passwd":  "root",
+				"app/templates/cm.yaml": "kind: ConfigMap\n",
+			},
+			wantErr: true,
+		},
+		{
+			name: "chart without a version",
+			files: map[string]string{
+				"app/Chart.yaml": "apiVersion: v2\nname: app\n",
+			},
+			wantErr: true,
+		},
+		{
+			name: "library chart",
+			files: map[string]string{
+				"app/Chart.yaml": testChartYAML + "type: library\n",
+			},
+			wantErr: true,
+		},
+		{
+			name: "dependency which is not vendored",
+			files: map[string]string{
+				"app/Chart.yaml": testChartYAML + "dependencies:\n- name: redis\n  version: 1.0.0\n",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid values file",
+			files: map[string]string{
+				"app/Chart.yaml":  testChartYAML,
+				"app/values.yaml": "- not\n- an object\n",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			archive := tc.archive
+			if archive == nil {
+				archive = buildArchive(t, tc.files)
+			}
+			got, err := LoadArchive(archive)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("LoadArchive() = %v, want error %t", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if got.Name() != tc.wantName {
+				t.Errorf("LoadArchive() returned chart %s, want %s", got.Name(), tc.wantName)
+			}
+			templates := make([]string, 0, len(got.Templates))
+			for _, f := range got.Templates {
+				templates = append(templates, f.Name)
+			}
+			sort.Strings(templates)
+			if diff := cmp.Diff(templates, tc.wantTemplates); diff != "" {
+				t.Errorf("LoadArchive() templates mismatch (-got, +want):\n%s", diff)
+			}
+			var subcharts []string
+			for _, sub := range got.Dependencies() {
+				subcharts = append(subcharts, sub.Name())
+			}
+			if diff := cmp.Diff(subcharts, tc.wantSubcharts); diff != "" {
+				t.Errorf("LoadArchive() subcharts mismatch (-got, +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLoadArchive_TooLarge(t *testing.T) {
+	// Random content cannot be compressed below the limit.
+	content := make([]byte, MaxChartArchiveSize+1)
+	if _, err := rand.Read(content); err != nil {
+		t.Fatalf("Read() = %v", err)
+	}
+	archive := buildArchive(t, map[string]string{
+		"app/Chart.yaml":       testChartYAML,
+		"app/files/random.bin": string(content),
+	})
+	if _, err := LoadArchive(archive); err == nil {
+		t.Errorf("LoadArchive() = nil, want error")
+	}
+}
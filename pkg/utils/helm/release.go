@@ -0,0 +1,162 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// ReleaseGVK is the GVK of the HelmRelease objects, which keep the references to the charts and the values of
+// the Helm sources in the resource snapshots. They are not served by the hub cluster and are never placed on the member clusters.
+var ReleaseGVK = schema.GroupVersionKind{
+	Group:   placementv1beta1.GroupVersion.Group,
+	Version: placementv1beta1.GroupVersion.Version,
+	Kind:    "HelmRelease",
+}
+
+// Release is a HelmRelease object.
+type Release struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ReleaseSpec `json:"spec"`
+}
+
+// ReleaseSpec is the spec of a HelmRelease object.
+type ReleaseSpec struct {
+	// Chart is the chart in the Helm source.
+	Chart placementv1beta1.HelmChart `json:"chart"`
+	// ChartDigest is the SHA-256 digest of the archive of the chart, fetched when the resource snapshot is created.
+	// The chart is fetched again when the release is rendered, and it must have the same digest, so that all the
+	// member clusters get the resources rendered from the same chart.
+	ChartDigest string `json:"chartDigest"`
+	// Values are the values to render the chart with.
+	Values map[string]interface{} `json:"values,omitempty"`
+}
+
+// NewRelease returns the HelmRelease object for a Helm source, with the digest of the archive of its chart.
+func NewRelease(source *placementv1beta1.HelmSource, releaseNamespace string, archive []byte) (*unstructured.Unstructured, error) {
+	values, err := decodeValues(source.Values)
+	if err != nil {
+		return nil, err
+	}
+	release := &Release{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: ReleaseGVK.GroupVersion().String(),
+			Kind:       ReleaseGVK.Kind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      source.ReleaseName,
+			Namespace: releaseNamespace,
+		},
+		Spec: ReleaseSpec{
+			Chart:       source.Chart,
+			ChartDigest: archiveDigest(archive),
+			Values:      values,
+		},
+	}
+	data, err := json.Marshal(release)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal HelmRelease %s: %w", source.ReleaseName, err)
+	}
+	u := &unstructured.Unstructured{}
+	if err := u.UnmarshalJSON(data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal HelmRelease %s: %w", source.ReleaseName, err)
+	}
+	// Drop the empty creation timestamp, which the resources read from the API server do not have either.
+	unstructured.RemoveNestedField(u.Object, "metadata", "creationTimestamp")
+	return u, nil
+}
+
+// IsRelease returns whether an object is a HelmRelease object.
+func IsRelease(obj *unstructured.Unstructured) bool {
+	return obj.GroupVersionKind() == ReleaseGVK
+}
+
+// FetchError is returned by RenderRelease when the chart of a HelmRelease object cannot be fetched, which might be
+// transient, unlike the other errors which are caused by the chart or the values.
+type FetchError struct {
+	Err error
+}
+
+// Error implements error.
+func (e *FetchError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *FetchError) Unwrap() error {
+	return e.Err
+}
+
+// RenderRelease fetches the chart in a HelmRelease object and renders it with the values in the object.
+func RenderRelease(ctx context.Context, fetcher ChartFetcher, obj *unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	data, err := obj.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal HelmRelease %s: %w", obj.GetName(), err)
+	}
+	var release Release
+	if err := json.Unmarshal(data, &release); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal HelmRelease %s: %w", obj.GetName(), err)
+	}
+	archive, err := fetcher.Fetch(ctx, &release.Spec.Chart)
+	if err != nil {
+		return nil, &FetchError{Err: fmt.Errorf("failed to fetch chart %s version %s: %w", release.Spec.Chart.Name, release.Spec.Chart.Version, err)}
+	}
+	if digest := archiveDigest(archive); digest != release.Spec.ChartDigest {
+		return nil, fmt.Errorf("chart %s version %s has digest %s, which has changed since the resource snapshot was created with digest %s",
+			release.Spec.Chart.Name, release.Spec.Chart.Version, digest, release.Spec.ChartDigest)
+	}
+	c, err := LoadArchive(archive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load chart %s version %s: %w", release.Spec.Chart.Name, release.Spec.Chart.Version, err)
+	}
+	rendered, err := Render(c, ReleaseOptions{Name: release.Name, Namespace: release.Namespace}, release.Spec.Values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render chart %s version %s: %w", release.Spec.Chart.Name, release.Spec.Chart.Version, err)
+	}
+	return rendered, nil
+}
+
+// archiveDigest returns the SHA-256 digest of a chart archive in the form of sha256:<hex>.
+func archiveDigest(archive []byte) string {
+	sum := sha256.Sum256(archive)
+	return sha256DigestPrefix + hex.EncodeToString(sum[:])
+}
+
+// decodeValues decodes the values in a Helm source.
+func decodeValues(raw *apiextensionsv1.JSON) (map[string]interface{}, error) {
+	if raw == nil || len(raw.Raw) == 0 {
+		return nil, nil
+	}
+	var values map[string]interface{}
+	if err := json.Unmarshal(raw.Raw, &values); err != nil {
+		return nil, fmt.Errorf("the values must be an object: %w", err)
+	}
+	return values, nil
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+func TestNewRelease(t *testing.T) {
+	archive := buildArchive(t, testChartFiles)
+	chart := placementv1beta1.HelmChart{
+		Repository: "oci://registry.example.com/charts",
+		Name:       "app",
+		Version:    "1.0.0",
+	}
+	tests := []struct {
+		name    string
+		source  *placementv1beta1.HelmSource
+		want    *unstructured.Unstructured
+		wantErr bool
+	}{
+		{
+			name: "source with values",
+			source: &placementv1beta1.HelmSource{
+				Chart:       chart,
+				ReleaseName: "web",
+				Values:      &apiextensionsv1.JSON{Raw: []byte(`{"replicaCount": 2}`)},
+			},
+			want: &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "placement.kubernetes-fleet.io/v1beta1",
+				"kind":       "HelmRelease",
+				"metadata": map[string]interface{}{
+					"name":      "web",
+					"namespace": "apps",
+				},
+				"spec": map[string]interface{}{
+					"chart": map[string]interface{}{
+						"repository": "oci://registry.example.com/charts",
+						"name":       "app",
+						"version":    "1.0.0",
+					},
+					"chartDigest": "sha256:" + sha256Hex(archive),
+					"values":      map[string]interface{}{"replicaCount": int64(2)},
+				},
+			}},
+		},
+		{
+			name: "source without values",
+			source: &placementv1beta1.HelmSource{
+				Chart:       chart,
+				ReleaseName: "web",
+			},
+			want: &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "placement.kubernetes-fleet.io/v1beta1",
+				"kind":       "HelmRelease",
+				"metadata": map[string]interface{}{
+					"name":      "web",
+					"namespace": "apps",
+				},
+				"spec": map[string]interface{}{
+					"chart": map[string]interface{}{
+						"repository": "oci://registry.example.com/charts",
+						"name":       "app",
+						"version":    "1.0.0",
+					},
+					"chartDigest": "sha256:" + sha256Hex(archive),
+				},
+			}},
+		},
+		{
+			name: "values which are not an object",
+			source: &placementv1beta1.HelmSource{
+				Chart:       chart,
+				ReleaseName: "web",
+				Values:      &apiextensionsv1.JSON{Raw: []byte(`"replicaCount"`)},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NewRelease(tc.source, "apps", archive)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("NewRelease() = %v, want error %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("NewRelease() mismatch (-got, +want):\n%s", diff)
+			}
+			if tc.wantErr {
+				return
+			}
+			if !IsRelease(got) {
+				t.Errorf("IsRelease() = false, want true")
+			}
+		})
+	}
+}
+
+// fakeChartFetcher returns the given chart archive or error.
+type fakeChartFetcher struct {
+	archive []byte
+	err     error
+}
+
+// Fetch implements ChartFetcher.
+func (f *fakeChartFetcher) Fetch(_ context.Context, _ *placementv1beta1.HelmChart) ([]byte, error) {
+	return f.archive, f.err
+}
+
+func TestRenderRelease(t *testing.T) {
+	archive := buildArchive(t, testChartFiles)
+	release, err := NewRelease(&placementv1beta1.HelmSource{
+		Chart: placementv1beta1.HelmChart{
+			Repository: "oci://registry.example.com/charts",
+			Name:       "app",
+			Version:    "1.0.0",
+		},
+		ReleaseName: "web",
+		Values:      &apiextensionsv1.JSON{Raw: []byte(`{"replicaCount": 2}`)},
+	}, "apps", archive)
+	if err != nil {
+		t.Fatalf("NewRelease() = %v", err)
+	}
+	// Set the values the same way as an override which patches /spec/values/replicaCount.
+	if err := unstructured.SetNestedField(release.Object, int64(5), "spec", "values", "replicaCount"); err != nil {
+		t.Fatalf("SetNestedField() = %v", err)
+	}
+
+	got, err := RenderRelease(context.Background(), &fakeChartFetcher{archive: archive}, release)
+	if err != nil {
+		t.Fatalf("RenderRelease() = %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("RenderRelease() returned %d resources, want 3", len(got))
+	}
+	replicas, _, err := unstructured.NestedInt64(got[2].Object, "spec", "replicas")
+	if err != nil || replicas != 5 {
+		t.Errorf("RenderRelease() rendered a deployment with %d replicas (err: %v), want 5", replicas, err)
+	}
+	namespace, _, _ := unstructured.NestedString(got[1].Object, "data", "namespace")
+	if namespace != "apps" {
+		t.Errorf("RenderRelease() rendered release namespace %q, want %q", namespace, "apps")
+	}
+
+	// The release cannot be rendered if the chart has changed since the resource snapshot was created.
+	changed := buildArchive(t, map[string]string{"app/Chart.yaml": testChartYAML})
+	_, err = RenderRelease(context.Background(), &fakeChartFetcher{archive: changed}, release)
+	var fetchErr *FetchError
+	if err == nil || errors.As(err, &fetchErr) {
+		t.Errorf("RenderRelease() = %v, want an error which is not a FetchError for a changed chart", err)
+	}
+
+	// The errors to fetch the chart are reported as FetchErrors.
+	_, err = RenderRelease(context.Background(), &fakeChartFetcher{err: errors.New("unavailable")}, release)
+	if !errors.As(err, &fetchErr) {
+		t.Errorf("RenderRelease() = %v, want a FetchError", err)
+	}
+}
+
+func TestIsRelease(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+	}}
+	if IsRelease(obj) {
+		t.Errorf("IsRelease() = true, want false")
+	}
+}
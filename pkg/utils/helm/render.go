@@ -0,0 +1,166 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helm
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/engine"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// maxRenderedSize is the maximum total size of the output of the templates of a chart.
+	maxRenderedSize = 10 * (1 << 20) // 10MB
+
+	// kubeVersion is the Kubernetes version that the charts are rendered against. The charts are rendered
+	// in the hub cluster for all the member clusters, so the version of the client libraries that Fleet is
+	// built with is used, similar to `helm template`.
+	kubeVersion = "v1.34.0"
+
+	// notesFileName is the name of the template which renders the usage notes of a chart.
+	notesFileName = "NOTES.txt"
+)
+
+// manifestSeparatorRE matches the separators between the YAML documents in the output of a template.
+var manifestSeparatorRE = regexp.MustCompile(`(?:^|\s*\n)---\s*`)
+
+// ReleaseOptions are the information of the release which the templates are rendered with.
+type ReleaseOptions struct {
+	// Name is the name of the release.
+	Name string
+	// Namespace is the namespace of the release.
+	Namespace string
+}
+
+// Render renders a chart with the given values, which are merged with the default values of the chart and of
+// its subcharts, and returns the rendered resources: the CRDs in the crds directories of the chart and of its
+// subcharts come first, followed by the resources rendered from the templates in the order of the template paths.
+//
+// The chart is rendered the same way as `helm template --include-crds` does, with the Helm template engine; the
+// lookup function always returns nothing, as the chart is not rendered against a live cluster. The namespaces of
+// the rendered resources are kept as they are, i.e., the namespaced resources without a namespace are to be created
+// in the release namespace.
+//
+// Render processes the dependencies of the chart in place, so a chart can only be rendered once.
+func Render(c *chart.Chart, opts ReleaseOptions, values map[string]interface{}) ([]*unstructured.Unstructured, error) {
+	caps, err := capabilities()
+	if err != nil {
+		return nil, err
+	}
+	if c.Metadata.KubeVersion != "" && !chartutil.IsCompatibleRange(c.Metadata.KubeVersion, caps.KubeVersion.String()) {
+		return nil, fmt.Errorf("chart %s requires kubeVersion %s, which is incompatible with Kubernetes %s", c.Name(), c.Metadata.KubeVersion, caps.KubeVersion.String())
+	}
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+	// Enable or disable the subcharts with their conditions and tags, and import the values of the subcharts.
+	if err := chartutil.ProcessDependenciesWithMerge(c, values); err != nil {
+		return nil, fmt.Errorf("failed to process the dependencies of chart %s: %w", c.Name(), err)
+	}
+	renderValues, err := chartutil.ToRenderValues(c, values, chartutil.ReleaseOptions{
+		Name:      opts.Name,
+		Namespace: opts.Namespace,
+		Revision:  1,
+		IsInstall: true,
+	}, caps)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compose the values of chart %s: %w", c.Name(), err)
+	}
+
+	var objs []*unstructured.Unstructured
+	renderedSize := 0
+	for _, crd := range c.CRDObjects() {
+		renderedSize += len(crd.File.Data)
+		rendered, err := parseManifests(crd.Filename, string(crd.File.Data))
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, rendered...)
+	}
+
+	files, err := engine.Render(c, renderValues)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render chart %s: %w", c.Name(), err)
+	}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		// Skip the notes of the chart and of its subcharts, which are not rendered into resources.
+		if path.Base(name) == notesFileName {
+			continue
+		}
+		names = append(names, name)
+		renderedSize += len(files[name])
+	}
+	if renderedSize > maxRenderedSize {
+		return nil, fmt.Errorf("the rendered output of chart %s has %d bytes, which exceeds the limit of %d bytes", c.Name(), renderedSize, maxRenderedSize)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		rendered, err := parseManifests(name, files[name])
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, rendered...)
+	}
+	return objs, nil
+}
+
+// capabilities returns the capabilities of the cluster which the charts are rendered for, i.e., .Capabilities
+// in the templates: the Kubernetes version that Fleet is built with and the built-in API versions.
+func capabilities() (*chartutil.Capabilities, error) {
+	caps := chartutil.DefaultCapabilities.Copy()
+	version, err := chartutil.ParseKubeVersion(kubeVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the Kubernetes version %s: %w", kubeVersion, err)
+	}
+	caps.KubeVersion = *version
+	return caps, nil
+}
+
+// parseManifests parses the YAML documents in the output of a template into resources.
+func parseManifests(name, content string) ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+	for i, doc := range manifestSeparatorRE.Split(content, -1) {
+		data, err := yaml.YAMLToJSON([]byte(doc))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse document %d rendered from %s: %w", i, name, err)
+		}
+		if data = bytes.TrimSpace(data); bytes.Equal(data, []byte("null")) || bytes.Equal(data, []byte("{}")) {
+			// The document is empty or only has comments.
+			continue
+		}
+		// Decode the resource from JSON so that the numbers are decoded the same way as in the objects
+		// read from the API server.
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(data); err != nil {
+			return nil, fmt.Errorf("document %d rendered from %s is not a Kubernetes resource: %w", i, name, err)
+		}
+		if obj.GetAPIVersion() == "" {
+			return nil, fmt.Errorf("document %d rendered from %s is not a Kubernetes resource: apiVersion is not set", i, name)
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
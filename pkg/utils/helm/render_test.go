@@ -0,0 +1,247 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helm
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var testChartFiles = map[string]string{
+	"app/Chart.yaml":  testChartYAML,
+	"app/values.yaml": "replicaCount: 1\nlabels:\n  tier: web\nextra: debug\nservice:\n  enabled: false\n",
+	"app/templates/_helpers.tpl": `{{- define "app.fullname" -}}
+{{ .Release.Name }}-{{ .Chart.Name }}
+{{- end }}
+`,
+	"app/templates/configmap.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ include "app.fullname" . }}
+  labels:
+    {{- toYaml .Values.labels | nindent 4 }}
+data:
+  replicas: {{ .Values.replicaCount | quote }}
+  namespace: {{ .Release.Namespace }}
+  {{- if .Values.extra }}
+  extra: {{ .Values.extra }}
+  {{- end }}
+  missing: "{{ .Values.missing }}"
+`,
+	"app/templates/deployment.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ include "app.fullname" . }}
+spec:
+  replicas: {{ .Values.replicaCount }}
+{{- if .Values.service.enabled }}
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: {{ include "app.fullname" . }}
+{{- end }}
+`,
+	"app/templates/NOTES.txt": "Thank you for installing {{ .Chart.Name }}.\n",
+	"app/crds/widget.yaml": `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+`,
+}
+
+func TestRender(t *testing.T) {
+	crd := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]interface{}{"name": "widgets.example.com"},
+	}}
+	configMap := func(labels map[string]interface{}, data map[string]interface{}) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":   "web-app",
+				"labels": labels,
+			},
+			"data": data,
+		}}
+	}
+	deployment := func(replicas int64) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata":   map[string]interface{}{"name": "web-app"},
+			"spec":       map[string]interface{}{"replicas": replicas},
+		}}
+	}
+	service := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Service",
+		"metadata":   map[string]interface{}{"name": "web-app"},
+	}}
+
+	tests := []struct {
+		name    string
+		files   map[string]string
+		values  map[string]interface{}
+		want    []*unstructured.Unstructured
+		wantErr bool
+	}{
+		{
+			name:  "default values",
+			files: testChartFiles,
+			want: []*unstructured.Unstructured{
+				crd,
+				configMap(map[string]interface{}{"tier": "web"}, map[string]interface{}{"replicas": "1", "namespace": "apps", "extra": "debug", "missing": ""}),
+				deployment(1),
+			},
+		},
+		{
+			name:  "given values are merged with the default values",
+			files: testChartFiles,
+			values: map[string]interface{}{
+				"replicaCount": 3,
+				"labels":       map[string]interface{}{"team": "blue"},
+				"extra":        nil,
+				"service":      map[string]interface{}{"enabled": true},
+			},
+			want: []*unstructured.Unstructured{
+				crd,
+				configMap(map[string]interface{}{"tier": "web", "team": "blue"}, map[string]interface{}{"replicas": "3", "namespace": "apps", "missing": ""}),
+				deployment(3),
+				service,
+			},
+		},
+		{
+			name: "required value is not set",
+			files: map[string]string{
+				"app/Chart.yaml":          testChartYAML,
+				"app/templates/cm.yaml":   "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{ required \"name is required\" .Values.name }}\n",
+				"app/templates/_none.tpl": "",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid template",
+			files: map[string]string{
+				"app/Chart.yaml":        testChartYAML,
+				"app/templates/cm.yaml": "{{ .Values.name ",
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown function",
+			files: map[string]string{
+				"app/Chart.yaml":        testChartYAML,
+				"app/templates/cm.yaml": "name: {{ notAFunction 5 }}\n",
+			},
+			wantErr: true,
+		},
+		{
+			name: "subcharts enabled by the values",
+			files: map[string]string{
+				"app/Chart.yaml": testChartYAML + `dependencies:
+- name: redis
+  version: 1.0.0
+  condition: redis.enabled
+- name: cache
+  version: 1.0.0
+  condition: cache.enabled
+`,
+				"app/values.yaml":                      "redis:\n  enabled: true\n  replicas: 1\ncache:\n  enabled: false\n",
+				"app/charts/redis/Chart.yaml":          "apiVersion: v2\nname: redis\nversion: 1.0.0\n",
+				"app/charts/redis/values.yaml":         "replicas: 3\n",
+				"app/charts/redis/templates/cm.yaml":   "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{ .Release.Name }}-redis\ndata:\n  replicas: {{ .Values.replicas | quote }}\n",
+				"app/charts/cache/Chart.yaml":          "apiVersion: v2\nname: cache\nversion: 1.0.0\n",
+				"app/charts/cache/templates/cm.yaml":   "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{ .Release.Name }}-cache\n",
+				"app/charts/cache/templates/NOTES.txt": "cache",
+			},
+			values: map[string]interface{}{"redis": map[string]interface{}{"replicas": 2}},
+			want: []*unstructured.Unstructured{
+				{Object: map[string]interface{}{
+					"apiVersion": "v1",
+					"kind":       "ConfigMap",
+					"metadata":   map[string]interface{}{"name": "web-redis"},
+					"data":       map[string]interface{}{"replicas": "2"},
+				}},
+			},
+		},
+		{
+			name: "chart requires another Kubernetes version",
+			files: map[string]string{
+				"app/Chart.yaml":        testChartYAML + "kubeVersion: \"<1.25.0\"\n",
+				"app/templates/cm.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: app\n",
+			},
+			wantErr: true,
+		},
+		{
+			name: "rendered document is not a resource",
+			files: map[string]string{
+				"app/Chart.yaml":        testChartYAML,
+				"app/templates/cm.yaml": "name: app\n",
+			},
+			wantErr: true,
+		},
+		{
+			name: "include recursion is bounded",
+			files: map[string]string{
+				"app/Chart.yaml":         testChartYAML,
+				"app/templates/_r.tpl":   `{{- define "loop" -}}{{ include "loop" . }}{{- end }}`,
+				"app/templates/cm.yaml":  `{{ include "loop" . }}`,
+				"app/templates/cm2.yaml": "",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := LoadArchive(buildArchive(t, tc.files))
+			if err != nil {
+				t.Fatalf("LoadArchive() = %v", err)
+			}
+			got, err := Render(c, ReleaseOptions{Name: "web", Namespace: "apps"}, tc.values)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Render() = %v, want error %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("Render() mismatch (-got, +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRender_DoesNotChangeDefaultValues(t *testing.T) {
+	c, err := LoadArchive(buildArchive(t, testChartFiles))
+	if err != nil {
+		t.Fatalf("LoadArchive() = %v", err)
+	}
+	want := map[string]interface{}{
+		"replicaCount": float64(1),
+		"labels":       map[string]interface{}{"tier": "web"},
+		"extra":        "debug",
+		"service":      map[string]interface{}{"enabled": false},
+	}
+	if _, err := Render(c, ReleaseOptions{Name: "web", Namespace: "apps"}, map[string]interface{}{"labels": map[string]interface{}{"team": "blue"}, "extra": nil}); err != nil {
+		t.Fatalf("Render() = %v", err)
+	}
+	if diff := cmp.Diff(c.Values, want); diff != "" {
+		t.Errorf("chart values mismatch (-got, +want):\n%s", diff)
+	}
+}
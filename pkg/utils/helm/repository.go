@@ -0,0 +1,471 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+const (
+	// defaultRequestTimeout is the timeout of a request to a chart repository or an OCI registry,
+	// including reading the response body.
+	defaultRequestTimeout = 30 * time.Second
+
+	// defaultCacheTTL is how long a fetched chart archive is cached before it is fetched again.
+	defaultCacheTTL = 10 * time.Minute
+
+	// maxCachedCharts is the maximum number of the chart archives in the cache.
+	maxCachedCharts = 100
+
+	// maxRedirects is the maximum number of the redirects followed by a request, the same as the default of
+	// the HTTP clients.
+	maxRedirects = 10
+
+	// maxIndexSize is the maximum size of the index file of a chart repository.
+	maxIndexSize = 20 * (1 << 20) // 20MB
+
+	// maxOCIManifestSize is the maximum size of the manifest of a chart in an OCI registry.
+	maxOCIManifestSize = 4 * (1 << 20) // 4MB
+
+	ociManifestMediaType     = "application/vnd.oci.image.manifest.v1+json"
+	ociChartLayerMediaType   = "application/vnd.cncf.helm.chart.content.v1.tar+gzip"
+	repositoryIndexFileName  = "index.yaml"
+	sha256DigestPrefix       = "sha256:"
+	ociRepositoryScheme      = "oci"
+	fileRepositoryScheme     = "file"
+	bearerAuthenticateScheme = "bearer"
+)
+
+// ChartFetcher fetches the chart archives from the chart repositories and the OCI registries.
+type ChartFetcher interface {
+	// Fetch returns the archive of a chart.
+	Fetch(ctx context.Context, chart *placementv1beta1.HelmChart) ([]byte, error)
+}
+
+// FetcherOptions are the options of a chart fetcher.
+type FetcherOptions struct {
+	// HTTPClient is the client for sending the requests; it defaults to a client with a timeout of
+	// defaultRequestTimeout.
+	HTTPClient *http.Client
+
+	// FileRepositoryRoot is the local directory under which the file:// chart repositories must be;
+	// the file:// chart repositories are not allowed if it is empty.
+	FileRepositoryRoot string
+
+	// CacheTTL is how long a fetched chart archive is cached; it defaults to defaultCacheTTL.
+	CacheTTL time.Duration
+
+	// AllowedHosts are the hosts of the chart repositories and the OCI registries, including their authorization
+	// services and the hosts they redirect to, which the charts can be fetched from. An entry is either a host name,
+	// which matches the host on any port, a host name with a port, or a wildcard such as *.example.com, which
+	// matches the subdomains of example.com. No chart can be fetched over HTTP(S) if it is empty.
+	AllowedHosts []string
+}
+
+// chartFetcher fetches the chart archives and caches them in memory, so that the placements with Helm
+// sources do not send requests to the chart repositories every time they are reconciled.
+type chartFetcher struct {
+	opts FetcherOptions
+	now  func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]cachedArchive
+}
+
+// cachedArchive is a chart archive in the cache.
+type cachedArchive struct {
+	archive   []byte
+	fetchedAt time.Time
+}
+
+// NewChartFetcher returns a chart fetcher which fetches the charts anonymously.
+func NewChartFetcher(opts FetcherOptions) ChartFetcher {
+	client := &http.Client{Timeout: defaultRequestTimeout}
+	if opts.HTTPClient != nil {
+		// Copy the client so that the redirects can be checked without changing the given client.
+		c := *opts.HTTPClient
+		client = &c
+	}
+	f := &chartFetcher{opts: opts, now: time.Now, cache: make(map[string]cachedArchive)}
+	checkRedirect := client.CheckRedirect
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if !f.isHostAllowed(req.URL) {
+			return fmt.Errorf("redirect to host %s is not allowed", req.URL.Host)
+		}
+		if checkRedirect != nil {
+			return checkRedirect(req, via)
+		}
+		// Follow the redirects the same way as the default policy.
+		if len(via) >= maxRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
+		return nil
+	}
+	f.opts.HTTPClient = client
+	if f.opts.CacheTTL <= 0 {
+		f.opts.CacheTTL = defaultCacheTTL
+	}
+	return f
+}
+
+// isHostAllowed returns whether the host of a URL is in the allowed hosts.
+func (f *chartFetcher) isHostAllowed(u *url.URL) bool {
+	host := strings.ToLower(u.Host)
+	hostname := strings.ToLower(u.Hostname())
+	for _, allowed := range f.opts.AllowedHosts {
+		allowed = strings.ToLower(strings.TrimSpace(allowed))
+		switch {
+		case allowed == "":
+			continue
+		case strings.HasPrefix(allowed, "*."):
+			if strings.HasSuffix(hostname, allowed[1:]) {
+				return true
+			}
+		case allowed == host || allowed == hostname:
+			return true
+		}
+	}
+	return false
+}
+
+// Fetch implements ChartFetcher.
+func (f *chartFetcher) Fetch(ctx context.Context, chart *placementv1beta1.HelmChart) ([]byte, error) {
+	key := fmt.Sprintf("%s|%s|%s|%t", chart.Repository, chart.Name, chart.Version, chart.PlainHTTP)
+	f.mu.Lock()
+	cached, found := f.cache[key]
+	f.mu.Unlock()
+	if found && f.now().Sub(cached.fetchedAt) < f.opts.CacheTTL {
+		return cached.archive, nil
+	}
+
+	repoURL, err := url.Parse(chart.Repository)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the chart repository URL %q: %w", chart.Repository, err)
+	}
+	var archive []byte
+	if repoURL.Scheme == ociRepositoryScheme {
+		archive, err = f.fetchFromOCIRegistry(ctx, repoURL, chart)
+	} else {
+		archive, err = f.fetchFromChartRepository(ctx, repoURL, chart)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.cache) >= maxCachedCharts {
+		f.evictLocked()
+	}
+	f.cache[key] = cachedArchive{archive: archive, fetchedAt: f.now()}
+	return archive, nil
+}
+
+// evictLocked removes the expired chart archives from the cache, or the oldest one if none has expired.
+func (f *chartFetcher) evictLocked() {
+	var oldestKey string
+	var oldest time.Time
+	for key, cached := range f.cache {
+		if f.now().Sub(cached.fetchedAt) >= f.opts.CacheTTL {
+			delete(f.cache, key)
+			continue
+		}
+		if oldestKey == "" || cached.fetchedAt.Before(oldest) {
+			oldestKey, oldest = key, cached.fetchedAt
+		}
+	}
+	if len(f.cache) >= maxCachedCharts {
+		delete(f.cache, oldestKey)
+	}
+}
+
+// repositoryIndex is the index file of a chart repository.
+type repositoryIndex struct {
+	Entries map[string][]repositoryIndexEntry `json:"entries"`
+}
+
+// repositoryIndexEntry is one version of a chart in the index file of a chart repository.
+type repositoryIndexEntry struct {
+	Version string   `json:"version"`
+	URLs    []string `json:"urls"`
+	Digest  string   `json:"digest,omitempty"`
+}
+
+// fetchFromChartRepository fetches a chart from a chart repository, i.e., an HTTP server or a local
+// directory with an index file.
+func (f *chartFetcher) fetchFromChartRepository(ctx context.Context, repoURL *url.URL, chart *placementv1beta1.HelmChart) ([]byte, error) {
+	// Make sure that the index file and the relative chart URLs are resolved under the repository.
+	if !strings.HasSuffix(repoURL.Path, "/") {
+		repoURL = repoURL.JoinPath("/")
+	}
+	indexURL := repoURL.JoinPath(repositoryIndexFileName)
+	data, err := f.get(ctx, indexURL, maxIndexSize, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the index of chart repository %s: %w", chart.Repository, err)
+	}
+	var index repositoryIndex
+	if err := yaml.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse the index of chart repository %s: %w", chart.Repository, err)
+	}
+	var entry *repositoryIndexEntry
+	for i := range index.Entries[chart.Name] {
+		if index.Entries[chart.Name][i].Version == chart.Version {
+			entry = &index.Entries[chart.Name][i]
+			break
+		}
+	}
+	if entry == nil || len(entry.URLs) == 0 {
+		return nil, fmt.Errorf("chart %s version %s is not found in chart repository %s", chart.Name, chart.Version, chart.Repository)
+	}
+	chartURL, err := repoURL.Parse(entry.URLs[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the URL %q of chart %s version %s: %w", entry.URLs[0], chart.Name, chart.Version, err)
+	}
+	archive, err := f.get(ctx, chartURL, MaxChartArchiveSize, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chart %s version %s from chart repository %s: %w", chart.Name, chart.Version, chart.Repository, err)
+	}
+	if entry.Digest != "" {
+		if err := verifyDigest(sha256DigestPrefix+strings.TrimPrefix(entry.Digest, sha256DigestPrefix), archive); err != nil {
+			return nil, fmt.Errorf("chart %s version %s from chart repository %s: %w", chart.Name, chart.Version, chart.Repository, err)
+		}
+	}
+	return archive, nil
+}
+
+// ociManifest is the manifest of a chart in an OCI registry.
+type ociManifest struct {
+	Layers []ociDescriptor `json:"layers"`
+}
+
+// ociDescriptor describes a blob in an OCI registry.
+type ociDescriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+}
+
+// fetchFromOCIRegistry fetches a chart from an OCI registry with the OCI distribution API, the same way as
+// `helm pull oci://<registry>/<path>/<name> --version <version>` does.
+func (f *chartFetcher) fetchFromOCIRegistry(ctx context.Context, repoURL *url.URL, chart *placementv1beta1.HelmChart) ([]byte, error) {
+	scheme := "https"
+	if chart.PlainHTTP {
+		scheme = "http"
+	}
+	repository := strings.Trim(repoURL.Path, "/") + "/" + chart.Name
+	repository = strings.TrimPrefix(repository, "/")
+	// OCI tags cannot have the plus sign; Helm replaces it with an underscore.
+	tag := strings.ReplaceAll(chart.Version, "+", "_")
+	registryURL := &url.URL{Scheme: scheme, Host: repoURL.Host}
+
+	manifestURL := registryURL.JoinPath("v2", repository, "manifests", tag)
+	token := ""
+	data, err := f.get(ctx, manifestURL, maxOCIManifestSize, token, ociManifestMediaType)
+	var challengeErr *authChallengeError
+	if errors.As(err, &challengeErr) {
+		if token, err = f.getAnonymousToken(ctx, challengeErr.challenge); err != nil {
+			return nil, fmt.Errorf("failed to authenticate with OCI registry %s: %w", repoURL.Host, err)
+		}
+		data, err = f.get(ctx, manifestURL, maxOCIManifestSize, token, ociManifestMediaType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the manifest of chart %s version %s from OCI registry %s: %w", chart.Name, chart.Version, chart.Repository, err)
+	}
+	var manifest ociManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse the manifest of chart %s version %s from OCI registry %s: %w", chart.Name, chart.Version, chart.Repository, err)
+	}
+	var layer *ociDescriptor
+	for i := range manifest.Layers {
+		if manifest.Layers[i].MediaType == ociChartLayerMediaType {
+			layer = &manifest.Layers[i]
+			break
+		}
+	}
+	switch {
+	case layer == nil:
+		return nil, fmt.Errorf("%s %s in OCI registry %s is not a Helm chart", chart.Name, chart.Version, chart.Repository)
+	case layer.Size > MaxChartArchiveSize:
+		return nil, fmt.Errorf("the archive of chart %s version %s has %d bytes, which exceeds the limit of %d bytes", chart.Name, chart.Version, layer.Size, MaxChartArchiveSize)
+	case !strings.HasPrefix(layer.Digest, sha256DigestPrefix):
+		return nil, fmt.Errorf("the archive of chart %s version %s has digest %q, which is not a SHA-256 digest", chart.Name, chart.Version, layer.Digest)
+	}
+
+	archive, err := f.get(ctx, registryURL.JoinPath("v2", repository, "blobs", layer.Digest), MaxChartArchiveSize, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the archive of chart %s version %s from OCI registry %s: %w", chart.Name, chart.Version, chart.Repository, err)
+	}
+	if err := verifyDigest(layer.Digest, archive); err != nil {
+		return nil, fmt.Errorf("chart %s version %s from OCI registry %s: %w", chart.Name, chart.Version, chart.Repository, err)
+	}
+	return archive, nil
+}
+
+// authChallengeError is returned when a registry requires a bearer token.
+type authChallengeError struct {
+	challenge string
+}
+
+// Error implements error.
+func (e *authChallengeError) Error() string {
+	return fmt.Sprintf("authentication is required: %s", e.challenge)
+}
+
+// getAnonymousToken gets an anonymous token from the authorization service in a bearer challenge of a
+// registry, e.g., `Bearer realm="https://auth.example.com/token",service="registry",scope="repository:charts/app:pull"`.
+func (f *chartFetcher) getAnonymousToken(ctx context.Context, challenge string) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, bearerAuthenticateScheme) {
+		return "", fmt.Errorf("authentication scheme %q is not supported", scheme)
+	}
+	attrs := map[string]string{}
+	for _, param := range strings.Split(params, ",") {
+		if k, v, found := strings.Cut(strings.TrimSpace(param), "="); found {
+			attrs[strings.ToLower(k)] = strings.Trim(v, `"`)
+		}
+	}
+	realm, err := url.Parse(attrs["realm"])
+	if err != nil || (realm.Scheme != "https" && realm.Scheme != "http") {
+		return "", fmt.Errorf("the realm %q of the bearer challenge is not an HTTP or HTTPS URL", attrs["realm"])
+	}
+	query := realm.Query()
+	for _, k := range []string{"service", "scope"} {
+		if v, found := attrs[k]; found {
+			query.Set(k, v)
+		}
+	}
+	realm.RawQuery = query.Encode()
+	data, err := f.get(ctx, realm, maxOCIManifestSize, "")
+	if err != nil {
+		return "", err
+	}
+	var resp struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return "", fmt.Errorf("failed to parse the token response: %w", err)
+	}
+	if resp.Token != "" {
+		return resp.Token, nil
+	}
+	if resp.AccessToken != "" {
+		return resp.AccessToken, nil
+	}
+	return "", fmt.Errorf("the token response has no token")
+}
+
+// get reads the content at a URL, which is either an HTTP(S) URL or a file:// URL under the file
+// repository root, up to the given limit.
+func (f *chartFetcher) get(ctx context.Context, u *url.URL, limit int64, token string, accept ...string) ([]byte, error) {
+	switch u.Scheme {
+	case fileRepositoryScheme:
+		return f.readFile(u, limit)
+	case "http", "https":
+	default:
+		return nil, fmt.Errorf("URL %s must be an OCI, HTTP, HTTPS, or file URL", u.Redacted())
+	}
+	if !f.isHostAllowed(u) {
+		return nil, fmt.Errorf("host %s of URL %s is not in the allowed hosts of the chart repositories", u.Host, u.Redacted())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range accept {
+		req.Header.Add("Accept", a)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := f.opts.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized && token == "" && resp.Header.Get("WWW-Authenticate") != "":
+		return nil, &authChallengeError{challenge: resp.Header.Get("WWW-Authenticate")}
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("GET %s returned status %s", u.Redacted(), resp.Status)
+	}
+	return readAllWithLimit(resp.Body, limit)
+}
+
+// readFile reads a file in a file:// chart repository.
+func (f *chartFetcher) readFile(u *url.URL, limit int64) ([]byte, error) {
+	if f.opts.FileRepositoryRoot == "" {
+		return nil, fmt.Errorf("file chart repositories are not enabled")
+	}
+	// Resolve the symbolic links so that they cannot point to the files outside of the root.
+	root, err := filepath.EvalSymlinks(f.opts.FileRepositoryRoot)
+	if err != nil {
+		return nil, err
+	}
+	if root, err = filepath.Abs(root); err != nil {
+		return nil, err
+	}
+	name, err := filepath.EvalSymlinks(filepath.Clean(filepath.FromSlash(u.Path)))
+	if err != nil {
+		return nil, err
+	}
+	if rel, err := filepath.Rel(root, name); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil, fmt.Errorf("file %s is not under the file chart repository root %s", u.Path, root)
+	}
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return readAllWithLimit(file, limit)
+}
+
+// readAllWithLimit reads all the content from a reader, and returns an error if the content exceeds the limit.
+func readAllWithLimit(r io.Reader, limit int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("the content exceeds the limit of %d bytes", limit)
+	}
+	return data, nil
+}
+
+// verifyDigest verifies that the content matches a SHA-256 digest in the form of sha256:<hex>.
+func verifyDigest(digest string, content []byte) error {
+	if got := archiveDigest(content); got != strings.ToLower(digest) {
+		return fmt.Errorf("the content has digest %s, want %s", got, digest)
+	}
+	return nil
+}
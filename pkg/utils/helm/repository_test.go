@@ -0,0 +1,399 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helm
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// newChartRepositoryServer returns a server of a chart repository at /charts with one chart app-1.0.0.
+func newChartRepositoryServer(t *testing.T, archive []byte, digest string, requests *atomic.Int32) *httptest.Server {
+	t.Helper()
+	index := fmt.Sprintf(`apiVersion: v1
+entries:
+  app:
+  - name: app
+    version: 1.0.0
+    urls:
+    - app-1.0.0.tgz
+    digest: %s
+  - name: app
+    version: 0.9.0
+    urls:
+    - https://elsewhere.example.com/app-0.9.0.tgz
+`, digest)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch r.URL.Path {
+		case "/charts/index.yaml":
+			_, _ = w.Write([]byte(index))
+		case "/charts/app-1.0.0.tgz":
+			_, _ = w.Write(archive)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestFetch_ChartRepository(t *testing.T) {
+	archive := buildArchive(t, testChartFiles)
+	tests := []struct {
+		name    string
+		digest  string
+		chart   string
+		version string
+		wantErr bool
+	}{
+		{
+			name:    "chart with the matching digest",
+			digest:  sha256Hex(archive),
+			chart:   "app",
+			version: "1.0.0",
+		},
+		{
+			name:    "chart with a mismatched digest",
+			digest:  sha256Hex([]byte("other")),
+			chart:   "app",
+			version: "1.0.0",
+			wantErr: true,
+		},
+		{
+			name:    "version not found",
+			digest:  sha256Hex(archive),
+			chart:   "app",
+			version: "2.0.0",
+			wantErr: true,
+		},
+		{
+			name:    "chart not found",
+			digest:  sha256Hex(archive),
+			chart:   "other",
+			version: "1.0.0",
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := newChartRepositoryServer(t, archive, tc.digest, &requests)
+			defer server.Close()
+
+			fetcher := NewChartFetcher(FetcherOptions{HTTPClient: server.Client(), AllowedHosts: []string{"127.0.0.1"}})
+			got, err := fetcher.Fetch(context.Background(), &placementv1beta1.HelmChart{
+				Repository: server.URL + "/charts",
+				Name:       tc.chart,
+				Version:    tc.version,
+			})
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Fetch() = %v, want error %t", err, tc.wantErr)
+			}
+			if !tc.wantErr && !bytes.Equal(got, archive) {
+				t.Errorf("Fetch() returned a different archive")
+			}
+		})
+	}
+}
+
+func TestFetch_Cache(t *testing.T) {
+	archive := buildArchive(t, testChartFiles)
+	var requests atomic.Int32
+	server := newChartRepositoryServer(t, archive, sha256Hex(archive), &requests)
+	defer server.Close()
+
+	now := time.Now()
+	fetcher := NewChartFetcher(FetcherOptions{HTTPClient: server.Client(), CacheTTL: time.Minute, AllowedHosts: []string{"127.0.0.1"}}).(*chartFetcher)
+	fetcher.now = func() time.Time { return now }
+	chart := &placementv1beta1.HelmChart{Repository: server.URL + "/charts", Name: "app", Version: "1.0.0"}
+
+	for i := 0; i < 2; i++ {
+		if _, err := fetcher.Fetch(context.Background(), chart); err != nil {
+			t.Fatalf("Fetch() = %v", err)
+		}
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("Fetch() sent %d requests before the cached chart expires, want 2", got)
+	}
+
+	now = now.Add(time.Minute)
+	if _, err := fetcher.Fetch(context.Background(), chart); err != nil {
+		t.Fatalf("Fetch() = %v", err)
+	}
+	if got := requests.Load(); got != 4 {
+		t.Errorf("Fetch() sent %d requests after the cached chart expires, want 4", got)
+	}
+}
+
+func TestFetch_OCIRegistry(t *testing.T) {
+	archive := buildArchive(t, testChartFiles)
+	layerDigest := "sha256:" + sha256Hex(archive)
+	const token = "anonymous-token"
+	var authServerURL string
+	registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			if r.URL.Query().Get("scope") != "repository:charts/app:pull" || r.URL.Query().Get("service") != "registry" {
+				http.Error(w, "invalid scope", http.StatusBadRequest)
+				return
+			}
+			_, _ = fmt.Fprintf(w, `{"token": %q}`, token)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:charts/app:pull"`, authServerURL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/v2/charts/app/manifests/1.0.0_build.1":
+			if !strings.Contains(r.Header.Get("Accept"), ociManifestMediaType) {
+				http.Error(w, "unsupported media type", http.StatusNotAcceptable)
+				return
+			}
+			_, _ = fmt.Fprintf(w, `{"schemaVersion": 2, "layers": [{"mediaType": %q, "digest": %q, "size": %d}]}`, ociChartLayerMediaType, layerDigest, len(archive))
+		case "/v2/charts/app/blobs/" + layerDigest:
+			_, _ = w.Write(archive)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer registry.Close()
+	authServerURL = registry.URL
+
+	fetcher := NewChartFetcher(FetcherOptions{HTTPClient: registry.Client(), AllowedHosts: []string{"127.0.0.1"}})
+	got, err := fetcher.Fetch(context.Background(), &placementv1beta1.HelmChart{
+		Repository: "oci://" + strings.TrimPrefix(registry.URL, "http://") + "/charts",
+		Name:       "app",
+		Version:    "1.0.0+build.1",
+		PlainHTTP:  true,
+	})
+	if err != nil {
+		t.Fatalf("Fetch() = %v", err)
+	}
+	if !bytes.Equal(got, archive) {
+		t.Errorf("Fetch() returned a different archive")
+	}
+
+	if _, err := fetcher.Fetch(context.Background(), &placementv1beta1.HelmChart{
+		Repository: "oci://" + strings.TrimPrefix(registry.URL, "http://") + "/charts",
+		Name:       "app",
+		Version:    "2.0.0",
+		PlainHTTP:  true,
+	}); err == nil {
+		t.Errorf("Fetch() = nil, want error for a chart not found")
+	}
+}
+
+func TestFetch_AllowedHosts(t *testing.T) {
+	archive := buildArchive(t, testChartFiles)
+	var requests atomic.Int32
+	server := newChartRepositoryServer(t, archive, sha256Hex(archive), &requests)
+	defer server.Close()
+	redirector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, server.URL+r.URL.Path, http.StatusFound)
+	}))
+	defer redirector.Close()
+	// The two servers listen on the same host with different ports.
+	serverHost := strings.TrimPrefix(server.URL, "http://")
+	redirectorHost := strings.TrimPrefix(redirector.URL, "http://")
+
+	tests := []struct {
+		name         string
+		repoURL      string
+		allowedHosts []string
+		wantErr      bool
+	}{
+		{
+			name:         "host is allowed on any port",
+			repoURL:      server.URL + "/charts",
+			allowedHosts: []string{"127.0.0.1"},
+		},
+		{
+			name:         "host and port are allowed",
+			repoURL:      server.URL + "/charts",
+			allowedHosts: []string{serverHost},
+		},
+		{
+			name:         "redirect to an allowed host",
+			repoURL:      redirector.URL + "/charts",
+			allowedHosts: []string{redirectorHost, serverHost},
+		},
+		{
+			name:    "no allowed hosts",
+			repoURL: server.URL + "/charts",
+			wantErr: true,
+		},
+		{
+			name:         "host is not allowed",
+			repoURL:      server.URL + "/charts",
+			allowedHosts: []string{"charts.example.com", "*.example.com"},
+			wantErr:      true,
+		},
+		{
+			name:         "redirect to a host which is not allowed",
+			repoURL:      redirector.URL + "/charts",
+			allowedHosts: []string{redirectorHost},
+			wantErr:      true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requests.Store(0)
+			fetcher := NewChartFetcher(FetcherOptions{AllowedHosts: tc.allowedHosts})
+			_, err := fetcher.Fetch(context.Background(), &placementv1beta1.HelmChart{Repository: tc.repoURL, Name: "app", Version: "1.0.0"})
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Fetch() = %v, want error %t", err, tc.wantErr)
+			}
+			if tc.wantErr && requests.Load() != 0 {
+				t.Errorf("Fetch() sent %d requests to a host which is not allowed, want 0", requests.Load())
+			}
+		})
+	}
+}
+
+func TestIsHostAllowed(t *testing.T) {
+	f := NewChartFetcher(FetcherOptions{AllowedHosts: []string{"charts.example.com", "registry.example.com:5000", "*.azurecr.io"}}).(*chartFetcher)
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{url: "https://charts.example.com/stable", want: true},
+		{url: "https://Charts.Example.com:8443/stable", want: true},
+		{url: "https://registry.example.com:5000/v2/", want: true},
+		{url: "https://registry.example.com/v2/", want: false},
+		{url: "https://fleet.azurecr.io/v2/", want: true},
+		{url: "https://azurecr.io/v2/", want: false},
+		{url: "https://evil-azurecr.io/v2/", want: false},
+		{url: "http://169.254.169.254/metadata", want: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.url, func(t *testing.T) {
+			u, err := url.Parse(tc.url)
+			if err != nil {
+				t.Fatalf("Parse() = %v", err)
+			}
+			if got := f.isHostAllowed(u); got != tc.want {
+				t.Errorf("isHostAllowed(%s) = %t, want %t", tc.url, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFetch_FileRepository(t *testing.T) {
+	archive := buildArchive(t, testChartFiles)
+	root := t.TempDir()
+	repoDir := filepath.Join(root, "charts")
+	if err := os.Mkdir(repoDir, 0o755); err != nil {
+		t.Fatalf("Mkdir() = %v", err)
+	}
+	index := "apiVersion: v1\nentries:\n  app:\n  - name: app\n    version: 1.0.0\n    urls:\n    - app-1.0.0.tgz\n  - name: app\n    version: 0.9.0\n    urls:\n    - ../../outside/app-0.9.0.tgz\n"
+	if err := os.WriteFile(filepath.Join(repoDir, "index.yaml"), []byte(index), 0o600); err != nil {
+		t.Fatalf("WriteFile() = %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repoDir, "app-1.0.0.tgz"), archive, 0o600); err != nil {
+		t.Fatalf("WriteFile() = %v", err)
+	}
+	outsideDir := filepath.Join(filepath.Dir(root), filepath.Base(root)+"-outside")
+	if err := os.Mkdir(outsideDir, 0o755); err != nil {
+		t.Fatalf("Mkdir() = %v", err)
+	}
+	defer os.RemoveAll(outsideDir)
+	if err := os.WriteFile(filepath.Join(outsideDir, "app-0.9.0.tgz"), archive, 0o600); err != nil {
+		t.Fatalf("WriteFile() = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		root     string
+		repoURL  string
+		version  string
+		wantErr  bool
+		wantData []byte
+	}{
+		{
+			name:     "chart under the root",
+			root:     root,
+			repoURL:  "file://" + repoDir,
+			version:  "1.0.0",
+			wantData: archive,
+		},
+		{
+			name:    "chart outside of the root",
+			root:    root,
+			repoURL: "file://" + repoDir,
+			version: "0.9.0",
+			wantErr: true,
+		},
+		{
+			name:    "repository outside of the root",
+			root:    repoDir,
+			repoURL: "file://" + root,
+			version: "1.0.0",
+			wantErr: true,
+		},
+		{
+			name:    "file repositories are not enabled",
+			repoURL: "file://" + repoDir,
+			version: "1.0.0",
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fetcher := NewChartFetcher(FetcherOptions{FileRepositoryRoot: tc.root})
+			got, err := fetcher.Fetch(context.Background(), &placementv1beta1.HelmChart{
+				Repository: tc.repoURL,
+				Name:       "app",
+				Version:    tc.version,
+			})
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Fetch() = %v, want error %t", err, tc.wantErr)
+			}
+			if !tc.wantErr && !bytes.Equal(got, tc.wantData) {
+				t.Errorf("Fetch() returned a different archive")
+			}
+		})
+	}
+}
+
+func TestReadAllWithLimit(t *testing.T) {
+	if _, err := readAllWithLimit(strings.NewReader("12345"), 4); err == nil {
+		t.Errorf("readAllWithLimit() = nil, want error for content over the limit")
+	}
+	got, err := readAllWithLimit(strings.NewReader("1234"), 4)
+	if err != nil || string(got) != "1234" {
+		t.Errorf("readAllWithLimit() = %q, %v, want %q, nil", got, err, "1234")
+	}
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package kustomize features utilities for rendering the resources from the Kustomize directories
+// stored in ConfigMaps.
+package kustomize
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/kustomize/api/konfig"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// rootDir is the directory in the in-memory file system where the Kustomize directory is built.
+	rootDir = "/kustomization"
+)
+
+// Render builds a flat Kustomize directory and returns the rendered resources, in the order
+// in which Kustomize emits them.
+//
+// The files in the directory are given as a map from the file names to the file contents, in the same
+// format as the data of the ConfigMap which stores the directory. All the files referenced by the
+// kustomization must be in the directory; remote resources, Helm charts and Kustomize plugins are
+// not supported.
+func Render(files map[string]string) ([]*unstructured.Unstructured, error) {
+	kustomizationFile, err := findKustomizationFile(files)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateKustomization(files, kustomizationFile); err != nil {
+		return nil, err
+	}
+
+	fSys := filesys.MakeFsInMemory()
+	if err := fSys.MkdirAll(rootDir); err != nil {
+		return nil, fmt.Errorf("failed to prepare the Kustomize directory: %w", err)
+	}
+	for name, content := range files {
+		if err := fSys.WriteFile(path.Join(rootDir, name), []byte(content)); err != nil {
+			return nil, fmt.Errorf("failed to write file %q to the Kustomize directory: %w", name, err)
+		}
+	}
+
+	// The default options of Kustomize disable all the plugins (including Helm) and restrict
+	// the loading of files to the Kustomize directory.
+	resMap, err := krusty.MakeKustomizer(krusty.MakeDefaultOptions()).Run(fSys, rootDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build the Kustomize directory: %w", err)
+	}
+	resources := resMap.Resources()
+	objs := make([]*unstructured.Unstructured, 0, len(resources))
+	for _, res := range resources {
+		// Round-trip the resource through JSON so that the numbers are decoded the same way
+		// as in the objects read from the API server.
+		raw, err := res.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal the rendered resource %s: %w", res.CurId(), err)
+		}
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(raw); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal the rendered resource %s: %w", res.CurId(), err)
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+// findKustomizationFile returns the name of the kustomization file in the directory.
+func findKustomizationFile(files map[string]string) (string, error) {
+	var found []string
+	for _, name := range konfig.RecognizedKustomizationFileNames() {
+		if _, ok := files[name]; ok {
+			found = append(found, name)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return "", fmt.Errorf("no kustomization file is found, must have one of %v", konfig.RecognizedKustomizationFileNames())
+	case 1:
+		return found[0], nil
+	default:
+		return "", fmt.Errorf("found multiple kustomization files %v, must have only one", found)
+	}
+}
+
+// validateKustomization validates that the kustomization refers to the files in the directory only,
+// as Kustomize would otherwise fetch the files it cannot find from remote locations.
+func validateKustomization(files map[string]string, kustomizationFile string) error {
+	var k types.Kustomization
+	if err := yaml.Unmarshal([]byte(files[kustomizationFile]), &k); err != nil {
+		return fmt.Errorf("failed to parse the kustomization file %q: %w", kustomizationFile, err)
+	}
+	k.FixKustomization()
+
+	if len(k.HelmCharts) != 0 || len(k.HelmChartInflationGenerator) != 0 || k.HelmGlobals != nil {
+		return fmt.Errorf("helm charts are not supported in a kustomization, use a Helm resource source instead")
+	}
+
+	var refs []string
+	refs = append(refs, k.Resources...)
+	refs = append(refs, k.Components...)
+	refs = append(refs, k.Crds...)
+	refs = append(refs, k.Configurations...)
+	for _, p := range k.Patches {
+		refs = appendIfSet(refs, p.Path)
+	}
+	for _, p := range k.PatchesJson6902 {
+		refs = appendIfSet(refs, p.Path)
+	}
+	for _, r := range k.Replacements {
+		refs = appendIfSet(refs, r.Path)
+	}
+	for _, g := range k.ConfigMapGenerator {
+		refs = appendKvPairSources(refs, g.KvPairSources)
+	}
+	for _, g := range k.SecretGenerator {
+		refs = appendKvPairSources(refs, g.KvPairSources)
+	}
+	// The following fields accept either a file or an inline object.
+	for _, p := range k.PatchesStrategicMerge {
+		refs = appendIfNotInline(refs, string(p))
+	}
+	for _, lists := range [][]string{k.Generators, k.Transformers, k.Validators} {
+		for _, ref := range lists {
+			refs = appendIfNotInline(refs, ref)
+		}
+	}
+
+	for _, ref := range refs {
+		if _, ok := files[path.Clean(ref)]; !ok {
+			return fmt.Errorf("%q referenced by the kustomization is not a file in the Kustomize directory; only the files in the directory can be referenced", ref)
+		}
+	}
+	return nil
+}
+
+func appendIfSet(refs []string, ref string) []string {
+	if ref == "" {
+		return refs
+	}
+	return append(refs, ref)
+}
+
+func appendIfNotInline(refs []string, ref string) []string {
+	if strings.Contains(ref, "\n") {
+		return refs
+	}
+	return append(refs, ref)
+}
+
+func appendKvPairSources(refs []string, sources types.KvPairSources) []string {
+	for _, src := range sources.FileSources {
+		// A file source might be in the form of key=path.
+		if _, filePath, found := strings.Cut(src, "="); found {
+			src = filePath
+		}
+		refs = append(refs, src)
+	}
+	return append(refs, sources.EnvSources...)
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kustomize
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	configMapYAML = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app-config
+data:
+  key: value
+`
+	deploymentYAML = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  replicas: 1
+`
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		wantObjs []*unstructured.Unstructured
+		wantErr  bool
+	}{
+		{
+			name: "resources with namespace and patches",
+			files: map[string]string{
+				"kustomization.yaml": `namespace: app
+resources:
+- ./configmap.yaml
+- deployment.yaml
+patches:
+- path: replicas.yaml
+`,
+				"configmap.yaml":  configMapYAML,
+				"deployment.yaml": deploymentYAML,
+				"replicas.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  replicas: 3
+`,
+			},
+			wantObjs: []*unstructured.Unstructured{
+				{
+					Object: map[string]interface{}{
+						"apiVersion": "v1",
+						"kind":       "ConfigMap",
+						"metadata": map[string]interface{}{
+							"name":      "app-config",
+							"namespace": "app",
+						},
+						"data": map[string]interface{}{
+							"key": "value",
+						},
+					},
+				},
+				{
+					Object: map[string]interface{}{
+						"apiVersion": "apps/v1",
+						"kind":       "Deployment",
+						"metadata": map[string]interface{}{
+							"name":      "app",
+							"namespace": "app",
+						},
+						"spec": map[string]interface{}{
+							"replicas": int64(3),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "kustomization file with an alternative name",
+			files: map[string]string{
+				"Kustomization": `resources:
+- configmap.yaml
+namePrefix: dev-
+`,
+				"configmap.yaml": configMapYAML,
+			},
+			wantObjs: []*unstructured.Unstructured{
+				{
+					Object: map[string]interface{}{
+						"apiVersion": "v1",
+						"kind":       "ConfigMap",
+						"metadata": map[string]interface{}{
+							"name": "dev-app-config",
+						},
+						"data": map[string]interface{}{
+							"key": "value",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "no kustomization file",
+			files: map[string]string{
+				"configmap.yaml": configMapYAML,
+			},
+			wantErr: true,
+		},
+		{
+			name: "multiple kustomization files",
+			files: map[string]string{
+				"kustomization.yaml": "resources:\n- configmap.yaml\n",
+				"kustomization.yml":  "resources:\n- configmap.yaml\n",
+				"configmap.yaml":     configMapYAML,
+			},
+			wantErr: true,
+		},
+		{
+			name: "remote resource",
+			files: map[string]string{
+				"kustomization.yaml": "resources:\n- https://github.com/kubernetes-sigs/kustomize//examples/helloWorld?ref=v5.0.0\n",
+			},
+			wantErr: true,
+		},
+		{
+			name: "remote patch",
+			files: map[string]string{
+				"kustomization.yaml": "resources:\n- configmap.yaml\npatches:\n- path: https://example.com/patch.yaml\n",
+				"configmap.yaml":     configMapYAML,
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing generator file",
+			files: map[string]string{
+				"kustomization.yaml": "configMapGenerator:\n- name: gen\n  files:\n  - config=app.properties\n",
+			},
+			wantErr: true,
+		},
+		{
+			name: "helm chart",
+			files: map[string]string{
+				"kustomization.yaml": "helmCharts:\n- name: nginx\n  repo: https://charts.example.com\n",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid resource",
+			files: map[string]string{
+				"kustomization.yaml": "resources:\n- configmap.yaml\n",
+				"configmap.yaml":     "not: [a valid resource",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Render(tc.files)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Render() got error %v, want error %t", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tc.wantObjs, got); diff != "" {
+				t.Errorf("Render() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package maintenancewindow features utilities to evaluate the cron based maintenance windows
+// which hold rollouts outside the allowed time.
+package maintenancewindow
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// scheduleParser parses the standard 5-field cron expressions as well as the descriptors such as @daily.
+var scheduleParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// Window is a maintenance window regardless of the API group it is declared in.
+type Window struct {
+	// Schedule is the cron expression which specifies when the window opens.
+	Schedule string
+	// Duration is how long the window stays open.
+	Duration time.Duration
+	// TimeZone is the IANA time zone name the schedule is evaluated in; empty means UTC.
+	TimeZone string
+}
+
+// FromPlacementWindows converts the maintenance windows declared in the placement API group.
+func FromPlacementWindows(windows []placementv1beta1.MaintenanceWindow) []Window {
+	res := make([]Window, 0, len(windows))
+	for _, w := range windows {
+		res = append(res, Window{Schedule: w.Schedule, Duration: w.Duration.Duration, TimeZone: derefString(w.TimeZone)})
+	}
+	return res
+}
+
+// FromClusterWindows converts the maintenance windows declared in the cluster API group.
+func FromClusterWindows(windows []clusterv1beta1.MaintenanceWindow) []Window {
+	res := make([]Window, 0, len(windows))
+	for _, w := range windows {
+		res = append(res, Window{Schedule: w.Schedule, Duration: w.Duration.Duration, TimeZone: derefString(w.TimeZone)})
+	}
+	return res
+}
+
+// Validate validates the schedule, the duration and the time zone of a maintenance window.
+func Validate(w Window) error {
+	if w.Duration <= 0 {
+		return fmt.Errorf("duration must be greater than 0, got %s", w.Duration)
+	}
+	if _, _, err := parse(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+// IsOpen returns true if any of the given windows is open at the given time; an empty list of windows is
+// always open. When all the windows are closed, it also returns how long to wait until the earliest window opens,
+// which is 0 if none of the windows will open again.
+func IsOpen(windows []Window, now time.Time) (bool, time.Duration, error) {
+	if len(windows) == 0 {
+		return true, 0, nil
+	}
+	var waitTime time.Duration
+	for _, w := range windows {
+		schedule, loc, err := parse(w)
+		if err != nil {
+			return false, 0, err
+		}
+		localNow := now.In(loc)
+		// The window is open if it was last opened within the duration, i.e., the first activation
+		// after (now - duration) is not after now.
+		lastOpen := schedule.Next(localNow.Add(-w.Duration))
+		if !lastOpen.IsZero() && !lastOpen.After(localNow) {
+			return true, 0, nil
+		}
+		nextOpen := schedule.Next(localNow)
+		if nextOpen.IsZero() {
+			// The schedule never activates again.
+			continue
+		}
+		if wait := nextOpen.Sub(localNow); waitTime == 0 || wait < waitTime {
+			waitTime = wait
+		}
+	}
+	return false, waitTime, nil
+}
+
+// AllOpen returns true if each of the given groups of windows is open at the given time.
+// When any group is closed, it also returns how long to wait before all the closed groups may have opened,
+// which is only a lower bound as the groups are evaluated independently.
+func AllOpen(now time.Time, groups ...[]Window) (bool, time.Duration, error) {
+	allOpen := true
+	var waitTime time.Duration
+	for _, windows := range groups {
+		open, wait, err := IsOpen(windows, now)
+		if err != nil {
+			return false, 0, err
+		}
+		if !open {
+			allOpen = false
+			waitTime = max(waitTime, wait)
+		}
+	}
+	return allOpen, waitTime, nil
+}
+
+// parse parses the schedule and loads the time zone of a maintenance window.
+func parse(w Window) (cron.Schedule, *time.Location, error) {
+	// The time zone must be set with the dedicated field instead of the cron prefix.
+	if strings.HasPrefix(w.Schedule, "TZ=") || strings.HasPrefix(w.Schedule, "CRON_TZ=") {
+		return nil, nil, fmt.Errorf("schedule %q must not specify a time zone, use the timeZone field instead", w.Schedule)
+	}
+	schedule, err := scheduleParser.Parse(w.Schedule)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid schedule %q: %w", w.Schedule, err)
+	}
+	loc := time.UTC
+	if w.TimeZone != "" {
+		if loc, err = time.LoadLocation(w.TimeZone); err != nil {
+			return nil, nil, fmt.Errorf("invalid time zone %q: %w", w.TimeZone, err)
+		}
+	}
+	return schedule, loc, nil
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
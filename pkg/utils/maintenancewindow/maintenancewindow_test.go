@@ -0,0 +1,186 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenancewindow
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		window  Window
+		wantErr bool
+	}{
+		{
+			name:   "valid window",
+			window: Window{Schedule: "0 22 * * 1-5", Duration: 2 * time.Hour, TimeZone: "Europe/Berlin"},
+		},
+		{
+			name:   "valid descriptor",
+			window: Window{Schedule: "@daily", Duration: time.Hour},
+		},
+		{
+			name:    "invalid schedule",
+			window:  Window{Schedule: "0 22 * *", Duration: time.Hour},
+			wantErr: true,
+		},
+		{
+			name:    "schedule with time zone prefix",
+			window:  Window{Schedule: "CRON_TZ=Europe/Berlin 0 22 * * *", Duration: time.Hour},
+			wantErr: true,
+		},
+		{
+			name:    "zero duration",
+			window:  Window{Schedule: "0 22 * * *"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid time zone",
+			window:  Window{Schedule: "0 22 * * *", Duration: time.Hour, TimeZone: "Mars/Olympus"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Validate(tt.window); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIsOpen(t *testing.T) {
+	// Wednesday.
+	now := time.Date(2025, time.March, 5, 22, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		windows      []Window
+		wantOpen     bool
+		wantWaitTime time.Duration
+		wantErr      bool
+	}{
+		{
+			name:     "no windows",
+			wantOpen: true,
+		},
+		{
+			name:     "window is open",
+			windows:  []Window{{Schedule: "0 22 * * *", Duration: time.Hour}},
+			wantOpen: true,
+		},
+		{
+			name:         "window has closed",
+			windows:      []Window{{Schedule: "0 22 * * *", Duration: 30 * time.Minute}},
+			wantOpen:     false,
+			wantWaitTime: 23*time.Hour + 30*time.Minute,
+		},
+		{
+			name:         "window is not open on weekdays",
+			windows:      []Window{{Schedule: "0 22 * * 6", Duration: 2 * time.Hour}},
+			wantOpen:     false,
+			wantWaitTime: 71*time.Hour + 30*time.Minute,
+		},
+		{
+			name:     "window spanning midnight opened the day before",
+			windows:  []Window{{Schedule: "0 23 * * *", Duration: 24 * time.Hour}},
+			wantOpen: true,
+		},
+		{
+			name: "one of the windows is open",
+			windows: []Window{
+				{Schedule: "0 8 * * *", Duration: time.Hour},
+				{Schedule: "0 22 * * *", Duration: time.Hour},
+			},
+			wantOpen: true,
+		},
+		{
+			name: "wait for the earliest window",
+			windows: []Window{
+				{Schedule: "0 8 * * *", Duration: time.Hour},
+				{Schedule: "0 23 * * *", Duration: time.Hour},
+			},
+			wantOpen:     false,
+			wantWaitTime: 30 * time.Minute,
+		},
+		{
+			name:     "window is open in the time zone",
+			windows:  []Window{{Schedule: "0 23 * * *", Duration: time.Hour, TimeZone: "Europe/Berlin"}},
+			wantOpen: true,
+		},
+		{
+			name:    "invalid window",
+			windows: []Window{{Schedule: "invalid", Duration: time.Hour}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotOpen, gotWaitTime, err := IsOpen(tt.windows, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("IsOpen() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if gotOpen != tt.wantOpen {
+				t.Errorf("IsOpen() open = %v, want %v", gotOpen, tt.wantOpen)
+			}
+			if gotWaitTime != tt.wantWaitTime {
+				t.Errorf("IsOpen() wait time = %v, want %v", gotWaitTime, tt.wantWaitTime)
+			}
+		})
+	}
+}
+
+func TestAllOpen(t *testing.T) {
+	now := time.Date(2025, time.March, 5, 22, 30, 0, 0, time.UTC)
+	openWindows := []Window{{Schedule: "0 22 * * *", Duration: time.Hour}}
+	closedSoon := []Window{{Schedule: "0 23 * * *", Duration: time.Hour}}
+	closedLater := []Window{{Schedule: "0 8 * * *", Duration: time.Hour}}
+	tests := []struct {
+		name         string
+		groups       [][]Window
+		wantOpen     bool
+		wantWaitTime time.Duration
+	}{
+		{
+			name:     "no groups",
+			wantOpen: true,
+		},
+		{
+			name:     "all groups are open",
+			groups:   [][]Window{openWindows, nil},
+			wantOpen: true,
+		},
+		{
+			name:         "wait for the latest closed group",
+			groups:       [][]Window{openWindows, closedSoon, closedLater},
+			wantOpen:     false,
+			wantWaitTime: 9*time.Hour + 30*time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotOpen, gotWaitTime, err := AllOpen(now, tt.groups...)
+			if err != nil {
+				t.Fatalf("AllOpen() error = %v", err)
+			}
+			if gotOpen != tt.wantOpen || gotWaitTime != tt.wantWaitTime {
+				t.Errorf("AllOpen() = (%v, %v), want (%v, %v)", gotOpen, gotWaitTime, tt.wantOpen, tt.wantWaitTime)
+			}
+		})
+	}
+}
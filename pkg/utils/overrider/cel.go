@@ -0,0 +1,252 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overrider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/ext"
+	"github.com/google/cel-go/interpreter"
+	"github.com/qri-io/jsonpointer"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+const (
+	celItemVarName    = "item"
+	celObjectVarName  = "object"
+	celClusterVarName = "cluster"
+
+	// celCostLimit is the max. runtime cost of a single evaluation of a CEL expression, which
+	// matches the per-call limit of the CEL admission control in Kubernetes.
+	celCostLimit uint64 = 1000000
+	// celInterruptCheckFrequency is the number of comprehension iterations after which the
+	// evaluation of a CEL expression checks whether it has been interrupted.
+	celInterruptCheckFrequency uint = 100
+	// celEvaluationTimeout is the max. duration of a single evaluation of a CEL expression.
+	celEvaluationTimeout = time.Second
+)
+
+var (
+	// ErrCELEvaluationLimitExceeded is returned when the evaluation of a CEL expression exceeds
+	// the cost limit or the timeout.
+	ErrCELEvaluationLimitExceeded = errors.New("the CEL expression exceeds the evaluation limit")
+
+	celEnvOnce sync.Once
+	celEnv     *cel.Env
+	celEnvErr  error
+)
+
+// celEnvironment returns the CEL environment in which the expressions of CEL overrides are compiled.
+func celEnvironment() (*cel.Env, error) {
+	celEnvOnce.Do(func() {
+		celEnv, celEnvErr = cel.NewEnv(
+			cel.Variable(celItemVarName, cel.DynType),
+			cel.Variable(celObjectVarName, cel.DynType),
+			cel.Variable(celClusterVarName, cel.DynType),
+			ext.Strings(),
+		)
+	})
+	return celEnv, celEnvErr
+}
+
+// compileCELExpression compiles a CEL expression and checks that it evaluates to the given type.
+// A nil type skips the type check.
+func compileCELExpression(expr string, wantType *cel.Type) (cel.Program, error) {
+	env, err := celEnvironment()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the CEL environment: %w", err)
+	}
+	ast, iss := env.Compile(expr)
+	if iss.Err() != nil {
+		return nil, fmt.Errorf("failed to compile CEL expression %q: %w", expr, iss.Err())
+	}
+	if wantType != nil && !ast.OutputType().IsExactType(wantType) && !ast.OutputType().IsExactType(cel.DynType) {
+		return nil, fmt.Errorf("CEL expression %q must evaluate to %s, got %s", expr, wantType, ast.OutputType())
+	}
+	prg, err := env.Program(ast,
+		cel.CostLimit(celCostLimit),
+		cel.InterruptCheckFrequency(celInterruptCheckFrequency),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build CEL program for expression %q: %w", expr, err)
+	}
+	return prg, nil
+}
+
+// ValidateCELOverride checks that the expressions in a CEL override can be compiled.
+func ValidateCELOverride(override *placementv1beta1.CELOverride) error {
+	var allErr []error
+	if _, err := compileCELExpression(override.Selector, cel.BoolType); err != nil {
+		allErr = append(allErr, fmt.Errorf("invalid selector: %w", err))
+	}
+	if len(override.ValueExpression) > 0 {
+		if _, err := compileCELExpression(override.ValueExpression, nil); err != nil {
+			allErr = append(allErr, fmt.Errorf("invalid valueExpression: %w", err))
+		}
+	}
+	return errors.Join(allErr...)
+}
+
+// celClusterVariable returns the value of the cluster variable in CEL expressions.
+func celClusterVariable(cluster *clusterv1beta1.MemberCluster) map[string]interface{} {
+	data := NewTemplateData(cluster)
+	return map[string]interface{}{
+		"name":        data.Cluster.Name,
+		"labels":      toInterfaceMap(data.Cluster.Labels),
+		"annotations": toInterfaceMap(data.Cluster.Annotations),
+		"properties":  toInterfaceMap(data.Cluster.Properties),
+	}
+}
+
+func toInterfaceMap(m map[string]string) map[string]interface{} {
+	res := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		res[k] = v
+	}
+	return res
+}
+
+// CELOverrideJSONPatchOp is a JSON patch operation generated from a CEL override.
+type CELOverrideJSONPatchOp struct {
+	Operator placementv1beta1.JSONPatchOverrideOperator `json:"op"`
+	Path     string                                     `json:"path"`
+	Value    json.RawMessage                            `json:"value,omitempty"`
+}
+
+// BuildJSONPatchForCELOverride evaluates a CEL override against an object and returns the
+// equivalent JSON patch operations, one for each list item selected by the override.
+//
+// value is the value of the override after variable substitution; it is ignored if the override
+// has a value expression.
+func BuildJSONPatchForCELOverride(
+	obj map[string]interface{},
+	cluster *clusterv1beta1.MemberCluster,
+	override *placementv1beta1.CELOverride,
+	value json.RawMessage,
+) ([]CELOverrideJSONPatchOp, error) {
+	listPtr, err := jsonpointer.Parse(override.ListPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid list path %q: %w", override.ListPath, err)
+	}
+	found, err := listPtr.Eval(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the list at %q: %w", override.ListPath, err)
+	}
+	list, ok := found.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("the value at %q is not a list", override.ListPath)
+	}
+
+	selector, err := compileCELExpression(override.Selector, cel.BoolType)
+	if err != nil {
+		return nil, err
+	}
+	var valueExpr cel.Program
+	if len(override.ValueExpression) > 0 {
+		if valueExpr, err = compileCELExpression(override.ValueExpression, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	clusterVar := celClusterVariable(cluster)
+	ops := make([]CELOverrideJSONPatchOp, 0, len(list))
+	for idx, item := range list {
+		vars := map[string]interface{}{
+			celItemVarName:    item,
+			celObjectVarName:  obj,
+			celClusterVarName: clusterVar,
+		}
+		out, err := evaluateCELProgram(selector, vars)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate selector %q on item %d of %q: %w", override.Selector, idx, override.ListPath, err)
+		}
+		selected, ok := out.Value().(bool)
+		if !ok {
+			return nil, fmt.Errorf("selector %q evaluated to %v on item %d of %q, want a boolean", override.Selector, out.Value(), idx, override.ListPath)
+		}
+		if !selected {
+			continue
+		}
+
+		op := CELOverrideJSONPatchOp{
+			Operator: override.Operator,
+			Path:     override.ListPath + "/" + strconv.Itoa(idx) + override.Path,
+		}
+		if override.Operator != placementv1beta1.JSONPatchOverrideOpRemove {
+			op.Value = value
+			if valueExpr != nil {
+				if op.Value, err = evaluateCELValueExpression(valueExpr, vars); err != nil {
+					return nil, fmt.Errorf("failed to evaluate valueExpression %q on item %d of %q: %w", override.ValueExpression, idx, override.ListPath, err)
+				}
+			}
+		}
+		ops = append(ops, op)
+	}
+
+	if override.Operator == placementv1beta1.JSONPatchOverrideOpRemove && len(override.Path) == 0 {
+		// Remove the selected items from the end of the list so that the indices of the remaining
+		// selected items are not shifted.
+		for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+			ops[i], ops[j] = ops[j], ops[i]
+		}
+	}
+	return ops, nil
+}
+
+// evaluateCELProgram evaluates a compiled CEL program with a timeout; the evaluation fails with
+// ErrCELEvaluationLimitExceeded if it exceeds the cost limit or the timeout.
+func evaluateCELProgram(prg cel.Program, vars map[string]interface{}) (ref.Val, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), celEvaluationTimeout)
+	defer cancel()
+	out, _, err := prg.ContextEval(ctx, vars)
+	if err != nil {
+		var cancelledErr interpreter.EvalCancelledError
+		if errors.As(err, &cancelledErr) {
+			return nil, fmt.Errorf("%w: %s", ErrCELEvaluationLimitExceeded, cancelledErr.Message)
+		}
+		return nil, err
+	}
+	return out, nil
+}
+
+func evaluateCELValueExpression(prg cel.Program, vars map[string]interface{}) (json.RawMessage, error) {
+	out, err := evaluateCELProgram(prg, vars)
+	if err != nil {
+		return nil, err
+	}
+	native, err := out.ConvertToNative(reflect.TypeOf(&structpb.Value{}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert the result to JSON: %w", err)
+	}
+	data, err := json.Marshal(native.(*structpb.Value).AsInterface())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the result: %w", err)
+	}
+	return data, nil
+}
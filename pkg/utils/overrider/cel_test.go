@@ -0,0 +1,74 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overrider
+
+import (
+	"errors"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// TestBuildJSONPatchForCELOverride_EvaluationLimit tests that the evaluation of an expensive
+// CEL expression is cancelled.
+func TestBuildJSONPatchForCELOverride_EvaluationLimit(t *testing.T) {
+	obj := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"name": "app"},
+		},
+	}
+	cluster := &clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-1"},
+	}
+	digits := "[0, 1, 2, 3, 4, 5, 6, 7, 8, 9]"
+	testCases := []struct {
+		name     string
+		override placementv1beta1.CELOverride
+		wantErr  error
+	}{
+		{
+			name: "cheap selector",
+			override: placementv1beta1.CELOverride{
+				ListPath: "/items",
+				Selector: `item.name == "app"`,
+				Operator: placementv1beta1.JSONPatchOverrideOpRemove,
+			},
+		},
+		{
+			name: "selector exceeds the cost limit",
+			override: placementv1beta1.CELOverride{
+				ListPath: "/items",
+				Selector: digits + ".all(a, " + digits + ".all(b, " + digits + ".all(c, " + digits + ".all(d, " +
+					digits + ".all(e, " + digits + ".all(f, a + b + c + d + e + f >= 0))))))",
+				Operator: placementv1beta1.JSONPatchOverrideOpRemove,
+			},
+			wantErr: ErrCELEvaluationLimitExceeded,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := BuildJSONPatchForCELOverride(obj, cluster, &tc.override, nil)
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("BuildJSONPatchForCELOverride() = %v, want %v", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -48,7 +48,46 @@ func FetchAllMatchingOverridesForResourceSnapshot(
 	placementKey string,
 	masterResourceSnapshot placementv1beta1.ResourceSnapshotObj,
 ) ([]*placementv1beta1.ClusterResourceOverrideSnapshot, []*placementv1beta1.ResourceOverrideSnapshot, error) {
-	// fetch the cro and ro snapshot list first before finding the matched ones.
+	croList, roList, err := listLatestOverrideSnapshots(ctx, c)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(croList.Items) == 0 && len(roList.Items) == 0 {
+		return nil, nil, nil // no overrides and nothing to do
+	}
+
+	resourceSnapshots, err := controller.FetchAllResourceSnapshotsAlongWithMaster(ctx, c, placementKey, masterResourceSnapshot)
+	if err != nil {
+		return nil, nil, err
+	}
+	var resources []placementv1beta1.ResourceContent
+	for _, snapshot := range resourceSnapshots {
+		resources = append(resources, snapshot.GetResourceSnapshotSpec().SelectedResources...)
+	}
+	return filterMatchingOverrides(manager, placementKey, resources, croList, roList)
+}
+
+// FetchAllMatchingOverridesForResources fetches all the matching overrides which are attached to the given resources,
+// which have not been saved in any resource snapshot yet, e.g., the resources selected for a placement preview.
+func FetchAllMatchingOverridesForResources(
+	ctx context.Context,
+	c client.Client,
+	manager informer.Manager,
+	placementKey string,
+	resources []placementv1beta1.ResourceContent,
+) ([]*placementv1beta1.ClusterResourceOverrideSnapshot, []*placementv1beta1.ResourceOverrideSnapshot, error) {
+	croList, roList, err := listLatestOverrideSnapshots(ctx, c)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(croList.Items) == 0 && len(roList.Items) == 0 {
+		return nil, nil, nil // no overrides and nothing to do
+	}
+	return filterMatchingOverrides(manager, placementKey, resources, croList, roList)
+}
+
+// listLatestOverrideSnapshots lists the latest snapshots of all the cro and ro.
+func listLatestOverrideSnapshots(ctx context.Context, c client.Client) (*placementv1beta1.ClusterResourceOverrideSnapshotList, *placementv1beta1.ResourceOverrideSnapshotList, error) {
 	latestSnapshotLabelMatcher := client.MatchingLabels{
 		placementv1beta1.IsLatestSnapshotLabel: strconv.FormatBool(true),
 	}
@@ -62,53 +101,52 @@ func FetchAllMatchingOverridesForResourceSnapshot(
 		klog.ErrorS(err, "Failed to list all the resourceOverrideSnapshots")
 		return nil, nil, err
 	}
+	return croList, roList, nil
+}
 
-	if len(croList.Items) == 0 && len(roList.Items) == 0 {
-		return nil, nil, nil // no overrides and nothing to do
-	}
-
-	resourceSnapshots, err := controller.FetchAllResourceSnapshotsAlongWithMaster(ctx, c, placementKey, masterResourceSnapshot)
-	if err != nil {
-		return nil, nil, err
-	}
-
+// filterMatchingOverrides finds the overrides which are attached to the given resources of the placement.
+func filterMatchingOverrides(
+	manager informer.Manager,
+	placementKey string,
+	resources []placementv1beta1.ResourceContent,
+	croList *placementv1beta1.ClusterResourceOverrideSnapshotList,
+	roList *placementv1beta1.ResourceOverrideSnapshotList,
+) ([]*placementv1beta1.ClusterResourceOverrideSnapshot, []*placementv1beta1.ResourceOverrideSnapshot, error) {
 	possibleCROs := make(map[placementv1beta1.ResourceIdentifier]bool)
 	possibleROs := make(map[placementv1beta1.ResourceIdentifier]bool)
 	// List all the possible CROs and ROs based on the selected resources.
-	for _, snapshot := range resourceSnapshots {
-		for _, res := range snapshot.GetResourceSnapshotSpec().SelectedResources {
-			var uResource unstructured.Unstructured
-			if err := uResource.UnmarshalJSON(res.Raw); err != nil {
-				klog.ErrorS(err, "Resource has invalid content", "snapshot", klog.KObj(snapshot), "selectedResource", res.Raw)
-				return nil, nil, controller.NewUnexpectedBehaviorError(err)
+	for _, res := range resources {
+		var uResource unstructured.Unstructured
+		if err := uResource.UnmarshalJSON(res.Raw); err != nil {
+			klog.ErrorS(err, "Resource has invalid content", "placement", placementKey, "selectedResource", res.Raw)
+			return nil, nil, controller.NewUnexpectedBehaviorError(err)
+		}
+		// If the resource is namespaced scope resource, the resource could be selected by the namespace or selected
+		// by the object itself.
+		if !manager.IsClusterScopedResources(uResource.GroupVersionKind()) {
+			croKey := placementv1beta1.ResourceIdentifier{
+				Group:   utils.NamespaceMetaGVK.Group,
+				Version: utils.NamespaceMetaGVK.Version,
+				Kind:    utils.NamespaceMetaGVK.Kind,
+				Name:    uResource.GetNamespace(),
 			}
-			// If the resource is namespaced scope resource, the resource could be selected by the namespace or selected
-			// by the object itself.
-			if !manager.IsClusterScopedResources(uResource.GroupVersionKind()) {
-				croKey := placementv1beta1.ResourceIdentifier{
-					Group:   utils.NamespaceMetaGVK.Group,
-					Version: utils.NamespaceMetaGVK.Version,
-					Kind:    utils.NamespaceMetaGVK.Kind,
-					Name:    uResource.GetNamespace(),
-				}
-				possibleCROs[croKey] = true // selected by the namespace
-				roKey := placementv1beta1.ResourceIdentifier{
-					Group:     uResource.GetObjectKind().GroupVersionKind().Group,
-					Version:   uResource.GetObjectKind().GroupVersionKind().Version,
-					Kind:      uResource.GetObjectKind().GroupVersionKind().Kind,
-					Namespace: uResource.GetNamespace(),
-					Name:      uResource.GetName(),
-				}
-				possibleROs[roKey] = true // selected by the object itself
-			} else {
-				croKey := placementv1beta1.ResourceIdentifier{
-					Group:   uResource.GetObjectKind().GroupVersionKind().Group,
-					Version: uResource.GetObjectKind().GroupVersionKind().Version,
-					Kind:    uResource.GetObjectKind().GroupVersionKind().Kind,
-					Name:    uResource.GetName(),
-				}
-				possibleCROs[croKey] = true // selected by the object itself
+			possibleCROs[croKey] = true // selected by the namespace
+			roKey := placementv1beta1.ResourceIdentifier{
+				Group:     uResource.GetObjectKind().GroupVersionKind().Group,
+				Version:   uResource.GetObjectKind().GroupVersionKind().Version,
+				Kind:      uResource.GetObjectKind().GroupVersionKind().Kind,
+				Namespace: uResource.GetNamespace(),
+				Name:      uResource.GetName(),
+			}
+			possibleROs[roKey] = true // selected by the object itself
+		} else {
+			croKey := placementv1beta1.ResourceIdentifier{
+				Group:   uResource.GetObjectKind().GroupVersionKind().Group,
+				Version: uResource.GetObjectKind().GroupVersionKind().Version,
+				Kind:    uResource.GetObjectKind().GroupVersionKind().Kind,
+				Name:    uResource.GetName(),
 			}
+			possibleCROs[croKey] = true // selected by the object itself
 		}
 	}
 
@@ -1528,6 +1528,133 @@ func TestFetchAllMatchingOverridesForResourceSnapshot(t *testing.T) {
 	}
 }
 
+func TestFetchAllMatchingOverridesForResources(t *testing.T) {
+	fakeInformer := informer.FakeManager{
+		APIResources: map[schema.GroupVersionKind]bool{
+			{
+				Group:   "",
+				Version: "v1",
+				Kind:    "Service",
+			}: true,
+		},
+		IsClusterScopedResource: false,
+	}
+	cro := placementv1beta1.ClusterResourceOverrideSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cro-1",
+			Labels: map[string]string{
+				placementv1beta1.IsLatestSnapshotLabel: "true",
+			},
+		},
+		Spec: placementv1beta1.ClusterResourceOverrideSnapshotSpec{
+			OverrideSpec: placementv1beta1.ClusterResourceOverrideSpec{
+				ClusterResourceSelectors: []placementv1beta1.ResourceSelectorTerm{
+					{
+						Group:   "",
+						Version: "v1",
+						Kind:    "Namespace",
+						Name:    "svc-namespace",
+					},
+				},
+			},
+		},
+	}
+	ro := placementv1beta1.ResourceOverrideSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ro-1",
+			Namespace: "svc-namespace",
+			Labels: map[string]string{
+				placementv1beta1.IsLatestSnapshotLabel: "true",
+			},
+		},
+		Spec: placementv1beta1.ResourceOverrideSnapshotSpec{
+			OverrideSpec: placementv1beta1.ResourceOverrideSpec{
+				Placement: &placementv1beta1.PlacementRef{
+					Name: crpName,
+				},
+				ResourceSelectors: []placementv1beta1.ResourceSelector{
+					{
+						Group:   "",
+						Version: "v1",
+						Kind:    "Service",
+						Name:    "svc-name",
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		placementKey string
+		resources    []placementv1beta1.ResourceContent
+		croList      []placementv1beta1.ClusterResourceOverrideSnapshot
+		roList       []placementv1beta1.ResourceOverrideSnapshot
+		wantCRO      []*placementv1beta1.ClusterResourceOverrideSnapshot
+		wantRO       []*placementv1beta1.ResourceOverrideSnapshot
+	}{
+		{
+			name:         "no overrides",
+			placementKey: crpName,
+			resources:    []placementv1beta1.ResourceContent{*resource.ServiceResourceContentForTest(t)},
+		},
+		{
+			name:         "no resources",
+			placementKey: crpName,
+			croList:      []placementv1beta1.ClusterResourceOverrideSnapshot{cro},
+			roList:       []placementv1beta1.ResourceOverrideSnapshot{ro},
+			wantCRO:      []*placementv1beta1.ClusterResourceOverrideSnapshot{},
+			wantRO:       []*placementv1beta1.ResourceOverrideSnapshot{},
+		},
+		{
+			name:         "matched cro and ro",
+			placementKey: crpName,
+			resources:    []placementv1beta1.ResourceContent{*resource.ServiceResourceContentForTest(t)},
+			croList:      []placementv1beta1.ClusterResourceOverrideSnapshot{cro},
+			roList:       []placementv1beta1.ResourceOverrideSnapshot{ro},
+			wantCRO:      []*placementv1beta1.ClusterResourceOverrideSnapshot{&cro},
+			wantRO:       []*placementv1beta1.ResourceOverrideSnapshot{&ro},
+		},
+		{
+			name:         "ro created for another placement",
+			placementKey: "other-crp",
+			resources:    []placementv1beta1.ResourceContent{*resource.ServiceResourceContentForTest(t)},
+			croList:      []placementv1beta1.ClusterResourceOverrideSnapshot{cro},
+			roList:       []placementv1beta1.ResourceOverrideSnapshot{ro},
+			wantCRO:      []*placementv1beta1.ClusterResourceOverrideSnapshot{&cro},
+			wantRO:       []*placementv1beta1.ResourceOverrideSnapshot{},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var objects []client.Object
+			for i := range tc.croList {
+				objects = append(objects, tc.croList[i].DeepCopy())
+			}
+			for i := range tc.roList {
+				objects = append(objects, tc.roList[i].DeepCopy())
+			}
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(serviceScheme(t)).
+				WithObjects(objects...).
+				Build()
+			gotCRO, gotRO, err := FetchAllMatchingOverridesForResources(context.Background(), fakeClient, &fakeInformer, tc.placementKey, tc.resources)
+			if err != nil {
+				t.Fatalf("FetchAllMatchingOverridesForResources() failed, got err %v, want no err", err)
+			}
+			options := []cmp.Option{
+				cmpopts.IgnoreFields(metav1.ObjectMeta{}, "ResourceVersion"),
+			}
+			if diff := cmp.Diff(tc.wantCRO, gotCRO, options...); diff != "" {
+				t.Errorf("FetchAllMatchingOverridesForResources() returned clusterResourceOverrides mismatch (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantRO, gotRO, options...); diff != "" {
+				t.Errorf("FetchAllMatchingOverridesForResources() returned resourceOverrides mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestPickFromResourceMatchedOverridesForTargetCluster(t *testing.T) {
 	clusterName := "cluster-1"
 	tests := []struct {
@@ -0,0 +1,387 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overrider
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+)
+
+const (
+	// templateActionLeftDelim is the left delimiter of a template action.
+	templateActionLeftDelim = "{{"
+
+	// captureFuncName is the name of the internal function that captures the result of a
+	// single-action template so that its type can be preserved.
+	captureFuncName = "__capture"
+)
+
+// TemplateData is the data that the templated values in a JSON patch override are rendered against.
+type TemplateData struct {
+	// Cluster is the member cluster that the override is applied for.
+	Cluster TemplateCluster
+}
+
+// TemplateCluster describes a member cluster in the template data.
+type TemplateCluster struct {
+	// Name is the name of the member cluster.
+	Name string
+	// Labels are the labels of the member cluster.
+	Labels map[string]string
+	// Annotations are the annotations of the member cluster.
+	Annotations map[string]string
+	// Properties are the values of the properties of the member cluster, keyed by the property names.
+	Properties map[string]string
+	// ResourceUsage is the resource usage of the member cluster.
+	ResourceUsage TemplateResourceUsage
+}
+
+// TemplateResourceUsage describes the resource usage of a member cluster in the template data.
+type TemplateResourceUsage struct {
+	// Capacity is the total resource capacity of the member cluster, keyed by the resource names.
+	Capacity map[string]string
+	// Allocatable is the total allocatable resources of the member cluster, keyed by the resource names.
+	Allocatable map[string]string
+	// Available is the total available resources of the member cluster, keyed by the resource names.
+	Available map[string]string
+}
+
+// NewTemplateData builds the template data for a member cluster.
+func NewTemplateData(cluster *clusterv1beta1.MemberCluster) *TemplateData {
+	properties := make(map[string]string, len(cluster.Status.Properties))
+	for name, property := range cluster.Status.Properties {
+		properties[string(name)] = property.Value
+	}
+	return &TemplateData{
+		Cluster: TemplateCluster{
+			Name:        cluster.Name,
+			Labels:      cluster.Labels,
+			Annotations: cluster.Annotations,
+			Properties:  properties,
+			ResourceUsage: TemplateResourceUsage{
+				Capacity:    resourceListToMap(cluster.Status.ResourceUsage.Capacity),
+				Allocatable: resourceListToMap(cluster.Status.ResourceUsage.Allocatable),
+				Available:   resourceListToMap(cluster.Status.ResourceUsage.Available),
+			},
+		},
+	}
+}
+
+func resourceListToMap(rl corev1.ResourceList) map[string]string {
+	m := make(map[string]string, len(rl))
+	for name, quantity := range rl {
+		m[string(name)] = quantity.String()
+	}
+	return m
+}
+
+// RenderJSONPatchOverrideValue renders all the templated string values in the value of a JSON patch
+// override and returns the rendered value.
+//
+// A string value that consists of a single template action is replaced by the result of the action
+// as is, so that the action can produce a non-string value (e.g., a number); any other string value
+// that contains template actions is rendered as a string.
+func RenderJSONPatchOverrideValue(raw []byte, data *TemplateData) ([]byte, error) {
+	if len(raw) == 0 {
+		return raw, nil
+	}
+	value, err := decodeJSONValue(raw)
+	if err != nil {
+		return nil, err
+	}
+	rendered, err := renderTemplatedValue(value, data)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(rendered)
+}
+
+// ValidateJSONPatchOverrideTemplate checks that all the templated string values in the value of a
+// JSON patch override can be parsed.
+func ValidateJSONPatchOverrideTemplate(raw []byte) error {
+	if len(raw) == 0 {
+		return nil
+	}
+	value, err := decodeJSONValue(raw)
+	if err != nil {
+		return err
+	}
+	var allErr []error
+	walkStrings(value, func(s string) {
+		if !strings.Contains(s, templateActionLeftDelim) {
+			return
+		}
+		if _, err := newTemplate(nil).Parse(s); err != nil {
+			allErr = append(allErr, fmt.Errorf("failed to parse template %q: %w", s, err))
+		}
+	})
+	return errors.Join(allErr...)
+}
+
+func decodeJSONValue(raw []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	// Keep the numbers as they are in the untemplated parts of the value.
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("failed to decode the value as JSON: %w", err)
+	}
+	return value, nil
+}
+
+func walkStrings(value interface{}, fn func(string)) {
+	switch v := value.(type) {
+	case string:
+		fn(v)
+	case []interface{}:
+		for _, item := range v {
+			walkStrings(item, fn)
+		}
+	case map[string]interface{}:
+		for _, item := range v {
+			walkStrings(item, fn)
+		}
+	}
+}
+
+func renderTemplatedValue(value interface{}, data *TemplateData) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		if !strings.Contains(v, templateActionLeftDelim) {
+			return v, nil
+		}
+		return renderTemplatedString(v, data)
+	case []interface{}:
+		for i := range v {
+			rendered, err := renderTemplatedValue(v[i], data)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = rendered
+		}
+		return v, nil
+	case map[string]interface{}:
+		for key := range v {
+			rendered, err := renderTemplatedValue(v[key], data)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = rendered
+		}
+		return v, nil
+	default:
+		return v, nil
+	}
+}
+
+func renderTemplatedString(s string, data *TemplateData) (interface{}, error) {
+	tmpl, err := newTemplate(nil).Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %q: %w", s, err)
+	}
+
+	nodes := tmpl.Tree.Root.Nodes
+	if len(nodes) == 1 {
+		if action, ok := nodes[0].(*parse.ActionNode); ok && len(action.Pipe.Decl) == 0 {
+			// Re-run the single action with the capture function at the end of the pipeline to
+			// keep the type of its result.
+			var captured interface{}
+			capture := func(v interface{}) string {
+				captured = v
+				return ""
+			}
+			captureTmpl, err := newTemplate(capture).Parse(fmt.Sprintf("{{%s | %s}}", action.Pipe.String(), captureFuncName))
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse template %q: %w", s, err)
+			}
+			if err := captureTmpl.Execute(&bytes.Buffer{}, data); err != nil {
+				return nil, fmt.Errorf("failed to render template %q: %w", s, err)
+			}
+			return captured, nil
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render template %q: %w", s, err)
+	}
+	return buf.String(), nil
+}
+
+func newTemplate(capture func(interface{}) string) *template.Template {
+	funcs := template.FuncMap{
+		"lower":      strings.ToLower,
+		"upper":      strings.ToUpper,
+		"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+		"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+		"replace":    func(old, replacement, s string) string { return strings.ReplaceAll(s, old, replacement) },
+		"default":    defaultValue,
+		"int":        toInt64,
+		"float":      toFloat64,
+		"add":        func(a, b interface{}) (interface{}, error) { return arithmetic("add", a, b) },
+		"sub":        func(a, b interface{}) (interface{}, error) { return arithmetic("sub", a, b) },
+		"mul":        func(a, b interface{}) (interface{}, error) { return arithmetic("mul", a, b) },
+		"div":        func(a, b interface{}) (interface{}, error) { return arithmetic("div", a, b) },
+		// Always register the capture function so that the templates can be parsed without it.
+		captureFuncName: func(interface{}) string { return "" },
+	}
+	if capture != nil {
+		funcs[captureFuncName] = capture
+	}
+	return template.New("override").Option("missingkey=error").Funcs(funcs)
+}
+
+// defaultValue returns the given default value if the value is empty (i.e., nil or the zero value of its type).
+func defaultValue(def, value interface{}) interface{} {
+	if value == nil {
+		return def
+	}
+	if v := reflect.ValueOf(value); v.IsZero() {
+		return def
+	}
+	return value
+}
+
+// toInt64 converts a value to an int64. Strings are parsed as integers first, and then as
+// Kubernetes quantities (e.g., `16Gi`), which are rounded up to the nearest integer.
+func toInt64(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case float64:
+		return int64(v), nil
+	case json.Number:
+		return toInt64(v.String())
+	case string:
+		s := strings.TrimSpace(v)
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return i, nil
+		}
+		q, err := resource.ParseQuantity(s)
+		if err != nil {
+			return 0, fmt.Errorf("cannot convert %q to an integer", v)
+		}
+		return q.Value(), nil
+	default:
+		return 0, fmt.Errorf("cannot convert %v (%T) to an integer", value, value)
+	}
+}
+
+// toFloat64 converts a value to a float64. Strings are parsed as floating-point numbers first, and
+// then as Kubernetes quantities (e.g., `500m`).
+func toFloat64(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), nil
+	case int32:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case json.Number:
+		return toFloat64(v.String())
+	case string:
+		s := strings.TrimSpace(v)
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f, nil
+		}
+		q, err := resource.ParseQuantity(s)
+		if err != nil {
+			return 0, fmt.Errorf("cannot convert %q to a number", v)
+		}
+		return q.AsApproximateFloat64(), nil
+	default:
+		return 0, fmt.Errorf("cannot convert %v (%T) to a number", value, value)
+	}
+}
+
+// arithmetic performs an arithmetic operation on two numbers, e.g., `{{ sub 10 2 }}` evaluates
+// to `10 - 2`. The result is an int64 if both operands are integers (or strings that represent
+// integers); otherwise it is a float64.
+func arithmetic(op string, a, b interface{}) (interface{}, error) {
+	ai, aErr := toInt64Strict(a)
+	bi, bErr := toInt64Strict(b)
+	if aErr == nil && bErr == nil {
+		switch op {
+		case "add":
+			return ai + bi, nil
+		case "sub":
+			return ai - bi, nil
+		case "mul":
+			return ai * bi, nil
+		case "div":
+			if bi == 0 {
+				return nil, errors.New("division by zero")
+			}
+			return ai / bi, nil
+		}
+	}
+
+	af, err := toFloat64(a)
+	if err != nil {
+		return nil, err
+	}
+	bf, err := toFloat64(b)
+	if err != nil {
+		return nil, err
+	}
+	switch op {
+	case "add":
+		return af + bf, nil
+	case "sub":
+		return af - bf, nil
+	case "mul":
+		return af * bf, nil
+	case "div":
+		if bf == 0 {
+			return nil, errors.New("division by zero")
+		}
+		return af / bf, nil
+	default:
+		return nil, fmt.Errorf("unknown arithmetic operation %q", op)
+	}
+}
+
+// toInt64Strict converts a value to an int64 only if it represents an integer exactly.
+func toInt64Strict(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case int, int32, int64:
+		return toInt64(v)
+	case json.Number:
+		return strconv.ParseInt(v.String(), 10, 64)
+	case string:
+		return strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+	}
+	return 0, fmt.Errorf("%v (%T) is not an integer", value, value)
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overrider
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+)
+
+func TestRenderJSONPatchOverrideValue(t *testing.T) {
+	cluster := &clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "member-1",
+			Labels: map[string]string{
+				"region": "WestEurope",
+			},
+		},
+		Status: clusterv1beta1.MemberClusterStatus{
+			Properties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+				"kubernetes-fleet.io/node-count": {Value: "5"},
+			},
+			ResourceUsage: clusterv1beta1.ResourceUsage{
+				Available: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("2500m"),
+					corev1.ResourceMemory: resource.MustParse("4Gi"),
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "no template",
+			value: `{"replicas": 3, "ratio": 0.5, "name": "nginx"}`,
+			want:  `{"name":"nginx","ratio":0.5,"replicas":3}`,
+		},
+		{
+			name:  "single action keeps the type of its result",
+			value: `["{{ .Cluster.Name }}", "{{ index .Cluster.Properties \"kubernetes-fleet.io/node-count\" | int }}"]`,
+			want:  `["member-1",5]`,
+		},
+		{
+			name:  "mixed text and actions",
+			value: `"app-{{ .Cluster.Labels.region | lower | trimSuffix \"europe\" }}-{{ .Cluster.Name | replace \"-\" \"\" }}"`,
+			want:  `"app-west-member1"`,
+		},
+		{
+			name:  "integer arithmetic",
+			value: `"{{ index .Cluster.Properties \"kubernetes-fleet.io/node-count\" | int | mul 3 | add 1 }}"`,
+			want:  `16`,
+		},
+		{
+			name:  "floating-point arithmetic",
+			value: `"{{ div (float .Cluster.ResourceUsage.Available.cpu) 2 }}"`,
+			want:  `1.25`,
+		},
+		{
+			name:  "quantity to integer",
+			value: `"{{ div (int .Cluster.ResourceUsage.Available.memory) 1073741824 }}"`,
+			want:  `4`,
+		},
+		{
+			name:  "default value",
+			value: `"{{ index .Cluster.Labels \"env\" | default \"staging\" }}"`,
+			want:  `"staging"`,
+		},
+		{
+			name:    "missing key",
+			value:   `"{{ .Cluster.Labels.env }}"`,
+			wantErr: true,
+		},
+		{
+			name:    "division by zero",
+			value:   `"{{ div 1 0 }}"`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid number",
+			value:   `"{{ .Cluster.Name | int }}"`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid template",
+			value:   `"{{ .Cluster.Name "`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := RenderJSONPatchOverrideValue([]byte(tc.value), NewTemplateData(cluster))
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("RenderJSONPatchOverrideValue() = error %v, want error %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if string(got) != tc.want {
+				t.Errorf("RenderJSONPatchOverrideValue() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
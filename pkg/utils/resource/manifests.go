@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resource
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/blobstore"
+)
+
+const (
+	// maxDecompressedManifestsBytes is the maximum size of the manifests in a workload after decompression,
+	// which guards the member agent against malformed data. It is set a few times above the size limit of
+	// the objects in etcd (1.5 MiB by default), which the manifests would have to fit in if they were not
+	// compressed; the large manifests are expected to be offloaded to the blob store instead.
+	maxDecompressedManifestsBytes = 4 << 20 // 4 MiB.
+)
+
+// ErrManifestsTooLarge is returned when the manifests in a workload exceed the size limit of the compressed
+// form after decompression.
+var ErrManifestsTooLarge = errors.New("manifests exceed the size limit")
+
+// CompressManifests compresses the manifests in a workload with gzip; the Manifests field is replaced
+// with the CompressedManifests field. A workload with no manifests is left as it is.
+//
+// If a blob store is given and the compressed data is larger than the offload threshold, the data is
+// saved in the store, and only its digest is kept in the workload.
+func CompressManifests(ctx context.Context, workload *placementv1beta1.WorkloadTemplate, store blobstore.Store, offloadThresholdBytes int) error {
+	if len(workload.Manifests) == 0 {
+		return nil
+	}
+	manifestsJSON, err := json.Marshal(workload.Manifests)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the manifests: %w", err)
+	}
+	if len(manifestsJSON) > maxDecompressedManifestsBytes {
+		return fmt.Errorf("%w: the manifests have %d bytes, which exceeds the limit of %d bytes", ErrManifestsTooLarge, len(manifestsJSON), maxDecompressedManifestsBytes)
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(manifestsJSON); err != nil {
+		return fmt.Errorf("failed to compress the manifests: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to compress the manifests: %w", err)
+	}
+
+	data := buf.Bytes()
+	compressed := &placementv1beta1.CompressedManifests{
+		Encoding: placementv1beta1.ManifestEncodingGzip,
+		Digest:   blobstore.Digest(data),
+		Data:     data,
+	}
+	if store != nil && len(data) > offloadThresholdBytes {
+		if _, err := store.Put(ctx, data); err != nil {
+			return fmt.Errorf("failed to offload the compressed manifests: %w", err)
+		}
+		compressed.Data = nil
+	}
+	workload.Manifests = nil
+	workload.CompressedManifests = compressed
+	return nil
+}
+
+// ManifestsOf returns the manifests in a workload, decompressing them if they are kept in the compressed
+// form; the compressed data is fetched from the blob store if it is not kept in the workload.
+func ManifestsOf(ctx context.Context, workload *placementv1beta1.WorkloadTemplate, store blobstore.Store) ([]placementv1beta1.Manifest, error) {
+	compressed := workload.CompressedManifests
+	if compressed == nil {
+		return workload.Manifests, nil
+	}
+
+	data := compressed.Data
+	if len(data) == 0 {
+		if store == nil {
+			return nil, fmt.Errorf("failed to fetch the compressed manifests %s: %w", compressed.Digest, blobstore.ErrNoStore)
+		}
+		var err error
+		// gzip barely expands incompressible data, so the limit on the decompressed manifests bounds the compressed data too.
+		if data, err = store.Get(ctx, compressed.Digest, maxDecompressedManifestsBytes); err != nil {
+			return nil, fmt.Errorf("failed to fetch the compressed manifests %s: %w", compressed.Digest, err)
+		}
+	}
+	if got := blobstore.Digest(data); got != compressed.Digest {
+		return nil, fmt.Errorf("compressed manifests do not match their digest: got %s, want %s", got, compressed.Digest)
+	}
+
+	var manifestsJSON []byte
+	switch compressed.Encoding {
+	case placementv1beta1.ManifestEncodingGzip:
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress the manifests: %w", err)
+		}
+		defer zr.Close()
+		if manifestsJSON, err = io.ReadAll(io.LimitReader(zr, maxDecompressedManifestsBytes+1)); err != nil {
+			return nil, fmt.Errorf("failed to decompress the manifests: %w", err)
+		}
+		if len(manifestsJSON) > maxDecompressedManifestsBytes {
+			return nil, fmt.Errorf("%w: the decompressed manifests exceed the limit of %d bytes", ErrManifestsTooLarge, maxDecompressedManifestsBytes)
+		}
+	default:
+		return nil, fmt.Errorf("manifest encoding %q is not supported", compressed.Encoding)
+	}
+
+	var manifests []placementv1beta1.Manifest
+	if err := json.Unmarshal(manifestsJSON, &manifests); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the decompressed manifests: %w", err)
+	}
+	return manifests, nil
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resource
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/blobstore"
+)
+
+func TestCompressManifests(t *testing.T) {
+	manifests := []placementv1beta1.Manifest{
+		{RawExtension: runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"app"}}`)}},
+		{RawExtension: runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"config","namespace":"app"}}`)}},
+	}
+
+	testCases := []struct {
+		name             string
+		manifests        []placementv1beta1.Manifest
+		store            *blobstore.MemoryStore
+		offloadThreshold int
+		wantCompressed   bool
+		wantOffloaded    bool
+	}{
+		{
+			name: "no manifests",
+		},
+		{
+			name:           "no blob store",
+			manifests:      manifests,
+			wantCompressed: true,
+		},
+		{
+			name:             "compressed data under the offload threshold",
+			manifests:        manifests,
+			store:            blobstore.NewMemoryStore(),
+			offloadThreshold: 1 << 20,
+			wantCompressed:   true,
+		},
+		{
+			name:             "compressed data over the offload threshold",
+			manifests:        manifests,
+			store:            blobstore.NewMemoryStore(),
+			offloadThreshold: 16,
+			wantCompressed:   true,
+			wantOffloaded:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			var store blobstore.Store
+			if tc.store != nil {
+				store = tc.store
+			}
+			workload := &placementv1beta1.WorkloadTemplate{Manifests: tc.manifests}
+			if err := CompressManifests(ctx, workload, store, tc.offloadThreshold); err != nil {
+				t.Fatalf("CompressManifests() = %v, want no error", err)
+			}
+			if gotCompressed := workload.CompressedManifests != nil; gotCompressed != tc.wantCompressed {
+				t.Fatalf("CompressManifests() compressed the manifests: %t, want %t", gotCompressed, tc.wantCompressed)
+			}
+			if tc.wantCompressed {
+				if len(workload.Manifests) != 0 {
+					t.Errorf("CompressManifests() kept %d uncompressed manifests, want none", len(workload.Manifests))
+				}
+				if gotOffloaded := len(workload.CompressedManifests.Data) == 0; gotOffloaded != tc.wantOffloaded {
+					t.Errorf("CompressManifests() offloaded the compressed data: %t, want %t", gotOffloaded, tc.wantOffloaded)
+				}
+			}
+
+			got, err := ManifestsOf(ctx, workload, store)
+			if err != nil {
+				t.Fatalf("ManifestsOf() = %v, want no error", err)
+			}
+			if diff := cmp.Diff(got, tc.manifests); diff != "" {
+				t.Errorf("ManifestsOf() mismatch (-got, +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCompressManifests_TooLarge(t *testing.T) {
+	workload := &placementv1beta1.WorkloadTemplate{
+		Manifests: []placementv1beta1.Manifest{
+			{RawExtension: runtime.RawExtension{Raw: []byte(`{"data":"` + strings.Repeat("a", maxDecompressedManifestsBytes) + `"}`)}},
+		},
+	}
+	if err := CompressManifests(context.Background(), workload, nil, 0); !errors.Is(err, ErrManifestsTooLarge) {
+		t.Fatalf("CompressManifests() = %v, want %v", err, ErrManifestsTooLarge)
+	}
+}
+
+func TestManifestsOf_Errors(t *testing.T) {
+	ctx := context.Background()
+	manifests := []placementv1beta1.Manifest{
+		{RawExtension: runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"app"}}`)}},
+	}
+	store := blobstore.NewMemoryStore()
+	offloaded := &placementv1beta1.WorkloadTemplate{Manifests: manifests}
+	if err := CompressManifests(ctx, offloaded, store, 0); err != nil {
+		t.Fatalf("CompressManifests() = %v, want no error", err)
+	}
+	inline := &placementv1beta1.WorkloadTemplate{Manifests: manifests}
+	if err := CompressManifests(ctx, inline, nil, 0); err != nil {
+		t.Fatalf("CompressManifests() = %v, want no error", err)
+	}
+	tampered := inline.DeepCopy()
+	tampered.CompressedManifests.Data = append(tampered.CompressedManifests.Data, 0)
+	unsupported := inline.DeepCopy()
+	unsupported.CompressedManifests.Encoding = "zstd"
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(`[{"data":"` + strings.Repeat("a", maxDecompressedManifestsBytes) + `"}]`)); err != nil {
+		t.Fatalf("failed to compress the manifests: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("failed to compress the manifests: %v", err)
+	}
+	oversized := &placementv1beta1.WorkloadTemplate{
+		CompressedManifests: &placementv1beta1.CompressedManifests{
+			Encoding: placementv1beta1.ManifestEncodingGzip,
+			Digest:   blobstore.Digest(buf.Bytes()),
+			Data:     buf.Bytes(),
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		workload *placementv1beta1.WorkloadTemplate
+		store    blobstore.Store
+		wantErr  error
+	}{
+		{
+			name:     "offloaded data with no blob store",
+			workload: offloaded,
+			wantErr:  blobstore.ErrNoStore,
+		},
+		{
+			name:     "offloaded data missing from the blob store",
+			workload: offloaded,
+			store:    blobstore.NewMemoryStore(),
+			wantErr:  blobstore.ErrNotFound,
+		},
+		{
+			name:     "data not matching the digest",
+			workload: tampered,
+		},
+		{
+			name:     "unsupported encoding",
+			workload: unsupported,
+		},
+		{
+			name:     "decompressed data over the size limit",
+			workload: oversized,
+			wantErr:  ErrManifestsTooLarge,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ManifestsOf(ctx, tc.workload, tc.store)
+			if err == nil {
+				t.Fatalf("ManifestsOf() = nil, want an error")
+			}
+			if tc.wantErr != nil && !errors.Is(err, tc.wantErr) {
+				t.Errorf("ManifestsOf() = %v, want %v", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -765,13 +765,13 @@ func TestValidateClusterResourceOverride(t *testing.T) {
 			},
 			wantErrMsg: apierrors.NewAggregate([]error{
 				fmt.Errorf("invalid JSONPatchOverride %s: path must start with /",
-					placementv1beta1.JSONPatchOverride{Operator: placementv1beta1.JSONPatchOverrideOpAdd, Path: "spec.resourceSelectors/matchExpressions", Value: apiextensionsv1.JSON{Raw: []byte(`"new-value"`)}}),
+					formatJSONPatchOverride(placementv1beta1.JSONPatchOverride{Operator: placementv1beta1.JSONPatchOverrideOpAdd, Path: "spec.resourceSelectors/matchExpressions", Value: apiextensionsv1.JSON{Raw: []byte(`"new-value"`)}})),
 				fmt.Errorf("invalid JSONPatchOverride %s: path cannot be empty",
-					placementv1beta1.JSONPatchOverride{Operator: placementv1beta1.JSONPatchOverrideOpReplace, Path: "", Value: apiextensionsv1.JSON{Raw: []byte(`"new-reason"`)}}),
+					formatJSONPatchOverride(placementv1beta1.JSONPatchOverride{Operator: placementv1beta1.JSONPatchOverrideOpReplace, Path: "", Value: apiextensionsv1.JSON{Raw: []byte(`"new-reason"`)}})),
 				fmt.Errorf("invalid JSONPatchOverride %s: cannot override status fields",
-					placementv1beta1.JSONPatchOverride{Operator: placementv1beta1.JSONPatchOverrideOpRemove, Path: "/status", Value: apiextensionsv1.JSON{Raw: []byte(`"new-value"`)}}),
+					formatJSONPatchOverride(placementv1beta1.JSONPatchOverride{Operator: placementv1beta1.JSONPatchOverrideOpRemove, Path: "/status", Value: apiextensionsv1.JSON{Raw: []byte(`"new-value"`)}})),
 				fmt.Errorf("invalid JSONPatchOverride %s: remove operation cannot have value",
-					placementv1beta1.JSONPatchOverride{Operator: placementv1beta1.JSONPatchOverrideOpRemove, Path: "/status", Value: apiextensionsv1.JSON{Raw: []byte(`"new-value"`)}}),
+					formatJSONPatchOverride(placementv1beta1.JSONPatchOverride{Operator: placementv1beta1.JSONPatchOverrideOpRemove, Path: "/status", Value: apiextensionsv1.JSON{Raw: []byte(`"new-value"`)}})),
 			}),
 		},
 		"valid cluster resource override - empty cluster selector": {
@@ -7,6 +7,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation"
 
 	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	"go.goms.io/fleet/pkg/utils/maintenancewindow"
 )
 
 var (
@@ -17,7 +18,10 @@ var (
 
 // ValidateMemberCluster validates member cluster fields and returns error.
 func ValidateMemberCluster(mc clusterv1beta1.MemberCluster) error {
-	return validateTaints(mc.Spec.Taints)
+	return apiErrors.NewAggregate([]error{
+		validateTaints(mc.Spec.Taints),
+		validateMaintenanceWindows(maintenancewindow.FromClusterWindows(mc.Spec.MaintenanceWindows)),
+	})
 }
 
 func validateTaints(taints []clusterv1beta1.Taint) error {
@@ -32,10 +36,22 @@ func validateTaints(taints []clusterv1beta1.Taint) error {
 				allErr = append(allErr, fmt.Errorf(invalidTaintValueErrFmt, taint, msg))
 			}
 		}
-		if taintMap[taint] {
+		// TimeAdded is set by the hub agent and does not tell taints apart.
+		key := clusterv1beta1.Taint{Key: taint.Key, Value: taint.Value, Effect: taint.Effect}
+		if taintMap[key] {
 			allErr = append(allErr, fmt.Errorf(uniqueTaintErrFmt, taint))
 		}
-		taintMap[taint] = true
+		taintMap[key] = true
+	}
+	return apiErrors.NewAggregate(allErr)
+}
+
+func validateMaintenanceWindows(windows []maintenancewindow.Window) error {
+	allErr := make([]error, 0)
+	for i, w := range windows {
+		if err := maintenancewindow.Validate(w); err != nil {
+			allErr = append(allErr, fmt.Errorf("invalid maintenance window %d: %w", i, err))
+		}
 	}
 	return apiErrors.NewAggregate(allErr)
 }
@@ -3,6 +3,10 @@ package validator
 import (
 	"strings"
 	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
 )
@@ -66,6 +70,22 @@ func TestValidateTaints(t *testing.T) {
 			wantErr:    true,
 			wantErrMsg: "taints must be unique",
 		},
+		"invalid taint, non-unique taint with different time added": {
+			taints: []clusterv1beta1.Taint{
+				{
+					Key:       "key1",
+					Effect:    "NoExecute",
+					TimeAdded: &metav1.Time{Time: time.Now()},
+				},
+				{
+					Key:       "key1",
+					Effect:    "NoExecute",
+					TimeAdded: &metav1.Time{Time: time.Now().Add(-time.Minute)},
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: "taints must be unique",
+		},
 		"valid taints": {
 			taints: []clusterv1beta1.Taint{
 				{
@@ -98,3 +118,63 @@ func TestValidateTaints(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateMemberCluster(t *testing.T) {
+	tests := map[string]struct {
+		windows    []clusterv1beta1.MaintenanceWindow
+		wantErr    bool
+		wantErrMsg string
+	}{
+		"invalid maintenance window, invalid schedule": {
+			windows: []clusterv1beta1.MaintenanceWindow{
+				{
+					Schedule: "every night",
+					Duration: metav1.Duration{Duration: time.Hour},
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: "invalid maintenance window 0",
+		},
+		"invalid maintenance window, invalid time zone": {
+			windows: []clusterv1beta1.MaintenanceWindow{
+				{
+					Schedule: "0 22 * * *",
+					Duration: metav1.Duration{Duration: time.Hour},
+				},
+				{
+					Schedule: "0 22 * * *",
+					Duration: metav1.Duration{Duration: time.Hour},
+					TimeZone: ptr.To("Mars/Olympus"),
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: "invalid maintenance window 1",
+		},
+		"valid maintenance windows": {
+			windows: []clusterv1beta1.MaintenanceWindow{
+				{
+					Schedule: "@weekly",
+					Duration: metav1.Duration{Duration: 4 * time.Hour},
+					TimeZone: ptr.To("America/New_York"),
+				},
+			},
+			wantErr: false,
+		},
+	}
+	for testName, testCase := range tests {
+		t.Run(testName, func(t *testing.T) {
+			mc := clusterv1beta1.MemberCluster{
+				Spec: clusterv1beta1.MemberClusterSpec{
+					MaintenanceWindows: testCase.windows,
+				},
+			}
+			gotErr := ValidateMemberCluster(mc)
+			if (gotErr != nil) != testCase.wantErr {
+				t.Errorf("ValidateMemberCluster() error = %v, wantErr %v", gotErr, testCase.wantErr)
+			}
+			if testCase.wantErr && !strings.Contains(gotErr.Error(), testCase.wantErrMsg) {
+				t.Errorf("ValidateMemberCluster() got %v, should contain want %s", gotErr, testCase.wantErrMsg)
+			}
+		})
+	}
+}
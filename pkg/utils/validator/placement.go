@@ -19,9 +19,11 @@ package validator
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"sort"
 	"strings"
 
@@ -43,6 +45,7 @@ import (
 	"go.goms.io/fleet/pkg/propertyprovider"
 	"go.goms.io/fleet/pkg/utils/controller"
 	"go.goms.io/fleet/pkg/utils/informer"
+	"go.goms.io/fleet/pkg/utils/maintenancewindow"
 )
 
 var ResourceInformer informer.Manager
@@ -66,7 +69,7 @@ var (
 )
 
 // validatePlacement validates a placement object (either ClusterResourcePlacement or ResourcePlacement).
-func validatePlacement(name string, resourceSelectors []placementv1beta1.ResourceSelectorTerm, policy *placementv1beta1.PlacementPolicy, strategy placementv1beta1.RolloutStrategy, isClusterScoped bool) error {
+func validatePlacement(name, placementNamespace string, resourceSelectors []placementv1beta1.ResourceSelectorTerm, resourceSources []placementv1beta1.ResourceSource, policy *placementv1beta1.PlacementPolicy, strategy placementv1beta1.RolloutStrategy, isClusterScoped bool) error {
 	allErr := make([]error, 0)
 
 	if len(name) > validation.DNS1035LabelMaxLength {
@@ -112,6 +115,10 @@ func validatePlacement(name string, resourceSelectors []placementv1beta1.Resourc
 		}
 	}
 
+	if err := validateResourceSources(resourceSources, placementNamespace); err != nil {
+		allErr = append(allErr, fmt.Errorf("the resource sources field is invalid: %w", err))
+	}
+
 	if policy != nil {
 		if err := validatePlacementPolicy(policy); err != nil {
 			allErr = append(allErr, fmt.Errorf("the placement policy field is invalid: %w", err))
@@ -125,11 +132,83 @@ func validatePlacement(name string, resourceSelectors []placementv1beta1.Resourc
 	return apiErrors.NewAggregate(allErr)
 }
 
+// validateResourceSources validates the resource sources of a placement; the placement namespace is empty
+// for a ClusterResourcePlacement.
+func validateResourceSources(sources []placementv1beta1.ResourceSource, placementNamespace string) error {
+	allErr := make([]error, 0)
+	for i, source := range sources {
+		switch {
+		case source.Kustomize != nil && source.Helm != nil:
+			allErr = append(allErr, fmt.Errorf("resource source %d cannot have both a kustomize source and a helm source", i))
+		case source.Kustomize != nil:
+			allErr = append(allErr, validateKustomizeSource(i, source.Kustomize, placementNamespace)...)
+		case source.Helm != nil:
+			allErr = append(allErr, validateHelmSource(i, source.Helm, placementNamespace)...)
+		default:
+			allErr = append(allErr, fmt.Errorf("resource source %d must have a kustomize source or a helm source", i))
+		}
+	}
+	return apiErrors.NewAggregate(allErr)
+}
+
+// validateKustomizeSource validates the Kustomize source in a resource source of a placement.
+func validateKustomizeSource(idx int, source *placementv1beta1.KustomizeSource, placementNamespace string) []error {
+	allErr := make([]error, 0)
+	if errs := validation.IsDNS1123Subdomain(source.ConfigMapName); len(errs) != 0 {
+		allErr = append(allErr, fmt.Errorf("resource source %d has an invalid ConfigMap name %q: %s", idx, source.ConfigMapName, strings.Join(errs, "; ")))
+	}
+	configMapNamespace := source.ConfigMapNamespace
+	switch {
+	case placementNamespace == "" && configMapNamespace == "":
+		allErr = append(allErr, fmt.Errorf("resource source %d must specify the ConfigMap namespace in a clusterResourcePlacement", idx))
+	case placementNamespace != "" && configMapNamespace != "" && configMapNamespace != placementNamespace:
+		allErr = append(allErr, fmt.Errorf("resource source %d must refer to a ConfigMap in the namespace %s of the resourcePlacement, got %s", idx, placementNamespace, configMapNamespace))
+	}
+	return allErr
+}
+
+// validateHelmSource validates the Helm source in a resource source of a placement.
+func validateHelmSource(idx int, source *placementv1beta1.HelmSource, placementNamespace string) []error {
+	allErr := make([]error, 0)
+	if errs := validation.IsDNS1123Label(source.ReleaseName); len(errs) != 0 {
+		allErr = append(allErr, fmt.Errorf("resource source %d has an invalid Helm release name %q: %s", idx, source.ReleaseName, strings.Join(errs, "; ")))
+	}
+	releaseNamespace := source.ReleaseNamespace
+	switch {
+	case placementNamespace == "" && releaseNamespace == "":
+		allErr = append(allErr, fmt.Errorf("resource source %d must specify the Helm release namespace in a clusterResourcePlacement", idx))
+	case placementNamespace != "" && releaseNamespace != "" && releaseNamespace != placementNamespace:
+		allErr = append(allErr, fmt.Errorf("resource source %d must install the Helm release in the namespace %s of the resourcePlacement, got %s", idx, placementNamespace, releaseNamespace))
+	}
+	chart := source.Chart
+	repoURL, err := url.Parse(chart.Repository)
+	switch {
+	case err != nil:
+		allErr = append(allErr, fmt.Errorf("resource source %d has an invalid chart repository %q: %w", idx, chart.Repository, err))
+	case repoURL.Scheme != "oci" && repoURL.Scheme != "https" && repoURL.Scheme != "http" && repoURL.Scheme != "file":
+		allErr = append(allErr, fmt.Errorf("resource source %d has chart repository %q, which is not an OCI, HTTP, HTTPS, or file URL", idx, chart.Repository))
+	case chart.PlainHTTP && repoURL.Scheme != "oci":
+		allErr = append(allErr, fmt.Errorf("resource source %d can only use plain HTTP for an OCI chart repository, got %q", idx, chart.Repository))
+	}
+	if chart.Name == "" || chart.Version == "" {
+		allErr = append(allErr, fmt.Errorf("resource source %d must specify the name and the version of the chart", idx))
+	}
+	if source.Values != nil && len(source.Values.Raw) != 0 {
+		var values map[string]interface{}
+		if err := json.Unmarshal(source.Values.Raw, &values); err != nil {
+			allErr = append(allErr, fmt.Errorf("resource source %d has invalid Helm values, which must be an object: %w", idx, err))
+		}
+	}
+	return allErr
+}
+
 // ValidateClusterResourcePlacement validates a ClusterResourcePlacement object.
 func ValidateClusterResourcePlacement(clusterResourcePlacement *placementv1beta1.ClusterResourcePlacement) error {
 	return validatePlacement(
 		clusterResourcePlacement.Name,
+		"",
 		clusterResourcePlacement.Spec.ResourceSelectors,
+		clusterResourcePlacement.Spec.ResourceSources,
 		clusterResourcePlacement.Spec.Policy,
 		clusterResourcePlacement.Spec.Strategy,
 		true, // isClusterScoped
@@ -140,7 +219,9 @@ func ValidateClusterResourcePlacement(clusterResourcePlacement *placementv1beta1
 func ValidateResourcePlacement(resourcePlacement *placementv1beta1.ResourcePlacement) error {
 	return validatePlacement(
 		resourcePlacement.Name,
+		resourcePlacement.Namespace,
 		resourcePlacement.Spec.ResourceSelectors,
+		resourcePlacement.Spec.ResourceSources,
 		resourcePlacement.Spec.Policy,
 		resourcePlacement.Spec.Strategy,
 		false, // isClusterScoped
@@ -231,6 +312,9 @@ func validatePolicyForPickAllPlacementType(policy *placementv1beta1.PlacementPol
 	if policy.Affinity != nil && policy.Affinity.ClusterAffinity != nil {
 		allErr = append(allErr, validateClusterAffinity(policy.Affinity.ClusterAffinity, policy.PlacementType))
 	}
+	if policy.Affinity != nil {
+		allErr = append(allErr, validatePlacementAffinity(policy.Affinity.PlacementAffinity, policy.Affinity.PlacementAntiAffinity, policy.PlacementType))
+	}
 	if len(policy.TopologySpreadConstraints) > 0 {
 		allErr = append(allErr, fmt.Errorf("topology spread constraints needs to be empty for policy type %s, only valid for PickN policy type", placementv1beta1.PickAllPlacementType))
 	}
@@ -255,6 +339,9 @@ func validatePolicyForPickNPolicyType(policy *placementv1beta1.PlacementPolicy)
 	if policy.Affinity != nil && policy.Affinity.ClusterAffinity != nil {
 		allErr = append(allErr, validateClusterAffinity(policy.Affinity.ClusterAffinity, policy.PlacementType))
 	}
+	if policy.Affinity != nil {
+		allErr = append(allErr, validatePlacementAffinity(policy.Affinity.PlacementAffinity, policy.Affinity.PlacementAntiAffinity, policy.PlacementType))
+	}
 	if len(policy.TopologySpreadConstraints) > 0 {
 		allErr = append(allErr, validateTopologySpreadConstraints(policy.TopologySpreadConstraints))
 	}
@@ -285,9 +372,53 @@ func validateClusterAffinity(clusterAffinity *placementv1beta1.ClusterAffinity,
 	return apiErrors.NewAggregate(allErr)
 }
 
+func validatePlacementAffinity(affinity *placementv1beta1.PlacementAffinity, antiAffinity *placementv1beta1.PlacementAntiAffinity, placementType placementv1beta1.PlacementType) error {
+	allErr := make([]error, 0)
+	var required, preferred []placementv1beta1.PlacementAffinityTerm
+	if affinity != nil {
+		required = append(required, affinity.RequiredDuringSchedulingIgnoredDuringExecution...)
+		for _, term := range affinity.PreferredDuringSchedulingIgnoredDuringExecution {
+			preferred = append(preferred, term.PlacementAffinityTerm)
+		}
+	}
+	if antiAffinity != nil {
+		required = append(required, antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution...)
+		for _, term := range antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+			preferred = append(preferred, term.PlacementAffinityTerm)
+		}
+	}
+	for i := range required {
+		allErr = append(allErr, validateLabelSelector(&required[i].PlacementSelector, "required placement affinity term"))
+	}
+	// API server validation on object occurs before webhook is triggered hence not validating weight.
+	if placementType == placementv1beta1.PickAllPlacementType && len(preferred) > 0 {
+		allErr = append(allErr, fmt.Errorf("PreferredDuringSchedulingIgnoredDuringExecution placement affinity terms will be ignored for placement policy type %s", placementType))
+	}
+	for i := range preferred {
+		allErr = append(allErr, validateLabelSelector(&preferred[i].PlacementSelector, "preferred placement affinity term"))
+	}
+	return apiErrors.NewAggregate(allErr)
+}
+
+// tolerationKey is the comparable form of a toleration, as TolerationSeconds is a pointer.
+type tolerationKey struct {
+	toleration        placementv1beta1.Toleration
+	tolerationSeconds int64
+}
+
+func newTolerationKey(toleration placementv1beta1.Toleration) tolerationKey {
+	key := tolerationKey{toleration: toleration, tolerationSeconds: -1}
+	key.toleration.TolerationSeconds = nil
+	if toleration.TolerationSeconds != nil {
+		// Zero and negative values are treated as the same.
+		key.tolerationSeconds = max(*toleration.TolerationSeconds, 0)
+	}
+	return key
+}
+
 func validateTolerations(tolerations []placementv1beta1.Toleration) error {
 	allErr := make([]error, 0)
-	tolerationMap := make(map[placementv1beta1.Toleration]bool)
+	tolerationMap := make(map[tolerationKey]bool)
 	for _, toleration := range tolerations {
 		if toleration.Key != "" {
 			for _, msg := range validation.IsQualifiedName(toleration.Key) {
@@ -307,21 +438,25 @@ func validateTolerations(tolerations []placementv1beta1.Toleration) error {
 				allErr = append(allErr, fmt.Errorf(invalidTolerationValueErrFmt, toleration, msg))
 			}
 		}
-		if tolerationMap[toleration] {
+		if toleration.TolerationSeconds != nil && toleration.Effect != corev1.TaintEffectNoExecute {
+			allErr = append(allErr, fmt.Errorf(invalidTolerationErrFmt, toleration, "toleration effect must be NoExecute, when toleration seconds is set"))
+		}
+		key := newTolerationKey(toleration)
+		if tolerationMap[key] {
 			allErr = append(allErr, fmt.Errorf(uniqueTolerationErrFmt, toleration))
 		}
-		tolerationMap[toleration] = true
+		tolerationMap[key] = true
 	}
 	return apiErrors.NewAggregate(allErr)
 }
 
 func IsTolerationsUpdatedOrDeleted(oldTolerations []placementv1beta1.Toleration, newTolerations []placementv1beta1.Toleration) bool {
-	newTolerationsMap := make(map[placementv1beta1.Toleration]bool)
+	newTolerationsMap := make(map[tolerationKey]bool)
 	for _, newToleration := range newTolerations {
-		newTolerationsMap[newToleration] = true
+		newTolerationsMap[newTolerationKey(newToleration)] = true
 	}
 	for _, oldToleration := range oldTolerations {
-		if !newTolerationsMap[oldToleration] {
+		if !newTolerationsMap[newTolerationKey(oldToleration)] {
 			return true
 		}
 	}
@@ -415,6 +550,9 @@ func validateRolloutStrategy(rolloutStrategy placementv1beta1.RolloutStrategy) e
 				allErr = append(allErr, fmt.Errorf("maxSurge must be greater than or equal to 0, got `%+v`", rolloutStrategy.RollingUpdate.MaxSurge))
 			}
 		}
+		if err := validateMaintenanceWindows(maintenancewindow.FromPlacementWindows(rolloutStrategy.RollingUpdate.MaintenanceWindows)); err != nil {
+			allErr = append(allErr, err)
+		}
 	}
 
 	// server-side apply strategy type is only valid for server-side apply strategy type
@@ -422,11 +560,38 @@ func validateRolloutStrategy(rolloutStrategy placementv1beta1.RolloutStrategy) e
 		if rolloutStrategy.ApplyStrategy.Type != placementv1beta1.ApplyStrategyTypeServerSideApply && rolloutStrategy.ApplyStrategy.ServerSideApplyConfig != nil {
 			allErr = append(allErr, errors.New("serverSideApplyConfig is only valid for ServerSideApply strategy type"))
 		}
+		if rolloutStrategy.ApplyStrategy.DriftRemediation != nil && rolloutStrategy.ApplyStrategy.WhenToApply != placementv1beta1.WhenToApplyTypeIfNotDrifted {
+			allErr = append(allErr, errors.New("driftRemediation is only valid when whenToApply is IfNotDrifted"))
+		}
+		for i := range rolloutStrategy.ApplyStrategy.IgnoreDifferences {
+			if err := validateIgnoreDifferenceRule(&rolloutStrategy.ApplyStrategy.IgnoreDifferences[i]); err != nil {
+				allErr = append(allErr, fmt.Errorf("invalid ignoreDifferences rule %d: %w", i, err))
+			}
+		}
 	}
 
 	return apiErrors.NewAggregate(allErr)
 }
 
+// validateIgnoreDifferenceRule validates an ignore difference rule in the apply strategy.
+func validateIgnoreDifferenceRule(rule *placementv1beta1.IgnoreDifferenceRule) error {
+	var allErr []error
+	if len(rule.JSONPointers) == 0 && len(rule.ManagedFieldsManagers) == 0 {
+		allErr = append(allErr, errors.New("at least one of jsonPointers and managedFieldsManagers must be specified"))
+	}
+	for _, pointer := range rule.JSONPointers {
+		if !strings.HasPrefix(pointer, "/") || len(pointer) == 1 {
+			allErr = append(allErr, fmt.Errorf("JSON pointer %q must start with '/' and must not refer to the whole object", pointer))
+		}
+	}
+	for _, manager := range rule.ManagedFieldsManagers {
+		if len(manager) == 0 {
+			allErr = append(allErr, errors.New("managed fields manager must not be empty"))
+		}
+	}
+	return apiErrors.NewAggregate(allErr)
+}
+
 // validatePropertySelector validates the property selector
 func validatePropertySelector(propertySelector *placementv1beta1.PropertySelector) error {
 	return validatePropertySelectorRequirements(propertySelector.MatchExpressions)
@@ -435,7 +600,7 @@ func validatePropertySelector(propertySelector *placementv1beta1.PropertySelecto
 func validatePropertySelectorRequirements(propertySelectorRequirements []placementv1beta1.PropertySelectorRequirement) error {
 	var allErr []error
 	for _, req := range propertySelectorRequirements {
-		if err := validateName(req.Name); err != nil {
+		if err := ValidatePropertyName(req.Name); err != nil {
 			allErr = append(allErr, fmt.Errorf("invalid property name %s: %w", req.Name, err))
 		}
 		if err := validateOperator(req.Operator, req.Values); err != nil {
@@ -451,7 +616,7 @@ func validatePropertySelectorRequirements(propertySelectorRequirements []placeme
 
 func validatePropertySorter(propertySorter *placementv1beta1.PropertySorter) error {
 	var allErr []error
-	if err := validateName(propertySorter.Name); err != nil {
+	if err := ValidatePropertyName(propertySorter.Name); err != nil {
 		allErr = append(allErr, err)
 	}
 	if propertySorter.SortOrder != placementv1beta1.Descending && propertySorter.SortOrder != placementv1beta1.Ascending {
@@ -460,7 +625,9 @@ func validatePropertySorter(propertySorter *placementv1beta1.PropertySorter) err
 	return apiErrors.NewAggregate(allErr)
 }
 
-func validateName(name string) error {
+// ValidatePropertyName validates the name of a cluster property, which can be either a resource
+// property or a non-resource one.
+func ValidatePropertyName(name string) error {
 	// we expect the resource property names to be in this format `[PREFIX]/[CAPACITY_TYPE]-[RESOURCE_NAME]`.
 	if strings.HasPrefix(name, propertyprovider.ResourcePropertyNamePrefix) {
 		resourcePropertyName, _ := strings.CutPrefix(name, propertyprovider.ResourcePropertyNamePrefix)
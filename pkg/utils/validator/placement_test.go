@@ -19,11 +19,14 @@ package validator
 import (
 	"strings"
 	"testing"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 
 	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
 	"go.goms.io/fleet/pkg/utils"
@@ -190,6 +193,218 @@ func TestValidateClusterResourcePlacement(t *testing.T) {
 			wantErr:    true,
 			wantErrMsg: "resource is not found in schema (please retry) or it is not a cluster scoped resource",
 		},
+		"CRP with kustomize source without ConfigMap namespace": {
+			crp: &placementv1beta1.ClusterResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-crp",
+				},
+				Spec: placementv1beta1.PlacementSpec{
+					ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{resourceSelector},
+					ResourceSources: []placementv1beta1.ResourceSource{
+						{
+							Kustomize: &placementv1beta1.KustomizeSource{
+								ConfigMapName: "test-kustomization",
+							},
+						},
+					},
+				},
+			},
+			resourceInformer: &testinformer.FakeManager{
+				APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
+				IsClusterScopedResource: true},
+			wantErr:    true,
+			wantErrMsg: "must specify the ConfigMap namespace in a clusterResourcePlacement",
+		},
+		"CRP with kustomize source with invalid ConfigMap name": {
+			crp: &placementv1beta1.ClusterResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-crp",
+				},
+				Spec: placementv1beta1.PlacementSpec{
+					ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{resourceSelector},
+					ResourceSources: []placementv1beta1.ResourceSource{
+						{
+							Kustomize: &placementv1beta1.KustomizeSource{
+								ConfigMapName:      "Invalid_Name",
+								ConfigMapNamespace: "test-namespace",
+							},
+						},
+					},
+				},
+			},
+			resourceInformer: &testinformer.FakeManager{
+				APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
+				IsClusterScopedResource: true},
+			wantErr:    true,
+			wantErrMsg: "has an invalid ConfigMap name",
+		},
+		"CRP with kustomize source should succeed": {
+			crp: &placementv1beta1.ClusterResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-crp",
+				},
+				Spec: placementv1beta1.PlacementSpec{
+					ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{resourceSelector},
+					ResourceSources: []placementv1beta1.ResourceSource{
+						{
+							Kustomize: &placementv1beta1.KustomizeSource{
+								ConfigMapName:      "test-kustomization",
+								ConfigMapNamespace: "test-namespace",
+							},
+						},
+					},
+				},
+			},
+			resourceInformer: &testinformer.FakeManager{
+				APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
+				IsClusterScopedResource: true},
+			wantErr: false,
+		},
+		"CRP with helm source without release namespace": {
+			crp: &placementv1beta1.ClusterResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-crp",
+				},
+				Spec: placementv1beta1.PlacementSpec{
+					ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{resourceSelector},
+					ResourceSources: []placementv1beta1.ResourceSource{
+						{
+							Helm: &placementv1beta1.HelmSource{
+								Chart: placementv1beta1.HelmChart{
+									Repository: "oci://registry.example.com/charts",
+									Name:       "app",
+									Version:    "1.0.0",
+								},
+								ReleaseName: "app",
+							},
+						},
+					},
+				},
+			},
+			resourceInformer: &testinformer.FakeManager{
+				APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
+				IsClusterScopedResource: true},
+			wantErr:    true,
+			wantErrMsg: "must specify the Helm release namespace in a clusterResourcePlacement",
+		},
+		"CRP with helm source with plain HTTP for a chart repository": {
+			crp: &placementv1beta1.ClusterResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-crp",
+				},
+				Spec: placementv1beta1.PlacementSpec{
+					ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{resourceSelector},
+					ResourceSources: []placementv1beta1.ResourceSource{
+						{
+							Helm: &placementv1beta1.HelmSource{
+								Chart: placementv1beta1.HelmChart{
+									Repository: "https://charts.example.com",
+									Name:       "app",
+									Version:    "1.0.0",
+									PlainHTTP:  true,
+								},
+								ReleaseName:      "app",
+								ReleaseNamespace: "test-namespace",
+							},
+						},
+					},
+				},
+			},
+			resourceInformer: &testinformer.FakeManager{
+				APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
+				IsClusterScopedResource: true},
+			wantErr:    true,
+			wantErrMsg: "can only use plain HTTP for an OCI chart repository",
+		},
+		"CRP with helm source with values which are not an object": {
+			crp: &placementv1beta1.ClusterResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-crp",
+				},
+				Spec: placementv1beta1.PlacementSpec{
+					ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{resourceSelector},
+					ResourceSources: []placementv1beta1.ResourceSource{
+						{
+							Helm: &placementv1beta1.HelmSource{
+								Chart: placementv1beta1.HelmChart{
+									Repository: "oci://registry.example.com/charts",
+									Name:       "app",
+									Version:    "1.0.0",
+								},
+								ReleaseName:      "app",
+								ReleaseNamespace: "test-namespace",
+								Values:           &apiextensionsv1.JSON{Raw: []byte(`[1, 2]`)},
+							},
+						},
+					},
+				},
+			},
+			resourceInformer: &testinformer.FakeManager{
+				APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
+				IsClusterScopedResource: true},
+			wantErr:    true,
+			wantErrMsg: "has invalid Helm values, which must be an object",
+		},
+		"CRP with both kustomize and helm sources": {
+			crp: &placementv1beta1.ClusterResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-crp",
+				},
+				Spec: placementv1beta1.PlacementSpec{
+					ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{resourceSelector},
+					ResourceSources: []placementv1beta1.ResourceSource{
+						{
+							Helm: &placementv1beta1.HelmSource{
+								Chart: placementv1beta1.HelmChart{
+									Repository: "oci://registry.example.com/charts",
+									Name:       "app",
+									Version:    "1.0.0",
+								},
+								ReleaseName:      "app",
+								ReleaseNamespace: "test-namespace",
+							},
+							Kustomize: &placementv1beta1.KustomizeSource{
+								ConfigMapName:      "test-kustomization",
+								ConfigMapNamespace: "test-namespace",
+							},
+						},
+					},
+				},
+			},
+			resourceInformer: &testinformer.FakeManager{
+				APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
+				IsClusterScopedResource: true},
+			wantErr:    true,
+			wantErrMsg: "cannot have both a kustomize source and a helm source",
+		},
+		"CRP with helm source should succeed": {
+			crp: &placementv1beta1.ClusterResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-crp",
+				},
+				Spec: placementv1beta1.PlacementSpec{
+					ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{resourceSelector},
+					ResourceSources: []placementv1beta1.ResourceSource{
+						{
+							Helm: &placementv1beta1.HelmSource{
+								Chart: placementv1beta1.HelmChart{
+									Repository: "oci://registry.example.com/charts",
+									Name:       "app",
+									Version:    "1.0.0",
+								},
+								ReleaseName:      "app",
+								ReleaseNamespace: "test-namespace",
+								Values:           &apiextensionsv1.JSON{Raw: []byte(`{"replicaCount": 2}`)},
+							},
+						},
+					},
+				},
+			},
+			resourceInformer: &testinformer.FakeManager{
+				APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
+				IsClusterScopedResource: true},
+			wantErr: false,
+		},
 	}
 	for testName, testCase := range tests {
 		t.Run(testName, func(t *testing.T) {
@@ -327,6 +542,106 @@ func TestValidateClusterResourcePlacement_RolloutStrategy(t *testing.T) {
 			wantErr:    true,
 			wantErrMsg: "serverSideApplyConfig is only valid for ServerSideApply strategy type",
 		},
+		"valid rollout strategy - ignoreDifferences": {
+			strategy: placementv1beta1.RolloutStrategy{
+				Type: placementv1beta1.RollingUpdateRolloutStrategyType,
+				ApplyStrategy: &placementv1beta1.ApplyStrategy{
+					ComparisonOption: placementv1beta1.ComparisonOptionTypeFullComparison,
+					IgnoreDifferences: []placementv1beta1.IgnoreDifferenceRule{
+						{
+							Group:                 "apps",
+							Kind:                  "Deployment",
+							JSONPointers:          []string{"/spec/replicas"},
+							ManagedFieldsManagers: []string{"kube-controller-manager"},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		"invalid rollout strategy - ignoreDifferences without pointers or managers": {
+			strategy: placementv1beta1.RolloutStrategy{
+				Type: placementv1beta1.RollingUpdateRolloutStrategyType,
+				ApplyStrategy: &placementv1beta1.ApplyStrategy{
+					IgnoreDifferences: []placementv1beta1.IgnoreDifferenceRule{
+						{
+							Group: "apps",
+							Kind:  "Deployment",
+						},
+					},
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: "at least one of jsonPointers and managedFieldsManagers must be specified",
+		},
+		"invalid rollout strategy - ignoreDifferences with invalid JSON pointer": {
+			strategy: placementv1beta1.RolloutStrategy{
+				Type: placementv1beta1.RollingUpdateRolloutStrategyType,
+				ApplyStrategy: &placementv1beta1.ApplyStrategy{
+					IgnoreDifferences: []placementv1beta1.IgnoreDifferenceRule{
+						{
+							Kind:         "ConfigMap",
+							JSONPointers: []string{"data"},
+						},
+					},
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: `JSON pointer "data" must start with '/'`,
+		},
+		"valid rollout strategy - driftRemediation": {
+			strategy: placementv1beta1.RolloutStrategy{
+				Type: placementv1beta1.RollingUpdateRolloutStrategyType,
+				ApplyStrategy: &placementv1beta1.ApplyStrategy{
+					WhenToApply: placementv1beta1.WhenToApplyTypeIfNotDrifted,
+					DriftRemediation: &placementv1beta1.DriftRemediationPolicy{
+						GracePeriodSeconds: ptr.To(int32(600)),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		"invalid rollout strategy - driftRemediation with whenToApply Always": {
+			strategy: placementv1beta1.RolloutStrategy{
+				Type: placementv1beta1.RollingUpdateRolloutStrategyType,
+				ApplyStrategy: &placementv1beta1.ApplyStrategy{
+					WhenToApply:      placementv1beta1.WhenToApplyTypeAlways,
+					DriftRemediation: &placementv1beta1.DriftRemediationPolicy{},
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: "driftRemediation is only valid when whenToApply is IfNotDrifted",
+		},
+		"invalid rollout strategy - invalid maintenance window": {
+			strategy: placementv1beta1.RolloutStrategy{
+				Type: placementv1beta1.RollingUpdateRolloutStrategyType,
+				RollingUpdate: &placementv1beta1.RollingUpdateConfig{
+					MaintenanceWindows: []placementv1beta1.MaintenanceWindow{
+						{
+							Schedule: "0 22 * *",
+							Duration: metav1.Duration{Duration: time.Hour},
+						},
+					},
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: "invalid maintenance window 0",
+		},
+		"valid rollout strategy - maintenance window": {
+			strategy: placementv1beta1.RolloutStrategy{
+				Type: placementv1beta1.RollingUpdateRolloutStrategyType,
+				RollingUpdate: &placementv1beta1.RollingUpdateConfig{
+					MaintenanceWindows: []placementv1beta1.MaintenanceWindow{
+						{
+							Schedule: "0 22 * * 1-5",
+							Duration: metav1.Duration{Duration: 2 * time.Hour},
+							TimeZone: ptr.To("Europe/Berlin"),
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
 	}
 
 	for testName, testCase := range tests {
@@ -1265,6 +1580,81 @@ func TestValidateClusterResourcePlacement_PickNPlacementPolicy(t *testing.T) {
 			wantErr:    true,
 			wantErrMsg: "property name segment $ is not valid",
 		},
+		"valid placement policy - PickN with placement affinity and anti-affinity": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:    placementv1beta1.PickNPlacementType,
+				NumberOfClusters: &positiveNumberOfClusters,
+				Affinity: &placementv1beta1.Affinity{
+					PlacementAffinity: &placementv1beta1.PlacementAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: []placementv1beta1.PlacementAffinityTerm{
+							{
+								PlacementSelector: metav1.LabelSelector{
+									MatchLabels: map[string]string{"app": "database"},
+								},
+							},
+						},
+					},
+					PlacementAntiAffinity: &placementv1beta1.PlacementAntiAffinity{
+						PreferredDuringSchedulingIgnoredDuringExecution: []placementv1beta1.WeightedPlacementAffinityTerm{
+							{
+								Weight: 10,
+								PlacementAffinityTerm: placementv1beta1.PlacementAffinityTerm{
+									PlacementSelector: metav1.LabelSelector{
+										MatchLabels: map[string]string{"tier": "control-plane"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		"invalid placement policy - PickN with invalid label selector in placement anti-affinity": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:    placementv1beta1.PickNPlacementType,
+				NumberOfClusters: &positiveNumberOfClusters,
+				Affinity: &placementv1beta1.Affinity{
+					PlacementAntiAffinity: &placementv1beta1.PlacementAntiAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: []placementv1beta1.PlacementAffinityTerm{
+							{
+								PlacementSelector: metav1.LabelSelector{
+									MatchExpressions: []metav1.LabelSelectorRequirement{
+										{
+											Key:      "tier",
+											Operator: metav1.LabelSelectorOpIn,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: "the labelSelector in required placement affinity term",
+		},
+		"invalid placement policy - PickAll with preferred placement affinity": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType: placementv1beta1.PickAllPlacementType,
+				Affinity: &placementv1beta1.Affinity{
+					PlacementAffinity: &placementv1beta1.PlacementAffinity{
+						PreferredDuringSchedulingIgnoredDuringExecution: []placementv1beta1.WeightedPlacementAffinityTerm{
+							{
+								Weight: 10,
+								PlacementAffinityTerm: placementv1beta1.PlacementAffinityTerm{
+									PlacementSelector: metav1.LabelSelector{
+										MatchLabels: map[string]string{"app": "database"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: "PreferredDuringSchedulingIgnoredDuringExecution placement affinity terms will be ignored for placement policy type PickAll",
+		},
 	}
 
 	for testName, testCase := range tests {
@@ -1567,6 +1957,52 @@ func TestValidateTolerations(t *testing.T) {
 			wantErr:    true,
 			wantErrMsg: "tolerations must be unique",
 		},
+		"valid toleration, toleration seconds is set, effect is NoExecute": {
+			tolerations: []placementv1beta1.Toleration{
+				{
+					Key:               "key1",
+					Operator:          corev1.TolerationOpExists,
+					Effect:            corev1.TaintEffectNoExecute,
+					TolerationSeconds: ptr.To(int64(300)),
+				},
+				{
+					Key:      "key1",
+					Operator: corev1.TolerationOpExists,
+					Effect:   corev1.TaintEffectNoExecute,
+				},
+			},
+			wantErr: false,
+		},
+		"invalid toleration, toleration seconds is set, effect is NoSchedule": {
+			tolerations: []placementv1beta1.Toleration{
+				{
+					Key:               "key1",
+					Operator:          corev1.TolerationOpExists,
+					Effect:            corev1.TaintEffectNoSchedule,
+					TolerationSeconds: ptr.To(int64(300)),
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: "toleration effect must be NoExecute, when toleration seconds is set",
+		},
+		"invalid toleration, non-unique toleration with toleration seconds": {
+			tolerations: []placementv1beta1.Toleration{
+				{
+					Key:               "key1",
+					Operator:          corev1.TolerationOpExists,
+					Effect:            corev1.TaintEffectNoExecute,
+					TolerationSeconds: ptr.To(int64(300)),
+				},
+				{
+					Key:               "key1",
+					Operator:          corev1.TolerationOpExists,
+					Effect:            corev1.TaintEffectNoExecute,
+					TolerationSeconds: ptr.To(int64(300)),
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: "tolerations must be unique",
+		},
 	}
 	for testName, testCase := range tests {
 		t.Run(testName, func(t *testing.T) {
@@ -1733,6 +2169,44 @@ func TestIsTolerationsUpdatedOrDeleted(t *testing.T) {
 			},
 			want: false,
 		},
+		"old tolerations, new tolerations with toleration seconds are same": {
+			oldTolerations: []placementv1beta1.Toleration{
+				{
+					Key:               "key1",
+					Operator:          corev1.TolerationOpExists,
+					Effect:            corev1.TaintEffectNoExecute,
+					TolerationSeconds: ptr.To(int64(300)),
+				},
+			},
+			newTolerations: []placementv1beta1.Toleration{
+				{
+					Key:               "key1",
+					Operator:          corev1.TolerationOpExists,
+					Effect:            corev1.TaintEffectNoExecute,
+					TolerationSeconds: ptr.To(int64(300)),
+				},
+			},
+			want: false,
+		},
+		"toleration seconds was updated in new tolerations": {
+			oldTolerations: []placementv1beta1.Toleration{
+				{
+					Key:               "key1",
+					Operator:          corev1.TolerationOpExists,
+					Effect:            corev1.TaintEffectNoExecute,
+					TolerationSeconds: ptr.To(int64(300)),
+				},
+			},
+			newTolerations: []placementv1beta1.Toleration{
+				{
+					Key:               "key1",
+					Operator:          corev1.TolerationOpExists,
+					Effect:            corev1.TaintEffectNoExecute,
+					TolerationSeconds: ptr.To(int64(600)),
+				},
+			},
+			want: true,
+		},
 	}
 	for testName, testCase := range tests {
 		t.Run(testName, func(t *testing.T) {
@@ -1853,6 +2327,146 @@ func TestValidateResourcePlacement(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		"RP with kustomize source in the same namespace should succeed": {
+			rp: &placementv1beta1.ResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-rp",
+					Namespace: "test-namespace",
+				},
+				Spec: placementv1beta1.PlacementSpec{
+					ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{
+						{
+							Group:   "apps",
+							Version: "v1",
+							Kind:    "Deployment",
+							Name:    "test-deployment",
+						},
+					},
+					ResourceSources: []placementv1beta1.ResourceSource{
+						{
+							Kustomize: &placementv1beta1.KustomizeSource{
+								ConfigMapName: "test-kustomization",
+							},
+						},
+						{
+							Kustomize: &placementv1beta1.KustomizeSource{
+								ConfigMapName:      "test-kustomization-2",
+								ConfigMapNamespace: "test-namespace",
+							},
+						},
+					},
+				},
+			},
+			resourceInformer: &testinformer.FakeManager{
+				APIResources:            map[schema.GroupVersionKind]bool{utils.DeploymentGVK: true},
+				IsClusterScopedResource: false,
+			},
+			wantErr: false,
+		},
+		"RP with kustomize source in another namespace should fail": {
+			rp: &placementv1beta1.ResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-rp",
+					Namespace: "test-namespace",
+				},
+				Spec: placementv1beta1.PlacementSpec{
+					ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{
+						{
+							Group:   "apps",
+							Version: "v1",
+							Kind:    "Deployment",
+							Name:    "test-deployment",
+						},
+					},
+					ResourceSources: []placementv1beta1.ResourceSource{
+						{
+							Kustomize: &placementv1beta1.KustomizeSource{
+								ConfigMapName:      "test-kustomization",
+								ConfigMapNamespace: "other-namespace",
+							},
+						},
+					},
+				},
+			},
+			resourceInformer: &testinformer.FakeManager{
+				APIResources:            map[schema.GroupVersionKind]bool{utils.DeploymentGVK: true},
+				IsClusterScopedResource: false,
+			},
+			wantErr:    true,
+			wantErrMsg: "must refer to a ConfigMap in the namespace test-namespace of the resourcePlacement",
+		},
+		"RP with helm source without release namespace should succeed": {
+			rp: &placementv1beta1.ResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-rp",
+					Namespace: "test-namespace",
+				},
+				Spec: placementv1beta1.PlacementSpec{
+					ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{
+						{
+							Group:   "apps",
+							Version: "v1",
+							Kind:    "Deployment",
+							Name:    "test-deployment",
+						},
+					},
+					ResourceSources: []placementv1beta1.ResourceSource{
+						{
+							Helm: &placementv1beta1.HelmSource{
+								Chart: placementv1beta1.HelmChart{
+									Repository: "oci://registry.example.com/charts",
+									Name:       "app",
+									Version:    "1.0.0",
+								},
+								ReleaseName: "app",
+							},
+						},
+					},
+				},
+			},
+			resourceInformer: &testinformer.FakeManager{
+				APIResources:            map[schema.GroupVersionKind]bool{utils.DeploymentGVK: true},
+				IsClusterScopedResource: false,
+			},
+			wantErr: false,
+		},
+		"RP with helm source in another namespace should fail": {
+			rp: &placementv1beta1.ResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-rp",
+					Namespace: "test-namespace",
+				},
+				Spec: placementv1beta1.PlacementSpec{
+					ResourceSelectors: []placementv1beta1.ResourceSelectorTerm{
+						{
+							Group:   "apps",
+							Version: "v1",
+							Kind:    "Deployment",
+							Name:    "test-deployment",
+						},
+					},
+					ResourceSources: []placementv1beta1.ResourceSource{
+						{
+							Helm: &placementv1beta1.HelmSource{
+								Chart: placementv1beta1.HelmChart{
+									Repository: "oci://registry.example.com/charts",
+									Name:       "app",
+									Version:    "1.0.0",
+								},
+								ReleaseName:      "app",
+								ReleaseNamespace: "other-namespace",
+							},
+						},
+					},
+				},
+			},
+			resourceInformer: &testinformer.FakeManager{
+				APIResources:            map[schema.GroupVersionKind]bool{utils.DeploymentGVK: true},
+				IsClusterScopedResource: false,
+			},
+			wantErr:    true,
+			wantErrMsg: "must install the Helm release in the namespace test-namespace of the resourcePlacement",
+		},
 	}
 
 	for testName, testCase := range tests {
@@ -18,13 +18,16 @@ limitations under the License.
 package validator
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	apierrors "k8s.io/apimachinery/pkg/util/errors"
 
 	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/overrider"
 )
 
 // ValidateResourceOverride validates resource override fields and returns error.
@@ -118,11 +121,33 @@ func validateOverridePolicy(policy *placementv1beta1.OverridePolicy) error {
 			if len(rule.JSONPatchOverrides) != 0 {
 				return errors.New("invalid JSONPatchOverrides: JSONPatchOverrides cannot be set when the override type is Delete")
 			}
+			if rule.MergePatchOverride != nil || len(rule.CELOverrides) != 0 {
+				return errors.New("invalid override rule: MergePatchOverride and CELOverrides cannot be set when the override type is Delete")
+			}
 
 		case placementv1beta1.JSONPatchOverrideType:
+			if rule.MergePatchOverride != nil || len(rule.CELOverrides) != 0 {
+				allErr = append(allErr, errors.New("invalid override rule: MergePatchOverride and CELOverrides cannot be set when the override type is JSONPatch"))
+			}
 			if err := validateJSONPatchOverride(rule.JSONPatchOverrides); err != nil {
 				allErr = append(allErr, err)
 			}
+
+		case placementv1beta1.MergePatchOverrideType:
+			if len(rule.JSONPatchOverrides) != 0 || len(rule.CELOverrides) != 0 {
+				allErr = append(allErr, errors.New("invalid override rule: JSONPatchOverrides and CELOverrides cannot be set when the override type is MergePatch"))
+			}
+			if err := validateMergePatchOverride(rule.MergePatchOverride); err != nil {
+				allErr = append(allErr, err)
+			}
+
+		case placementv1beta1.CELOverrideType:
+			if len(rule.JSONPatchOverrides) != 0 || rule.MergePatchOverride != nil {
+				allErr = append(allErr, errors.New("invalid override rule: JSONPatchOverrides and MergePatchOverride cannot be set when the override type is CEL"))
+			}
+			if err := validateCELOverride(rule.CELOverrides); err != nil {
+				allErr = append(allErr, err)
+			}
 		}
 	}
 	return apierrors.NewAggregate(allErr)
@@ -137,16 +162,104 @@ func validateJSONPatchOverride(jsonPatchOverrides []placementv1beta1.JSONPatchOv
 	allErr := make([]error, 0)
 	for _, patch := range jsonPatchOverrides {
 		if err := validateJSONPatchOverridePath(patch.Path); err != nil {
-			allErr = append(allErr, fmt.Errorf("invalid JSONPatchOverride %s: %w", patch, err))
+			allErr = append(allErr, fmt.Errorf("invalid JSONPatchOverride %s: %w", formatJSONPatchOverride(patch), err))
 		}
 
 		if patch.Operator == placementv1beta1.JSONPatchOverrideOpRemove && len(patch.Value.Raw) != 0 {
-			allErr = append(allErr, fmt.Errorf("invalid JSONPatchOverride %s: remove operation cannot have value", patch))
+			allErr = append(allErr, fmt.Errorf("invalid JSONPatchOverride %s: remove operation cannot have value", formatJSONPatchOverride(patch)))
+		}
+
+		if patch.EnableTemplate {
+			if err := overrider.ValidateJSONPatchOverrideTemplate(patch.Value.Raw); err != nil {
+				allErr = append(allErr, fmt.Errorf("invalid JSONPatchOverride %s: %w", formatJSONPatchOverride(patch), err))
+			}
+		}
+	}
+	return apierrors.NewAggregate(allErr)
+}
+
+func validateMergePatchOverride(mergePatchOverride *placementv1beta1.MergePatchOverride) error {
+	if mergePatchOverride == nil {
+		return errors.New("invalid MergePatchOverride: MergePatchOverride cannot be empty")
+	}
+
+	var patch map[string]interface{}
+	if err := json.Unmarshal(mergePatchOverride.Patch.Raw, &patch); err != nil || patch == nil {
+		return errors.New("invalid MergePatchOverride: patch must be a JSON object")
+	}
+	allErr := make([]error, 0)
+	for field, value := range patch {
+		switch field {
+		case "kind", "apiVersion":
+			allErr = append(allErr, errors.New("invalid MergePatchOverride: cannot override typeMeta fields"))
+		case "status":
+			allErr = append(allErr, errors.New("invalid MergePatchOverride: cannot override status fields"))
+		case "metadata":
+			metadata, ok := value.(map[string]interface{})
+			if !ok {
+				allErr = append(allErr, errors.New("invalid MergePatchOverride: cannot override field metadata"))
+				continue
+			}
+			for metadataField := range metadata {
+				if metadataField != "annotations" && metadataField != "labels" {
+					allErr = append(allErr, errors.New("invalid MergePatchOverride: cannot override metadata fields except annotations and labels"))
+					break
+				}
+			}
+		}
+	}
+
+	if mergePatchOverride.EnableTemplate {
+		if err := overrider.ValidateJSONPatchOverrideTemplate(mergePatchOverride.Patch.Raw); err != nil {
+			allErr = append(allErr, fmt.Errorf("invalid MergePatchOverride: %w", err))
 		}
 	}
 	return apierrors.NewAggregate(allErr)
 }
 
+func validateCELOverride(celOverrides []placementv1beta1.CELOverride) error {
+	if len(celOverrides) == 0 {
+		return errors.New("invalid CELOverrides: CELOverrides cannot be empty")
+	}
+
+	allErr := make([]error, 0)
+	for i := range celOverrides {
+		override := &celOverrides[i]
+		if err := validateJSONPatchOverridePath(override.ListPath); err != nil {
+			allErr = append(allErr, fmt.Errorf("invalid CELOverride %d: invalid list path: %w", i, err))
+		}
+		if len(override.Path) != 0 {
+			if !strings.HasPrefix(override.Path, "/") {
+				allErr = append(allErr, fmt.Errorf("invalid CELOverride %d: path must start with /", i))
+			} else if slices.ContainsFunc(strings.Split(override.Path, "/")[1:], func(part string) bool { return len(strings.TrimSpace(part)) == 0 }) {
+				allErr = append(allErr, fmt.Errorf("invalid CELOverride %d: path cannot contain empty string", i))
+			}
+		}
+
+		hasValue := len(override.Value.Raw) != 0
+		hasValueExpression := len(override.ValueExpression) != 0
+		switch {
+		case override.Operator == placementv1beta1.JSONPatchOverrideOpRemove && (hasValue || hasValueExpression):
+			allErr = append(allErr, fmt.Errorf("invalid CELOverride %d: remove operation cannot have value or valueExpression", i))
+		case hasValue && hasValueExpression:
+			allErr = append(allErr, fmt.Errorf("invalid CELOverride %d: value and valueExpression cannot be set at the same time", i))
+		}
+		if override.Operator == placementv1beta1.JSONPatchOverrideOpAdd && len(override.Path) == 0 {
+			allErr = append(allErr, fmt.Errorf("invalid CELOverride %d: add operation must have a path", i))
+		}
+
+		if err := overrider.ValidateCELOverride(override); err != nil {
+			allErr = append(allErr, fmt.Errorf("invalid CELOverride %d: %w", i, err))
+		}
+	}
+	return apierrors.NewAggregate(allErr)
+}
+
+// formatJSONPatchOverride formats a JSON patch override for error messages.
+func formatJSONPatchOverride(patch placementv1beta1.JSONPatchOverride) string {
+	return fmt.Sprintf("{%s %s %s}", patch.Operator, patch.Path, patch.Value.Raw)
+}
+
 func validateJSONPatchOverridePath(path string) error {
 	if path == "" {
 		return fmt.Errorf("path cannot be empty")
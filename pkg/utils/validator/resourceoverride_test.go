@@ -540,11 +540,11 @@ func TestValidateResourceOverride(t *testing.T) {
 				},
 			},
 			wantErrMsg: apierrors.NewAggregate([]error{fmt.Errorf("invalid JSONPatchOverride %s: cannot override typeMeta fields",
-				placementv1beta1.JSONPatchOverride{Operator: placementv1beta1.JSONPatchOverrideOpRemove, Path: "/apiVersion"}),
+				formatJSONPatchOverride(placementv1beta1.JSONPatchOverride{Operator: placementv1beta1.JSONPatchOverrideOpRemove, Path: "/apiVersion"})),
 				fmt.Errorf("invalid JSONPatchOverride %s: cannot override status fields",
-					placementv1beta1.JSONPatchOverride{Operator: placementv1beta1.JSONPatchOverrideOpReplace, Path: "/status/conditions/0/reason", Value: apiextensionsv1.JSON{Raw: []byte(`"new-reason"`)}}),
+					formatJSONPatchOverride(placementv1beta1.JSONPatchOverride{Operator: placementv1beta1.JSONPatchOverrideOpReplace, Path: "/status/conditions/0/reason", Value: apiextensionsv1.JSON{Raw: []byte(`"new-reason"`)}})),
 				fmt.Errorf("invalid JSONPatchOverride %s: path cannot contain empty string",
-					placementv1beta1.JSONPatchOverride{Operator: placementv1beta1.JSONPatchOverrideOpReplace, Path: "/////kind///", Value: apiextensionsv1.JSON{Raw: []byte(`"value"`)}}),
+					formatJSONPatchOverride(placementv1beta1.JSONPatchOverride{Operator: placementv1beta1.JSONPatchOverrideOpReplace, Path: "/////kind///", Value: apiextensionsv1.JSON{Raw: []byte(`"value"`)}})),
 			}),
 		},
 	}
@@ -843,6 +843,38 @@ func TestValidateJSONPatchOverride(t *testing.T) {
 			},
 			wantErrMsg: errors.New("cannot override status fields"),
 		},
+		"valid json patch override - templated value": {
+			jsonPatchOverrides: []placementv1beta1.JSONPatchOverride{
+				{
+					Operator:       placementv1beta1.JSONPatchOverrideOpReplace,
+					Path:           "/spec/replicas",
+					Value:          apiextensionsv1.JSON{Raw: []byte(`"{{ index .Cluster.Properties \"kubernetes-fleet.io/node-count\" | int | mul 2 }}"`)},
+					EnableTemplate: true,
+				},
+			},
+			wantErrMsg: nil,
+		},
+		"valid json patch override - invalid template without template enabled": {
+			jsonPatchOverrides: []placementv1beta1.JSONPatchOverride{
+				{
+					Operator: placementv1beta1.JSONPatchOverrideOpReplace,
+					Path:     "/metadata/annotations/template",
+					Value:    apiextensionsv1.JSON{Raw: []byte(`"{{ .Cluster.Name"`)},
+				},
+			},
+			wantErrMsg: nil,
+		},
+		"invalid json patch override - unknown template function": {
+			jsonPatchOverrides: []placementv1beta1.JSONPatchOverride{
+				{
+					Operator:       placementv1beta1.JSONPatchOverrideOpAdd,
+					Path:           "/metadata/labels",
+					Value:          apiextensionsv1.JSON{Raw: []byte(`{"region": "{{ .Cluster.Labels.region | capitalize }}"}`)},
+					EnableTemplate: true,
+				},
+			},
+			wantErrMsg: errors.New(`function "capitalize" not defined`),
+		},
 	}
 	for testName, tt := range tests {
 		t.Run(testName, func(t *testing.T) {
@@ -945,3 +977,209 @@ func TestValidateJSONPatchOverridePath(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateMergePatchOverride(t *testing.T) {
+	tests := map[string]struct {
+		mergePatchOverride *placementv1beta1.MergePatchOverride
+		wantErrMsg         error
+	}{
+		"valid merge patch override": {
+			mergePatchOverride: &placementv1beta1.MergePatchOverride{
+				Patch: apiextensionsv1.JSON{Raw: []byte(`{"metadata": {"labels": {"app": "nginx"}}, "spec": {"replicas": 3}}`)},
+			},
+			wantErrMsg: nil,
+		},
+		"valid merge patch override - templated patch": {
+			mergePatchOverride: &placementv1beta1.MergePatchOverride{
+				Type:           placementv1beta1.JSONMergePatchType,
+				Patch:          apiextensionsv1.JSON{Raw: []byte(`{"spec": {"replicas": "{{ index .Cluster.Properties \"kubernetes-fleet.io/node-count\" | int }}"}}`)},
+				EnableTemplate: true,
+			},
+			wantErrMsg: nil,
+		},
+		"invalid merge patch override - nil": {
+			mergePatchOverride: nil,
+			wantErrMsg:         errors.New("MergePatchOverride cannot be empty"),
+		},
+		"invalid merge patch override - not an object": {
+			mergePatchOverride: &placementv1beta1.MergePatchOverride{
+				Patch: apiextensionsv1.JSON{Raw: []byte(`["spec"]`)},
+			},
+			wantErrMsg: errors.New("patch must be a JSON object"),
+		},
+		"invalid merge patch override - kind": {
+			mergePatchOverride: &placementv1beta1.MergePatchOverride{
+				Patch: apiextensionsv1.JSON{Raw: []byte(`{"kind": "ConfigMap"}`)},
+			},
+			wantErrMsg: errors.New("cannot override typeMeta fields"),
+		},
+		"invalid merge patch override - status": {
+			mergePatchOverride: &placementv1beta1.MergePatchOverride{
+				Patch: apiextensionsv1.JSON{Raw: []byte(`{"status": {"phase": "Ready"}}`)},
+			},
+			wantErrMsg: errors.New("cannot override status fields"),
+		},
+		"invalid merge patch override - metadata fields": {
+			mergePatchOverride: &placementv1beta1.MergePatchOverride{
+				Patch: apiextensionsv1.JSON{Raw: []byte(`{"metadata": {"name": "new-name"}}`)},
+			},
+			wantErrMsg: errors.New("cannot override metadata fields except annotations and labels"),
+		},
+		"invalid merge patch override - invalid template": {
+			mergePatchOverride: &placementv1beta1.MergePatchOverride{
+				Patch:          apiextensionsv1.JSON{Raw: []byte(`{"spec": {"replicas": "{{ .Cluster.Name "}}`)},
+				EnableTemplate: true,
+			},
+			wantErrMsg: errors.New("failed to parse template"),
+		},
+	}
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			got := validateMergePatchOverride(tt.mergePatchOverride)
+			if gotErr, wantErr := got != nil, tt.wantErrMsg != nil; gotErr != wantErr {
+				t.Fatalf("validateMergePatchOverride() = %v, want %v", got, tt.wantErrMsg)
+			}
+
+			if got != nil && !strings.Contains(got.Error(), tt.wantErrMsg.Error()) {
+				t.Errorf("validateMergePatchOverride() = %v, want %v", got, tt.wantErrMsg)
+			}
+		})
+	}
+}
+
+func TestValidateCELOverride(t *testing.T) {
+	tests := map[string]struct {
+		celOverrides []placementv1beta1.CELOverride
+		wantErrMsg   error
+	}{
+		"valid CEL override": {
+			celOverrides: []placementv1beta1.CELOverride{
+				{
+					ListPath: "/spec/template/spec/containers",
+					Selector: `item.name == "app"`,
+					Operator: placementv1beta1.JSONPatchOverrideOpReplace,
+					Path:     "/image",
+					Value:    apiextensionsv1.JSON{Raw: []byte(`"nginx:2.0"`)},
+				},
+				{
+					ListPath:        "/spec/template/spec/containers",
+					Selector:        `cluster.labels["env"] == "prod"`,
+					Operator:        placementv1beta1.JSONPatchOverrideOpReplace,
+					Path:            "/image",
+					ValueExpression: `item.image.replace("docker.io", "myregistry.io")`,
+				},
+				{
+					ListPath: "/spec/template/spec/containers",
+					Selector: `item.name == "debugger"`,
+					Operator: placementv1beta1.JSONPatchOverrideOpRemove,
+				},
+			},
+			wantErrMsg: nil,
+		},
+		"invalid CEL override - empty": {
+			celOverrides: []placementv1beta1.CELOverride{},
+			wantErrMsg:   errors.New("CELOverrides cannot be empty"),
+		},
+		"invalid CEL override - invalid list path": {
+			celOverrides: []placementv1beta1.CELOverride{
+				{
+					ListPath: "/status/conditions",
+					Selector: `item.type == "Ready"`,
+					Operator: placementv1beta1.JSONPatchOverrideOpRemove,
+				},
+			},
+			wantErrMsg: errors.New("cannot override status fields"),
+		},
+		"invalid CEL override - invalid path": {
+			celOverrides: []placementv1beta1.CELOverride{
+				{
+					ListPath: "/spec/template/spec/containers",
+					Selector: `item.name == "app"`,
+					Operator: placementv1beta1.JSONPatchOverrideOpReplace,
+					Path:     "image",
+					Value:    apiextensionsv1.JSON{Raw: []byte(`"nginx:2.0"`)},
+				},
+			},
+			wantErrMsg: errors.New("path must start with /"),
+		},
+		"invalid CEL override - remove with value": {
+			celOverrides: []placementv1beta1.CELOverride{
+				{
+					ListPath: "/spec/template/spec/containers",
+					Selector: `item.name == "app"`,
+					Operator: placementv1beta1.JSONPatchOverrideOpRemove,
+					Value:    apiextensionsv1.JSON{Raw: []byte(`"nginx:2.0"`)},
+				},
+			},
+			wantErrMsg: errors.New("remove operation cannot have value or valueExpression"),
+		},
+		"invalid CEL override - both value and value expression": {
+			celOverrides: []placementv1beta1.CELOverride{
+				{
+					ListPath:        "/spec/template/spec/containers",
+					Selector:        `item.name == "app"`,
+					Operator:        placementv1beta1.JSONPatchOverrideOpReplace,
+					Path:            "/image",
+					Value:           apiextensionsv1.JSON{Raw: []byte(`"nginx:2.0"`)},
+					ValueExpression: `"nginx:3.0"`,
+				},
+			},
+			wantErrMsg: errors.New("value and valueExpression cannot be set at the same time"),
+		},
+		"invalid CEL override - add without path": {
+			celOverrides: []placementv1beta1.CELOverride{
+				{
+					ListPath: "/spec/template/spec/containers",
+					Selector: `item.name == "app"`,
+					Operator: placementv1beta1.JSONPatchOverrideOpAdd,
+					Value:    apiextensionsv1.JSON{Raw: []byte(`{"name": "sidecar"}`)},
+				},
+			},
+			wantErrMsg: errors.New("add operation must have a path"),
+		},
+		"invalid CEL override - selector does not compile": {
+			celOverrides: []placementv1beta1.CELOverride{
+				{
+					ListPath: "/spec/template/spec/containers",
+					Selector: `item.name == `,
+					Operator: placementv1beta1.JSONPatchOverrideOpRemove,
+				},
+			},
+			wantErrMsg: errors.New("invalid selector"),
+		},
+		"invalid CEL override - selector is not a boolean": {
+			celOverrides: []placementv1beta1.CELOverride{
+				{
+					ListPath: "/spec/template/spec/containers",
+					Selector: `size(item.name)`,
+					Operator: placementv1beta1.JSONPatchOverrideOpRemove,
+				},
+			},
+			wantErrMsg: errors.New("must evaluate to bool"),
+		},
+		"invalid CEL override - value expression does not compile": {
+			celOverrides: []placementv1beta1.CELOverride{
+				{
+					ListPath:        "/spec/template/spec/containers",
+					Selector:        `item.name == "app"`,
+					Operator:        placementv1beta1.JSONPatchOverrideOpReplace,
+					Path:            "/image",
+					ValueExpression: `unknownVar + "x"`,
+				},
+			},
+			wantErrMsg: errors.New("invalid valueExpression"),
+		},
+	}
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			got := validateCELOverride(tt.celOverrides)
+			if gotErr, wantErr := got != nil, tt.wantErrMsg != nil; gotErr != wantErr {
+				t.Fatalf("validateCELOverride() = %v, want %v", got, tt.wantErrMsg)
+			}
+
+			if got != nil && !strings.Contains(got.Error(), tt.wantErrMsg.Error()) {
+				t.Errorf("validateCELOverride() = %v, want %v", got, tt.wantErrMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package approvalrequest provides a validating webhook for the clusterapprovalrequest and approvalrequest custom resources in the KubeFleet API group.
+package approvalrequest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+	"go.goms.io/fleet/pkg/webhook/validation"
+)
+
+const (
+	healthCheckApprovalDeniedFormat  = "user: %s in groups: %s cannot approve or reject the health check approval request %s, which is graded by the update run controller"
+	healthCheckLabelRemovedFormat    = "user: %s in groups: %s cannot remove the label %s from the health check approval request %s"
+	healthCheckApprovalAllowedFormat = "user: %s in groups: %s is allowed to update the health check approval request %s"
+)
+
+var (
+	// ValidationPath is the webhook service path which admission requests are routed to for validating clusterapprovalrequest and approvalrequest resources.
+	ValidationPath = fmt.Sprintf(utils.ValidationPathFmt, placementv1beta1.GroupVersion.Group, placementv1beta1.GroupVersion.Version, "approvalrequest")
+)
+
+type approvalRequestValidator struct {
+	whiteListedUsers []string
+	decoder          webhook.AdmissionDecoder
+}
+
+// Add registers the webhook for the approval request custom resources.
+func Add(mgr manager.Manager, whiteListedUsers []string) error {
+	hookServer := mgr.GetWebhookServer()
+	hookServer.Register(ValidationPath, &webhook.Admission{Handler: &approvalRequestValidator{
+		whiteListedUsers: whiteListedUsers,
+		decoder:          admission.NewDecoder(mgr.GetScheme()),
+	}})
+	return nil
+}
+
+// Handle approvalRequestValidator denies the updates which approve or reject a health check approval request, or
+// which remove its health check label, unless they are made by the fleet agents or the white listed users.
+func (v *approvalRequestValidator) Handle(_ context.Context, req admission.Request) admission.Response {
+	namespacedName := types.NamespacedName{Name: req.Name, Namespace: req.Namespace}
+	klog.V(2).InfoS("Validating webhook handling approval request", "operation", req.Operation, "subResource", req.SubResource, "approvalRequest", namespacedName)
+	if req.Operation != admissionv1.Update {
+		return admission.Allowed("only updates of approval requests are validated")
+	}
+
+	var currentObj, oldObj placementv1beta1.ApprovalRequestObj
+	if req.Namespace == "" {
+		currentObj, oldObj = &placementv1beta1.ClusterApprovalRequest{}, &placementv1beta1.ClusterApprovalRequest{}
+	} else {
+		currentObj, oldObj = &placementv1beta1.ApprovalRequest{}, &placementv1beta1.ApprovalRequest{}
+	}
+	if err := v.decoder.Decode(req, currentObj); err != nil {
+		klog.ErrorS(err, "Failed to decode approval request object", "userName", req.UserInfo.Username, "groups", req.UserInfo.Groups, "approvalRequest", namespacedName)
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if err := v.decoder.DecodeRaw(req.OldObject, oldObj); err != nil {
+		klog.ErrorS(err, "Failed to decode old approval request object", "userName", req.UserInfo.Username, "groups", req.UserInfo.Groups, "approvalRequest", namespacedName)
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if oldObj.GetLabels()[placementv1beta1.IsHealthCheckApprovalLabel] != "true" {
+		return admission.Allowed("the approval request is not created for a health check task")
+	}
+
+	userInfo := req.UserInfo
+	if validation.IsFleetSystemServiceAccountOrWhiteListedUser(v.whiteListedUsers, userInfo) {
+		klog.V(3).InfoS("Allowed the fleet agents to update the health check approval request", "user", userInfo.Username, "groups", userInfo.Groups, "approvalRequest", namespacedName)
+		return admission.Allowed(fmt.Sprintf(healthCheckApprovalAllowedFormat, userInfo.Username, utils.GenerateGroupString(userInfo.Groups), namespacedName))
+	}
+	if currentObj.GetLabels()[placementv1beta1.IsHealthCheckApprovalLabel] != "true" {
+		klog.V(2).InfoS("Denied the removal of the health check label", "user", userInfo.Username, "groups", userInfo.Groups, "approvalRequest", namespacedName)
+		return admission.Denied(fmt.Sprintf(healthCheckLabelRemovedFormat, userInfo.Username, utils.GenerateGroupString(userInfo.Groups), placementv1beta1.IsHealthCheckApprovalLabel, namespacedName))
+	}
+	approvedType := string(placementv1beta1.ApprovalRequestConditionApproved)
+	if isConditionChanged(meta.FindStatusCondition(currentObj.GetApprovalRequestStatus().Conditions, approvedType), meta.FindStatusCondition(oldObj.GetApprovalRequestStatus().Conditions, approvedType)) {
+		klog.V(2).InfoS("Denied the manual approval of the health check approval request", "user", userInfo.Username, "groups", userInfo.Groups, "approvalRequest", namespacedName)
+		return admission.Denied(fmt.Sprintf(healthCheckApprovalDeniedFormat, userInfo.Username, utils.GenerateGroupString(userInfo.Groups), namespacedName))
+	}
+	return admission.Allowed(fmt.Sprintf(healthCheckApprovalAllowedFormat, userInfo.Username, utils.GenerateGroupString(userInfo.Groups), namespacedName))
+}
+
+// isConditionChanged returns whether a condition is added, removed or changed, ignoring its last transition time.
+func isConditionChanged(current, old *metav1.Condition) bool {
+	if current == nil || old == nil {
+		return current != old
+	}
+	return current.Status != old.Status || current.Reason != old.Reason || current.Message != old.Message ||
+		current.ObservedGeneration != old.ObservedGeneration
+}
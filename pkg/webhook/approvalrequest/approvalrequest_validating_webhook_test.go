@@ -0,0 +1,175 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approvalrequest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+)
+
+func TestHandle(t *testing.T) {
+	healthCheckLabels := map[string]string{placementv1beta1.IsHealthCheckApprovalLabel: "true"}
+	approvedCond := metav1.Condition{
+		Type:   string(placementv1beta1.ApprovalRequestConditionApproved),
+		Status: metav1.ConditionTrue,
+		Reason: "ManuallyApproved",
+	}
+	clusterApprovalRequest := func(labels map[string]string, conds ...metav1.Condition) []byte {
+		obj := &placementv1beta1.ClusterApprovalRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-request", Labels: labels},
+			Status:     placementv1beta1.ApprovalRequestStatus{Conditions: conds},
+		}
+		raw, err := json.Marshal(obj)
+		if err != nil {
+			t.Fatalf("failed to marshal the cluster approval request: %v", err)
+		}
+		return raw
+	}
+	approvalRequest := func(labels map[string]string, conds ...metav1.Condition) []byte {
+		obj := &placementv1beta1.ApprovalRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-request", Namespace: "test-ns", Labels: labels},
+			Status:     placementv1beta1.ApprovalRequestStatus{Conditions: conds},
+		}
+		raw, err := json.Marshal(obj)
+		if err != nil {
+			t.Fatalf("failed to marshal the approval request: %v", err)
+		}
+		return raw
+	}
+	testUser := authenticationv1.UserInfo{Username: "test-user", Groups: []string{"system:masters"}}
+	hubAgent := authenticationv1.UserInfo{Username: "system:serviceaccount:fleet-system:hub-agent-sa", Groups: []string{"system:serviceaccounts"}}
+	clusterRequestName := types.NamespacedName{Name: "test-request"}
+	requestName := types.NamespacedName{Name: "test-request", Namespace: "test-ns"}
+
+	scheme := runtime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the placement scheme: %v", err)
+	}
+	validator := approvalRequestValidator{
+		whiteListedUsers: []string{"white-listed-user"},
+		decoder:          admission.NewDecoder(scheme),
+	}
+
+	testCases := map[string]struct {
+		namespace   string
+		operation   admissionv1.Operation
+		subResource string
+		object      []byte
+		oldObject   []byte
+		userInfo    authenticationv1.UserInfo
+		want        admission.Response
+	}{
+		"allow creating a health check approval request": {
+			operation: admissionv1.Create,
+			object:    clusterApprovalRequest(healthCheckLabels),
+			userInfo:  testUser,
+			want:      admission.Allowed("only updates of approval requests are validated"),
+		},
+		"allow approving an approval request of an approval task": {
+			operation:   admissionv1.Update,
+			subResource: "status",
+			object:      clusterApprovalRequest(nil, approvedCond),
+			oldObject:   clusterApprovalRequest(nil),
+			userInfo:    testUser,
+			want:        admission.Allowed("the approval request is not created for a health check task"),
+		},
+		"deny approving a health check cluster approval request": {
+			operation:   admissionv1.Update,
+			subResource: "status",
+			object:      clusterApprovalRequest(healthCheckLabels, approvedCond),
+			oldObject:   clusterApprovalRequest(healthCheckLabels),
+			userInfo:    testUser,
+			want:        admission.Denied(fmt.Sprintf(healthCheckApprovalDeniedFormat, testUser.Username, utils.GenerateGroupString(testUser.Groups), clusterRequestName)),
+		},
+		"deny rejecting a health check approval request": {
+			namespace:   "test-ns",
+			operation:   admissionv1.Update,
+			subResource: "status",
+			object: approvalRequest(healthCheckLabels, metav1.Condition{
+				Type:   string(placementv1beta1.ApprovalRequestConditionApproved),
+				Status: metav1.ConditionFalse,
+				Reason: "ManuallyRejected",
+			}),
+			oldObject: approvalRequest(healthCheckLabels),
+			userInfo:  testUser,
+			want:      admission.Denied(fmt.Sprintf(healthCheckApprovalDeniedFormat, testUser.Username, utils.GenerateGroupString(testUser.Groups), requestName)),
+		},
+		"deny removing the health check label": {
+			operation: admissionv1.Update,
+			object:    clusterApprovalRequest(map[string]string{"foo": "bar"}),
+			oldObject: clusterApprovalRequest(healthCheckLabels),
+			userInfo:  testUser,
+			want:      admission.Denied(fmt.Sprintf(healthCheckLabelRemovedFormat, testUser.Username, utils.GenerateGroupString(testUser.Groups), placementv1beta1.IsHealthCheckApprovalLabel, clusterRequestName)),
+		},
+		"allow updating other fields of a health check approval request": {
+			operation: admissionv1.Update,
+			object:    clusterApprovalRequest(map[string]string{placementv1beta1.IsHealthCheckApprovalLabel: "true", "foo": "bar"}, approvedCond),
+			oldObject: clusterApprovalRequest(healthCheckLabels, approvedCond),
+			userInfo:  testUser,
+			want:      admission.Allowed(fmt.Sprintf(healthCheckApprovalAllowedFormat, testUser.Username, utils.GenerateGroupString(testUser.Groups), clusterRequestName)),
+		},
+		"allow the hub agent to grade a health check approval request": {
+			operation:   admissionv1.Update,
+			subResource: "status",
+			object:      clusterApprovalRequest(healthCheckLabels, approvedCond),
+			oldObject:   clusterApprovalRequest(healthCheckLabels),
+			userInfo:    hubAgent,
+			want:        admission.Allowed(fmt.Sprintf(healthCheckApprovalAllowedFormat, hubAgent.Username, utils.GenerateGroupString(hubAgent.Groups), clusterRequestName)),
+		},
+		"allow a white listed user to grade a health check approval request": {
+			namespace:   "test-ns",
+			operation:   admissionv1.Update,
+			subResource: "status",
+			object:      approvalRequest(healthCheckLabels, approvedCond),
+			oldObject:   approvalRequest(healthCheckLabels),
+			userInfo:    authenticationv1.UserInfo{Username: "white-listed-user"},
+			want:        admission.Allowed(fmt.Sprintf(healthCheckApprovalAllowedFormat, "white-listed-user", utils.GenerateGroupString(nil), requestName)),
+		},
+	}
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			req := admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Name:        "test-request",
+					Namespace:   testCase.namespace,
+					Operation:   testCase.operation,
+					SubResource: testCase.subResource,
+					Object:      runtime.RawExtension{Raw: testCase.object},
+					OldObject:   runtime.RawExtension{Raw: testCase.oldObject},
+					UserInfo:    testCase.userInfo,
+				},
+			}
+			got := validator.Handle(context.Background(), req)
+			if diff := cmp.Diff(testCase.want, got); diff != "" {
+				t.Errorf("approvalRequestValidator Handle() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
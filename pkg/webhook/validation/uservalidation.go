@@ -132,6 +132,12 @@ func isAdminGroupUserOrWhiteListedUser(whiteListedUsers []string, userInfo authe
 	return slices.Contains(whiteListedUsers, userInfo.Username) || slices.Contains(userInfo.Groups, mastersGroup) || slices.Contains(userInfo.Groups, kubeadmClusterAdminsGroup)
 }
 
+// IsFleetSystemServiceAccountOrWhiteListedUser returns true if user is a service account in the fleet-system namespace,
+// i.e., a fleet agent, or belongs to white listed users.
+func IsFleetSystemServiceAccountOrWhiteListedUser(whiteListedUsers []string, userInfo authenticationv1.UserInfo) bool {
+	return strings.HasPrefix(userInfo.Username, fmt.Sprintf(serviceAccountFmt, "")) || slices.Contains(whiteListedUsers, userInfo.Username)
+}
+
 // isUserAuthenticatedServiceAccount returns true if user is a valid service account.
 func isUserAuthenticatedServiceAccount(userInfo authenticationv1.UserInfo) bool {
 	return slices.Contains(userInfo.Groups, serviceAccountsGroup)
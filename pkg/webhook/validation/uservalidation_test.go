@@ -603,3 +603,34 @@ func TestValidateUserForFleetCRD(t *testing.T) {
 		})
 	}
 }
+
+func TestIsFleetSystemServiceAccountOrWhiteListedUser(t *testing.T) {
+	testCases := map[string]struct {
+		userInfo authenticationv1.UserInfo
+		want     bool
+	}{
+		"fleet-system service account": {
+			userInfo: authenticationv1.UserInfo{Username: "system:serviceaccount:fleet-system:hub-agent-sa", Groups: []string{serviceAccountsGroup}},
+			want:     true,
+		},
+		"white listed user": {
+			userInfo: authenticationv1.UserInfo{Username: "test-user"},
+			want:     true,
+		},
+		"service account in another namespace": {
+			userInfo: authenticationv1.UserInfo{Username: "system:serviceaccount:default:test-sa", Groups: []string{serviceAccountsGroup}},
+			want:     false,
+		},
+		"admin user": {
+			userInfo: authenticationv1.UserInfo{Username: "admin", Groups: []string{mastersGroup}},
+			want:     false,
+		},
+	}
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			if got := IsFleetSystemServiceAccountOrWhiteListedUser([]string{"test-user"}, testCase.userInfo); got != testCase.want {
+				t.Errorf("IsFleetSystemServiceAccountOrWhiteListedUser() = %v, want %v", got, testCase.want)
+			}
+		})
+	}
+}
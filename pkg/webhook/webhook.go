@@ -55,6 +55,7 @@ import (
 	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
 	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
 	"go.goms.io/fleet/cmd/hubagent/options"
+	"go.goms.io/fleet/pkg/webhook/approvalrequest"
 	"go.goms.io/fleet/pkg/webhook/clusterresourceoverride"
 	"go.goms.io/fleet/pkg/webhook/clusterresourceplacement"
 	"go.goms.io/fleet/pkg/webhook/clusterresourceplacementdisruptionbudget"
@@ -123,6 +124,10 @@ const (
 	resourceOverrideName                 = "resourceoverrides"
 	evictionName                         = "clusterresourceplacementevictions"
 	disruptionBudgetName                 = "clusterresourceplacementdisruptionbudgets"
+	clusterApprovalRequestName           = "clusterapprovalrequests"
+	clusterApprovalRequestStatusName     = "clusterapprovalrequests/status"
+	approvalRequestName                  = "approvalrequests"
+	approvalRequestStatusName            = "approvalrequests/status"
 )
 
 var (
@@ -140,6 +145,7 @@ var (
 var AddToManagerFuncs []func(manager.Manager) error
 var AddToManagerFleetResourceValidator func(manager.Manager, []string, bool) error
 var AddToManagerMemberclusterValidator func(manager.Manager, bool)
+var AddToManagerApprovalRequestValidator func(manager.Manager, []string) error
 
 // AddToManager adds all Controllers to the Manager
 func AddToManager(m manager.Manager, config *Config) error {
@@ -149,6 +155,9 @@ func AddToManager(m manager.Manager, config *Config) error {
 		}
 	}
 	AddToManagerMemberclusterValidator(m, config.networkingAgentsEnabled)
+	if err := AddToManagerApprovalRequestValidator(m, config.whiteListedUsers); err != nil {
+		return err
+	}
 	return AddToManagerFleetResourceValidator(m, config.whiteListedUsers, config.denyModifyMemberClusterLabels)
 }
 
@@ -541,6 +550,28 @@ func (w *Config) buildFleetValidatingWebhooks() []admv1.ValidatingWebhook {
 			}},
 			TimeoutSeconds: longWebhookTimeout,
 		},
+		admv1.ValidatingWebhook{
+			Name:                    "fleet.approvalrequest.validating",
+			ClientConfig:            w.createClientConfig(approvalrequest.ValidationPath),
+			FailurePolicy:           &failFailurePolicy,
+			SideEffects:             &sideEffortsNone,
+			AdmissionReviewVersions: admissionReviewVersions,
+			// Only the approval requests of the health check tasks are validated.
+			ObjectSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{placementv1beta1.IsHealthCheckApprovalLabel: "true"},
+			},
+			Rules: []admv1.RuleWithOperations{
+				{
+					Operations: []admv1.OperationType{admv1.Update},
+					Rule:       createRule([]string{placementv1beta1.GroupVersion.Group}, []string{placementv1beta1.GroupVersion.Version}, []string{clusterApprovalRequestName, clusterApprovalRequestStatusName}, &clusterScope),
+				},
+				{
+					Operations: []admv1.OperationType{admv1.Update},
+					Rule:       createRule([]string{placementv1beta1.GroupVersion.Group}, []string{placementv1beta1.GroupVersion.Version}, []string{approvalRequestName, approvalRequestStatusName}, &namespacedScope),
+				},
+			},
+			TimeoutSeconds: longWebhookTimeout,
+		},
 	)
 
 	return webHooks
@@ -60,7 +60,7 @@ func TestBuildFleetValidatingWebhooks(t *testing.T) {
 				serviceURL:           "test-url",
 				clientConnectionType: &url,
 			},
-			wantLength: 8,
+			wantLength: 9,
 		},
 		"enable workload": {
 			config: Config{
@@ -70,7 +70,7 @@ func TestBuildFleetValidatingWebhooks(t *testing.T) {
 				clientConnectionType: &url,
 				enableWorkload:       true,
 			},
-			wantLength: 6,
+			wantLength: 7,
 		},
 	}
 
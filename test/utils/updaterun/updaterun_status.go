@@ -39,6 +39,8 @@ var (
 		cmpopts.SortSlices(lessFuncCondition),
 		utils.IgnoreConditionLTTAndMessageFields,
 		cmpopts.IgnoreFields(placementv1beta1.StageUpdatingStatus{}, "StartTime", "EndTime"),
+		// Whether a cluster is newly scheduled depends on the update runs that the test case has run before.
+		cmpopts.IgnoreFields(placementv1beta1.ClusterUpdatingStatus{}, "NewlyScheduled"),
 		cmpopts.EquateEmpty(),
 	}
 )